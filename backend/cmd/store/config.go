@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	"github.com/Neimess/zorkin-store-project/pkg/args"
+	"gopkg.in/yaml.v3"
+)
+
+// runConfig выполняет store config check и возвращает код выхода.
+// Действующий конфиг (файл + переменные окружения, секреты скрыты) идёт
+// в stdout, ошибки проверки — в stderr, код 1, если они есть.
+func runConfig(a *args.Args) int {
+	if a.Config.Check == nil {
+		fmt.Fprintln(os.Stderr, "usage: store config check")
+		return 2
+	}
+	path := config.Path(a)
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config check: %s: %v\n", path, err)
+		return 1
+	}
+
+	fmt.Printf("# %s\n", path)
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(4)
+	if err := enc.Encode(cfg.Redacted()); err != nil {
+		fmt.Fprintf(os.Stderr, "config check: %v\n", err)
+		return 1
+	}
+	_ = enc.Close()
+
+	err = cfg.Validate()
+	if err == nil {
+		fmt.Fprintln(os.Stderr, "config check: ok")
+		return 0
+	}
+	problems := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		problems = joined.Unwrap()
+	}
+	fmt.Fprintf(os.Stderr, "config check: %d problem(s):\n", len(problems))
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "  - %v\n", p)
+	}
+	return 1
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/Neimess/zorkin-store-project/docs"
 	"github.com/Neimess/zorkin-store-project/internal/app"
@@ -29,13 +28,27 @@ var (
 // @in                         header
 // @name                       Authorization
 // @description                Type **"Bearer <JWT>"** here
+// @securityDefinitions.basic  BasicAuth
 func main() {
-	// 1. аргументы + конфиг
-	cfg := config.MustLoad(args.Parse())
+	// 1. аргументы + конфиг; store migrate, seed, snapshot и config — отдельные команды, сервер не запускается
+	argv := args.Parse()
+	switch {
+	case argv.Migrate != nil:
+		os.Exit(runMigrate(argv))
+	case argv.Seed != nil:
+		os.Exit(runSeed(argv))
+	case argv.Snapshot != nil:
+		os.Exit(runSnapshot(argv))
+	case argv.Config != nil:
+		os.Exit(runConfig(argv))
+	}
+	cfg := config.MustLoad(argv)
 	printVersion()
 	initSwagger(cfg.Swagger)
-	// 2. логгер (корневой + контекст op)
-	root := logger.MustInitLogger(cfg.Env)
+	// 2. логгер (корневой + контекст op); уровень меняется перезагрузкой конфига
+	level := new(slog.LevelVar)
+	level.Set(cfg.LogLevel())
+	root := logger.MustInitLoggerLevel(cfg.Env, os.Stdout, level)
 
 	logMain := root.With(slog.String("component", "cmd"),
 		slog.String("op", op),
@@ -44,12 +57,12 @@ func main() {
 	)
 	slog.SetDefault(root)
 	if cfg.Env == config.EnvLocal || cfg.Env == config.EnvDev {
-		logMain.Debug("cmd.main started", slog.String("version", cfg.Version), slog.String("config", fmt.Sprintf("%+v", cfg)))
+		logMain.Debug("cmd.main started", slog.String("version", cfg.Version), slog.String("config", fmt.Sprintf("%+v", cfg.Redacted())))
 	}
 	logMain.Info("main started")
 
 	// 4. DI, создание приложения
-	application := mustCreateApp(cfg, root, logMain)
+	application := mustCreateApp(&app.Deps{Config: cfg, Logger: root, ConfigPath: config.Path(argv), LogLevel: level}, logMain)
 
 	// 5. graceful-shutdown контекст
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -60,14 +73,12 @@ func main() {
 
 	// 7. ожидание сигнала и финальный shutdown
 	<-ctx.Done()
-	gracefulShutdown(application, root, logMain)
+	gracefulShutdown(application, cfg, root, logMain)
 }
 
 /* -------- helpers -------- */
 
-func mustCreateApp(cfg *config.Config, root *slog.Logger, log *slog.Logger) *app.Application {
-	deps := &app.Deps{Config: cfg, Logger: root}
-
+func mustCreateApp(deps *app.Deps, log *slog.Logger) *app.Application {
 	application, err := app.NewApplication(deps)
 	if err != nil {
 		log.Error("application init failed", slog.Any("error", err))
@@ -90,9 +101,10 @@ func initSwagger(cfg config.SwaggerInfo) {
 	docs.SwaggerInfo.Version = cfg.Version
 }
 
-func gracefulShutdown(a *app.Application, root, log *slog.Logger) {
+func gracefulShutdown(a *app.Application, cfg *config.Config, root, log *slog.Logger) {
 	log.With(slog.String("op", "cmd.main.graceful_shutdown")).Info("starting graceful shutdown")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// на увод трафика с /readyz уходит drain_delay сверх времени на остановку сервера
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout+cfg.Health.DrainDelay)
 	defer cancel()
 
 	if err := a.Shutdown(ctx); err != nil {
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	"github.com/Neimess/zorkin-store-project/pkg/args"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+)
+
+// runMigrate выполняет store migrate ... и возвращает код выхода.
+// create работает только с файлами, остальным командам нужен конфиг с БД.
+func runMigrate(a *args.Args) int {
+	cmd := a.Migrate
+	if cmd.Create != nil {
+		up, down, err := migrator.Create(cmd.Create.Dir, cmd.Create.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate create: %v\n", err)
+			return 1
+		}
+		fmt.Printf("created %s\ncreated %s\n", up, down)
+		return 0
+	}
+
+	dsn := config.MustLoad(a).Storage.DSN()
+	var (
+		opts migrator.Options
+		name string
+	)
+	switch {
+	case cmd.Up != nil:
+		name, opts = "up", migrator.Options{Mode: migrator.Up}
+	case cmd.Down != nil:
+		name, opts = "down", migrator.Options{Mode: migrator.DownOne}
+	case cmd.Goto != nil:
+		name, opts = "goto", migrator.Options{Mode: migrator.Goto, Version: int(cmd.Goto.Version)}
+	case cmd.Force != nil:
+		name, opts = "force", migrator.Options{Mode: migrator.ForceTo, Version: cmd.Force.Version}
+	case cmd.Status != nil:
+		if err := printStatus(dsn); err != nil {
+			fmt.Fprintf(os.Stderr, "migrate status: %v\n", err)
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, "usage: store migrate up|down|status|goto N|force N|create NAME")
+		return 2
+	}
+
+	if err := migrator.Run(dsn, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate %s: %v\n", name, err)
+		return 1
+	}
+	if err := printSchemaVersion(dsn); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate %s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+func printStatus(dsn string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	st, err := migrator.GetStatus(ctx, dsn)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS")
+	for _, m := range st.Migrations {
+		status := "pending"
+		if m.Applied {
+			status = "applied"
+		}
+		if m.Version == st.Current && st.Dirty {
+			status = "dirty"
+		}
+		fmt.Fprintf(tw, "%04d\t%s\t%s\n", m.Version, m.Name, status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\ncurrent: %d, latest: %d, dirty: %t\n", st.Current, st.Latest, st.Dirty)
+	return nil
+}
+
+func printSchemaVersion(dsn string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	st, err := migrator.GetStatus(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version: %d (latest %d)\n", st.Current, st.Latest)
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/internal/app"
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	"github.com/Neimess/zorkin-store-project/internal/seed"
+	"github.com/Neimess/zorkin-store-project/pkg/args"
+	logger "github.com/Neimess/zorkin-store-project/pkg/log"
+)
+
+// runSeed выполняет store seed и возвращает код выхода.
+func runSeed(a *args.Args) int {
+	profile, err := seed.GetProfile(a.Seed.Profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		return 2
+	}
+	cfg := config.MustLoad(a)
+	root := logger.MustInitLogger(cfg.Env)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	sum, err := app.Seed(ctx, &app.Deps{Config: cfg, Logger: root}, profile, a.Seed.Seed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("seeded profile %s (seed %d) in %s: %d categories, %d attributes, %d services, %d products, %d presets, %d coefficients\n",
+		profile.Name, a.Seed.Seed, sum.Took.Round(time.Millisecond),
+		sum.Categories, sum.Attributes, sum.Services, sum.Products, sum.Presets, sum.Coefficients)
+	return 0
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/Neimess/zorkin-store-project/internal/app"
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	"github.com/Neimess/zorkin-store-project/pkg/args"
+	logger "github.com/Neimess/zorkin-store-project/pkg/log"
+)
+
+// runSnapshot выполняет store snapshot export|restore и возвращает код выхода.
+// Журнал пишется в stderr: в stdout может идти сам снимок.
+func runSnapshot(a *args.Args) int {
+	cmd := a.Snapshot
+	if cmd.Export == nil && cmd.Restore == nil {
+		fmt.Fprintln(os.Stderr, "usage: store snapshot export [--out FILE] | restore FILE [--mode append|replace]")
+		return 2
+	}
+	cfg := config.MustLoad(a)
+	dep := &app.Deps{Config: cfg, Logger: logger.MustInitLoggerTo(cfg.Env, os.Stderr)}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if cmd.Export != nil {
+		if err := exportSnapshot(ctx, dep, cmd.Export.Out); err != nil {
+			fmt.Fprintf(os.Stderr, "snapshot export: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	mode, err := domSnapshot.ParseMode(cmd.Restore.Mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot restore: %v\n", err)
+		return 2
+	}
+	res, err := restoreSnapshot(ctx, dep, cmd.Restore.File, mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot restore: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "restored snapshot (%s): created %s", res.Mode, formatCounts(res.Created))
+	if len(res.Matched) > 0 {
+		fmt.Fprintf(os.Stderr, "; matched existing %s", formatCounts(res.Matched))
+	}
+	fmt.Fprintln(os.Stderr)
+	return 0
+}
+
+// exportSnapshot пишет снимок в out; недописанный файл удаляется.
+func exportSnapshot(ctx context.Context, dep *app.Deps, out string) (err error) {
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(out)
+			}
+		}()
+		w = f
+		if strings.HasSuffix(out, ".gz") {
+			zw := gzip.NewWriter(f)
+			defer func() {
+				if cerr := zw.Close(); err == nil {
+					err = cerr
+				}
+			}()
+			w = zw
+		}
+	}
+
+	counts, err := app.ExportSnapshot(ctx, dep, w)
+	if err != nil {
+		return err
+	}
+	if out != "-" {
+		fmt.Fprintf(os.Stderr, "exported %s to %s\n", formatCounts(counts), out)
+	}
+	return nil
+}
+
+func restoreSnapshot(ctx context.Context, dep *app.Deps, file string, mode domSnapshot.Mode) (domSnapshot.Result, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return domSnapshot.Result{}, err
+		}
+		defer f.Close()
+		r = f
+	}
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return domSnapshot.Result{}, err
+		}
+		defer zr.Close()
+		return app.RestoreSnapshot(ctx, dep, zr, mode)
+	}
+	return app.RestoreSnapshot(ctx, dep, br, mode)
+}
+
+// formatCounts печатает количество записей в порядке domSnapshot.Kinds.
+func formatCounts(c domSnapshot.Counts) string {
+	parts := make([]string, 0, len(domSnapshot.Kinds))
+	for _, k := range domSnapshot.Kinds {
+		if c[k] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c[k], k))
+		}
+	}
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
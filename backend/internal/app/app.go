@@ -5,46 +5,94 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/Neimess/zorkin-store-project/internal/config"
 	repository "github.com/Neimess/zorkin-store-project/internal/infrastructure"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/commerceml"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/notifier"
+	snapshotInfra "github.com/Neimess/zorkin-store-project/internal/infrastructure/snapshot"
+	webhookInfra "github.com/Neimess/zorkin-store-project/internal/infrastructure/webhook"
 	"github.com/Neimess/zorkin-store-project/internal/server/rest"
 	"github.com/Neimess/zorkin-store-project/internal/service"
+	exchangeSvc "github.com/Neimess/zorkin-store-project/internal/service/exchange"
+	leadSvc "github.com/Neimess/zorkin-store-project/internal/service/lead"
+	webhookSvc "github.com/Neimess/zorkin-store-project/internal/service/webhook"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP"
 	"github.com/Neimess/zorkin-store-project/pkg/database/psql"
+	"github.com/Neimess/zorkin-store-project/pkg/health"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
 	"github.com/Neimess/zorkin-store-project/pkg/secret/jwt"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
 )
 
 type Application struct {
-	cfg    *config.Config
-	db     *sqlx.DB
-	server *rest.Server
-	logger *slog.Logger
+	cfg        *config.Config
+	db         *sqlx.DB
+	server     *rest.Server
+	logger     *slog.Logger
+	dispatcher *webhookSvc.Dispatcher
+	leads      *leadSvc.Service
+	probe      *health.Probe
+	// redis — клиент хранилища лимитов; nil, если rate_limit.store не redis
+	redis *redis.Client
+	// reloader перечитывает конфиг; nil — Deps.ConfigPath не задан
+	reloader *config.Reloader
+	// stopTracing дописывает span'ы в коллектор; nil — трассировка выключена
+	stopTracing func(context.Context) error
+
+	// фоновые задачи живут до Shutdown и останавливаются до закрытия БД
+	backgroundCtx    context.Context
+	cancelBackground context.CancelFunc
+	background       sync.WaitGroup
 }
 
 func NewApplication(dep *Deps) (*Application, error) {
 	log := dep.Logger.With(slog.String("component", "app"))
 	logNew := log.With(slog.String("op", "app.new"))
-	fmt.Println(dep.Config)
+	var err error
+	telemetryCfg := dep.Config.Telemetry
+	var metrics *telemetry.Metrics
+	if telemetryCfg.MetricsEnabled {
+		metrics = telemetry.NewMetrics()
+	}
+	var stopTracing func(context.Context) error
+	if telemetryCfg.TracingEnabled {
+		stopTracing, err = telemetry.InitTracing(context.Background(), telemetry.TracingOptions{
+			ServiceName:    telemetryCfg.ServiceName,
+			ServiceVersion: dep.Config.Version,
+			Endpoint:       telemetryCfg.OTLPEndpoint,
+			Insecure:       telemetryCfg.OTLPInsecure,
+			SampleRatio:    telemetryCfg.SampleRatio,
+		})
+		if err != nil {
+			log.Error("tracing initialization failed", slog.Any("error", err))
+			return nil, fmt.Errorf("application.tracing: %w", err)
+		}
+		logNew.Info("tracing enabled", slog.String("otlp_endpoint", telemetryCfg.OTLPEndpoint))
+	}
+
 	start := time.Now()
-	db, err := psql.New(
-		context.Background(),
-		psql.WithHost(dep.Config.Storage.Host),
-		psql.WithUser(dep.Config.Storage.User),
-		psql.WithPassword(dep.Config.Storage.Password),
-		psql.WithPort(dep.Config.Storage.Port),
-		psql.WithConnLifetime(dep.Config.Storage.ConnMaxLifetime),
-		psql.WithMaxConns(dep.Config.Storage.MaxOpenConns),
-		psql.WithSSL(dep.Config.Storage.SSLMode),
-		psql.WithDB(dep.Config.Storage.DBName),
-	)
+	dbOpts := dbOptions(dep.Config.Storage)
+	if metrics != nil || stopTracing != nil {
+		// операция запроса — метод репозитория из internal/infrastructure/...
+		repoPackages := reflect.TypeOf(repository.Repositories{}).PkgPath() + "/"
+		dbOpts = append(dbOpts, psql.WithTracer(telemetry.NewQueryTracer(metrics, repoPackages)))
+	}
+	db, err := psql.New(context.Background(), dbOpts...)
 	if err != nil {
 		log.Error("db connect failed", slog.Any("error", err))
 		return nil, fmt.Errorf("application.dbconnect: %w", err)
 	}
+	if err := metrics.RegisterDB(db.DB, dep.Config.Storage.DBName); err != nil {
+		log.Error("db metrics registration failed", slog.Any("error", err))
+		return nil, fmt.Errorf("application.dbmetrics: %w", err)
+	}
 
 	logNew.Info("db connected",
 		slog.String("host", dep.Config.Storage.Host),
@@ -52,10 +100,42 @@ func NewApplication(dep *Deps) (*Application, error) {
 		slog.Duration("took", time.Since(start)),
 	)
 
+	if err := ensureSchema(dep.Config, db, logNew); err != nil {
+		log.Error("schema check failed", slog.Any("error", err))
+		return nil, fmt.Errorf("application.migrations: %w", err)
+	}
+
+	probe, err := newProbe(dep.Config, db)
+	if err != nil {
+		log.Error("health probe initialization failed", slog.Any("error", err))
+		return nil, fmt.Errorf("application.health: %w", err)
+	}
+
+	rateStore, redisClient, err := newRateStore(dep.Config)
+	if err != nil {
+		log.Error("rate limit store initialization failed", slog.Any("error", err))
+		return nil, fmt.Errorf("application.ratelimit: %w", err)
+	}
+
+	var reloader *config.Reloader
+	if dep.ConfigPath != "" {
+		reloader = config.NewReloader(dep.ConfigPath, dep.Config, dep.Logger)
+		if dep.LogLevel != nil {
+			reloader.OnReload(func(c *config.Config) { dep.LogLevel.Set(c.LogLevel()) })
+		}
+	}
+
+	rounding, err := dep.Config.Currency.RoundingRules()
+	if err != nil {
+		log.Error("invalid currency config", slog.Any("error", err))
+		return nil, fmt.Errorf("application.currency: %w", err)
+	}
+
 	// 2. Репозитории — отвечают за доступ к данным
 	repos, err := repository.New(repository.Deps{
-		DB:     db,
-		Logger: dep.Logger,
+		DB:       db,
+		Logger:   dep.Logger,
+		Rounding: rounding,
 	})
 	if err != nil {
 		logNew.Error("repositories initialization failed", slog.Any("error", err))
@@ -72,6 +152,18 @@ func NewApplication(dep *Deps) (*Application, error) {
 		Algorithm: dep.Config.JWTConfig.Algorithm,
 	})
 
+	// уведомления менеджеров о заявках
+	leadNotifier, err := notifier.New(dep.Config.Notifier, dep.Logger)
+	if err != nil {
+		log.Error("notifier initialization failed", slog.Any("error", err))
+		return nil, fmt.Errorf("application.notifier: %w", err)
+	}
+
+	schemaVersion, err := migrator.Latest()
+	if err != nil {
+		return nil, fmt.Errorf("application.migrations: %w", err)
+	}
+
 	services, err := service.New(
 		service.NewDeps(
 			jwtGenerator,
@@ -82,6 +174,40 @@ func NewApplication(dep *Deps) (*Application, error) {
 			repos.AttributeRepository,
 			repos.CoefficientRepository,
 			repos.ServiceRepository,
+			repos.ReviewRepository,
+			repos.LeadRepository,
+			leadNotifier,
+			dep.Config.Notifier.Timeout,
+			repos.TranslationRepository,
+			repos.CurrencyRepository,
+			repos.DiscountRepository,
+			repos.WebhookRepository,
+			webhookInfra.NewHTTPSender(nil),
+			webhookSvc.DispatcherOptions{
+				Interval:    dep.Config.Webhooks.Interval,
+				BatchSize:   dep.Config.Webhooks.BatchSize,
+				MaxAttempts: dep.Config.Webhooks.MaxAttempts,
+				BackoffBase: dep.Config.Webhooks.BackoffBase,
+				BackoffMax:  dep.Config.Webhooks.BackoffMax,
+				Timeout:     dep.Config.Webhooks.Timeout,
+			},
+			repos.ExchangeRepository,
+			commerceml.New(dep.Config.Exchange1C.PriceType),
+			exchangeSvc.Options{
+				Login:       dep.Config.Exchange1C.Login,
+				Password:    dep.Config.Exchange1C.Password,
+				Dir:         dep.Config.Exchange1C.Dir,
+				FileLimit:   dep.Config.Exchange1C.FileLimit,
+				SessionTTL:  dep.Config.Exchange1C.SessionTTL,
+				OrdersLimit: dep.Config.Exchange1C.OrdersLimit,
+			},
+			repos.ExternalRepository,
+			repos.IdempotencyRepository,
+			dep.Config.Idempotency.TTL,
+			repos.SnapshotRepository,
+			snapshotInfra.NewCodec(),
+			schemaVersion,
+			repos.Transactor,
 		),
 	)
 	if err == nil {
@@ -100,6 +226,16 @@ func NewApplication(dep *Deps) (*Application, error) {
 		services.AttributeService,
 		services.CoefficientService,
 		services.ServiceService,
+		services.ReviewService,
+		services.LeadService,
+		services.TranslationService,
+		services.CurrencyService,
+		services.DiscountService,
+		services.WebhookService,
+		services.ExchangeService,
+		services.ExternalService,
+		services.IdempotencyService,
+		services.SnapshotService,
 	)
 	if err != nil {
 		logNew.Error("handlers dependencies initialization failed", slog.Any("error", err))
@@ -118,6 +254,10 @@ func NewApplication(dep *Deps) (*Application, error) {
 		dep.Config,
 		restHandlers,
 		dep.Logger,
+		metrics,
+		probe,
+		rateStore,
+		reloader,
 	)
 	if err != nil {
 		logNew.Error("server dependencies initialization failed", slog.Any("error", err))
@@ -132,11 +272,20 @@ func NewApplication(dep *Deps) (*Application, error) {
 	logNew.Info("http server constructed",
 		slog.String("addr", dep.Config.HTTPServer.Address),
 	)
+	backgroundCtx, cancelBackground := context.WithCancel(context.Background())
 	return &Application{
-		cfg:    dep.Config,
-		db:     db,
-		server: srv,
-		logger: log,
+		cfg:              dep.Config,
+		db:               db,
+		server:           srv,
+		logger:           log,
+		dispatcher:       services.WebhookDispatcher,
+		leads:            services.LeadService,
+		probe:            probe,
+		redis:            redisClient,
+		reloader:         reloader,
+		stopTracing:      stopTracing,
+		backgroundCtx:    backgroundCtx,
+		cancelBackground: cancelBackground,
 	}, nil
 }
 
@@ -144,6 +293,8 @@ func (a *Application) Run(ctx context.Context) error {
 	const op = "app.app.run"
 	log := a.logger.With("op", op)
 
+	a.startBackground()
+
 	log.Info("server started", slog.String("address", a.cfg.HTTPServer.Address))
 
 	if err := a.server.Run(); err != nil && err != http.ErrServerClosed {
@@ -159,12 +310,38 @@ func (a *Application) Shutdown(ctx context.Context) error {
 
 	log.Info("attempting graceful shutdown")
 
+	// сначала /readyz отвечает 503, и только потом сервер перестаёт принимать соединения
+	a.probe.Drain()
+	if delay := a.cfg.Health.DrainDelay; delay > 0 {
+		log.Info("draining traffic", slog.Duration("delay", delay))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
 	if err := a.server.Shutdown(ctx); err != nil {
 		log.Error("HTTP server shutdown failed", slog.Any("error", err))
 	} else {
 		log.Info("HTTP server shutdown completed")
 	}
 
+	// заявки уже не принимаются — дослать уведомления о последних
+	a.leads.Wait()
+	a.stopBackground()
+
+	if a.stopTracing != nil {
+		if err := a.stopTracing(ctx); err != nil {
+			log.Warn("tracing shutdown failed", slog.Any("error", err))
+		}
+	}
+
+	if a.redis != nil {
+		if err := a.redis.Close(); err != nil {
+			log.Warn("redis close failed", slog.Any("error", err))
+		}
+	}
+
 	if err := a.db.Close(); err != nil {
 		log.Error("DB close failed", slog.Any("error", err))
 		return err
@@ -174,6 +351,92 @@ func (a *Application) Shutdown(ctx context.Context) error {
 
 }
 
+// dbOptions — параметры подключения к БД из конфига.
+func dbOptions(cfg config.Storage) []psql.Option {
+	return []psql.Option{
+		psql.WithHost(cfg.Host),
+		psql.WithUser(cfg.User),
+		psql.WithPassword(cfg.Password),
+		psql.WithPort(cfg.Port),
+		psql.WithConnLifetime(cfg.ConnMaxLifetime),
+		psql.WithMaxConns(cfg.MaxOpenConns),
+		psql.WithSSL(cfg.SSLMode),
+		psql.WithDB(cfg.DBName),
+	}
+}
+
+// ensureSchema сверяет схему БД со встроенными миграциями по migrations.on_start.
+func ensureSchema(cfg *config.Config, db *sqlx.DB, log *slog.Logger) error {
+	mode := cfg.Migrations.OnStart
+	switch mode {
+	case config.MigrateOnStartOff:
+		return nil
+	case config.MigrateOnStartAuto:
+		if err := migrator.Run(cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}); err != nil {
+			return fmt.Errorf("auto-migrate: %w", err)
+		}
+	case config.MigrateOnStartCheck:
+	default:
+		return fmt.Errorf("unknown migrations.on_start %q", mode)
+	}
+
+	expected, err := migrator.Latest()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := migrator.Check(ctx, db.DB, expected); err != nil {
+		return fmt.Errorf("%w (run `store migrate up` or set migrations.on_start: auto)", err)
+	}
+	log.Info("schema is up to date", slog.Uint64("version", uint64(expected)), slog.String("on_start", mode))
+	return nil
+}
+
+// newProbe собирает проверки готовности: БД, версия схемы и, если обмен с 1С
+// включён, каталог для его файлов.
+func newProbe(cfg *config.Config, db *sqlx.DB) (*health.Probe, error) {
+	expected, err := migrator.Latest()
+	if err != nil {
+		return nil, err
+	}
+	probe := health.New(cfg.Health.Timeout)
+	probe.Add("database", db.PingContext)
+	probe.Add("migrations", func(ctx context.Context) error {
+		return migrator.Check(ctx, db.DB, expected)
+	})
+	if cfg.Exchange1C.Login != "" && cfg.Exchange1C.Dir != "" {
+		probe.Add("exchange_storage", health.Writable(cfg.Exchange1C.Dir))
+	}
+	return probe, nil
+}
+
+// startBackground запускает слежение за конфигом и рассылку вебхуков, если она включена.
+func (a *Application) startBackground() {
+	if a.reloader != nil {
+		a.background.Add(1)
+		go func() {
+			defer a.background.Done()
+			a.reloader.Watch(a.backgroundCtx)
+		}()
+	}
+	if !a.cfg.Webhooks.Enabled || a.dispatcher == nil {
+		a.logger.Info("webhook dispatcher disabled")
+		return
+	}
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		a.dispatcher.Run(a.backgroundCtx)
+	}()
+}
+
+func (a *Application) stopBackground() {
+	a.cancelBackground()
+	a.background.Wait()
+	a.logger.Info("background workers stopped")
+}
+
 func (a *Application) ServerHandler() http.Handler {
 	return a.server.Handler()
 }
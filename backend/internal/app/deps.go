@@ -9,4 +9,10 @@ import (
 type Deps struct {
 	Config *config.Config
 	Logger *slog.Logger
+	// ConfigPath — файл, из которого загружен Config; пусто — конфиг
+	// не перечитывается
+	ConfigPath string
+	// LogLevel — уровень корневого логгера, его меняет перезагрузка
+	// log.level; nil — уровень фиксирован
+	LogLevel *slog.LevelVar
 }
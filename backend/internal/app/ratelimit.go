@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	"github.com/Neimess/zorkin-store-project/pkg/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRateStore создаёт хранилище лимитов по rate_limit.store. Для redis
+// возвращается и клиент, его закрывает Shutdown.
+//
+// Память дешёвая, поэтому memory-хранилище есть и при выключенных лимитах:
+// их можно включить перезагрузкой конфига. Redis без лимитов не нужен —
+// тогда nil, и лимиты включаются только перезапуском.
+//
+// Redis проверяется только при старте: во время работы лимитер при ошибках
+// Redis пропускает запросы, поэтому в /readyz его нет.
+func newRateStore(cfg *config.Config) (ratelimit.Store, *redis.Client, error) {
+	if !cfg.RateLimit.Enabled && cfg.RateLimit.Store != config.RateLimitStoreMemory {
+		return nil, nil, nil
+	}
+	switch cfg.RateLimit.Store {
+	case config.RateLimitStoreMemory:
+		return ratelimit.NewMemoryStore(), nil, nil
+	case config.RateLimitStoreRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Ping(ctx).Err(); err != nil {
+			_ = client.Close()
+			return nil, nil, fmt.Errorf("redis %s: %w", cfg.Redis.Addr, err)
+		}
+		return ratelimit.NewRedisStore(client, cfg.Redis.Prefix+"ratelimit:"), client, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown rate_limit.store %q", cfg.RateLimit.Store)
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	repository "github.com/Neimess/zorkin-store-project/internal/infrastructure"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/outbox"
+	"github.com/Neimess/zorkin-store-project/internal/seed"
+	"github.com/Neimess/zorkin-store-project/pkg/database/psql"
+)
+
+// Seed заливает в пустую базу каталог профиля profile (store seed).
+// Схема приводится к актуальной так же, как при старте сервера. События
+// вебхуков о залитом каталоге в outbox не пишутся.
+func Seed(ctx context.Context, dep *Deps, profile seed.Profile, n int64) (seed.Summary, error) {
+	log := dep.Logger.With(slog.String("component", "app"), slog.String("op", "app.seed"))
+
+	db, err := psql.New(ctx, dbOptions(dep.Config.Storage)...)
+	if err != nil {
+		return seed.Summary{}, fmt.Errorf("db connect: %w", err)
+	}
+	defer db.Close()
+
+	if err := ensureSchema(dep.Config, db, log); err != nil {
+		return seed.Summary{}, fmt.Errorf("migrations: %w", err)
+	}
+	rounding, err := dep.Config.Currency.RoundingRules()
+	if err != nil {
+		return seed.Summary{}, fmt.Errorf("currency: %w", err)
+	}
+	repos, err := repository.New(repository.Deps{DB: db, Logger: dep.Logger, Rounding: rounding})
+	if err != nil {
+		return seed.Summary{}, fmt.Errorf("repositories: %w", err)
+	}
+
+	return seed.New(seed.Deps{
+		Categories:   repos.CategoryRepository,
+		Attributes:   repos.AttributeRepository,
+		Services:     repos.ServiceRepository,
+		Products:     repos.ProductRepository,
+		Presets:      repos.PresetRepository,
+		Coefficients: repos.CoefficientRepository,
+		Tx:           repos.Transactor,
+		Logger:       dep.Logger,
+	}).Run(outbox.Suppress(ctx), profile, n)
+}
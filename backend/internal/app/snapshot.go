@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	snapshotInfra "github.com/Neimess/zorkin-store-project/internal/infrastructure/snapshot"
+	snapshotSvc "github.com/Neimess/zorkin-store-project/internal/service/snapshot"
+	"github.com/Neimess/zorkin-store-project/pkg/database/psql"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+)
+
+// ExportSnapshot пишет снимок каталога в w (store snapshot export).
+func ExportSnapshot(ctx context.Context, dep *Deps, w io.Writer) (domSnapshot.Counts, error) {
+	var counts domSnapshot.Counts
+	err := withSnapshotService(ctx, dep, "app.snapshot_export", func(svc *snapshotSvc.Service) error {
+		var err error
+		counts, err = svc.Export(ctx, w)
+		return err
+	})
+	return counts, err
+}
+
+// RestoreSnapshot загружает снимок из r (store snapshot restore).
+func RestoreSnapshot(ctx context.Context, dep *Deps, r io.Reader, mode domSnapshot.Mode) (domSnapshot.Result, error) {
+	var res domSnapshot.Result
+	err := withSnapshotService(ctx, dep, "app.snapshot_restore", func(svc *snapshotSvc.Service) error {
+		var err error
+		res, err = svc.Restore(ctx, r, mode)
+		return err
+	})
+	return res, err
+}
+
+// withSnapshotService подключается к базе, приводит схему к актуальной так
+// же, как при старте сервера, и передаёт fn сервис снимков.
+func withSnapshotService(ctx context.Context, dep *Deps, op string, fn func(svc *snapshotSvc.Service) error) error {
+	log := dep.Logger.With(slog.String("component", "app"), slog.String("op", op))
+
+	db, err := psql.New(ctx, dbOptions(dep.Config.Storage)...)
+	if err != nil {
+		return fmt.Errorf("db connect: %w", err)
+	}
+	defer db.Close()
+
+	if err := ensureSchema(dep.Config, db, log); err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
+	schemaVersion, err := migrator.Latest()
+	if err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
+	deps, err := snapshotSvc.NewDeps(
+		snapshotInfra.NewPGSnapshotRepository(db, dep.Logger),
+		snapshotInfra.NewCodec(),
+		schemaVersion,
+		dep.Logger,
+	)
+	if err != nil {
+		return err
+	}
+	return fn(snapshotSvc.New(deps))
+}
@@ -1,29 +1,60 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"net/netip"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	"github.com/Neimess/zorkin-store-project/pkg/args"
+	logger "github.com/Neimess/zorkin-store-project/pkg/log"
 	"github.com/ilyakaznacheev/cleanenv"
+	"gopkg.in/yaml.v3"
 )
 
 var (
 	EnvLocal = "local"
 	EnvDev   = "development"
 	EnvProd  = "production"
+	// EnvTest — интеграционные тесты: логгер и сервер они собирают сами
+	EnvTest = "test"
 )
 
 type Config struct {
-	Env        string      `yaml:"env" env:"ENV" end-default:"local"`
-	Version    string      `yaml:"version" env:"VERSION" end-default:"1.0.0"`
-	AdminCode  string      `yaml:"admin_code" env:"ADMIN_CODE" envRequired:"true"`
-	HTTPServer HTTPServer  `yaml:"http_server"`
-	JWTConfig  JWTConfig   `yaml:"jwt_config"`
-	Storage    Storage     `yaml:"storage"`
-	Swagger    SwaggerInfo `yaml:"swagger"`
+	Env         string      `yaml:"env" env:"ENV" env-default:"local"`
+	Version     string      `yaml:"version" env:"VERSION" env-default:"1.0.0"`
+	AdminCode   string      `yaml:"admin_code" env:"ADMIN_CODE" secret:"true"`
+	Log         Log         `yaml:"log"`
+	HTTPServer  HTTPServer  `yaml:"http_server"`
+	JWTConfig   JWTConfig   `yaml:"jwt_config"`
+	Storage     Storage     `yaml:"storage"`
+	Swagger     SwaggerInfo `yaml:"swagger"`
+	RateLimit   RateLimit   `yaml:"rate_limit"`
+	Redis       Redis       `yaml:"redis"`
+	Notifier    Notifier    `yaml:"notifier"`
+	Currency    Currency    `yaml:"currency"`
+	Webhooks    Webhooks    `yaml:"webhooks"`
+	Exchange1C  Exchange1C  `yaml:"exchange_1c"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	Telemetry   Telemetry   `yaml:"telemetry"`
+	Health      Health      `yaml:"health"`
+	Migrations  Migrations  `yaml:"migrations"`
+	// ReloadInterval — как часто проверять, не изменился ли файл конфига;
+	// SIGHUP перечитывает его сразу. Что применяется без перезапуска — см. Reloader.
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"CONFIG_RELOAD_INTERVAL" env-default:"10s"`
+}
+
+// Log — журнал сервиса.
+type Log struct {
+	// Level — debug, info, warn или error; пусто — по окружению
+	// (info в production, иначе debug).
+	Level string `yaml:"level" env:"LOG_LEVEL"`
 }
 
 type HTTPServer struct {
@@ -33,34 +64,111 @@ type HTTPServer struct {
 	WriteTimeout    time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"10s"`
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"5s"`
 	IdleTimeout     time.Duration `yaml:"idle_timeout"  env:"HTTP_IDLE_TIMEOUT"   env-default:"60s"`
-	EnableCORS      bool          `yaml:"enable_cors" env:"HTTP_ENABLE_CORS" env-default:"false"`
 	EnablePProf     bool          `yaml:"enable_pprof" env:"HTTP_ENABLE_PPROF" env-default:"false"`
+	CORS            CORS          `yaml:"cors" env-prefix:"HTTP_CORS_"`
+	Security        Security      `yaml:"security" env-prefix:"HTTP_SECURITY_"`
+	BodyLimit       BodyLimit     `yaml:"body_limit" env-prefix:"HTTP_BODY_LIMIT_"`
+	TLS             TLS           `yaml:"tls" env-prefix:"HTTP_TLS_"`
+	// H2C — HTTP/2 без TLS (prior knowledge), например за прокси, который
+	// ходит к бэкенду по h2c. С TLS HTTP/2 включён всегда.
+	H2C bool `yaml:"h2c" env:"HTTP_H2C" env-default:"false"`
+	// AdminAddress — отдельный адрес для /api/admin, /metrics и pprof: "127.0.0.1:8081"
+	// или "unix:/run/zorkin/admin.sock". Пусто — они на основном адресе.
+	AdminAddress string `yaml:"admin_address" env:"HTTP_ADMIN_ADDRESS"`
+	// TrustedProxies — адреса и подсети прокси (nginx), которым можно верить
+	// в X-Forwarded-For и X-Real-IP. От остальных клиентов заголовки
+	// игнорируются, и клиентом считается адрес соединения.
+	TrustedProxies []string `yaml:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES"`
+}
+
+// TrustedPrefixes разбирает TrustedProxies: подсеть "10.0.0.0/8" или
+// отдельный адрес "127.0.0.1".
+func (h HTTPServer) TrustedPrefixes() ([]netip.Prefix, error) {
+	res := make([]netip.Prefix, 0, len(h.TrustedProxies))
+	for _, s := range h.TrustedProxies {
+		if p, err := netip.ParsePrefix(s); err == nil {
+			res = append(res, p.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is neither an IP address nor a CIDR", s)
+		}
+		res = append(res, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+	return res, nil
+}
+
+// TLS — терминация TLS в самом сервисе, без nginx. Сертификат перечитывается
+// без перезапуска, когда меняются файлы или приходит SIGHUP.
+type TLS struct {
+	Enabled  bool   `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	CertFile string `yaml:"cert_file" env:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"KEY_FILE"`
+	// ReloadInterval — как часто проверять время изменения файлов.
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"RELOAD_INTERVAL" env-default:"30s"`
+}
+
+// CORS — какие сайты могут обращаться к API из браузера. Пустой
+// allowed_origins выключает CORS; "*" нельзя сочетать с allow_credentials.
+type CORS struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"ALLOWED_METHODS" env-default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"ALLOWED_HEADERS" env-default:"Accept,Accept-Language,Authorization,Content-Type,If-Match,Idempotency-Key,X-Request-ID,traceparent,tracestate"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"EXPOSED_HEADERS" env-default:"ETag,Location,Content-Disposition,Content-Language,X-Request-ID,Idempotent-Replayed,RateLimit-Policy,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,Retry-After"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"ALLOW_CREDENTIALS" env-default:"false"`
+	MaxAge           time.Duration `yaml:"max_age" env:"MAX_AGE" env-default:"10m"`
+}
+
+// Security — заголовки безопасности ответов. CSP по умолчанию запрещает
+// API всё, кроме JSON; Swagger UI получает swagger_csp.
+type Security struct {
+	// HSTSMaxAge отдаётся только для HTTPS; 0 — без HSTS.
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age" env:"HSTS_MAX_AGE" env-default:"0s"`
+	HSTSIncludeSubdomains bool          `yaml:"hsts_include_subdomains" env:"HSTS_INCLUDE_SUBDOMAINS" env-default:"false"`
+	ContentSecurityPolicy string        `yaml:"csp" env:"CSP" env-default:"default-src 'none'; frame-ancestors 'none'"`
+	SwaggerCSP            string        `yaml:"swagger_csp" env:"SWAGGER_CSP" env-default:"default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"`
+	ReferrerPolicy        string        `yaml:"referrer_policy" env:"REFERRER_POLICY" env-default:"no-referrer"`
+}
+
+// BodyLimit — максимальный размер тела запроса в байтах; 0 — без ограничения.
+// Обмен с 1С ограничен своим exchange_1c.file_limit.
+type BodyLimit struct {
+	Default int64 `yaml:"default" env:"DEFAULT" env-default:"1048576"` // 1 MB
+	// Forms — публичные формы: заявки и отзывы.
+	Forms int64 `yaml:"forms" env:"FORMS" env-default:"65536"` // 64 KB
+	// Snapshot — восстановление каталога из снимка.
+	Snapshot int64 `yaml:"snapshot" env:"SNAPSHOT" env-default:"268435456"` // 256 MB
 }
 
+// StorageDriverPostgres — единственная поддерживаемая БД; поля SQLite
+// остались от прототипа и не используются.
+const StorageDriverPostgres = "postgres"
+
 type Storage struct {
-	Driver string `yaml:"driver"  env:"STORAGE_DRIVER"  end-default:"sqlite"`
+	Driver string `yaml:"driver"  env:"STORAGE_DRIVER"  env-default:"postgres"`
 	// --- PostgreSQL ---
 	Host     string `yaml:"host"              env:"STORAGE_HOST"`
-	Port     int    `yaml:"port"              env:"STORAGE_PORT" end-default:"5432"`
+	Port     int    `yaml:"port"              env:"STORAGE_PORT" env-default:"5432"`
 	User     string `yaml:"user"              env:"STORAGE_USER"`
-	Password string `yaml:"password"          env:"STORAGE_PASSWORD"`
+	Password string `yaml:"password"          env:"STORAGE_PASSWORD" secret:"true"`
 	DBName   string `yaml:"dbname"            env:"STORAGE_DBNAME"`
-	SSLMode  string `yaml:"sslmode"           env:"STORAGE_SSLMODE" end-default:"disable"`
+	SSLMode  string `yaml:"sslmode"           env:"STORAGE_SSLMODE" env-default:"disable"`
 	// --- SQLite ---
 	Path        string        `yaml:"path"              env:"STORAGE_PATH"  env-default:"./data/app.db"`
 	ForeignKeys bool          `yaml:"foreign_keys"      env:"STORAGE_FK"    env-default:"true"`
 	BusyTimeout time.Duration `yaml:"busy_timeout"      env:"STORAGE_BT"    env-default:"5s"`
 	// --- общие ---
-	MaxOpenConns    int           `yaml:"max_open_conns"    env:"STORAGE_MAX_OPEN" end-default:"10"`
-	MaxIdleConns    int           `yaml:"max_idle_conns"    env:"STORAGE_MAX_IDLE" end-default:"5"`
-	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"STORAGE_LIFETIME" end-default:"30m"`
-	ConnMaxTimeout  time.Duration `yaml:"conn_max_timeout"  env:"STORAGE_TIMEOUT"  end-default:"5s"`
+	MaxOpenConns    int           `yaml:"max_open_conns"    env:"STORAGE_MAX_OPEN" env-default:"10"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"    env:"STORAGE_MAX_IDLE" env-default:"5"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"STORAGE_LIFETIME" env-default:"30m"`
+	ConnMaxTimeout  time.Duration `yaml:"conn_max_timeout"  env:"STORAGE_TIMEOUT"  env-default:"5s"`
 }
 
 type JWTConfig struct {
-	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET" env-required:"true"`
-	Issuer    string `yaml:"issuer" env:"JWT_ISSUER" env-required:"true"`
-	Audience  string `yaml:"audience" env:"JWT_AUDIENCE" env-required:"true"`
+	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	Issuer    string `yaml:"issuer" env:"JWT_ISSUER"`
+	Audience  string `yaml:"audience" env:"JWT_AUDIENCE"`
 	Algorithm string `yaml:"algorithm" env:"JWT_ALGORITHM" env-default:"HS256"`
 }
 
@@ -71,24 +179,230 @@ type SwaggerInfo struct {
 	Version string   `yaml:"version" env:"SWAGGER_VERSION" env-default:"1.0.0"`
 }
 
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// RateLimit — ограничение частоты запросов (token bucket): публичные
+// запросы считаются по IP, админские — по subject токена. Счётчики хранятся
+// в памяти процесса (memory) или в Redis (redis), если экземпляров несколько.
+type RateLimit struct {
+	Enabled bool   `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"true"`
+	Store   string `yaml:"store" env:"RATE_LIMIT_STORE" env-default:"memory"`
+	// Public — все запросы публичного API; Search — дополнительно списки
+	// каталога с сортировкой и фильтрами; Leads — отправка заявок;
+	// Auth — вход в админку по одноразовой ссылке; Admin — админский API.
+	Public RatePolicy `yaml:"public" env-prefix:"RATE_LIMIT_PUBLIC_"`
+	Search RatePolicy `yaml:"search" env-prefix:"RATE_LIMIT_SEARCH_"`
+	Leads  RatePolicy `yaml:"leads" env-prefix:"RATE_LIMIT_LEADS_"`
+	Auth   RatePolicy `yaml:"auth" env-prefix:"RATE_LIMIT_AUTH_"`
+	Admin  RatePolicy `yaml:"admin" env-prefix:"RATE_LIMIT_ADMIN_"`
+}
+
+// RatePolicy — requests запросов за per, подряд не больше burst
+// (0 — равен requests). requests: 0 выключает политику.
+type RatePolicy struct {
+	Requests int           `yaml:"requests" env:"REQUESTS"`
+	Per      time.Duration `yaml:"per" env:"PER"`
+	Burst    int           `yaml:"burst" env:"BURST"`
+}
+
+// defaultRateLimit — политики, если в конфиге их нет; у каждой свои
+// значения, поэтому они заданы здесь, а не в env-default.
+var defaultRateLimit = RateLimit{
+	Public: RatePolicy{Requests: 300, Per: time.Minute, Burst: 60},
+	Search: RatePolicy{Requests: 60, Per: time.Minute, Burst: 20},
+	Leads:  RatePolicy{Requests: 5, Per: 10 * time.Minute, Burst: 5},
+	Auth:   RatePolicy{Requests: 5, Per: 15 * time.Minute, Burst: 5},
+	Admin:  RatePolicy{Requests: 1200, Per: time.Minute, Burst: 200},
+}
+
+// Redis — подключение к Redis (rate_limit.store: redis).
+type Redis struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR" env-default:"localhost:6379"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `yaml:"db" env:"REDIS_DB" env-default:"0"`
+	// Prefix — префикс ключей сервиса в общей базе Redis.
+	Prefix string `yaml:"prefix" env:"REDIS_PREFIX" env-default:"zorkin:"`
+}
+
+// Notifier — каналы уведомлений менеджеров о новых заявках.
+// Выключенные каналы пропускаются; если выключены все, заявки только сохраняются.
+type Notifier struct {
+	Timeout  time.Duration  `yaml:"timeout" env:"NOTIFIER_TIMEOUT" env-default:"10s"`
+	SMTP     SMTPConfig     `yaml:"smtp"`
+	Telegram TelegramConfig `yaml:"telegram"`
+}
+
+type SMTPConfig struct {
+	Enabled  bool     `yaml:"enabled" env:"SMTP_ENABLED" env-default:"false"`
+	Host     string   `yaml:"host" env:"SMTP_HOST"`
+	Port     int      `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string   `yaml:"username" env:"SMTP_USERNAME"`
+	Password string   `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	From     string   `yaml:"from" env:"SMTP_FROM"`
+	To       []string `yaml:"to" env:"SMTP_TO"`
+}
+
+type TelegramConfig struct {
+	Enabled  bool   `yaml:"enabled" env:"TELEGRAM_ENABLED" env-default:"false"`
+	BotToken string `yaml:"bot_token" env:"TELEGRAM_BOT_TOKEN" secret:"true"`
+	ChatID   string `yaml:"chat_id" env:"TELEGRAM_CHAT_ID"`
+	APIURL   string `yaml:"api_url" env:"TELEGRAM_API_URL" env-default:"https://api.telegram.org"`
+}
+
+// Currency — пересчёт цен каталога в другие валюты (?currency=).
+type Currency struct {
+	// Rounding — правила округления по кодам валют, ключ "default" задаёт
+	// правило для остальных. Без настроек цены округляются до сотых.
+	Rounding map[string]RoundingRule `yaml:"rounding"`
+}
+
+// Webhooks — рассылка событий каталога и заявок внешним системам.
+// При enabled=false события копятся в outbox и уйдут после включения.
+type Webhooks struct {
+	Enabled     bool          `yaml:"enabled" env:"WEBHOOKS_ENABLED" env-default:"true"`
+	Interval    time.Duration `yaml:"interval" env:"WEBHOOKS_INTERVAL" env-default:"5s"`
+	BatchSize   int           `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" env-default:"50"`
+	MaxAttempts int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" env-default:"8"`
+	BackoffBase time.Duration `yaml:"backoff_base" env:"WEBHOOKS_BACKOFF_BASE" env-default:"30s"`
+	BackoffMax  time.Duration `yaml:"backoff_max" env:"WEBHOOKS_BACKOFF_MAX" env-default:"6h"`
+	Timeout     time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" env-default:"10s"`
+}
+
+// Exchange1C — обмен каталогом и заказами с 1С (CommerceML) на
+// /api/admin/1c/exchange. Без login обмен выключен.
+type Exchange1C struct {
+	Login       string        `yaml:"login" env:"EXCHANGE_1C_LOGIN"`
+	Password    string        `yaml:"password" env:"EXCHANGE_1C_PASSWORD" secret:"true"`
+	Dir         string        `yaml:"dir" env:"EXCHANGE_1C_DIR"`
+	FileLimit   int64         `yaml:"file_limit" env:"EXCHANGE_1C_FILE_LIMIT" env-default:"52428800"` // 50 MB
+	SessionTTL  time.Duration `yaml:"session_ttl" env:"EXCHANGE_1C_SESSION_TTL" env-default:"1h"`
+	OrdersLimit int           `yaml:"orders_limit" env:"EXCHANGE_1C_ORDERS_LIMIT" env-default:"500"`
+	// PriceType — наименование или Ид типа цены 1С, который идёт в каталог;
+	// пусто — первый тип цены в offers.xml.
+	PriceType string `yaml:"price_type" env:"EXCHANGE_1C_PRICE_TYPE"`
+}
+
+// Idempotency — хранение ответов на админские POST с Idempotency-Key.
+type Idempotency struct {
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+}
+
+// Telemetry — метрики Prometheus и трассировка OpenTelemetry.
+type Telemetry struct {
+	MetricsEnabled bool   `yaml:"metrics_enabled" env:"METRICS_ENABLED" env-default:"true"`
+	MetricsPath    string `yaml:"metrics_path" env:"METRICS_PATH" env-default:"/metrics"`
+	TracingEnabled bool   `yaml:"tracing_enabled" env:"TRACING_ENABLED" env-default:"false"`
+	ServiceName    string `yaml:"service_name" env:"OTEL_SERVICE_NAME" env-default:"zorkin-store"`
+	// OTLPEndpoint — host:port OTLP/HTTP коллектора (Jaeger, OpenTelemetry Collector).
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTLP_ENDPOINT" env-default:"localhost:4318"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"OTLP_INSECURE" env-default:"true"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// Health — пробы /livez и /readyz.
+type Health struct {
+	// Timeout — общий предел проверок одного запроса /readyz.
+	Timeout time.Duration `yaml:"timeout" env:"HEALTH_TIMEOUT" env-default:"2s"`
+	// DrainDelay — сколько /readyz отвечает 503 перед остановкой HTTP-сервера,
+	// чтобы балансировщик успел увести трафик.
+	DrainDelay time.Duration `yaml:"drain_delay" env:"HEALTH_DRAIN_DELAY" env-default:"0s"`
+}
+
+const (
+	MigrateOnStartAuto  = "auto"
+	MigrateOnStartCheck = "check"
+	MigrateOnStartOff   = "off"
+)
+
+// Migrations — что делать при старте, если схема БД не совпадает со
+// встроенными миграциями: auto — применить недостающие, check — не
+// запускаться, off — не проверять.
+type Migrations struct {
+	OnStart string `yaml:"on_start" env:"MIGRATIONS_ON_START" env-default:"check"`
+}
+
+type RoundingRule struct {
+	Mode string `yaml:"mode"` // half_up, half_even, up, down
+	Step string `yaml:"step"` // шаг округления: "0.01", "1", "10"
+}
+
+// RoundingRules переводит настройки округления в доменные правила.
+func (c Currency) RoundingRules() (money.RoundingRules, error) {
+	rules := money.RoundingRules{Default: money.DefaultRounding, ByCurrency: map[money.Currency]money.Rounding{}}
+	for code, rule := range c.Rounding {
+		r, err := money.ParseRounding(rule.Mode, rule.Step)
+		if err != nil {
+			return money.RoundingRules{}, fmt.Errorf("currency.rounding.%s: %w", code, err)
+		}
+		if code == "default" {
+			rules.Default = r
+			continue
+		}
+		cur, err := money.ParseCurrency(code)
+		if err != nil {
+			return money.RoundingRules{}, fmt.Errorf("currency.rounding.%s: %w", code, err)
+		}
+		rules.ByCurrency[cur] = r
+	}
+	return rules, nil
+}
+
 func MustLoad(argument *args.Args) *Config {
-	configPath := fetchConfigPath(argument)
+	configPath := Path(argument)
 	if configPath == "" {
 		log.Fatalf("configuration file path is not set")
 	}
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Fatalf("configuration file does not exist: %v", configPath)
 	}
-	var cfg Config
-	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+	cfg, err := Load(configPath)
+	if err != nil {
 		log.Fatalf("failed to read configuration file: %v", err)
 	}
-	return &cfg
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	return cfg
+}
+
+// Load читает конфиг из файла и переменных окружения. Неизвестный ключ
+// в файле — ошибка: cleanenv молча пропустил бы опечатку, и настройка
+// осталась бы по умолчанию.
+func Load(path string) (*Config, error) {
+	if err := checkKeys(path); err != nil {
+		return nil, err
+	}
+	cfg := Config{RateLimit: defaultRateLimit}
+	if err := cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
 }
 
-// fetchConfigPath fetches the configuration file path from command line or environment variable.
+// checkKeys разбирает YAML-файл с запретом неизвестных полей.
+func checkKeys(path string) error {
+	if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&Config{}); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// Path возвращает путь к файлу конфига.
 // priority: flag > env > default
-func fetchConfigPath(a *args.Args) string {
+func Path(a *args.Args) string {
 	switch {
 	case a.ConfigPath != "":
 		return a.ConfigPath
@@ -99,6 +413,15 @@ func fetchConfigPath(a *args.Args) string {
 	}
 }
 
+// LogLevel — уровень журнала из log.level или по окружению.
+func (c *Config) LogLevel() slog.Level {
+	var level slog.Level
+	if c.Log.Level == "" || level.UnmarshalText([]byte(c.Log.Level)) != nil {
+		return logger.DefaultLevel(c.Env)
+	}
+	return level
+}
+
 func (s *Storage) DSN() string {
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=disable",
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseYAML = `
+env: production
+admin_code: admin-code
+jwt_config:
+  jwt_secret: 0123456789abcdef0123456789abcdef
+  issuer: test
+  audience: test
+storage:
+  host: db
+  user: store
+  dbname: store
+`
+
+func writeConfig(t *testing.T, path, extra string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(baseYAML+extra), 0o600))
+}
+
+func TestValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "")
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	// опечатки в тегах больше не теряют значения по умолчанию
+	assert.Equal(t, 5432, cfg.Storage.Port)
+	assert.Equal(t, 10, cfg.Storage.MaxOpenConns)
+	assert.Equal(t, StorageDriverPostgres, cfg.Storage.Driver)
+
+	cfg.AdminCode = ""
+	cfg.Log.Level = "loud"
+	cfg.JWTConfig.JWTSecret = "short"
+	cfg.HTTPServer.CORS = CORS{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+	cfg.HTTPServer.TLS.Enabled = true
+	cfg.HTTPServer.TrustedProxies = []string{"10.0.0.0/8", "nginx"}
+	cfg.Storage.Port = 70000
+	cfg.Storage.MaxIdleConns = 100
+	cfg.RateLimit.Store = "etcd"
+	cfg.RateLimit.Search.Requests = -1
+	cfg.Telemetry.SampleRatio = 2
+	cfg.Migrations.OnStart = "maybe"
+	err = cfg.Validate()
+	for _, field := range []string{
+		"admin_code", "log.level", "jwt_config.jwt_secret", "http_server.cors", "http_server.tls",
+		"http_server.trusted_proxies", "storage.port", "storage.max_idle_conns", "rate_limit.store", "rate_limit.search",
+		"telemetry.sample_ratio", "migrations.on_start",
+	} {
+		assert.ErrorContains(t, err, field+":")
+	}
+}
+
+func TestLoadUnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+swagger:
+  enable: false
+`)
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "field enable not found")
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{AdminCode: "code"}
+	cfg.JWTConfig.JWTSecret = "secret"
+	cfg.Notifier.Telegram.BotToken = "token"
+	cfg.Storage.User = "store"
+
+	red := cfg.Redacted()
+	assert.Equal(t, RedactedValue, red.AdminCode)
+	assert.Equal(t, RedactedValue, red.JWTConfig.JWTSecret)
+	assert.Equal(t, RedactedValue, red.Notifier.Telegram.BotToken)
+	assert.Empty(t, red.Storage.Password, "unset secret stays empty")
+	assert.Equal(t, "store", red.Storage.User)
+	assert.Equal(t, "secret", cfg.JWTConfig.JWTSecret, "original is untouched")
+}
@@ -0,0 +1,31 @@
+package config
+
+import "reflect"
+
+// RedactedValue заменяет секреты в выводе конфига.
+const RedactedValue = "[redacted]"
+
+// Redacted — копия конфига, которую можно печатать: строки с тегом
+// secret:"true" заменены на RedactedValue. Пустой секрет остаётся пустым —
+// так видно, что он не задан.
+func (c *Config) Redacted() *Config {
+	res := *c
+	redact(reflect.ValueOf(&res).Elem())
+	return &res
+}
+
+// redact меняет только строки во вложенных структурах этого пакета: они
+// скопированы по значению, а срезы и карты копия делит с оригиналом.
+func redact(v reflect.Value) {
+	for i := range v.NumField() {
+		f, fv := v.Type().Field(i), v.Field(i)
+		switch {
+		case f.Tag.Get("secret") == "true" && fv.Kind() == reflect.String:
+			if fv.String() != "" {
+				fv.SetString(RedactedValue)
+			}
+		case fv.Kind() == reflect.Struct && fv.Type().PkgPath() == configPkg:
+			redact(fv)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Neimess/zorkin-store-project/pkg/filewatch"
+)
+
+// Reloader перечитывает конфиг без перезапуска. Применяются только
+// безопасные настройки (см. reloadable): уровень журнала, CORS, лимиты
+// запросов и переключатели Swagger и pprof. Остальное — адреса, DSN,
+// секреты, хранилище лимитов — требует перезапуска: такие изменения
+// попадают в журнал и не применяются.
+//
+// Новый конфиг сначала проверяется Validate целиком; с ошибкой он
+// отбрасывается, и продолжает действовать текущий.
+type Reloader struct {
+	path string
+	log  *slog.Logger
+	cur  atomic.Pointer[Config]
+
+	// mu упорядочивает перезагрузки, чтобы подписчики получали их по очереди
+	mu   sync.Mutex
+	subs []func(*Config)
+}
+
+func NewReloader(path string, cfg *Config, log *slog.Logger) *Reloader {
+	r := &Reloader{path: path, log: log.With(slog.String("component", "config"), slog.String("path", path))}
+	r.cur.Store(cfg)
+	return r
+}
+
+// Current — действующий конфиг.
+func (r *Reloader) Current() *Config {
+	return r.cur.Load()
+}
+
+// OnReload подписывает fn на применённые перезагрузки; fn получает новый
+// конфиг и не должен его менять.
+func (r *Reloader) OnReload(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subs = append(r.subs, fn)
+}
+
+// Reload читает файл и применяет изменившиеся безопасные настройки.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := Load(r.path)
+	if err == nil {
+		err = next.Validate()
+	}
+	if err != nil {
+		r.log.Error("config reload rejected, keeping current", slog.Any("error", err))
+		return err
+	}
+
+	cur := r.cur.Load()
+	applied := reloadable(cur, next)
+	if restart := diff(applied, next); len(restart) > 0 {
+		r.log.Warn("config changes require restart and are not applied", slog.Any("fields", restart))
+	}
+	changed := diff(cur, applied)
+	if len(changed) == 0 {
+		r.log.Info("config reloaded, nothing to apply")
+		return nil
+	}
+	r.cur.Store(applied)
+	for _, fn := range r.subs {
+		fn(applied)
+	}
+	r.log.Info("config reloaded", slog.Any("changed", changed))
+	return nil
+}
+
+// Watch перечитывает конфиг по SIGHUP и при изменении файла, пока не отменён ctx.
+func (r *Reloader) Watch(ctx context.Context) {
+	filewatch.Watch(ctx, r.Current().ReloadInterval, []string{r.path}, func(string) error {
+		// отклонённый конфиг уже в журнале; повторять его чтение до
+		// следующей правки файла незачем
+		_ = r.Reload()
+		return nil
+	})
+}
+
+// reloadable — cur с безопасными настройками из next.
+func reloadable(cur, next *Config) *Config {
+	res := *cur
+	res.Log.Level = next.Log.Level
+	res.HTTPServer.CORS = next.HTTPServer.CORS
+	res.HTTPServer.EnablePProf = next.HTTPServer.EnablePProf
+	res.Swagger.Enabled = next.Swagger.Enabled
+	// хранилище счётчиков создаётся при старте
+	res.RateLimit = next.RateLimit
+	res.RateLimit.Store = cur.RateLimit.Store
+	return &res
+}
+
+var configPkg = reflect.TypeOf(Config{}).PkgPath()
+
+// diff — YAML-пути полей, которыми a и b различаются. Значения в журнал
+// не попадают: среди них есть секреты.
+func diff(a, b *Config) []string {
+	var fields []string
+	diffValue(reflect.ValueOf(*a), reflect.ValueOf(*b), "", &fields)
+	return fields
+}
+
+// diffValue спускается только в структуры этого пакета: у остальных
+// (decimal, time) поля скрыты, и их сравнивает DeepEqual.
+func diffValue(a, b reflect.Value, path string, fields *[]string) {
+	if a.Kind() != reflect.Struct || a.Type().PkgPath() != configPkg {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*fields = append(*fields, path)
+		}
+		return
+	}
+	for i := range a.NumField() {
+		f := a.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+		diffValue(a.Field(i), b.Field(i), name, fields)
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "")
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, slog.LevelInfo, cfg.LogLevel())
+
+	r := NewReloader(path, cfg, slog.New(slog.DiscardHandler))
+	var got []*Config
+	r.OnReload(func(c *Config) { got = append(got, c) })
+
+	t.Run("safe settings are applied", func(t *testing.T) {
+		writeConfig(t, path, `
+log:
+  level: debug
+http_server:
+  cors:
+    allowed_origins: ["https://zorkin.ru"]
+rate_limit:
+  leads: { requests: 2, per: 1m }
+`)
+		require.NoError(t, r.Reload())
+		require.Len(t, got, 1)
+		cur := r.Current()
+		assert.Same(t, got[0], cur)
+		assert.Equal(t, slog.LevelDebug, cur.LogLevel())
+		assert.Equal(t, []string{"https://zorkin.ru"}, cur.HTTPServer.CORS.AllowedOrigins)
+		assert.Equal(t, RatePolicy{Requests: 2, Per: time.Minute, Burst: defaultRateLimit.Leads.Burst}, cur.RateLimit.Leads)
+		// ключи, которых нет в файле, остаются по умолчанию
+		assert.Equal(t, defaultRateLimit.Public, cur.RateLimit.Public)
+	})
+
+	t.Run("restart-only settings are ignored", func(t *testing.T) {
+		writeConfig(t, path, `
+log:
+  level: debug
+http_server:
+  address: 0.0.0.0:9999
+  cors:
+    allowed_origins: ["https://zorkin.ru"]
+rate_limit:
+  store: redis
+  leads: { requests: 2, per: 1m }
+`)
+		require.NoError(t, r.Reload())
+		assert.Len(t, got, 1, "nothing to apply")
+		assert.Equal(t, "localhost:8080", r.Current().HTTPServer.Address)
+		assert.Equal(t, RateLimitStoreMemory, r.Current().RateLimit.Store)
+	})
+
+	t.Run("invalid config is rejected", func(t *testing.T) {
+		writeConfig(t, path, `
+log:
+  level: verbose
+`)
+		require.ErrorContains(t, r.Reload(), "log.level")
+		assert.Len(t, got, 1)
+		assert.Equal(t, slog.LevelDebug, r.Current().LogLevel())
+	})
+}
+
+func TestDiff(t *testing.T) {
+	a := &Config{}
+	b := &Config{}
+	b.Storage.Password = "secret"
+	b.HTTPServer.CORS.AllowedOrigins = []string{"*"}
+	b.RateLimit.Auth.Burst = 1
+	assert.Equal(t, []string{
+		"http_server.cors.allowed_origins",
+		"storage.password",
+		"rate_limit.auth.burst",
+	}, diff(a, b))
+}
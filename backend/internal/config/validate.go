@@ -0,0 +1,178 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// Validate проверяет загруженный конфиг: обязательные поля, диапазоны,
+// допустимые значения и согласованность разделов. Ошибки перечисляются
+// все сразу, с путём ключа в YAML.
+//
+// Обязательные поля проверяются здесь, а не тегом env-required: cleanenv
+// останавливается на первом пропуске, а store config check должен
+// показать всё.
+func (c *Config) Validate() error {
+	var v validation
+	v.oneOf(c.Env, "env", EnvLocal, EnvDev, EnvProd, EnvTest)
+	v.check(c.Version != "", "version", "is required")
+	v.check(len(c.AdminCode) >= minAdminCode, "admin_code", "must be at least %d characters", minAdminCode)
+	if c.Log.Level != "" {
+		var level slog.Level
+		v.check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level", "%q is not debug, info, warn or error", c.Log.Level)
+	}
+	v.check(c.ReloadInterval > 0, "reload_interval", "must be positive")
+
+	c.HTTPServer.validate(&v)
+	c.JWTConfig.validate(&v)
+	c.Storage.validate(&v)
+	c.RateLimit.validate(&v)
+	if c.RateLimit.Store == RateLimitStoreRedis {
+		v.check(c.Redis.Addr != "", "redis.addr", "is required for rate_limit.store: redis")
+		v.check(c.Redis.DB >= 0, "redis.db", "must not be negative")
+	}
+	c.Notifier.validate(&v)
+	if _, err := c.Currency.RoundingRules(); err != nil {
+		v = append(v, err)
+	}
+	c.Webhooks.validate(&v)
+	c.Exchange1C.validate(&v)
+	v.check(c.Idempotency.TTL > 0, "idempotency.ttl", "must be positive")
+	c.Telemetry.validate(&v)
+	v.check(c.Health.Timeout > 0, "health.timeout", "must be positive")
+	v.check(c.Health.DrainDelay >= 0, "health.drain_delay", "must not be negative")
+	v.oneOf(c.Migrations.OnStart, "migrations.on_start", MigrateOnStartAuto, MigrateOnStartCheck, MigrateOnStartOff)
+	return errors.Join(v...)
+}
+
+const (
+	minAdminCode = 8
+	// minJWTSecret — RFC 7518 требует для HS256 ключ не короче хеша, 256 бит.
+	minJWTSecret = 32
+)
+
+// validation копит ошибки проверки.
+type validation []error
+
+func (v *validation) check(ok bool, field, format string, args ...any) {
+	if !ok {
+		*v = append(*v, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (v *validation) oneOf(value, field string, allowed ...string) {
+	v.check(slices.Contains(allowed, value), field, "%q is not one of %s", value, strings.Join(allowed, ", "))
+}
+
+func (h HTTPServer) validate(v *validation) {
+	v.check(h.Address != "", "http_server.address", "is required")
+	v.check(h.MaxHeaderBytes > 0, "http_server.max_header_bytes", "must be positive")
+	// 0 у таймаутов net/http означает «без ограничения»
+	v.check(h.ReadTimeout >= 0 && h.WriteTimeout >= 0 && h.IdleTimeout >= 0,
+		"http_server", "read_timeout, write_timeout and idle_timeout must not be negative")
+	v.check(h.ShutdownTimeout > 0, "http_server.shutdown_timeout", "must be positive")
+	if err := h.CORS.Validate(); err != nil {
+		*v = append(*v, err)
+	}
+	v.check(h.CORS.MaxAge >= 0, "http_server.cors.max_age", "must not be negative")
+	v.check(h.Security.HSTSMaxAge >= 0, "http_server.security.hsts_max_age", "must not be negative")
+	v.check(h.BodyLimit.Default >= 0 && h.BodyLimit.Forms >= 0 && h.BodyLimit.Snapshot >= 0,
+		"http_server.body_limit", "limits must not be negative")
+	if h.TLS.Enabled {
+		v.check(h.TLS.CertFile != "" && h.TLS.KeyFile != "", "http_server.tls", "cert_file and key_file are required when enabled")
+		v.check(h.TLS.ReloadInterval > 0, "http_server.tls.reload_interval", "must be positive")
+	}
+	v.check(h.AdminAddress == "" || h.AdminAddress != h.Address, "http_server.admin_address", "must differ from http_server.address")
+	if _, err := h.TrustedPrefixes(); err != nil {
+		v.check(false, "http_server.trusted_proxies", "%v", err)
+	}
+}
+
+// Validate: на "*" go-chi/cors отвечает Access-Control-Allow-Origin: *,
+// а браузеры не принимают его вместе с учётными данными — такой конфиг
+// выглядел бы рабочим, но запросы с cookie и Authorization падали бы.
+func (c CORS) Validate() error {
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		return errors.New(`http_server.cors: allow_credentials cannot be used with allowed_origins "*"`)
+	}
+	return nil
+}
+
+func (j JWTConfig) validate(v *validation) {
+	v.check(len(j.JWTSecret) >= minJWTSecret, "jwt_config.jwt_secret", "must be at least %d bytes", minJWTSecret)
+	v.check(j.Issuer != "", "jwt_config.issuer", "is required")
+	v.check(j.Audience != "", "jwt_config.audience", "is required")
+	// токены выпускает pkg/secret/jwt, и подписывает он их только HS256
+	v.check(j.Algorithm == "HS256", "jwt_config.algorithm", "%q is not supported, tokens are signed with HS256", j.Algorithm)
+}
+
+func (s Storage) validate(v *validation) {
+	v.oneOf(s.Driver, "storage.driver", StorageDriverPostgres)
+	v.check(s.Host != "", "storage.host", "is required")
+	v.check(s.Port > 0 && s.Port <= 65535, "storage.port", "%d is not a valid port", s.Port)
+	v.check(s.User != "", "storage.user", "is required")
+	v.check(s.DBName != "", "storage.dbname", "is required")
+	v.oneOf(s.SSLMode, "storage.sslmode", "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	v.check(s.MaxOpenConns > 0, "storage.max_open_conns", "must be positive")
+	v.check(s.MaxIdleConns >= 0 && s.MaxIdleConns <= s.MaxOpenConns, "storage.max_idle_conns", "must be between 0 and max_open_conns")
+	v.check(s.ConnMaxLifetime >= 0 && s.ConnMaxTimeout >= 0, "storage", "conn_max_lifetime and conn_max_timeout must not be negative")
+}
+
+func (r RateLimit) validate(v *validation) {
+	v.oneOf(r.Store, "rate_limit.store", RateLimitStoreMemory, RateLimitStoreRedis)
+	for _, p := range []struct {
+		name string
+		RatePolicy
+	}{{"public", r.Public}, {"search", r.Search}, {"leads", r.Leads}, {"auth", r.Auth}, {"admin", r.Admin}} {
+		v.check(p.Requests >= 0 && p.Per >= 0 && p.Burst >= 0, "rate_limit."+p.name, "requests, per and burst must not be negative")
+		v.check(p.Requests == 0 || p.Per > 0, "rate_limit."+p.name+".per", "is required when requests is set")
+	}
+}
+
+func (n Notifier) validate(v *validation) {
+	v.check(n.Timeout > 0, "notifier.timeout", "must be positive")
+	if s := n.SMTP; s.Enabled {
+		v.check(s.Host != "", "notifier.smtp.host", "is required when enabled")
+		v.check(s.Port > 0 && s.Port <= 65535, "notifier.smtp.port", "%d is not a valid port", s.Port)
+		v.check(s.From != "", "notifier.smtp.from", "is required when enabled")
+		v.check(len(s.To) > 0, "notifier.smtp.to", "is required when enabled")
+	}
+	if t := n.Telegram; t.Enabled {
+		v.check(t.BotToken != "" && t.ChatID != "", "notifier.telegram", "bot_token and chat_id are required when enabled")
+		v.check(t.APIURL != "", "notifier.telegram.api_url", "is required when enabled")
+	}
+}
+
+// validate: при enabled=false события только копятся, поэтому расписание
+// рассылки проверяется всегда — его включат без правки остальных ключей.
+func (w Webhooks) validate(v *validation) {
+	v.check(w.Interval > 0, "webhooks.interval", "must be positive")
+	v.check(w.BatchSize > 0, "webhooks.batch_size", "must be positive")
+	v.check(w.MaxAttempts > 0, "webhooks.max_attempts", "must be positive")
+	v.check(w.BackoffBase > 0 && w.BackoffMax >= w.BackoffBase, "webhooks", "backoff_base must be positive and not exceed backoff_max")
+	v.check(w.Timeout > 0, "webhooks.timeout", "must be positive")
+}
+
+func (e Exchange1C) validate(v *validation) {
+	if e.Login == "" {
+		return
+	}
+	v.check(e.Password != "", "exchange_1c.password", "is required when login is set")
+	v.check(e.FileLimit > 0, "exchange_1c.file_limit", "must be positive")
+	v.check(e.SessionTTL > 0, "exchange_1c.session_ttl", "must be positive")
+	v.check(e.OrdersLimit > 0, "exchange_1c.orders_limit", "must be positive")
+}
+
+func (t Telemetry) validate(v *validation) {
+	if t.MetricsEnabled {
+		v.check(strings.HasPrefix(t.MetricsPath, "/"), "telemetry.metrics_path", "%q must start with /", t.MetricsPath)
+	}
+	if t.TracingEnabled {
+		v.check(t.OTLPEndpoint != "", "telemetry.otlp_endpoint", "is required when tracing is enabled")
+		v.check(t.ServiceName != "", "telemetry.service_name", "is required when tracing is enabled")
+	}
+	v.check(t.SampleRatio >= 0 && t.SampleRatio <= 1, "telemetry.sample_ratio", "must be between 0 and 1")
+}
@@ -0,0 +1,80 @@
+// Package batch описывает пакетные операции над товарами, услугами и атрибутами:
+// список create/update/delete, у каждой операции — свой результат.
+package batch
+
+import "fmt"
+
+// MaxItems — сколько операций можно передать в одном пакете.
+const MaxItems = 100
+
+type Op string
+
+const (
+	OpCreate Op = "create"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+func (o Op) Valid() bool {
+	switch o {
+	case OpCreate, OpUpdate, OpDelete:
+		return true
+	}
+	return false
+}
+
+type Status string
+
+const (
+	StatusOK     Status = "ok"
+	StatusFailed Status = "failed"
+	// StatusRolledBack — операция выполнилась, но атомарный пакет откатился
+	// из-за ошибки в другой операции.
+	StatusRolledBack Status = "rolled_back"
+	// StatusSkipped — до операции дело не дошло: атомарный пакет прерван раньше.
+	StatusSkipped Status = "skipped"
+)
+
+// Item — одна операция пакета. ID нужен для update и delete,
+// Value — для create и update. Version — ожидаемая версия сущности для
+// update и delete, как If-Match у одиночного запроса; для сущностей с
+// версиями она обязательна.
+type Item[T any] struct {
+	Op      Op
+	ID      int64
+	Version int64
+	Value   T
+}
+
+func (it Item[T]) Validate() error {
+	if !it.Op.Valid() {
+		return ErrInvalidOp
+	}
+	if it.Op != OpCreate && it.ID <= 0 {
+		return ErrMissingID
+	}
+	if it.Version < 0 || (it.Op == OpCreate && it.Version != 0) {
+		return ErrInvalidVersion
+	}
+	return nil
+}
+
+// Result — итог одной операции. Value — созданная или обновлённая сущность.
+type Result[T any] struct {
+	Op     Op
+	ID     int64
+	Status Status
+	Value  T
+	Err    error
+}
+
+// Validate проверяет размер пакета.
+func Validate[T any](items []Item[T]) error {
+	if len(items) == 0 {
+		return ErrEmpty
+	}
+	if len(items) > MaxItems {
+		return fmt.Errorf("%w: %d operations, max %d", ErrTooLarge, len(items), MaxItems)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package batch
+
+import "errors"
+
+var (
+	ErrEmpty     = errors.New("batch is empty")
+	ErrTooLarge  = errors.New("batch is too large")
+	ErrInvalidOp = errors.New("batch: op must be create, update or delete")
+	ErrMissingID = errors.New("batch: id is required for update and delete")
+	// ErrInvalidVersion — version передан для create или не положителен.
+	ErrInvalidVersion = errors.New("batch: version is allowed only for update and delete")
+	// ErrVersionNotSupported — у сущностей пакета нет версий.
+	ErrVersionNotSupported = errors.New("batch: version is not supported for these entities")
+)
@@ -10,6 +10,7 @@ type Category struct {
 	ID       int64
 	Name     string
 	ParentID *int64
+	Version  int64
 }
 
 func (c *Category) Validate() error {
@@ -0,0 +1,15 @@
+package category
+
+import "github.com/Neimess/zorkin-store-project/internal/domain/patch"
+
+// Patch — частичное обновление категории.
+type Patch struct {
+	ID       int64
+	Version  int64
+	Name     patch.Field[string]
+	ParentID patch.Field[*int64]
+}
+
+func (p *Patch) Empty() bool {
+	return !p.Name.Set && !p.ParentID.Set
+}
@@ -5,9 +5,10 @@ import (
 )
 
 type Coefficient struct {
-	ID    int64
-	Name  string
-	Value float64
+	ID      int64
+	Name    string
+	Value   float64
+	Version int64
 }
 
 func (c *Coefficient) Validate() error {
@@ -0,0 +1,15 @@
+package coefficients
+
+import "github.com/Neimess/zorkin-store-project/internal/domain/patch"
+
+// Patch — частичное обновление коэффициента.
+type Patch struct {
+	ID      int64
+	Version int64
+	Name    patch.Field[string]
+	Value   patch.Field[float64]
+}
+
+func (p *Patch) Empty() bool {
+	return !p.Name.Set && !p.Value.Set
+}
@@ -0,0 +1,131 @@
+package discount
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+)
+
+const MaxNameLength = 255
+
+var hundred = decimal.NewFromInt(100)
+
+// Kind — способ расчёта скидки.
+type Kind string
+
+const (
+	// KindPercent — процент от цены, Value от 0 до 100.
+	KindPercent Kind = "percent"
+	// KindFixed — фиксированная сумма в базовой валюте.
+	KindFixed Kind = "fixed"
+)
+
+func (k Kind) Valid() bool {
+	return k == KindPercent || k == KindFixed
+}
+
+// Target — к чему привязана скидка. Скидка на категорию действует
+// на товары самой категории и всех её подкатегорий.
+type Target string
+
+const (
+	TargetProduct  Target = "product"
+	TargetCategory Target = "category"
+	TargetPreset   Target = "preset"
+	TargetService  Target = "service"
+)
+
+func (t Target) Valid() bool {
+	switch t {
+	case TargetProduct, TargetCategory, TargetPreset, TargetService:
+		return true
+	}
+	return false
+}
+
+// Rule — правило скидки, действующее в интервале [StartsAt, EndsAt).
+// Пустая граница интервала означает «без ограничения». Правило с PromoOnly
+// применяется только по одному из своих промокодов.
+type Rule struct {
+	ID        int64
+	Name      string
+	Kind      Kind
+	Value     decimal.Decimal
+	Target    Target
+	TargetID  int64
+	StartsAt  *time.Time
+	EndsAt    *time.Time
+	PromoOnly bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (r *Rule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return ErrEmptyName
+	}
+	if len([]rune(r.Name)) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if !r.Kind.Valid() {
+		return ErrInvalidKind
+	}
+	if !r.Value.IsPositive() {
+		return ErrInvalidValue
+	}
+	switch r.Kind {
+	case KindPercent:
+		if r.Value.GreaterThan(hundred) {
+			return ErrInvalidValue
+		}
+	case KindFixed:
+		if !r.Value.Equal(r.Value.Round(2)) {
+			return ErrInvalidValue
+		}
+	}
+	if !r.Target.Valid() || r.TargetID <= 0 {
+		return ErrInvalidTarget
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
+
+// ActiveAt сообщает, действует ли правило в момент t.
+func (r Rule) ActiveAt(t time.Time) bool {
+	if r.StartsAt != nil && t.Before(*r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !t.Before(*r.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// Apply возвращает цену со скидкой. fixed — сумма фиксированной скидки,
+// уже пересчитанная в валюту цены; для процентной скидки не используется.
+// Цена со скидкой не бывает отрицательной.
+func (r Rule) Apply(price, fixed money.Money, rnd money.Rounding) money.Money {
+	off := fixed.Amount
+	if r.Kind == KindPercent {
+		off = price.Amount.Mul(r.Value).Div(hundred)
+	}
+	res := price.Amount.Sub(off)
+	if res.IsNegative() {
+		res = decimal.Zero
+	}
+	return money.New(res, price.Currency).Round(rnd)
+}
+
+// Sale — применённая к цене скидка: цена до неё и правило, по которому
+// она посчитана. Текущая цена хранится в самой сущности.
+type Sale struct {
+	OldPrice  money.Money
+	Rule      Rule
+	PromoCode *string
+}
@@ -0,0 +1,20 @@
+package discount
+
+import "errors"
+
+var (
+	ErrEmptyName            = errors.New("discount name must not be empty")
+	ErrNameTooLong          = errors.New("discount name is too long")
+	ErrInvalidKind          = errors.New("invalid discount kind")
+	ErrInvalidValue         = errors.New("discount value must be positive; percent at most 100, amount with at most two decimals")
+	ErrInvalidTarget        = errors.New("invalid discount target")
+	ErrInvalidPeriod        = errors.New("discount must end after it starts")
+	ErrRuleNotFound         = errors.New("discount rule not found")
+	ErrTargetNotFound       = errors.New("discount target not found")
+	ErrInvalidPromoCode     = errors.New("invalid promo code")
+	ErrInvalidUsageLimit    = errors.New("promo code usage limit must be positive")
+	ErrPromoCodeNotFound    = errors.New("promo code not found")
+	ErrPromoCodeExists      = errors.New("promo code already exists")
+	ErrPromoCodeNotPromo    = errors.New("promo codes can be attached to promo-only rules only")
+	ErrPromoCodeUnavailable = errors.New("promo code is expired or exhausted")
+)
@@ -0,0 +1,76 @@
+package discount
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var promoRe = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{2,31}$`)
+
+// NormalizePromoCode приводит код к виду, в котором он хранится:
+// без пробелов по краям и в верхнем регистре.
+func NormalizePromoCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !promoRe.MatchString(code) {
+		return "", ErrInvalidPromoCode
+	}
+	return code, nil
+}
+
+// PromoCode открывает доступ к правилу с PromoOnly. Срок действия кода
+// совпадает со сроком правила; UsageLimit ограничивает число погашений,
+// nil — без ограничения.
+type PromoCode struct {
+	Code       string
+	RuleID     int64
+	UsageLimit *int
+	UsedCount  int
+	CreatedAt  time.Time
+}
+
+func (p *PromoCode) Validate() error {
+	code, err := NormalizePromoCode(p.Code)
+	if err != nil {
+		return err
+	}
+	p.Code = code
+	if p.UsageLimit != nil && *p.UsageLimit <= 0 {
+		return ErrInvalidUsageLimit
+	}
+	return nil
+}
+
+// Remaining — сколько раз код ещё можно погасить; nil — без ограничения.
+func (p PromoCode) Remaining() *int {
+	if p.UsageLimit == nil {
+		return nil
+	}
+	left := max(*p.UsageLimit-p.UsedCount, 0)
+	return &left
+}
+
+// Exhausted сообщает, исчерпан ли лимит погашений.
+func (p PromoCode) Exhausted() bool {
+	left := p.Remaining()
+	return left != nil && *left == 0
+}
+
+// Pricing включает скидки при чтении каталога. Без него (например,
+// в админке) цены отдаются как есть, чтобы их не сохранили со скидкой.
+type Pricing struct {
+	// PromoCode — нормализованный промокод покупателя, может быть пустым.
+	PromoCode string
+}
+
+type pricingKey struct{}
+
+func WithPricing(ctx context.Context, p Pricing) context.Context {
+	return context.WithValue(ctx, pricingKey{}, p)
+}
+
+func PricingFromContext(ctx context.Context) (Pricing, bool) {
+	p, ok := ctx.Value(pricingKey{}).(Pricing)
+	return p, ok
+}
@@ -0,0 +1,13 @@
+package exchange
+
+import "errors"
+
+var (
+	ErrUnauthorized      = errors.New("invalid exchange credentials")
+	ErrSessionNotFound   = errors.New("exchange session not found or expired")
+	ErrInvalidFilename   = errors.New("invalid exchange file name")
+	ErrFileNotFound      = errors.New("exchange file not found")
+	ErrFileTooLarge      = errors.New("exchange file is too large")
+	ErrUnknownDocument   = errors.New("document contains neither catalog nor offers")
+	ErrMalformedDocument = errors.New("malformed CommerceML document")
+)
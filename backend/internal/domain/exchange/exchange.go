@@ -0,0 +1,143 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/lead"
+)
+
+// Source1C — источник внешних идентификаторов при обмене с 1С.
+const Source1C = "1c"
+
+// Entity — тип сущности, к которой привязан внешний идентификатор.
+type Entity string
+
+const (
+	EntityCategory  Entity = "category"
+	EntityAttribute Entity = "attribute"
+	EntityProduct   Entity = "product"
+	EntityService   Entity = "service"
+)
+
+// Group — группа номенклатуры, становится категорией.
+// ParentID пуст у групп верхнего уровня.
+type Group struct {
+	ExternalID string
+	ParentID   string
+	Name       string
+}
+
+// Property — свойство номенклатуры, становится атрибутом категории товара.
+// Values — варианты значений свойства-справочника по их идентификаторам.
+type Property struct {
+	ExternalID string
+	Name       string
+	Values     map[string]string
+}
+
+type PropertyValue struct {
+	PropertyID string
+	Value      string
+}
+
+// Item — товар каталога.
+type Item struct {
+	ExternalID  string
+	Name        string
+	Description *string
+	GroupID     string
+	Properties  []PropertyValue
+	// Deleted — товар помечен в 1С на удаление.
+	Deleted bool
+}
+
+// Catalog — классификатор и каталог из import.xml. Группы идут так,
+// что родитель всегда раньше потомков.
+type Catalog struct {
+	Groups     []Group
+	Properties []Property
+	Items      []Item
+	// OnlyChanges — выгрузка содержит только изменения, отсутствующие
+	// в ней товары не трогаются.
+	OnlyChanges bool
+}
+
+// PropertyValueText возвращает значение свойства с учётом справочника.
+func (c *Catalog) PropertyValueText(v PropertyValue) string {
+	for _, p := range c.Properties {
+		if p.ExternalID != v.PropertyID {
+			continue
+		}
+		if text, ok := p.Values[v.Value]; ok {
+			return text
+		}
+		break
+	}
+	return v.Value
+}
+
+// Offer — цена и остаток товара из offers.xml. ProductID — идентификатор
+// товара без характеристики: предложения по характеристикам одного товара
+// сворачиваются в одно.
+type Offer struct {
+	ProductID string
+	Price     *decimal.Decimal
+	Quantity  *decimal.Decimal
+}
+
+// Package — разобранный файл обмена: каталог, предложения или оба сразу.
+type Package struct {
+	Catalog *Catalog
+	Offers  []Offer
+}
+
+// ImportResult — итог загрузки файла.
+type ImportResult struct {
+	Categories int
+	Attributes int
+	Products   int
+	Deleted    int
+	Offers     int
+	Skipped    int
+}
+
+func (r *ImportResult) Add(o ImportResult) {
+	r.Categories += o.Categories
+	r.Attributes += o.Attributes
+	r.Products += o.Products
+	r.Deleted += o.Deleted
+	r.Offers += o.Offers
+	r.Skipped += o.Skipped
+}
+
+// Order — заявка, выгружаемая в 1С как заказ.
+type Order struct {
+	Lead  lead.Lead
+	Items []OrderItem
+}
+
+// OrderItem — позиция заказа. ExternalID — GUID товара из 1С, для
+// созданных в магазине позиций — собственный идентификатор вида "product-42".
+type OrderItem struct {
+	ExternalID string
+	Name       string
+	Price      decimal.Decimal
+	Quantity   decimal.Decimal
+}
+
+func (o *Order) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, it := range o.Items {
+		total = total.Add(it.Price.Mul(it.Quantity))
+	}
+	return total
+}
+
+// OrdersBatch — выгруженные заказы; QueriedAt запоминается в сессии и
+// после подтверждения 1С становится временем выгрузки.
+type OrdersBatch struct {
+	Orders    []Order
+	QueriedAt time.Time
+}
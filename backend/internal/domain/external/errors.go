@@ -0,0 +1,11 @@
+package external
+
+import "errors"
+
+var (
+	ErrInvalidSource     = errors.New("source must be 1-32 characters: lowercase latin letters, digits, '-', '_' or '.'")
+	ErrInvalidExternalID = errors.New("external id must be 1-128 characters without control characters")
+	ErrInvalidEntity     = errors.New("entity must be one of category, product, service")
+	ErrRefNotFound       = errors.New("external id not found")
+	ErrEntityNotFound    = errors.New("entity for external id not found")
+)
@@ -0,0 +1,68 @@
+package external
+
+import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Entity — сущность каталога, к которой привязывается внешний идентификатор.
+type Entity string
+
+const (
+	EntityCategory Entity = "category"
+	EntityProduct  Entity = "product"
+	EntityService  Entity = "service"
+)
+
+func (e Entity) Valid() bool {
+	switch e {
+	case EntityCategory, EntityProduct, EntityService:
+		return true
+	}
+	return false
+}
+
+const (
+	maxSourceLen     = 32
+	maxExternalIDLen = 128
+)
+
+// Ref — идентификатор сущности во внешней системе (учётной системе, PIM и т. п.).
+// Пара Source + ExternalID однозначно указывает на сущность своего типа,
+// поэтому повторная синхронизация обновляет запись, а не создаёт дубль.
+type Ref struct {
+	Source     string
+	ExternalID string
+	Entity     Entity
+	EntityID   int64
+	UpdatedAt  time.Time
+}
+
+// ValidateKey проверяет источник и внешний идентификатор.
+func ValidateKey(source, externalID string) error {
+	if source == "" || len(source) > maxSourceLen {
+		return ErrInvalidSource
+	}
+	for _, c := range source {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return ErrInvalidSource
+		}
+	}
+	if externalID == "" || utf8.RuneCountInString(externalID) > maxExternalIDLen || !utf8.ValidString(externalID) {
+		return ErrInvalidExternalID
+	}
+	for _, c := range externalID {
+		if unicode.IsControl(c) {
+			return ErrInvalidExternalID
+		}
+	}
+	return nil
+}
+
+func (r Ref) Validate() error {
+	if !r.Entity.Valid() {
+		return ErrInvalidEntity
+	}
+	return ValidateKey(r.Source, r.ExternalID)
+}
@@ -0,0 +1,9 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInvalidKey = errors.New("idempotency key must be 1-255 printable ASCII characters")
+	ErrKeyReused  = errors.New("idempotency key was already used for a different request")
+	ErrInProgress = errors.New("request with this idempotency key is still in progress")
+)
@@ -0,0 +1,44 @@
+package idempotency
+
+import "time"
+
+// Header — заголовок запроса с ключом идемпотентности.
+const Header = "Idempotency-Key"
+
+const maxKeyLen = 255
+
+// Response — сохранённый ответ, который отдаётся на повтор запроса.
+type Response struct {
+	StatusCode int
+	// Headers — только заголовки, нужные клиенту при повторе (Content-Type, Location).
+	Headers map[string]string
+	Body    []byte
+}
+
+// Record — ключ идемпотентности. Пока Response == nil, первый запрос с этим
+// ключом ещё выполняется.
+type Record struct {
+	Key string
+	// Fingerprint — хеш метода, пути и тела: тот же ключ с другим запросом — ошибка клиента.
+	Fingerprint string
+	Response    *Response
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+func (r Record) Completed() bool {
+	return r.Response != nil
+}
+
+// ValidateKey: ключ — печатные ASCII-символы, обычно UUID.
+func ValidateKey(key string) error {
+	if key == "" || len(key) > maxKeyLen {
+		return ErrInvalidKey
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
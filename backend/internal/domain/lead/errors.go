@@ -0,0 +1,16 @@
+package lead
+
+import "errors"
+
+var (
+	ErrLeadNotFound      = errors.New("lead not found")
+	ErrInvalidKind       = errors.New("invalid lead kind")
+	ErrEmptyName         = errors.New("lead name must not be empty")
+	ErrNameTooLong       = errors.New("lead name is too long")
+	ErrInvalidPhone      = errors.New("invalid phone number")
+	ErrInvalidEmail      = errors.New("invalid email")
+	ErrMessageTooLong    = errors.New("lead message is too long")
+	ErrInvalidStatus     = errors.New("invalid lead status")
+	ErrInvalidTransition = errors.New("lead status transition is not allowed")
+	ErrBadReference      = errors.New("referenced preset or product not found")
+)
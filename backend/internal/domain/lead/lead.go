@@ -0,0 +1,142 @@
+package lead
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/discount"
+)
+
+const (
+	MaxNameLength    = 100
+	MaxMessageLength = 2000
+	// maxLinks — больше ссылок в сообщении живые клиенты почти не присылают.
+	maxLinks = 2
+)
+
+// Kind — тип заявки.
+type Kind string
+
+const (
+	KindCallback     Kind = "callback"
+	KindConsultation Kind = "consultation"
+)
+
+func (k Kind) Valid() bool {
+	return k == KindCallback || k == KindConsultation
+}
+
+// Status — этап обработки заявки менеджером.
+type Status string
+
+const (
+	StatusNew        Status = "new"
+	StatusInProgress Status = "in_progress"
+	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
+	StatusSpam       Status = "spam"
+)
+
+// transitions описывает допустимые переходы: done, cancelled и spam — конечные.
+var transitions = map[Status][]Status{
+	StatusNew:        {StatusInProgress, StatusCancelled, StatusSpam},
+	StatusInProgress: {StatusDone, StatusCancelled},
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusNew, StatusInProgress, StatusDone, StatusCancelled, StatusSpam:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo сообщает, можно ли перевести заявку из s в next.
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, st := range transitions[s] {
+		if st == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Lead — заявка на обратный звонок или консультацию дизайнера.
+type Lead struct {
+	ID        int64
+	Kind      Kind
+	Name      string
+	Phone     string
+	Email     *string
+	Message   *string
+	PresetID  *int64
+	ProductID *int64
+	// PromoCode — промокод покупателя, погашается при сохранении заявки.
+	PromoCode *string
+	Status    Status
+	// ManagerNote — комментарий менеджера к последней смене статуса.
+	ManagerNote *string
+	SourceIP    *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+var phoneRe = regexp.MustCompile(`^\+?[0-9][0-9\s\-()]{5,19}$`)
+
+func (l *Lead) Validate() error {
+	if !l.Kind.Valid() {
+		return ErrInvalidKind
+	}
+	name := strings.TrimSpace(l.Name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	if len([]rune(name)) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if !phoneRe.MatchString(strings.TrimSpace(l.Phone)) {
+		return ErrInvalidPhone
+	}
+	if l.Email != nil {
+		if _, err := mail.ParseAddress(*l.Email); err != nil {
+			return ErrInvalidEmail
+		}
+	}
+	if l.Message != nil && len([]rune(*l.Message)) > MaxMessageLength {
+		return ErrMessageTooLong
+	}
+	if l.PromoCode != nil {
+		code, err := discount.NormalizePromoCode(*l.PromoCode)
+		if err != nil {
+			return err
+		}
+		l.PromoCode = &code
+	}
+	return nil
+}
+
+// LooksLikeSpam — простая эвристика для заявок, прошедших honeypot:
+// сообщения с большим количеством ссылок почти всегда рассылка.
+func (l *Lead) LooksLikeSpam() bool {
+	if l.Message == nil {
+		return false
+	}
+	msg := strings.ToLower(*l.Message)
+	return strings.Count(msg, "http://")+strings.Count(msg, "https://") > maxLinks
+}
+
+// TransitionTo меняет статус заявки с проверкой workflow.
+func (l *Lead) TransitionTo(next Status, note *string, at time.Time) error {
+	if !next.Valid() {
+		return ErrInvalidStatus
+	}
+	if !l.Status.CanTransitionTo(next) {
+		return ErrInvalidTransition
+	}
+	l.Status = next
+	l.ManagerNote = note
+	l.UpdatedAt = at
+	return nil
+}
@@ -0,0 +1,17 @@
+package money
+
+import "errors"
+
+var (
+	ErrInvalidCurrency     = errors.New("invalid currency code")
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrBaseCurrency        = errors.New("operation is not allowed for the base currency")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrInvalidRate         = errors.New("exchange rate must be positive")
+	ErrInvalidPrice        = errors.New("price must be positive with at most two decimals")
+	ErrInvalidEntity       = errors.New("entity does not support price overrides")
+	ErrInvalidRounding     = errors.New("invalid rounding rule")
+	ErrRateNotFound        = errors.New("exchange rate not found")
+	ErrOverrideNotFound    = errors.New("price override not found")
+	ErrEntityNotFound      = errors.New("priced entity not found")
+)
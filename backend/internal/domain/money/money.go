@@ -0,0 +1,116 @@
+// Package money — денежные суммы с валютой, курсы и правила округления.
+package money
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Currency — код валюты ISO 4217.
+type Currency string
+
+const (
+	RUB Currency = "RUB"
+	KZT Currency = "KZT"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+
+	// Base — валюта, в которой хранятся цены каталога.
+	Base = RUB
+)
+
+var currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ParseCurrency разбирает код валюты без учёта регистра ("kzt" → KZT).
+func ParseCurrency(s string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if !currencyRe.MatchString(string(c)) {
+		return "", ErrInvalidCurrency
+	}
+	return c, nil
+}
+
+// Money — сумма в валюте. Арифметика десятичная, без ошибок округления float.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency Currency
+}
+
+func New(amount decimal.Decimal, c Currency) Money {
+	return Money{Amount: amount, Currency: c}
+}
+
+// FromFloat переводит число из JSON в сумму: берётся кратчайшее
+// десятичное представление, т.е. 0.1 остаётся 0.1.
+func FromFloat(f float64, c Currency) Money {
+	return Money{Amount: decimal.NewFromFloat(f), Currency: c}
+}
+
+// Zero — нулевая сумма в валюте.
+func Zero(c Currency) Money {
+	return Money{Amount: decimal.Zero, Currency: c}
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.Currency}, nil
+}
+
+// Sum складывает суммы одной валюты; пустой список даёт ноль в Base.
+func Sum(ms []Money) (Money, error) {
+	if len(ms) == 0 {
+		return Zero(Base), nil
+	}
+	total := ms[0]
+	for _, m := range ms[1:] {
+		var err error
+		if total, err = total.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Mul умножает сумму на количество.
+func (m Money) Mul(q decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(q), Currency: m.Currency}
+}
+
+func (m Money) Round(r Rounding) Money {
+	return Money{Amount: r.Apply(m.Amount), Currency: m.Currency}
+}
+
+func (m Money) IsPositive() bool { return m.Amount.IsPositive() }
+
+func (m Money) Equal(o Money) bool {
+	return m.Currency == o.Currency && m.Amount.Equal(o.Amount)
+}
+
+// Float64 — сумма для JSON-ответов, где цена остаётся числом.
+func (m Money) Float64() float64 {
+	return m.Amount.InexactFloat64()
+}
+
+func (m Money) String() string {
+	return m.Amount.StringFixed(2) + " " + string(m.Currency)
+}
+
+type ctxKey struct{}
+
+// WithCurrency кладёт в контекст валюту, в которой нужно отдать цены.
+func WithCurrency(ctx context.Context, c Currency) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// CurrencyFromContext возвращает валюту запроса, по умолчанию — Base.
+func CurrencyFromContext(ctx context.Context) Currency {
+	if c, ok := ctx.Value(ctxKey{}).(Currency); ok && c != "" {
+		return c
+	}
+	return Base
+}
@@ -0,0 +1,78 @@
+package money
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rate — курс валюты: сколько единиц Currency стоит одна единица Base.
+type Rate struct {
+	Currency  Currency
+	Rate      decimal.Decimal
+	UpdatedAt time.Time
+}
+
+func (r *Rate) Validate() error {
+	if !currencyRe.MatchString(string(r.Currency)) {
+		return ErrInvalidCurrency
+	}
+	if r.Currency == Base {
+		return ErrBaseCurrency
+	}
+	if !r.Rate.IsPositive() {
+		return ErrInvalidRate
+	}
+	return nil
+}
+
+// Convert пересчитывает сумму в базовой валюте в валюту курса.
+func (r Rate) Convert(m Money, rnd Rounding) (Money, error) {
+	if m.Currency != Base {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return New(m.Amount.Mul(r.Rate), r.Currency).Round(rnd), nil
+}
+
+// Entity — сущность каталога, для которой можно задать цену в валюте.
+type Entity string
+
+const (
+	EntityProduct Entity = "product"
+	EntityService Entity = "service"
+	// EntityPreset — итог пресета; ручные цены для него не задаются.
+	EntityPreset Entity = "preset"
+)
+
+// Overridable сообщает, можно ли задать сущности цену вручную.
+func (e Entity) Overridable() bool {
+	return e == EntityProduct || e == EntityService
+}
+
+// PriceOverride — заданная администратором цена сущности в валюте,
+// которая используется вместо пересчёта по курсу.
+type PriceOverride struct {
+	Entity    Entity
+	EntityID  int64
+	Price     Money
+	UpdatedAt time.Time
+}
+
+func (o *PriceOverride) Validate() error {
+	if !o.Entity.Overridable() {
+		return ErrInvalidEntity
+	}
+	if !currencyRe.MatchString(string(o.Price.Currency)) {
+		return ErrInvalidCurrency
+	}
+	if o.Price.Currency == Base {
+		return ErrBaseCurrency
+	}
+	if !o.Price.IsPositive() {
+		return ErrInvalidPrice
+	}
+	if !o.Price.Amount.Equal(o.Price.Amount.Round(2)) {
+		return ErrInvalidPrice
+	}
+	return nil
+}
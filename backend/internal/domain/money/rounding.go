@@ -0,0 +1,88 @@
+package money
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// RoundingMode — способ округления до шага.
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"
+	RoundHalfEven RoundingMode = "half_even"
+	RoundUp       RoundingMode = "up"
+	RoundDown     RoundingMode = "down"
+)
+
+// Rounding округляет сумму до кратного Step (0.01 — до копеек, 10 — до десятков).
+type Rounding struct {
+	Mode RoundingMode
+	Step decimal.Decimal
+}
+
+// DefaultRounding — математическое округление до сотых.
+var DefaultRounding = Rounding{Mode: RoundHalfUp, Step: decimal.New(1, -2)}
+
+// ParseRounding собирает правило из конфигурации; пустые значения
+// берутся из DefaultRounding.
+func ParseRounding(mode, step string) (Rounding, error) {
+	r := DefaultRounding
+	if mode != "" {
+		r.Mode = RoundingMode(mode)
+	}
+	if step != "" {
+		s, err := decimal.NewFromString(step)
+		if err != nil {
+			return Rounding{}, ErrInvalidRounding
+		}
+		r.Step = s
+	}
+	return r, r.Validate()
+}
+
+func (r Rounding) Validate() error {
+	switch r.Mode {
+	case RoundHalfUp, RoundHalfEven, RoundUp, RoundDown:
+	default:
+		return ErrInvalidRounding
+	}
+	if !r.Step.IsPositive() {
+		return ErrInvalidRounding
+	}
+	return nil
+}
+
+func (r Rounding) Apply(d decimal.Decimal) decimal.Decimal {
+	step := r.Step
+	if !step.IsPositive() {
+		step = DefaultRounding.Step
+	}
+	q := d.Div(step)
+	switch r.Mode {
+	case RoundHalfEven:
+		q = q.RoundBank(0)
+	case RoundUp:
+		q = q.RoundCeil(0)
+	case RoundDown:
+		q = q.RoundFloor(0)
+	default:
+		q = q.Round(0)
+	}
+	return q.Mul(step)
+}
+
+// RoundingRules — правила округления по валютам.
+type RoundingRules struct {
+	Default    Rounding
+	ByCurrency map[Currency]Rounding
+}
+
+func (rr RoundingRules) For(c Currency) Rounding {
+	if r, ok := rr.ByCurrency[c]; ok {
+		return r
+	}
+	if rr.Default.Mode == "" {
+		return DefaultRounding
+	}
+	return rr.Default
+}
@@ -0,0 +1,32 @@
+// Package patch описывает частичное обновление сущностей (PATCH): записываются
+// только изменённые поля, а запись проходит, только если сущность не менялась
+// с момента чтения (оптимистичная блокировка по version).
+package patch
+
+// Field — поле частичного обновления. Set — поле изменилось и его нужно
+// записать, в том числе нулевым значением (например, очистить описание).
+type Field[T any] struct {
+	Set   bool
+	Value T
+}
+
+// To — поле, которое нужно записать значением v.
+func To[T any](v T) Field[T] {
+	return Field[T]{Set: true, Value: v}
+}
+
+// Diff возвращает To(after), если значение изменилось, иначе пустое поле.
+func Diff[T comparable](before, after T) Field[T] {
+	if before == after {
+		return Field[T]{}
+	}
+	return To(after)
+}
+
+// DiffPtr — Diff для необязательных полей: сравниваются значения, а не указатели.
+func DiffPtr[T comparable](before, after *T) Field[*T] {
+	if (before == nil && after == nil) || (before != nil && after != nil && *before == *after) {
+		return Field[*T]{}
+	}
+	return To(after)
+}
@@ -0,0 +1,123 @@
+package preset
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/shopspring/decimal"
+)
+
+// Choice — выбор клиента для одного слота. Нулевой ProductID оставляет
+// товар по умолчанию, Omit убирает необязательный слот из комплекта.
+type Choice struct {
+	SlotID    int64
+	ProductID int64
+	Omit      bool
+}
+
+// Configuration — состав пресета после выбора в конфигураторе.
+type Configuration struct {
+	PresetID   int64
+	Items      []ConfiguredItem
+	TotalPrice money.Money
+}
+
+type ConfiguredItem struct {
+	SlotID   int64
+	SlotName *string
+	Product  *product.ProductSummary
+	Quantity float64
+	// Replaced — клиент заменил товар по умолчанию.
+	Replaced bool
+}
+
+// Configure собирает комплект по выбору клиента. Слоты без выбора остаются
+// с товаром по умолчанию. Цена считается от TotalPrice пресета: замена
+// добавляет разницу цен товаров, убранный слот вычитает цену своего товара,
+// так что скидка на комплект сохраняется. Цены берутся из Product уже в
+// валюте и со скидками запроса.
+func (p *Preset) Configure(choices []Choice) (*Configuration, error) {
+	if p.IsTemplate {
+		return nil, ErrNotConfigurable
+	}
+	slots := make(map[int64]bool, len(p.Items))
+	for _, it := range p.Items {
+		slots[it.ID] = true
+	}
+	bySlot := make(map[int64]Choice, len(choices))
+	for _, c := range choices {
+		if !slots[c.SlotID] {
+			return nil, ErrUnknownSlot
+		}
+		if _, dup := bySlot[c.SlotID]; dup {
+			return nil, ErrDuplicateChoice
+		}
+		bySlot[c.SlotID] = c
+	}
+
+	res := &Configuration{PresetID: p.ID, Items: make([]ConfiguredItem, 0, len(p.Items))}
+	total := p.TotalPrice
+	for _, it := range p.Items {
+		if it.Product == nil {
+			return nil, ErrNilProductSummary
+		}
+		qty := decimal.NewFromFloat(it.Qty())
+		item := ConfiguredItem{SlotID: it.ID, SlotName: it.SlotName, Product: it.Product, Quantity: it.Qty()}
+
+		c := bySlot[it.ID]
+		switch {
+		case c.Omit:
+			if !it.Optional {
+				return nil, ErrSlotNotOptional
+			}
+			if err := addLine(&total, it.Product.Price, qty.Neg()); err != nil {
+				return nil, err
+			}
+			continue
+		case c.ProductID != 0 && c.ProductID != it.ProductID:
+			alt, ok := it.alternative(c.ProductID)
+			if !ok {
+				return nil, ErrProductNotAllowed
+			}
+			if alt.Product == nil {
+				return nil, ErrNilProductSummary
+			}
+			if err := addLine(&total, alt.Product.Price, qty); err != nil {
+				return nil, err
+			}
+			if err := addLine(&total, it.Product.Price, qty.Neg()); err != nil {
+				return nil, err
+			}
+			item.Product = alt.Product
+			item.Replaced = true
+		}
+		res.Items = append(res.Items, item)
+	}
+	if len(res.Items) == 0 {
+		return nil, ErrNoItems
+	}
+	// скидка на комплект может оказаться больше оставшихся позиций
+	if total.Amount.IsNegative() {
+		total = money.Zero(total.Currency)
+	}
+	res.TotalPrice = total.Round(money.DefaultRounding)
+	return res, nil
+}
+
+func (it PresetItem) alternative(productID int64) (Alternative, bool) {
+	for _, a := range it.Alternatives {
+		if a.ProductID == productID {
+			return a, true
+		}
+	}
+	return Alternative{}, false
+}
+
+// addLine прибавляет к total цену price за qty единиц; отрицательное qty вычитает.
+func addLine(total *money.Money, price money.Money, qty decimal.Decimal) error {
+	sum, err := total.Add(price.Mul(qty))
+	if err != nil {
+		return err
+	}
+	*total = sum
+	return nil
+}
@@ -13,7 +13,26 @@ var (
 )
 
 var (
-	ErrItemNotFound      = errors.New("preset item not found")
-	ErrInvalidProductID  = errors.New("invalid product ID in preset item")
-	ErrNilProductSummary = errors.New("product summary must not be nil")
+	ErrItemNotFound       = errors.New("preset item not found")
+	ErrInvalidProductID   = errors.New("invalid product ID in preset item")
+	ErrNilProductSummary  = errors.New("product summary must not be nil")
+	ErrInvalidQuantity    = errors.New("preset item quantity must be positive")
+	ErrSlotNameTooLong    = errors.New("preset slot name is too long")
+	ErrInvalidAlternative = errors.New("alternative must differ from the default product and from other alternatives")
+)
+
+var (
+	ErrNotTemplate       = errors.New("preset is not a template")
+	ErrFormulaNotAllowed = errors.New("quantity formula is allowed only in templates")
+	ErrInvalidFormula    = errors.New("invalid quantity formula")
+	ErrUnknownVariable   = errors.New("unknown variable in quantity formula")
+	ErrInvalidRoomParams = errors.New("invalid room parameters")
+)
+
+var (
+	ErrNotConfigurable   = errors.New("template must be instantiated before configuring")
+	ErrUnknownSlot       = errors.New("unknown preset slot")
+	ErrDuplicateChoice   = errors.New("preset slot is chosen more than once")
+	ErrSlotNotOptional   = errors.New("preset slot is not optional")
+	ErrProductNotAllowed = errors.New("product is not allowed in preset slot")
 )
@@ -0,0 +1,301 @@
+package preset
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Formula — арифметическое выражение количества позиции шаблона
+// от параметров помещения, например "ceil(floor_area / 1.44 * 1.1)".
+//
+// Поддерживаются числа, переменные из RoomParams.Vars, операторы + - * /,
+// скобки и функции ceil, floor, round, min, max.
+type Formula struct {
+	src  string
+	root formulaNode
+}
+
+type formulaNode interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+// ParseFormula разбирает выражение и проверяет, что все переменные известны.
+func ParseFormula(src string) (*Formula, error) {
+	p := &formulaParser{src: src}
+	p.next()
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidFormula, p.tok.text, p.tok.pos)
+	}
+	return &Formula{src: src, root: root}, nil
+}
+
+func (f *Formula) String() string { return f.src }
+
+// Eval вычисляет значение формулы для заданных переменных.
+func (f *Formula) Eval(vars map[string]float64) (float64, error) {
+	v, err := f.root.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%w: result is not a finite number", ErrInvalidFormula)
+	}
+	return v, nil
+}
+
+type numNode float64
+
+func (n numNode) eval(map[string]float64) (float64, error) { return float64(n), nil }
+
+type varNode string
+
+func (n varNode) eval(vars map[string]float64) (float64, error) {
+	v, ok := vars[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownVariable, string(n))
+	}
+	return v, nil
+}
+
+type unaryNode struct {
+	x formulaNode
+}
+
+func (n unaryNode) eval(vars map[string]float64) (float64, error) {
+	v, err := n.x.eval(vars)
+	return -v, err
+}
+
+type binaryNode struct {
+	op   byte
+	l, r formulaNode
+}
+
+func (n binaryNode) eval(vars map[string]float64) (float64, error) {
+	l, err := n.l.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	r, err := n.r.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	default:
+		if r == 0 {
+			return 0, fmt.Errorf("%w: division by zero", ErrInvalidFormula)
+		}
+		return l / r, nil
+	}
+}
+
+type callNode struct {
+	fn   string
+	args []formulaNode
+}
+
+var formulaFuncs = map[string]struct {
+	arity int
+	fn    func(a []float64) float64
+}{
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"min":   {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":   {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+func (n callNode) eval(vars map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+	return formulaFuncs[n.fn].fn(args), nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+type formulaParser struct {
+	src string
+	pos int
+	tok token
+}
+
+func (p *formulaParser) next() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+	c := rune(p.src[p.pos])
+	switch {
+	case unicode.IsDigit(c) || c == '.':
+		for p.pos < len(p.src) && (unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		p.tok = token{kind: tokNum, text: p.src[start:p.pos], pos: start}
+	case unicode.IsLetter(c) || c == '_':
+		for p.pos < len(p.src) && (unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '_') {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+	default:
+		p.pos++
+		p.tok = token{kind: tokOp, text: p.src[start:p.pos], pos: start}
+	}
+}
+
+func (p *formulaParser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("%w: unexpected end of expression", ErrInvalidFormula)
+	}
+	return fmt.Errorf("%w: unexpected %q at %d", ErrInvalidFormula, p.tok.text, p.tok.pos)
+}
+
+// expr := term { (+|-) term }
+func (p *formulaParser) parseExpr() (formulaNode, error) {
+	l, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text[0]
+		p.next()
+		r, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryNode{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+// term := unary { (*|/) unary }
+func (p *formulaParser) parseTerm() (formulaNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "*" || p.tok.text == "/") {
+		op := p.tok.text[0]
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryNode{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+// unary := [-] primary
+func (p *formulaParser) parseUnary() (formulaNode, error) {
+	if p.tok.kind == tokOp && p.tok.text == "-" {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+// primary := number | ident | ident "(" args ")" | "(" expr ")"
+func (p *formulaParser) parsePrimary() (formulaNode, error) {
+	switch p.tok.kind {
+	case tokNum:
+		v, err := strconv.ParseFloat(p.tok.text, 64)
+		if err != nil {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return numNode(v), nil
+	case tokIdent:
+		name := strings.ToLower(p.tok.text)
+		p.next()
+		if p.tok.kind == tokOp && p.tok.text == "(" {
+			return p.parseCall(name)
+		}
+		if !isRoomVar(name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownVariable, name)
+		}
+		return varNode(name), nil
+	case tokOp:
+		if p.tok.text == "(" {
+			p.next()
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokOp || p.tok.text != ")" {
+				return nil, p.unexpected()
+			}
+			p.next()
+			return x, nil
+		}
+	}
+	return nil, p.unexpected()
+}
+
+func (p *formulaParser) parseCall(name string) (formulaNode, error) {
+	f, ok := formulaFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %s", ErrInvalidFormula, name)
+	}
+	p.next() // "("
+	var args []formulaNode
+	for {
+		a, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+		if p.tok.kind == tokOp && p.tok.text == "," {
+			p.next()
+			continue
+		}
+		break
+	}
+	if p.tok.kind != tokOp || p.tok.text != ")" {
+		return nil, p.unexpected()
+	}
+	p.next()
+	if len(args) != f.arity {
+		return nil, fmt.Errorf("%w: %s expects %d argument(s)", ErrInvalidFormula, name, f.arity)
+	}
+	return callNode{fn: name, args: args}, nil
+}
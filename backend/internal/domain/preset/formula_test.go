@@ -0,0 +1,73 @@
+package preset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormula(t *testing.T) {
+	room := RoomParams{Length: 4, Width: 2.5, Height: 2.7, OpeningsArea: 1.6}
+
+	for _, tc := range []struct {
+		name     string
+		src      string
+		want     float64
+		parseErr error
+		evalErr  error
+	}{
+		{name: "number", src: "42", want: 42},
+		{name: "precedence", src: "2 + 3 * 4", want: 14},
+		{name: "left associative", src: "10 - 4 - 3", want: 3},
+		{name: "division before subtraction", src: "8 - 6 / 2", want: 5},
+		{name: "parentheses", src: "(2 + 3) * 4", want: 20},
+		{name: "unary minus", src: "-3 + 5", want: 2},
+		{name: "double unary minus", src: "--3", want: 3},
+		{name: "unary minus binds tighter than product", src: "-2 * -3", want: 6},
+		{name: "variables", src: "length * width", want: 10},
+		{name: "variables are case insensitive", src: "FLOOR_AREA", want: 10},
+		{name: "functions", src: "ceil(floor_area / 1.44 * 1.1)", want: 8},
+		{name: "min and max", src: "max(min(length, width), 3)", want: 3},
+		{name: "round", src: "round(2.5) + floor(1.9)", want: 4},
+		{name: "whitespace", src: "  ceil( 1.2 )  ", want: 2},
+
+		{name: "unknown variable", src: "area * 2", parseErr: ErrUnknownVariable},
+		{name: "unknown function", src: "sqrt(4)", parseErr: ErrInvalidFormula},
+		{name: "wrong arity", src: "min(1)", parseErr: ErrInvalidFormula},
+		{name: "empty", src: "", parseErr: ErrInvalidFormula},
+		{name: "dangling operator", src: "1 +", parseErr: ErrInvalidFormula},
+		{name: "unclosed parenthesis", src: "(1 + 2", parseErr: ErrInvalidFormula},
+		{name: "extra parenthesis", src: "1 + 2)", parseErr: ErrInvalidFormula},
+		{name: "two operators", src: "1 * / 2", parseErr: ErrInvalidFormula},
+		{name: "malformed number", src: "1.2.3", parseErr: ErrInvalidFormula},
+		{name: "unknown symbol", src: "2 ^ 3", parseErr: ErrInvalidFormula},
+
+		{name: "division by zero", src: "length / (width - 2.5)", evalErr: ErrInvalidFormula},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseFormula(tc.src)
+			if tc.parseErr != nil {
+				assert.ErrorIs(t, err, tc.parseErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.src, f.String())
+
+			got, err := f.Eval(room.Vars())
+			if tc.evalErr != nil {
+				assert.ErrorIs(t, err, tc.evalErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+
+	t.Run("variable missing at evaluation", func(t *testing.T) {
+		f, err := ParseFormula("wall_area / 2")
+		require.NoError(t, err)
+		_, err = f.Eval(map[string]float64{VarLength: 1})
+		assert.ErrorIs(t, err, ErrUnknownVariable)
+	})
+}
@@ -0,0 +1,23 @@
+package preset
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
+)
+
+// Patch — частичное обновление пресета. Items, если заданы, перезаписываются целиком.
+type Patch struct {
+	ID          int64
+	Version     int64
+	Name        patch.Field[string]
+	Description patch.Field[*string]
+	TotalPrice  patch.Field[money.Money]
+	ImageURL    patch.Field[*string]
+	IsTemplate  patch.Field[bool]
+	Items       patch.Field[[]PresetItem]
+}
+
+func (p *Patch) Empty() bool {
+	return !p.Name.Set && !p.Description.Set && !p.TotalPrice.Set && !p.ImageURL.Set &&
+		!p.IsTemplate.Set && !p.Items.Set
+}
@@ -3,7 +3,10 @@ package preset
 import (
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	"github.com/Neimess/zorkin-store-project/internal/domain/product"
 )
 
@@ -11,32 +14,65 @@ type Preset struct {
 	ID          int64
 	Name        string
 	Description *string
-	TotalPrice  float64
-	ImageURL    *string
-	CreatedAt   time.Time
-	Items       []PresetItem
+	TotalPrice  money.Money
+	// Sale — скидка на пресет целиком, заполняется только при чтении каталога.
+	Sale      *discount.Sale
+	ImageURL  *string
+	CreatedAt time.Time
+	Version   int64
+	// IsTemplate — параметрический шаблон, количества позиций которого
+	// задаются формулами от размеров помещения (см. Instantiate).
+	IsTemplate bool
+	Items      []PresetItem
 }
 
+// PresetItem — слот пресета: товар по умолчанию и товары, которыми клиент
+// может его заменить в конфигураторе (см. Configure).
 type PresetItem struct {
 	ID        int64
 	PresetID  int64
 	ProductID int64
 	Product   *product.ProductSummary
+	Quantity  float64
+	// QuantityFormula — формула количества, только для шаблонов.
+	QuantityFormula *string
+	// SlotName — подпись слота в конфигураторе («Раковина», «Смеситель»).
+	SlotName *string
+	// Optional — слот можно убрать из комплекта.
+	Optional     bool
+	Alternatives []Alternative
 }
 
+// Alternative — допустимая замена товара по умолчанию в слоте.
+type Alternative struct {
+	ProductID int64
+	Product   *product.ProductSummary
+}
+
+// MaxNameLength — длина имени пресета в символах (VARCHAR(100) в БД).
+const MaxNameLength = 100
+
+// MaxSlotNameLength — длина подписи слота в символах (slot_name VARCHAR(100) в БД).
+const MaxSlotNameLength = 100
+
 func (p *Preset) Validate() error {
 	name := strings.TrimSpace(p.Name)
 	if name == "" {
 		return ErrEmptyName
 	}
-	if len(name) > 100 {
+	if utf8.RuneCountInString(name) > MaxNameLength {
 		return ErrNameTooLong
 	}
-	if len(*p.Description) > 500 {
+	if p.Description != nil && len(*p.Description) > 500 {
 		return ErrDescriptionTooLong
 	}
 	if len(p.Items) == 0 {
 		return ErrNoItems
 	}
+	for _, it := range p.Items {
+		if err := it.validate(p.IsTemplate); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,51 @@
+package preset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresetValidateNameLength(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		preset  string
+		wantErr error
+	}{
+		{name: "ascii at limit", preset: strings.Repeat("a", MaxNameLength)},
+		{name: "cyrillic at limit", preset: strings.Repeat("ж", MaxNameLength)},
+		{name: "over limit", preset: strings.Repeat("ж", MaxNameLength+1), wantErr: ErrNameTooLong},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Preset{Name: tc.preset, Items: []PresetItem{{ProductID: 1, Quantity: 1}}}
+			err := p.Validate()
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPresetValidateSlotNameLength(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		slotName string
+		wantErr  error
+	}{
+		{name: "cyrillic at limit", slotName: strings.Repeat("ж", MaxSlotNameLength)},
+		{name: "over limit", slotName: strings.Repeat("ж", MaxSlotNameLength+1), wantErr: ErrSlotNameTooLong},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Preset{Name: "Ванная", Items: []PresetItem{{ProductID: 1, Quantity: 1, SlotName: &tc.slotName}}}
+			err := p.Validate()
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
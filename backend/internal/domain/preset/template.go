@@ -0,0 +1,206 @@
+package preset
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/shopspring/decimal"
+)
+
+// RoomParams — размеры помещения в метрах, по которым разворачивается шаблон.
+type RoomParams struct {
+	Length float64
+	Width  float64
+	Height float64
+	// OpeningsArea — площадь дверей и окон, вычитается из площади стен.
+	OpeningsArea float64
+}
+
+const (
+	VarLength       = "length"
+	VarWidth        = "width"
+	VarHeight       = "height"
+	VarPerimeter    = "perimeter"
+	VarFloorArea    = "floor_area"
+	VarWallArea     = "wall_area"
+	VarVolume       = "volume"
+	VarOpeningsArea = "openings_area"
+)
+
+func isRoomVar(name string) bool {
+	switch name {
+	case VarLength, VarWidth, VarHeight, VarPerimeter, VarFloorArea, VarWallArea, VarVolume, VarOpeningsArea:
+		return true
+	}
+	return false
+}
+
+func (r RoomParams) Validate() error {
+	if r.Length <= 0 || r.Width <= 0 || r.Height <= 0 || r.OpeningsArea < 0 {
+		return ErrInvalidRoomParams
+	}
+	if r.OpeningsArea >= r.Perimeter()*r.Height {
+		return ErrInvalidRoomParams
+	}
+	return nil
+}
+
+func (r RoomParams) Perimeter() float64 { return 2 * (r.Length + r.Width) }
+
+func (r RoomParams) FloorArea() float64 { return r.Length * r.Width }
+
+func (r RoomParams) WallArea() float64 { return r.Perimeter()*r.Height - r.OpeningsArea }
+
+// Vars возвращает значения переменных, доступных в формулах.
+func (r RoomParams) Vars() map[string]float64 {
+	return map[string]float64{
+		VarLength:       r.Length,
+		VarWidth:        r.Width,
+		VarHeight:       r.Height,
+		VarPerimeter:    r.Perimeter(),
+		VarFloorArea:    r.FloorArea(),
+		VarWallArea:     r.WallArea(),
+		VarVolume:       r.FloorArea() * r.Height,
+		VarOpeningsArea: r.OpeningsArea,
+	}
+}
+
+// Clone делает глубокую копию пресета без идентификаторов.
+func (p *Preset) Clone() *Preset {
+	cp := &Preset{
+		Name:       p.Name,
+		TotalPrice: p.TotalPrice,
+		IsTemplate: p.IsTemplate,
+	}
+	if p.Description != nil {
+		d := *p.Description
+		cp.Description = &d
+	}
+	if p.ImageURL != nil {
+		u := *p.ImageURL
+		cp.ImageURL = &u
+	}
+	cp.Items = make([]PresetItem, len(p.Items))
+	for i, it := range p.Items {
+		cp.Items[i] = PresetItem{
+			ProductID:    it.ProductID,
+			Product:      it.Product,
+			Quantity:     it.Quantity,
+			Optional:     it.Optional,
+			Alternatives: slices.Clone(it.Alternatives),
+		}
+		if it.QuantityFormula != nil {
+			f := *it.QuantityFormula
+			cp.Items[i].QuantityFormula = &f
+		}
+		if it.SlotName != nil {
+			n := *it.SlotName
+			cp.Items[i].SlotName = &n
+		}
+	}
+	return cp
+}
+
+// Instantiate разворачивает шаблон в обычный пресет: количество каждой
+// позиции вычисляется по формуле, итоговая цена — по ценам товаров.
+// Позиции с нулевым количеством отбрасываются.
+func (p *Preset) Instantiate(room RoomParams) (*Preset, error) {
+	if !p.IsTemplate {
+		return nil, ErrNotTemplate
+	}
+	if err := room.Validate(); err != nil {
+		return nil, err
+	}
+	vars := room.Vars()
+
+	res := p.Clone()
+	res.IsTemplate = false
+	res.Items = res.Items[:0]
+	lines := make([]money.Money, 0, len(p.Items))
+	for _, it := range p.Items {
+		qty := it.Qty()
+		if it.QuantityFormula != nil {
+			f, err := ParseFormula(*it.QuantityFormula)
+			if err != nil {
+				return nil, err
+			}
+			if qty, err = f.Eval(vars); err != nil {
+				return nil, err
+			}
+		}
+		qty = roundQuantity(qty)
+		if qty < 0 {
+			return nil, ErrInvalidQuantity
+		}
+		if qty == 0 {
+			continue
+		}
+		if it.Product == nil {
+			return nil, ErrNilProductSummary
+		}
+		res.Items = append(res.Items, PresetItem{
+			ProductID:    it.ProductID,
+			Product:      it.Product,
+			Quantity:     qty,
+			SlotName:     it.SlotName,
+			Optional:     it.Optional,
+			Alternatives: it.Alternatives,
+		})
+		lines = append(lines, it.Product.Price.Mul(decimal.NewFromFloat(qty)))
+	}
+	if len(res.Items) == 0 {
+		return nil, ErrNoItems
+	}
+	total, err := money.Sum(lines)
+	if err != nil {
+		return nil, err
+	}
+	res.TotalPrice = total.Round(money.DefaultRounding)
+	return res, nil
+}
+
+func roundQuantity(q float64) float64 {
+	return math.Round(q*100) / 100
+}
+
+// Qty возвращает количество позиции; незаданное количество считается равным 1.
+func (it PresetItem) Qty() float64 {
+	if it.Quantity == 0 {
+		return 1
+	}
+	return it.Quantity
+}
+
+func (it PresetItem) validate(isTemplate bool) error {
+	if it.Quantity < 0 {
+		return ErrInvalidQuantity
+	}
+	if it.SlotName != nil && utf8.RuneCountInString(*it.SlotName) > MaxSlotNameLength {
+		return ErrSlotNameTooLong
+	}
+	seen := make(map[int64]bool, len(it.Alternatives))
+	for _, a := range it.Alternatives {
+		if a.ProductID <= 0 {
+			return ErrInvalidProductID
+		}
+		if a.ProductID == it.ProductID || seen[a.ProductID] {
+			return ErrInvalidAlternative
+		}
+		seen[a.ProductID] = true
+	}
+	if it.QuantityFormula != nil {
+		if !isTemplate {
+			return ErrFormulaNotAllowed
+		}
+		if strings.TrimSpace(*it.QuantityFormula) == "" {
+			return ErrInvalidFormula
+		}
+		if _, err := ParseFormula(*it.QuantityFormula); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,4 +7,13 @@ var (
 	ErrBadCategoryID    = errors.New("invalid category ID")
 	ErrInvalidAttribute = errors.New("invalid product attribute data")
 	ErrBadServiceID     = errors.New("invalid service ID")
+	ErrInvalidSort      = errors.New("invalid sort order")
+)
+
+var (
+	ErrRelationNotFound      = errors.New("product relation not found")
+	ErrRelationAlreadyExists = errors.New("product relation already exists")
+	ErrInvalidRelationType   = errors.New("invalid product relation type")
+	ErrSelfRelation          = errors.New("product cannot be related to itself")
+	ErrBadRelatedProduct     = errors.New("invalid related product ID")
 )
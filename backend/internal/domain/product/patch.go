@@ -0,0 +1,27 @@
+package product
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+)
+
+// Patch — частичное обновление товара. Version — версия, прочитанная перед
+// правкой. Attributes и Services, если заданы, перезаписываются целиком.
+type Patch struct {
+	ID          int64
+	Version     int64
+	Name        patch.Field[string]
+	Price       patch.Field[money.Money]
+	Description patch.Field[*string]
+	CategoryID  patch.Field[int64]
+	ImageURL    patch.Field[*string]
+	Attributes  patch.Field[[]ProductAttribute]
+	Services    patch.Field[[]serviceDom.Service]
+}
+
+// Empty — в патче нет ни одного изменения.
+func (p *Patch) Empty() bool {
+	return !p.Name.Set && !p.Price.Set && !p.Description.Set && !p.CategoryID.Set &&
+		!p.ImageURL.Set && !p.Attributes.Set && !p.Services.Set
+}
@@ -3,24 +3,60 @@ package product
 import (
 	"time"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/shopspring/decimal"
 )
 
 type Product struct {
-	ID          int64
-	Name        string
-	Price       float64
+	ID    int64
+	Name  string
+	Price money.Money
+	// Sale — действующая скидка, заполняется только при чтении каталога.
+	Sale        *discount.Sale
 	Description *string
 	CategoryID  int64
 	ImageURL    *string
-	CreatedAt   time.Time
-	Attributes  []ProductAttribute
-	Services    []serviceDom.Service
+	// Stock — остаток из учётной системы (обмен с 1С), nil — не ведётся.
+	Stock      *decimal.Decimal
+	CreatedAt  time.Time
+	Version    int64
+	Attributes []ProductAttribute
+	Services   []serviceDom.Service
+	Relations  []ProductRelation
+	Rating     RatingSummary
 }
 
 type ProductSummary struct {
 	ID       int64
 	Name     string
-	Price    float64
+	Price    money.Money
+	Sale     *discount.Sale
 	ImageURL *string
 }
+
+// RatingSummary — агрегат по одобренным отзывам товара.
+type RatingSummary struct {
+	Average float64
+	Count   int64
+}
+
+// SortOrder задаёт порядок товаров в каталоге.
+type SortOrder string
+
+const (
+	SortDefault    SortOrder = ""
+	SortRatingDesc SortOrder = "rating"
+	SortPriceAsc   SortOrder = "price"
+	SortPriceDesc  SortOrder = "-price"
+	SortNewest     SortOrder = "newest"
+)
+
+func (s SortOrder) Valid() bool {
+	switch s {
+	case SortDefault, SortRatingDesc, SortPriceAsc, SortPriceDesc, SortNewest:
+		return true
+	}
+	return false
+}
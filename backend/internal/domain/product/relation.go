@@ -0,0 +1,63 @@
+package product
+
+import "time"
+
+// RelationType — тип связи между товарами.
+type RelationType string
+
+const (
+	// RelationAccessory — сопутствующий товар (затирка, клей, профиль к плитке).
+	RelationAccessory RelationType = "accessory"
+	// RelationAlternative — взаимозаменяемый товар.
+	RelationAlternative RelationType = "alternative"
+	// RelationCompatibleWith — товары, которые можно использовать вместе.
+	RelationCompatibleWith RelationType = "compatible_with"
+	// RelationRequired — товар, без которого основной не установить.
+	RelationRequired RelationType = "required"
+)
+
+var relationTypes = []RelationType{RelationAccessory, RelationAlternative, RelationCompatibleWith, RelationRequired}
+
+// RelationTypes возвращает все допустимые типы связей.
+func RelationTypes() []RelationType {
+	out := make([]RelationType, len(relationTypes))
+	copy(out, relationTypes)
+	return out
+}
+
+func (t RelationType) Valid() bool {
+	for _, rt := range relationTypes {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// Symmetric сообщает, действует ли связь в обе стороны:
+// если A — альтернатива B, то и B — альтернатива A.
+func (t RelationType) Symmetric() bool {
+	return t == RelationAlternative || t == RelationCompatibleWith
+}
+
+type ProductRelation struct {
+	ID        int64
+	ProductID int64
+	RelatedID int64
+	Type      RelationType
+	Related   *ProductSummary
+	CreatedAt time.Time
+}
+
+func (r *ProductRelation) Validate() error {
+	if r.ProductID <= 0 || r.RelatedID <= 0 {
+		return ErrBadRelatedProduct
+	}
+	if r.ProductID == r.RelatedID {
+		return ErrSelfRelation
+	}
+	if !r.Type.Valid() {
+		return ErrInvalidRelationType
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package review
+
+import "errors"
+
+var (
+	ErrReviewNotFound   = errors.New("review not found")
+	ErrInvalidRating    = errors.New("rating must be between 1 and 5")
+	ErrEmptyAuthor      = errors.New("review author must not be empty")
+	ErrAuthorTooLong    = errors.New("review author name is too long")
+	ErrTextTooLong      = errors.New("review text is too long")
+	ErrTooManyPhotos    = errors.New("too many photos in review")
+	ErrInvalidStatus    = errors.New("invalid review status")
+	ErrAlreadyModerated = errors.New("review has already been moderated")
+	ErrProductNotFound  = errors.New("reviewed product not found")
+	ErrReasonTooLong    = errors.New("rejection reason is too long")
+)
@@ -0,0 +1,85 @@
+package review
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	MinRating       = 1
+	MaxRating       = 5
+	MaxAuthorLength = 100
+	MaxTextLength   = 5000
+	MaxPhotos       = 5
+	MaxReasonLength = 500
+)
+
+// Status — состояние отзыва в очереди модерации.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected:
+		return true
+	}
+	return false
+}
+
+type Review struct {
+	ID         int64
+	ProductID  int64
+	AuthorName string
+	Rating     int
+	Text       *string
+	Photos     []string
+	Status     Status
+	// RejectReason заполняется модератором при отклонении.
+	RejectReason *string
+	CreatedAt    time.Time
+	ModeratedAt  *time.Time
+}
+
+func (r *Review) Validate() error {
+	author := strings.TrimSpace(r.AuthorName)
+	if author == "" {
+		return ErrEmptyAuthor
+	}
+	if len([]rune(author)) > MaxAuthorLength {
+		return ErrAuthorTooLong
+	}
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		return ErrInvalidRating
+	}
+	if r.Text != nil && len([]rune(*r.Text)) > MaxTextLength {
+		return ErrTextTooLong
+	}
+	if len(r.Photos) > MaxPhotos {
+		return ErrTooManyPhotos
+	}
+	return nil
+}
+
+// Moderate переводит отзыв из очереди в approved/rejected.
+func (r *Review) Moderate(status Status, reason *string, at time.Time) error {
+	if status != StatusApproved && status != StatusRejected {
+		return ErrInvalidStatus
+	}
+	if r.Status != StatusPending {
+		return ErrAlreadyModerated
+	}
+	if reason != nil && len([]rune(*reason)) > MaxReasonLength {
+		return ErrReasonTooLong
+	}
+	r.Status = status
+	r.ModeratedAt = &at
+	if status == StatusRejected {
+		r.RejectReason = reason
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
+)
+
+// Patch — частичное обновление услуги.
+type Patch struct {
+	ID          int64
+	Version     int64
+	Name        patch.Field[string]
+	Description patch.Field[*string]
+	Price       patch.Field[money.Money]
+}
+
+func (p *Patch) Empty() bool {
+	return !p.Name.Set && !p.Description.Set && !p.Price.Set
+}
@@ -1,8 +1,16 @@
 package service
 
+import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+)
+
 type Service struct {
 	ID          int64
 	Name        string
 	Description *string
-	Price       float64
+	Price       money.Money
+	// Sale — действующая скидка, заполняется только при чтении каталога.
+	Sale    *discount.Sale
+	Version int64
 }
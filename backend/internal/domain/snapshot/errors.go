@@ -0,0 +1,12 @@
+package snapshot
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat  = errors.New("not a catalog snapshot")
+	ErrUnsupportedVersion = errors.New("unsupported catalog snapshot version")
+	ErrMalformed          = errors.New("malformed catalog snapshot")
+	ErrTruncated          = errors.New("catalog snapshot is incomplete")
+	ErrDanglingReference  = errors.New("snapshot record references a missing record")
+	ErrInvalidMode        = errors.New("restore mode must be append or replace")
+)
@@ -0,0 +1,189 @@
+// Package snapshot — переносимый снимок каталога: категории, атрибуты,
+// товары, услуги, пресеты и коэффициенты вместе со связями между ними.
+//
+// ID в снимке — ID исходной базы. При восстановлении строки получают новые
+// ID, а ссылки между записями пересчитываются, поэтому снимок можно залить
+// и в пустую, и в уже заполненную базу.
+package snapshot
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// Format — значение поля format в заголовке снимка.
+	Format = "zorkin-catalog"
+	// Version — версия формата записей; меняется при несовместимых изменениях.
+	Version = 1
+)
+
+// Header — первая запись снимка.
+type Header struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	// SchemaVersion — версия миграций исходной базы, справочно.
+	SchemaVersion uint      `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Kind — тип записи снимка. Записи идут в порядке Kinds: всё, на что
+// ссылается запись, встречается раньше неё.
+type Kind string
+
+const (
+	KindCategory         Kind = "category"
+	KindAttribute        Kind = "attribute"
+	KindService          Kind = "service"
+	KindProduct          Kind = "product"
+	KindProductAttribute Kind = "product_attribute"
+	KindProductService   Kind = "product_service"
+	KindPreset           Kind = "preset"
+	KindPresetItem       Kind = "preset_item"
+	// KindPresetAlternative — замена в слоте пресета; слот определяется
+	// пресетом и товаром по умолчанию.
+	KindPresetAlternative Kind = "preset_alternative"
+	KindCoefficient       Kind = "coefficient"
+	// KindEnd — последняя запись с количеством записей каждого типа;
+	// без неё снимок считается обрезанным.
+	KindEnd Kind = "end"
+)
+
+var Kinds = []Kind{
+	KindCategory, KindAttribute, KindService, KindProduct, KindProductAttribute,
+	KindProductService, KindPreset, KindPresetItem, KindPresetAlternative, KindCoefficient,
+}
+
+// Record — запись снимка. Data — указатель на структуру, соответствующую
+// Kind: *Category, *Attribute, ... или *End.
+type Record struct {
+	Kind Kind
+	Data any
+}
+
+// Writer пишет снимок в конкретном формате; Close завершает снимок.
+type Writer interface {
+	Write(rec Record) error
+	Close() error
+}
+
+// Reader читает снимок: Next возвращает io.EOF после последней записи.
+type Reader interface {
+	Header() Header
+	Next() (Record, error)
+}
+
+// Counts — количество записей по типам.
+type Counts map[Kind]int
+
+// Category идёт после своего родителя.
+type Category struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id,omitempty"`
+}
+
+type Attribute struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Unit       *string `json:"unit,omitempty"`
+	CategoryID int64   `json:"category_id"`
+}
+
+type Service struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	Price       decimal.Decimal `json:"price"`
+}
+
+type Product struct {
+	ID          int64            `json:"id"`
+	Name        string           `json:"name"`
+	Price       *decimal.Decimal `json:"price,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	CategoryID  int64            `json:"category_id"`
+	ImageURL    *string          `json:"image_url,omitempty"`
+	Stock       *decimal.Decimal `json:"stock,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type ProductAttribute struct {
+	ProductID   int64  `json:"product_id"`
+	AttributeID int64  `json:"attribute_id"`
+	Value       string `json:"value"`
+}
+
+type ProductService struct {
+	ProductID int64 `json:"product_id"`
+	ServiceID int64 `json:"service_id"`
+}
+
+type Preset struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	TotalPrice  decimal.Decimal `json:"total_price"`
+	ImageURL    *string         `json:"image_url,omitempty"`
+	IsTemplate  bool            `json:"is_template"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type PresetItem struct {
+	PresetID        int64           `json:"preset_id"`
+	ProductID       int64           `json:"product_id"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	QuantityFormula *string         `json:"quantity_formula,omitempty"`
+	SlotName        *string         `json:"slot_name,omitempty"`
+	Optional        bool            `json:"optional,omitempty"`
+}
+
+type PresetAlternative struct {
+	PresetID      int64 `json:"preset_id"`
+	ProductID     int64 `json:"product_id"`
+	AlternativeID int64 `json:"alternative_id"`
+}
+
+type Coefficient struct {
+	ID    int64           `json:"id"`
+	Name  string          `json:"name"`
+	Value decimal.Decimal `json:"value"`
+}
+
+type End struct {
+	Counts Counts `json:"counts"`
+}
+
+// Mode — как восстановление обходится с каталогом, который уже есть в базе.
+type Mode string
+
+const (
+	// ModeAppend добавляет снимок к существующему каталогу. Категории
+	// и коэффициенты уникальны по имени: совпавшая категория переиспользуется
+	// вместе с одноимёнными атрибутами, у совпавшего коэффициента обновляется
+	// значение.
+	ModeAppend Mode = "append"
+	// ModeReplace сначала удаляет весь каталог, а с ним отзывы, связи товаров,
+	// переводы, скидки и внешние ID удалённых сущностей.
+	ModeReplace Mode = "replace"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case "":
+		return ModeAppend, nil
+	case ModeAppend, ModeReplace:
+		return m, nil
+	}
+	return "", ErrInvalidMode
+}
+
+// Result — итог восстановления: сколько записей каждого типа создано,
+// а для ModeAppend — сколько категорий, атрибутов и коэффициентов совпало
+// с имеющимися.
+type Result struct {
+	Mode    Mode
+	Created Counts
+	Matched Counts
+}
@@ -0,0 +1,15 @@
+package translation
+
+import "errors"
+
+var (
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrEntityNotFound      = errors.New("translated entity not found")
+	ErrInvalidEntity       = errors.New("invalid translatable entity")
+	ErrInvalidLocale       = errors.New("unsupported locale")
+	ErrDefaultLocale       = errors.New("default locale is stored in the entity itself")
+	ErrEmptyFields         = errors.New("no fields to translate")
+	ErrUnknownField        = errors.New("field is not translatable")
+	ErrEmptyValue          = errors.New("translation must not be empty")
+	ErrValueTooLong        = errors.New("translation is too long")
+)
@@ -0,0 +1,105 @@
+package translation
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// Entity — тип переводимой сущности каталога.
+type Entity string
+
+const (
+	EntityProduct   Entity = "product"
+	EntityCategory  Entity = "category"
+	EntityAttribute Entity = "attribute"
+	EntityPreset    Entity = "preset"
+	EntityService   Entity = "service"
+)
+
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldUnit        = "unit"
+
+	MaxValueLength = 5000
+)
+
+// fields — переводимые поля каждой сущности.
+var fields = map[Entity][]string{
+	EntityProduct:   {FieldName, FieldDescription},
+	EntityCategory:  {FieldName},
+	EntityAttribute: {FieldName, FieldUnit},
+	EntityPreset:    {FieldName, FieldDescription},
+	EntityService:   {FieldName, FieldDescription},
+}
+
+func (e Entity) Valid() bool {
+	_, ok := fields[e]
+	return ok
+}
+
+// Fields возвращает список переводимых полей сущности.
+func (e Entity) Fields() []string {
+	return fields[e]
+}
+
+func (e Entity) hasField(name string) bool {
+	for _, f := range fields[e] {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Translation — значения переводимых полей сущности на одной локали.
+// Язык по умолчанию хранится в самой сущности и здесь не допускается.
+type Translation struct {
+	Entity    Entity
+	EntityID  int64
+	Locale    i18n.Locale
+	Fields    map[string]string
+	UpdatedAt time.Time
+}
+
+func (t *Translation) Validate() error {
+	if !t.Entity.Valid() {
+		return ErrInvalidEntity
+	}
+	if err := ValidateLocale(t.Locale); err != nil {
+		return err
+	}
+	if len(t.Fields) == 0 {
+		return ErrEmptyFields
+	}
+	for name, value := range t.Fields {
+		if !t.Entity.hasField(name) {
+			return ErrUnknownField
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return ErrEmptyValue
+		}
+		if utf8.RuneCountInString(value) > MaxValueLength {
+			return ErrValueTooLong
+		}
+		t.Fields[name] = value
+	}
+	return nil
+}
+
+// ValidateLocale проверяет, что локаль поддерживается и не является языком по умолчанию.
+func ValidateLocale(loc i18n.Locale) error {
+	if loc == i18n.Default {
+		return ErrDefaultLocale
+	}
+	for _, l := range i18n.Supported {
+		if l == loc {
+			return nil
+		}
+	}
+	return ErrInvalidLocale
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// DeliveryStatus — состояние доставки события одному подписчику.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed — попытки исчерпаны; доставку можно повторить вручную.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery — журнал доставки события подписчику. Attempts учитывает
+// и текущую попытку: счётчик растёт, когда диспетчер берёт доставку в работу.
+type Delivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	EventType      EventType
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Job — доставка, взятая диспетчером в работу, вместе с адресом и событием.
+type Job struct {
+	Delivery Delivery
+	URL      string
+	Secret   string
+	Event    Event
+}
+
+// Backoff возвращает паузу перед следующей попыткой после attempt неудачных:
+// base, 2·base, 4·base… но не больше limit.
+func Backoff(attempt int, base, limit time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := base
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= limit {
+			return limit
+		}
+	}
+	return min(d, limit)
+}
+
+// Sign возвращает подпись тела запроса: hex(HMAC-SHA256(secret, "<timestamp>.<body>")).
+// Получатель пересчитывает её по заголовкам X-Webhook-Timestamp и X-Webhook-Signature
+// и отклоняет запросы со старой меткой времени.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,12 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrInvalidURL           = errors.New("webhook url must be an absolute http(s) url")
+	ErrSecretTooShort       = errors.New("webhook secret must be at least 16 characters")
+	ErrNoEvents             = errors.New("webhook must subscribe to at least one event")
+	ErrUnknownEvent         = errors.New("unknown webhook event type")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	"github.com/Neimess/zorkin-store-project/internal/domain/product"
+)
+
+// Данные событий — поле data в теле запроса к подписчику.
+// Суммы передаются строками, чтобы не терять точность.
+
+type ProductData struct {
+	ProductID  int64           `json:"product_id"`
+	Name       string          `json:"name,omitempty"`
+	Price      decimal.Decimal `json:"price"`
+	Currency   money.Currency  `json:"currency"`
+	CategoryID int64           `json:"category_id,omitempty"`
+	ImageURL   *string         `json:"image_url,omitempty"`
+}
+
+func NewProductData(p *product.Product) ProductData {
+	return ProductData{
+		ProductID:  p.ID,
+		Name:       p.Name,
+		Price:      p.Price.Amount,
+		Currency:   p.Price.Currency,
+		CategoryID: p.CategoryID,
+		ImageURL:   p.ImageURL,
+	}
+}
+
+// DeletedData — данные событий *.deleted.
+type DeletedData struct {
+	ID int64 `json:"id"`
+}
+
+// PriceData — новая цена сущности. Price == nil означает, что ручная цена
+// в этой валюте удалена и цена снова считается по курсу.
+type PriceData struct {
+	Entity   money.Entity     `json:"entity"`
+	EntityID int64            `json:"entity_id"`
+	Currency money.Currency   `json:"currency"`
+	Price    *decimal.Decimal `json:"price"`
+	OldPrice *decimal.Decimal `json:"old_price,omitempty"`
+}
+
+type PresetData struct {
+	PresetID   int64            `json:"preset_id"`
+	Name       string           `json:"name"`
+	TotalPrice decimal.Decimal  `json:"total_price"`
+	Currency   money.Currency   `json:"currency"`
+	IsTemplate bool             `json:"is_template"`
+	Items      []PresetItemData `json:"items"`
+}
+
+type PresetItemData struct {
+	ProductID       int64   `json:"product_id"`
+	Quantity        float64 `json:"quantity"`
+	QuantityFormula *string `json:"quantity_formula,omitempty"`
+	SlotName        *string `json:"slot_name,omitempty"`
+	Optional        bool    `json:"optional,omitempty"`
+	// Alternatives — ID товаров, допустимых в слоте вместо ProductID.
+	Alternatives []int64 `json:"alternatives,omitempty"`
+}
+
+func NewPresetData(p *preset.Preset) PresetData {
+	items := make([]PresetItemData, len(p.Items))
+	for i, it := range p.Items {
+		items[i] = PresetItemData{
+			ProductID:       it.ProductID,
+			Quantity:        it.Qty(),
+			QuantityFormula: it.QuantityFormula,
+			SlotName:        it.SlotName,
+			Optional:        it.Optional,
+		}
+		for _, a := range it.Alternatives {
+			items[i].Alternatives = append(items[i].Alternatives, a.ProductID)
+		}
+	}
+	return PresetData{
+		PresetID:   p.ID,
+		Name:       p.Name,
+		TotalPrice: p.TotalPrice.Amount,
+		Currency:   p.TotalPrice.Currency,
+		IsTemplate: p.IsTemplate,
+		Items:      items,
+	}
+}
+
+// LeadData — заявка (заказ) покупателя.
+type LeadData struct {
+	LeadID      int64       `json:"lead_id"`
+	Kind        lead.Kind   `json:"kind"`
+	Status      lead.Status `json:"status"`
+	Name        string      `json:"name"`
+	Phone       string      `json:"phone"`
+	Email       *string     `json:"email,omitempty"`
+	Message     *string     `json:"message,omitempty"`
+	PresetID    *int64      `json:"preset_id,omitempty"`
+	ProductID   *int64      `json:"product_id,omitempty"`
+	PromoCode   *string     `json:"promo_code,omitempty"`
+	ManagerNote *string     `json:"manager_note,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+func NewLeadData(l *lead.Lead) LeadData {
+	return LeadData{
+		LeadID:      l.ID,
+		Kind:        l.Kind,
+		Status:      l.Status,
+		Name:        l.Name,
+		Phone:       l.Phone,
+		Email:       l.Email,
+		Message:     l.Message,
+		PresetID:    l.PresetID,
+		ProductID:   l.ProductID,
+		PromoCode:   l.PromoCode,
+		ManagerNote: l.ManagerNote,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
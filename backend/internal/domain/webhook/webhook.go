@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// EventType — тип события каталога или заявки.
+type EventType string
+
+const (
+	EventProductCreated EventType = "product.created"
+	EventProductUpdated EventType = "product.updated"
+	EventProductDeleted EventType = "product.deleted"
+	// EventPriceUpdated — изменилась базовая цена товара или ручная цена
+	// товара или услуги в другой валюте.
+	EventPriceUpdated      EventType = "price.updated"
+	EventPresetCreated     EventType = "preset.created"
+	EventPresetUpdated     EventType = "preset.updated"
+	EventPresetDeleted     EventType = "preset.deleted"
+	EventLeadCreated       EventType = "lead.created"
+	EventLeadStatusChanged EventType = "lead.status_changed"
+
+	// EventAll в подписке означает все события, в том числе добавленные позже.
+	EventAll EventType = "*"
+)
+
+// EventTypes — все типы событий, которые может получить подписчик.
+var EventTypes = []EventType{
+	EventProductCreated, EventProductUpdated, EventProductDeleted,
+	EventPriceUpdated,
+	EventPresetCreated, EventPresetUpdated, EventPresetDeleted,
+	EventLeadCreated, EventLeadStatusChanged,
+}
+
+func (t EventType) Valid() bool {
+	if t == EventAll {
+		return true
+	}
+	for _, et := range EventTypes {
+		if t == et {
+			return true
+		}
+	}
+	return false
+}
+
+// Event — запись outbox: сохраняется в одной транзакции с изменением данных
+// и рассылается подписчикам диспетчером.
+type Event struct {
+	ID        int64
+	Type      EventType
+	Data      json.RawMessage
+	CreatedAt time.Time
+}
+
+const MinSecretLength = 16
+
+// Subscription — адрес, на который отправляются события выбранных типов.
+// Тело запроса подписывается HMAC-SHA256 по Secret (см. Sign).
+type Subscription struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []EventType
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if len(s.Secret) < MinSecretLength {
+		return ErrSecretTooShort
+	}
+	if len(s.Events) == 0 {
+		return ErrNoEvents
+	}
+	for _, t := range s.Events {
+		if !t.Valid() {
+			return ErrUnknownEvent
+		}
+	}
+	return nil
+}
+
+// Matches сообщает, подписана ли подписка на события типа t.
+func (s *Subscription) Matches(t EventType) bool {
+	for _, et := range s.Events {
+		if et == t || et == EventAll {
+			return true
+		}
+	}
+	return false
+}
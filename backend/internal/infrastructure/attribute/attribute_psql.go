@@ -9,6 +9,7 @@ import (
 	e "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
 	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
@@ -59,7 +60,7 @@ func (r *PGAttributeRepository) SaveBatch(ctx context.Context, attrs []attr.Attr
 	returnedIDs := make([]int64, 0, len(attrs))
 
 	err := r.withQuery(ctx, query, func() error {
-		return r.db.SelectContext(ctx, &returnedIDs,
+		return r.conn(ctx).SelectContext(ctx, &returnedIDs,
 			query,
 			pq.Array(names),
 			pq.Array(units),
@@ -90,7 +91,7 @@ func (r *PGAttributeRepository) Save(ctx context.Context, attr *attr.Attribute)
 	var id int64
 
 	err := r.withQuery(ctx, query, func() error {
-		return r.db.QueryRowContext(ctx, query, attr.Name, attr.Unit, attr.CategoryID).Scan(&id)
+		return r.conn(ctx).QueryRowContext(ctx, query, attr.Name, attr.Unit, attr.CategoryID).Scan(&id)
 	})
 	if err != nil {
 		return r.mapPostgreSQLError(err)
@@ -110,7 +111,7 @@ func (r *PGAttributeRepository) GetByID(ctx context.Context, id int64) (*attr.At
 
 	var raw attributeDB
 	err := r.withQuery(ctx, query, func() error {
-		return r.db.GetContext(ctx, &raw, query, id)
+		return r.conn(ctx).GetContext(ctx, &raw, query, id)
 	})
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
@@ -130,7 +131,7 @@ func (r *PGAttributeRepository) FindByCategory(ctx context.Context, categoryID i
 
 	var raws []attributeDB
 	err := r.withQuery(ctx, query, func() error {
-		return r.db.SelectContext(ctx, &raws, query, categoryID)
+		return r.conn(ctx).SelectContext(ctx, &raws, query, categoryID)
 	})
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
@@ -148,7 +149,7 @@ func (r *PGAttributeRepository) Update(ctx context.Context, attr *attr.Attribute
 	`
 	var updated attributeDB
 	err := r.withQuery(ctx, query, func() error {
-		return r.db.QueryRowContext(ctx, query, attr.Name, attr.Unit, attr.CategoryID, attr.ID).Scan(&updated.ID, &updated.Name, &updated.Unit, &updated.CategoryID)
+		return r.conn(ctx).QueryRowContext(ctx, query, attr.Name, attr.Unit, attr.CategoryID, attr.ID).Scan(&updated.ID, &updated.Name, &updated.Unit, &updated.CategoryID)
 	})
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
@@ -162,7 +163,7 @@ func (r *PGAttributeRepository) Delete(ctx context.Context, id int64) error {
 	WHERE attribute_id = $1
 	`
 	err := r.withQuery(ctx, query, func() error {
-		res, err := r.db.ExecContext(ctx, query, id)
+		res, err := r.conn(ctx).ExecContext(ctx, query, id)
 		if err != nil {
 			return err
 		}
@@ -181,6 +182,11 @@ func (r *PGAttributeRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул.
+func (r *PGAttributeRepository) conn(ctx context.Context) tx.Querier {
+	return tx.Q(ctx, r.db)
+}
+
 func (r *PGAttributeRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
 	return database.WithQuery(ctx, r.log, query, fn, extras...)
 }
@@ -2,6 +2,7 @@ package attribute_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,7 +12,9 @@ import (
 
 	attr "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
 	attrRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/attribute"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
 	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	"github.com/Neimess/zorkin-store-project/pkg/migrator"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -142,6 +145,32 @@ func (s *PGAttributeRepositorySuite) Test_FindByCategory() {
 	assert.Len(s.T(), found, 3)
 }
 
+func (s *PGAttributeRepositorySuite) Test_InTransactor() {
+	catID := s.createCategory(fmt.Sprintf("cat_%d", time.Now().UnixNano()))
+	tr := tx.NewTransactor(s.db)
+
+	a := &attr.Attribute{Name: "rolled back", CategoryID: catID}
+	errStop := errors.New("stop")
+	err := tr.InTx(s.ctx, func(ctx context.Context) error {
+		require.NoError(s.T(), s.repo.Save(ctx, a))
+		got, err := s.repo.GetByID(ctx, a.ID)
+		require.NoError(s.T(), err, "write is visible inside the transaction")
+		assert.Equal(s.T(), a.Name, got.Name)
+		return errStop
+	})
+	require.ErrorIs(s.T(), err, errStop)
+
+	_, err = s.repo.GetByID(s.ctx, a.ID)
+	require.ErrorIs(s.T(), err, der.ErrNotFound)
+
+	b := &attr.Attribute{Name: "committed", CategoryID: catID}
+	require.NoError(s.T(), tr.InTx(s.ctx, func(ctx context.Context) error {
+		return s.repo.Save(ctx, b)
+	}))
+	_, err = s.repo.GetByID(s.ctx, b.ID)
+	require.NoError(s.T(), err)
+}
+
 func ptr(s string) *string {
 	return &s
 }
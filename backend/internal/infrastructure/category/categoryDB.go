@@ -8,4 +8,5 @@ type categoryDB struct {
 	ID       int64         `db:"category_id"`
 	Name     string        `db:"name"`
 	ParentID sql.NullInt64 `db:"parent_id"`
+	Version  int64         `db:"version"`
 }
@@ -2,12 +2,14 @@ package category
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"log/slog"
 
 	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
 	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
-	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -51,7 +53,7 @@ func (r *PGCategoryRepository) Create(ctx context.Context, cat *catDom.Category)
 	} else {
 		parent = nil
 	}
-	err := r.db.QueryRowContext(ctx, query, cat.Name, parent).Scan(&id)
+	err := r.conn(ctx).QueryRowContext(ctx, query, cat.Name, parent).Scan(&id)
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
@@ -61,14 +63,15 @@ func (r *PGCategoryRepository) Create(ctx context.Context, cat *catDom.Category)
 
 func (r *PGCategoryRepository) GetByID(ctx context.Context, id int64) (*catDom.Category, error) {
 	var dbCat categoryDB
-	const query = `SELECT category_id, name, parent_id FROM categories WHERE category_id = $1`
-	err := r.db.GetContext(ctx, &dbCat, query, id)
+	const query = `SELECT category_id, name, parent_id, version FROM categories WHERE category_id = $1`
+	err := r.conn(ctx).GetContext(ctx, &dbCat, query, id)
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
 	cat := &catDom.Category{
-		ID:   dbCat.ID,
-		Name: dbCat.Name,
+		ID:      dbCat.ID,
+		Name:    dbCat.Name,
+		Version: dbCat.Version,
 	}
 	if dbCat.ParentID.Valid {
 		pid := dbCat.ParentID.Int64
@@ -78,7 +81,6 @@ func (r *PGCategoryRepository) GetByID(ctx context.Context, id int64) (*catDom.C
 }
 
 func (r *PGCategoryRepository) Update(ctx context.Context, cat *catDom.Category) (*catDom.Category, error) {
-	const query = `UPDATE categories SET name = $1, parent_id = $2 WHERE category_id = $3 RETURNING category_id, name, parent_id`
 	var dbCat categoryDB
 	var parent interface{}
 	if cat.ParentID != nil {
@@ -86,11 +88,17 @@ func (r *PGCategoryRepository) Update(ctx context.Context, cat *catDom.Category)
 	} else {
 		parent = nil
 	}
-	err := r.db.GetContext(ctx, &dbCat, query, cat.Name, parent, cat.ID)
+	guard, args := database.Guard(ctx, []any{cat.Name, parent, cat.ID})
+	query := `UPDATE categories SET name = $1, parent_id = $2, version = version + 1 WHERE category_id = $3` + guard +
+		` RETURNING category_id, name, parent_id, version`
+	err := r.conn(ctx).GetContext(ctx, &dbCat, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = database.NoRows(ctx, r.db, "categories", "category_id", cat.ID)
+	}
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
-	updated := &catDom.Category{ID: dbCat.ID, Name: dbCat.Name}
+	updated := &catDom.Category{ID: dbCat.ID, Name: dbCat.Name, Version: dbCat.Version}
 	if dbCat.ParentID.Valid {
 		pid := dbCat.ParentID.Int64
 		updated.ParentID = &pid
@@ -98,8 +106,25 @@ func (r *PGCategoryRepository) Update(ctx context.Context, cat *catDom.Category)
 	return updated, nil
 }
 
+// Patch записывает только изменённые поля категории. Если категория изменилась
+// после чтения, возвращается ErrVersionConflict.
+func (r *PGCategoryRepository) Patch(ctx context.Context, p *catDom.Patch) (*catDom.Category, error) {
+	u := database.NewUpdate("categories", "category_id")
+	if p.Name.Set {
+		u.Set("name", p.Name.Value)
+	}
+	if p.ParentID.Set {
+		u.Set("parent_id", p.ParentID.Value)
+	}
+	if _, err := u.Exec(ctx, r.db, p.ID, p.Version); err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	return r.GetByID(ctx, p.ID)
+}
+
 func (r *PGCategoryRepository) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM categories WHERE category_id = $1`, id)
+	guard, args := database.Guard(ctx, []any{id})
+	res, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM categories WHERE category_id = $1`+guard, args...)
 	if err != nil {
 		return r.mapPostgreSQLError(err)
 	}
@@ -108,20 +133,20 @@ func (r *PGCategoryRepository) Delete(ctx context.Context, id int64) error {
 		return r.mapPostgreSQLError(err)
 	}
 	if rows == 0 {
-		return app_error.ErrNotFound
+		return r.mapPostgreSQLError(database.NoRows(ctx, r.db, "categories", "category_id", id))
 	}
 	return nil
 }
 
 func (r *PGCategoryRepository) List(ctx context.Context) ([]catDom.Category, error) {
 	var dbCats []categoryDB
-	const query = `SELECT category_id, name, parent_id FROM categories ORDER BY name`
-	if err := r.db.SelectContext(ctx, &dbCats, query); err != nil {
+	const query = `SELECT category_id, name, parent_id, version FROM categories ORDER BY name`
+	if err := r.conn(ctx).SelectContext(ctx, &dbCats, query); err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
 	cats := make([]catDom.Category, len(dbCats))
 	for i, dbCat := range dbCats {
-		cats[i] = catDom.Category{ID: dbCat.ID, Name: dbCat.Name}
+		cats[i] = catDom.Category{ID: dbCat.ID, Name: dbCat.Name, Version: dbCat.Version}
 		if dbCat.ParentID.Valid {
 			pid := dbCat.ParentID.Int64
 			cats[i].ParentID = &pid
@@ -133,3 +158,8 @@ func (r *PGCategoryRepository) List(ctx context.Context) ([]catDom.Category, err
 func (r *PGCategoryRepository) mapPostgreSQLError(err error) error {
 	return repoError.MapPostgreSQLError(r.log, err)
 }
+
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул.
+func (r *PGCategoryRepository) conn(ctx context.Context) tx.Querier {
+	return tx.Q(ctx, r.db)
+}
@@ -11,8 +11,10 @@ import (
 	"time"
 
 	cat "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
 	categoryRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/category"
 	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
 	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
 	"github.com/Neimess/zorkin-store-project/pkg/migrator"
 	"github.com/jmoiron/sqlx"
@@ -97,6 +99,46 @@ func (s *CategoryRepositorySuite) Test_Update_NotFound() {
 	assert.ErrorIs(s.T(), err, app_error.ErrNotFound)
 }
 
+func (s *CategoryRepositorySuite) Test_Update_IfMatch() {
+	id := s.createCategory("oldname")
+	fetched, err := s.repo.GetByID(s.ctx, id)
+	require.NoError(s.T(), err)
+
+	ctx := database.WithExpectedVersions(s.ctx, []int64{fetched.Version})
+	updated, err := s.repo.Update(ctx, &cat.Category{ID: id, Name: "newname"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fetched.Version+1, updated.Version)
+
+	// ETag той же версии уже устарел
+	_, err = s.repo.Update(ctx, &cat.Category{ID: id, Name: "other"})
+	assert.ErrorIs(s.T(), err, app_error.ErrVersionConflict)
+	err = s.repo.Delete(ctx, id)
+	assert.ErrorIs(s.T(), err, app_error.ErrVersionConflict)
+
+	_, err = s.repo.Update(ctx, &cat.Category{ID: 30000, Name: "other"})
+	assert.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *CategoryRepositorySuite) Test_Patch() {
+	id := s.createCategory("oldname")
+	fetched, err := s.repo.GetByID(s.ctx, id)
+	require.NoError(s.T(), err)
+
+	patched, err := s.repo.Patch(s.ctx, &cat.Patch{ID: id, Version: fetched.Version, Name: patch.To("newname")})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "newname", patched.Name)
+	assert.Equal(s.T(), fetched.Version+1, patched.Version)
+
+	// второй патч по устаревшей версии не проходит
+	_, err = s.repo.Patch(s.ctx, &cat.Patch{ID: id, Version: fetched.Version, Name: patch.To("other")})
+	assert.ErrorIs(s.T(), err, app_error.ErrVersionConflict)
+}
+
+func (s *CategoryRepositorySuite) Test_Patch_NotFound() {
+	_, err := s.repo.Patch(s.ctx, &cat.Patch{ID: 30000, Version: 1, Name: patch.To("newname")})
+	assert.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
 func (s *CategoryRepositorySuite) Test_Delete() {
 	id := s.createCategory("todelete")
 
@@ -5,16 +5,18 @@ import (
 )
 
 type coefficientDB struct {
-	ID    int64   `db:"coefficient_id"`
-	Name  string  `db:"name"`
-	Value float64 `db:"value"`
+	ID      int64   `db:"coefficient_id"`
+	Name    string  `db:"name"`
+	Value   float64 `db:"value"`
+	Version int64   `db:"version"`
 }
 
 func (c coefficientDB) toDomain() *domCoeff.Coefficient {
 	return &domCoeff.Coefficient{
-		ID:    c.ID,
-		Name:  c.Name,
-		Value: c.Value,
+		ID:      c.ID,
+		Name:    c.Name,
+		Value:   c.Value,
+		Version: c.Version,
 	}
 }
 
@@ -2,10 +2,14 @@ package coefficients
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 
 	domCoeff "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
 	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -28,7 +32,7 @@ func (r *PGCoefficientsRepository) Create(ctx context.Context, c *domCoeff.Coeff
 	const q = `INSERT INTO coefficients (name, value) VALUES ($1, $2) RETURNING coefficient_id`
 	var id int64
 	err := r.withQuery(ctx, q, func() error {
-		return r.db.QueryRowContext(ctx, q, c.Name, c.Value).Scan(&id)
+		return r.conn(ctx).QueryRowContext(ctx, q, c.Name, c.Value).Scan(&id)
 	})
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
@@ -38,10 +42,10 @@ func (r *PGCoefficientsRepository) Create(ctx context.Context, c *domCoeff.Coeff
 }
 
 func (r *PGCoefficientsRepository) Get(ctx context.Context, id int64) (*domCoeff.Coefficient, error) {
-	const q = `SELECT coefficient_id, name, value FROM coefficients WHERE coefficient_id = $1`
+	const q = `SELECT coefficient_id, name, value, version FROM coefficients WHERE coefficient_id = $1`
 	var raw coefficientDB
 	err := r.withQuery(ctx, q, func() error {
-		return r.db.GetContext(ctx, &raw, q, id)
+		return r.conn(ctx).GetContext(ctx, &raw, q, id)
 	})
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
@@ -50,10 +54,10 @@ func (r *PGCoefficientsRepository) Get(ctx context.Context, id int64) (*domCoeff
 }
 
 func (r *PGCoefficientsRepository) List(ctx context.Context) ([]domCoeff.Coefficient, error) {
-	const q = `SELECT coefficient_id, name, value FROM coefficients`
+	const q = `SELECT coefficient_id, name, value, version FROM coefficients`
 	var raws []coefficientDB
 	err := r.withQuery(ctx, q, func() error {
-		return r.db.SelectContext(ctx, &raws, q)
+		return r.conn(ctx).SelectContext(ctx, &raws, q)
 	})
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
@@ -62,9 +66,13 @@ func (r *PGCoefficientsRepository) List(ctx context.Context) ([]domCoeff.Coeffic
 }
 
 func (r *PGCoefficientsRepository) Update(ctx context.Context, c *domCoeff.Coefficient) (*domCoeff.Coefficient, error) {
-	const q = `UPDATE coefficients SET name = $1, value = $2 WHERE coefficient_id = $3`
+	guard, args := database.Guard(ctx, []any{c.Name, c.Value, c.ID})
+	q := `UPDATE coefficients SET name = $1, value = $2, version = version + 1 WHERE coefficient_id = $3` + guard + ` RETURNING version`
 	err := r.withQuery(ctx, q, func() error {
-		_, execErr := r.db.ExecContext(ctx, q, c.Name, c.Value, c.ID)
+		execErr := r.conn(ctx).GetContext(ctx, &c.Version, q, args...)
+		if errors.Is(execErr, sql.ErrNoRows) {
+			return database.NoRows(ctx, r.db, "coefficients", "coefficient_id", c.ID)
+		}
 		return execErr
 	})
 	if err != nil {
@@ -73,11 +81,42 @@ func (r *PGCoefficientsRepository) Update(ctx context.Context, c *domCoeff.Coeff
 	return c, nil
 }
 
+// Patch записывает только изменённые поля коэффициента. Если коэффициент
+// изменился после чтения, возвращается ErrVersionConflict.
+func (r *PGCoefficientsRepository) Patch(ctx context.Context, p *domCoeff.Patch) (*domCoeff.Coefficient, error) {
+	u := database.NewUpdate("coefficients", "coefficient_id")
+	if p.Name.Set {
+		u.Set("name", p.Name.Value)
+	}
+	if p.Value.Set {
+		u.Set("value", p.Value.Value)
+	}
+	query, _ := u.SQL(ctx, p.ID, p.Version)
+	err := r.withQuery(ctx, query, func() error {
+		_, execErr := u.Exec(ctx, r.db, p.ID, p.Version)
+		return execErr
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return r.Get(ctx, p.ID)
+}
+
 func (r *PGCoefficientsRepository) Delete(ctx context.Context, id int64) error {
-	const q = `DELETE FROM coefficients WHERE coefficient_id = $1`
+	guard, args := database.Guard(ctx, []any{id})
+	q := `DELETE FROM coefficients WHERE coefficient_id = $1` + guard
 	err := r.withQuery(ctx, q, func() error {
-		_, execErr := r.db.ExecContext(ctx, q, id)
-		return execErr
+		res, execErr := r.conn(ctx).ExecContext(ctx, q, args...)
+		if execErr != nil || guard == "" {
+			return execErr
+		}
+		// без If-Match удаление идемпотентно; с ним несовпавшая версия — конфликт
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			if err := database.NoRows(ctx, r.db, "coefficients", "coefficient_id", id); !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+		}
+		return nil
 	})
 	if err != nil {
 		return repoError.MapPostgreSQLError(r.log, err)
@@ -89,3 +128,8 @@ func (r *PGCoefficientsRepository) withQuery(ctx context.Context, query string,
 	r.log.Debug("query", slog.String("query", query))
 	return fn()
 }
+
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул.
+func (r *PGCoefficientsRepository) conn(ctx context.Context) tx.Querier {
+	return tx.Q(ctx, r.db)
+}
@@ -0,0 +1,275 @@
+// Package commerceml читает и пишет документы CommerceML 2 — формата обмена
+// каталогом и заказами с 1С:Предприятием.
+package commerceml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/charmap"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+)
+
+// SchemaVersion — версия схемы в выгружаемых документах.
+const SchemaVersion = "2.05"
+
+type commerceInfo struct {
+	XMLName       xml.Name       `xml:"КоммерческаяИнформация"`
+	Classifier    *classifier    `xml:"Классификатор"`
+	Catalog       *catalog       `xml:"Каталог"`
+	OffersPackage *offersPackage `xml:"ПакетПредложений"`
+}
+
+type classifier struct {
+	Groups     []group    `xml:"Группы>Группа"`
+	Properties []property `xml:"Свойства>Свойство"`
+	// в схемах до 2.04 свойства называются СвойствоНоменклатуры
+	LegacyProperties []property `xml:"Свойства>СвойствоНоменклатуры"`
+}
+
+type group struct {
+	ID       string  `xml:"Ид"`
+	Name     string  `xml:"Наименование"`
+	Children []group `xml:"Группы>Группа"`
+}
+
+type property struct {
+	ID     string          `xml:"Ид"`
+	Name   string          `xml:"Наименование"`
+	Values []propertyValue `xml:"ВариантыЗначений>Справочник"`
+}
+
+type propertyValue struct {
+	ID    string `xml:"ИдЗначения"`
+	Value string `xml:"Значение"`
+}
+
+type catalog struct {
+	OnlyChanges bool   `xml:"СодержитТолькоИзменения,attr"`
+	Items       []item `xml:"Товары>Товар"`
+}
+
+type item struct {
+	ID          string      `xml:"Ид"`
+	Name        string      `xml:"Наименование"`
+	Description string      `xml:"Описание"`
+	Groups      []string    `xml:"Группы>Ид"`
+	Values      []itemValue `xml:"ЗначенияСвойств>ЗначенияСвойства"`
+	Status      string      `xml:"Статус"`
+	StatusAttr  string      `xml:"Статус,attr"`
+	Deleted     bool        `xml:"ПометкаУдаления"`
+}
+
+type itemValue struct {
+	ID     string   `xml:"Ид"`
+	Values []string `xml:"Значение"`
+}
+
+type offersPackage struct {
+	PriceTypes []priceType `xml:"ТипыЦен>ТипЦены"`
+	Offers     []offer     `xml:"Предложения>Предложение"`
+}
+
+type priceType struct {
+	ID   string `xml:"Ид"`
+	Name string `xml:"Наименование"`
+}
+
+type offer struct {
+	ID       string  `xml:"Ид"`
+	Prices   []price `xml:"Цены>Цена"`
+	Quantity string  `xml:"Количество"`
+}
+
+type price struct {
+	TypeID  string `xml:"ИдТипаЦены"`
+	PerUnit string `xml:"ЦенаЗаЕдиницу"`
+}
+
+// Codec разбирает файлы обмена и формирует выгрузку заказов.
+type Codec struct {
+	// priceType — наименование или Ид типа цены, который считается ценой
+	// каталога; пустое значение — первый тип цены в пакете предложений.
+	priceType string
+}
+
+func New(priceType string) *Codec {
+	return &Codec{priceType: strings.TrimSpace(priceType)}
+}
+
+// Decode разбирает import.xml или offers.xml (кодировки UTF-8 и windows-1251).
+func (c *Codec) Decode(r io.Reader) (*domExchange.Package, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+	var doc commerceInfo
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domExchange.ErrMalformedDocument, err)
+	}
+	if doc.Classifier == nil && doc.Catalog == nil && doc.OffersPackage == nil {
+		return nil, domExchange.ErrUnknownDocument
+	}
+
+	pkg := &domExchange.Package{}
+	if doc.Classifier != nil || doc.Catalog != nil {
+		pkg.Catalog = decodeCatalog(doc.Classifier, doc.Catalog)
+	}
+	if doc.OffersPackage != nil {
+		offers, err := c.decodeOffers(doc.OffersPackage)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Offers = offers
+	}
+	return pkg, nil
+}
+
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "windows-1251", "cp1251":
+		return charmap.Windows1251.NewDecoder().Reader(input), nil
+	case "utf-8", "utf8":
+		return input, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", label)
+}
+
+func decodeCatalog(cl *classifier, cat *catalog) *domExchange.Catalog {
+	res := &domExchange.Catalog{}
+	if cl != nil {
+		var walk func(gs []group, parent string)
+		walk = func(gs []group, parent string) {
+			for _, g := range gs {
+				res.Groups = append(res.Groups, domExchange.Group{
+					ExternalID: strings.TrimSpace(g.ID),
+					ParentID:   parent,
+					Name:       strings.TrimSpace(g.Name),
+				})
+				walk(g.Children, strings.TrimSpace(g.ID))
+			}
+		}
+		walk(cl.Groups, "")
+
+		for _, p := range append(cl.Properties, cl.LegacyProperties...) {
+			dp := domExchange.Property{ExternalID: strings.TrimSpace(p.ID), Name: strings.TrimSpace(p.Name)}
+			if len(p.Values) > 0 {
+				dp.Values = make(map[string]string, len(p.Values))
+				for _, v := range p.Values {
+					dp.Values[strings.TrimSpace(v.ID)] = strings.TrimSpace(v.Value)
+				}
+			}
+			res.Properties = append(res.Properties, dp)
+		}
+	}
+	if cat == nil {
+		return res
+	}
+
+	res.OnlyChanges = cat.OnlyChanges
+	for _, it := range cat.Items {
+		di := domExchange.Item{
+			ExternalID: strings.TrimSpace(it.ID),
+			Name:       strings.TrimSpace(it.Name),
+			Deleted:    it.Deleted || isDeleted(it.Status) || isDeleted(it.StatusAttr),
+		}
+		if d := strings.TrimSpace(it.Description); d != "" {
+			di.Description = &d
+		}
+		if len(it.Groups) > 0 {
+			di.GroupID = strings.TrimSpace(it.Groups[0])
+		}
+		for _, v := range it.Values {
+			for _, val := range v.Values {
+				val = strings.TrimSpace(val)
+				if val == "" {
+					continue
+				}
+				di.Properties = append(di.Properties, domExchange.PropertyValue{PropertyID: strings.TrimSpace(v.ID), Value: val})
+			}
+		}
+		res.Items = append(res.Items, di)
+	}
+	return res
+}
+
+func isDeleted(status string) bool {
+	return strings.EqualFold(strings.TrimSpace(status), "Удален")
+}
+
+func (c *Codec) decodeOffers(pkg *offersPackage) ([]domExchange.Offer, error) {
+	typeID := ""
+	for _, pt := range pkg.PriceTypes {
+		if c.priceType == "" || pt.ID == c.priceType || strings.EqualFold(strings.TrimSpace(pt.Name), c.priceType) {
+			typeID = pt.ID
+			break
+		}
+	}
+	if c.priceType != "" && typeID == "" && len(pkg.PriceTypes) > 0 {
+		return nil, fmt.Errorf("%w: price type %q not found", domExchange.ErrMalformedDocument, c.priceType)
+	}
+
+	// предложения по характеристикам ("товар#характеристика") сворачиваются
+	// в товар: цена — минимальная, остаток — суммарный
+	index := make(map[string]int)
+	var res []domExchange.Offer
+	for _, o := range pkg.Offers {
+		productID, _, _ := strings.Cut(strings.TrimSpace(o.ID), "#")
+		if productID == "" {
+			continue
+		}
+		price, err := pickPrice(o.Prices, typeID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: offer %s: %v", domExchange.ErrMalformedDocument, o.ID, err)
+		}
+		qty, err := parseDecimal(o.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("%w: offer %s: %v", domExchange.ErrMalformedDocument, o.ID, err)
+		}
+
+		i, ok := index[productID]
+		if !ok {
+			index[productID] = len(res)
+			res = append(res, domExchange.Offer{ProductID: productID, Price: price, Quantity: qty})
+			continue
+		}
+		cur := &res[i]
+		if price != nil && (cur.Price == nil || price.LessThan(*cur.Price)) {
+			cur.Price = price
+		}
+		if qty != nil {
+			if cur.Quantity == nil {
+				cur.Quantity = qty
+			} else {
+				sum := cur.Quantity.Add(*qty)
+				cur.Quantity = &sum
+			}
+		}
+	}
+	return res, nil
+}
+
+func pickPrice(prices []price, typeID string) (*decimal.Decimal, error) {
+	for _, p := range prices {
+		if typeID != "" && p.TypeID != typeID {
+			continue
+		}
+		return parseDecimal(p.PerUnit)
+	}
+	return nil, nil
+}
+
+// parseDecimal понимает "1 234,50" — 1С иногда выгружает числа в локали.
+func parseDecimal(s string) (*decimal.Decimal, error) {
+	s = strings.NewReplacer(" ", "", " ", "", ",", ".").Replace(strings.TrimSpace(s))
+	if s == "" {
+		return nil, nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,174 @@
+package commerceml_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/commerceml"
+)
+
+const importXML = `<?xml version="1.0" encoding="UTF-8"?>
+<КоммерческаяИнформация ВерсияСхемы="2.05">
+	<Классификатор>
+		<Ид>cls</Ид>
+		<Группы>
+			<Группа>
+				<Ид>g-tile</Ид><Наименование>Плитка</Наименование>
+				<Группы>
+					<Группа><Ид>g-floor</Ид><Наименование> Напольная </Наименование></Группа>
+				</Группы>
+			</Группа>
+		</Группы>
+		<Свойства>
+			<Свойство>
+				<Ид>p-color</Ид><Наименование>Цвет</Наименование>
+				<ВариантыЗначений>
+					<Справочник><ИдЗначения>v-white</ИдЗначения><Значение>Белый</Значение></Справочник>
+				</ВариантыЗначений>
+			</Свойство>
+			<Свойство><Ид>p-size</Ид><Наименование>Размер</Наименование></Свойство>
+		</Свойства>
+	</Классификатор>
+	<Каталог СодержитТолькоИзменения="true">
+		<Товары>
+			<Товар>
+				<Ид>i-1</Ид><Наименование>Керамогранит</Наименование><Описание>Матовый</Описание>
+				<Группы><Ид>g-floor</Ид></Группы>
+				<ЗначенияСвойств>
+					<ЗначенияСвойства><Ид>p-color</Ид><Значение>v-white</Значение></ЗначенияСвойства>
+					<ЗначенияСвойства><Ид>p-size</Ид><Значение>60x60</Значение></ЗначенияСвойства>
+					<ЗначенияСвойства><Ид>p-size</Ид><Значение></Значение></ЗначенияСвойства>
+				</ЗначенияСвойств>
+			</Товар>
+			<Товар Статус="Удален"><Ид>i-2</Ид><Наименование>Старый</Наименование></Товар>
+		</Товары>
+	</Каталог>
+</КоммерческаяИнформация>`
+
+const offersXML = `<?xml version="1.0" encoding="UTF-8"?>
+<КоммерческаяИнформация ВерсияСхемы="2.05">
+	<ПакетПредложений>
+		<ТипыЦен>
+			<ТипЦены><Ид>pt-opt</Ид><Наименование>Оптовая</Наименование></ТипЦены>
+			<ТипЦены><Ид>pt-retail</Ид><Наименование>Розничная</Наименование></ТипЦены>
+		</ТипыЦен>
+		<Предложения>
+			<Предложение>
+				<Ид>i-1#c-1</Ид>
+				<Цены>
+					<Цена><ИдТипаЦены>pt-opt</ИдТипаЦены><ЦенаЗаЕдиницу>900</ЦенаЗаЕдиницу></Цена>
+					<Цена><ИдТипаЦены>pt-retail</ИдТипаЦены><ЦенаЗаЕдиницу>1 290,50</ЦенаЗаЕдиницу></Цена>
+				</Цены>
+				<Количество>3</Количество>
+			</Предложение>
+			<Предложение>
+				<Ид>i-1#c-2</Ид>
+				<Цены><Цена><ИдТипаЦены>pt-retail</ИдТипаЦены><ЦенаЗаЕдиницу>1190</ЦенаЗаЕдиницу></Цена></Цены>
+				<Количество>2.5</Количество>
+			</Предложение>
+			<Предложение><Ид>i-3</Ид><Количество>0</Количество></Предложение>
+		</Предложения>
+	</ПакетПредложений>
+</КоммерческаяИнформация>`
+
+func TestDecodeCatalog(t *testing.T) {
+	pkg, err := commerceml.New("").Decode(strings.NewReader(importXML))
+	require.NoError(t, err)
+	require.NotNil(t, pkg.Catalog)
+	assert.Empty(t, pkg.Offers)
+
+	cat := pkg.Catalog
+	assert.True(t, cat.OnlyChanges)
+	assert.Equal(t, []domExchange.Group{
+		{ExternalID: "g-tile", Name: "Плитка"},
+		{ExternalID: "g-floor", ParentID: "g-tile", Name: "Напольная"},
+	}, cat.Groups)
+	require.Len(t, cat.Properties, 2)
+
+	require.Len(t, cat.Items, 2)
+	it := cat.Items[0]
+	assert.Equal(t, "g-floor", it.GroupID)
+	assert.Equal(t, "Матовый", *it.Description)
+	require.Len(t, it.Properties, 2)
+	assert.Equal(t, "Белый", cat.PropertyValueText(it.Properties[0]))
+	assert.Equal(t, "60x60", cat.PropertyValueText(it.Properties[1]))
+	assert.False(t, it.Deleted)
+	assert.True(t, cat.Items[1].Deleted)
+}
+
+func TestDecodeOffers(t *testing.T) {
+	pkg, err := commerceml.New("Розничная").Decode(strings.NewReader(offersXML))
+	require.NoError(t, err)
+	assert.Nil(t, pkg.Catalog)
+	require.Len(t, pkg.Offers, 2)
+
+	// характеристики свёрнуты: минимальная цена, суммарный остаток
+	o := pkg.Offers[0]
+	assert.Equal(t, "i-1", o.ProductID)
+	assert.Equal(t, "1190", o.Price.String())
+	assert.Equal(t, "5.5", o.Quantity.String())
+
+	assert.Equal(t, "i-3", pkg.Offers[1].ProductID)
+	assert.Nil(t, pkg.Offers[1].Price)
+	assert.True(t, pkg.Offers[1].Quantity.IsZero())
+
+	_, err = commerceml.New("Закупочная").Decode(strings.NewReader(offersXML))
+	assert.ErrorIs(t, err, domExchange.ErrMalformedDocument)
+}
+
+func TestDecodeWindows1251(t *testing.T) {
+	doc := strings.Replace(importXML, "UTF-8", "windows-1251", 1)
+	encoded, err := charmap.Windows1251.NewEncoder().String(doc)
+	require.NoError(t, err)
+
+	pkg, err := commerceml.New("").Decode(strings.NewReader(encoded))
+	require.NoError(t, err)
+	assert.Equal(t, "Плитка", pkg.Catalog.Groups[0].Name)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	_, err := commerceml.New("").Decode(strings.NewReader("<КоммерческаяИнформация>"))
+	assert.ErrorIs(t, err, domExchange.ErrMalformedDocument)
+
+	_, err = commerceml.New("").Decode(strings.NewReader(`<КоммерческаяИнформация ВерсияСхемы="2.05"/>`))
+	assert.ErrorIs(t, err, domExchange.ErrUnknownDocument)
+}
+
+func TestEncodeOrders(t *testing.T) {
+	email := "ivan@example.com"
+	orders := []domExchange.Order{{
+		Lead: lead.Lead{
+			ID: 42, Kind: lead.KindConsultation, Name: "Иван", Phone: "+77010000000", Email: &email,
+			Status: lead.StatusNew, CreatedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		},
+		Items: []domExchange.OrderItem{
+			{ExternalID: "i-1", Name: "Керамогранит", Price: decimal.RequireFromString("1190"), Quantity: decimal.NewFromInt(2)},
+		},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, commerceml.New("").EncodeOrders(&buf, orders, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8"?>`))
+	for _, want := range []string{
+		`<Ид>lead-42</Ид>`,
+		`<Дата>2026-03-01</Дата>`,
+		`<ХозОперация>Заказ товара</ХозОперация>`,
+		`<Сумма>2380.00</Сумма>`,
+		`<Значение>ivan@example.com</Значение>`,
+		`<ЦенаЗаЕдиницу>1190.00</ЦенаЗаЕдиницу>`,
+		`<Значение>new</Значение>`,
+	} {
+		assert.Contains(t, out, want)
+	}
+}
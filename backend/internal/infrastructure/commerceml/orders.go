@@ -0,0 +1,142 @@
+package commerceml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+)
+
+// currency1C — обозначение базовой валюты в документах 1С.
+const currency1C = "руб"
+
+type ordersInfo struct {
+	XMLName   xml.Name   `xml:"КоммерческаяИнформация"`
+	Version   string     `xml:"ВерсияСхемы,attr"`
+	CreatedAt string     `xml:"ДатаФормирования,attr"`
+	Documents []document `xml:"Документ"`
+}
+
+type document struct {
+	ID           string        `xml:"Ид"`
+	Number       string        `xml:"Номер"`
+	Date         string        `xml:"Дата"`
+	Time         string        `xml:"Время"`
+	Operation    string        `xml:"ХозОперация"`
+	Role         string        `xml:"Роль"`
+	Currency     string        `xml:"Валюта"`
+	Rate         string        `xml:"Курс"`
+	Sum          string        `xml:"Сумма"`
+	Counterparty []counterpart `xml:"Контрагенты>Контрагент"`
+	Comment      string        `xml:"Комментарий,omitempty"`
+	Items        []orderItem   `xml:"Товары>Товар"`
+	Requisites   []requisite   `xml:"ЗначенияРеквизитов>ЗначениеРеквизита"`
+}
+
+type counterpart struct {
+	ID       string    `xml:"Ид"`
+	Name     string    `xml:"Наименование"`
+	FullName string    `xml:"ПолноеНаименование"`
+	Role     string    `xml:"Роль"`
+	Contacts []contact `xml:"Контакты>Контакт"`
+}
+
+type contact struct {
+	Type  string `xml:"Тип"`
+	Value string `xml:"Значение"`
+}
+
+type orderItem struct {
+	ID       string `xml:"Ид"`
+	Name     string `xml:"Наименование"`
+	Unit     unit   `xml:"БазоваяЕдиница"`
+	PerUnit  string `xml:"ЦенаЗаЕдиницу"`
+	Quantity string `xml:"Количество"`
+	Sum      string `xml:"Сумма"`
+}
+
+type unit struct {
+	Code  string `xml:"Код,attr"`
+	Full  string `xml:"НаименованиеПолное,attr"`
+	Short string `xml:"МеждународноеСокращение,attr"`
+	Name  string `xml:",chardata"`
+}
+
+type requisite struct {
+	Name  string `xml:"Наименование"`
+	Value string `xml:"Значение"`
+}
+
+var pieceUnit = unit{Code: "796", Full: "Штука", Short: "PCE", Name: "шт"}
+
+// EncodeOrders пишет заявки документами "Заказ товара" в UTF-8.
+func (c *Codec) EncodeOrders(w io.Writer, orders []domExchange.Order, at time.Time) error {
+	doc := ordersInfo{
+		Version:   SchemaVersion,
+		CreatedAt: at.Format("2006-01-02T15:04:05"),
+		Documents: make([]document, 0, len(orders)),
+	}
+	for _, o := range orders {
+		doc.Documents = append(doc.Documents, encodeOrder(o, at.Location()))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("commerceml: encode orders: %w", err)
+	}
+	return enc.Close()
+}
+
+// encodeOrder переводит время заявки в часовой пояс выгрузки: 1С ждёт местное время.
+func encodeOrder(o domExchange.Order, loc *time.Location) document {
+	l := o.Lead
+	id := "lead-" + strconv.FormatInt(l.ID, 10)
+	created := l.CreatedAt.In(loc)
+	d := document{
+		ID:        id,
+		Number:    strconv.FormatInt(l.ID, 10),
+		Date:      created.Format("2006-01-02"),
+		Time:      created.Format("15:04:05"),
+		Operation: "Заказ товара",
+		Role:      "Продавец",
+		Currency:  currency1C,
+		Rate:      "1",
+		Sum:       o.Total().StringFixed(2),
+		Requisites: []requisite{
+			{Name: "Статус заказа", Value: string(l.Status)},
+			{Name: "Тип заявки", Value: string(l.Kind)},
+		},
+	}
+	if l.Message != nil {
+		d.Comment = *l.Message
+	}
+	if l.PromoCode != nil {
+		d.Requisites = append(d.Requisites, requisite{Name: "Промокод", Value: *l.PromoCode})
+	}
+
+	buyer := counterpart{ID: id, Name: l.Name, FullName: l.Name, Role: "Покупатель",
+		Contacts: []contact{{Type: "Телефон рабочий", Value: l.Phone}}}
+	if l.Email != nil {
+		buyer.Contacts = append(buyer.Contacts, contact{Type: "Почта", Value: *l.Email})
+	}
+	d.Counterparty = []counterpart{buyer}
+
+	for _, it := range o.Items {
+		d.Items = append(d.Items, orderItem{
+			ID:       it.ExternalID,
+			Name:     it.Name,
+			Unit:     pieceUnit,
+			PerUnit:  it.Price.StringFixed(2),
+			Quantity: it.Quantity.String(),
+			Sum:      it.Price.Mul(it.Quantity).StringFixed(2),
+		})
+	}
+	return d
+}
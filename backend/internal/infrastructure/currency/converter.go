@@ -0,0 +1,107 @@
+package currency
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+// Converter пересчитывает цены загруженных сущностей в валюту из контекста
+// (money.WithCurrency). Ручная цена в валюте имеет приоритет над курсом.
+// В отличие от переводов, ошибка здесь прерывает чтение: отдать цены
+// не в той валюте, что запрошена, хуже, чем не отдать ничего.
+type Converter struct {
+	repo  *PGCurrencyRepository
+	rules money.RoundingRules
+}
+
+func NewConverter(repo *PGCurrencyRepository, rules money.RoundingRules) *Converter {
+	if repo == nil {
+		panic("NewConverter: repo is nil")
+	}
+	return &Converter{repo: repo, rules: rules}
+}
+
+// PriceTarget — цена сущности, которую нужно пересчитать на месте.
+type PriceTarget struct {
+	Entity money.Entity
+	ID     int64
+	Price  *money.Money
+}
+
+func (c *Converter) Convert(ctx context.Context, targets []PriceTarget) error {
+	cur := money.CurrencyFromContext(ctx)
+	if cur == money.Base || len(targets) == 0 {
+		return nil
+	}
+	rate, err := c.rate(ctx, cur)
+	if err != nil {
+		return err
+	}
+
+	ids := map[money.Entity][]int64{}
+	for _, t := range targets {
+		if t.Entity.Overridable() {
+			ids[t.Entity] = append(ids[t.Entity], t.ID)
+		}
+	}
+	overrides := make(map[money.Entity]map[int64]decimal.Decimal, len(ids))
+	for entity, list := range ids {
+		found, err := c.repo.LookupOverrides(ctx, entity, list, cur)
+		if err != nil {
+			return err
+		}
+		overrides[entity] = found
+	}
+
+	rnd := c.rules.For(cur)
+	for _, t := range targets {
+		if t.Price.Currency == cur {
+			continue
+		}
+		if p, ok := overrides[t.Entity][t.ID]; ok {
+			*t.Price = money.New(p, cur)
+			continue
+		}
+		converted, err := rate.Convert(*t.Price, rnd)
+		if err != nil {
+			return err
+		}
+		*t.Price = converted
+	}
+	return nil
+}
+
+// FromBase пересчитывает сумму в базовой валюте в валюту из контекста по курсу,
+// без учёта ручных цен.
+func (c *Converter) FromBase(ctx context.Context, m money.Money) (money.Money, error) {
+	cur := money.CurrencyFromContext(ctx)
+	if cur == m.Currency {
+		return m, nil
+	}
+	rate, err := c.rate(ctx, cur)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return rate.Convert(m, c.rules.For(cur))
+}
+
+// Rounding возвращает правило округления цен в валюте c.
+func (c *Converter) Rounding(cur money.Currency) money.Rounding {
+	return c.rules.For(cur)
+}
+
+func (c *Converter) rate(ctx context.Context, cur money.Currency) (*money.Rate, error) {
+	rate, err := c.repo.GetRate(ctx, cur)
+	if errors.Is(err, app_error.ErrNotFound) {
+		return nil, money.ErrUnsupportedCurrency
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
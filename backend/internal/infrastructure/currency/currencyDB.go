@@ -0,0 +1,56 @@
+package currency
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+)
+
+type rateDB struct {
+	Currency  string          `db:"currency"`
+	Rate      decimal.Decimal `db:"rate"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+func (r rateDB) toDomain() money.Rate {
+	return money.Rate{
+		Currency:  money.Currency(r.Currency),
+		Rate:      r.Rate,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func rawRateListToDomain(raws []rateDB) []money.Rate {
+	rates := make([]money.Rate, len(raws))
+	for i, r := range raws {
+		rates[i] = r.toDomain()
+	}
+	return rates
+}
+
+type overrideDB struct {
+	Entity    string          `db:"entity"`
+	EntityID  int64           `db:"entity_id"`
+	Currency  string          `db:"currency"`
+	Price     decimal.Decimal `db:"price"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+func (o overrideDB) toDomain() money.PriceOverride {
+	return money.PriceOverride{
+		Entity:    money.Entity(o.Entity),
+		EntityID:  o.EntityID,
+		Price:     money.New(o.Price, money.Currency(o.Currency)),
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+func rawOverrideListToDomain(raws []overrideDB) []money.PriceOverride {
+	res := make([]money.PriceOverride, len(raws))
+	for i, o := range raws {
+		res[i] = o.toDomain()
+	}
+	return res
+}
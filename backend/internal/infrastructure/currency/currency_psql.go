@@ -0,0 +1,237 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/outbox"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
+)
+
+// entityTables — таблица и первичный ключ сущностей с ручными ценами.
+var entityTables = map[money.Entity][2]string{
+	money.EntityProduct: {"products", "product_id"},
+	money.EntityService: {"services", "service_id"},
+}
+
+type PGCurrencyRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGCurrencyRepository(db *sqlx.DB, log *slog.Logger) *PGCurrencyRepository {
+	if db == nil {
+		panic("NewPGCurrencyRepository: db is nil")
+	}
+	return &PGCurrencyRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// ListRates возвращает курсы всех поддерживаемых валют.
+func (r *PGCurrencyRepository) ListRates(ctx context.Context) ([]money.Rate, error) {
+	const q = `SELECT currency, rate, updated_at FROM exchange_rates ORDER BY currency`
+	var raws []rateDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawRateListToDomain(raws), nil
+}
+
+func (r *PGCurrencyRepository) GetRate(ctx context.Context, c money.Currency) (*money.Rate, error) {
+	const q = `SELECT currency, rate, updated_at FROM exchange_rates WHERE currency = $1`
+	var raw rateDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &raw, q, string(c))
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	rate := raw.toDomain()
+	return &rate, nil
+}
+
+// PutRate создаёт или обновляет курс валюты.
+func (r *PGCurrencyRepository) PutRate(ctx context.Context, rate *money.Rate) (*money.Rate, error) {
+	const q = `
+		INSERT INTO exchange_rates (currency, rate, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at
+	`
+	now := time.Now().UTC()
+	err := r.withQuery(ctx, q, func() error {
+		_, err := r.db.ExecContext(ctx, q, string(rate.Currency), rate.Rate, now)
+		return err
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	rate.UpdatedAt = now
+	return rate, nil
+}
+
+// DeleteRate удаляет валюту вместе с ручными ценами в ней.
+func (r *PGCurrencyRepository) DeleteRate(ctx context.Context, c money.Currency) error {
+	const q = `DELETE FROM exchange_rates WHERE currency = $1`
+	return r.exec(ctx, q, string(c))
+}
+
+// ListOverrides возвращает ручные цены сущности во всех валютах.
+func (r *PGCurrencyRepository) ListOverrides(ctx context.Context, entity money.Entity, id int64) ([]money.PriceOverride, error) {
+	if err := r.ensureEntityExists(ctx, entity, id); err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	const q = `
+		SELECT entity, entity_id, currency, price, updated_at
+		FROM price_overrides
+		WHERE entity = $1 AND entity_id = $2
+		ORDER BY currency
+	`
+	var raws []overrideDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(entity), id)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawOverrideListToDomain(raws), nil
+}
+
+// PutOverride создаёт или обновляет ручную цену. Валюта должна иметь курс.
+// Вместе с ценой в outbox пишется price.updated.
+func (r *PGCurrencyRepository) PutOverride(ctx context.Context, o *money.PriceOverride) (*money.PriceOverride, error) {
+	if err := r.ensureEntityExists(ctx, o.Entity, o.EntityID); err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	const q = `
+		INSERT INTO price_overrides (entity, entity_id, currency, price, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (entity, entity_id, currency)
+		DO UPDATE SET price = EXCLUDED.price, updated_at = EXCLUDED.updated_at
+	`
+	now := time.Now().UTC()
+	err := tx.RunInTxAction(ctx, r.db, func(tx *sqlx.Tx) error {
+		err := r.withQuery(ctx, q, func() error {
+			_, err := tx.ExecContext(ctx, q, string(o.Entity), o.EntityID,
+				string(o.Price.Currency), o.Price.Amount, now)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		price := o.Price.Amount
+		return outbox.Enqueue(ctx, tx, webhook.EventPriceUpdated, webhook.PriceData{
+			Entity:   o.Entity,
+			EntityID: o.EntityID,
+			Currency: o.Price.Currency,
+			Price:    &price,
+		})
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	o.UpdatedAt = now
+	return o, nil
+}
+
+// DeleteOverride удаляет ручную цену; в outbox пишется price.updated без цены.
+func (r *PGCurrencyRepository) DeleteOverride(ctx context.Context, entity money.Entity, id int64, c money.Currency) error {
+	const q = `DELETE FROM price_overrides WHERE entity = $1 AND entity_id = $2 AND currency = $3`
+	err := tx.RunInTxAction(ctx, r.db, func(tx *sqlx.Tx) error {
+		err := r.withQuery(ctx, q, func() error {
+			res, err := tx.ExecContext(ctx, q, string(entity), id, string(c))
+			if err != nil {
+				return err
+			}
+			if cnt, _ := res.RowsAffected(); cnt == 0 {
+				return app_error.ErrNotFound
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, webhook.EventPriceUpdated, webhook.PriceData{
+			Entity:   entity,
+			EntityID: id,
+			Currency: c,
+		})
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+// LookupOverrides возвращает ручные цены сущностей ids в валюте: entity_id → цена.
+func (r *PGCurrencyRepository) LookupOverrides(ctx context.Context, entity money.Entity, ids []int64, c money.Currency) (map[int64]decimal.Decimal, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	const q = `
+		SELECT entity, entity_id, currency, price, updated_at
+		FROM price_overrides
+		WHERE entity = $1 AND currency = $2 AND entity_id = ANY($3)
+	`
+	var raws []overrideDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(entity), string(c), pq.Array(ids))
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	res := make(map[int64]decimal.Decimal, len(raws))
+	for _, raw := range raws {
+		res[raw.EntityID] = raw.Price
+	}
+	return res, nil
+}
+
+func (r *PGCurrencyRepository) exec(ctx context.Context, q string, args ...any) error {
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+func (r *PGCurrencyRepository) ensureEntityExists(ctx context.Context, entity money.Entity, id int64) error {
+	tbl, ok := entityTables[entity]
+	if !ok {
+		return app_error.ErrBadRequest
+	}
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1)`, tbl[0], tbl[1])
+	var exists bool
+	err := r.withQuery(ctx, query, func() error {
+		return r.db.GetContext(ctx, &exists, query, id)
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return app_error.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGCurrencyRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
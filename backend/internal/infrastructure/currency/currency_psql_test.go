@@ -0,0 +1,125 @@
+package currency_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	curRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/currency"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGCurrencyRepositorySuite struct {
+	suite.Suite
+	repo *curRepo.PGCurrencyRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+	db   *sqlx.DB
+}
+
+func (s *PGCurrencyRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	s.repo = curRepo.NewPGCurrencyRepository(s.db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGCurrencyRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGCurrencyRepositorySuite) createProduct(price string) int64 {
+	var catID, id int64
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO categories(name) VALUES ($1) RETURNING category_id`,
+		fmt.Sprintf("currency_%d", time.Now().UnixNano())).Scan(&catID))
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO products(name, price, category_id) VALUES ('Плитка', $1, $2) RETURNING product_id`,
+		price, catID).Scan(&id))
+	return id
+}
+
+func (s *PGCurrencyRepositorySuite) Test_ConvertWithRateAndOverride() {
+	_, err := s.repo.PutRate(s.ctx, &money.Rate{Currency: money.KZT, Rate: decimal.RequireFromString("5.4321")})
+	require.NoError(s.T(), err)
+
+	byRate := s.createProduct("1000.00")
+	overridden := s.createProduct("2000.00")
+	_, err = s.repo.PutOverride(s.ctx, &money.PriceOverride{
+		Entity: money.EntityProduct, EntityID: overridden,
+		Price: money.New(decimal.RequireFromString("9990"), money.KZT),
+	})
+	require.NoError(s.T(), err)
+
+	conv := curRepo.NewConverter(s.repo, money.RoundingRules{
+		ByCurrency: map[money.Currency]money.Rounding{
+			money.KZT: {Mode: money.RoundUp, Step: decimal.NewFromInt(10)},
+		},
+	})
+	p1 := money.New(decimal.RequireFromString("1000"), money.Base)
+	p2 := money.New(decimal.RequireFromString("2000"), money.Base)
+	err = conv.Convert(money.WithCurrency(s.ctx, money.KZT), []curRepo.PriceTarget{
+		{Entity: money.EntityProduct, ID: byRate, Price: &p1},
+		{Entity: money.EntityProduct, ID: overridden, Price: &p2},
+	})
+	require.NoError(s.T(), err)
+	// 1000 * 5.4321 = 5432.1 → вверх до десятков
+	require.Equal(s.T(), "5440.00 KZT", p1.String())
+	require.Equal(s.T(), "9990.00 KZT", p2.String())
+
+	// без курса валюта не поддерживается
+	p3 := money.New(decimal.RequireFromString("1000"), money.Base)
+	err = conv.Convert(money.WithCurrency(s.ctx, money.USD), []curRepo.PriceTarget{
+		{Entity: money.EntityProduct, ID: byRate, Price: &p3},
+	})
+	require.ErrorIs(s.T(), err, money.ErrUnsupportedCurrency)
+}
+
+func (s *PGCurrencyRepositorySuite) Test_DeleteRateDropsOverrides() {
+	_, err := s.repo.PutRate(s.ctx, &money.Rate{Currency: money.EUR, Rate: decimal.RequireFromString("0.0105")})
+	require.NoError(s.T(), err)
+	id := s.createProduct("500.00")
+	_, err = s.repo.PutOverride(s.ctx, &money.PriceOverride{
+		Entity: money.EntityProduct, EntityID: id,
+		Price: money.New(decimal.RequireFromString("5.50"), money.EUR),
+	})
+	require.NoError(s.T(), err)
+
+	require.NoError(s.T(), s.repo.DeleteRate(s.ctx, money.EUR))
+	list, err := s.repo.ListOverrides(s.ctx, money.EntityProduct, id)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), list)
+	require.ErrorIs(s.T(), s.repo.DeleteRate(s.ctx, money.EUR), app_error.ErrNotFound)
+}
+
+func (s *PGCurrencyRepositorySuite) Test_OverrideForMissingEntity() {
+	_, err := s.repo.PutOverride(s.ctx, &money.PriceOverride{
+		Entity: money.EntityService, EntityID: 999999,
+		Price: money.New(decimal.NewFromInt(10), money.KZT),
+	})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func TestPGCurrencyRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGCurrencyRepositorySuite))
+}
@@ -0,0 +1,90 @@
+package discount
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+)
+
+type ruleDB struct {
+	ID        int64           `db:"rule_id"`
+	Name      string          `db:"name"`
+	Kind      string          `db:"kind"`
+	Value     decimal.Decimal `db:"value"`
+	Target    string          `db:"target"`
+	TargetID  int64           `db:"target_id"`
+	StartsAt  sql.NullTime    `db:"starts_at"`
+	EndsAt    sql.NullTime    `db:"ends_at"`
+	PromoOnly bool            `db:"promo_only"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+func (r ruleDB) toDomain() domDiscount.Rule {
+	d := domDiscount.Rule{
+		ID:        r.ID,
+		Name:      r.Name,
+		Kind:      domDiscount.Kind(r.Kind),
+		Value:     r.Value,
+		Target:    domDiscount.Target(r.Target),
+		TargetID:  r.TargetID,
+		PromoOnly: r.PromoOnly,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if r.StartsAt.Valid {
+		d.StartsAt = &r.StartsAt.Time
+	}
+	if r.EndsAt.Valid {
+		d.EndsAt = &r.EndsAt.Time
+	}
+	return d
+}
+
+func rawRuleListToDomain(raws []ruleDB) []domDiscount.Rule {
+	res := make([]domDiscount.Rule, len(raws))
+	for i, r := range raws {
+		res[i] = r.toDomain()
+	}
+	return res
+}
+
+// candidateDB — правило, подходящее сущности entity_id (напрямую
+// или через одну из категорий товара).
+type candidateDB struct {
+	EntityID int64 `db:"entity_id"`
+	ruleDB
+}
+
+type promoDB struct {
+	Code       string        `db:"code"`
+	RuleID     int64         `db:"rule_id"`
+	UsageLimit sql.NullInt32 `db:"usage_limit"`
+	UsedCount  int           `db:"used_count"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
+
+func (p promoDB) toDomain() domDiscount.PromoCode {
+	d := domDiscount.PromoCode{
+		Code:      p.Code,
+		RuleID:    p.RuleID,
+		UsedCount: p.UsedCount,
+		CreatedAt: p.CreatedAt,
+	}
+	if p.UsageLimit.Valid {
+		limit := int(p.UsageLimit.Int32)
+		d.UsageLimit = &limit
+	}
+	return d
+}
+
+func rawPromoListToDomain(raws []promoDB) []domDiscount.PromoCode {
+	res := make([]domDiscount.PromoCode, len(raws))
+	for i, p := range raws {
+		res[i] = p.toDomain()
+	}
+	return res
+}
@@ -0,0 +1,270 @@
+package discount
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+const selectRule = `
+	SELECT rule_id, name, kind, value, target, target_id, starts_at, ends_at,
+	       promo_only, created_at, updated_at
+	FROM discount_rules
+`
+
+const selectPromo = `SELECT code, rule_id, usage_limit, used_count, created_at FROM promo_codes `
+
+// targetTables — таблица и первичный ключ сущностей, на которые даётся скидка.
+var targetTables = map[domDiscount.Target][2]string{
+	domDiscount.TargetProduct:  {"products", "product_id"},
+	domDiscount.TargetCategory: {"categories", "category_id"},
+	domDiscount.TargetPreset:   {"presets", "preset_id"},
+	domDiscount.TargetService:  {"services", "service_id"},
+}
+
+// activeRule — условие «правило действует в момент $2 и доступно с промокодом $3».
+const activeRule = `
+	(r.starts_at IS NULL OR r.starts_at <= $2)
+	AND (r.ends_at IS NULL OR r.ends_at > $2)
+	AND (NOT r.promo_only OR EXISTS (
+		SELECT 1 FROM promo_codes pc
+		WHERE pc.rule_id = r.rule_id AND pc.code = $3
+		  AND (pc.usage_limit IS NULL OR pc.used_count < pc.usage_limit)
+	))
+`
+
+type PGDiscountRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGDiscountRepository(db *sqlx.DB, log *slog.Logger) *PGDiscountRepository {
+	if db == nil {
+		panic("NewPGDiscountRepository: db is nil")
+	}
+	return &PGDiscountRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *PGDiscountRepository) ListRules(ctx context.Context) ([]domDiscount.Rule, error) {
+	const q = selectRule + `ORDER BY rule_id`
+	var raws []ruleDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawRuleListToDomain(raws), nil
+}
+
+func (r *PGDiscountRepository) GetRule(ctx context.Context, id int64) (*domDiscount.Rule, error) {
+	const q = selectRule + `WHERE rule_id = $1`
+	var raw ruleDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &raw, q, id)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	rule := raw.toDomain()
+	return &rule, nil
+}
+
+// CreateRule сохраняет правило; если цели скидки нет, возвращает ErrNotFound.
+func (r *PGDiscountRepository) CreateRule(ctx context.Context, rule *domDiscount.Rule) (*domDiscount.Rule, error) {
+	if err := r.ensureTargetExists(ctx, rule.Target, rule.TargetID); err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	const q = `
+		INSERT INTO discount_rules (name, kind, value, target, target_id, starts_at, ends_at, promo_only)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING rule_id, created_at, updated_at
+	`
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q,
+			rule.Name, string(rule.Kind), rule.Value, string(rule.Target), rule.TargetID,
+			rule.StartsAt, rule.EndsAt, rule.PromoOnly,
+		).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rule, nil
+}
+
+// UpdateRule перезаписывает правило целиком; ErrNotFound — нет правила или цели.
+func (r *PGDiscountRepository) UpdateRule(ctx context.Context, rule *domDiscount.Rule) (*domDiscount.Rule, error) {
+	if err := r.ensureTargetExists(ctx, rule.Target, rule.TargetID); err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	const q = `
+		UPDATE discount_rules
+		SET name = $2, kind = $3, value = $4, target = $5, target_id = $6,
+		    starts_at = $7, ends_at = $8, promo_only = $9, updated_at = $10
+		WHERE rule_id = $1
+		RETURNING created_at, updated_at
+	`
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q,
+			rule.ID, rule.Name, string(rule.Kind), rule.Value, string(rule.Target), rule.TargetID,
+			rule.StartsAt, rule.EndsAt, rule.PromoOnly, time.Now().UTC(),
+		).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rule, nil
+}
+
+// DeleteRule удаляет правило вместе с его промокодами.
+func (r *PGDiscountRepository) DeleteRule(ctx context.Context, id int64) error {
+	const q = `DELETE FROM discount_rules WHERE rule_id = $1`
+	return r.exec(ctx, q, id)
+}
+
+func (r *PGDiscountRepository) ListPromoCodes(ctx context.Context, ruleID int64) ([]domDiscount.PromoCode, error) {
+	const q = selectPromo + `WHERE rule_id = $1 ORDER BY code`
+	var raws []promoDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, ruleID)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawPromoListToDomain(raws), nil
+}
+
+func (r *PGDiscountRepository) GetPromoCode(ctx context.Context, code string) (*domDiscount.PromoCode, error) {
+	const q = selectPromo + `WHERE code = $1`
+	var raw promoDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &raw, q, code)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	p := raw.toDomain()
+	return &p, nil
+}
+
+// CreatePromoCode сохраняет код; ErrConflict — код уже занят, ErrNotFound — нет правила.
+func (r *PGDiscountRepository) CreatePromoCode(ctx context.Context, p *domDiscount.PromoCode) (*domDiscount.PromoCode, error) {
+	const q = `
+		INSERT INTO promo_codes (code, rule_id, usage_limit)
+		VALUES ($1, $2, $3)
+		RETURNING used_count, created_at
+	`
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q, p.Code, p.RuleID, p.UsageLimit).
+			Scan(&p.UsedCount, &p.CreatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return p, nil
+}
+
+func (r *PGDiscountRepository) DeletePromoCode(ctx context.Context, code string) error {
+	const q = `DELETE FROM promo_codes WHERE code = $1`
+	return r.exec(ctx, q, code)
+}
+
+// Candidates возвращает правила, действующие в момент now для сущностей ids:
+// entity_id → правила. Для товаров учитываются скидки на их категорию
+// и всех её предков. promo — промокод покупателя, может быть пустым.
+func (r *PGDiscountRepository) Candidates(ctx context.Context, target domDiscount.Target, ids []int64, now time.Time, promo string) (map[int64][]domDiscount.Rule, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var q string
+	switch target {
+	case domDiscount.TargetProduct:
+		q = `
+			WITH RECURSIVE tree AS (
+				SELECT p.product_id AS entity_id, p.category_id
+				FROM products p
+				WHERE p.product_id = ANY($1)
+			  UNION
+				SELECT t.entity_id, c.parent_id
+				FROM tree t
+				JOIN categories c ON c.category_id = t.category_id
+				WHERE c.parent_id IS NOT NULL
+			)
+			SELECT t.entity_id, r.*
+			FROM tree t
+			JOIN discount_rules r ON r.target = 'category' AND r.target_id = t.category_id
+			WHERE ` + activeRule + `
+			UNION ALL
+			SELECT r.target_id AS entity_id, r.*
+			FROM discount_rules r
+			WHERE r.target = 'product' AND r.target_id = ANY($1) AND ` + activeRule
+	case domDiscount.TargetPreset, domDiscount.TargetService:
+		q = `
+			SELECT r.target_id AS entity_id, r.*
+			FROM discount_rules r
+			WHERE r.target = '` + string(target) + `' AND r.target_id = ANY($1) AND ` + activeRule
+	default:
+		return nil, app_error.ErrBadRequest
+	}
+	var raws []candidateDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, pq.Array(ids), now, promo)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	res := make(map[int64][]domDiscount.Rule, len(raws))
+	for _, raw := range raws {
+		res[raw.EntityID] = append(res[raw.EntityID], raw.toDomain())
+	}
+	return res, nil
+}
+
+func (r *PGDiscountRepository) exec(ctx context.Context, q string, args ...any) error {
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+func (r *PGDiscountRepository) ensureTargetExists(ctx context.Context, target domDiscount.Target, id int64) error {
+	tbl, ok := targetTables[target]
+	if !ok {
+		return app_error.ErrBadRequest
+	}
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1)`, tbl[0], tbl[1])
+	var exists bool
+	err := r.withQuery(ctx, query, func() error {
+		return r.db.GetContext(ctx, &exists, query, id)
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return app_error.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGDiscountRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
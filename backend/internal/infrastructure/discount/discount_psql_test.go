@@ -0,0 +1,210 @@
+package discount_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/currency"
+	discountRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/discount"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGDiscountRepositorySuite struct {
+	suite.Suite
+	repo    *discountRepo.PGDiscountRepository
+	rates   *currency.PGCurrencyRepository
+	pricer  *discountRepo.Pricer
+	ctx     context.Context
+	srv     *testsuite.TestServer
+	db      *sqlx.DB
+	pricing context.Context
+}
+
+func (s *PGDiscountRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s.repo = discountRepo.NewPGDiscountRepository(s.db, logger)
+	s.rates = currency.NewPGCurrencyRepository(s.db, logger)
+	s.pricer = discountRepo.NewPricer(s.repo, currency.NewConverter(s.rates, money.RoundingRules{}))
+	s.pricing = domDiscount.WithPricing(s.ctx, domDiscount.Pricing{})
+}
+
+func (s *PGDiscountRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGDiscountRepositorySuite) createCategory(parent *int64) int64 {
+	var id int64
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO categories(name, parent_id) VALUES ($1, $2) RETURNING category_id`,
+		fmt.Sprintf("discount_%d", time.Now().UnixNano()), parent).Scan(&id))
+	return id
+}
+
+func (s *PGDiscountRepositorySuite) createProduct(catID int64, price string) int64 {
+	var id int64
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO products(name, price, category_id) VALUES ('Плитка', $1, $2) RETURNING product_id`,
+		price, catID).Scan(&id))
+	return id
+}
+
+func (s *PGDiscountRepositorySuite) createRule(r domDiscount.Rule) *domDiscount.Rule {
+	if r.Name == "" {
+		r.Name = "Скидка"
+	}
+	res, err := s.repo.CreateRule(s.ctx, &r)
+	require.NoError(s.T(), err)
+	return res
+}
+
+func (s *PGDiscountRepositorySuite) apply(ctx context.Context, productID int64, price string) (money.Money, *domDiscount.Sale) {
+	p := money.New(decimal.RequireFromString(price), money.Base)
+	var sale *domDiscount.Sale
+	require.NoError(s.T(), s.pricer.Apply(ctx, []discountRepo.PriceTarget{
+		{Entity: money.EntityProduct, ID: productID, Price: &p, Sale: &sale},
+	}))
+	return p, sale
+}
+
+func (s *PGDiscountRepositorySuite) Test_CategoryRuleCoversDescendants() {
+	root := s.createCategory(nil)
+	child := s.createCategory(&root)
+	id := s.createProduct(child, "1000.00")
+	rule := s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(10),
+		Target: domDiscount.TargetCategory, TargetID: root,
+	})
+
+	price, sale := s.apply(s.pricing, id, "1000")
+	require.Equal(s.T(), "900.00 RUB", price.String())
+	require.NotNil(s.T(), sale)
+	require.Equal(s.T(), "1000.00 RUB", sale.OldPrice.String())
+	require.Equal(s.T(), rule.ID, sale.Rule.ID)
+
+	// без WithPricing (админка) цена не меняется
+	price, sale = s.apply(s.ctx, id, "1000")
+	require.Equal(s.T(), "1000.00 RUB", price.String())
+	require.Nil(s.T(), sale)
+}
+
+func (s *PGDiscountRepositorySuite) Test_BestRuleWinsAndExpiredIgnored() {
+	cat := s.createCategory(nil)
+	id := s.createProduct(cat, "1000.00")
+	past := time.Now().Add(-48 * time.Hour)
+	ended := time.Now().Add(-24 * time.Hour)
+	s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(50),
+		Target: domDiscount.TargetProduct, TargetID: id, StartsAt: &past, EndsAt: &ended,
+	})
+	s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(10),
+		Target: domDiscount.TargetCategory, TargetID: cat,
+	})
+	fixed := s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindFixed, Value: decimal.NewFromInt(150),
+		Target: domDiscount.TargetProduct, TargetID: id,
+	})
+
+	price, sale := s.apply(s.pricing, id, "1000")
+	require.Equal(s.T(), "850.00 RUB", price.String())
+	require.Equal(s.T(), fixed.ID, sale.Rule.ID)
+}
+
+func (s *PGDiscountRepositorySuite) Test_PromoOnlyRuleNeedsCode() {
+	cat := s.createCategory(nil)
+	id := s.createProduct(cat, "2000.00")
+	rule := s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(20),
+		Target: domDiscount.TargetProduct, TargetID: id, PromoOnly: true,
+	})
+	code := fmt.Sprintf("P%d", time.Now().UnixNano()%1_000_000_000)
+	limit := 1
+	_, err := s.repo.CreatePromoCode(s.ctx, &domDiscount.PromoCode{Code: code, RuleID: rule.ID, UsageLimit: &limit})
+	require.NoError(s.T(), err)
+
+	price, sale := s.apply(s.pricing, id, "2000")
+	require.Equal(s.T(), "2000.00 RUB", price.String())
+	require.Nil(s.T(), sale)
+
+	withCode := domDiscount.WithPricing(s.ctx, domDiscount.Pricing{PromoCode: code})
+	price, sale = s.apply(withCode, id, "2000")
+	require.Equal(s.T(), "1600.00 RUB", price.String())
+	require.Equal(s.T(), code, *sale.PromoCode)
+
+	// исчерпанный код больше не даёт скидку
+	_, err = s.db.Exec(`UPDATE promo_codes SET used_count = 1 WHERE code = $1`, code)
+	require.NoError(s.T(), err)
+	price, _ = s.apply(withCode, id, "2000")
+	require.Equal(s.T(), "2000.00 RUB", price.String())
+}
+
+func (s *PGDiscountRepositorySuite) Test_FixedAmountIsConvertedToRequestCurrency() {
+	_, err := s.rates.PutRate(s.ctx, &money.Rate{Currency: money.KZT, Rate: decimal.NewFromInt(5)})
+	require.NoError(s.T(), err)
+	cat := s.createCategory(nil)
+	id := s.createProduct(cat, "1000.00")
+	s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindFixed, Value: decimal.NewFromInt(100),
+		Target: domDiscount.TargetProduct, TargetID: id,
+	})
+
+	ctx := money.WithCurrency(s.pricing, money.KZT)
+	p := money.New(decimal.NewFromInt(5000), money.KZT)
+	var sale *domDiscount.Sale
+	require.NoError(s.T(), s.pricer.Apply(ctx, []discountRepo.PriceTarget{
+		{Entity: money.EntityProduct, ID: id, Price: &p, Sale: &sale},
+	}))
+	require.Equal(s.T(), "4500.00 KZT", p.String())
+	require.Equal(s.T(), "5000.00 KZT", sale.OldPrice.String())
+}
+
+func (s *PGDiscountRepositorySuite) Test_RuleForMissingTarget() {
+	_, err := s.repo.CreateRule(s.ctx, &domDiscount.Rule{
+		Name: "Нет цели", Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(5),
+		Target: domDiscount.TargetService, TargetID: 999999,
+	})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *PGDiscountRepositorySuite) Test_DeleteProductDropsRules() {
+	cat := s.createCategory(nil)
+	id := s.createProduct(cat, "100.00")
+	rule := s.createRule(domDiscount.Rule{
+		Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(5),
+		Target: domDiscount.TargetProduct, TargetID: id,
+	})
+	_, err := s.db.Exec(`DELETE FROM products WHERE product_id = $1`, id)
+	require.NoError(s.T(), err)
+
+	_, err = s.repo.GetRule(s.ctx, rule.ID)
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func TestPGDiscountRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGDiscountRepositorySuite))
+}
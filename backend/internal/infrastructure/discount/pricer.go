@@ -0,0 +1,106 @@
+package discount
+
+import (
+	"context"
+	"time"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/currency"
+)
+
+// Pricer применяет действующие скидки к уже загруженным и пересчитанным
+// в валюту запроса ценам. Работает только при discount.WithPricing в контексте.
+// Из подходящих правил выбирается дающее наименьшую цену, при равенстве —
+// созданное раньше; выбранное правило сохраняется в Sale как объяснение цены.
+type Pricer struct {
+	repo   *PGDiscountRepository
+	prices *currency.Converter
+	now    func() time.Time
+}
+
+func NewPricer(repo *PGDiscountRepository, prices *currency.Converter) *Pricer {
+	if repo == nil {
+		panic("NewPricer: repo is nil")
+	}
+	if prices == nil {
+		panic("NewPricer: prices is nil")
+	}
+	return &Pricer{repo: repo, prices: prices, now: time.Now}
+}
+
+// PriceTarget — цена сущности и место для применённой к ней скидки.
+type PriceTarget struct {
+	Entity money.Entity
+	ID     int64
+	Price  *money.Money
+	Sale   **domDiscount.Sale
+}
+
+func (p *Pricer) Apply(ctx context.Context, targets []PriceTarget) error {
+	pricing, ok := domDiscount.PricingFromContext(ctx)
+	if !ok || len(targets) == 0 {
+		return nil
+	}
+	now := p.now().UTC()
+
+	ids := map[domDiscount.Target][]int64{}
+	for _, t := range targets {
+		target := domDiscount.Target(t.Entity)
+		ids[target] = append(ids[target], t.ID)
+	}
+	candidates := make(map[domDiscount.Target]map[int64][]domDiscount.Rule, len(ids))
+	for target, list := range ids {
+		found, err := p.repo.Candidates(ctx, target, list, now, pricing.PromoCode)
+		if err != nil {
+			return err
+		}
+		candidates[target] = found
+	}
+
+	for _, t := range targets {
+		rules := candidates[domDiscount.Target(t.Entity)][t.ID]
+		if len(rules) == 0 {
+			continue
+		}
+		best, price, err := p.best(ctx, rules, *t.Price)
+		if err != nil {
+			return err
+		}
+		if !price.Amount.LessThan(t.Price.Amount) {
+			continue
+		}
+		sale := &domDiscount.Sale{OldPrice: *t.Price, Rule: best}
+		if best.PromoOnly {
+			code := pricing.PromoCode
+			sale.PromoCode = &code
+		}
+		*t.Price = price
+		*t.Sale = sale
+	}
+	return nil
+}
+
+func (p *Pricer) best(ctx context.Context, rules []domDiscount.Rule, price money.Money) (domDiscount.Rule, money.Money, error) {
+	rnd := p.prices.Rounding(price.Currency)
+	var (
+		best     domDiscount.Rule
+		bestCost money.Money
+	)
+	for i, r := range rules {
+		fixed := money.Zero(price.Currency)
+		if r.Kind == domDiscount.KindFixed {
+			var err error
+			fixed, err = p.prices.FromBase(ctx, money.New(r.Value, money.Base))
+			if err != nil {
+				return domDiscount.Rule{}, money.Money{}, err
+			}
+		}
+		cost := r.Apply(price, fixed, rnd)
+		if i == 0 || cost.Amount.LessThan(bestCost.Amount) ||
+			(cost.Amount.Equal(bestCost.Amount) && r.ID < best.ID) {
+			best, bestCost = r, cost
+		}
+	}
+	return best, bestCost, nil
+}
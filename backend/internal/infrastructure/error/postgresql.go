@@ -73,6 +73,11 @@ func MapPostgreSQLError(logger *slog.Logger, err error) error {
 		logger.Debug("not found error", slog.String("error", err.Error()))
 		return app_error.ErrNotFound
 	}
+
+	if errors.Is(err, app_error.ErrVersionConflict) {
+		logger.Debug("version conflict", slog.String("error", err.Error()))
+		return app_error.ErrVersionConflict
+	}
 	// Всё прочее — внутренняя ошибка хранилища
 	logger.Error("unexpected database error", slog.String("error", err.Error()))
 	return app_error.ErrInternal
@@ -0,0 +1,82 @@
+package exchange
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
+)
+
+type productDB struct {
+	ID         int64           `db:"product_id"`
+	Name       string          `db:"name"`
+	Price      decimal.Decimal `db:"price"`
+	CategoryID int64           `db:"category_id"`
+	ImageURL   sql.NullString  `db:"image_url"`
+}
+
+func (p productDB) toDomain() *domProduct.Product {
+	d := &domProduct.Product{
+		ID:         p.ID,
+		Name:       p.Name,
+		Price:      money.New(p.Price, money.Base),
+		CategoryID: p.CategoryID,
+	}
+	if p.ImageURL.Valid {
+		d.ImageURL = &p.ImageURL.String
+	}
+	return d
+}
+
+// orderDB — заявка вместе с товаром или пресетом, на который она оставлена.
+type orderDB struct {
+	ID        int64               `db:"lead_id"`
+	Kind      string              `db:"kind"`
+	Name      string              `db:"name"`
+	Phone     string              `db:"phone"`
+	Email     sql.NullString      `db:"email"`
+	Message   sql.NullString      `db:"message"`
+	PromoCode sql.NullString      `db:"promo_code"`
+	Status    string              `db:"status"`
+	CreatedAt time.Time           `db:"created_at"`
+	UpdatedAt time.Time           `db:"updated_at"`
+	ItemID    sql.NullString      `db:"item_id"`
+	ItemName  sql.NullString      `db:"item_name"`
+	ItemPrice decimal.NullDecimal `db:"item_price"`
+}
+
+func (o orderDB) toDomain() domExchange.Order {
+	l := domLead.Lead{
+		ID:        o.ID,
+		Kind:      domLead.Kind(o.Kind),
+		Name:      o.Name,
+		Phone:     o.Phone,
+		Status:    domLead.Status(o.Status),
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+	if o.Email.Valid {
+		l.Email = &o.Email.String
+	}
+	if o.Message.Valid {
+		l.Message = &o.Message.String
+	}
+	if o.PromoCode.Valid {
+		l.PromoCode = &o.PromoCode.String
+	}
+	order := domExchange.Order{Lead: l}
+	if o.ItemID.Valid {
+		order.Items = []domExchange.OrderItem{{
+			ExternalID: o.ItemID.String,
+			Name:       o.ItemName.String,
+			Price:      o.ItemPrice.Decimal,
+			Quantity:   decimal.NewFromInt(1),
+		}}
+	}
+	return order
+}
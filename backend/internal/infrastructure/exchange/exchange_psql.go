@@ -0,0 +1,496 @@
+package exchange
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/outbox"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
+)
+
+const (
+	maxNameLength  = 255
+	maxValueLength = 100
+)
+
+type PGExchangeRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGExchangeRepository(db *sqlx.DB, log *slog.Logger) *PGExchangeRepository {
+	if db == nil {
+		panic("NewPGExchangeRepository: db is nil")
+	}
+	return &PGExchangeRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// catalogImport — состояние загрузки одного каталога внутри транзакции.
+type catalogImport struct {
+	r       *PGExchangeRepository
+	tx      *sqlx.Tx
+	source  string
+	catalog *domExchange.Catalog
+	groups  map[string]int64
+	attrs   map[string]int64
+	res     domExchange.ImportResult
+}
+
+// ImportCatalog загружает группы, свойства и товары одной транзакцией.
+// Сущности сопоставляются по внешним идентификаторам source: повторная
+// загрузка того же файла ничего не дублирует. Товары без группы
+// пропускаются, помеченные на удаление — удаляются, если не входят в пресеты.
+func (r *PGExchangeRepository) ImportCatalog(ctx context.Context, source string, c *domExchange.Catalog) (domExchange.ImportResult, error) {
+	return tx.RunInTx(ctx, r.db, func(tx *sqlx.Tx) (domExchange.ImportResult, error) {
+		imp := &catalogImport{
+			r: r, tx: tx, source: source, catalog: c,
+			groups: make(map[string]int64, len(c.Groups)),
+			attrs:  make(map[string]int64),
+		}
+		for _, g := range c.Groups {
+			if err := imp.group(ctx, g); err != nil {
+				return imp.res, fmt.Errorf("group %s: %w", g.ExternalID, err)
+			}
+		}
+		for _, it := range c.Items {
+			if err := imp.item(ctx, it); err != nil {
+				return imp.res, fmt.Errorf("item %s: %w", it.ExternalID, err)
+			}
+		}
+		return imp.res, nil
+	})
+}
+
+func (imp *catalogImport) group(ctx context.Context, g domExchange.Group) error {
+	if g.ExternalID == "" || g.Name == "" {
+		imp.res.Skipped++
+		return nil
+	}
+	var parentID *int64
+	if g.ParentID != "" {
+		id, ok, err := imp.category(ctx, g.ParentID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			parentID = &id
+		}
+	}
+
+	const (
+		update = `UPDATE categories SET name = $2, parent_id = $3, version = version + 1 WHERE category_id = $1`
+		// категория с таким именем, заведённая вручную, привязывается к группе
+		insert = `
+			INSERT INTO categories (name, parent_id) VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE SET parent_id = EXCLUDED.parent_id, version = categories.version + 1
+			RETURNING category_id`
+	)
+	name := truncate(g.Name, maxNameLength)
+	id, found, err := imp.r.lookupTx(ctx, imp.tx, imp.source, domExchange.EntityCategory, g.ExternalID)
+	if err != nil {
+		return err
+	}
+	if found {
+		found, err = imp.r.execTx(ctx, imp.tx, update, id, name, parentID)
+		if err != nil {
+			return err
+		}
+	}
+	if !found {
+		if err := imp.r.getTx(ctx, imp.tx, &id, insert, name, parentID); err != nil {
+			return err
+		}
+		if err := imp.r.bindTx(ctx, imp.tx, imp.source, domExchange.EntityCategory, g.ExternalID, id); err != nil {
+			return err
+		}
+	}
+	imp.groups[g.ExternalID] = id
+	imp.res.Categories++
+	return nil
+}
+
+// category находит категорию группы: из текущей загрузки или из прошлых.
+func (imp *catalogImport) category(ctx context.Context, groupID string) (int64, bool, error) {
+	if id, ok := imp.groups[groupID]; ok {
+		return id, true, nil
+	}
+	id, ok, err := imp.r.lookupTx(ctx, imp.tx, imp.source, domExchange.EntityCategory, groupID)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	imp.groups[groupID] = id
+	return id, true, nil
+}
+
+func (imp *catalogImport) item(ctx context.Context, it domExchange.Item) error {
+	if it.ExternalID == "" {
+		imp.res.Skipped++
+		return nil
+	}
+	if it.Deleted {
+		return imp.deleteItem(ctx, it)
+	}
+	if it.Name == "" || it.GroupID == "" {
+		imp.res.Skipped++
+		return nil
+	}
+	catID, ok, err := imp.category(ctx, it.GroupID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		imp.r.log.Warn("exchange: item group is unknown, skipped",
+			slog.String("item", it.ExternalID), slog.String("group", it.GroupID))
+		imp.res.Skipped++
+		return nil
+	}
+
+	const (
+		returning = ` RETURNING product_id, name, price, category_id, image_url`
+		update    = `
+			UPDATE products SET name = $2, description = COALESCE($3, description), category_id = $4,
+				version = version + 1
+			WHERE product_id = $1` + returning
+		// цена и остаток придут с предложениями
+		insert = `
+			INSERT INTO products (name, price, description, category_id)
+			VALUES ($1, 0, $2, $3)` + returning
+	)
+	name := truncate(it.Name, maxNameLength)
+	id, found, err := imp.r.lookupTx(ctx, imp.tx, imp.source, domExchange.EntityProduct, it.ExternalID)
+	if err != nil {
+		return err
+	}
+	var row productDB
+	if found {
+		err = imp.r.getTx(ctx, imp.tx, &row, update, id, name, it.Description, catID)
+		if errors.Is(err, sql.ErrNoRows) {
+			found = false
+		} else if err != nil {
+			return err
+		}
+	}
+	event := webhook.EventProductUpdated
+	if !found {
+		if err := imp.r.getTx(ctx, imp.tx, &row, insert, name, it.Description, catID); err != nil {
+			return err
+		}
+		if err := imp.r.bindTx(ctx, imp.tx, imp.source, domExchange.EntityProduct, it.ExternalID, row.ID); err != nil {
+			return err
+		}
+		event = webhook.EventProductCreated
+	}
+
+	if err := imp.attributes(ctx, row.ID, catID, it.Properties); err != nil {
+		return err
+	}
+	if err := imp.r.enqueueTx(ctx, imp.tx, event, webhook.NewProductData(row.toDomain())); err != nil {
+		return err
+	}
+	imp.res.Products++
+	return nil
+}
+
+// attributes заменяет значения атрибутов, пришедших из source; атрибуты,
+// заведённые вручную, не трогаются. Несколько значений свойства
+// склеиваются через запятую.
+func (imp *catalogImport) attributes(ctx context.Context, productID, catID int64, values []domExchange.PropertyValue) error {
+	const detach = `
+		DELETE FROM product_attributes
+		WHERE product_id = $1 AND attribute_id IN (
+			SELECT entity_id FROM external_ids WHERE source = $2 AND entity = 'attribute'
+		)`
+	if _, err := imp.r.execTx(ctx, imp.tx, detach, productID, imp.source); err != nil {
+		return err
+	}
+
+	var (
+		order  []int64
+		byAttr = make(map[int64][]string)
+	)
+	for _, v := range values {
+		attrID, ok, err := imp.attribute(ctx, v.PropertyID, catID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if _, seen := byAttr[attrID]; !seen {
+			order = append(order, attrID)
+		}
+		byAttr[attrID] = append(byAttr[attrID], imp.catalog.PropertyValueText(v))
+	}
+	if len(order) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(order))
+	vals := make([]string, 0, len(order))
+	for _, attrID := range order {
+		ids = append(ids, attrID)
+		vals = append(vals, truncate(strings.Join(byAttr[attrID], ", "), maxValueLength))
+	}
+	const insert = `
+		INSERT INTO product_attributes (product_id, attribute_id, value)
+		SELECT $1, x.attribute_id, x.value FROM UNNEST($2::bigint[], $3::text[]) AS x(attribute_id, value)
+		ON CONFLICT (product_id, attribute_id) DO UPDATE SET value = EXCLUDED.value`
+	_, err := imp.r.execTx(ctx, imp.tx, insert, productID, pq.Array(ids), pq.Array(vals))
+	return err
+}
+
+// attribute находит или создаёт атрибут свойства в категории товара:
+// атрибуты в магазине принадлежат категории, свойства в 1С — общие, поэтому
+// внешний идентификатор атрибута — "<свойство>/<категория>".
+func (imp *catalogImport) attribute(ctx context.Context, propertyID string, catID int64) (int64, bool, error) {
+	key := propertyID + "/" + strconv.FormatInt(catID, 10)
+	if id, ok := imp.attrs[key]; ok {
+		return id, true, nil
+	}
+
+	var name string
+	for _, p := range imp.catalog.Properties {
+		if p.ExternalID == propertyID {
+			name = truncate(p.Name, maxNameLength)
+			break
+		}
+	}
+
+	id, found, err := imp.r.lookupTx(ctx, imp.tx, imp.source, domExchange.EntityAttribute, key)
+	if err != nil {
+		return 0, false, err
+	}
+	if found && name != "" {
+		const update = `UPDATE attributes SET name = $2 WHERE attribute_id = $1 AND category_id = $3`
+		if found, err = imp.r.execTx(ctx, imp.tx, update, id, name, catID); err != nil {
+			return 0, false, err
+		}
+	}
+	if !found {
+		// свойство без описания в классификаторе (выгрузка изменений) создать нельзя
+		if name == "" {
+			return 0, false, nil
+		}
+		const insert = `INSERT INTO attributes (name, category_id) VALUES ($1, $2) RETURNING attribute_id`
+		if err := imp.r.getTx(ctx, imp.tx, &id, insert, name, catID); err != nil {
+			return 0, false, err
+		}
+		if err := imp.r.bindTx(ctx, imp.tx, imp.source, domExchange.EntityAttribute, key, id); err != nil {
+			return 0, false, err
+		}
+		imp.res.Attributes++
+	}
+	imp.attrs[key] = id
+	return id, true, nil
+}
+
+func (imp *catalogImport) deleteItem(ctx context.Context, it domExchange.Item) error {
+	id, found, err := imp.r.lookupTx(ctx, imp.tx, imp.source, domExchange.EntityProduct, it.ExternalID)
+	if err != nil || !found {
+		return err
+	}
+	const (
+		inPresets = `SELECT EXISTS (SELECT 1 FROM preset_items WHERE product_id = $1)`
+		del       = `DELETE FROM products WHERE product_id = $1`
+	)
+	var used bool
+	if err := imp.r.getTx(ctx, imp.tx, &used, inPresets, id); err != nil {
+		return err
+	}
+	if used {
+		imp.r.log.Warn("exchange: deleted item is used in presets, kept", slog.Int64("product_id", id))
+		imp.res.Skipped++
+		return nil
+	}
+	deleted, err := imp.r.execTx(ctx, imp.tx, del, id)
+	if err != nil || !deleted {
+		return err
+	}
+	if err := imp.r.enqueueTx(ctx, imp.tx, webhook.EventProductDeleted, webhook.DeletedData{ID: id}); err != nil {
+		return err
+	}
+	imp.res.Deleted++
+	return nil
+}
+
+// ImportOffers обновляет цены и остатки товаров, загруженных из source.
+// Предложения по неизвестным товарам пропускаются.
+func (r *PGExchangeRepository) ImportOffers(ctx context.Context, source string, offers []domExchange.Offer) (domExchange.ImportResult, error) {
+	const q = `
+		WITH old AS (SELECT price FROM products WHERE product_id = $1 FOR UPDATE)
+		UPDATE products p SET price = COALESCE($2, p.price), stock = $3, version = p.version + 1
+		FROM old WHERE p.product_id = $1
+		RETURNING old.price AS old_price, p.price AS price`
+	return tx.RunInTx(ctx, r.db, func(tx *sqlx.Tx) (domExchange.ImportResult, error) {
+		var res domExchange.ImportResult
+		for _, o := range offers {
+			id, found, err := r.lookupTx(ctx, tx, source, domExchange.EntityProduct, o.ProductID)
+			if err != nil {
+				return res, fmt.Errorf("offer %s: %w", o.ProductID, err)
+			}
+			var prices struct {
+				Old decimal.Decimal `db:"old_price"`
+				New decimal.Decimal `db:"price"`
+			}
+			if found {
+				err = r.getTx(ctx, tx, &prices, q, id, o.Price, o.Quantity)
+				if errors.Is(err, sql.ErrNoRows) {
+					found = false
+				} else if err != nil {
+					return res, fmt.Errorf("offer %s: %w", o.ProductID, err)
+				}
+			}
+			if !found {
+				res.Skipped++
+				continue
+			}
+			if !prices.Old.Equal(prices.New) {
+				if err := r.enqueueTx(ctx, tx, webhook.EventPriceUpdated, webhook.PriceData{
+					Entity:   money.EntityProduct,
+					EntityID: id,
+					Currency: money.Base,
+					Price:    &prices.New,
+					OldPrice: &prices.Old,
+				}); err != nil {
+					return res, err
+				}
+			}
+			res.Offers++
+		}
+		return res, nil
+	})
+}
+
+// PendingOrders возвращает заявки, ещё не выгруженные в 1С или изменённые
+// после выгрузки; спам не выгружается.
+func (r *PGExchangeRepository) PendingOrders(ctx context.Context, source string, limit int) ([]domExchange.Order, error) {
+	const q = `
+		SELECT l.lead_id, l.kind, l.name, l.phone, l.email, l.message, l.promo_code,
+		       l.status, l.created_at, l.updated_at,
+		       CASE
+		           WHEN p.product_id IS NOT NULL THEN COALESCE(x.external_id, 'product-' || p.product_id)
+		           WHEN ps.preset_id IS NOT NULL THEN 'preset-' || ps.preset_id
+		       END AS item_id,
+		       COALESCE(p.name, ps.name) AS item_name,
+		       COALESCE(p.price, ps.total_price) AS item_price
+		FROM leads l
+		LEFT JOIN products p ON p.product_id = l.product_id
+		LEFT JOIN presets ps ON ps.preset_id = l.preset_id
+		LEFT JOIN external_ids x
+		       ON x.source = $1 AND x.entity = 'product' AND x.entity_id = p.product_id
+		WHERE l.status <> 'spam'
+		  AND (l.exported_at IS NULL OR l.updated_at > l.exported_at)
+		ORDER BY l.lead_id
+		LIMIT $2`
+	var raws []orderDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, source, limit)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	orders := make([]domExchange.Order, 0, len(raws))
+	for _, raw := range raws {
+		orders = append(orders, raw.toDomain())
+	}
+	return orders, nil
+}
+
+// MarkOrdersExported отмечает заявки выгруженными на момент at — момент
+// запроса выгрузки, чтобы изменения, сделанные после него, ушли повторно.
+func (r *PGExchangeRepository) MarkOrdersExported(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	const q = `UPDATE leads SET exported_at = $2 WHERE lead_id = ANY($1)`
+	err := r.withQuery(ctx, q, func() error {
+		_, err := r.db.ExecContext(ctx, q, pq.Array(ids), at)
+		return err
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+func (r *PGExchangeRepository) lookupTx(ctx context.Context, tx *sqlx.Tx, source string, entity domExchange.Entity, externalID string) (int64, bool, error) {
+	const q = `SELECT entity_id FROM external_ids WHERE source = $1 AND entity = $2 AND external_id = $3`
+	var id int64
+	err := r.getTx(ctx, tx, &id, q, source, string(entity), externalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+func (r *PGExchangeRepository) bindTx(ctx context.Context, tx *sqlx.Tx, source string, entity domExchange.Entity, externalID string, id int64) error {
+	const q = `
+		INSERT INTO external_ids (source, entity, external_id, entity_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source, entity, external_id) DO UPDATE SET entity_id = EXCLUDED.entity_id, updated_at = now()`
+	_, err := r.execTx(ctx, tx, q, source, string(entity), externalID, id)
+	return err
+}
+
+// getTx возвращает sql.ErrNoRows как есть: вызывающий решает, ошибка ли это.
+func (r *PGExchangeRepository) getTx(ctx context.Context, tx *sqlx.Tx, dest any, q string, args ...any) error {
+	err := r.withQuery(ctx, q, func() error {
+		return tx.GetContext(ctx, dest, q, args...)
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	return err
+}
+
+// execTx сообщает, затронул ли запрос хотя бы одну строку.
+func (r *PGExchangeRepository) execTx(ctx context.Context, tx *sqlx.Tx, q string, args ...any) (bool, error) {
+	var cnt int64
+	err := r.withQuery(ctx, q, func() error {
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		cnt, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return false, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return cnt > 0, nil
+}
+
+func (r *PGExchangeRepository) enqueueTx(ctx context.Context, tx *sqlx.Tx, typ webhook.EventType, data any) error {
+	if err := outbox.Enqueue(ctx, tx, typ, data); err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	return nil
+}
+
+func (r *PGExchangeRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,214 @@
+package exchange_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	exchangeRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/exchange"
+)
+
+type PGExchangeRepositorySuite struct {
+	suite.Suite
+	repo *exchangeRepo.PGExchangeRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+	db   *sqlx.DB
+}
+
+func (s *PGExchangeRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	s.repo = exchangeRepo.NewPGExchangeRepository(s.db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGExchangeRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+// catalog строит каталог с уникальными идентификаторами, чтобы тесты не пересекались.
+func (s *PGExchangeRepositorySuite) catalog() (*domExchange.Catalog, string) {
+	p := fmt.Sprintf("t%d", time.Now().UnixNano())
+	desc := "Матовый"
+	return &domExchange.Catalog{
+		Groups: []domExchange.Group{
+			{ExternalID: p + "-g1", Name: p + " Плитка"},
+			{ExternalID: p + "-g2", ParentID: p + "-g1", Name: p + " Напольная"},
+		},
+		Properties: []domExchange.Property{
+			{ExternalID: p + "-color", Name: "Цвет", Values: map[string]string{p + "-white": "Белый"}},
+		},
+		Items: []domExchange.Item{
+			{ExternalID: p + "-i1", Name: "Керамогранит", Description: &desc, GroupID: p + "-g2",
+				Properties: []domExchange.PropertyValue{{PropertyID: p + "-color", Value: p + "-white"}}},
+			{ExternalID: p + "-i2", Name: "Без группы"},
+		},
+	}, p
+}
+
+func (s *PGExchangeRepositorySuite) productID(externalID string) int64 {
+	var id int64
+	require.NoError(s.T(), s.db.Get(&id,
+		`SELECT entity_id FROM external_ids WHERE source = '1c' AND entity = 'product' AND external_id = $1`, externalID))
+	return id
+}
+
+func (s *PGExchangeRepositorySuite) Test_ImportCatalogIsIdempotent() {
+	c, p := s.catalog()
+
+	res, err := s.repo.ImportCatalog(s.ctx, domExchange.Source1C, c)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domExchange.ImportResult{Categories: 2, Attributes: 1, Products: 1, Skipped: 1}, res)
+
+	id := s.productID(p + "-i1")
+	var parent int64
+	require.NoError(s.T(), s.db.Get(&parent,
+		`SELECT c.parent_id FROM products pr JOIN categories c USING (category_id) WHERE pr.product_id = $1`, id))
+	var value string
+	require.NoError(s.T(), s.db.Get(&value, `SELECT value FROM product_attributes WHERE product_id = $1`, id))
+	require.Equal(s.T(), "Белый", value)
+
+	// повторная загрузка с переименованием обновляет, а не дублирует
+	c.Items[0].Name = "Керамогранит 60x60"
+	res, err = s.repo.ImportCatalog(s.ctx, domExchange.Source1C, c)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, res.Attributes)
+	require.Equal(s.T(), id, s.productID(p+"-i1"))
+
+	var cnt int
+	require.NoError(s.T(), s.db.Get(&cnt, `SELECT count(*) FROM product_attributes WHERE product_id = $1`, id))
+	require.Equal(s.T(), 1, cnt)
+	var name string
+	require.NoError(s.T(), s.db.Get(&name, `SELECT name FROM products WHERE product_id = $1`, id))
+	require.Equal(s.T(), "Керамогранит 60x60", name)
+}
+
+func (s *PGExchangeRepositorySuite) Test_ImportOffersAndDelete() {
+	c, p := s.catalog()
+	_, err := s.repo.ImportCatalog(s.ctx, domExchange.Source1C, c)
+	require.NoError(s.T(), err)
+	id := s.productID(p + "-i1")
+
+	price, qty := decimal.RequireFromString("1290.50"), decimal.RequireFromString("7.5")
+	res, err := s.repo.ImportOffers(s.ctx, domExchange.Source1C, []domExchange.Offer{
+		{ProductID: p + "-i1", Price: &price, Quantity: &qty},
+		{ProductID: p + "-unknown", Price: &price},
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domExchange.ImportResult{Offers: 1, Skipped: 1}, res)
+
+	var row struct {
+		Price decimal.Decimal `db:"price"`
+		Stock decimal.Decimal `db:"stock"`
+	}
+	require.NoError(s.T(), s.db.Get(&row, `SELECT price, stock FROM products WHERE product_id = $1`, id))
+	require.True(s.T(), price.Equal(row.Price))
+	require.True(s.T(), qty.Equal(row.Stock))
+
+	var events int
+	require.NoError(s.T(), s.db.Get(&events,
+		`SELECT count(*) FROM outbox_events WHERE event_type = 'price.updated' AND (payload->>'entity_id')::bigint = $1`, id))
+	require.Equal(s.T(), 1, events)
+
+	c.Items[0].Deleted = true
+	res, err = s.repo.ImportCatalog(s.ctx, domExchange.Source1C, &domExchange.Catalog{Items: c.Items[:1]})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, res.Deleted)
+	require.NoError(s.T(), s.db.Get(&events, `SELECT count(*) FROM external_ids WHERE entity = 'product' AND entity_id = $1`, id))
+	require.Zero(s.T(), events)
+}
+
+// Загрузка меняет товары и категории так же, как админка, поэтому поднимает
+// версию: ETag, прочитанный до синхронизации, больше не подходит.
+func (s *PGExchangeRepositorySuite) Test_ImportBumpsVersions() {
+	c, p := s.catalog()
+	_, err := s.repo.ImportCatalog(s.ctx, domExchange.Source1C, c)
+	require.NoError(s.T(), err)
+	id := s.productID(p + "-i1")
+
+	versions := func() (product, category int64) {
+		require.NoError(s.T(), s.db.QueryRow(
+			`SELECT p.version, c.version FROM products p JOIN categories c USING (category_id) WHERE p.product_id = $1`, id,
+		).Scan(&product, &category))
+		return product, category
+	}
+	product, category := versions()
+
+	c.Items[0].Name = "Керамогранит 60x60"
+	c.Groups[1].Name = p + " Напольная плитка"
+	_, err = s.repo.ImportCatalog(s.ctx, domExchange.Source1C, c)
+	require.NoError(s.T(), err)
+	gotProduct, gotCategory := versions()
+	require.Equal(s.T(), product+1, gotProduct)
+	require.Equal(s.T(), category+1, gotCategory)
+
+	price := decimal.RequireFromString("990")
+	_, err = s.repo.ImportOffers(s.ctx, domExchange.Source1C, []domExchange.Offer{{ProductID: p + "-i1", Price: &price}})
+	require.NoError(s.T(), err)
+	gotProduct, _ = versions()
+	require.Equal(s.T(), product+2, gotProduct)
+}
+
+func (s *PGExchangeRepositorySuite) Test_PendingOrders() {
+	c, p := s.catalog()
+	_, err := s.repo.ImportCatalog(s.ctx, domExchange.Source1C, c)
+	require.NoError(s.T(), err)
+	productID := s.productID(p + "-i1")
+
+	var leadID int64
+	require.NoError(s.T(), s.db.Get(&leadID,
+		`INSERT INTO leads (kind, name, phone, product_id) VALUES ('callback', 'Иван', '+77010000000', $1) RETURNING lead_id`,
+		productID))
+	_, err = s.db.Exec(`INSERT INTO leads (kind, name, phone, status) VALUES ('callback', 'Бот', '+77010000001', 'spam')`)
+	require.NoError(s.T(), err)
+
+	find := func() *domExchange.Order {
+		orders, err := s.repo.PendingOrders(s.ctx, domExchange.Source1C, 1000)
+		require.NoError(s.T(), err)
+		for i := range orders {
+			require.NotEqual(s.T(), "Бот", orders[i].Lead.Name)
+			if orders[i].Lead.ID == leadID {
+				return &orders[i]
+			}
+		}
+		return nil
+	}
+
+	order := find()
+	require.NotNil(s.T(), order)
+	require.Len(s.T(), order.Items, 1)
+	require.Equal(s.T(), p+"-i1", order.Items[0].ExternalID)
+
+	require.NoError(s.T(), s.repo.MarkOrdersExported(s.ctx, []int64{leadID}, time.Now()))
+	require.Nil(s.T(), find())
+
+	// изменённая после выгрузки заявка уходит повторно
+	_, err = s.db.Exec(`UPDATE leads SET status = 'in_progress', updated_at = now() + interval '1 second' WHERE lead_id = $1`, leadID)
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), find())
+}
+
+func TestPGExchangeRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGExchangeRepositorySuite))
+}
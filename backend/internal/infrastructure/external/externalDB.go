@@ -0,0 +1,41 @@
+package external
+
+import (
+	"time"
+
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+)
+
+type refDB struct {
+	Source     string    `db:"source"`
+	Entity     string    `db:"entity"`
+	ExternalID string    `db:"external_id"`
+	EntityID   int64     `db:"entity_id"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (r refDB) toDomain() domExternal.Ref {
+	return domExternal.Ref{
+		Source:     r.Source,
+		ExternalID: r.ExternalID,
+		Entity:     domExternal.Entity(r.Entity),
+		EntityID:   r.EntityID,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+func rawRefListToDomain(raws []refDB) []domExternal.Ref {
+	res := make([]domExternal.Ref, len(raws))
+	for i, raw := range raws {
+		res[i] = raw.toDomain()
+	}
+	return res
+}
+
+// entityTables — таблица и ключ сущности: привязать идентификатор можно
+// только к существующей записи.
+var entityTables = map[domExternal.Entity]struct{ table, key string }{
+	domExternal.EntityCategory: {"categories", "category_id"},
+	domExternal.EntityProduct:  {"products", "product_id"},
+	domExternal.EntityService:  {"services", "service_id"},
+}
@@ -0,0 +1,142 @@
+package external
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGExternalRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGExternalRepository(db *sqlx.DB, log *slog.Logger) *PGExternalRepository {
+	if db == nil {
+		panic("NewPGExternalRepository: db is nil")
+	}
+	return &PGExternalRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Resolve возвращает ID сущности по её внешнему идентификатору.
+func (r *PGExternalRepository) Resolve(ctx context.Context, source string, entity domExternal.Entity, externalID string) (int64, error) {
+	const q = `SELECT entity_id FROM external_ids WHERE source = $1 AND entity = $2 AND external_id = $3`
+	var id int64
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &id, q, source, string(entity), externalID)
+	})
+	if err != nil {
+		return 0, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return id, nil
+}
+
+// Bind привязывает внешний идентификатор к сущности; уже занятый
+// идентификатор перепривязывается. Несуществующая сущность — ErrNotFound.
+func (r *PGExternalRepository) Bind(ctx context.Context, ref *domExternal.Ref) (*domExternal.Ref, error) {
+	t, ok := entityTables[ref.Entity]
+	if !ok {
+		return nil, app_error.ErrBadRequest
+	}
+	q := fmt.Sprintf(`
+		INSERT INTO external_ids (source, entity, external_id, entity_id)
+		SELECT $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM %s WHERE %s = $4)
+		ON CONFLICT (source, entity, external_id)
+		DO UPDATE SET entity_id = EXCLUDED.entity_id, updated_at = now()
+		RETURNING updated_at
+	`, t.table, t.key)
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q, ref.Source, string(ref.Entity), ref.ExternalID, ref.EntityID).
+			Scan(&ref.UpdatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return ref, nil
+}
+
+func (r *PGExternalRepository) Unbind(ctx context.Context, source string, entity domExternal.Entity, externalID string) error {
+	const q = `DELETE FROM external_ids WHERE source = $1 AND entity = $2 AND external_id = $3`
+	var affected int64
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, source, string(entity), externalID)
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	if affected == 0 {
+		return app_error.ErrNotFound
+	}
+	return nil
+}
+
+// ListByEntity — все внешние идентификаторы сущности из всех источников.
+func (r *PGExternalRepository) ListByEntity(ctx context.Context, entity domExternal.Entity, entityID int64) ([]domExternal.Ref, error) {
+	const q = `
+		SELECT source, entity, external_id, entity_id, updated_at
+		FROM external_ids
+		WHERE entity = $1 AND entity_id = $2
+		ORDER BY source, external_id
+	`
+	var raws []refDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(entity), entityID)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawRefListToDomain(raws), nil
+}
+
+// Lock берёт advisory-блокировку на внешний идентификатор, чтобы два
+// одновременных upsert не создали две сущности. Блокировка сессионная и
+// живёт на отдельном соединении до вызова unlock.
+func (r *PGExternalRepository) Lock(ctx context.Context, source string, entity domExternal.Entity, externalID string) (func(), error) {
+	const (
+		lock   = `SELECT pg_advisory_lock(hashtextextended($1, 0))`
+		unlock = `SELECT pg_advisory_unlock(hashtextextended($1, 0))`
+	)
+	key := source + "\x00" + string(entity) + "\x00" + externalID
+
+	conn, err := r.db.Connx(ctx)
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	err = r.withQuery(ctx, lock, func() error {
+		_, err := conn.ExecContext(ctx, lock, key)
+		return err
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return func() {
+		// контекст запроса к этому моменту может быть отменён, а снять блокировку нужно
+		if _, err := conn.ExecContext(context.Background(), unlock, key); err != nil {
+			r.log.Warn("advisory unlock failed", slog.Any("error", err))
+			// соединение с неснятой блокировкой не должно вернуться в пул
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}, nil
+}
+
+func (r *PGExternalRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
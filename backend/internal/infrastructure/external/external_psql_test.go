@@ -0,0 +1,111 @@
+package external_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	externalRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/external"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGExternalRepositorySuite struct {
+	suite.Suite
+	repo *externalRepo.PGExternalRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+	db   *sqlx.DB
+}
+
+func (s *PGExternalRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	s.repo = externalRepo.NewPGExternalRepository(s.db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGExternalRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGExternalRepositorySuite) service() (int64, string) {
+	name := fmt.Sprintf("svc-%d", time.Now().UnixNano())
+	var id int64
+	require.NoError(s.T(), s.db.Get(&id, `INSERT INTO services (name, price) VALUES ($1, 100) RETURNING service_id`, name))
+	return id, name
+}
+
+func (s *PGExternalRepositorySuite) Test_BindResolveUnbind() {
+	id, ext := s.service()
+
+	ref, err := s.repo.Bind(s.ctx, &domExternal.Ref{Source: "pim", Entity: domExternal.EntityService, ExternalID: ext, EntityID: id})
+	require.NoError(s.T(), err)
+	require.False(s.T(), ref.UpdatedAt.IsZero())
+
+	got, err := s.repo.Resolve(s.ctx, "pim", domExternal.EntityService, ext)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), id, got)
+
+	refs, err := s.repo.ListByEntity(s.ctx, domExternal.EntityService, id)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), refs, 1)
+
+	require.NoError(s.T(), s.repo.Unbind(s.ctx, "pim", domExternal.EntityService, ext))
+	require.ErrorIs(s.T(), s.repo.Unbind(s.ctx, "pim", domExternal.EntityService, ext), app_error.ErrNotFound)
+	_, err = s.repo.Resolve(s.ctx, "pim", domExternal.EntityService, ext)
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *PGExternalRepositorySuite) Test_BindMissingEntity() {
+	_, err := s.repo.Bind(s.ctx, &domExternal.Ref{Source: "pim", Entity: domExternal.EntityProduct, ExternalID: "ghost", EntityID: 1 << 40})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *PGExternalRepositorySuite) Test_DeleteEntityDropsBinding() {
+	id, ext := s.service()
+	_, err := s.repo.Bind(s.ctx, &domExternal.Ref{Source: "pim", Entity: domExternal.EntityService, ExternalID: ext, EntityID: id})
+	require.NoError(s.T(), err)
+
+	_, err = s.db.Exec(`DELETE FROM services WHERE service_id = $1`, id)
+	require.NoError(s.T(), err)
+	_, err = s.repo.Resolve(s.ctx, "pim", domExternal.EntityService, ext)
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *PGExternalRepositorySuite) Test_LockSerializes() {
+	unlock, err := s.repo.Lock(s.ctx, "pim", domExternal.EntityProduct, "locked")
+	require.NoError(s.T(), err)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 200*time.Millisecond)
+	defer cancel()
+	_, err = s.repo.Lock(ctx, "pim", domExternal.EntityProduct, "locked")
+	require.Error(s.T(), err, "second lock must wait for the first")
+
+	unlock()
+	unlock2, err := s.repo.Lock(s.ctx, "pim", domExternal.EntityProduct, "locked")
+	require.NoError(s.T(), err)
+	unlock2()
+}
+
+func TestPGExternalRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGExternalRepositorySuite))
+}
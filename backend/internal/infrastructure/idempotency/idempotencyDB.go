@@ -0,0 +1,41 @@
+package idempotency
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+)
+
+type recordDB struct {
+	Key         string        `db:"idempotency_key"`
+	Fingerprint string        `db:"fingerprint"`
+	StatusCode  sql.NullInt32 `db:"status_code"`
+	Headers     []byte        `db:"headers"`
+	Body        []byte        `db:"body"`
+	CreatedAt   time.Time     `db:"created_at"`
+	CompletedAt sql.NullTime  `db:"completed_at"`
+}
+
+func (r recordDB) toDomain() (*domIdempotency.Record, error) {
+	rec := &domIdempotency.Record{
+		Key:         r.Key,
+		Fingerprint: r.Fingerprint,
+		CreatedAt:   r.CreatedAt,
+	}
+	if r.CompletedAt.Valid {
+		rec.CompletedAt = &r.CompletedAt.Time
+	}
+	if !r.StatusCode.Valid {
+		return rec, nil
+	}
+	resp := &domIdempotency.Response{StatusCode: int(r.StatusCode.Int32), Body: r.Body}
+	if len(r.Headers) > 0 {
+		if err := json.Unmarshal(r.Headers, &resp.Headers); err != nil {
+			return nil, err
+		}
+	}
+	rec.Response = resp
+	return rec, nil
+}
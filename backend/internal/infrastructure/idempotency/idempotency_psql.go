@@ -0,0 +1,130 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+)
+
+type PGIdempotencyRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGIdempotencyRepository(db *sqlx.DB, log *slog.Logger) *PGIdempotencyRepository {
+	if db == nil {
+		panic("NewPGIdempotencyRepository: db is nil")
+	}
+	return &PGIdempotencyRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Reserve занимает ключ под новый запрос. Если ключ уже занят и не истёк
+// (создан не раньше expiredBefore), возвращается существующая запись и false.
+func (r *PGIdempotencyRepository) Reserve(ctx context.Context, key, fingerprint string, expiredBefore time.Time) (*domIdempotency.Record, bool, error) {
+	const insert = `
+		INSERT INTO idempotency_keys (idempotency_key, fingerprint)
+		VALUES ($1, $2)
+		ON CONFLICT (idempotency_key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, headers = NULL, body = NULL,
+		    created_at = now(), completed_at = NULL
+		WHERE idempotency_keys.created_at < $3
+		RETURNING created_at
+	`
+	const selectRecord = `
+		SELECT idempotency_key, fingerprint, status_code, headers, body, created_at, completed_at
+		FROM idempotency_keys
+		WHERE idempotency_key = $1
+	`
+	// между неудачной вставкой и чтением ключ могут освободить — тогда пробуем ещё раз
+	for attempt := 0; attempt < 2; attempt++ {
+		var createdAt time.Time
+		err := r.withQuery(ctx, insert, func() error {
+			return r.db.QueryRowContext(ctx, insert, key, fingerprint, expiredBefore).Scan(&createdAt)
+		})
+		if err == nil {
+			return &domIdempotency.Record{Key: key, Fingerprint: fingerprint, CreatedAt: createdAt}, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, repoError.MapPostgreSQLError(r.log, err)
+		}
+
+		var raw recordDB
+		err = r.withQuery(ctx, selectRecord, func() error {
+			return r.db.GetContext(ctx, &raw, selectRecord, key)
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, repoError.MapPostgreSQLError(r.log, err)
+		}
+		rec, err := raw.toDomain()
+		if err != nil {
+			return nil, false, repoError.MapPostgreSQLError(r.log, err)
+		}
+		return rec, false, nil
+	}
+	return nil, false, repoError.MapPostgreSQLError(r.log, sql.ErrNoRows)
+}
+
+// Complete сохраняет ответ на запрос, занявший ключ.
+func (r *PGIdempotencyRepository) Complete(ctx context.Context, key string, resp domIdempotency.Response) error {
+	const q = `
+		UPDATE idempotency_keys
+		SET status_code = $2, headers = $3, body = $4, completed_at = now()
+		WHERE idempotency_key = $1 AND status_code IS NULL
+	`
+	headers, err := json.Marshal(resp.Headers)
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	err = r.withQuery(ctx, q, func() error {
+		_, err := r.db.ExecContext(ctx, q, key, resp.StatusCode, headers, resp.Body)
+		return err
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+// Release освобождает ключ незавершённого запроса, чтобы его можно было повторить.
+func (r *PGIdempotencyRepository) Release(ctx context.Context, key string) error {
+	const q = `DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND status_code IS NULL`
+	err := r.withQuery(ctx, q, func() error {
+		_, err := r.db.ExecContext(ctx, q, key)
+		return err
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+// DeleteExpired удаляет ключи, созданные раньше before.
+func (r *PGIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	const q = `DELETE FROM idempotency_keys WHERE created_at < $1`
+	var affected int64
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, before)
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return affected, nil
+}
+
+func (r *PGIdempotencyRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
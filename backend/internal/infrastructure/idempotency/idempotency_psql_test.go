@@ -0,0 +1,95 @@
+package idempotency_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	idempotencyRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/idempotency"
+)
+
+type PGIdempotencyRepositorySuite struct {
+	suite.Suite
+	repo *idempotencyRepo.PGIdempotencyRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+}
+
+func (s *PGIdempotencyRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.repo = idempotencyRepo.NewPGIdempotencyRepository(srv.App.DB(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGIdempotencyRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func key() string {
+	return fmt.Sprintf("key-%d", time.Now().UnixNano())
+}
+
+func (s *PGIdempotencyRepositorySuite) Test_ReserveCompleteReplay() {
+	k, fp := key(), fmt.Sprintf("%064d", 1)
+	past := time.Now().Add(-time.Hour)
+
+	_, reserved, err := s.repo.Reserve(s.ctx, k, fp, past)
+	require.NoError(s.T(), err)
+	require.True(s.T(), reserved)
+
+	rec, reserved, err := s.repo.Reserve(s.ctx, k, fp, past)
+	require.NoError(s.T(), err)
+	require.False(s.T(), reserved)
+	require.False(s.T(), rec.Completed())
+
+	resp := domIdempotency.Response{StatusCode: 201, Headers: map[string]string{"Location": "/api/product/1"}, Body: []byte(`{"id":1}`)}
+	require.NoError(s.T(), s.repo.Complete(s.ctx, k, resp))
+
+	rec, _, err = s.repo.Reserve(s.ctx, k, fp, past)
+	require.NoError(s.T(), err)
+	require.True(s.T(), rec.Completed())
+	require.Equal(s.T(), resp, *rec.Response)
+}
+
+func (s *PGIdempotencyRepositorySuite) Test_ReleaseAndExpiry() {
+	k, fp := key(), fmt.Sprintf("%064d", 2)
+	past := time.Now().Add(-time.Hour)
+
+	_, _, err := s.repo.Reserve(s.ctx, k, fp, past)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.repo.Release(s.ctx, k))
+	_, reserved, err := s.repo.Reserve(s.ctx, k, fp, past)
+	require.NoError(s.T(), err)
+	require.True(s.T(), reserved, "released key can be reused")
+
+	// истёкший ключ занимается заново, даже с другим отпечатком
+	_, reserved, err = s.repo.Reserve(s.ctx, k, fmt.Sprintf("%064d", 3), time.Now().Add(time.Minute))
+	require.NoError(s.T(), err)
+	require.True(s.T(), reserved)
+
+	n, err := s.repo.DeleteExpired(s.ctx, time.Now().Add(time.Minute))
+	require.NoError(s.T(), err)
+	require.Positive(s.T(), n)
+}
+
+func TestPGIdempotencyRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGIdempotencyRepositorySuite))
+}
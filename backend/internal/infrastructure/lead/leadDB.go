@@ -0,0 +1,67 @@
+package lead
+
+import (
+	"database/sql"
+	"time"
+
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+)
+
+type leadDB struct {
+	ID          int64          `db:"lead_id"`
+	Kind        string         `db:"kind"`
+	Name        string         `db:"name"`
+	Phone       string         `db:"phone"`
+	Email       sql.NullString `db:"email"`
+	Message     sql.NullString `db:"message"`
+	PresetID    sql.NullInt64  `db:"preset_id"`
+	ProductID   sql.NullInt64  `db:"product_id"`
+	PromoCode   sql.NullString `db:"promo_code"`
+	Status      string         `db:"status"`
+	ManagerNote sql.NullString `db:"manager_note"`
+	SourceIP    sql.NullString `db:"source_ip"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func (l leadDB) toDomain() *domLead.Lead {
+	d := &domLead.Lead{
+		ID:        l.ID,
+		Kind:      domLead.Kind(l.Kind),
+		Name:      l.Name,
+		Phone:     l.Phone,
+		Status:    domLead.Status(l.Status),
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+	if l.Email.Valid {
+		d.Email = &l.Email.String
+	}
+	if l.Message.Valid {
+		d.Message = &l.Message.String
+	}
+	if l.PresetID.Valid {
+		d.PresetID = &l.PresetID.Int64
+	}
+	if l.ProductID.Valid {
+		d.ProductID = &l.ProductID.Int64
+	}
+	if l.PromoCode.Valid {
+		d.PromoCode = &l.PromoCode.String
+	}
+	if l.ManagerNote.Valid {
+		d.ManagerNote = &l.ManagerNote.String
+	}
+	if l.SourceIP.Valid {
+		d.SourceIP = &l.SourceIP.String
+	}
+	return d
+}
+
+func rawLeadListToDomain(raws []leadDB) []domLead.Lead {
+	leads := make([]domLead.Lead, len(raws))
+	for i, r := range raws {
+		leads[i] = *r.toDomain()
+	}
+	return leads
+}
@@ -0,0 +1,159 @@
+package lead
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/outbox"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
+)
+
+const selectLead = `
+	SELECT lead_id, kind, name, phone, email, message, preset_id, product_id,
+	       promo_code, status, manager_note, source_ip, created_at, updated_at
+	FROM leads
+`
+
+type PGLeadRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGLeadRepository(db *sqlx.DB, log *slog.Logger) *PGLeadRepository {
+	if db == nil {
+		panic("NewPGLeadRepository: db is nil")
+	}
+	return &PGLeadRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create сохраняет заявку и в той же транзакции погашает её промокод
+// и пишет lead.created в outbox (кроме заявок, помеченных как спам).
+// Если код истёк или исчерпан, заявка не сохраняется: ErrPromoCodeUnavailable.
+func (r *PGLeadRepository) Create(ctx context.Context, l *domLead.Lead) (*domLead.Lead, error) {
+	const q = `
+		INSERT INTO leads (kind, name, phone, email, message, preset_id, product_id, promo_code, status, source_ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING lead_id, created_at, updated_at
+	`
+	return tx.RunInTx(ctx, r.db, func(tx *sqlx.Tx) (*domLead.Lead, error) {
+		if l.PromoCode != nil {
+			if err := r.redeemPromoCodeTx(ctx, tx, *l.PromoCode); err != nil {
+				return nil, err
+			}
+		}
+		err := r.withQuery(ctx, q, func() error {
+			return tx.QueryRowContext(ctx, q,
+				string(l.Kind), l.Name, l.Phone, l.Email, l.Message,
+				l.PresetID, l.ProductID, l.PromoCode, string(l.Status), l.SourceIP,
+			).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
+		})
+		if err != nil {
+			return nil, repoError.MapPostgreSQLError(r.log, err)
+		}
+		if l.Status != domLead.StatusSpam {
+			if err := outbox.Enqueue(ctx, tx, webhook.EventLeadCreated, webhook.NewLeadData(l)); err != nil {
+				return nil, repoError.MapPostgreSQLError(r.log, err)
+			}
+		}
+		return l, nil
+	})
+}
+
+func (r *PGLeadRepository) redeemPromoCodeTx(ctx context.Context, tx *sqlx.Tx, code string) error {
+	const q = `
+		UPDATE promo_codes pc
+		   SET used_count = pc.used_count + 1
+		  FROM discount_rules r
+		 WHERE pc.code = $1 AND r.rule_id = pc.rule_id
+		   AND (pc.usage_limit IS NULL OR pc.used_count < pc.usage_limit)
+		   AND (r.starts_at IS NULL OR r.starts_at <= now())
+		   AND (r.ends_at IS NULL OR r.ends_at > now())
+	`
+	var cnt int64
+	err := r.withQuery(ctx, q, func() error {
+		res, err := tx.ExecContext(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		cnt, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	if cnt == 0 {
+		return domDiscount.ErrPromoCodeUnavailable
+	}
+	return nil
+}
+
+func (r *PGLeadRepository) Get(ctx context.Context, id int64) (*domLead.Lead, error) {
+	const q = selectLead + `WHERE lead_id = $1`
+	var raw leadDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &raw, q, id)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return raw.toDomain(), nil
+}
+
+// List возвращает заявки, новые сверху; пустой статус — все заявки.
+func (r *PGLeadRepository) List(ctx context.Context, status domLead.Status) ([]domLead.Lead, error) {
+	const q = selectLead + `WHERE ($1 = '' OR status = $1) ORDER BY created_at DESC, lead_id DESC`
+	var raws []leadDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(status))
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawLeadListToDomain(raws), nil
+}
+
+// UpdateStatus сохраняет новый статус, если заявка всё ещё в статусе prev,
+// и пишет lead.status_changed в outbox.
+// Иначе (заявку уже обработали параллельно) возвращает ErrNotFound.
+func (r *PGLeadRepository) UpdateStatus(ctx context.Context, l *domLead.Lead, prev domLead.Status) error {
+	const q = `
+		UPDATE leads
+		   SET status = $1, manager_note = $2, updated_at = $3
+		 WHERE lead_id = $4 AND status = $5
+	`
+	err := tx.RunInTxAction(ctx, r.db, func(tx *sqlx.Tx) error {
+		err := r.withQuery(ctx, q, func() error {
+			res, err := tx.ExecContext(ctx, q, string(l.Status), l.ManagerNote, l.UpdatedAt, l.ID, string(prev))
+			if err != nil {
+				return err
+			}
+			if cnt, _ := res.RowsAffected(); cnt == 0 {
+				return app_error.ErrNotFound
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, webhook.EventLeadStatusChanged, webhook.NewLeadData(l))
+	})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	return nil
+}
+
+func (r *PGLeadRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
@@ -0,0 +1,100 @@
+package lead_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	leadRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/lead"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGLeadRepositorySuite struct {
+	suite.Suite
+	repo *leadRepo.PGLeadRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+	db   *sqlx.DB
+}
+
+func (s *PGLeadRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	s.repo = leadRepo.NewPGLeadRepository(s.db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGLeadRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGLeadRepositorySuite) Test_CreateListUpdateStatus() {
+	email := "anna@example.com"
+	ip := "192.0.2.10"
+	created, err := s.repo.Create(s.ctx, &domLead.Lead{
+		Kind:     domLead.KindCallback,
+		Name:     "Анна",
+		Phone:    "+79001234567",
+		Email:    &email,
+		Status:   domLead.StatusNew,
+		SourceIP: &ip,
+	})
+	require.NoError(s.T(), err)
+	require.NotZero(s.T(), created.ID)
+
+	got, err := s.repo.Get(s.ctx, created.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), email, *got.Email)
+	require.Nil(s.T(), got.PresetID)
+
+	news, err := s.repo.List(s.ctx, domLead.StatusNew)
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), news)
+
+	note := "перезвонили"
+	require.NoError(s.T(), got.TransitionTo(domLead.StatusInProgress, &note, time.Now()))
+	require.NoError(s.T(), s.repo.UpdateStatus(s.ctx, got, domLead.StatusNew))
+
+	// второй апдейт с устаревшим prev не проходит
+	err = s.repo.UpdateStatus(s.ctx, got, domLead.StatusNew)
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+
+	got, err = s.repo.Get(s.ctx, created.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domLead.StatusInProgress, got.Status)
+	require.Equal(s.T(), note, *got.ManagerNote)
+}
+
+func (s *PGLeadRepositorySuite) Test_CreateWithMissingPreset() {
+	presetID := int64(999999)
+	_, err := s.repo.Create(s.ctx, &domLead.Lead{
+		Kind:     domLead.KindConsultation,
+		Name:     "Иван",
+		Phone:    "+79001234567",
+		PresetID: &presetID,
+		Status:   domLead.StatusNew,
+	})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func TestPGLeadRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGLeadRepositorySuite))
+}
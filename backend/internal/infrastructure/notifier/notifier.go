@@ -0,0 +1,93 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+)
+
+// Notifier отправляет уведомление о новой заявке в один канал.
+type Notifier interface {
+	NotifyLead(ctx context.Context, l *domLead.Lead) error
+}
+
+// New собирает уведомитель из включённых в конфиге каналов.
+// Если не включён ни один канал, возвращается Nop.
+func New(cfg config.Notifier, log *slog.Logger) (Notifier, error) {
+	var channels []Notifier
+	if cfg.SMTP.Enabled {
+		n, err := NewSMTP(cfg.SMTP)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, n)
+	}
+	if cfg.Telegram.Enabled {
+		n, err := NewTelegram(cfg.Telegram, nil)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, n)
+	}
+	if len(channels) == 0 {
+		log.Info("no notification channels enabled, leads will only be stored")
+		return Nop{}, nil
+	}
+	return Multi(channels), nil
+}
+
+// Nop ничего не отправляет.
+type Nop struct{}
+
+func (Nop) NotifyLead(context.Context, *domLead.Lead) error { return nil }
+
+// Multi рассылает уведомление во все каналы; ошибка одного канала
+// не мешает отправке в остальные.
+type Multi []Notifier
+
+func (m Multi) NotifyLead(ctx context.Context, l *domLead.Lead) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.NotifyLead(ctx, l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var kindTitles = map[domLead.Kind]string{
+	domLead.KindCallback:     "обратный звонок",
+	domLead.KindConsultation: "консультация дизайнера",
+}
+
+// formatLead возвращает тему и текст уведомления.
+func formatLead(l *domLead.Lead) (subject, body string) {
+	title, ok := kindTitles[l.Kind]
+	if !ok {
+		title = string(l.Kind)
+	}
+	subject = fmt.Sprintf("Новая заявка #%d: %s", l.ID, title)
+
+	var b strings.Builder
+	b.WriteString(subject + "\n\n")
+	fmt.Fprintf(&b, "Имя: %s\n", l.Name)
+	fmt.Fprintf(&b, "Телефон: %s\n", l.Phone)
+	if l.Email != nil {
+		fmt.Fprintf(&b, "Email: %s\n", *l.Email)
+	}
+	if l.PresetID != nil {
+		fmt.Fprintf(&b, "Пресет: #%d\n", *l.PresetID)
+	}
+	if l.ProductID != nil {
+		fmt.Fprintf(&b, "Товар: #%d\n", *l.ProductID)
+	}
+	if l.Message != nil && *l.Message != "" {
+		fmt.Fprintf(&b, "\n%s\n", *l.Message)
+	}
+	return subject, b.String()
+}
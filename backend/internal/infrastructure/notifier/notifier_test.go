@@ -0,0 +1,204 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/notifier"
+)
+
+func testLead() *domLead.Lead {
+	email := "client@example.com"
+	msg := "Хочу подобрать плитку для ванной"
+	presetID := int64(3)
+	return &domLead.Lead{
+		ID:       42,
+		Kind:     domLead.KindConsultation,
+		Name:     "Анна",
+		Phone:    "+7 900 123-45-67",
+		Email:    &email,
+		Message:  &msg,
+		PresetID: &presetID,
+	}
+}
+
+// fakeSMTP — минимальный SMTP-сервер, достаточный для net/smtp.SendMail.
+type fakeSMTP struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	rcpt []string
+	data string
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTP{ln: ln}
+	go s.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			s.mu.Lock()
+			s.rcpt = append(s.rcpt, strings.TrimSpace(line[len("RCPT TO:"):]))
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.mu.Lock()
+			s.data = b.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTP) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	srv := startFakeSMTP(t)
+	n, err := notifier.NewSMTP(config.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: srv.port(),
+		From: "shop@example.com",
+		To:   []string{"manager@example.com"},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, n.NotifyLead(ctx, testLead()))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Equal(t, []string{"<manager@example.com>"}, srv.rcpt)
+	require.Contains(t, srv.data, "Subject: =?utf-8?q?")
+	require.Contains(t, srv.data, "Телефон: +7 900 123-45-67")
+	require.Contains(t, srv.data, "Пресет: #3")
+}
+
+func TestSMTPNotifier_RequiresRecipients(t *testing.T) {
+	_, err := notifier.NewSMTP(config.SMTPConfig{Host: "127.0.0.1", Port: 25, From: "shop@example.com"})
+	require.Error(t, err)
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	var got map[string]string
+	var path string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = io.WriteString(w, `{"ok":true,"result":{}}`)
+	}))
+	defer api.Close()
+
+	n, err := notifier.NewTelegram(config.TelegramConfig{BotToken: "123:abc", ChatID: "-100500", APIURL: api.URL}, api.Client())
+	require.NoError(t, err)
+	require.NoError(t, n.NotifyLead(context.Background(), testLead()))
+
+	require.Equal(t, "/bot123:abc/sendMessage", path)
+	require.Equal(t, "-100500", got["chat_id"])
+	require.Contains(t, got["text"], "Новая заявка #42: консультация дизайнера")
+}
+
+func TestTelegramNotifier_APIError(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"ok":false,"description":"Bad Request: chat not found"}`)
+	}))
+	defer api.Close()
+
+	n, err := notifier.NewTelegram(config.TelegramConfig{BotToken: "secret-token", ChatID: "1", APIURL: api.URL}, api.Client())
+	require.NoError(t, err)
+	err = n.NotifyLead(context.Background(), testLead())
+	require.ErrorContains(t, err, "chat not found")
+	require.NotContains(t, err.Error(), "secret-token")
+}
+
+type failing struct{ calls *int }
+
+func (f failing) NotifyLead(context.Context, *domLead.Lead) error {
+	*f.calls++
+	return errors.New("channel down")
+}
+
+func TestMulti_ContinuesAfterFailure(t *testing.T) {
+	calls := 0
+	m := notifier.Multi{failing{&calls}, failing{&calls}}
+	err := m.NotifyLead(context.Background(), testLead())
+	require.Error(t, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestNew_NoChannels(t *testing.T) {
+	n, err := notifier.New(config.Notifier{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.IsType(t, notifier.Nop{}, n)
+}
+
+func TestNew_InvalidChannelConfig(t *testing.T) {
+	_, err := notifier.New(config.Notifier{
+		Telegram: config.TelegramConfig{Enabled: true},
+	}, slog.Default())
+	require.Error(t, err)
+}
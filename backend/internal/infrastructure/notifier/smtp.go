@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+)
+
+// SMTPNotifier отправляет заявки письмом через SMTP-сервер.
+// Авторизация PLAIN используется, только если задан username.
+type SMTPNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+func NewSMTP(cfg config.SMTPConfig) (*SMTPNotifier, error) {
+	if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("smtp notifier: host, from and to are required")
+	}
+	n := &SMTPNotifier{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from: cfg.From,
+		to:   cfg.To,
+	}
+	if cfg.Username != "" {
+		n.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return n, nil
+}
+
+func (n *SMTPNotifier) NotifyLead(ctx context.Context, l *domLead.Lead) error {
+	subject, body := formatLead(l)
+	msg := n.buildMessage(subject, body)
+
+	// net/smtp не умеет работать с контекстом, поэтому отправка идёт
+	// в отдельной горутине, а мы ждём её не дольше дедлайна ctx.
+	done := make(chan error, 1)
+	go func() { done <- smtp.SendMail(n.addr, n.auth, n.from, n.to, msg) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp notifier: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("smtp notifier: %w", ctx.Err())
+	}
+}
+
+func (n *SMTPNotifier) buildMessage(subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + n.from + "\r\n")
+	b.WriteString("To: " + strings.Join(n.to, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+)
+
+// TelegramNotifier отправляет заявки сообщением от бота в чат менеджеров.
+// APIURL можно переопределить, чтобы направить запросы на локальную заглушку.
+type TelegramNotifier struct {
+	endpoint string
+	chatID   string
+	client   *http.Client
+}
+
+// NewTelegram создаёт уведомитель; при client == nil используется
+// http.Client с таймаутом 10 секунд.
+func NewTelegram(cfg config.TelegramConfig, client *http.Client) (*TelegramNotifier, error) {
+	if cfg.BotToken == "" || cfg.ChatID == "" {
+		return nil, errors.New("telegram notifier: bot_token and chat_id are required")
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = "https://api.telegram.org"
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &TelegramNotifier{
+		endpoint: strings.TrimRight(apiURL, "/") + "/bot" + cfg.BotToken + "/sendMessage",
+		chatID:   cfg.ChatID,
+		client:   client,
+	}, nil
+}
+
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+func (n *TelegramNotifier) NotifyLead(ctx context.Context, l *domLead.Lead) error {
+	_, body := formatLead(l)
+	payload, err := json.Marshal(map[string]string{
+		"chat_id": n.chatID,
+		"text":    body,
+	})
+	if err != nil {
+		return fmt.Errorf("telegram notifier: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("telegram notifier: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// в тексте ошибки URL содержит токен бота — не пробрасываем его в логи
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("telegram notifier: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tr telegramResponse
+	_ = json.NewDecoder(resp.Body).Decode(&tr)
+	if resp.StatusCode != http.StatusOK || !tr.OK {
+		return fmt.Errorf("telegram notifier: status %d: %s", resp.StatusCode, tr.Description)
+	}
+	return nil
+}
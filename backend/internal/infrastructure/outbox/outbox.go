@@ -0,0 +1,46 @@
+// Package outbox записывает события для вебхуков в таблицу outbox_events.
+// Репозитории вызывают Enqueue внутри своей транзакции, поэтому событие
+// сохраняется тогда и только тогда, когда фиксируется само изменение.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+)
+
+const insertEvent = `INSERT INTO outbox_events (event_type, payload) VALUES ($1, $2)`
+
+type suppressKey struct{}
+
+// Suppress возвращает контекст, в котором Enqueue не пишет событий. Нужен
+// массовой заливке данных (store seed): подписчикам не нужны тысячи
+// product.created о демонстрационном каталоге.
+func Suppress(ctx context.Context) context.Context {
+	return context.WithValue(ctx, suppressKey{}, true)
+}
+
+func suppressed(ctx context.Context) bool {
+	v, _ := ctx.Value(suppressKey{}).(bool)
+	return v
+}
+
+// Enqueue сохраняет событие typ с данными data в транзакции tx. В контексте
+// Suppress событие пропускается.
+func Enqueue(ctx context.Context, tx sqlx.ExecerContext, typ domWebhook.EventType, data any) error {
+	if suppressed(ctx) {
+		return nil
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("outbox: marshal %s: %w", typ, err)
+	}
+	if _, err := tx.ExecContext(ctx, insertEvent, string(typ), payload); err != nil {
+		return err
+	}
+	return nil
+}
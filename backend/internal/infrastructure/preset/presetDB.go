@@ -4,17 +4,21 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
 	"github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/shopspring/decimal"
 )
 
 type presetDB struct {
-	ID          int64          `db:"preset_id"`
-	Name        string         `db:"name"`
-	Description sql.NullString `db:"description"`
-	TotalPrice  float64        `db:"total_price"`
-	ImageURL    sql.NullString `db:"image_url"`
-	CreatedAt   time.Time      `db:"created_at"`
+	ID          int64           `db:"preset_id"`
+	Name        string          `db:"name"`
+	Description sql.NullString  `db:"description"`
+	TotalPrice  decimal.Decimal `db:"total_price"`
+	ImageURL    sql.NullString  `db:"image_url"`
+	CreatedAt   time.Time       `db:"created_at"`
+	IsTemplate  bool            `db:"is_template"`
+	Version     int64           `db:"version"`
 }
 
 func (p presetDB) toDomain() *presetDom.Preset {
@@ -22,9 +26,11 @@ func (p presetDB) toDomain() *presetDom.Preset {
 		ID:          p.ID,
 		Name:        p.Name,
 		Description: optionalString(p.Description),
-		TotalPrice:  p.TotalPrice,
+		TotalPrice:  money.New(p.TotalPrice, money.Base),
 		ImageURL:    optionalString(p.ImageURL),
 		CreatedAt:   p.CreatedAt,
+		IsTemplate:  p.IsTemplate,
+		Version:     p.Version,
 	}
 }
 
@@ -38,12 +44,16 @@ func rawPresetListToDomain(raws []presetDB) []presetDom.Preset {
 }
 
 type presetItemDetailedDB struct {
-	ID           int64          `db:"preset_item_id"`
-	PresetID     int64          `db:"preset_id"`
-	ProductID    int64          `db:"product_id"`
-	ProductName  string         `db:"product_name"`
-	ProductPrice float64        `db:"product_price"`
-	ProductImage sql.NullString `db:"product_image_url"`
+	ID           int64           `db:"preset_item_id"`
+	PresetID     int64           `db:"preset_id"`
+	ProductID    int64           `db:"product_id"`
+	ProductName  string          `db:"product_name"`
+	ProductPrice decimal.Decimal `db:"product_price"`
+	ProductImage sql.NullString  `db:"product_image_url"`
+	Quantity     float64         `db:"quantity"`
+	Formula      sql.NullString  `db:"quantity_formula"`
+	SlotName     sql.NullString  `db:"slot_name"`
+	Optional     bool            `db:"optional"`
 }
 
 func (r presetItemDetailedDB) toDomain() presetDom.PresetItem {
@@ -54,7 +64,31 @@ func (r presetItemDetailedDB) toDomain() presetDom.PresetItem {
 		Product: &product.ProductSummary{
 			ID:       r.ProductID,
 			Name:     r.ProductName,
-			Price:    r.ProductPrice,
+			Price:    money.New(r.ProductPrice, money.Base),
+			ImageURL: optionalString(r.ProductImage),
+		},
+		Quantity:        r.Quantity,
+		QuantityFormula: optionalString(r.Formula),
+		SlotName:        optionalString(r.SlotName),
+		Optional:        r.Optional,
+	}
+}
+
+type alternativeDB struct {
+	ItemID       int64           `db:"preset_item_id"`
+	ProductID    int64           `db:"product_id"`
+	ProductName  string          `db:"product_name"`
+	ProductPrice decimal.Decimal `db:"product_price"`
+	ProductImage sql.NullString  `db:"product_image_url"`
+}
+
+func (r alternativeDB) toDomain() presetDom.Alternative {
+	return presetDom.Alternative{
+		ProductID: r.ProductID,
+		Product: &product.ProductSummary{
+			ID:       r.ProductID,
+			Name:     r.ProductName,
+			Price:    money.New(r.ProductPrice, money.Base),
 			ImageURL: optionalString(r.ProductImage),
 		},
 	}
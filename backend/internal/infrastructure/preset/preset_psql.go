@@ -11,7 +11,10 @@ import (
 	"log/slog"
 
 	"github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	"github.com/Neimess/zorkin-store-project/internal/domain/webhook"
 	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/outbox"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
 	"github.com/Neimess/zorkin-store-project/pkg/database"
 	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	logger "github.com/Neimess/zorkin-store-project/pkg/log"
@@ -46,13 +49,13 @@ func (r *PGPresetRepository) Create(ctx context.Context, p *preset.Preset) (*pre
 // Get returns preset with embedded Items slice.
 func (r *PGPresetRepository) Get(ctx context.Context, id int64) (*preset.Preset, error) {
 	const qPreset = `
-		SELECT preset_id, name, description, total_price, image_url, created_at
+		SELECT preset_id, name, description, total_price, image_url, created_at, is_template, version
 		FROM presets WHERE preset_id = $1
 	`
 
 	var raw presetDB
 	if err := database.WithQuery(ctx, r.log, qPreset, func() error {
-		return r.db.GetContext(ctx, &raw, qPreset, id)
+		return r.conn(ctx).GetContext(ctx, &raw, qPreset, id)
 	}); err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
@@ -66,16 +69,21 @@ func (r *PGPresetRepository) Get(ctx context.Context, id int64) (*preset.Preset,
 			pi.product_id,
 			p.name  AS product_name,
 			p.price AS product_price,
-			p.image_url AS product_image_url
+			p.image_url AS product_image_url,
+			pi.quantity,
+			pi.quantity_formula,
+			pi.slot_name,
+			pi.optional
 		FROM preset_items pi
 		JOIN products p ON p.product_id = pi.product_id
 		WHERE pi.preset_id = $1
+		ORDER BY pi.preset_item_id
 	`
 
 	var rows []presetItemDetailedDB
 
 	if err := database.WithQuery(ctx, r.log, qItems, func() error {
-		return r.db.SelectContext(ctx, &rows, qItems, id)
+		return r.conn(ctx).SelectContext(ctx, &rows, qItems, id)
 	}); err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
@@ -83,12 +91,19 @@ func (r *PGPresetRepository) Get(ctx context.Context, id int64) (*preset.Preset,
 		item := row.toDomain()
 		p.Items = append(p.Items, item)
 	}
+	alts, err := r.alternatives(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	for i := range p.Items {
+		p.Items[i].Alternatives = alts[p.Items[i].ID]
+	}
 	return p, nil
 }
 
 func (r *PGPresetRepository) ListDetailed(ctx context.Context) ([]preset.Preset, error) {
 	const qPresets = `
-		SELECT preset_id, name, description, total_price, image_url, created_at
+		SELECT preset_id, name, description, total_price, image_url, created_at, is_template, version
 		FROM presets
 	`
 
@@ -116,10 +131,15 @@ func (r *PGPresetRepository) ListDetailed(ctx context.Context) ([]preset.Preset,
 			pi.product_id,
 			p.name  AS product_name,
 			p.price AS product_price,
-			p.image_url AS product_image_url
+			p.image_url AS product_image_url,
+			pi.quantity,
+			pi.quantity_formula,
+			pi.slot_name,
+			pi.optional
 		FROM preset_items pi
 		JOIN products p ON p.product_id = pi.product_id
 		WHERE pi.preset_id = ANY($1)
+		ORDER BY pi.preset_item_id
 	`
 
 	var rows []presetItemDetailedDB
@@ -138,13 +158,50 @@ func (r *PGPresetRepository) ListDetailed(ctx context.Context) ([]preset.Preset,
 		item := row.toDomain()
 		byID[row.PresetID].Items = append(byID[row.PresetID].Items, item)
 	}
+	alts, err := r.alternatives(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range presets {
+		for j := range presets[i].Items {
+			presets[i].Items[j].Alternatives = alts[presets[i].Items[j].ID]
+		}
+	}
 
 	return presets, nil
 }
 
+// alternatives загружает замены слотов пресетов, по ID позиции.
+func (r *PGPresetRepository) alternatives(ctx context.Context, presetIDs []int64) (map[int64][]preset.Alternative, error) {
+	const q = `
+		SELECT
+			a.preset_item_id,
+			a.product_id,
+			p.name  AS product_name,
+			p.price AS product_price,
+			p.image_url AS product_image_url
+		FROM preset_item_alternatives a
+		JOIN preset_items pi ON pi.preset_item_id = a.preset_item_id
+		JOIN products p ON p.product_id = a.product_id
+		WHERE pi.preset_id = ANY($1)
+		ORDER BY a.preset_item_id, a.product_id
+	`
+	var rows []alternativeDB
+	if err := r.withQuery(ctx, q, func() error {
+		return r.conn(ctx).SelectContext(ctx, &rows, q, pq.Array(presetIDs))
+	}); err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	res := make(map[int64][]preset.Alternative)
+	for _, row := range rows {
+		res[row.ItemID] = append(res[row.ItemID], row.toDomain())
+	}
+	return res, nil
+}
+
 func (r *PGPresetRepository) ListShort(ctx context.Context) ([]preset.Preset, error) {
 	const q = `
-		SELECT preset_id, name, description, total_price, image_url, created_at
+		SELECT preset_id, name, description, total_price, image_url, created_at, is_template, version
 		FROM presets
 	`
 	var raws []presetDB
@@ -158,15 +215,33 @@ func (r *PGPresetRepository) ListShort(ctx context.Context) ([]preset.Preset, er
 }
 
 func (r *PGPresetRepository) Delete(ctx context.Context, id int64) error {
-	const q = `DELETE FROM presets WHERE preset_id=$1`
-	err := database.WithQuery(ctx, r.log, q, func() error {
-		_, execErr := r.db.ExecContext(ctx, q, id)
-		return execErr
+	guard, args := database.Guard(ctx, []any{id})
+	q := `DELETE FROM presets WHERE preset_id=$1` + guard
+	return tx.RunInTxAction(ctx, r.db, func(tx *sqlx.Tx) error {
+		var cnt int64
+		err := database.WithQuery(ctx, r.log, q, func() error {
+			res, execErr := tx.ExecContext(ctx, q, args...)
+			if execErr != nil {
+				return execErr
+			}
+			cnt, execErr = res.RowsAffected()
+			return execErr
+		})
+		if err != nil {
+			return r.mapPostgreSQLError(err)
+		}
+		if cnt == 0 {
+			// пресет есть, но его версия не совпала с If-Match — это конфликт, а не «нечего удалять»
+			if guard != "" {
+				if err := database.NoRows(ctx, tx, "presets", "preset_id", id); !errors.Is(err, sql.ErrNoRows) {
+					return r.mapPostgreSQLError(err)
+				}
+			}
+			// удалять было нечего — событие не нужно
+			return nil
+		}
+		return r.enqueueTx(ctx, tx, webhook.EventPresetDeleted, webhook.DeletedData{ID: id})
 	})
-	if err != nil {
-		return r.mapPostgreSQLError(err)
-	}
-	return nil
 }
 
 func (r *PGPresetRepository) Update(ctx context.Context, p *preset.Preset) (*preset.Preset, error) {
@@ -174,37 +249,100 @@ func (r *PGPresetRepository) Update(ctx context.Context, p *preset.Preset) (*pre
 	return r.save(ctx, p, false)
 }
 
+// Patch записывает только изменённые поля пресета; позиции, если они есть
+// в патче, перезаписываются целиком. Если пресет изменился после чтения,
+// возвращается ErrVersionConflict.
+func (r *PGPresetRepository) Patch(ctx context.Context, p *preset.Patch) (*preset.Preset, error) {
+	return tx.RunInTx(ctx, r.db, func(t *sqlx.Tx) (*preset.Preset, error) {
+		ctx := tx.WithTx(ctx, t)
+
+		u := database.NewUpdate("presets", "preset_id")
+		if p.Name.Set {
+			u.Set("name", p.Name.Value)
+		}
+		if p.Description.Set {
+			u.Set("description", p.Description.Value)
+		}
+		if p.TotalPrice.Set {
+			u.Set("total_price", p.TotalPrice.Value.Amount)
+		}
+		if p.ImageURL.Set {
+			u.Set("image_url", p.ImageURL.Value)
+		}
+		if p.IsTemplate.Set {
+			u.Set("is_template", p.IsTemplate.Value)
+		}
+		query, _ := u.SQL(ctx, p.ID, p.Version)
+		err := r.withQuery(ctx, query, func() error {
+			_, err := u.Exec(ctx, t, p.ID, p.Version)
+			return err
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, preset.ErrPresetNotFound
+		}
+		if err != nil {
+			return nil, r.mapPostgreSQLError(err)
+		}
+
+		if p.Items.Set {
+			if err := r.replaceItems(ctx, t, p.ID, p.Items.Value); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := r.Get(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.enqueueTx(ctx, t, webhook.EventPresetUpdated, webhook.NewPresetData(res)); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
 func (r *PGPresetRepository) save(ctx context.Context, p *preset.Preset, isNew bool) (*preset.Preset, error) {
-	queryPreset := `UPDATE presets SET name=$1, description=$2, total_price=$3, image_url=$4 WHERE preset_id=$5`
-	if isNew {
-		queryPreset = `INSERT INTO presets (name, description, total_price, image_url) VALUES ($1,$2,$3,$4) RETURNING preset_id, created_at`
+	args := []any{p.Name, p.Description, p.TotalPrice.Amount, p.ImageURL, p.IsTemplate}
+	var guard string
+	queryPreset := `INSERT INTO presets (name, description, total_price, image_url, is_template) VALUES ($1,$2,$3,$4,$5) RETURNING preset_id, created_at, version`
+	if !isNew {
+		guard, args = database.Guard(ctx, append(args, p.ID))
+		queryPreset = `UPDATE presets SET name=$1, description=$2, total_price=$3, image_url=$4, is_template=$5, version = version + 1 WHERE preset_id=$6` + guard + ` RETURNING version`
 	}
 
 	resPreset, err := tx.RunInTx(ctx, r.db, func(tx *sqlx.Tx) (*preset.Preset, error) {
 		// Сохранение Preset
 		err := database.WithQuery(ctx, r.log, queryPreset, func() error {
 			if isNew {
-				return tx.QueryRowContext(ctx, queryPreset, p.Name, p.Description, p.TotalPrice, p.ImageURL).
-					Scan(&p.ID, &p.CreatedAt)
+				return tx.QueryRowContext(ctx, queryPreset, args...).Scan(&p.ID, &p.CreatedAt, &p.Version)
 			}
-			_, execErr := tx.ExecContext(ctx, queryPreset, p.Name, p.Description, p.TotalPrice, p.ImageURL, p.ID)
-			return execErr
+			execErr := tx.QueryRowContext(ctx, queryPreset, args...).Scan(&p.Version)
+			if errors.Is(execErr, sql.ErrNoRows) {
+				return database.NoRows(ctx, tx, "presets", "preset_id", p.ID)
+			}
+			if execErr != nil {
+				return execErr
+			}
+			return nil
 		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
 		if err != nil {
 			return nil, r.mapPostgreSQLError(err)
 		}
 
 		// Перезапись элементов
-		err = r.deleteItems(ctx, tx, p.ID)
-		if err != nil {
+		if err := r.replaceItems(ctx, tx, p.ID, p.Items); err != nil {
 			return nil, err
 		}
 
-		if len(p.Items) > 0 {
-			err = r.insertItems(ctx, tx, p.ID, p.Items)
-			if err != nil {
-				return nil, err
-			}
+		event := webhook.EventPresetUpdated
+		if isNew {
+			event = webhook.EventPresetCreated
+		}
+		if err := r.enqueueTx(ctx, tx, event, webhook.NewPresetData(p)); err != nil {
+			return nil, err
 		}
 		return p, nil
 	})
@@ -217,27 +355,103 @@ func (r *PGPresetRepository) save(ctx context.Context, p *preset.Preset, isNew b
 	return resPreset, nil
 }
 
-func (r *PGPresetRepository) deleteItems(ctx context.Context, tx *sqlx.Tx, presetID int64) error {
-	const q = `DELETE FROM preset_items WHERE preset_id=$1`
-	err := database.WithQuery(ctx, r.log, q, func() error {
-		_, execErr := tx.ExecContext(ctx, q, presetID)
+// replaceItems приводит позиции пресета к items. Позиция сопоставляется по
+// товару по умолчанию (он уникален в пресете): у оставшихся позиций
+// preset_item_id не меняется, поэтому ID слотов конфигуратора живут между
+// PUT и PATCH. Позиции с убранными товарами удаляются, замены пишутся заново.
+func (r *PGPresetRepository) replaceItems(ctx context.Context, tx *sqlx.Tx, presetID int64, items []preset.PresetItem) error {
+	n := len(items)
+	ids := make([]int64, n)
+	pids := make([]int64, n)
+	qtys := make([]float64, n)
+	formulas := make([]sql.NullString, n)
+	slots := make([]sql.NullString, n)
+	optional := make([]bool, n)
+	seen := make(map[int64]bool, n)
+	for i, it := range items {
+		if seen[it.ProductID] {
+			return app_error.ErrConflict
+		}
+		seen[it.ProductID] = true
+		ids[i] = presetID
+		pids[i] = it.ProductID
+		qtys[i] = it.Qty()
+		if it.QuantityFormula != nil {
+			formulas[i] = sql.NullString{String: *it.QuantityFormula, Valid: true}
+		}
+		if it.SlotName != nil {
+			slots[i] = sql.NullString{String: *it.SlotName, Valid: true}
+		}
+		optional[i] = it.Optional
+	}
+
+	const qDelete = `DELETE FROM preset_items WHERE preset_id = $1 AND NOT (product_id = ANY($2))`
+	err := database.WithQuery(ctx, r.log, qDelete, func() error {
+		_, execErr := tx.ExecContext(ctx, qDelete, presetID, pq.Array(pids))
 		return execErr
 	})
 	if err != nil {
 		return r.mapPostgreSQLError(err)
 	}
-	return nil
+	if n == 0 {
+		return nil
+	}
+
+	const qUpsert = `INSERT INTO preset_items (preset_id, product_id, quantity, quantity_formula, slot_name, optional)
+		SELECT * FROM UNNEST($1::bigint[],$2::bigint[],$3::numeric[],$4::text[],$5::varchar[],$6::boolean[])
+		ON CONFLICT (preset_id, product_id) DO UPDATE
+		   SET quantity = EXCLUDED.quantity, quantity_formula = EXCLUDED.quantity_formula,
+		       slot_name = EXCLUDED.slot_name, optional = EXCLUDED.optional
+		RETURNING preset_item_id, product_id`
+	itemIDs := make(map[int64]int64, n)
+	err = database.WithQuery(ctx, r.log, qUpsert, func() error {
+		rows, execErr := tx.QueryContext(ctx, qUpsert, pq.Array(ids), pq.Array(pids), pq.Array(qtys), pq.Array(formulas),
+			pq.Array(slots), pq.Array(optional))
+		if execErr != nil {
+			return execErr
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var itemID, productID int64
+			if execErr = rows.Scan(&itemID, &productID); execErr != nil {
+				return execErr
+			}
+			itemIDs[productID] = itemID
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return r.mapPostgreSQLError(err)
+	}
+
+	itemIDList := make([]int64, 0, n)
+	for _, id := range itemIDs {
+		itemIDList = append(itemIDList, id)
+	}
+	const qAlternatives = `DELETE FROM preset_item_alternatives WHERE preset_item_id = ANY($1)`
+	err = database.WithQuery(ctx, r.log, qAlternatives, func() error {
+		_, execErr := tx.ExecContext(ctx, qAlternatives, pq.Array(itemIDList))
+		return execErr
+	})
+	if err != nil {
+		return r.mapPostgreSQLError(err)
+	}
+	return r.insertAlternatives(ctx, tx, items, itemIDs)
 }
 
-func (r *PGPresetRepository) insertItems(ctx context.Context, tx *sqlx.Tx, presetID int64, items []preset.PresetItem) error {
-	n := len(items)
-	ids := make([]int64, n)
-	pids := make([]int64, n)
-	for i, it := range items {
-		ids[i] = presetID
-		pids[i] = it.ProductID
+func (r *PGPresetRepository) insertAlternatives(ctx context.Context, tx *sqlx.Tx, items []preset.PresetItem, itemIDs map[int64]int64) error {
+	var ids, pids []int64
+	for _, it := range items {
+		for _, a := range it.Alternatives {
+			ids = append(ids, itemIDs[it.ProductID])
+			pids = append(pids, a.ProductID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
 	}
-	const q = `INSERT INTO preset_items (preset_id, product_id) SELECT * FROM UNNEST($1::bigint[],$2::bigint[])`
+	const q = `INSERT INTO preset_item_alternatives (preset_item_id, product_id)
+		SELECT * FROM UNNEST($1::bigint[],$2::bigint[])`
 	err := database.WithQuery(ctx, r.log, q, func() error {
 		_, execErr := tx.ExecContext(ctx, q, pq.Array(ids), pq.Array(pids))
 		return execErr
@@ -248,6 +462,18 @@ func (r *PGPresetRepository) insertItems(ctx context.Context, tx *sqlx.Tx, prese
 	return nil
 }
 
+func (r *PGPresetRepository) enqueueTx(ctx context.Context, tx *sqlx.Tx, typ webhook.EventType, data any) error {
+	if err := outbox.Enqueue(ctx, tx, typ, data); err != nil {
+		return r.mapPostgreSQLError(err)
+	}
+	return nil
+}
+
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул.
+func (r *PGPresetRepository) conn(ctx context.Context) tx.Querier {
+	return tx.Q(ctx, r.db)
+}
+
 func (r *PGPresetRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
 	return database.WithQuery(ctx, r.log, query, fn, extras...)
 }
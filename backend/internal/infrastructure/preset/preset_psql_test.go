@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	domPreset "github.com/Neimess/zorkin-store-project/internal/domain/preset"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/preset"
 )
@@ -110,7 +111,7 @@ func (s *PGPresetRepositorySuite) Test_CreateGetDelete() {
 	in := &domPreset.Preset{
 		Name:        "Bathroom Set",
 		Description: ptr("Full set for bathroom"),
-		TotalPrice:  199.99,
+		TotalPrice:  money.FromFloat(199.99, money.Base),
 		ImageURL:    ptr("https://example.com/image.jpg"),
 		Items:       []domPreset.PresetItem{{ProductID: prodID}},
 	}
@@ -136,7 +137,7 @@ func (s *PGPresetRepositorySuite) Test_ListDetailedAndShort() {
 	prodID := s.createProduct("Sink", 199.99, categoryID, attrID, svcID)
 	p := &domPreset.Preset{
 		Name:       "Toilet Only",
-		TotalPrice: 88.0,
+		TotalPrice: money.FromFloat(88.0, money.Base),
 		Items:      []domPreset.PresetItem{{ProductID: prodID}},
 	}
 	_, err := s.repo.Create(s.ctx, p)
@@ -161,18 +162,18 @@ func (s *PGPresetRepositorySuite) Test_UpdatePreset() {
 	prodB := s.createProduct("Beta", 75, categoryID, attrB, svcB)
 	in := &domPreset.Preset{
 		Name:       "Original",
-		TotalPrice: 50,
+		TotalPrice: money.FromFloat(50, money.Base),
 		Items:      []domPreset.PresetItem{{ProductID: prodA}},
 	}
 	pRes, err := s.repo.Create(s.ctx, in)
 	require.NoError(s.T(), err)
 	pRes.Name = "Updated"
-	pRes.TotalPrice = 75
+	pRes.TotalPrice = money.FromFloat(75, money.Base)
 	pRes.Items = []domPreset.PresetItem{{ProductID: prodB}}
 	r, err := s.repo.Update(s.ctx, pRes)
 	require.NoError(s.T(), err)
 	require.Equal(s.T(), "Updated", r.Name)
-	require.Equal(s.T(), float64(75), r.TotalPrice)
+	require.Equal(s.T(), "75.00 RUB", r.TotalPrice.String())
 	got, err := s.repo.Get(s.ctx, pRes.ID)
 	require.NoError(s.T(), err)
 	require.Equal(s.T(), "Updated", got.Name)
@@ -185,7 +186,7 @@ func (s *PGPresetRepositorySuite) Test_UpdateIdempotent() {
 	attrID := s.createAttribute("depth", "cm", categoryID)
 	svcID := s.createService("support", 30)
 	prod := s.createProduct("Gamma", 20, categoryID, attrID, svcID)
-	p := &domPreset.Preset{Name: "Same", TotalPrice: 20, Items: []domPreset.PresetItem{{ProductID: prod}}}
+	p := &domPreset.Preset{Name: "Same", TotalPrice: money.FromFloat(20, money.Base), Items: []domPreset.PresetItem{{ProductID: prod}}}
 	pRes, err := s.repo.Create(s.ctx, p)
 	require.NoError(s.T(), err)
 	_, err = s.repo.Update(s.ctx, pRes)
@@ -194,6 +195,100 @@ func (s *PGPresetRepositorySuite) Test_UpdateIdempotent() {
 	require.NoError(s.T(), err)
 }
 
+func (s *PGPresetRepositorySuite) Test_TemplateItemsRoundTrip() {
+	categoryID := s.createCategory("Bathroom")
+	tile := s.createProduct("Tile", 1000, categoryID, 0, 0)
+	bath := s.createProduct("Bath", 15000, categoryID, 0, 0)
+
+	in := &domPreset.Preset{
+		Name:       "Bathroom template",
+		TotalPrice: money.FromFloat(1, money.Base),
+		IsTemplate: true,
+		Items: []domPreset.PresetItem{
+			{ProductID: tile, QuantityFormula: ptr("ceil(floor_area / 1.44)")},
+			{ProductID: bath, Quantity: 2},
+		},
+	}
+	pRes, err := s.repo.Create(s.ctx, in)
+	require.NoError(s.T(), err)
+
+	got, err := s.repo.Get(s.ctx, pRes.ID)
+	require.NoError(s.T(), err)
+	require.True(s.T(), got.IsTemplate)
+	require.Len(s.T(), got.Items, 2)
+	for _, it := range got.Items {
+		switch it.ProductID {
+		case tile:
+			require.NotNil(s.T(), it.QuantityFormula)
+			require.Equal(s.T(), "ceil(floor_area / 1.44)", *it.QuantityFormula)
+			require.Equal(s.T(), float64(1), it.Quantity)
+		case bath:
+			require.Nil(s.T(), it.QuantityFormula)
+			require.Equal(s.T(), float64(2), it.Quantity)
+		}
+	}
+}
+
+func (s *PGPresetRepositorySuite) Test_SlotsRoundTrip() {
+	categoryID := s.createCategory("Bathroom")
+	sink := s.createProduct("Sink", 5000, categoryID, 0, 0)
+	sinkWide := s.createProduct("Sink wide", 7500, categoryID, 0, 0)
+	sinkSmall := s.createProduct("Sink small", 4200, categoryID, 0, 0)
+	mirror := s.createProduct("Mirror", 3000, categoryID, 0, 0)
+
+	in := &domPreset.Preset{
+		Name:       "Configurable bathroom",
+		TotalPrice: money.FromFloat(8000, money.Base),
+		Items: []domPreset.PresetItem{
+			{ProductID: sink, SlotName: ptr("Раковина"), Alternatives: []domPreset.Alternative{{ProductID: sinkWide}, {ProductID: sinkSmall}}},
+			{ProductID: mirror, Optional: true},
+		},
+	}
+	pRes, err := s.repo.Create(s.ctx, in)
+	require.NoError(s.T(), err)
+
+	got, err := s.repo.Get(s.ctx, pRes.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), got.Items, 2)
+	require.Equal(s.T(), sink, got.Items[0].ProductID)
+	require.Equal(s.T(), "Раковина", *got.Items[0].SlotName)
+	require.False(s.T(), got.Items[0].Optional)
+	require.Len(s.T(), got.Items[0].Alternatives, 2)
+	require.Equal(s.T(), "Sink wide", got.Items[0].Alternatives[0].Product.Name)
+	require.True(s.T(), got.Items[1].Optional)
+	require.Empty(s.T(), got.Items[1].Alternatives)
+
+	cfg, err := got.Configure([]domPreset.Choice{{SlotID: got.Items[0].ID, ProductID: sinkWide}})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "10500.00 RUB", cfg.TotalPrice.String())
+
+	// удалённый товар пропадает из замен, слот остаётся
+	_, err = s.db.Exec(`DELETE FROM products WHERE product_id = $1`, sinkSmall)
+	require.NoError(s.T(), err)
+	list, err := s.repo.ListDetailed(s.ctx)
+	require.NoError(s.T(), err)
+	for _, p := range list {
+		if p.ID == pRes.ID {
+			require.Len(s.T(), p.Items[0].Alternatives, 1)
+			require.Equal(s.T(), sinkWide, p.Items[0].Alternatives[0].ProductID)
+		}
+	}
+
+	// ID слота переживает PUT: позиция обновляется на месте, а не пересоздаётся
+	slotID := got.Items[0].ID
+	got.Items = []domPreset.PresetItem{
+		{ProductID: sink, Quantity: 2, SlotName: ptr("Раковина"), Alternatives: []domPreset.Alternative{{ProductID: sinkWide}}},
+	}
+	_, err = s.repo.Update(s.ctx, got)
+	require.NoError(s.T(), err)
+	updated, err := s.repo.Get(s.ctx, pRes.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), updated.Items, 1)
+	require.Equal(s.T(), slotID, updated.Items[0].ID)
+	require.Equal(s.T(), 2.0, updated.Items[0].Qty())
+	require.Len(s.T(), updated.Items[0].Alternatives, 1)
+}
+
 func TestPGPresetRepositorySuite(t *testing.T) {
 	suite.Run(t, new(PGPresetRepositorySuite))
 }
@@ -5,27 +5,35 @@ import (
 	"time"
 
 	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/shopspring/decimal"
 )
 
 type productRow struct {
-	ID          int64          `db:"product_id"`
-	Name        string         `db:"name"`
-	Price       float64        `db:"price"`
-	Description sql.NullString `db:"description"`
-	CategoryID  int64          `db:"category_id"`
-	ImageURL    sql.NullString `db:"image_url"`
-	CreatedAt   time.Time      `db:"created_at"`
+	ID          int64               `db:"product_id"`
+	Name        string              `db:"name"`
+	Price       decimal.Decimal     `db:"price"`
+	Description sql.NullString      `db:"description"`
+	CategoryID  int64               `db:"category_id"`
+	ImageURL    sql.NullString      `db:"image_url"`
+	Stock       decimal.NullDecimal `db:"stock"`
+	CreatedAt   time.Time           `db:"created_at"`
+	Version     int64               `db:"version"`
+	RatingAvg   float64             `db:"rating_avg"`
+	RatingCount int64               `db:"rating_count"`
 }
 
 func (r *productRow) toDomain(attrs []prodDom.ProductAttribute) *prodDom.Product {
 	d := &prodDom.Product{
 		ID:         r.ID,
 		Name:       r.Name,
-		Price:      r.Price,
+		Price:      money.New(r.Price, money.Base),
 		CategoryID: r.CategoryID,
 		CreatedAt:  r.CreatedAt,
+		Version:    r.Version,
 		Attributes: attrs,
+		Rating:     prodDom.RatingSummary{Average: r.RatingAvg, Count: r.RatingCount},
 	}
 	if r.Description.Valid {
 		d.Description = &r.Description.String
@@ -33,6 +41,9 @@ func (r *productRow) toDomain(attrs []prodDom.ProductAttribute) *prodDom.Product
 	if r.ImageURL.Valid {
 		d.ImageURL = &r.ImageURL.String
 	}
+	if r.Stock.Valid {
+		d.Stock = &r.Stock.Decimal
+	}
 	return d
 }
 
@@ -60,3 +71,48 @@ func (ar *productAttributeRow) toDomainAttr() prodDom.ProductAttribute {
 		},
 	}
 }
+
+type productRelationRow struct {
+	ID           int64           `db:"relation_id"`
+	ProductID    int64           `db:"product_id"`
+	RelatedID    int64           `db:"related_product_id"`
+	Type         string          `db:"relation_type"`
+	CreatedAt    time.Time       `db:"created_at"`
+	RelatedName  string          `db:"related_name"`
+	RelatedPrice decimal.Decimal `db:"related_price"`
+	RelatedImage sql.NullString  `db:"related_image_url"`
+}
+
+func (rr *productRelationRow) toDomain() prodDom.ProductRelation {
+	rel := prodDom.ProductRelation{
+		ID:        rr.ID,
+		ProductID: rr.ProductID,
+		RelatedID: rr.RelatedID,
+		Type:      prodDom.RelationType(rr.Type),
+		CreatedAt: rr.CreatedAt,
+		Related: &prodDom.ProductSummary{
+			ID:    rr.RelatedID,
+			Name:  rr.RelatedName,
+			Price: money.New(rr.RelatedPrice, money.Base),
+		},
+	}
+	if rr.RelatedImage.Valid {
+		rel.Related.ImageURL = &rr.RelatedImage.String
+	}
+	return rel
+}
+
+type productSummaryRow struct {
+	ID       int64           `db:"product_id"`
+	Name     string          `db:"name"`
+	Price    decimal.Decimal `db:"price"`
+	ImageURL sql.NullString  `db:"image_url"`
+}
+
+func (sr *productSummaryRow) toDomain() prodDom.ProductSummary {
+	s := prodDom.ProductSummary{ID: sr.ID, Name: sr.Name, Price: money.New(sr.Price, money.Base)}
+	if sr.ImageURL.Valid {
+		s.ImageURL = &sr.ImageURL.String
+	}
+	return s
+}
@@ -2,22 +2,28 @@ package product
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 
 	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/outbox"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/service"
 	"github.com/Neimess/zorkin-store-project/pkg/app_error"
 	database "github.com/Neimess/zorkin-store-project/pkg/database"
 	tx "github.com/Neimess/zorkin-store-project/pkg/database/tx"
 
 	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
 	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/Neimess/zorkin-store-project/internal/domain/webhook"
 )
 
 // Deps holds dependencies for PGProductRepository
@@ -53,18 +59,23 @@ func (r *PGProductRepository) Create(ctx context.Context, p *prodDom.Product) (*
 	const query = `INSERT INTO products(name, price, description, category_id, image_url)
 		VALUES ($1,$2,$3,$4,$5) RETURNING product_id, created_at`
 
-	var id int64
-	var created time.Time
-	err := database.WithQuery(ctx, r.log, query, func() error {
-		return r.db.QueryRowContext(ctx, query,
-			p.Name, p.Price, p.Description, p.CategoryID, p.ImageURL,
-		).Scan(&id, &created)
+	return tx.RunInTx(ctx, r.db, func(tx *sqlx.Tx) (*prodDom.Product, error) {
+		var id int64
+		var created time.Time
+		err := database.WithQuery(ctx, r.log, query, func() error {
+			return tx.QueryRowContext(ctx, query,
+				p.Name, p.Price.Amount, p.Description, p.CategoryID, p.ImageURL,
+			).Scan(&id, &created)
+		})
+		if err := r.mapPostgreSQLError(err); err != nil {
+			return nil, err
+		}
+		p.ID, p.CreatedAt = id, created
+		if err := r.enqueueTx(ctx, tx, webhook.EventProductCreated, webhook.NewProductData(p)); err != nil {
+			return nil, err
+		}
+		return p, nil
 	})
-	if err := r.mapPostgreSQLError(err); err != nil {
-		return nil, err
-	}
-	p.ID, p.CreatedAt = id, created
-	return p, nil
 }
 
 // CreateWithAttrs создаёт продукт, заводит все переданные атрибуты
@@ -86,6 +97,9 @@ func (r *PGProductRepository) CreateWithAttrs(
 
 		p.ID = prodID
 		p.Attributes = created
+		if err := r.enqueueTx(ctx, tx, webhook.EventProductCreated, webhook.NewProductData(p)); err != nil {
+			return nil, err
+		}
 		return p, nil
 	})
 }
@@ -106,16 +120,46 @@ func (r *PGProductRepository) Get(ctx context.Context, id int64) (*prodDom.Produ
 		return nil, err
 	}
 	prod.Services = services
+	relations, err := r.fetchRelations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	prod.Relations = relations
 	r.log.Debug("Product fetched", slog.Any("Product", prod))
 	return prod, nil
 }
 
+// selectProductWithRating выбирает товар вместе со средней оценкой
+// и количеством одобренных отзывов.
+const selectProductWithRating = `
+	SELECT p.product_id, p.name, p.price, p.description, p.category_id, p.image_url, p.stock, p.created_at, p.version,
+	       COALESCE(rv.rating_avg, 0)::float8 AS rating_avg,
+	       COALESCE(rv.rating_count, 0) AS rating_count
+	FROM products p
+	LEFT JOIN (
+		SELECT product_id, ROUND(AVG(rating), 2) AS rating_avg, COUNT(*) AS rating_count
+		FROM product_reviews
+		WHERE status = 'approved'
+		GROUP BY product_id
+	) rv ON rv.product_id = p.product_id
+`
+
+// categoryOrderBy — белый список сортировок каталога.
+var categoryOrderBy = map[prodDom.SortOrder]string{
+	prodDom.SortDefault:    `p.product_id`,
+	prodDom.SortRatingDesc: `rating_avg DESC, rating_count DESC, p.product_id`,
+	prodDom.SortPriceAsc:   `p.price, p.product_id`,
+	prodDom.SortPriceDesc:  `p.price DESC, p.product_id`,
+	prodDom.SortNewest:     `p.created_at DESC, p.product_id DESC`,
+}
+
 // ListByCategory lists all products in a category with attributes
-func (r *PGProductRepository) ListByCategory(ctx context.Context, catID int64) ([]prodDom.Product, error) {
-	const query = `
-		SELECT product_id, name, price, description, category_id, image_url, created_at
-		FROM products WHERE category_id = $1
-	`
+func (r *PGProductRepository) ListByCategory(ctx context.Context, catID int64, sort prodDom.SortOrder) ([]prodDom.Product, error) {
+	orderBy, ok := categoryOrderBy[sort]
+	if !ok {
+		return nil, prodDom.ErrInvalidSort
+	}
+	query := selectProductWithRating + ` WHERE p.category_id = $1 ORDER BY ` + orderBy
 	var raws []productRow
 	err := r.withQuery(ctx, query, func() error {
 		return r.db.SelectContext(ctx, &raws, query, catID)
@@ -160,27 +204,32 @@ func (r *PGProductRepository) ListByCategory(ctx context.Context, catID int64) (
 	return prods, nil
 }
 
+// UpdateWithAttrs перезаписывает продукт с атрибутами и услугами. Вместе
+// с изменением в outbox пишется product.updated, а если изменилась цена — price.updated.
 func (r *PGProductRepository) UpdateWithAttrs(
 	ctx context.Context,
 	p *prodDom.Product,
 ) (*prodDom.Product, error) {
 	return tx.RunInTx(ctx, r.db, func(tx *sqlx.Tx) (*prodDom.Product, error) {
+		oldPrice, err := r.lockPriceTx(ctx, tx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
 		const upd = `
             UPDATE products
                SET name=$1, price=$2, description=$3,
-                   category_id=$4, image_url=$5
-             WHERE product_id=$6
-        `
-		res, err := tx.ExecContext(
-			ctx, upd,
-			p.Name, p.Price, p.Description, p.CategoryID, p.ImageURL, p.ID,
-		)
+                   category_id=$4, image_url=$5, version = version + 1
+             WHERE product_id=$6`
+		guard, args := database.Guard(ctx, []any{p.Name, p.Price.Amount, p.Description, p.CategoryID, p.ImageURL, p.ID})
+		err = tx.GetContext(ctx, &p.Version, upd+guard+` RETURNING version`, args...)
+		if errors.Is(err, sql.ErrNoRows) {
+			// строка заблокирована lockPriceTx, значит не совпала версия из If-Match
+			return nil, app_error.ErrVersionConflict
+		}
 		if err != nil {
 			return nil, r.mapPostgreSQLError(err)
 		}
-		if cnt, _ := res.RowsAffected(); cnt == 0 {
-			return nil, prodDom.ErrProductNotFound
-		}
 
 		if _, err := tx.ExecContext(ctx, `DELETE FROM product_attributes WHERE product_id=$1`, p.ID); err != nil {
 			return nil, r.mapPostgreSQLError(err)
@@ -195,37 +244,160 @@ func (r *PGProductRepository) UpdateWithAttrs(
 		}
 
 		p.Attributes = created
+		if err := r.enqueueTx(ctx, tx, webhook.EventProductUpdated, webhook.NewProductData(p)); err != nil {
+			return nil, err
+		}
+		if !oldPrice.Equal(p.Price.Amount) {
+			newPrice := p.Price.Amount
+			if err := r.enqueueTx(ctx, tx, webhook.EventPriceUpdated, webhook.PriceData{
+				Entity:   money.EntityProduct,
+				EntityID: p.ID,
+				Currency: p.Price.Currency,
+				Price:    &newPrice,
+				OldPrice: &oldPrice,
+			}); err != nil {
+				return nil, err
+			}
+		}
 		return p, nil
 	})
 }
 
-// Delete removes product; cascades attributes via FK
-func (r *PGProductRepository) Delete(ctx context.Context, id int64) error {
-	const del = `DELETE FROM products WHERE product_id = $1`
-	err := r.withQuery(ctx, del, func() error {
-		res, err := r.db.ExecContext(ctx, del, id)
+// Patch записывает только изменённые поля товара; атрибуты и услуги, если они
+// есть в патче, перезаписываются целиком, как в UpdateWithAttrs. Если товар
+// изменился после чтения (версия не совпала), возвращается ErrVersionConflict.
+func (r *PGProductRepository) Patch(ctx context.Context, p *prodDom.Patch) (*prodDom.Product, error) {
+	return tx.RunInTx(ctx, r.db, func(t *sqlx.Tx) (*prodDom.Product, error) {
+		ctx := tx.WithTx(ctx, t)
+		oldPrice, err := r.lockPriceTx(ctx, t, p.ID)
 		if err != nil {
+			return nil, err
+		}
+
+		u := database.NewUpdate("products", "product_id")
+		if p.Name.Set {
+			u.Set("name", p.Name.Value)
+		}
+		if p.Price.Set {
+			u.Set("price", p.Price.Value.Amount)
+		}
+		if p.Description.Set {
+			u.Set("description", p.Description.Value)
+		}
+		if p.CategoryID.Set {
+			u.Set("category_id", p.CategoryID.Value)
+		}
+		if p.ImageURL.Set {
+			u.Set("image_url", p.ImageURL.Value)
+		}
+		query, _ := u.SQL(ctx, p.ID, p.Version)
+		err = r.withQuery(ctx, query, func() error {
+			_, err := u.Exec(ctx, t, p.ID, p.Version)
 			return err
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, prodDom.ErrProductNotFound
 		}
-		cnt, err := res.RowsAffected()
 		if err != nil {
-			return err
+			return nil, r.mapPostgreSQLError(err)
 		}
-		if cnt == 0 {
-			return app_error.ErrNotFound
+
+		if p.Attributes.Set {
+			if _, err := t.ExecContext(ctx, `DELETE FROM product_attributes WHERE product_id=$1`, p.ID); err != nil {
+				return nil, r.mapPostgreSQLError(err)
+			}
+			if _, err := r.saveAttrsAndServices(ctx, t, p.ID, p.Attributes.Value, nil); err != nil {
+				return nil, err
+			}
 		}
-		return nil
+		if p.Services.Set {
+			if _, err := t.ExecContext(ctx, `DELETE FROM product_services WHERE product_id=$1`, p.ID); err != nil {
+				return nil, r.mapPostgreSQLError(err)
+			}
+			if _, err := r.saveAttrsAndServices(ctx, t, p.ID, nil, p.Services.Value); err != nil {
+				return nil, err
+			}
+		}
+
+		prod, err := r.Get(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.enqueueTx(ctx, t, webhook.EventProductUpdated, webhook.NewProductData(prod)); err != nil {
+			return nil, err
+		}
+		if !oldPrice.Equal(prod.Price.Amount) {
+			newPrice := prod.Price.Amount
+			if err := r.enqueueTx(ctx, t, webhook.EventPriceUpdated, webhook.PriceData{
+				Entity:   money.EntityProduct,
+				EntityID: prod.ID,
+				Currency: prod.Price.Currency,
+				Price:    &newPrice,
+				OldPrice: &oldPrice,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return prod, nil
+	})
+}
+
+// Delete removes product; cascades attributes via FK
+func (r *PGProductRepository) Delete(ctx context.Context, id int64) error {
+	guard, args := database.Guard(ctx, []any{id})
+	del := `DELETE FROM products WHERE product_id = $1` + guard
+	return tx.RunInTxAction(ctx, r.db, func(tx *sqlx.Tx) error {
+		err := r.withQuery(ctx, del, func() error {
+			res, err := tx.ExecContext(ctx, del, args...)
+			if err != nil {
+				return err
+			}
+			cnt, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if cnt == 0 {
+				return database.NoRows(ctx, tx, "products", "product_id", id)
+			}
+			return nil
+		})
+		if err != nil {
+			return r.mapPostgreSQLError(err)
+		}
+		return r.enqueueTx(ctx, tx, webhook.EventProductDeleted, webhook.DeletedData{ID: id})
 	})
-	return r.mapPostgreSQLError(err)
 }
 
 // --- Internal helpers below ---
 
+// lockPriceTx блокирует строку продукта до конца транзакции и возвращает его текущую цену.
+func (r *PGProductRepository) lockPriceTx(ctx context.Context, tx *sqlx.Tx, id int64) (decimal.Decimal, error) {
+	const q = `SELECT price FROM products WHERE product_id = $1 FOR UPDATE`
+	var price decimal.Decimal
+	err := r.withQuery(ctx, q, func() error {
+		return tx.GetContext(ctx, &price, q, id)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return price, prodDom.ErrProductNotFound
+	}
+	if err != nil {
+		return price, r.mapPostgreSQLError(err)
+	}
+	return price, nil
+}
+
+func (r *PGProductRepository) enqueueTx(ctx context.Context, tx *sqlx.Tx, typ webhook.EventType, data any) error {
+	if err := outbox.Enqueue(ctx, tx, typ, data); err != nil {
+		return r.mapPostgreSQLError(err)
+	}
+	return nil
+}
+
 func (r *PGProductRepository) insertProductTx(ctx context.Context, tx *sqlx.Tx, p *prodDom.Product) (int64, error) {
 	const q = `INSERT INTO products(name, price, description, category_id, image_url) VALUES($1,$2,$3,$4,$5) RETURNING product_id`
 	var id int64
 	if err := r.withQuery(ctx, q, func() error {
-		return tx.QueryRowContext(ctx, q, p.Name, p.Price, p.Description, p.CategoryID, p.ImageURL).Scan(&id)
+		return tx.QueryRowContext(ctx, q, p.Name, p.Price.Amount, p.Description, p.CategoryID, p.ImageURL).Scan(&id)
 	}); err != nil {
 		return 0, r.mapPostgreSQLError(err)
 	}
@@ -355,10 +527,10 @@ func (r *PGProductRepository) insertServicesTx(
 }
 
 func (r *PGProductRepository) fetchProduct(ctx context.Context, id int64) (*prodDom.Product, error) {
-	const q = `SELECT product_id, name, price, description, category_id, image_url, created_at FROM products WHERE product_id=$1`
+	const q = selectProductWithRating + ` WHERE p.product_id = $1`
 	var raw productRow
 	err := r.withQuery(ctx, q, func() error {
-		return r.db.GetContext(ctx, &raw, q, id)
+		return r.conn(ctx).GetContext(ctx, &raw, q, id)
 	})
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
@@ -376,7 +548,7 @@ func (r *PGProductRepository) fetchProduct(ctx context.Context, id int64) (*prod
 func (r *PGProductRepository) fetchAttributes(ctx context.Context, pid int64) ([]prodDom.ProductAttribute, error) {
 	const q = `SELECT pa.attribute_id, pa.value, a.name, a.unit FROM product_attributes pa JOIN attributes a ON a.attribute_id=pa.attribute_id WHERE pa.product_id=$1`
 	var rows []productAttributeRow
-	err := r.db.SelectContext(ctx, &rows, q, pid)
+	err := r.conn(ctx).SelectContext(ctx, &rows, q, pid)
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
@@ -402,7 +574,7 @@ func (r *PGProductRepository) fetchAttributesBatch(ctx context.Context, ids []in
 func (r *PGProductRepository) fetchServices(ctx context.Context, prodID int64) ([]serviceDom.Service, error) {
 	const q = `SELECT s.service_id, s.name, s.description, s.price FROM services s JOIN product_services ps ON s.service_id = ps.service_id WHERE ps.product_id = $1`
 	var rows []service.ServiceDB
-	err := r.db.SelectContext(ctx, &rows, q, prodID)
+	err := r.conn(ctx).SelectContext(ctx, &rows, q, prodID)
 	if err != nil {
 		return nil, r.mapPostgreSQLError(err)
 	}
@@ -412,7 +584,7 @@ func (r *PGProductRepository) fetchServices(ctx context.Context, prodID int64) (
 			ID:          row.ID,
 			Name:        row.Name,
 			Description: row.Description,
-			Price:       row.Price,
+			Price:       money.New(row.Price, money.Base),
 		})
 	}
 	return services, nil
@@ -439,6 +611,11 @@ func (r *PGProductRepository) validateServiceIDs(
 	return nil
 }
 
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул.
+func (r *PGProductRepository) conn(ctx context.Context) tx.Querier {
+	return tx.Q(ctx, r.db)
+}
+
 func (r *PGProductRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
 	return database.WithQuery(ctx, r.log, query, fn, extras...)
 }
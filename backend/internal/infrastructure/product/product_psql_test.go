@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
 	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
 	"github.com/Neimess/zorkin-store-project/pkg/migrator"
 	"github.com/jmoiron/sqlx"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
 	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
 	prodRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/product"
@@ -84,7 +86,7 @@ func (s *PGProductRepositorySuite) Test_ProductWithServicesAndAttributes() {
 
 	p := &prodDom.Product{
 		Name:       "TestProduct",
-		Price:      123.45,
+		Price:      money.FromFloat(123.45, money.Base),
 		CategoryID: catID,
 		Attributes: []prodDom.ProductAttribute{{
 			AttributeID: attrID,
@@ -99,7 +101,7 @@ func (s *PGProductRepositorySuite) Test_ProductWithServicesAndAttributes() {
 		Services: []serviceDom.Service{{
 			ID:    serviceID,
 			Name:  "delivery",
-			Price: 100,
+			Price: money.FromFloat(100, money.Base),
 		}},
 	}
 	created, err := s.repo.CreateWithAttrs(s.ctx, p)
@@ -111,7 +113,7 @@ func (s *PGProductRepositorySuite) Test_ProductWithNoServicesOrAttributes() {
 	catID := s.createCategory("cat")
 	p := &prodDom.Product{
 		Name:       "NoLinks",
-		Price:      50,
+		Price:      money.FromFloat(50, money.Base),
 		CategoryID: catID,
 	}
 	created, err := s.repo.Create(s.ctx, p)
@@ -125,7 +127,7 @@ func (s *PGProductRepositorySuite) Test_UpdateProductWithAttrsAndServices() {
 	attrID := s.createAttribute("height", "cm", catID)
 	p := &prodDom.Product{
 		Name:       "ToUpdate",
-		Price:      77.7,
+		Price:      money.FromFloat(77.7, money.Base),
 		CategoryID: catID,
 	}
 	created, err := s.repo.Create(s.ctx, p)
@@ -144,7 +146,7 @@ func (s *PGProductRepositorySuite) Test_UpdateProductWithAttrsAndServices() {
 	created.Services = []serviceDom.Service{{
 		ID:    serviceID,
 		Name:  "install",
-		Price: 200,
+		Price: money.FromFloat(200, money.Base),
 	}}
 	_, err = s.repo.UpdateWithAttrs(s.ctx, created)
 	require.NoError(s.T(), err)
@@ -152,6 +154,88 @@ func (s *PGProductRepositorySuite) Test_UpdateProductWithAttrsAndServices() {
 	require.NoError(s.T(), err)
 }
 
+func (s *PGProductRepositorySuite) Test_ProductRelations() {
+	catID := s.createCategory("Tiles")
+	tile, err := s.repo.Create(s.ctx, &prodDom.Product{Name: "Tile", Price: money.FromFloat(1000, money.Base), CategoryID: catID})
+	require.NoError(s.T(), err)
+	grout, err := s.repo.Create(s.ctx, &prodDom.Product{Name: "Grout", Price: money.FromFloat(300, money.Base), CategoryID: catID})
+	require.NoError(s.T(), err)
+	other, err := s.repo.Create(s.ctx, &prodDom.Product{Name: "Other tile", Price: money.FromFloat(1100, money.Base), CategoryID: catID})
+	require.NoError(s.T(), err)
+
+	acc, err := s.repo.CreateRelation(s.ctx, &prodDom.ProductRelation{ProductID: tile.ID, RelatedID: grout.ID, Type: prodDom.RelationAccessory})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Grout", acc.Related.Name)
+
+	alt, err := s.repo.CreateRelation(s.ctx, &prodDom.ProductRelation{ProductID: tile.ID, RelatedID: other.ID, Type: prodDom.RelationAlternative})
+	require.NoError(s.T(), err)
+
+	// симметричную связь нельзя завести второй раз с обратной стороны
+	_, err = s.repo.CreateRelation(s.ctx, &prodDom.ProductRelation{ProductID: other.ID, RelatedID: tile.ID, Type: prodDom.RelationAlternative})
+	require.Error(s.T(), err)
+
+	got, err := s.repo.Get(s.ctx, tile.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), got.Relations, 2)
+
+	// альтернатива видна и со стороны другого товара, accessory — нет
+	rels, err := s.repo.ListRelations(s.ctx, other.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), rels, 1)
+	require.Equal(s.T(), tile.ID, rels[0].RelatedID)
+
+	// симметричную связь можно изменить со стороны другого товара, accessory — нет
+	upd, err := s.repo.UpdateRelation(s.ctx, &prodDom.ProductRelation{ID: alt.ID, ProductID: other.ID, RelatedID: tile.ID, Type: prodDom.RelationCompatibleWith})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), prodDom.RelationCompatibleWith, upd.Type)
+	_, err = s.repo.UpdateRelation(s.ctx, &prodDom.ProductRelation{ID: acc.ID, ProductID: grout.ID, RelatedID: other.ID, Type: prodDom.RelationAccessory})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+
+	require.NoError(s.T(), s.repo.DeleteRelation(s.ctx, tile.ID, acc.ID))
+	rels, err = s.repo.ListRelations(s.ctx, tile.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), rels, 1)
+}
+
+func (s *PGProductRepositorySuite) Test_RatingAggregateAndSort() {
+	catID := s.createCategory("Rated")
+	low, err := s.repo.Create(s.ctx, &prodDom.Product{Name: "Low", Price: money.FromFloat(100, money.Base), CategoryID: catID})
+	require.NoError(s.T(), err)
+	high, err := s.repo.Create(s.ctx, &prodDom.Product{Name: "High", Price: money.FromFloat(200, money.Base), CategoryID: catID})
+	require.NoError(s.T(), err)
+
+	addReview := func(productID int64, rating int, status string) {
+		_, err := s.db.Exec(
+			`INSERT INTO product_reviews(product_id, author_name, rating, status) VALUES ($1, 'Test', $2, $3)`,
+			productID, rating, status,
+		)
+		require.NoError(s.T(), err)
+	}
+	addReview(low.ID, 2, "approved")
+	addReview(high.ID, 5, "approved")
+	addReview(high.ID, 4, "approved")
+	// неодобренные отзывы в рейтинг не входят
+	addReview(high.ID, 1, "pending")
+	addReview(high.ID, 1, "rejected")
+
+	got, err := s.repo.Get(s.ctx, high.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(2), got.Rating.Count)
+	require.InDelta(s.T(), 4.5, got.Rating.Average, 0.001)
+
+	list, err := s.repo.ListByCategory(s.ctx, catID, prodDom.SortRatingDesc)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list, 2)
+	require.Equal(s.T(), high.ID, list[0].ID)
+
+	list, err = s.repo.ListByCategory(s.ctx, catID, prodDom.SortPriceAsc)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), low.ID, list[0].ID)
+
+	_, err = s.repo.ListByCategory(s.ctx, catID, prodDom.SortOrder("bogus"))
+	require.ErrorIs(s.T(), err, prodDom.ErrInvalidSort)
+}
+
 func TestPGProductRepositorySuite(t *testing.T) {
 	suite.Run(t, new(PGProductRepositorySuite))
 }
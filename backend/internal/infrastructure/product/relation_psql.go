@@ -0,0 +1,173 @@
+package product
+
+import (
+	"context"
+
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+)
+
+// selectRelations выбирает связи товара $1 вместе с кратким описанием связанного товара.
+// Симметричные связи (alternative, compatible_with), заведённые со стороны другого товара,
+// разворачиваются так, чтобы product_id всегда был равен $1.
+const selectRelations = `
+	SELECT
+		r.relation_id,
+		$1::bigint AS product_id,
+		p.product_id AS related_product_id,
+		r.relation_type,
+		r.created_at,
+		p.name AS related_name,
+		p.price AS related_price,
+		p.image_url AS related_image_url
+	FROM product_relations r
+	JOIN products p
+	  ON p.product_id = CASE WHEN r.product_id = $1 THEN r.related_product_id ELSE r.product_id END
+	WHERE (r.product_id = $1
+	   OR (r.related_product_id = $1 AND r.relation_type IN ('alternative', 'compatible_with')))
+`
+
+// ListRelations возвращает все связи товара.
+func (r *PGProductRepository) ListRelations(ctx context.Context, productID int64) ([]prodDom.ProductRelation, error) {
+	if err := r.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+	return r.fetchRelations(ctx, productID)
+}
+
+// CreateRelation заводит связь и возвращает её вместе со связанным товаром.
+func (r *PGProductRepository) CreateRelation(ctx context.Context, rel *prodDom.ProductRelation) (*prodDom.ProductRelation, error) {
+	const q = `
+		INSERT INTO product_relations (product_id, related_product_id, relation_type)
+		VALUES ($1, $2, $3)
+		RETURNING relation_id
+	`
+	var id int64
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q, rel.ProductID, rel.RelatedID, string(rel.Type)).Scan(&id)
+	})
+	if err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	return r.fetchRelation(ctx, rel.ProductID, id)
+}
+
+// UpdateRelation меняет связанный товар и/или тип связи. Симметричную связь,
+// как и в DeleteRelation, можно изменить с любой стороны: строка
+// переписывается от лица rel.ProductID.
+func (r *PGProductRepository) UpdateRelation(ctx context.Context, rel *prodDom.ProductRelation) (*prodDom.ProductRelation, error) {
+	const q = `
+		UPDATE product_relations
+		   SET product_id = $4, related_product_id = $1, relation_type = $2
+		 WHERE relation_id = $3
+		   AND (product_id = $4
+		    OR (related_product_id = $4 AND relation_type IN ('alternative', 'compatible_with')))
+	`
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, rel.RelatedID, string(rel.Type), rel.ID, rel.ProductID)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	return r.fetchRelation(ctx, rel.ProductID, rel.ID)
+}
+
+// DeleteRelation удаляет связь; симметричную связь можно удалить с любой стороны.
+func (r *PGProductRepository) DeleteRelation(ctx context.Context, productID, relationID int64) error {
+	const q = `
+		DELETE FROM product_relations
+		 WHERE relation_id = $1
+		   AND (product_id = $2
+		    OR (related_product_id = $2 AND relation_type IN ('alternative', 'compatible_with')))
+	`
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, relationID, productID)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	return r.mapPostgreSQLError(err)
+}
+
+// ListBoughtTogether возвращает товары, которые чаще всего встречаются
+// вместе с productID в одних пресетах, по убыванию частоты.
+func (r *PGProductRepository) ListBoughtTogether(ctx context.Context, productID int64, limit int) ([]prodDom.ProductSummary, error) {
+	const q = `
+		SELECT p.product_id, p.name, p.price, p.image_url
+		FROM preset_items a
+		JOIN preset_items b ON b.preset_id = a.preset_id AND b.product_id <> a.product_id
+		JOIN products p ON p.product_id = b.product_id
+		WHERE a.product_id = $1
+		GROUP BY p.product_id, p.name, p.price, p.image_url
+		ORDER BY COUNT(*) DESC, p.product_id
+		LIMIT $2
+	`
+	var rows []productSummaryRow
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &rows, q, productID, limit)
+	})
+	if err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	res := make([]prodDom.ProductSummary, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, row.toDomain())
+	}
+	return res, nil
+}
+
+func (r *PGProductRepository) fetchRelations(ctx context.Context, productID int64) ([]prodDom.ProductRelation, error) {
+	const q = selectRelations + ` ORDER BY r.relation_type, r.relation_id`
+	var rows []productRelationRow
+	err := r.withQuery(ctx, q, func() error {
+		return r.conn(ctx).SelectContext(ctx, &rows, q, productID)
+	})
+	if err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	res := make([]prodDom.ProductRelation, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, row.toDomain())
+	}
+	return res, nil
+}
+
+func (r *PGProductRepository) fetchRelation(ctx context.Context, productID, relationID int64) (*prodDom.ProductRelation, error) {
+	const q = selectRelations + ` AND r.relation_id = $2`
+	var row productRelationRow
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &row, q, productID, relationID)
+	})
+	if err != nil {
+		return nil, r.mapPostgreSQLError(err)
+	}
+	rel := row.toDomain()
+	return &rel, nil
+}
+
+func (r *PGProductRepository) ensureProductExists(ctx context.Context, productID int64) error {
+	const q = `SELECT EXISTS(SELECT 1 FROM products WHERE product_id = $1)`
+	var exists bool
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &exists, q, productID)
+	})
+	if err != nil {
+		return r.mapPostgreSQLError(err)
+	}
+	if !exists {
+		return app_error.ErrNotFound
+	}
+	return nil
+}
@@ -4,27 +4,55 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/attribute"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/category"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/coefficients"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/currency"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/discount"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/external"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/lead"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/preset"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/product"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/review"
 	"github.com/Neimess/zorkin-store-project/internal/infrastructure/service"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/translation"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/webhook"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	"github.com/jmoiron/sqlx"
 )
 
 type Deps struct {
 	DB     *sqlx.DB
 	Logger *slog.Logger
+	// Rounding — правила округления цен при пересчёте в другие валюты.
+	Rounding money.RoundingRules
 }
 
+// Repositories — репозитории приложения. Репозитории каталога обёрнуты
+// в translation.Localized*: их чтения учитывают локаль, валюту и скидки из контекста.
 type Repositories struct {
-	ProductRepository     *product.PGProductRepository
-	CategoryRepository    *category.PGCategoryRepository
-	PresetRepository      *preset.PGPresetRepository
-	AttributeRepository   *attribute.PGAttributeRepository
+	ProductRepository     *translation.LocalizedProductRepository
+	CategoryRepository    *translation.LocalizedCategoryRepository
+	PresetRepository      *translation.LocalizedPresetRepository
+	AttributeRepository   *translation.LocalizedAttributeRepository
 	CoefficientRepository *coefficients.PGCoefficientsRepository
-	ServiceRepository     *service.PGServiceRepository
+	ServiceRepository     *translation.LocalizedServiceRepository
+	ReviewRepository      *review.PGReviewRepository
+	LeadRepository        *lead.PGLeadRepository
+	TranslationRepository *translation.PGTranslationRepository
+	CurrencyRepository    *currency.PGCurrencyRepository
+	DiscountRepository    *discount.PGDiscountRepository
+	WebhookRepository     *webhook.PGWebhookRepository
+	ExchangeRepository    *exchange.PGExchangeRepository
+	ExternalRepository    *external.PGExternalRepository
+	IdempotencyRepository *idempotency.PGIdempotencyRepository
+	SnapshotRepository    *snapshot.PGSnapshotRepository
+	// Transactor открывает транзакцию, в которой работают репозитории выше.
+	Transactor *tx.Transactor
 }
 
 func New(deps Deps) (*Repositories, error) {
@@ -43,13 +71,29 @@ func New(deps Deps) (*Repositories, error) {
 	}
 	coeffRepo := coefficients.NewPGCoefficientsRepository(deps.DB, deps.Logger)
 	serviceRepo := service.NewPGServiceRepository(deps.DB, deps.Logger)
+	trRepo := translation.NewPGTranslationRepository(deps.DB, deps.Logger)
+	curRepo := currency.NewPGCurrencyRepository(deps.DB, deps.Logger)
+	discountRepo := discount.NewPGDiscountRepository(deps.DB, deps.Logger)
+	prices := currency.NewConverter(curRepo, deps.Rounding)
+	localizer := translation.NewLocalizer(trRepo, prices, discount.NewPricer(discountRepo, prices), deps.Logger)
 	r := &Repositories{
-		ProductRepository:     product.NewPGProductRepository(depsProduct),
-		CategoryRepository:    category.NewPGCategoryRepository(depsCat),
-		PresetRepository:      preset.NewPGPresetRepository(deps.DB, deps.Logger),
-		AttributeRepository:   attribute.NewPGAttributeRepository(depsAttr),
+		ProductRepository:     translation.NewLocalizedProductRepository(product.NewPGProductRepository(depsProduct), localizer),
+		CategoryRepository:    translation.NewLocalizedCategoryRepository(category.NewPGCategoryRepository(depsCat), localizer),
+		PresetRepository:      translation.NewLocalizedPresetRepository(preset.NewPGPresetRepository(deps.DB, deps.Logger), localizer),
+		AttributeRepository:   translation.NewLocalizedAttributeRepository(attribute.NewPGAttributeRepository(depsAttr), localizer),
 		CoefficientRepository: coeffRepo,
-		ServiceRepository:     serviceRepo,
+		ServiceRepository:     translation.NewLocalizedServiceRepository(serviceRepo, localizer),
+		ReviewRepository:      review.NewPGReviewRepository(deps.DB, deps.Logger),
+		LeadRepository:        lead.NewPGLeadRepository(deps.DB, deps.Logger),
+		TranslationRepository: trRepo,
+		CurrencyRepository:    curRepo,
+		DiscountRepository:    discountRepo,
+		WebhookRepository:     webhook.NewPGWebhookRepository(deps.DB, deps.Logger),
+		ExchangeRepository:    exchange.NewPGExchangeRepository(deps.DB, deps.Logger),
+		ExternalRepository:    external.NewPGExternalRepository(deps.DB, deps.Logger),
+		IdempotencyRepository: idempotency.NewPGIdempotencyRepository(deps.DB, deps.Logger),
+		SnapshotRepository:    snapshot.NewPGSnapshotRepository(deps.DB, deps.Logger),
+		Transactor:            tx.NewTransactor(deps.DB),
 	}
 
 	r.mustValidate()
@@ -70,5 +114,27 @@ func (r *Repositories) mustValidate() {
 		panic("CoefficientRepository is not initialized")
 	case r.ServiceRepository == nil:
 		panic("ServiceRepository is not initialized")
+	case r.ReviewRepository == nil:
+		panic("ReviewRepository is not initialized")
+	case r.LeadRepository == nil:
+		panic("LeadRepository is not initialized")
+	case r.TranslationRepository == nil:
+		panic("TranslationRepository is not initialized")
+	case r.CurrencyRepository == nil:
+		panic("CurrencyRepository is not initialized")
+	case r.DiscountRepository == nil:
+		panic("DiscountRepository is not initialized")
+	case r.WebhookRepository == nil:
+		panic("WebhookRepository is not initialized")
+	case r.ExchangeRepository == nil:
+		panic("ExchangeRepository is not initialized")
+	case r.ExternalRepository == nil:
+		panic("ExternalRepository is not initialized")
+	case r.IdempotencyRepository == nil:
+		panic("IdempotencyRepository is not initialized")
+	case r.SnapshotRepository == nil:
+		panic("SnapshotRepository is not initialized")
+	case r.Transactor == nil:
+		panic("Transactor is not initialized")
 	}
 }
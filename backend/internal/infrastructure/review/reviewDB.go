@@ -0,0 +1,53 @@
+package review
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+)
+
+type reviewDB struct {
+	ID           int64          `db:"review_id"`
+	ProductID    int64          `db:"product_id"`
+	AuthorName   string         `db:"author_name"`
+	Rating       int            `db:"rating"`
+	Text         sql.NullString `db:"text"`
+	Photos       pq.StringArray `db:"photos"`
+	Status       string         `db:"status"`
+	RejectReason sql.NullString `db:"reject_reason"`
+	CreatedAt    time.Time      `db:"created_at"`
+	ModeratedAt  sql.NullTime   `db:"moderated_at"`
+}
+
+func (r reviewDB) toDomain() *domReview.Review {
+	rv := &domReview.Review{
+		ID:         r.ID,
+		ProductID:  r.ProductID,
+		AuthorName: r.AuthorName,
+		Rating:     r.Rating,
+		Photos:     []string(r.Photos),
+		Status:     domReview.Status(r.Status),
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.Text.Valid {
+		rv.Text = &r.Text.String
+	}
+	if r.RejectReason.Valid {
+		rv.RejectReason = &r.RejectReason.String
+	}
+	if r.ModeratedAt.Valid {
+		rv.ModeratedAt = &r.ModeratedAt.Time
+	}
+	return rv
+}
+
+func rawReviewListToDomain(raws []reviewDB) []domReview.Review {
+	reviews := make([]domReview.Review, len(raws))
+	for i, r := range raws {
+		reviews[i] = *r.toDomain()
+	}
+	return reviews
+}
@@ -0,0 +1,141 @@
+package review
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+const selectReview = `
+	SELECT review_id, product_id, author_name, rating, text, photos, status,
+	       reject_reason, created_at, moderated_at
+	FROM product_reviews
+`
+
+type PGReviewRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGReviewRepository(db *sqlx.DB, log *slog.Logger) *PGReviewRepository {
+	if db == nil {
+		panic("NewPGReviewRepository: db is nil")
+	}
+	return &PGReviewRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create сохраняет отзыв; статус берётся из домена (новые отзывы — pending).
+func (r *PGReviewRepository) Create(ctx context.Context, rv *domReview.Review) (*domReview.Review, error) {
+	const q = `
+		INSERT INTO product_reviews (product_id, author_name, rating, text, photos, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING review_id, created_at
+	`
+	photos := rv.Photos
+	if photos == nil {
+		photos = []string{}
+	}
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q,
+			rv.ProductID, rv.AuthorName, rv.Rating, rv.Text, pq.Array(photos), string(rv.Status),
+		).Scan(&rv.ID, &rv.CreatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rv, nil
+}
+
+func (r *PGReviewRepository) Get(ctx context.Context, id int64) (*domReview.Review, error) {
+	const q = selectReview + `WHERE review_id = $1`
+	var raw reviewDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &raw, q, id)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return raw.toDomain(), nil
+}
+
+// ListByProduct возвращает отзывы товара с указанным статусом, новые сверху.
+func (r *PGReviewRepository) ListByProduct(ctx context.Context, productID int64, status domReview.Status) ([]domReview.Review, error) {
+	const q = selectReview + `WHERE product_id = $1 AND status = $2 ORDER BY created_at DESC, review_id DESC`
+	var raws []reviewDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, productID, string(status))
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawReviewListToDomain(raws), nil
+}
+
+// ListByStatus возвращает очередь модерации: старые отзывы первыми.
+func (r *PGReviewRepository) ListByStatus(ctx context.Context, status domReview.Status) ([]domReview.Review, error) {
+	const q = selectReview + `WHERE status = $1 ORDER BY created_at, review_id`
+	var raws []reviewDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(status))
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawReviewListToDomain(raws), nil
+}
+
+// UpdateStatus сохраняет результат модерации. Обновляются только отзывы
+// в статусе pending, поэтому повторная модерация вернёт ErrNotFound.
+func (r *PGReviewRepository) UpdateStatus(ctx context.Context, rv *domReview.Review) error {
+	const q = `
+		UPDATE product_reviews
+		   SET status = $1, reject_reason = $2, moderated_at = $3
+		 WHERE review_id = $4 AND status = 'pending'
+	`
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, string(rv.Status), rv.RejectReason, rv.ModeratedAt, rv.ID)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	return nil
+}
+
+func (r *PGReviewRepository) Delete(ctx context.Context, id int64) error {
+	const q = `DELETE FROM product_reviews WHERE review_id = $1`
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	return nil
+}
+
+func (r *PGReviewRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
@@ -0,0 +1,116 @@
+package review_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	reviewRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/review"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGReviewRepositorySuite struct {
+	suite.Suite
+	repo *reviewRepo.PGReviewRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+	db   *sqlx.DB
+}
+
+func (s *PGReviewRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	s.repo = reviewRepo.NewPGReviewRepository(s.db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGReviewRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGReviewRepositorySuite) createProduct() int64 {
+	var catID, prodID int64
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO categories(name) VALUES ($1) RETURNING category_id`,
+		fmt.Sprintf("ReviewCat_%d", time.Now().UnixNano()),
+	).Scan(&catID))
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO products(name, price, category_id) VALUES ('Reviewed', 100, $1) RETURNING product_id`,
+		catID,
+	).Scan(&prodID))
+	return prodID
+}
+
+func (s *PGReviewRepositorySuite) Test_CreateModerateList() {
+	prodID := s.createProduct()
+	text := "Отличная плитка"
+
+	created, err := s.repo.Create(s.ctx, &domReview.Review{
+		ProductID:  prodID,
+		AuthorName: "Иван",
+		Rating:     5,
+		Text:       &text,
+		Photos:     []string{"https://example.com/1.jpg"},
+		Status:     domReview.StatusPending,
+	})
+	require.NoError(s.T(), err)
+	require.NotZero(s.T(), created.ID)
+
+	queue, err := s.repo.ListByStatus(s.ctx, domReview.StatusPending)
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), queue)
+
+	approved, err := s.repo.ListByProduct(s.ctx, prodID, domReview.StatusApproved)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), approved)
+
+	require.NoError(s.T(), created.Moderate(domReview.StatusApproved, nil, time.Now()))
+	require.NoError(s.T(), s.repo.UpdateStatus(s.ctx, created))
+
+	approved, err = s.repo.ListByProduct(s.ctx, prodID, domReview.StatusApproved)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), approved, 1)
+	require.Equal(s.T(), []string{"https://example.com/1.jpg"}, approved[0].Photos)
+	require.NotNil(s.T(), approved[0].ModeratedAt)
+
+	// повторная модерация не проходит
+	err = s.repo.UpdateStatus(s.ctx, created)
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+
+	require.NoError(s.T(), s.repo.Delete(s.ctx, created.ID))
+	_, err = s.repo.Get(s.ctx, created.ID)
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *PGReviewRepositorySuite) Test_CreateForMissingProduct() {
+	_, err := s.repo.Create(s.ctx, &domReview.Review{
+		ProductID:  999999,
+		AuthorName: "Nobody",
+		Rating:     3,
+		Status:     domReview.StatusPending,
+	})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func TestPGReviewRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGReviewRepositorySuite))
+}
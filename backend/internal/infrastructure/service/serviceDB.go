@@ -1,14 +1,17 @@
 package service
 
 import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/shopspring/decimal"
 )
 
 type ServiceDB struct {
-	ID          int64   `db:"service_id"`
-	Name        string  `db:"name"`
-	Description *string `db:"description"`
-	Price       float64 `db:"price"`
+	ID          int64           `db:"service_id"`
+	Name        string          `db:"name"`
+	Description *string         `db:"description"`
+	Price       decimal.Decimal `db:"price"`
+	Version     int64           `db:"version"`
 }
 
 func (s ServiceDB) toDomain() *domService.Service {
@@ -16,7 +19,8 @@ func (s ServiceDB) toDomain() *domService.Service {
 		ID:          s.ID,
 		Name:        s.Name,
 		Description: s.Description,
-		Price:       s.Price,
+		Price:       money.New(s.Price, money.Base),
+		Version:     s.Version,
 	}
 }
 
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
 	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -27,7 +31,7 @@ func NewPGServiceRepository(db *sqlx.DB, log *slog.Logger) *PGServiceRepository
 func (r *PGServiceRepository) Create(ctx context.Context, s *domService.Service) (*domService.Service, error) {
 	const q = `INSERT INTO services (name, description, price) VALUES ($1, $2, $3) RETURNING service_id`
 	var id int64
-	err := r.db.QueryRowContext(ctx, q, s.Name, s.Description, s.Price).Scan(&id)
+	err := r.conn(ctx).QueryRowContext(ctx, q, s.Name, s.Description, s.Price.Amount).Scan(&id)
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
 	}
@@ -36,9 +40,9 @@ func (r *PGServiceRepository) Create(ctx context.Context, s *domService.Service)
 }
 
 func (r *PGServiceRepository) Get(ctx context.Context, id int64) (*domService.Service, error) {
-	const q = `SELECT service_id, name, description, price FROM services WHERE service_id = $1`
+	const q = `SELECT service_id, name, description, price, version FROM services WHERE service_id = $1`
 	var raw ServiceDB
-	err := r.db.GetContext(ctx, &raw, q, id)
+	err := r.conn(ctx).GetContext(ctx, &raw, q, id)
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
 	}
@@ -46,9 +50,9 @@ func (r *PGServiceRepository) Get(ctx context.Context, id int64) (*domService.Se
 }
 
 func (r *PGServiceRepository) List(ctx context.Context) ([]domService.Service, error) {
-	const q = `SELECT service_id, name, description, price FROM services`
+	const q = `SELECT service_id, name, description, price, version FROM services`
 	var raws []ServiceDB
-	err := r.db.SelectContext(ctx, &raws, q)
+	err := r.conn(ctx).SelectContext(ctx, &raws, q)
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
 	}
@@ -56,20 +60,46 @@ func (r *PGServiceRepository) List(ctx context.Context) ([]domService.Service, e
 }
 
 func (r *PGServiceRepository) Update(ctx context.Context, s *domService.Service) (*domService.Service, error) {
-	const q = `UPDATE services SET name = $1, description = $2, price = $3 WHERE service_id = $4`
-	_, err := r.db.ExecContext(ctx, q, s.Name, s.Description, s.Price, s.ID)
+	guard, args := database.Guard(ctx, []any{s.Name, s.Description, s.Price.Amount, s.ID})
+	q := `UPDATE services SET name = $1, description = $2, price = $3, version = version + 1 WHERE service_id = $4` + guard + ` RETURNING version`
+	err := r.conn(ctx).GetContext(ctx, &s.Version, q, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = database.NoRows(ctx, r.conn(ctx), "services", "service_id", s.ID)
+	}
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
 	}
 	return s, nil
 }
 
+// Patch записывает только изменённые поля услуги. Если услуга изменилась
+// после чтения, возвращается ErrVersionConflict.
+func (r *PGServiceRepository) Patch(ctx context.Context, p *domService.Patch) (*domService.Service, error) {
+	u := database.NewUpdate("services", "service_id")
+	if p.Name.Set {
+		u.Set("name", p.Name.Value)
+	}
+	if p.Description.Set {
+		u.Set("description", p.Description.Value)
+	}
+	if p.Price.Set {
+		u.Set("price", p.Price.Value.Amount)
+	}
+	if _, err := u.Exec(ctx, r.conn(ctx), p.ID, p.Version); err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return r.Get(ctx, p.ID)
+}
+
 func (r *PGServiceRepository) Delete(ctx context.Context, id int64) error {
-	const q = `DELETE FROM services WHERE service_id = $1`
-	_, err := r.db.ExecContext(ctx, q, id)
+	guard, args := database.Guard(ctx, []any{id})
+	res, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM services WHERE service_id = $1`+guard, args...)
 	if err != nil {
 		return repoError.MapPostgreSQLError(r.log, err)
 	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoError.MapPostgreSQLError(r.log, database.NoRows(ctx, r.conn(ctx), "services", "service_id", id))
+	}
 	return nil
 }
 
@@ -83,7 +113,7 @@ func (r *PGServiceRepository) AddServicesToProduct(ctx context.Context, productI
 	for i := range serviceIDs {
 		prodIDs[i] = productID
 	}
-	_, err := r.db.ExecContext(ctx, q, prodIDs, serviceIDs)
+	_, err := r.conn(ctx).ExecContext(ctx, q, prodIDs, serviceIDs)
 	if err != nil {
 		return repoError.MapPostgreSQLError(r.log, err)
 	}
@@ -91,11 +121,16 @@ func (r *PGServiceRepository) AddServicesToProduct(ctx context.Context, productI
 }
 
 func (r *PGServiceRepository) GetServicesByProduct(ctx context.Context, productID int64) ([]domService.Service, error) {
-	const q = `SELECT s.service_id, s.name, s.description, s.price FROM services s JOIN product_services ps ON s.service_id = ps.service_id WHERE ps.product_id = $1`
+	const q = `SELECT s.service_id, s.name, s.description, s.price, s.version FROM services s JOIN product_services ps ON s.service_id = ps.service_id WHERE ps.product_id = $1`
 	var raws []ServiceDB
-	err := r.db.SelectContext(ctx, &raws, q, productID)
+	err := r.conn(ctx).SelectContext(ctx, &raws, q, productID)
 	if err != nil {
 		return nil, repoError.MapPostgreSQLError(r.log, err)
 	}
 	return rawServiceListToDomain(raws), nil
 }
+
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул.
+func (r *PGServiceRepository) conn(ctx context.Context) tx.Querier {
+	return tx.Q(ctx, r.db)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
 	serviceRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/service"
 )
@@ -50,7 +51,7 @@ func (s *PGServiceRepositorySuite) TearDownSuite() {
 
 func (s *PGServiceRepositorySuite) Test_CRUD() {
 	ctx := s.ctx
-	svc := &domService.Service{Name: "TestService", Description: ptr("desc"), Price: 10.5}
+	svc := &domService.Service{Name: "TestService", Description: ptr("desc"), Price: money.FromFloat(10.5, money.Base)}
 	created, err := s.repo.Create(ctx, svc)
 	require.NoError(s.T(), err)
 	require.NotZero(s.T(), created.ID)
@@ -0,0 +1,169 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+)
+
+// Снимок в NDJSON — по JSON-объекту на строку:
+//
+//	{"format":"zorkin-catalog","version":1,"schema_version":21,"created_at":"..."}
+//	{"type":"category","data":{"id":1,"name":"Плитка"}}
+//	...
+//	{"type":"end","data":{"counts":{"category":1}}}
+//
+// Первая строка — заголовок, последняя — запись end с количеством записей.
+
+type line struct {
+	Type domSnapshot.Kind `json:"type"`
+	Data json.RawMessage  `json:"data"`
+}
+
+// Codec читает и пишет снимки в NDJSON.
+type Codec struct{}
+
+func NewCodec() Codec { return Codec{} }
+
+// encoder пишет записи снимка; Close дописывает запись end и сбрасывает буфер.
+type encoder struct {
+	bw     *bufio.Writer
+	enc    *json.Encoder
+	counts domSnapshot.Counts
+}
+
+func (Codec) NewWriter(w io.Writer, h domSnapshot.Header) (domSnapshot.Writer, error) {
+	bw := bufio.NewWriterSize(w, 64<<10)
+	e := &encoder{bw: bw, enc: json.NewEncoder(bw), counts: domSnapshot.Counts{}}
+	if err := e.enc.Encode(h); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *encoder) Write(rec domSnapshot.Record) error {
+	if err := e.enc.Encode(struct {
+		Type domSnapshot.Kind `json:"type"`
+		Data any              `json:"data"`
+	}{rec.Kind, rec.Data}); err != nil {
+		return err
+	}
+	e.counts[rec.Kind]++
+	return nil
+}
+
+func (e *encoder) Close() error {
+	if err := e.Write(domSnapshot.Record{Kind: domSnapshot.KindEnd, Data: domSnapshot.End{Counts: e.counts}}); err != nil {
+		return err
+	}
+	return e.bw.Flush()
+}
+
+// decoder читает снимок. Next возвращает io.EOF только после записи end,
+// количество записей в которой совпало с прочитанным; поток, оборванный
+// раньше, даёт ErrTruncated.
+type decoder struct {
+	dec    *json.Decoder
+	header domSnapshot.Header
+	counts domSnapshot.Counts
+	line   int
+	done   bool
+}
+
+// NewReader читает заголовок. Формат и версию проверяет вызывающий:
+// записи других версий Next может разобрать неверно.
+func (Codec) NewReader(r io.Reader) (domSnapshot.Reader, error) {
+	d := &decoder{dec: json.NewDecoder(bufio.NewReaderSize(r, 64<<10)), counts: domSnapshot.Counts{}, line: 1}
+	if err := d.dec.Decode(&d.header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty input", domSnapshot.ErrUnsupportedFormat)
+		}
+		return nil, fmt.Errorf("%w: %w", domSnapshot.ErrUnsupportedFormat, err)
+	}
+	return d, nil
+}
+
+func (d *decoder) Header() domSnapshot.Header {
+	return d.header
+}
+
+func (d *decoder) Next() (domSnapshot.Record, error) {
+	if d.done {
+		return domSnapshot.Record{}, io.EOF
+	}
+	d.line++
+	var l line
+	if err := d.dec.Decode(&l); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return domSnapshot.Record{}, domSnapshot.ErrTruncated
+		}
+		// ошибка чтения (например, лимит тела запроса) остаётся в цепочке
+		return domSnapshot.Record{}, fmt.Errorf("%w: line %d: %w", domSnapshot.ErrMalformed, d.line, err)
+	}
+
+	if l.Type == domSnapshot.KindEnd {
+		return domSnapshot.Record{}, d.end(l.Data)
+	}
+	data := newRecordData(l.Type)
+	if data == nil {
+		return domSnapshot.Record{}, d.malformed("unknown record type %q", l.Type)
+	}
+	if err := json.Unmarshal(l.Data, data); err != nil {
+		return domSnapshot.Record{}, d.malformed("%s: %v", l.Type, err)
+	}
+	d.counts[l.Type]++
+	return domSnapshot.Record{Kind: l.Type, Data: data}, nil
+}
+
+// end сверяет запись end с прочитанным и проверяет, что после неё ничего нет.
+func (d *decoder) end(raw json.RawMessage) error {
+	var end domSnapshot.End
+	if err := json.Unmarshal(raw, &end); err != nil {
+		return d.malformed("end: %v", err)
+	}
+	expected := maps.Clone(end.Counts)
+	maps.DeleteFunc(expected, func(_ domSnapshot.Kind, n int) bool { return n == 0 })
+	if !maps.Equal(expected, d.counts) {
+		return fmt.Errorf("%w: end record counts %v, read %v", domSnapshot.ErrTruncated, end.Counts, d.counts)
+	}
+	if d.dec.More() {
+		return d.malformed("data after end record")
+	}
+	d.done = true
+	return io.EOF
+}
+
+func (d *decoder) malformed(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", domSnapshot.ErrMalformed, d.line, fmt.Sprintf(format, args...))
+}
+
+func newRecordData(kind domSnapshot.Kind) any {
+	switch kind {
+	case domSnapshot.KindCategory:
+		return &domSnapshot.Category{}
+	case domSnapshot.KindAttribute:
+		return &domSnapshot.Attribute{}
+	case domSnapshot.KindService:
+		return &domSnapshot.Service{}
+	case domSnapshot.KindProduct:
+		return &domSnapshot.Product{}
+	case domSnapshot.KindProductAttribute:
+		return &domSnapshot.ProductAttribute{}
+	case domSnapshot.KindProductService:
+		return &domSnapshot.ProductService{}
+	case domSnapshot.KindPreset:
+		return &domSnapshot.Preset{}
+	case domSnapshot.KindPresetItem:
+		return &domSnapshot.PresetItem{}
+	case domSnapshot.KindPresetAlternative:
+		return &domSnapshot.PresetAlternative{}
+	case domSnapshot.KindCoefficient:
+		return &domSnapshot.Coefficient{}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	snapshotInfra "github.com/Neimess/zorkin-store-project/internal/infrastructure/snapshot"
+)
+
+const header = `{"format":"zorkin-catalog","version":1,"schema_version":21,"created_at":"2026-01-02T03:04:05Z"}`
+
+func readAll(t *testing.T, input string) ([]domSnapshot.Record, error) {
+	t.Helper()
+	rd, err := snapshotInfra.NewCodec().NewReader(strings.NewReader(input))
+	require.NoError(t, err)
+	var recs []domSnapshot.Record
+	for {
+		rec, err := rd.Next()
+		if err == io.EOF {
+			return recs, nil
+		}
+		if err != nil {
+			return recs, err
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	unit := "м²"
+	price := decimal.RequireFromString("1290.5")
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []domSnapshot.Record{
+		{Kind: domSnapshot.KindCategory, Data: &domSnapshot.Category{ID: 3, Name: "Плитка"}},
+		{Kind: domSnapshot.KindAttribute, Data: &domSnapshot.Attribute{ID: 7, Name: "Площадь", Unit: &unit, CategoryID: 3}},
+		{Kind: domSnapshot.KindProduct, Data: &domSnapshot.Product{ID: 11, Name: "Керамогранит", Price: &price, CategoryID: 3, CreatedAt: created}},
+		{Kind: domSnapshot.KindProductAttribute, Data: &domSnapshot.ProductAttribute{ProductID: 11, AttributeID: 7, Value: "1.44"}},
+		{Kind: domSnapshot.KindCoefficient, Data: &domSnapshot.Coefficient{ID: 1, Name: "Запас", Value: decimal.RequireFromString("1.1")}},
+	}
+
+	var buf bytes.Buffer
+	codec := snapshotInfra.NewCodec()
+	w, err := codec.NewWriter(&buf, domSnapshot.Header{Format: domSnapshot.Format, Version: domSnapshot.Version, SchemaVersion: 21, CreatedAt: created})
+	require.NoError(t, err)
+	for _, rec := range records {
+		require.NoError(t, w.Write(rec))
+	}
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(records)+2)
+	require.Equal(t, header, lines[0])
+	require.Equal(t, `{"type":"end","data":{"counts":{"attribute":1,"category":1,"coefficient":1,"product":1,"product_attribute":1}}}`, lines[len(lines)-1])
+
+	rd, err := codec.NewReader(&buf)
+	require.NoError(t, err)
+	require.Equal(t, domSnapshot.Format, rd.Header().Format)
+	require.Equal(t, uint(21), rd.Header().SchemaVersion)
+	for _, want := range records {
+		got, err := rd.Next()
+		require.NoError(t, err)
+		require.Equal(t, want.Kind, got.Kind)
+		require.Equal(t, want.Data, got.Data)
+	}
+	_, err = rd.Next()
+	require.ErrorIs(t, err, io.EOF)
+	_, err = rd.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestCodecRejects(t *testing.T) {
+	category := `{"type":"category","data":{"id":1,"name":"Плитка"}}`
+	end := `{"type":"end","data":{"counts":{"category":1}}}`
+
+	cases := []struct {
+		name  string
+		lines []string
+		err   error
+		msg   string
+	}{
+		{"no end record", []string{header, category}, domSnapshot.ErrTruncated, ""},
+		{"cut mid-line", []string{header, `{"type":"category","data":{"id":1,`}, domSnapshot.ErrTruncated, ""},
+		{"count mismatch", []string{header, `{"type":"end","data":{"counts":{"category":1}}}`}, domSnapshot.ErrTruncated, ""},
+		{"unknown type", []string{header, `{"type":"order","data":{}}`, end}, domSnapshot.ErrMalformed, "line 2"},
+		{"bad field", []string{header, category, `{"type":"product","data":{"id":"x"}}`}, domSnapshot.ErrMalformed, "line 3"},
+		{"data after end", []string{header, category, end, category}, domSnapshot.ErrMalformed, "after end"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readAll(t, strings.Join(tc.lines, "\n"))
+			require.ErrorIs(t, err, tc.err)
+			require.ErrorContains(t, err, tc.msg)
+		})
+	}
+
+	t.Run("read error is kept", func(t *testing.T) {
+		errLimit := errors.New("body limit")
+		input := io.MultiReader(strings.NewReader(header+"\n"+category+"\n"), iotest.ErrReader(errLimit))
+		rd, err := snapshotInfra.NewCodec().NewReader(input)
+		require.NoError(t, err)
+		_, err = rd.Next()
+		require.NoError(t, err)
+		_, err = rd.Next()
+		require.ErrorIs(t, err, errLimit)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		_, err := snapshotInfra.NewCodec().NewReader(strings.NewReader(""))
+		require.ErrorIs(t, err, domSnapshot.ErrUnsupportedFormat)
+	})
+}
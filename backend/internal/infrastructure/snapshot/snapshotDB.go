@@ -0,0 +1,168 @@
+package snapshot
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+)
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func nullInt(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int64
+}
+
+func nullDecimal(d decimal.NullDecimal) *decimal.Decimal {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Decimal
+}
+
+type categoryDB struct {
+	ID       int64         `db:"category_id"`
+	Name     string        `db:"name"`
+	ParentID sql.NullInt64 `db:"parent_id"`
+}
+
+func (c categoryDB) toDomain() any {
+	return &domSnapshot.Category{ID: c.ID, Name: c.Name, ParentID: nullInt(c.ParentID)}
+}
+
+type attributeDB struct {
+	ID         int64          `db:"attribute_id"`
+	Name       string         `db:"name"`
+	Unit       sql.NullString `db:"unit"`
+	CategoryID int64          `db:"category_id"`
+}
+
+func (a attributeDB) toDomain() any {
+	return &domSnapshot.Attribute{ID: a.ID, Name: a.Name, Unit: nullString(a.Unit), CategoryID: a.CategoryID}
+}
+
+type serviceDB struct {
+	ID          int64           `db:"service_id"`
+	Name        string          `db:"name"`
+	Description sql.NullString  `db:"description"`
+	Price       decimal.Decimal `db:"price"`
+}
+
+func (s serviceDB) toDomain() any {
+	return &domSnapshot.Service{ID: s.ID, Name: s.Name, Description: nullString(s.Description), Price: s.Price}
+}
+
+type productDB struct {
+	ID          int64               `db:"product_id"`
+	Name        string              `db:"name"`
+	Price       decimal.NullDecimal `db:"price"`
+	Description sql.NullString      `db:"description"`
+	CategoryID  int64               `db:"category_id"`
+	ImageURL    sql.NullString      `db:"image_url"`
+	Stock       decimal.NullDecimal `db:"stock"`
+	CreatedAt   time.Time           `db:"created_at"`
+}
+
+func (p productDB) toDomain() any {
+	return &domSnapshot.Product{
+		ID:          p.ID,
+		Name:        p.Name,
+		Price:       nullDecimal(p.Price),
+		Description: nullString(p.Description),
+		CategoryID:  p.CategoryID,
+		ImageURL:    nullString(p.ImageURL),
+		Stock:       nullDecimal(p.Stock),
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+type productAttributeDB struct {
+	ProductID   int64  `db:"product_id"`
+	AttributeID int64  `db:"attribute_id"`
+	Value       string `db:"value"`
+}
+
+func (pa productAttributeDB) toDomain() any {
+	return &domSnapshot.ProductAttribute{ProductID: pa.ProductID, AttributeID: pa.AttributeID, Value: pa.Value}
+}
+
+type productServiceDB struct {
+	ProductID int64 `db:"product_id"`
+	ServiceID int64 `db:"service_id"`
+}
+
+func (ps productServiceDB) toDomain() any {
+	return &domSnapshot.ProductService{ProductID: ps.ProductID, ServiceID: ps.ServiceID}
+}
+
+type presetDB struct {
+	ID          int64           `db:"preset_id"`
+	Name        string          `db:"name"`
+	Description sql.NullString  `db:"description"`
+	TotalPrice  decimal.Decimal `db:"total_price"`
+	ImageURL    sql.NullString  `db:"image_url"`
+	IsTemplate  bool            `db:"is_template"`
+	CreatedAt   time.Time       `db:"created_at"`
+}
+
+func (p presetDB) toDomain() any {
+	return &domSnapshot.Preset{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: nullString(p.Description),
+		TotalPrice:  p.TotalPrice,
+		ImageURL:    nullString(p.ImageURL),
+		IsTemplate:  p.IsTemplate,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+type presetItemDB struct {
+	PresetID        int64           `db:"preset_id"`
+	ProductID       int64           `db:"product_id"`
+	Quantity        decimal.Decimal `db:"quantity"`
+	QuantityFormula sql.NullString  `db:"quantity_formula"`
+	SlotName        sql.NullString  `db:"slot_name"`
+	Optional        bool            `db:"optional"`
+}
+
+func (it presetItemDB) toDomain() any {
+	return &domSnapshot.PresetItem{
+		PresetID:        it.PresetID,
+		ProductID:       it.ProductID,
+		Quantity:        it.Quantity,
+		QuantityFormula: nullString(it.QuantityFormula),
+		SlotName:        nullString(it.SlotName),
+		Optional:        it.Optional,
+	}
+}
+
+type presetAlternativeDB struct {
+	PresetID      int64 `db:"preset_id"`
+	ProductID     int64 `db:"product_id"`
+	AlternativeID int64 `db:"alternative_id"`
+}
+
+func (a presetAlternativeDB) toDomain() any {
+	return &domSnapshot.PresetAlternative{PresetID: a.PresetID, ProductID: a.ProductID, AlternativeID: a.AlternativeID}
+}
+
+type coefficientDB struct {
+	ID    int64           `db:"coefficient_id"`
+	Name  string          `db:"name"`
+	Value decimal.Decimal `db:"value"`
+}
+
+func (c coefficientDB) toDomain() any {
+	return &domSnapshot.Coefficient{ID: c.ID, Name: c.Name, Value: c.Value}
+}
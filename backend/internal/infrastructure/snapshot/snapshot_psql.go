@@ -0,0 +1,429 @@
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
+)
+
+type PGSnapshotRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGSnapshotRepository(db *sqlx.DB, log *slog.Logger) *PGSnapshotRepository {
+	if db == nil {
+		panic("NewPGSnapshotRepository: db is nil")
+	}
+	return &PGSnapshotRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// exportQueries — выборки таблиц в порядке domSnapshot.Kinds. Категории
+// упорядочены по глубине, чтобы родитель шёл раньше детей.
+var exportQueries = map[domSnapshot.Kind]string{
+	domSnapshot.KindCategory: `
+		WITH RECURSIVE tree AS (
+			SELECT category_id, name, parent_id, 0 AS depth FROM categories WHERE parent_id IS NULL
+			UNION ALL
+			SELECT c.category_id, c.name, c.parent_id, t.depth + 1
+			FROM categories c JOIN tree t ON c.parent_id = t.category_id
+		)
+		SELECT category_id, name, parent_id FROM tree ORDER BY depth, category_id`,
+	domSnapshot.KindAttribute:        `SELECT attribute_id, name, unit, category_id FROM attributes ORDER BY attribute_id`,
+	domSnapshot.KindService:          `SELECT service_id, name, description, price FROM services ORDER BY service_id`,
+	domSnapshot.KindProduct:          `SELECT product_id, name, price, description, category_id, image_url, stock, created_at FROM products ORDER BY product_id`,
+	domSnapshot.KindProductAttribute: `SELECT product_id, attribute_id, value FROM product_attributes ORDER BY product_id, attribute_id`,
+	domSnapshot.KindProductService:   `SELECT product_id, service_id FROM product_services ORDER BY product_id, service_id`,
+	domSnapshot.KindPreset:           `SELECT preset_id, name, description, total_price, image_url, is_template, created_at FROM presets ORDER BY preset_id`,
+	domSnapshot.KindPresetItem:       `SELECT preset_id, product_id, quantity, quantity_formula, slot_name, optional FROM preset_items ORDER BY preset_id, preset_item_id`,
+	domSnapshot.KindPresetAlternative: `
+		SELECT pi.preset_id, pi.product_id, a.product_id AS alternative_id
+		FROM preset_item_alternatives a JOIN preset_items pi ON pi.preset_item_id = a.preset_item_id
+		ORDER BY pi.preset_id, pi.preset_item_id, a.product_id`,
+	domSnapshot.KindCoefficient: `SELECT coefficient_id, name, value FROM coefficients ORDER BY coefficient_id`,
+}
+
+// Export отдаёт каталог в emit запись за записью. Все таблицы читаются
+// в одной транзакции REPEATABLE READ, так что снимок согласован, даже если
+// каталог в это время правят.
+func (r *PGSnapshotRepository) Export(ctx context.Context, emit func(domSnapshot.Record) error) error {
+	t, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	defer func() { _ = t.Rollback() }()
+
+	for _, kind := range domSnapshot.Kinds {
+		var err error
+		q := exportQueries[kind]
+		switch kind {
+		case domSnapshot.KindCategory:
+			err = exportRows[categoryDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindAttribute:
+			err = exportRows[attributeDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindService:
+			err = exportRows[serviceDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindProduct:
+			err = exportRows[productDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindProductAttribute:
+			err = exportRows[productAttributeDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindProductService:
+			err = exportRows[productServiceDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindPreset:
+			err = exportRows[presetDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindPresetItem:
+			err = exportRows[presetItemDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindPresetAlternative:
+			err = exportRows[presetAlternativeDB](ctx, r, t, kind, q, emit)
+		case domSnapshot.KindCoefficient:
+			err = exportRows[coefficientDB](ctx, r, t, kind, q, emit)
+		}
+		if err != nil {
+			return fmt.Errorf("export %s: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+type rowDB interface {
+	toDomain() any
+}
+
+// exportRows читает строки курсором, не собирая таблицу в памяти. Ошибка
+// emit (например, оборванное соединение клиента) возвращается как есть.
+func exportRows[T rowDB](ctx context.Context, r *PGSnapshotRepository, t *sqlx.Tx, kind domSnapshot.Kind, q string, emit func(domSnapshot.Record) error) error {
+	var rows *sqlx.Rows
+	err := r.withQuery(ctx, q, func() error {
+		var err error
+		rows, err = t.QueryxContext(ctx, q)
+		return err
+	})
+	if err != nil {
+		return repoError.MapPostgreSQLError(r.log, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := rows.StructScan(&row); err != nil {
+			return repoError.MapPostgreSQLError(r.log, err)
+		}
+		if err := emit(domSnapshot.Record{Kind: kind, Data: row.toDomain()}); err != nil {
+			return err
+		}
+	}
+	return repoError.MapPostgreSQLError(r.log, rows.Err())
+}
+
+// restore — состояние восстановления внутри транзакции: соответствие
+// ID снимка новым ID по типам записей.
+type restore struct {
+	r   *PGSnapshotRepository
+	tx  *sqlx.Tx
+	ids map[domSnapshot.Kind]map[int64]int64
+	res domSnapshot.Result
+}
+
+// Restore заливает записи из next (до io.EOF) одной транзакцией: при любой
+// ошибке база остаётся как была. Вебхуки о созданных сущностях не отправляются.
+func (r *PGSnapshotRepository) Restore(ctx context.Context, mode domSnapshot.Mode, next func() (domSnapshot.Record, error)) (domSnapshot.Result, error) {
+	return tx.RunInTx(ctx, r.db, func(t *sqlx.Tx) (domSnapshot.Result, error) {
+		rs := &restore{
+			r:   r,
+			tx:  t,
+			ids: make(map[domSnapshot.Kind]map[int64]int64),
+			res: domSnapshot.Result{Mode: mode, Created: domSnapshot.Counts{}, Matched: domSnapshot.Counts{}},
+		}
+		if mode == domSnapshot.ModeReplace {
+			if err := rs.clear(ctx); err != nil {
+				return rs.res, err
+			}
+		}
+		for {
+			rec, err := next()
+			if errors.Is(err, io.EOF) {
+				return rs.res, nil
+			}
+			if err != nil {
+				return rs.res, err
+			}
+			if err := rs.record(ctx, rec); err != nil {
+				return rs.res, fmt.Errorf("%s: %w", rec.Kind, err)
+			}
+		}
+	})
+}
+
+// clear удаляет каталог. Атрибуты, значения и связи уходят каскадом,
+// переводы, скидки и внешние ID — триггерами.
+func (rs *restore) clear(ctx context.Context) error {
+	for _, q := range []string{
+		`DELETE FROM presets`,
+		`DELETE FROM products`,
+		`DELETE FROM services`,
+		`DELETE FROM coefficients`,
+		`DELETE FROM categories`,
+	} {
+		if _, err := rs.exec(ctx, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rs *restore) record(ctx context.Context, rec domSnapshot.Record) error {
+	switch d := rec.Data.(type) {
+	case *domSnapshot.Category:
+		return rs.category(ctx, d)
+	case *domSnapshot.Attribute:
+		return rs.attribute(ctx, d)
+	case *domSnapshot.Service:
+		return rs.insert(ctx, rec.Kind, d.ID,
+			`INSERT INTO services (name, description, price) VALUES ($1, $2, $3) RETURNING service_id`,
+			d.Name, d.Description, d.Price)
+	case *domSnapshot.Product:
+		catID, err := rs.ref(domSnapshot.KindCategory, d.CategoryID)
+		if err != nil {
+			return err
+		}
+		return rs.insert(ctx, rec.Kind, d.ID, `
+			INSERT INTO products (name, price, description, category_id, image_url, stock, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING product_id`,
+			d.Name, d.Price, d.Description, catID, d.ImageURL, d.Stock, d.CreatedAt)
+	case *domSnapshot.ProductAttribute:
+		return rs.link(ctx, rec.Kind,
+			`INSERT INTO product_attributes (product_id, attribute_id, value) VALUES ($1, $2, $3)`,
+			domSnapshot.KindProduct, d.ProductID, domSnapshot.KindAttribute, d.AttributeID, d.Value)
+	case *domSnapshot.ProductService:
+		return rs.link(ctx, rec.Kind,
+			`INSERT INTO product_services (product_id, service_id) VALUES ($1, $2)`,
+			domSnapshot.KindProduct, d.ProductID, domSnapshot.KindService, d.ServiceID)
+	case *domSnapshot.Preset:
+		return rs.insert(ctx, rec.Kind, d.ID, `
+			INSERT INTO presets (name, description, total_price, image_url, is_template, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING preset_id`,
+			d.Name, d.Description, d.TotalPrice, d.ImageURL, d.IsTemplate, d.CreatedAt)
+	case *domSnapshot.PresetItem:
+		return rs.link(ctx, rec.Kind,
+			`INSERT INTO preset_items (preset_id, product_id, quantity, quantity_formula, slot_name, optional) VALUES ($1, $2, $3, $4, $5, $6)`,
+			domSnapshot.KindPreset, d.PresetID, domSnapshot.KindProduct, d.ProductID, d.Quantity, d.QuantityFormula, d.SlotName, d.Optional)
+	case *domSnapshot.PresetAlternative:
+		return rs.presetAlternative(ctx, d)
+	case *domSnapshot.Coefficient:
+		return rs.coefficient(ctx, d)
+	}
+	return fmt.Errorf("%w: unexpected record %T", domSnapshot.ErrMalformed, rec.Data)
+}
+
+// category переиспользует категорию с тем же именем: имена уникальны.
+func (rs *restore) category(ctx context.Context, c *domSnapshot.Category) error {
+	if _, dup := rs.ids[domSnapshot.KindCategory][c.ID]; dup {
+		return fmt.Errorf("%w: duplicate id %d", domSnapshot.ErrMalformed, c.ID)
+	}
+	var parentID *int64
+	if c.ParentID != nil {
+		id, err := rs.ref(domSnapshot.KindCategory, *c.ParentID)
+		if err != nil {
+			return err
+		}
+		parentID = &id
+	}
+	const (
+		insert = `
+			INSERT INTO categories (name, parent_id) VALUES ($1, $2)
+			ON CONFLICT (name) DO NOTHING
+			RETURNING category_id`
+		existing = `SELECT category_id FROM categories WHERE name = $1`
+	)
+	var id int64
+	err := rs.get(ctx, &id, insert, c.Name, parentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := rs.getRow(ctx, &id, existing, c.Name); err != nil {
+			return err
+		}
+		rs.remember(domSnapshot.KindCategory, c.ID, id)
+		rs.res.Matched[domSnapshot.KindCategory]++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	rs.remember(domSnapshot.KindCategory, c.ID, id)
+	rs.res.Created[domSnapshot.KindCategory]++
+	return nil
+}
+
+// attribute переиспользует атрибут с тем же именем, если категория
+// совпала с уже существующей; у новой категории атрибутов ещё нет.
+func (rs *restore) attribute(ctx context.Context, a *domSnapshot.Attribute) error {
+	if _, dup := rs.ids[domSnapshot.KindAttribute][a.ID]; dup {
+		return fmt.Errorf("%w: duplicate id %d", domSnapshot.ErrMalformed, a.ID)
+	}
+	catID, err := rs.ref(domSnapshot.KindCategory, a.CategoryID)
+	if err != nil {
+		return err
+	}
+	var id int64
+	err = rs.get(ctx, &id,
+		`SELECT attribute_id FROM attributes WHERE category_id = $1 AND name = $2 ORDER BY attribute_id LIMIT 1`,
+		catID, a.Name)
+	if err == nil {
+		rs.remember(domSnapshot.KindAttribute, a.ID, id)
+		rs.res.Matched[domSnapshot.KindAttribute]++
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return rs.insert(ctx, domSnapshot.KindAttribute, a.ID,
+		`INSERT INTO attributes (name, unit, category_id) VALUES ($1, $2, $3) RETURNING attribute_id`,
+		a.Name, a.Unit, catID)
+}
+
+// coefficient обновляет значение коэффициента с тем же именем.
+func (rs *restore) coefficient(ctx context.Context, c *domSnapshot.Coefficient) error {
+	const q = `
+		INSERT INTO coefficients (name, value) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET value = EXCLUDED.value, version = coefficients.version + 1
+		RETURNING (xmax = 0) AS inserted`
+	var inserted bool
+	if err := rs.getRow(ctx, &inserted, q, c.Name, c.Value); err != nil {
+		return err
+	}
+	if inserted {
+		rs.res.Created[domSnapshot.KindCoefficient]++
+	} else {
+		rs.res.Matched[domSnapshot.KindCoefficient]++
+	}
+	return nil
+}
+
+// insert создаёт строку и запоминает её новый ID под ID из снимка.
+func (rs *restore) insert(ctx context.Context, kind domSnapshot.Kind, oldID int64, q string, args ...any) error {
+	if _, dup := rs.ids[kind][oldID]; dup {
+		return fmt.Errorf("%w: duplicate id %d", domSnapshot.ErrMalformed, oldID)
+	}
+	var id int64
+	if err := rs.getRow(ctx, &id, q, args...); err != nil {
+		return err
+	}
+	rs.remember(kind, oldID, id)
+	rs.res.Created[kind]++
+	return nil
+}
+
+// link создаёт связующую строку: первые два аргумента — ссылки на записи
+// типов aKind и bKind, остальные передаются как есть.
+func (rs *restore) link(ctx context.Context, kind domSnapshot.Kind, q string, aKind domSnapshot.Kind, a int64, bKind domSnapshot.Kind, b int64, rest ...any) error {
+	aID, err := rs.ref(aKind, a)
+	if err != nil {
+		return err
+	}
+	bID, err := rs.ref(bKind, b)
+	if err != nil {
+		return err
+	}
+	if _, err := rs.exec(ctx, q, append([]any{aID, bID}, rest...)...); err != nil {
+		return err
+	}
+	rs.res.Created[kind]++
+	return nil
+}
+
+// presetAlternative находит слот по пресету и товару по умолчанию: ID
+// позиций в снимок не попадают.
+func (rs *restore) presetAlternative(ctx context.Context, a *domSnapshot.PresetAlternative) error {
+	presetID, err := rs.ref(domSnapshot.KindPreset, a.PresetID)
+	if err != nil {
+		return err
+	}
+	productID, err := rs.ref(domSnapshot.KindProduct, a.ProductID)
+	if err != nil {
+		return err
+	}
+	altID, err := rs.ref(domSnapshot.KindProduct, a.AlternativeID)
+	if err != nil {
+		return err
+	}
+	res, err := rs.exec(ctx, `
+		INSERT INTO preset_item_alternatives (preset_item_id, product_id)
+		SELECT preset_item_id, $3 FROM preset_items WHERE preset_id = $1 AND product_id = $2`,
+		presetID, productID, altID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return repoError.MapPostgreSQLError(rs.r.log, err)
+	} else if n == 0 {
+		return fmt.Errorf("%w: %s %d/%d", domSnapshot.ErrDanglingReference, domSnapshot.KindPresetItem, a.PresetID, a.ProductID)
+	}
+	rs.res.Created[domSnapshot.KindPresetAlternative]++
+	return nil
+}
+
+func (rs *restore) ref(kind domSnapshot.Kind, oldID int64) (int64, error) {
+	id, ok := rs.ids[kind][oldID]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s %d", domSnapshot.ErrDanglingReference, kind, oldID)
+	}
+	return id, nil
+}
+
+func (rs *restore) remember(kind domSnapshot.Kind, oldID, id int64) {
+	m := rs.ids[kind]
+	if m == nil {
+		m = make(map[int64]int64)
+		rs.ids[kind] = m
+	}
+	m[oldID] = id
+}
+
+// get возвращает sql.ErrNoRows как есть: вызывающий решает, ошибка ли это.
+func (rs *restore) get(ctx context.Context, dest any, q string, args ...any) error {
+	err := rs.r.withQuery(ctx, q, func() error {
+		return rs.tx.GetContext(ctx, dest, q, args...)
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return repoError.MapPostgreSQLError(rs.r.log, err)
+	}
+	return err
+}
+
+// getRow — get, для которого отсутствие строки тоже ошибка.
+func (rs *restore) getRow(ctx context.Context, dest any, q string, args ...any) error {
+	err := rs.get(ctx, dest, q, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repoError.MapPostgreSQLError(rs.r.log, err)
+	}
+	return err
+}
+
+func (rs *restore) exec(ctx context.Context, q string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	err := rs.r.withQuery(ctx, q, func() error {
+		var err error
+		res, err = rs.tx.ExecContext(ctx, q, args...)
+		return err
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(rs.r.log, err)
+	}
+	return res, nil
+}
+
+func (r *PGSnapshotRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
@@ -0,0 +1,166 @@
+package snapshot_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	snapshotRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/snapshot"
+)
+
+type PGSnapshotRepositorySuite struct {
+	suite.Suite
+	repo *snapshotRepo.PGSnapshotRepository
+	ctx  context.Context
+	srv  *testsuite.TestServer
+	db   *sqlx.DB
+}
+
+func (s *PGSnapshotRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	s.repo = snapshotRepo.NewPGSnapshotRepository(s.db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (s *PGSnapshotRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+// records — небольшой снимок с ID, заведомо не совпадающими с ID базы;
+// имена с префиксом, чтобы тесты не пересекались.
+func records(p string) []domSnapshot.Record {
+	parent := int64(100)
+	formula := "area * 1.1"
+	slot := "Плитка"
+	price := decimal.RequireFromString("990")
+	created := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	return []domSnapshot.Record{
+		{Kind: domSnapshot.KindCategory, Data: &domSnapshot.Category{ID: 100, Name: p + " Плитка"}},
+		{Kind: domSnapshot.KindCategory, Data: &domSnapshot.Category{ID: 101, Name: p + " Настенная", ParentID: &parent}},
+		{Kind: domSnapshot.KindAttribute, Data: &domSnapshot.Attribute{ID: 200, Name: "Цвет", CategoryID: 101}},
+		{Kind: domSnapshot.KindService, Data: &domSnapshot.Service{ID: 300, Name: p + " Укладка", Price: decimal.RequireFromString("450")}},
+		{Kind: domSnapshot.KindProduct, Data: &domSnapshot.Product{ID: 400, Name: p + " Плитка белая", Price: &price, CategoryID: 101, CreatedAt: created}},
+		{Kind: domSnapshot.KindProduct, Data: &domSnapshot.Product{ID: 401, Name: p + " Плитка серая", Price: &price, CategoryID: 101, CreatedAt: created}},
+		{Kind: domSnapshot.KindProductAttribute, Data: &domSnapshot.ProductAttribute{ProductID: 400, AttributeID: 200, Value: "Белый"}},
+		{Kind: domSnapshot.KindProductService, Data: &domSnapshot.ProductService{ProductID: 400, ServiceID: 300}},
+		{Kind: domSnapshot.KindPreset, Data: &domSnapshot.Preset{ID: 500, Name: p + " Ванная", TotalPrice: price, CreatedAt: created}},
+		{Kind: domSnapshot.KindPresetItem, Data: &domSnapshot.PresetItem{PresetID: 500, ProductID: 400, Quantity: decimal.NewFromInt(1), QuantityFormula: &formula, SlotName: &slot}},
+		{Kind: domSnapshot.KindPresetAlternative, Data: &domSnapshot.PresetAlternative{PresetID: 500, ProductID: 400, AlternativeID: 401}},
+		{Kind: domSnapshot.KindCoefficient, Data: &domSnapshot.Coefficient{ID: 600, Name: p + " Запас", Value: decimal.RequireFromString("1.1")}},
+	}
+}
+
+func feed(recs []domSnapshot.Record) func() (domSnapshot.Record, error) {
+	i := 0
+	return func() (domSnapshot.Record, error) {
+		if i == len(recs) {
+			return domSnapshot.Record{}, io.EOF
+		}
+		i++
+		return recs[i-1], nil
+	}
+}
+
+func (s *PGSnapshotRepositorySuite) export() []domSnapshot.Record {
+	var out []domSnapshot.Record
+	require.NoError(s.T(), s.repo.Export(s.ctx, func(rec domSnapshot.Record) error {
+		out = append(out, rec)
+		return nil
+	}))
+	return out
+}
+
+func (s *PGSnapshotRepositorySuite) Test_AppendRemapsReferences() {
+	p := fmt.Sprintf("t%d", time.Now().UnixNano())
+
+	res, err := s.repo.Restore(s.ctx, domSnapshot.ModeAppend, feed(records(p)))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 2, res.Created[domSnapshot.KindCategory])
+	require.Equal(s.T(), 1, res.Created[domSnapshot.KindPresetItem])
+	require.Equal(s.T(), 1, res.Created[domSnapshot.KindPresetAlternative])
+
+	var row struct {
+		Parent  string `db:"parent"`
+		Value   string `db:"value"`
+		Service string `db:"service"`
+	}
+	require.NoError(s.T(), s.db.Get(&row, `
+		SELECT pc.name AS parent, pa.value, sv.name AS service
+		FROM products pr
+		JOIN categories c ON c.category_id = pr.category_id
+		JOIN categories pc ON pc.category_id = c.parent_id
+		JOIN product_attributes pa ON pa.product_id = pr.product_id
+		JOIN product_services ps ON ps.product_id = pr.product_id
+		JOIN services sv ON sv.service_id = ps.service_id
+		WHERE pr.name = $1`, p+" Плитка белая"))
+	require.Equal(s.T(), p+" Плитка", row.Parent)
+	require.Equal(s.T(), "Белый", row.Value)
+	require.Equal(s.T(), p+" Укладка", row.Service)
+
+	// повторная заливка переиспользует категории, атрибуты и коэффициенты
+	res, err = s.repo.Restore(s.ctx, domSnapshot.ModeAppend, feed(records(p)))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domSnapshot.Counts{
+		domSnapshot.KindCategory: 2, domSnapshot.KindAttribute: 1, domSnapshot.KindCoefficient: 1,
+	}, res.Matched)
+	require.Zero(s.T(), res.Created[domSnapshot.KindCategory])
+	require.Equal(s.T(), 2, res.Created[domSnapshot.KindProduct])
+}
+
+func (s *PGSnapshotRepositorySuite) Test_DanglingReferenceRollsBack() {
+	p := fmt.Sprintf("t%d", time.Now().UnixNano())
+	recs := records(p)
+	recs = append(recs, domSnapshot.Record{Kind: domSnapshot.KindPresetItem,
+		Data: &domSnapshot.PresetItem{PresetID: 500, ProductID: 999, Quantity: decimal.NewFromInt(1)}})
+
+	_, err := s.repo.Restore(s.ctx, domSnapshot.ModeAppend, feed(recs))
+	require.ErrorIs(s.T(), err, domSnapshot.ErrDanglingReference)
+
+	var n int
+	require.NoError(s.T(), s.db.Get(&n, `SELECT count(*) FROM categories WHERE name LIKE $1`, p+"%"))
+	require.Zero(s.T(), n)
+}
+
+func (s *PGSnapshotRepositorySuite) Test_ReplaceRestoresExport() {
+	p := fmt.Sprintf("t%d", time.Now().UnixNano())
+	_, err := s.repo.Restore(s.ctx, domSnapshot.ModeAppend, feed(records(p)))
+	require.NoError(s.T(), err)
+
+	before := s.export()
+	res, err := s.repo.Restore(s.ctx, domSnapshot.ModeReplace, feed(before))
+	require.NoError(s.T(), err)
+
+	counts := domSnapshot.Counts{}
+	for _, rec := range before {
+		counts[rec.Kind]++
+	}
+	for _, kind := range domSnapshot.Kinds {
+		require.Equal(s.T(), counts[kind], res.Created[kind], kind)
+	}
+	require.Len(s.T(), s.export(), len(before))
+}
+
+func TestPGSnapshotRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGSnapshotRepositorySuite))
+}
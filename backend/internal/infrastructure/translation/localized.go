@@ -0,0 +1,252 @@
+package translation
+
+import (
+	"context"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/category"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/preset"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/product"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/service"
+)
+
+// Обёртки над репозиториями каталога: чтения возвращают контент на локали
+// и цены в валюте и со скидками из контекста, запись идёт в основные колонки без изменений.
+
+type LocalizedProductRepository struct {
+	*product.PGProductRepository
+	l *Localizer
+}
+
+func NewLocalizedProductRepository(repo *product.PGProductRepository, l *Localizer) *LocalizedProductRepository {
+	return &LocalizedProductRepository{PGProductRepository: repo, l: l}
+}
+
+func (r *LocalizedProductRepository) Get(ctx context.Context, id int64) (*prodDom.Product, error) {
+	p, err := r.PGProductRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	b.product(p)
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *LocalizedProductRepository) ListByCategory(ctx context.Context, catID int64, sort prodDom.SortOrder) ([]prodDom.Product, error) {
+	ps, err := r.PGProductRepository.ListByCategory(ctx, catID, sort)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range ps {
+		b.product(&ps[i])
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+func (r *LocalizedProductRepository) ListRelations(ctx context.Context, productID int64) ([]prodDom.ProductRelation, error) {
+	rels, err := r.PGProductRepository.ListRelations(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range rels {
+		b.summary(rels[i].Related)
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return rels, nil
+}
+
+func (r *LocalizedProductRepository) ListBoughtTogether(ctx context.Context, productID int64, limit int) ([]prodDom.ProductSummary, error) {
+	items, err := r.PGProductRepository.ListBoughtTogether(ctx, productID, limit)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range items {
+		b.summary(&items[i])
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+type LocalizedCategoryRepository struct {
+	*category.PGCategoryRepository
+	l *Localizer
+}
+
+func NewLocalizedCategoryRepository(repo *category.PGCategoryRepository, l *Localizer) *LocalizedCategoryRepository {
+	return &LocalizedCategoryRepository{PGCategoryRepository: repo, l: l}
+}
+
+func (r *LocalizedCategoryRepository) GetByID(ctx context.Context, id int64) (*catDom.Category, error) {
+	c, err := r.PGCategoryRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	b.category(c)
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *LocalizedCategoryRepository) List(ctx context.Context) ([]catDom.Category, error) {
+	cats, err := r.PGCategoryRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range cats {
+		b.category(&cats[i])
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return cats, nil
+}
+
+type LocalizedAttributeRepository struct {
+	*attribute.PGAttributeRepository
+	l *Localizer
+}
+
+func NewLocalizedAttributeRepository(repo *attribute.PGAttributeRepository, l *Localizer) *LocalizedAttributeRepository {
+	return &LocalizedAttributeRepository{PGAttributeRepository: repo, l: l}
+}
+
+func (r *LocalizedAttributeRepository) GetByID(ctx context.Context, id int64) (*attrDom.Attribute, error) {
+	a, err := r.PGAttributeRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	b.attribute(a)
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *LocalizedAttributeRepository) FindByCategory(ctx context.Context, categoryID int64) ([]attrDom.Attribute, error) {
+	attrs, err := r.PGAttributeRepository.FindByCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range attrs {
+		b.attribute(&attrs[i])
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+type LocalizedPresetRepository struct {
+	*preset.PGPresetRepository
+	l *Localizer
+}
+
+func NewLocalizedPresetRepository(repo *preset.PGPresetRepository, l *Localizer) *LocalizedPresetRepository {
+	return &LocalizedPresetRepository{PGPresetRepository: repo, l: l}
+}
+
+func (r *LocalizedPresetRepository) Get(ctx context.Context, id int64) (*presetDom.Preset, error) {
+	p, err := r.PGPresetRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	b.preset(p)
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *LocalizedPresetRepository) ListDetailed(ctx context.Context) ([]presetDom.Preset, error) {
+	return r.localizeList(ctx, r.PGPresetRepository.ListDetailed)
+}
+
+func (r *LocalizedPresetRepository) ListShort(ctx context.Context) ([]presetDom.Preset, error) {
+	return r.localizeList(ctx, r.PGPresetRepository.ListShort)
+}
+
+func (r *LocalizedPresetRepository) localizeList(ctx context.Context, list func(context.Context) ([]presetDom.Preset, error)) ([]presetDom.Preset, error) {
+	ps, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range ps {
+		b.preset(&ps[i])
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+type LocalizedServiceRepository struct {
+	*service.PGServiceRepository
+	l *Localizer
+}
+
+func NewLocalizedServiceRepository(repo *service.PGServiceRepository, l *Localizer) *LocalizedServiceRepository {
+	return &LocalizedServiceRepository{PGServiceRepository: repo, l: l}
+}
+
+func (r *LocalizedServiceRepository) Get(ctx context.Context, id int64) (*serviceDom.Service, error) {
+	s, err := r.PGServiceRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	b.service(s)
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *LocalizedServiceRepository) List(ctx context.Context) ([]serviceDom.Service, error) {
+	return r.localizeList(ctx, r.PGServiceRepository.List)
+}
+
+func (r *LocalizedServiceRepository) GetServicesByProduct(ctx context.Context, productID int64) ([]serviceDom.Service, error) {
+	return r.localizeList(ctx, func(ctx context.Context) ([]serviceDom.Service, error) {
+		return r.PGServiceRepository.GetServicesByProduct(ctx, productID)
+	})
+}
+
+func (r *LocalizedServiceRepository) localizeList(ctx context.Context, list func(context.Context) ([]serviceDom.Service, error)) ([]serviceDom.Service, error) {
+	ss, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b := newBatch()
+	for i := range ss {
+		b.service(&ss[i])
+	}
+	if err := r.l.apply(ctx, b); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
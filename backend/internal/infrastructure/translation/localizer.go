@@ -0,0 +1,172 @@
+package translation
+
+import (
+	"context"
+	"log/slog"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	discountDom "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/currency"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/discount"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// Localizer подставляет переводы в загруженные сущности по локали из контекста
+// (i18n.WithLocale). На языке по умолчанию ничего не делает; не найденные
+// переводы оставляют значения по умолчанию. Ошибка загрузки переводов не
+// ломает чтение каталога: она логируется, и отдаётся контент по умолчанию.
+// Цены тем же проходом пересчитываются в валюту запроса (currency.Converter),
+// после чего к ним применяются скидки (discount.Pricer).
+type Localizer struct {
+	repo      *PGTranslationRepository
+	prices    *currency.Converter
+	discounts *discount.Pricer
+	log       *slog.Logger
+}
+
+func NewLocalizer(repo *PGTranslationRepository, prices *currency.Converter, discounts *discount.Pricer, log *slog.Logger) *Localizer {
+	if repo == nil {
+		panic("NewLocalizer: repo is nil")
+	}
+	if prices == nil {
+		panic("NewLocalizer: prices is nil")
+	}
+	if discounts == nil {
+		panic("NewLocalizer: discounts is nil")
+	}
+	return &Localizer{repo: repo, prices: prices, discounts: discounts, log: log}
+}
+
+type target struct {
+	field string
+	str   *string
+	ptr   **string
+}
+
+// batch собирает поля, которые нужно перевести, чтобы загрузить
+// переводы одним запросом на тип сущности.
+type batch struct {
+	targets map[domTr.Entity]map[int64][]target
+	prices  []currency.PriceTarget
+	sales   []discount.PriceTarget
+}
+
+func newBatch() *batch {
+	return &batch{targets: map[domTr.Entity]map[int64][]target{}}
+}
+
+func (b *batch) add(entity domTr.Entity, id int64, t target) {
+	if b.targets[entity] == nil {
+		b.targets[entity] = map[int64][]target{}
+	}
+	b.targets[entity][id] = append(b.targets[entity][id], t)
+}
+
+func (b *batch) str(entity domTr.Entity, id int64, field string, s *string) {
+	b.add(entity, id, target{field: field, str: s})
+}
+
+func (b *batch) ptr(entity domTr.Entity, id int64, field string, p **string) {
+	b.add(entity, id, target{field: field, ptr: p})
+}
+
+func (b *batch) price(entity money.Entity, id int64, p *money.Money, sale **discountDom.Sale) {
+	b.prices = append(b.prices, currency.PriceTarget{Entity: entity, ID: id, Price: p})
+	b.sales = append(b.sales, discount.PriceTarget{Entity: entity, ID: id, Price: p, Sale: sale})
+}
+
+func (l *Localizer) apply(ctx context.Context, b *batch) error {
+	l.translate(ctx, b)
+	if err := l.prices.Convert(ctx, b.prices); err != nil {
+		return err
+	}
+	return l.discounts.Apply(ctx, b.sales)
+}
+
+func (l *Localizer) translate(ctx context.Context, b *batch) {
+	loc := i18n.FromContext(ctx)
+	if loc == i18n.Default {
+		return
+	}
+	for entity, byID := range b.targets {
+		ids := make([]int64, 0, len(byID))
+		for id := range byID {
+			ids = append(ids, id)
+		}
+		found, err := l.repo.Lookup(ctx, entity, ids, loc)
+		if err != nil {
+			l.log.Warn("load translations", slog.String("entity", string(entity)),
+				slog.String("locale", string(loc)), slog.Any("error", err))
+			continue
+		}
+		for id, targets := range byID {
+			for _, t := range targets {
+				v, ok := found[id][t.field]
+				if !ok {
+					continue
+				}
+				if t.str != nil {
+					*t.str = v
+				} else {
+					*t.ptr = &v
+				}
+			}
+		}
+	}
+}
+
+func (b *batch) product(p *prodDom.Product) {
+	b.str(domTr.EntityProduct, p.ID, domTr.FieldName, &p.Name)
+	b.ptr(domTr.EntityProduct, p.ID, domTr.FieldDescription, &p.Description)
+	b.price(money.EntityProduct, p.ID, &p.Price, &p.Sale)
+	for i := range p.Attributes {
+		b.attribute(&p.Attributes[i].Attribute)
+	}
+	for i := range p.Services {
+		b.service(&p.Services[i])
+	}
+	for i := range p.Relations {
+		b.summary(p.Relations[i].Related)
+	}
+}
+
+func (b *batch) summary(ps *prodDom.ProductSummary) {
+	if ps == nil {
+		return
+	}
+	b.str(domTr.EntityProduct, ps.ID, domTr.FieldName, &ps.Name)
+	b.price(money.EntityProduct, ps.ID, &ps.Price, &ps.Sale)
+}
+
+func (b *batch) category(c *catDom.Category) {
+	b.str(domTr.EntityCategory, c.ID, domTr.FieldName, &c.Name)
+}
+
+func (b *batch) attribute(a *attrDom.Attribute) {
+	b.str(domTr.EntityAttribute, a.ID, domTr.FieldName, &a.Name)
+	b.ptr(domTr.EntityAttribute, a.ID, domTr.FieldUnit, &a.Unit)
+}
+
+func (b *batch) preset(p *presetDom.Preset) {
+	b.str(domTr.EntityPreset, p.ID, domTr.FieldName, &p.Name)
+	b.ptr(domTr.EntityPreset, p.ID, domTr.FieldDescription, &p.Description)
+	b.price(money.EntityPreset, p.ID, &p.TotalPrice, &p.Sale)
+	for i := range p.Items {
+		b.summary(p.Items[i].Product)
+		for j := range p.Items[i].Alternatives {
+			b.summary(p.Items[i].Alternatives[j].Product)
+		}
+	}
+}
+
+func (b *batch) service(s *serviceDom.Service) {
+	b.str(domTr.EntityService, s.ID, domTr.FieldName, &s.Name)
+	b.ptr(domTr.EntityService, s.ID, domTr.FieldDescription, &s.Description)
+	b.price(money.EntityService, s.ID, &s.Price, &s.Sale)
+}
@@ -0,0 +1,38 @@
+package translation
+
+import (
+	"time"
+
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+type translationDB struct {
+	EntityID  int64     `db:"entity_id"`
+	Locale    string    `db:"locale"`
+	Field     string    `db:"field"`
+	Value     string    `db:"value"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// rawTranslationListToDomain собирает строки (по одной на поле) в переводы по локалям.
+// Строки должны быть отсортированы по локали.
+func rawTranslationListToDomain(entity domTr.Entity, raws []translationDB) []domTr.Translation {
+	var res []domTr.Translation
+	for _, raw := range raws {
+		if n := len(res); n == 0 || string(res[n-1].Locale) != raw.Locale {
+			res = append(res, domTr.Translation{
+				Entity:   entity,
+				EntityID: raw.EntityID,
+				Locale:   i18n.Locale(raw.Locale),
+				Fields:   map[string]string{},
+			})
+		}
+		t := &res[len(res)-1]
+		t.Fields[raw.Field] = raw.Value
+		if raw.UpdatedAt.After(t.UpdatedAt) {
+			t.UpdatedAt = raw.UpdatedAt
+		}
+	}
+	return res
+}
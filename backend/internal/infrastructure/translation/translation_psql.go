@@ -0,0 +1,171 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database/tx"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// entityTables — таблица и первичный ключ каждой переводимой сущности.
+var entityTables = map[domTr.Entity][2]string{
+	domTr.EntityProduct:   {"products", "product_id"},
+	domTr.EntityCategory:  {"categories", "category_id"},
+	domTr.EntityAttribute: {"attributes", "attribute_id"},
+	domTr.EntityPreset:    {"presets", "preset_id"},
+	domTr.EntityService:   {"services", "service_id"},
+}
+
+type PGTranslationRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGTranslationRepository(db *sqlx.DB, log *slog.Logger) *PGTranslationRepository {
+	if db == nil {
+		panic("NewPGTranslationRepository: db is nil")
+	}
+	return &PGTranslationRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// List возвращает переводы сущности на все локали.
+func (r *PGTranslationRepository) List(ctx context.Context, entity domTr.Entity, id int64) ([]domTr.Translation, error) {
+	if err := r.ensureEntityExists(ctx, r.db, entity, id); err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	const q = `
+		SELECT entity_id, locale, field, value, updated_at
+		FROM translations
+		WHERE entity = $1 AND entity_id = $2
+		ORDER BY locale, field
+	`
+	var raws []translationDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(entity), id)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawTranslationListToDomain(entity, raws), nil
+}
+
+// Put заменяет перевод сущности на локали t.Locale целиком:
+// поля, которых нет в t.Fields, удаляются.
+func (r *PGTranslationRepository) Put(ctx context.Context, t *domTr.Translation) (*domTr.Translation, error) {
+	const (
+		qDelete = `DELETE FROM translations WHERE entity = $1 AND entity_id = $2 AND locale = $3`
+		qInsert = `
+			INSERT INTO translations (entity, entity_id, locale, field, value, updated_at)
+			SELECT $1, $2, $3, f, v, $6
+			FROM unnest($4::text[], $5::text[]) AS u(f, v)
+		`
+	)
+	names := make([]string, 0, len(t.Fields))
+	values := make([]string, 0, len(t.Fields))
+	for name, value := range t.Fields {
+		names = append(names, name)
+		values = append(values, value)
+	}
+	now := time.Now().UTC()
+
+	err := tx.RunInTxAction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.ensureEntityExists(ctx, tx, t.Entity, t.EntityID); err != nil {
+			return err
+		}
+		if err := r.withQuery(ctx, qDelete, func() error {
+			_, err := tx.ExecContext(ctx, qDelete, string(t.Entity), t.EntityID, string(t.Locale))
+			return err
+		}); err != nil {
+			return err
+		}
+		return r.withQuery(ctx, qInsert, func() error {
+			_, err := tx.ExecContext(ctx, qInsert, string(t.Entity), t.EntityID, string(t.Locale),
+				pq.Array(names), pq.Array(values), now)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	t.UpdatedAt = now
+	return t, nil
+}
+
+// Delete удаляет перевод сущности на локаль.
+func (r *PGTranslationRepository) Delete(ctx context.Context, entity domTr.Entity, id int64, locale i18n.Locale) error {
+	const q = `DELETE FROM translations WHERE entity = $1 AND entity_id = $2 AND locale = $3`
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, string(entity), id, string(locale))
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+// Lookup возвращает переведённые поля сущностей ids на локали: entity_id → поле → значение.
+func (r *PGTranslationRepository) Lookup(ctx context.Context, entity domTr.Entity, ids []int64, locale i18n.Locale) (map[int64]map[string]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	const q = `
+		SELECT entity_id, locale, field, value, updated_at
+		FROM translations
+		WHERE entity = $1 AND locale = $2 AND entity_id = ANY($3)
+	`
+	var raws []translationDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, string(entity), string(locale), pq.Array(ids))
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	res := make(map[int64]map[string]string, len(raws))
+	for _, raw := range raws {
+		if res[raw.EntityID] == nil {
+			res[raw.EntityID] = map[string]string{}
+		}
+		res[raw.EntityID][raw.Field] = raw.Value
+	}
+	return res, nil
+}
+
+func (r *PGTranslationRepository) ensureEntityExists(ctx context.Context, q sqlx.QueryerContext, entity domTr.Entity, id int64) error {
+	tbl, ok := entityTables[entity]
+	if !ok {
+		return app_error.ErrBadRequest
+	}
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1)`, tbl[0], tbl[1])
+	var exists bool
+	err := r.withQuery(ctx, query, func() error {
+		return sqlx.GetContext(ctx, q, &exists, query, id)
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return app_error.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGTranslationRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
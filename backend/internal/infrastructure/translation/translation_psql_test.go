@@ -0,0 +1,118 @@
+package translation_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	catRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/category"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/currency"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/discount"
+	trRepo "github.com/Neimess/zorkin-store-project/internal/infrastructure/translation"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+type PGTranslationRepositorySuite struct {
+	suite.Suite
+	repo       *trRepo.PGTranslationRepository
+	categories *trRepo.LocalizedCategoryRepository
+	ctx        context.Context
+	srv        *testsuite.TestServer
+	db         *sqlx.DB
+}
+
+func (s *PGTranslationRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s.repo = trRepo.NewPGTranslationRepository(s.db, logger)
+
+	deps, err := catRepo.NewDeps(s.db, logger)
+	require.NoError(s.T(), err)
+	prices := currency.NewConverter(currency.NewPGCurrencyRepository(s.db, logger), money.RoundingRules{})
+	s.categories = trRepo.NewLocalizedCategoryRepository(
+		catRepo.NewPGCategoryRepository(deps),
+		trRepo.NewLocalizer(s.repo, prices, discount.NewPricer(discount.NewPGDiscountRepository(s.db, logger), prices), logger))
+}
+
+func (s *PGTranslationRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGTranslationRepositorySuite) Test_PutListAndOverlay() {
+	cat, err := s.categories.Create(s.ctx, &catDom.Category{Name: "Плитка"})
+	require.NoError(s.T(), err)
+
+	_, err = s.repo.Put(s.ctx, &domTr.Translation{
+		Entity: domTr.EntityCategory, EntityID: cat.ID, Locale: i18n.EN,
+		Fields: map[string]string{domTr.FieldName: "Tiles"},
+	})
+	require.NoError(s.T(), err)
+
+	list, err := s.repo.List(s.ctx, domTr.EntityCategory, cat.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list, 1)
+	require.Equal(s.T(), "Tiles", list[0].Fields[domTr.FieldName])
+
+	got, err := s.categories.GetByID(i18n.WithLocale(s.ctx, i18n.EN), cat.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Tiles", got.Name)
+
+	// нет перевода на kz — остаётся исходное название
+	got, err = s.categories.GetByID(i18n.WithLocale(s.ctx, i18n.KZ), cat.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Плитка", got.Name)
+
+	require.NoError(s.T(), s.repo.Delete(s.ctx, domTr.EntityCategory, cat.ID, i18n.EN))
+	require.ErrorIs(s.T(), s.repo.Delete(s.ctx, domTr.EntityCategory, cat.ID, i18n.EN), app_error.ErrNotFound)
+}
+
+func (s *PGTranslationRepositorySuite) Test_PutForMissingEntity() {
+	_, err := s.repo.Put(s.ctx, &domTr.Translation{
+		Entity: domTr.EntityProduct, EntityID: 999999, Locale: i18n.EN,
+		Fields: map[string]string{domTr.FieldName: "Ghost"},
+	})
+	require.ErrorIs(s.T(), err, app_error.ErrNotFound)
+}
+
+func (s *PGTranslationRepositorySuite) Test_DeletedEntityDropsTranslations() {
+	cat, err := s.categories.Create(s.ctx, &catDom.Category{Name: "Обои"})
+	require.NoError(s.T(), err)
+	_, err = s.repo.Put(s.ctx, &domTr.Translation{
+		Entity: domTr.EntityCategory, EntityID: cat.ID, Locale: i18n.KZ,
+		Fields: map[string]string{domTr.FieldName: "Тұсқағаздар"},
+	})
+	require.NoError(s.T(), err)
+
+	require.NoError(s.T(), s.categories.Delete(s.ctx, cat.ID))
+
+	var cnt int
+	require.NoError(s.T(), s.db.Get(&cnt,
+		`SELECT count(*) FROM translations WHERE entity = 'category' AND entity_id = $1`, cat.ID))
+	require.Zero(s.T(), cnt)
+}
+
+func TestPGTranslationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGTranslationRepositorySuite))
+}
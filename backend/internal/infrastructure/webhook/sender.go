@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	userAgent = "zorkin-store-webhooks/1.0"
+	// maxErrorBody — сколько байт ответа подписчика сохраняется в журнал.
+	maxErrorBody = 512
+)
+
+// envelope — тело запроса к подписчику.
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// HTTPSender отправляет события POST-запросом с подписью HMAC-SHA256.
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender создаёт отправителя; при client == nil используется
+// http.Client с таймаутом 10 секунд.
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPSender{client: client, now: time.Now}
+}
+
+// Send доставляет событие и возвращает код ответа. Успехом считается любой 2xx,
+// иначе возвращается ошибка с началом тела ответа. Код 0 — ответа не было.
+func (s *HTTPSender) Send(ctx context.Context, job domWebhook.Job) (int, error) {
+	body, err := json.Marshal(envelope{
+		ID:        job.Event.ID,
+		Type:      string(job.Event.Type),
+		CreatedAt: job.Event.CreatedAt.UTC(),
+		Data:      job.Event.Data,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("webhook: marshal event %d: %w", job.Event.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", err)
+	}
+	ts := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, string(job.Event.Type))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(job.Delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, "sha256="+domWebhook.Sign(job.Secret, ts, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return resp.StatusCode, fmt.Errorf("webhook: status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+}
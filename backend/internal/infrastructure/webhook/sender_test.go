@@ -0,0 +1,86 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/webhook"
+)
+
+func testJob(url string) domWebhook.Job {
+	return domWebhook.Job{
+		Delivery: domWebhook.Delivery{ID: 17, SubscriptionID: 3, EventID: 42},
+		URL:      url,
+		Secret:   "0123456789abcdef",
+		Event: domWebhook.Event{
+			ID:        42,
+			Type:      domWebhook.EventPriceUpdated,
+			Data:      json.RawMessage(`{"entity":"product","entity_id":5,"currency":"RUB","price":"990"}`),
+			CreatedAt: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestHTTPSender_SignsPayload(t *testing.T) {
+	var (
+		header http.Header
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	code, err := webhook.NewHTTPSender(srv.Client()).Send(context.Background(), testJob(srv.URL))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, code)
+
+	require.Equal(t, "price.updated", header.Get(webhook.HeaderEvent))
+	require.Equal(t, "17", header.Get(webhook.HeaderDelivery))
+	ts, err := strconv.ParseInt(header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, "sha256="+domWebhook.Sign("0123456789abcdef", ts, body), header.Get(webhook.HeaderSignature))
+
+	var got struct {
+		ID        int64           `json:"id"`
+		Type      string          `json:"type"`
+		CreatedAt time.Time       `json:"created_at"`
+		Data      json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &got))
+	require.Equal(t, int64(42), got.ID)
+	require.Equal(t, "price.updated", got.Type)
+	require.JSONEq(t, `{"entity":"product","entity_id":5,"currency":"RUB","price":"990"}`, string(got.Data))
+}
+
+func TestHTTPSender_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "signature mismatch", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	code, err := webhook.NewHTTPSender(srv.Client()).Send(context.Background(), testJob(srv.URL))
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.ErrorContains(t, err, "signature mismatch")
+}
+
+func TestHTTPSender_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	code, err := webhook.NewHTTPSender(nil).Send(context.Background(), testJob(url))
+	require.Zero(t, code)
+	require.Error(t, err)
+}
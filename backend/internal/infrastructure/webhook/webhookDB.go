@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+)
+
+type subscriptionDB struct {
+	ID        int64          `db:"subscription_id"`
+	URL       string         `db:"url"`
+	Secret    string         `db:"secret"`
+	Events    pq.StringArray `db:"events"`
+	Active    bool           `db:"active"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+func (s subscriptionDB) toDomain() domWebhook.Subscription {
+	events := make([]domWebhook.EventType, len(s.Events))
+	for i, e := range s.Events {
+		events[i] = domWebhook.EventType(e)
+	}
+	return domWebhook.Subscription{
+		ID:        s.ID,
+		URL:       s.URL,
+		Secret:    s.Secret,
+		Events:    events,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func rawSubscriptionListToDomain(raws []subscriptionDB) []domWebhook.Subscription {
+	res := make([]domWebhook.Subscription, len(raws))
+	for i, raw := range raws {
+		res[i] = raw.toDomain()
+	}
+	return res
+}
+
+func eventsToDB(events []domWebhook.EventType) pq.StringArray {
+	res := make(pq.StringArray, len(events))
+	for i, e := range events {
+		res[i] = string(e)
+	}
+	return res
+}
+
+type deliveryDB struct {
+	ID             int64          `db:"delivery_id"`
+	SubscriptionID int64          `db:"subscription_id"`
+	EventID        int64          `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	LastStatusCode sql.NullInt32  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func (d deliveryDB) toDomain() domWebhook.Delivery {
+	res := domWebhook.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      domWebhook.EventType(d.EventType),
+		Status:         domWebhook.DeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	if d.LastStatusCode.Valid {
+		code := int(d.LastStatusCode.Int32)
+		res.LastStatusCode = &code
+	}
+	if d.LastError.Valid {
+		res.LastError = &d.LastError.String
+	}
+	if d.DeliveredAt.Valid {
+		res.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return res
+}
+
+func rawDeliveryListToDomain(raws []deliveryDB) []domWebhook.Delivery {
+	res := make([]domWebhook.Delivery, len(raws))
+	for i, raw := range raws {
+		res[i] = raw.toDomain()
+	}
+	return res
+}
+
+// jobDB — доставка, взятая в работу, с адресом подписки и телом события.
+type jobDB struct {
+	deliveryDB
+	URL            string          `db:"url"`
+	Secret         string          `db:"secret"`
+	Payload        json.RawMessage `db:"payload"`
+	EventCreatedAt time.Time       `db:"event_created_at"`
+}
+
+func (j jobDB) toDomain() domWebhook.Job {
+	d := j.deliveryDB.toDomain()
+	return domWebhook.Job{
+		Delivery: d,
+		URL:      j.URL,
+		Secret:   j.Secret,
+		Event: domWebhook.Event{
+			ID:        d.EventID,
+			Type:      d.EventType,
+			Data:      j.Payload,
+			CreatedAt: j.EventCreatedAt,
+		},
+	}
+}
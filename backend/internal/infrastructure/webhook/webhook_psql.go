@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	repoError "github.com/Neimess/zorkin-store-project/internal/infrastructure/error"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+const selectSubscription = `
+	SELECT subscription_id, url, secret, events, active, created_at, updated_at
+	FROM webhook_subscriptions
+`
+
+const deliveryColumns = `
+	d.delivery_id, d.subscription_id, d.event_id, e.event_type, d.status, d.attempts,
+	d.next_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at, d.updated_at
+`
+
+type PGWebhookRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewPGWebhookRepository(db *sqlx.DB, log *slog.Logger) *PGWebhookRepository {
+	if db == nil {
+		panic("NewPGWebhookRepository: db is nil")
+	}
+	return &PGWebhookRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *PGWebhookRepository) ListSubscriptions(ctx context.Context) ([]domWebhook.Subscription, error) {
+	const q = selectSubscription + `ORDER BY subscription_id`
+	var raws []subscriptionDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawSubscriptionListToDomain(raws), nil
+}
+
+func (r *PGWebhookRepository) GetSubscription(ctx context.Context, id int64) (*domWebhook.Subscription, error) {
+	const q = selectSubscription + `WHERE subscription_id = $1`
+	var raw subscriptionDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.GetContext(ctx, &raw, q, id)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	s := raw.toDomain()
+	return &s, nil
+}
+
+func (r *PGWebhookRepository) CreateSubscription(ctx context.Context, s *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+	const q = `
+		INSERT INTO webhook_subscriptions (url, secret, events, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING subscription_id, created_at, updated_at
+	`
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q, s.URL, s.Secret, eventsToDB(s.Events), s.Active).
+			Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return s, nil
+}
+
+// UpdateSubscription перезаписывает подписку целиком; ErrNotFound — подписки нет.
+// Новые события доставляются уже по новому адресу и секрету, в том числе повторные попытки.
+func (r *PGWebhookRepository) UpdateSubscription(ctx context.Context, s *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+	const q = `
+		UPDATE webhook_subscriptions
+		SET url = $2, secret = $3, events = $4, active = $5, updated_at = $6
+		WHERE subscription_id = $1
+		RETURNING created_at, updated_at
+	`
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.QueryRowContext(ctx, q,
+			s.ID, s.URL, s.Secret, eventsToDB(s.Events), s.Active, time.Now().UTC(),
+		).Scan(&s.CreatedAt, &s.UpdatedAt)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return s, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с журналом её доставок.
+func (r *PGWebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	const q = `DELETE FROM webhook_subscriptions WHERE subscription_id = $1`
+	return r.exec(ctx, q, id)
+}
+
+// ListDeliveries возвращает последние limit доставок подписки, новые сверху.
+func (r *PGWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domWebhook.Delivery, error) {
+	const q = `SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.event_id = d.event_id
+		WHERE d.subscription_id = $1
+		ORDER BY d.delivery_id DESC
+		LIMIT $2
+	`
+	var raws []deliveryDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, subscriptionID, limit)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return rawDeliveryListToDomain(raws), nil
+}
+
+// RetryDelivery возвращает доставку в очередь с обнулённым счётчиком попыток.
+func (r *PGWebhookRepository) RetryDelivery(ctx context.Context, subscriptionID, deliveryID int64) error {
+	const q = `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = now(), updated_at = now()
+		WHERE subscription_id = $1 AND delivery_id = $2
+	`
+	return r.exec(ctx, q, subscriptionID, deliveryID)
+}
+
+// FanOut раскладывает до limit неразосланных событий outbox по активным
+// подпискам и отмечает их разосланными. Возвращает число обработанных событий.
+// SKIP LOCKED позволяет запускать несколько экземпляров приложения.
+func (r *PGWebhookRepository) FanOut(ctx context.Context, limit int) (int, error) {
+	const q = `
+		WITH batch AS (
+			SELECT event_id, event_type
+			FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY event_id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), queued AS (
+			INSERT INTO webhook_deliveries (subscription_id, event_id)
+			SELECT s.subscription_id, b.event_id
+			FROM batch b
+			JOIN webhook_subscriptions s
+			  ON s.active AND (b.event_type = ANY(s.events) OR '*' = ANY(s.events))
+			ON CONFLICT (subscription_id, event_id) DO NOTHING
+		)
+		UPDATE outbox_events e
+		SET dispatched_at = now()
+		FROM batch b
+		WHERE e.event_id = b.event_id
+	`
+	var cnt int64
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, limit)
+		if err != nil {
+			return err
+		}
+		cnt, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, repoError.MapPostgreSQLError(r.log, err)
+	}
+	return int(cnt), nil
+}
+
+// ClaimDue берёт в работу до limit доставок активных подписок, срок которых наступил к now:
+// увеличивает счётчик попыток и откладывает следующую на lease. Если процесс
+// упадёт, не записав результат, доставка вернётся в очередь после lease.
+func (r *PGWebhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domWebhook.Job, error) {
+	const q = `
+		WITH due AS (
+			SELECT d.delivery_id
+			FROM webhook_deliveries d
+			JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id AND s.active
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $1
+			ORDER BY d.next_attempt_at, d.delivery_id
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = $2, updated_at = $1
+		FROM due, webhook_subscriptions s, outbox_events e
+		WHERE d.delivery_id = due.delivery_id
+		  AND s.subscription_id = d.subscription_id
+		  AND e.event_id = d.event_id
+		RETURNING ` + deliveryColumns + `, s.url, s.secret, e.payload, e.created_at AS event_created_at
+	`
+	var raws []jobDB
+	err := r.withQuery(ctx, q, func() error {
+		return r.db.SelectContext(ctx, &raws, q, now, now.Add(lease), limit)
+	})
+	if err != nil {
+		return nil, repoError.MapPostgreSQLError(r.log, err)
+	}
+	jobs := make([]domWebhook.Job, len(raws))
+	for i, raw := range raws {
+		jobs[i] = raw.toDomain()
+	}
+	return jobs, nil
+}
+
+// SaveAttempt записывает результат попытки: статус, код ответа, ошибку
+// и время следующей попытки.
+func (r *PGWebhookRepository) SaveAttempt(ctx context.Context, d *domWebhook.Delivery) error {
+	const q = `
+		UPDATE webhook_deliveries
+		SET status = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5,
+		    delivered_at = $6, updated_at = $7
+		WHERE delivery_id = $1
+	`
+	return r.exec(ctx, q, d.ID, string(d.Status), d.NextAttemptAt, d.LastStatusCode,
+		d.LastError, d.DeliveredAt, d.UpdatedAt)
+}
+
+func (r *PGWebhookRepository) exec(ctx context.Context, q string, args ...any) error {
+	err := r.withQuery(ctx, q, func() error {
+		res, err := r.db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			return app_error.ErrNotFound
+		}
+		return nil
+	})
+	return repoError.MapPostgreSQLError(r.log, err)
+}
+
+func (r *PGWebhookRepository) withQuery(ctx context.Context, query string, fn func() error, extras ...slog.Attr) error {
+	r.log.Debug("query", slog.String("query", query))
+	return fn()
+}
@@ -0,0 +1,179 @@
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	testsuite "github.com/Neimess/zorkin-store-project/pkg/database/test_suite"
+	"github.com/Neimess/zorkin-store-project/pkg/migrator"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/product"
+	"github.com/Neimess/zorkin-store-project/internal/infrastructure/webhook"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type PGWebhookRepositorySuite struct {
+	suite.Suite
+	repo     *webhook.PGWebhookRepository
+	products *product.PGProductRepository
+	ctx      context.Context
+	srv      *testsuite.TestServer
+	db       *sqlx.DB
+}
+
+func (s *PGWebhookRepositorySuite) SetupSuite() {
+	log.SetOutput(io.Discard)
+
+	srv := testsuite.RunTestServer(s.T())
+	require.NotNil(s.T(), srv)
+
+	s.srv = srv
+	s.ctx = context.Background()
+	require.NoError(s.T(), migrator.Run(srv.Cfg.Storage.DSN(), migrator.Options{Mode: migrator.Up}))
+
+	s.db = srv.App.DB()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s.repo = webhook.NewPGWebhookRepository(s.db, logger)
+	deps, err := product.NewDeps(s.db, logger)
+	require.NoError(s.T(), err)
+	s.products = product.NewPGProductRepository(deps)
+}
+
+func (s *PGWebhookRepositorySuite) TearDownSuite() {
+	_ = s.srv.App.DB().Close()
+}
+
+func (s *PGWebhookRepositorySuite) SetupTest() {
+	// события прошлых тестов не должны попадать в новые подписки
+	_, err := s.db.Exec(`UPDATE outbox_events SET dispatched_at = now() WHERE dispatched_at IS NULL`)
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec(`DELETE FROM webhook_subscriptions`)
+	require.NoError(s.T(), err)
+}
+
+func (s *PGWebhookRepositorySuite) createCategory() int64 {
+	var id int64
+	require.NoError(s.T(), s.db.QueryRow(
+		`INSERT INTO categories(name) VALUES ($1) RETURNING category_id`,
+		fmt.Sprintf("webhook_%d", time.Now().UnixNano())).Scan(&id))
+	return id
+}
+
+func (s *PGWebhookRepositorySuite) subscribe(events ...domWebhook.EventType) *domWebhook.Subscription {
+	sub, err := s.repo.CreateSubscription(s.ctx, &domWebhook.Subscription{
+		URL:    "https://example.com/hook",
+		Secret: "0123456789abcdef",
+		Events: events,
+		Active: true,
+	})
+	require.NoError(s.T(), err)
+	return sub
+}
+
+func (s *PGWebhookRepositorySuite) TestProductMutationsWriteOutbox() {
+	sub := s.subscribe(domWebhook.EventPriceUpdated)
+	all := s.subscribe(domWebhook.EventAll)
+
+	p, err := s.products.CreateWithAttrs(s.ctx, &domProduct.Product{
+		Name:       "Плитка",
+		Price:      money.FromFloat(1000, money.Base),
+		CategoryID: s.createCategory(),
+	})
+	require.NoError(s.T(), err)
+	p.Price = money.FromFloat(900, money.Base)
+	_, err = s.products.UpdateWithAttrs(s.ctx, p)
+	require.NoError(s.T(), err)
+
+	// created + updated + price.updated
+	n, err := s.repo.FanOut(s.ctx, 100)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 3, n)
+
+	priceOnly, err := s.repo.ListDeliveries(s.ctx, sub.ID, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), priceOnly, 1)
+	require.Equal(s.T(), domWebhook.EventPriceUpdated, priceOnly[0].EventType)
+	require.Equal(s.T(), domWebhook.DeliveryPending, priceOnly[0].Status)
+
+	everything, err := s.repo.ListDeliveries(s.ctx, all.ID, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), everything, 3)
+
+	// повторный проход ничего не дублирует
+	n, err = s.repo.FanOut(s.ctx, 100)
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), n)
+}
+
+func (s *PGWebhookRepositorySuite) TestFailedMutationWritesNothing() {
+	s.subscribe(domWebhook.EventAll)
+
+	_, err := s.products.UpdateWithAttrs(s.ctx, &domProduct.Product{
+		ID:    -1,
+		Name:  "Нет такого",
+		Price: money.FromFloat(1, money.Base),
+	})
+	require.ErrorIs(s.T(), err, domProduct.ErrProductNotFound)
+
+	n, err := s.repo.FanOut(s.ctx, 100)
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), n)
+}
+
+func (s *PGWebhookRepositorySuite) TestClaimAndSaveAttempt() {
+	sub := s.subscribe(domWebhook.EventProductDeleted)
+	p, err := s.products.CreateWithAttrs(s.ctx, &domProduct.Product{
+		Name:       "Смеситель",
+		Price:      money.FromFloat(5000, money.Base),
+		CategoryID: s.createCategory(),
+	})
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.products.Delete(s.ctx, p.ID))
+	_, err = s.repo.FanOut(s.ctx, 100)
+	require.NoError(s.T(), err)
+
+	now := time.Now()
+	jobs, err := s.repo.ClaimDue(s.ctx, now, time.Minute, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), jobs, 1)
+	job := jobs[0]
+	require.Equal(s.T(), 1, job.Delivery.Attempts)
+	require.Equal(s.T(), sub.URL, job.URL)
+	require.Equal(s.T(), domWebhook.EventProductDeleted, job.Event.Type)
+	require.JSONEq(s.T(), fmt.Sprintf(`{"id":%d}`, p.ID), string(job.Event.Data))
+
+	// взятая доставка не выдаётся повторно до истечения lease
+	again, err := s.repo.ClaimDue(s.ctx, now, time.Minute, 10)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), again)
+
+	code := 200
+	delivered := now.UTC()
+	d := job.Delivery
+	d.Status, d.LastStatusCode, d.DeliveredAt, d.UpdatedAt = domWebhook.DeliveryDelivered, &code, &delivered, delivered
+	require.NoError(s.T(), s.repo.SaveAttempt(s.ctx, &d))
+
+	list, err := s.repo.ListDeliveries(s.ctx, sub.ID, 10)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domWebhook.DeliveryDelivered, list[0].Status)
+	require.Equal(s.T(), 200, *list[0].LastStatusCode)
+
+	require.NoError(s.T(), s.repo.RetryDelivery(s.ctx, sub.ID, d.ID))
+	require.ErrorIs(s.T(), s.repo.RetryDelivery(s.ctx, sub.ID+1000, d.ID), app_error.ErrNotFound)
+}
+
+func TestPGWebhookRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PGWebhookRepositorySuite))
+}
@@ -0,0 +1,369 @@
+// Package seed наполняет пустую базу демонстрационным каталогом: дерево
+// категорий, атрибуты, товары с услугами, пресеты и коэффициенты.
+// Каталог строится детерминированно из профиля и зерна, а записывается
+// через обычные репозитории.
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	coeffDom "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/shopspring/decimal"
+)
+
+// Catalog — сгенерированный каталог. Связи между сущностями заданы
+// индексами в срезах: ID появляются только после записи в БД.
+type Catalog struct {
+	Categories   []Category
+	Services     []serviceDom.Service
+	Products     []Product
+	Presets      []Preset
+	Coefficients []coeffDom.Coefficient
+}
+
+// Category — узел дерева. Parent — индекс родителя в Catalog.Categories
+// (-1 у корня), Attributes заполнены только у листьев.
+type Category struct {
+	Name       string
+	Parent     int
+	Attributes []attrDom.Attribute
+}
+
+// Product — товар листовой категории Category со значениями её атрибутов
+// (Product.Attributes) и услугами Services (индексы в Catalog.Services).
+type Product struct {
+	Product  prodDom.Product
+	Category int
+	Services []int
+}
+
+// Preset — пресет из товаров Catalog.Products[Items[i].Product].
+type Preset struct {
+	Name        string
+	Description string
+	Items       []PresetItem
+}
+
+type PresetItem struct {
+	Product  int
+	Quantity float64
+}
+
+// Generate строит каталог профиля p. Одинаковые p и seed всегда дают
+// одинаковый каталог.
+func Generate(p Profile, seed int64) (*Catalog, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	g := &generator{
+		p:   p,
+		rng: rand.New(rand.NewPCG(uint64(seed), 0x5eed)),
+		c:   &Catalog{},
+	}
+	leaves := g.categories()
+	g.services()
+	g.products(leaves)
+	g.presets()
+	g.coefficients()
+	return g.c, nil
+}
+
+type generator struct {
+	p   Profile
+	rng *rand.Rand
+	c   *Catalog
+}
+
+func (g *generator) categories() []int {
+	var leaves []int
+	add := func(name string, parent int) int {
+		g.c.Categories = append(g.c.Categories, Category{Name: name, Parent: parent})
+		return len(g.c.Categories) - 1
+	}
+	for _, d := range departments[:g.p.Departments] {
+		root := add(d.name, -1)
+		for _, sub := range d.subs[:g.p.SubsPerDepartment] {
+			node := add(sub, root)
+			if g.p.SeriesPerSub == 0 {
+				leaves = append(leaves, node)
+				continue
+			}
+			for _, s := range series[:g.p.SeriesPerSub] {
+				leaves = append(leaves, add(sub+" — "+s, node))
+			}
+		}
+	}
+	for _, i := range leaves {
+		g.c.Categories[i].Attributes = g.attributes()
+	}
+	return leaves
+}
+
+// attributes выбирает набор атрибутов листа: производитель есть всегда,
+// остальные берутся из пула в случайном порядке.
+func (g *generator) attributes() []attrDom.Attribute {
+	picked := []attrSpec{brandAttr}
+	for _, i := range g.rng.Perm(len(attrPool))[:g.p.AttributesPerLeaf-1] {
+		picked = append(picked, attrPool[i])
+	}
+	attrs := make([]attrDom.Attribute, len(picked))
+	for i, spec := range picked {
+		attrs[i] = attrDom.Attribute{Name: spec.name}
+		if spec.unit != "" {
+			unit := spec.unit
+			attrs[i].Unit = &unit
+		}
+	}
+	return attrs
+}
+
+func (g *generator) services() {
+	for i := range g.p.Services {
+		base := serviceNames[i%len(serviceNames)]
+		tier := serviceTiers[i/len(serviceNames)]
+		name, desc := base.name, base.description
+		if tier.name != "" {
+			name += " (" + tier.name + ")"
+		}
+		g.c.Services = append(g.c.Services, serviceDom.Service{
+			Name:        name,
+			Description: &desc,
+			Price:       price(base.price.Mul(tier.factor)),
+		})
+	}
+}
+
+func (g *generator) products(leaves []int) {
+	for _, leaf := range leaves {
+		cat := g.c.Categories[leaf]
+		// «Ламинат — Премиум» → товары называются «Ламинат …»
+		kind := cat.Name
+		if g.p.SeriesPerSub > 0 {
+			kind = g.c.Categories[cat.Parent].Name
+		}
+		for range g.p.ProductsPerLeaf {
+			g.c.Products = append(g.c.Products, g.product(kind, leaf, cat.Attributes))
+		}
+	}
+}
+
+func (g *generator) product(kind string, leaf int, attrs []attrDom.Attribute) Product {
+	values := make([]prodDom.ProductAttribute, len(attrs))
+	desc := make([]string, 0, len(attrs))
+	var brand string
+	for i, a := range attrs {
+		v := specByName[a.Name].value(g.rng)
+		if a.Name == brandAttr.name {
+			brand = v
+		}
+		values[i] = prodDom.ProductAttribute{Attribute: a, Value: v}
+		part := strings.ToLower(a.Name) + ": " + v
+		if a.Unit != nil {
+			part += " " + *a.Unit
+		}
+		desc = append(desc, part)
+	}
+
+	n := len(g.c.Products) + 1
+	name := fmt.Sprintf("%s %s %c%c-%04d", kind, brand, 'A'+g.rng.IntN(26), 'A'+g.rng.IntN(26), g.rng.IntN(10000))
+	description := name + ": " + strings.Join(desc, ", ") + "."
+	image := fmt.Sprintf("https://picsum.photos/seed/zorkin-%d/600/400", n)
+	// цены распределены неравномерно: дешёвых позиций больше, чем дорогих
+	f := g.rng.Float64()
+	amount := g.p.MinPrice + (g.p.MaxPrice-g.p.MinPrice)*f*f
+
+	var services []int
+	if len(g.c.Services) > 0 {
+		k := g.rng.IntN(g.p.ServicesPerProduct + 1)
+		services = g.rng.Perm(len(g.c.Services))[:k]
+	}
+	return Product{
+		Product: prodDom.Product{
+			Name:        name,
+			Price:       price(decimal.NewFromFloat(amount)),
+			Description: &description,
+			ImageURL:    &image,
+			Attributes:  values,
+		},
+		Category: leaf,
+		Services: services,
+	}
+}
+
+func (g *generator) presets() {
+	for i := range g.p.Presets {
+		base := presetNames[i%len(presetNames)]
+		name := base
+		if i >= len(presetNames) {
+			name = fmt.Sprintf("%s №%d", base, i/len(presetNames)+1)
+		}
+
+		size := 2 + g.rng.IntN(g.p.ItemsPerPreset-1)
+		seen := make(map[int]struct{}, size)
+		items := make([]PresetItem, 0, size)
+		for len(items) < size {
+			idx := g.rng.IntN(len(g.c.Products))
+			if _, ok := seen[idx]; ok {
+				continue
+			}
+			seen[idx] = struct{}{}
+			items = append(items, PresetItem{Product: idx, Quantity: float64(1 + g.rng.IntN(10))})
+		}
+		g.c.Presets = append(g.c.Presets, Preset{
+			Name:        name,
+			Description: "Подборка материалов: " + strings.ToLower(base) + ".",
+			Items:       items,
+		})
+	}
+}
+
+func (g *generator) coefficients() {
+	for _, c := range coefficientNames[:g.p.Coefficients] {
+		g.c.Coefficients = append(g.c.Coefficients, coeffDom.Coefficient{Name: c.name, Value: c.value})
+	}
+}
+
+// price округляет сумму до копеек, как её хранит NUMERIC(10, 2).
+func price(amount decimal.Decimal) money.Money {
+	return money.New(amount.Round(2), money.Base)
+}
+
+/* -------- словари -------- */
+
+type department struct {
+	name string
+	subs []string
+}
+
+var departments = []department{
+	{"Напольные покрытия", []string{"Ламинат", "Паркетная доска", "Линолеум", "Виниловые полы", "Ковролин"}},
+	{"Плитка и керамогранит", []string{"Настенная плитка", "Напольная плитка", "Керамогранит", "Мозаика", "Клинкер"}},
+	{"Стены и потолки", []string{"Гипсокартон", "Обои", "Декоративная штукатурка", "Стеновые панели", "Потолочные плиты"}},
+	{"Сухие смеси", []string{"Плиточный клей", "Штукатурка", "Шпаклёвка", "Наливной пол", "Затирка"}},
+	{"Сантехника", []string{"Смесители", "Раковины", "Унитазы", "Ванны", "Душевые кабины"}},
+	{"Краски и лаки", []string{"Интерьерные краски", "Фасадные краски", "Грунтовки", "Лаки", "Эмали"}},
+}
+
+var series = []string{"Эконом", "Стандарт", "Комфорт", "Премиум", "Дизайнерская серия"}
+
+type attrKind int
+
+const (
+	kindEnum attrKind = iota
+	kindNumber
+	kindBool
+)
+
+// attrSpec описывает тип атрибута: перечисление, число в диапазоне
+// с заданной точностью или да/нет.
+type attrSpec struct {
+	name     string
+	unit     string
+	kind     attrKind
+	values   []string
+	min, max float64
+	prec     int
+}
+
+func (s attrSpec) value(rng *rand.Rand) string {
+	switch s.kind {
+	case kindNumber:
+		v := s.min + (s.max-s.min)*rng.Float64()
+		return strconv.FormatFloat(v, 'f', s.prec, 64)
+	case kindBool:
+		if rng.IntN(2) == 0 {
+			return "нет"
+		}
+		return "да"
+	default:
+		return s.values[rng.IntN(len(s.values))]
+	}
+}
+
+var brandAttr = attrSpec{name: "Производитель", kind: kindEnum, values: []string{
+	"Kronospan", "Tarkett", "Egger", "Quick-Step", "Kerama Marazzi", "Cersanit",
+	"Knauf", "Ceresit", "Grohe", "Vitra", "Tikkurila", "Dulux",
+}}
+
+var attrPool = []attrSpec{
+	{name: "Страна производства", kind: kindEnum, values: []string{"Россия", "Казахстан", "Германия", "Польша", "Италия", "Турция", "Китай"}},
+	{name: "Цвет", kind: kindEnum, values: []string{"Белый", "Бежевый", "Серый", "Графит", "Дуб натуральный", "Орех", "Венге", "Слоновая кость"}},
+	{name: "Длина", unit: "мм", kind: kindNumber, min: 200, max: 2000},
+	{name: "Ширина", unit: "мм", kind: kindNumber, min: 100, max: 1200},
+	{name: "Толщина", unit: "мм", kind: kindNumber, min: 2, max: 25, prec: 1},
+	{name: "Вес", unit: "кг", kind: kindNumber, min: 0.5, max: 50, prec: 1},
+	{name: "Площадь в упаковке", unit: "м²", kind: kindNumber, min: 0.5, max: 3.5, prec: 2},
+	{name: "Гарантия", unit: "лет", kind: kindNumber, min: 1, max: 25},
+	{name: "Класс износостойкости", kind: kindEnum, values: []string{"31", "32", "33", "34"}},
+	{name: "Влагостойкость", kind: kindBool},
+}
+
+var specByName = func() map[string]attrSpec {
+	m := map[string]attrSpec{brandAttr.name: brandAttr}
+	for _, s := range attrPool {
+		m[s.name] = s
+	}
+	return m
+}()
+
+type serviceName struct {
+	name        string
+	description string
+	price       decimal.Decimal
+}
+
+var serviceNames = []serviceName{
+	{"Доставка по городу", "Доставка до подъезда в пределах города", decimal.NewFromInt(1500)},
+	{"Доставка за город", "Доставка до 50 км от города", decimal.NewFromInt(3500)},
+	{"Подъём на этаж", "Подъём без лифта, за этаж", decimal.NewFromInt(300)},
+	{"Укладка ламината", "Укладка на подготовленное основание, за м²", decimal.NewFromInt(450)},
+	{"Укладка плитки", "Облицовка пола или стен, за м²", decimal.NewFromInt(1200)},
+	{"Монтаж гипсокартона", "Каркас и обшивка в один слой, за м²", decimal.NewFromInt(700)},
+	{"Поклейка обоев", "Подготовка стен и поклейка, за м²", decimal.NewFromInt(350)},
+	{"Покраска стен", "Грунтование и окраска в два слоя, за м²", decimal.NewFromInt(400)},
+	{"Установка смесителя", "Монтаж и подключение", decimal.NewFromInt(1800)},
+	{"Установка унитаза", "Монтаж, подключение и герметизация", decimal.NewFromInt(3500)},
+	{"Замер помещения", "Выезд замерщика и расчёт материалов", decimal.NewFromInt(1000)},
+	{"Вывоз мусора", "Погрузка и вывоз строительного мусора", decimal.NewFromInt(2500)},
+}
+
+var serviceTiers = []struct {
+	name   string
+	factor decimal.Decimal
+}{
+	{"", decimal.NewFromInt(1)},
+	{"срочно", decimal.RequireFromString("1.5")},
+	{"выходной день", decimal.RequireFromString("1.3")},
+}
+
+var presetNames = []string{
+	"Ремонт ванной комнаты",
+	"Санузел под ключ",
+	"Полы в гостиной",
+	"Косметический ремонт спальни",
+	"Отделка прихожей",
+	"Ремонт детской",
+	"Кухня: стены и фартук",
+	"Утепление балкона",
+}
+
+var coefficientNames = []struct {
+	name  string
+	value float64
+}{
+	{"Запас ламината на подрезку", 1.05},
+	{"Запас плитки на подрезку", 1.1},
+	{"Запас обоев на подгонку рисунка", 1.15},
+	{"Расход плиточного клея, кг/м²", 4.5},
+	{"Расход шпаклёвки, кг/м²", 1.2},
+	{"Расход краски, л/м²", 0.12},
+	{"Расход грунтовки, л/м²", 0.1},
+	{"Наценка за сложный монтаж", 1.25},
+}
@@ -0,0 +1,132 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Profile задаёт размер каталога.
+type Profile struct {
+	Name string
+	// Departments, SubsPerDepartment, SeriesPerSub — ширина дерева категорий
+	// по уровням; при SeriesPerSub = 0 дерево двухуровневое.
+	Departments       int
+	SubsPerDepartment int
+	SeriesPerSub      int
+	// AttributesPerLeaf — атрибутов у листовой категории, включая производителя.
+	AttributesPerLeaf int
+	ProductsPerLeaf   int
+	// MinPrice, MaxPrice — диапазон цен товаров в базовой валюте.
+	MinPrice, MaxPrice float64
+	Services           int
+	// ServicesPerProduct — максимум услуг у одного товара.
+	ServicesPerProduct int
+	Presets            int
+	// ItemsPerPreset — максимум позиций в пресете, минимум — две.
+	ItemsPerPreset int
+	Coefficients   int
+}
+
+var profiles = map[string]Profile{
+	"small": {
+		Name:               "small",
+		Departments:        3,
+		SubsPerDepartment:  2,
+		AttributesPerLeaf:  4,
+		ProductsPerLeaf:    10,
+		MinPrice:           150,
+		MaxPrice:           30000,
+		Services:           6,
+		ServicesPerProduct: 2,
+		Presets:            5,
+		ItemsPerPreset:     4,
+		Coefficients:       3,
+	},
+	"medium": {
+		Name:               "medium",
+		Departments:        6,
+		SubsPerDepartment:  4,
+		SeriesPerSub:       2,
+		AttributesPerLeaf:  5,
+		ProductsPerLeaf:    50,
+		MinPrice:           150,
+		MaxPrice:           60000,
+		Services:           18,
+		ServicesPerProduct: 3,
+		Presets:            40,
+		ItemsPerPreset:     6,
+		Coefficients:       6,
+	},
+	"load-test": {
+		Name:               "load-test",
+		Departments:        6,
+		SubsPerDepartment:  5,
+		SeriesPerSub:       5,
+		AttributesPerLeaf:  6,
+		ProductsPerLeaf:    400,
+		MinPrice:           150,
+		MaxPrice:           90000,
+		Services:           36,
+		ServicesPerProduct: 3,
+		Presets:            500,
+		ItemsPerPreset:     8,
+		Coefficients:       8,
+	},
+}
+
+// ProfileNames возвращает имена встроенных профилей.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetProfile возвращает встроенный профиль по имени.
+func GetProfile(name string) (Profile, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown seed profile %q, expected one of %v", name, ProfileNames())
+	}
+	return p, nil
+}
+
+// Validate проверяет, что профиль укладывается в словари генератора.
+func (p Profile) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	check(p.Departments > 0 && p.Departments <= len(departments), "departments must be in 1..%d", len(departments))
+	check(p.SubsPerDepartment > 0 && slices.IndexFunc(departments, func(d department) bool {
+		return len(d.subs) < p.SubsPerDepartment
+	}) < 0, "subs per department must be positive and fit the dictionary")
+	check(p.SeriesPerSub >= 0 && p.SeriesPerSub <= len(series), "series per sub must be in 0..%d", len(series))
+	check(p.AttributesPerLeaf > 0 && p.AttributesPerLeaf <= len(attrPool)+1, "attributes per leaf must be in 1..%d", len(attrPool)+1)
+	check(p.ProductsPerLeaf > 0, "products per leaf must be positive")
+	check(p.MinPrice > 0 && p.MinPrice <= p.MaxPrice, "price range must be positive and ordered")
+	check(p.Services >= 0 && p.Services <= len(serviceNames)*len(serviceTiers), "services must be in 0..%d", len(serviceNames)*len(serviceTiers))
+	check(p.ServicesPerProduct >= 0 && p.ServicesPerProduct <= p.Services, "services per product must not exceed services")
+	check(p.Presets >= 0, "presets must not be negative")
+	check(p.Presets == 0 || p.ItemsPerPreset >= 2 && p.ItemsPerPreset <= p.leaves()*p.ProductsPerLeaf,
+		"items per preset must be at least 2 and not exceed the number of products")
+	check(p.Coefficients >= 0 && p.Coefficients <= len(coefficientNames), "coefficients must be in 0..%d", len(coefficientNames))
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("seed profile %q: %w", p.Name, err)
+	}
+	return nil
+}
+
+func (p Profile) leaves() int {
+	n := p.Departments * p.SubsPerDepartment
+	if p.SeriesPerSub > 0 {
+		n *= p.SeriesPerSub
+	}
+	return n
+}
@@ -0,0 +1,258 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	coeffDom "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/shopspring/decimal"
+)
+
+// ErrCatalogNotEmpty — в базе уже есть категории: имена категорий уникальны,
+// поэтому каталог заливается только в пустую базу.
+var ErrCatalogNotEmpty = errors.New("catalog is not empty")
+
+// progressEvery — через сколько товаров или пресетов в лог пишется прогресс.
+const progressEvery = 500
+
+type CategoryRepository interface {
+	Create(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
+	List(ctx context.Context) ([]catDom.Category, error)
+}
+
+type AttributeRepository interface {
+	SaveBatch(ctx context.Context, attrs []attrDom.Attribute) error
+}
+
+type ServiceRepository interface {
+	Create(ctx context.Context, s *serviceDom.Service) (*serviceDom.Service, error)
+}
+
+type ProductRepository interface {
+	CreateWithAttrs(ctx context.Context, p *prodDom.Product) (*prodDom.Product, error)
+}
+
+type PresetRepository interface {
+	Create(ctx context.Context, p *presetDom.Preset) (*presetDom.Preset, error)
+}
+
+type CoefficientRepository interface {
+	Create(ctx context.Context, c *coeffDom.Coefficient) (*coeffDom.Coefficient, error)
+}
+
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Deps struct {
+	Categories   CategoryRepository
+	Attributes   AttributeRepository
+	Services     ServiceRepository
+	Products     ProductRepository
+	Presets      PresetRepository
+	Coefficients CoefficientRepository
+	Tx           Transactor
+	Logger       *slog.Logger
+}
+
+type Seeder struct {
+	deps Deps
+	log  *slog.Logger
+}
+
+func New(deps Deps) *Seeder {
+	if deps.Categories == nil || deps.Attributes == nil || deps.Services == nil ||
+		deps.Products == nil || deps.Presets == nil || deps.Coefficients == nil || deps.Tx == nil {
+		panic("seed.New: nil repository")
+	}
+	if deps.Logger == nil {
+		panic("seed.New: logger is nil")
+	}
+	return &Seeder{deps: deps, log: deps.Logger.With("component", "seed")}
+}
+
+// Summary — сколько сущностей записано.
+type Summary struct {
+	Categories   int
+	Attributes   int
+	Services     int
+	Products     int
+	Presets      int
+	Coefficients int
+	Took         time.Duration
+}
+
+// Run генерирует каталог профиля p из seed и записывает его в базу одной
+// транзакцией: при ошибке база остаётся пустой и команду можно повторить.
+func (s *Seeder) Run(ctx context.Context, p Profile, seed int64) (Summary, error) {
+	start := time.Now()
+	c, err := Generate(p, seed)
+	if err != nil {
+		return Summary{}, err
+	}
+	var sum Summary
+	err = s.deps.Tx.InTx(ctx, func(ctx context.Context) error {
+		return s.write(ctx, p, seed, c, &sum)
+	})
+	if err != nil {
+		return Summary{}, err
+	}
+	sum.Took = time.Since(start)
+	s.log.Info("catalog seeded", slog.Int("products", sum.Products), slog.Duration("took", sum.Took))
+	return sum, nil
+}
+
+func (s *Seeder) write(ctx context.Context, p Profile, seed int64, c *Catalog, sum *Summary) error {
+	existing, err := s.deps.Categories.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list categories: %w", err)
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("%w: %d categories found", ErrCatalogNotEmpty, len(existing))
+	}
+	s.log.Info("seeding catalog",
+		slog.String("profile", p.Name),
+		slog.Int64("seed", seed),
+		slog.Int("categories", len(c.Categories)),
+		slog.Int("products", len(c.Products)),
+		slog.Int("presets", len(c.Presets)),
+	)
+
+	catIDs, err := s.categories(ctx, c, sum)
+	if err != nil {
+		return err
+	}
+	svcIDs := make([]int64, len(c.Services))
+	for i := range c.Services {
+		created, err := s.deps.Services.Create(ctx, &c.Services[i])
+		if err != nil {
+			return fmt.Errorf("create service %q: %w", c.Services[i].Name, err)
+		}
+		svcIDs[i] = created.ID
+		sum.Services++
+	}
+	for i := range c.Coefficients {
+		if _, err := s.deps.Coefficients.Create(ctx, &c.Coefficients[i]); err != nil {
+			return fmt.Errorf("create coefficient %q: %w", c.Coefficients[i].Name, err)
+		}
+		sum.Coefficients++
+	}
+	prodIDs, err := s.products(ctx, c, catIDs, svcIDs, sum)
+	if err != nil {
+		return err
+	}
+	return s.presets(ctx, c, prodIDs, sum)
+}
+
+// categories создаёт дерево (родители в Catalog.Categories идут раньше
+// детей) и атрибуты листьев.
+func (s *Seeder) categories(ctx context.Context, c *Catalog, sum *Summary) ([]int64, error) {
+	ids := make([]int64, len(c.Categories))
+	for i, cat := range c.Categories {
+		dom := &catDom.Category{Name: cat.Name}
+		if cat.Parent >= 0 {
+			dom.ParentID = &ids[cat.Parent]
+		}
+		created, err := s.deps.Categories.Create(ctx, dom)
+		if err != nil {
+			return nil, fmt.Errorf("create category %q: %w", cat.Name, err)
+		}
+		ids[i] = created.ID
+		sum.Categories++
+
+		if len(cat.Attributes) == 0 {
+			continue
+		}
+		for j := range cat.Attributes {
+			cat.Attributes[j].CategoryID = created.ID
+		}
+		if err := s.deps.Attributes.SaveBatch(ctx, cat.Attributes); err != nil {
+			return nil, fmt.Errorf("create attributes of %q: %w", cat.Name, err)
+		}
+		sum.Attributes += len(cat.Attributes)
+	}
+	return ids, nil
+}
+
+func (s *Seeder) products(ctx context.Context, c *Catalog, catIDs, svcIDs []int64, sum *Summary) ([]int64, error) {
+	ids := make([]int64, len(c.Products))
+	err := s.each("products", len(c.Products), func(i int) error {
+		p := &c.Products[i]
+		p.Product.CategoryID = catIDs[p.Category]
+		for j := range p.Product.Attributes {
+			p.Product.Attributes[j].Attribute.CategoryID = p.Product.CategoryID
+		}
+		p.Product.Services = make([]serviceDom.Service, len(p.Services))
+		for j, idx := range p.Services {
+			p.Product.Services[j] = serviceDom.Service{ID: svcIDs[idx]}
+		}
+		created, err := s.deps.Products.CreateWithAttrs(ctx, &p.Product)
+		if err != nil {
+			return fmt.Errorf("create product %q: %w", p.Product.Name, err)
+		}
+		ids[i] = created.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sum.Products = len(ids)
+	return ids, nil
+}
+
+func (s *Seeder) presets(ctx context.Context, c *Catalog, prodIDs []int64, sum *Summary) error {
+	err := s.each("presets", len(c.Presets), func(i int) error {
+		ps := c.Presets[i]
+		desc := ps.Description
+		dom := &presetDom.Preset{
+			Name:        ps.Name,
+			Description: &desc,
+			TotalPrice:  money.Zero(money.Base),
+			Items:       make([]presetDom.PresetItem, len(ps.Items)),
+		}
+		// итог пресета репозиторий не считает — его передаёт вызывающий
+		for j, it := range ps.Items {
+			dom.Items[j] = presetDom.PresetItem{ProductID: prodIDs[it.Product], Quantity: it.Quantity}
+			line := c.Products[it.Product].Product.Price.Mul(decimal.NewFromFloat(it.Quantity))
+			total, err := dom.TotalPrice.Add(line)
+			if err != nil {
+				return err
+			}
+			dom.TotalPrice = total
+		}
+		if err := dom.Validate(); err != nil {
+			return fmt.Errorf("preset %q: %w", ps.Name, err)
+		}
+		if _, err := s.deps.Presets.Create(ctx, dom); err != nil {
+			return fmt.Errorf("create preset %q: %w", ps.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sum.Presets = len(c.Presets)
+	return nil
+}
+
+// each вызывает fn для 0..n-1 и пишет прогресс в лог.
+func (s *Seeder) each(what string, n int, fn func(i int) error) error {
+	for i := range n {
+		if err := fn(i); err != nil {
+			return err
+		}
+		if done := i + 1; done%progressEvery == 0 || done == n {
+			s.log.Info("seeding "+what, slog.Int("done", done), slog.Int("total", n))
+		}
+	}
+	return nil
+}
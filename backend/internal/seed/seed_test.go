@@ -0,0 +1,233 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	coeffDom "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
+	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	assert.Equal(t, []string{"load-test", "medium", "small"}, ProfileNames())
+	for _, name := range ProfileNames() {
+		p, err := GetProfile(name)
+		require.NoError(t, err)
+		assert.NoError(t, p.Validate(), name)
+	}
+
+	_, err := GetProfile("huge")
+	assert.Error(t, err)
+
+	p, _ := GetProfile("small")
+	p.Departments = len(departments) + 1
+	p.ItemsPerPreset = 1
+	assert.Error(t, p.Validate())
+}
+
+func TestGenerate(t *testing.T) {
+	p, err := GetProfile("medium")
+	require.NoError(t, err)
+
+	c, err := Generate(p, 42)
+	require.NoError(t, err)
+	again, err := Generate(p, 42)
+	require.NoError(t, err)
+	other, err := Generate(p, 43)
+	require.NoError(t, err)
+
+	assert.Equal(t, c, again, "same seed must produce the same catalog")
+	assert.NotEqual(t, c.Products[0].Product.Name, other.Products[0].Product.Name)
+
+	assert.Len(t, c.Categories, 6+6*4+6*4*2)
+	assert.Len(t, c.Products, 6*4*2*50)
+	assert.Len(t, c.Services, 18)
+	assert.Len(t, c.Presets, 40)
+	assert.Len(t, c.Coefficients, 6)
+
+	names := make(map[string]struct{}, len(c.Categories))
+	for i, cat := range c.Categories {
+		assert.Less(t, cat.Parent, i, "parents go before children")
+		names[cat.Name] = struct{}{}
+	}
+	assert.Len(t, names, len(c.Categories), "category names are unique")
+
+	for _, prod := range c.Products {
+		leaf := c.Categories[prod.Category]
+		require.Len(t, prod.Product.Attributes, p.AttributesPerLeaf)
+		for i, a := range prod.Product.Attributes {
+			assert.Equal(t, leaf.Attributes[i].Name, a.Attribute.Name)
+			assert.NotEmpty(t, a.Value)
+		}
+		assert.True(t, prod.Product.Price.IsPositive())
+		assert.LessOrEqual(t, len(prod.Services), p.ServicesPerProduct)
+	}
+
+	for _, ps := range c.Presets {
+		assert.LessOrEqual(t, len(ps.Name), 100)
+		assert.GreaterOrEqual(t, len(ps.Items), 2)
+		seen := map[int]bool{}
+		for _, it := range ps.Items {
+			assert.False(t, seen[it.Product], "preset items are distinct products")
+			seen[it.Product] = true
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	p, err := GetProfile("small")
+	require.NoError(t, err)
+
+	t.Run("writes the catalog", func(t *testing.T) {
+		f := &fakeRepos{}
+		sum, err := newSeeder(f).Run(context.Background(), p, 1)
+		require.NoError(t, err)
+
+		c, _ := Generate(p, 1)
+		assert.Equal(t, len(c.Categories), sum.Categories)
+		assert.Equal(t, len(c.Products), sum.Products)
+		assert.Equal(t, len(c.Presets), sum.Presets)
+		assert.Len(t, f.categories, len(c.Categories))
+		assert.Len(t, f.products, len(c.Products))
+		assert.Equal(t, 1, f.txs, "the whole catalog is one transaction")
+		assert.Zero(t, f.outsideTx, "every write goes through the transaction")
+
+		for _, prod := range f.products {
+			parent := f.categories[prod.CategoryID-1].ParentID
+			require.NotNil(t, parent, "products belong to leaf categories")
+		}
+		for _, ps := range f.presets {
+			var total float64
+			for _, it := range ps.Items {
+				total += f.products[it.ProductID-1].Price.Float64() * it.Quantity
+			}
+			assert.InDelta(t, total, ps.TotalPrice.Float64(), 0.001)
+		}
+	})
+
+	t.Run("refuses a non-empty catalog", func(t *testing.T) {
+		f := &fakeRepos{categories: []catDom.Category{{ID: 1, Name: "Ламинат"}}}
+		_, err := newSeeder(f).Run(context.Background(), p, 1)
+		assert.ErrorIs(t, err, ErrCatalogNotEmpty)
+		assert.Empty(t, f.products)
+	})
+
+	t.Run("reports nothing written on failure", func(t *testing.T) {
+		f := &fakeRepos{failProduct: 3}
+		sum, err := newSeeder(f).Run(context.Background(), p, 1)
+		assert.Error(t, err)
+		assert.Zero(t, sum, "the transaction is rolled back")
+		assert.Equal(t, 1, f.txs)
+		assert.Empty(t, f.presets)
+	})
+}
+
+func newSeeder(f *fakeRepos) *Seeder {
+	return New(Deps{
+		Categories:   fakeCategories{f},
+		Attributes:   fakeAttributes{f},
+		Services:     fakeServices{f},
+		Products:     fakeProducts{f},
+		Presets:      fakePresets{f},
+		Coefficients: fakeCoefficients{f},
+		Tx:           f,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+}
+
+// fakeRepos хранит записанное в памяти и выдаёт ID по порядку с 1.
+type fakeRepos struct {
+	categories []catDom.Category
+	attributes []attrDom.Attribute
+	services   []serviceDom.Service
+	products   []prodDom.Product
+	presets    []presetDom.Preset
+	coeffs     []coeffDom.Coefficient
+	txs        int
+	// outsideTx — сколько записей сделано вне InTx
+	outsideTx int
+	// failProduct — CreateWithAttrs вернёт ошибку на товаре с этим номером (с 1)
+	failProduct int
+}
+
+type fakeTxKey struct{}
+
+func (f *fakeRepos) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.txs++
+	return fn(context.WithValue(ctx, fakeTxKey{}, true))
+}
+
+func (f *fakeRepos) write(ctx context.Context) {
+	if ctx.Value(fakeTxKey{}) == nil {
+		f.outsideTx++
+	}
+}
+
+type fakeCategories struct{ *fakeRepos }
+
+func (f fakeCategories) Create(ctx context.Context, c *catDom.Category) (*catDom.Category, error) {
+	f.write(ctx)
+	c.ID = int64(len(f.categories) + 1)
+	f.categories = append(f.categories, *c)
+	return c, nil
+}
+
+func (f fakeCategories) List(context.Context) ([]catDom.Category, error) {
+	return f.categories, nil
+}
+
+type fakeAttributes struct{ *fakeRepos }
+
+func (f fakeAttributes) SaveBatch(ctx context.Context, attrs []attrDom.Attribute) error {
+	f.write(ctx)
+	f.attributes = append(f.attributes, attrs...)
+	return nil
+}
+
+type fakeServices struct{ *fakeRepos }
+
+func (f fakeServices) Create(ctx context.Context, s *serviceDom.Service) (*serviceDom.Service, error) {
+	f.write(ctx)
+	s.ID = int64(len(f.services) + 1)
+	f.services = append(f.services, *s)
+	return s, nil
+}
+
+type fakeProducts struct{ *fakeRepos }
+
+func (f fakeProducts) CreateWithAttrs(ctx context.Context, p *prodDom.Product) (*prodDom.Product, error) {
+	f.write(ctx)
+	if len(f.products)+1 == f.failProduct {
+		return nil, errors.New("insert failed")
+	}
+	p.ID = int64(len(f.products) + 1)
+	f.products = append(f.products, *p)
+	return p, nil
+}
+
+type fakePresets struct{ *fakeRepos }
+
+func (f fakePresets) Create(ctx context.Context, p *presetDom.Preset) (*presetDom.Preset, error) {
+	f.write(ctx)
+	p.ID = int64(len(f.presets) + 1)
+	f.presets = append(f.presets, *p)
+	return p, nil
+}
+
+type fakeCoefficients struct{ *fakeRepos }
+
+func (f fakeCoefficients) Create(ctx context.Context, c *coeffDom.Coefficient) (*coeffDom.Coefficient, error) {
+	f.write(ctx)
+	c.ID = int64(len(f.coeffs) + 1)
+	f.coeffs = append(f.coeffs, *c)
+	return c, nil
+}
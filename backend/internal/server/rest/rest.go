@@ -2,88 +2,198 @@ package rest
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/Neimess/zorkin-store-project/internal/config"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP"
 	route "github.com/Neimess/zorkin-store-project/internal/transport/http/routes"
+	"github.com/Neimess/zorkin-store-project/pkg/certreload"
+	"github.com/Neimess/zorkin-store-project/pkg/health"
+	"github.com/Neimess/zorkin-store-project/pkg/ratelimit"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 	"github.com/go-chi/chi/v5"
 )
 
 type Deps struct {
-	cfg      *config.Config
-	handlers *restHTTP.Handlers
-	log      *slog.Logger
+	cfg       *config.Config
+	handlers  *restHTTP.Handlers
+	log       *slog.Logger
+	metrics   *telemetry.Metrics
+	probe     *health.Probe
+	rateStore ratelimit.Store
+	reloader  *config.Reloader
 }
 
-func NewDeps(cfg *config.Config, handlers *restHTTP.Handlers, logger *slog.Logger) (Deps, error) {
-	if cfg == nil || handlers == nil || logger == nil {
+// NewDeps: metrics == nil — метрики выключены, rateStore == nil — лимиты
+// запросов, reloader == nil — перезагрузка конфига.
+func NewDeps(cfg *config.Config, handlers *restHTTP.Handlers, logger *slog.Logger, metrics *telemetry.Metrics, probe *health.Probe, rateStore ratelimit.Store, reloader *config.Reloader) (Deps, error) {
+	if cfg == nil || handlers == nil || logger == nil || probe == nil {
 		return Deps{}, errors.New("invalid dependencies")
 	}
 	return Deps{
-		cfg:      cfg,
-		handlers: handlers,
-		log:      logger,
+		cfg:       cfg,
+		handlers:  handlers,
+		log:       logger,
+		metrics:   metrics,
+		probe:     probe,
+		rateStore: rateStore,
+		reloader:  reloader,
 	}, nil
 }
 
 type Server struct {
 	httpServer *http.Server
-	log        *slog.Logger
+	// adminServer слушает http_server.admin_address; nil — адрес не задан
+	adminServer *http.Server
+	// certs != nil — сервер сам терминирует TLS
+	certs          *certreload.Reloader
+	reloadInterval time.Duration
+	log            *slog.Logger
 }
 
 func NewServer(dep Deps) (*Server, error) {
-
+	cfg := dep.cfg.HTTPServer
 	r := chi.NewRouter()
+	// nil-интерфейс, а не nil *chi.Mux: роуты проверяют adminRouter на nil
+	var adminRouter chi.Router
+	if cfg.AdminAddress != "" {
+		adminRouter = chi.NewRouter()
+	}
 	deps, err := route.NewDeps(
 		dep.cfg,
 		dep.log.With("component", "restHTTP.routes"),
 		r,
+		adminRouter,
 		dep.handlers,
+		dep.metrics,
+		dep.probe,
+		dep.rateStore,
+		dep.reloader,
 	)
 	if err != nil {
 		dep.log.Error("failed to create routes dependencies", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to create routes dependencies: %w", err)
 	}
 	route.NewRouter(deps)
-	srv := &http.Server{
-		Handler:           r,
-		Addr:              dep.cfg.HTTPServer.Address,
-		ReadTimeout:       dep.cfg.HTTPServer.ReadTimeout,
-		ReadHeaderTimeout: dep.cfg.HTTPServer.ReadTimeout,
-		WriteTimeout:      dep.cfg.HTTPServer.WriteTimeout,
-		IdleTimeout:       dep.cfg.HTTPServer.IdleTimeout,
-		MaxHeaderBytes:    dep.cfg.HTTPServer.MaxHeaderBytes,
+
+	s := &Server{log: dep.log, reloadInterval: cfg.TLS.ReloadInterval}
+	s.httpServer = newHTTPServer(cfg, r, cfg.Address)
+	if cfg.TLS.Enabled {
+		s.certs, err = certreload.New(cfg.TLS.CertFile, cfg.TLS.KeyFile, dep.log.With("component", "tls"))
+		if err != nil {
+			return nil, fmt.Errorf("http_server.tls: %w", err)
+		}
+		s.httpServer.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.certs.GetCertificate,
+		}
 	}
+	if adminRouter != nil {
+		s.adminServer = newHTTPServer(cfg, adminRouter, cfg.AdminAddress)
+	}
+	return s, nil
+}
 
-	return &Server{
-		httpServer: srv,
-		log:        dep.log,
-	}, nil
+func newHTTPServer(cfg config.HTTPServer, h http.Handler, addr string) *http.Server {
+	// HTTP/2 поверх TLS есть всегда, h2c — только по настройке
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(cfg.H2C)
+	return &http.Server{
+		Handler:           h,
+		Addr:              addr,
+		Protocols:         protocols,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
 }
 
+// Run обслуживает основной и, если задан, админский адрес, пока оба не
+// остановит Shutdown. Ошибка одного из них возвращается сразу.
 func (s *Server) Run() error {
 	s.log.Info("http server starting",
 		slog.String("address", s.httpServer.Addr),
+		slog.Bool("tls", s.certs != nil),
 		slog.String("read_timeout", s.httpServer.ReadTimeout.String()),
 		slog.String("write_timeout", s.httpServer.WriteTimeout.String()),
 		slog.String("idle_timeout", s.httpServer.IdleTimeout.String()),
 	)
 
-	if err := s.httpServer.ListenAndServe(); err != nil {
+	ln, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
 		s.log.Error("http server error", slog.Any("error", err))
 		return err
 	}
+	var adminLn net.Listener
+	if s.adminServer != nil {
+		adminLn, err = listen(s.adminServer.Addr)
+		if err != nil {
+			_ = ln.Close()
+			s.log.Error("admin listener error", slog.Any("error", err))
+			return err
+		}
+		s.log.Info("admin listener started", slog.String("address", s.adminServer.Addr))
+	}
 
-	return nil
+	errs := make(chan error, 2)
+	servers := 1
+	if s.certs != nil {
+		// сертификат перечитывается, пока Run не вернётся
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.certs.Watch(ctx, s.reloadInterval)
+		go func() { errs <- s.httpServer.ServeTLS(ln, "", "") }()
+	} else {
+		go func() { errs <- s.httpServer.Serve(ln) }()
+	}
+	if adminLn != nil {
+		servers++
+		go func() { errs <- s.adminServer.Serve(adminLn) }()
+	}
+
+	for range servers {
+		if err = <-errs; !errors.Is(err, http.ErrServerClosed) {
+			s.log.Error("http server error", slog.Any("error", err))
+			return err
+		}
+	}
+	return err
+}
+
+// listen: адрес "unix:/path" — unix-сокет, остальные — TCP.
+func listen(addr string) (net.Listener, error) {
+	path, ok := strings.CutPrefix(addr, "unix:")
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+	// сокет мог остаться от прошлого запуска, упавшего без Shutdown
+	if st, err := os.Stat(path); err == nil && st.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	return net.Listen("unix", path)
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.log.Info("http server shutdown initiated")
-	return s.httpServer.Shutdown(ctx)
+	var adminErr error
+	if s.adminServer != nil {
+		adminErr = s.adminServer.Shutdown(ctx)
+	}
+	return errors.Join(s.httpServer.Shutdown(ctx), adminErr)
 }
 
 func (s *Server) Handler() http.Handler {
@@ -7,14 +7,15 @@ import (
 	"log/slog"
 
 	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
 	"github.com/Neimess/zorkin-store-project/internal/service/category"
 	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 )
 
 type AttributeRepository interface {
-	SaveBatch(ctx context.Context, attrs []attrDom.Attribute) error
 	Save(ctx context.Context, attr *attrDom.Attribute) error
 	GetByID(ctx context.Context, id int64) (*attrDom.Attribute, error)
 	FindByCategory(ctx context.Context, categoryID int64) ([]attrDom.Attribute, error)
@@ -25,25 +26,30 @@ type AttributeRepository interface {
 type Deps struct {
 	repoAttr AttributeRepository
 	repoCat  category.CategoryRepository
+	tx       utils.Transactor
 	log      *slog.Logger
 }
 
-func NewDeps(repoAttr AttributeRepository, repoCat category.CategoryRepository, log *slog.Logger) (*Deps, error) {
+func NewDeps(repoAttr AttributeRepository, repoCat category.CategoryRepository, tx utils.Transactor, log *slog.Logger) (*Deps, error) {
 	if repoAttr == nil {
 		return nil, errors.New("attribute service: missing AttributeRepository")
 	}
 	if repoCat == nil {
 		return nil, errors.New("attribute service: missing CategoryRepository")
 	}
+	if tx == nil {
+		return nil, errors.New("attribute service: missing Transactor")
+	}
 	if log == nil {
 		return nil, errors.New("attribute service: missing logger")
 	}
-	return &Deps{repoAttr: repoAttr, repoCat: repoCat, log: log.With("component", "service.attribute")}, nil
+	return &Deps{repoAttr: repoAttr, repoCat: repoCat, tx: tx, log: log.With("component", "service.attribute")}, nil
 }
 
 type Service struct {
 	repoAttr AttributeRepository
 	repoCat  category.CategoryRepository
+	tx       utils.Transactor
 	log      *slog.Logger
 }
 
@@ -51,34 +57,62 @@ func New(d *Deps) *Service {
 	return &Service{
 		repoAttr: d.repoAttr,
 		repoCat:  d.repoCat,
+		tx:       d.tx,
 		log:      d.log,
 	}
 }
 
-func (s *Service) CreateAttributesBatch(ctx context.Context, categoryID int64, attrs []attrDom.Attribute) error {
-	if len(attrs) == 0 {
-		return attrDom.ErrBatchEmpty
+// Batch применяет пакет операций над атрибутами категории categoryID.
+// Результат — по одному на операцию; с atomic пакет выполняется целиком или не выполняется.
+func (s *Service) Batch(ctx context.Context, categoryID int64, items []batch.Item[*attrDom.Attribute], atomic bool) ([]batch.Result[*attrDom.Attribute], error) {
+	ctx, span := telemetry.Start(ctx, "service.attribute.Batch")
+	defer span.End()
+
+	if err := batch.Validate(items); err != nil {
+		return nil, err
 	}
 	if err := s.ensureCategory(ctx, categoryID); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.repoAttr.SaveBatch(ctx, attrs); err != nil {
-		s.log.Error("SaveBatch failed", slog.Any("error", err))
-		return utils.ErrorHandler(s.log, "service.attribute.CreateAttributesBatch", err, map[error]error{
-			der.ErrConflict: attrDom.ErrAttributeAlreadyExists,
-		})
+	results, err := utils.RunBatch(ctx, s.tx, items, atomic, func(ctx context.Context, it batch.Item[*attrDom.Attribute]) (*attrDom.Attribute, error) {
+		// у атрибутов нет version: ожидаемую версию нечем проверить
+		if it.Version != 0 {
+			return nil, batch.ErrVersionNotSupported
+		}
+		switch it.Op {
+		case batch.OpCreate:
+			it.Value.CategoryID = categoryID
+			return s.save(ctx, it.Value)
+		case batch.OpUpdate:
+			it.Value.ID, it.Value.CategoryID = it.ID, categoryID
+			if err := it.Value.Validate(); err != nil {
+				return nil, err
+			}
+			return s.update(ctx, it.Value)
+		default:
+			return nil, s.DeleteAttribute(ctx, it.ID)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.attribute.Batch: %w", err)
 	}
 
-	s.log.Info("CreateAttributesBatch succeeded", slog.Int("count", len(attrs)))
-	return nil
+	s.log.Info("Batch finished", slog.Int64("categoryID", categoryID), slog.Int("count", len(results)), slog.Bool("atomic", atomic))
+	return results, nil
 }
 
 func (s *Service) CreateAttribute(ctx context.Context, categoryID int64, a *attrDom.Attribute) (*attrDom.Attribute, error) {
+	ctx, span := telemetry.Start(ctx, "service.attribute.CreateAttribute")
+	defer span.End()
+
 	if err := s.ensureCategory(ctx, categoryID); err != nil {
 		return nil, err
 	}
+	return s.save(ctx, a)
+}
 
+func (s *Service) save(ctx context.Context, a *attrDom.Attribute) (*attrDom.Attribute, error) {
 	if err := s.repoAttr.Save(ctx, a); err != nil {
 		s.log.Error("Save failed", slog.Any("error", err))
 		return nil, utils.ErrorHandler(s.log, "service.attribute.CreateAttribute", err, map[error]error{
@@ -91,6 +125,9 @@ func (s *Service) CreateAttribute(ctx context.Context, categoryID int64, a *attr
 }
 
 func (s *Service) GetAttribute(ctx context.Context, categoryID, id int64) (*attrDom.Attribute, error) {
+	ctx, span := telemetry.Start(ctx, "service.attribute.GetAttribute")
+	defer span.End()
+
 	s.log.Debug("GetAttribute", slog.Int64("categoryID", categoryID), slog.Int64("id", id))
 
 	if err := s.ensureCategory(ctx, categoryID); err != nil {
@@ -107,6 +144,9 @@ func (s *Service) GetAttribute(ctx context.Context, categoryID, id int64) (*attr
 }
 
 func (s *Service) ListAttributes(ctx context.Context, categoryID int64) ([]attrDom.Attribute, error) {
+	ctx, span := telemetry.Start(ctx, "service.attribute.ListAttributes")
+	defer span.End()
+
 	s.log.Debug("ListAttributes", slog.Int64("categoryID", categoryID))
 
 	if err := s.ensureCategory(ctx, categoryID); err != nil {
@@ -123,6 +163,9 @@ func (s *Service) ListAttributes(ctx context.Context, categoryID int64) ([]attrD
 }
 
 func (s *Service) UpdateAttribute(ctx context.Context, a *attrDom.Attribute) (*attrDom.Attribute, error) {
+	ctx, span := telemetry.Start(ctx, "service.attribute.UpdateAttribute")
+	defer span.End()
+
 	if err := a.Validate(); err != nil {
 		return nil, err
 	}
@@ -130,7 +173,10 @@ func (s *Service) UpdateAttribute(ctx context.Context, a *attrDom.Attribute) (*a
 	if err := s.ensureCategory(ctx, a.CategoryID); err != nil {
 		return nil, err
 	}
+	return s.update(ctx, a)
+}
 
+func (s *Service) update(ctx context.Context, a *attrDom.Attribute) (*attrDom.Attribute, error) {
 	updated, err := s.repoAttr.Update(ctx, a)
 	if err != nil {
 		s.log.Error("Update failed", slog.Any("error", err))
@@ -143,6 +189,9 @@ func (s *Service) UpdateAttribute(ctx context.Context, a *attrDom.Attribute) (*a
 }
 
 func (s *Service) DeleteAttribute(ctx context.Context, id int64) error {
+	ctx, span := telemetry.Start(ctx, "service.attribute.DeleteAttribute")
+	defer span.End()
+
 	s.log.Debug("DeleteAttribute", slog.Int64("id", id))
 
 	if err := s.repoAttr.Delete(ctx, id); err != nil {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
 	mocks "github.com/Neimess/zorkin-store-project/internal/service/attribute/mocks"
 	catMocks "github.com/Neimess/zorkin-store-project/internal/service/category/mocks"
@@ -33,7 +34,7 @@ func (s *ServiceTestSuite) SetupTest() {
 	s.repoAttr = new(mocks.MockAttributeRepository)
 	s.repoCat = new(catMocks.MockCategoryRepository)
 
-	deps, err := NewDeps(s.repoAttr, s.repoCat, slog.New(slog.DiscardHandler))
+	deps, err := NewDeps(s.repoAttr, s.repoCat, &fakeTx{}, slog.New(slog.DiscardHandler))
 	s.Require().NoError(err)
 	s.svc = New(deps)
 }
@@ -52,81 +53,95 @@ func (s *ServiceTestSuite) stubCatNotFound(id int64) {
 		Once()
 }
 
-// ─── CreateAttributesBatch ────────────────────────────────────────────────────
+// ─── Batch ────────────────────────────────────────────────────────────────────
 
-func (s *ServiceTestSuite) TestCreateAttributesBatch() {
-	batch := []attrDom.Attribute{
-		{Name: "A1", CategoryID: 1},
-		{Name: "A2", CategoryID: 1},
-	}
+// fakeTx выполняет fn без настоящей транзакции и запоминает её исход.
+type fakeTx struct {
+	calls int
+	err   error
+}
 
-	cases := []struct {
-		name    string
-		catID   int64
-		attrs   []attrDom.Attribute
-		setup   func()
-		wantErr error
-	}{
-		{
-			name:  "success",
-			catID: 1,
-			attrs: batch,
-			setup: func() {
-				s.stubCatFound(1)
-				s.repoAttr.
-					On("SaveBatch", mock.Anything, batch).
-					Return(nil).
-					Once()
-			},
-			wantErr: nil,
-		},
-		{
-			name:    "empty slice",
-			catID:   1,
-			attrs:   []attrDom.Attribute{},
-			setup:   func() {},
-			wantErr: attrDom.ErrBatchEmpty,
-		},
-		{
-			name:  "category not found",
-			catID: 999,
-			attrs: batch,
-			setup: func() {
-				s.stubCatNotFound(999)
-			},
-			wantErr: catDom.ErrCategoryNotFound,
-		},
-		{
-			name:  "saving conflict",
-			catID: 1,
-			attrs: batch,
-			setup: func() {
-				s.stubCatFound(1)
-				s.repoAttr.
-					On("SaveBatch", mock.Anything, batch).
-					Return(fmt.Errorf("%w", der.ErrConflict)).
-					Once()
-			},
-			wantErr: attrDom.ErrAttributeAlreadyExists,
-		},
+func (f *fakeTx) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	f.err = fn(ctx)
+	return f.err
+}
+
+func (s *ServiceTestSuite) TestBatch() {
+	ctx := context.Background()
+	items := func() []batch.Item[*attrDom.Attribute] {
+		return []batch.Item[*attrDom.Attribute]{
+			{Op: batch.OpCreate, Value: &attrDom.Attribute{Name: "A1"}},
+			{Op: batch.OpUpdate, ID: 7, Value: &attrDom.Attribute{Name: "A2"}},
+			{Op: batch.OpDelete, ID: 8},
+		}
 	}
 
-	for _, tc := range cases {
-		s.Run(tc.name, func() {
-			s.T().Parallel()
-			tc.setup()
-			err := s.svc.CreateAttributesBatch(context.Background(), tc.catID, tc.attrs)
+	s.Run("independent operations", func() {
+		s.SetupTest()
+		s.stubCatFound(1)
+		s.repoAttr.On("Save", mock.Anything, &attrDom.Attribute{Name: "A1", CategoryID: 1}).
+			Run(func(args mock.Arguments) { args.Get(1).(*attrDom.Attribute).ID = 10 }).
+			Return(nil).Once()
+		s.repoAttr.On("Update", mock.Anything, &attrDom.Attribute{ID: 7, Name: "A2", CategoryID: 1}).
+			Return(nil, der.ErrNotFound).Once()
+		s.repoAttr.On("Delete", mock.Anything, int64(8)).Return(nil).Once()
 
-			if tc.wantErr != nil {
-				assert.ErrorIs(s.T(), err, tc.wantErr)
-			} else {
-				assert.NoError(s.T(), err)
-			}
+		res, err := s.svc.Batch(ctx, 1, items(), false)
+		s.Require().NoError(err)
+		s.Require().Len(res, 3)
+		s.Equal(batch.StatusOK, res[0].Status)
+		s.Equal(int64(10), res[0].Value.ID)
+		s.Equal(batch.StatusFailed, res[1].Status)
+		s.ErrorIs(res[1].Err, attrDom.ErrAttributeNotFound)
+		s.Equal(batch.StatusOK, res[2].Status)
+		s.Zero(s.svc.tx.(*fakeTx).calls)
+		s.repoAttr.AssertExpectations(s.T())
+	})
 
-			s.repoCat.AssertExpectations(s.T())
-			s.repoAttr.AssertExpectations(s.T())
-		})
-	}
+	s.Run("atomic rolls back on first failure", func() {
+		s.SetupTest()
+		s.stubCatFound(1)
+		s.repoAttr.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+		s.repoAttr.On("Update", mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+
+		res, err := s.svc.Batch(ctx, 1, items(), true)
+		s.Require().NoError(err)
+		s.Equal([]batch.Status{batch.StatusRolledBack, batch.StatusFailed, batch.StatusSkipped},
+			[]batch.Status{res[0].Status, res[1].Status, res[2].Status})
+		s.Nil(res[0].Value)
+		tx := s.svc.tx.(*fakeTx)
+		s.Equal(1, tx.calls)
+		s.ErrorIs(tx.err, attrDom.ErrAttributeNotFound)
+		s.repoAttr.AssertExpectations(s.T())
+	})
+
+	s.Run("atomic with invalid item runs nothing", func() {
+		s.SetupTest()
+		s.stubCatFound(1)
+		list := items()
+		list[2].ID = 0
+
+		res, err := s.svc.Batch(ctx, 1, list, true)
+		s.Require().NoError(err)
+		s.Equal(batch.StatusSkipped, res[0].Status)
+		s.ErrorIs(res[2].Err, batch.ErrMissingID)
+		s.Zero(s.svc.tx.(*fakeTx).calls)
+		s.repoAttr.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+	})
+
+	s.Run("empty", func() {
+		s.SetupTest()
+		_, err := s.svc.Batch(ctx, 1, nil, false)
+		s.ErrorIs(err, batch.ErrEmpty)
+	})
+
+	s.Run("category not found", func() {
+		s.SetupTest()
+		s.stubCatNotFound(999)
+		_, err := s.svc.Batch(ctx, 999, items(), false)
+		s.ErrorIs(err, catDom.ErrCategoryNotFound)
+	})
 }
 
 // ─── UpdateAttribute ─────────────────────────────────────────────────────---
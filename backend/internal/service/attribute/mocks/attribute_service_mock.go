@@ -288,63 +288,6 @@ func (_c *MockAttributeRepository_Save_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// SaveBatch provides a mock function for the type MockAttributeRepository
-func (_mock *MockAttributeRepository) SaveBatch(ctx context.Context, attrs []attr.Attribute) error {
-	ret := _mock.Called(ctx, attrs)
-
-	if len(ret) == 0 {
-		panic("no return value specified for SaveBatch")
-	}
-
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []attr.Attribute) error); ok {
-		r0 = returnFunc(ctx, attrs)
-	} else {
-		r0 = ret.Error(0)
-	}
-	return r0
-}
-
-// MockAttributeRepository_SaveBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBatch'
-type MockAttributeRepository_SaveBatch_Call struct {
-	*mock.Call
-}
-
-// SaveBatch is a helper method to define mock.On call
-//   - ctx context.Context
-//   - attrs []attr.Attribute
-func (_e *MockAttributeRepository_Expecter) SaveBatch(ctx interface{}, attrs interface{}) *MockAttributeRepository_SaveBatch_Call {
-	return &MockAttributeRepository_SaveBatch_Call{Call: _e.mock.On("SaveBatch", ctx, attrs)}
-}
-
-func (_c *MockAttributeRepository_SaveBatch_Call) Run(run func(ctx context.Context, attrs []attr.Attribute)) *MockAttributeRepository_SaveBatch_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 []attr.Attribute
-		if args[1] != nil {
-			arg1 = args[1].([]attr.Attribute)
-		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockAttributeRepository_SaveBatch_Call) Return(err error) *MockAttributeRepository_SaveBatch_Call {
-	_c.Call.Return(err)
-	return _c
-}
-
-func (_c *MockAttributeRepository_SaveBatch_Call) RunAndReturn(run func(ctx context.Context, attrs []attr.Attribute) error) *MockAttributeRepository_SaveBatch_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // Update provides a mock function for the type MockAttributeRepository
 func (_mock *MockAttributeRepository) Update(ctx context.Context, attr1 *attr.Attribute) (*attr.Attribute, error) {
 	ret := _mock.Called(ctx, attr1)
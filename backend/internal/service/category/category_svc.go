@@ -8,12 +8,14 @@ import (
 	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
 	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 )
 
 type CategoryRepository interface {
 	Create(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
 	GetByID(ctx context.Context, id int64) (*catDom.Category, error)
 	Update(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
+	Patch(ctx context.Context, p *catDom.Patch) (*catDom.Category, error)
 	Delete(ctx context.Context, id int64) error
 	List(ctx context.Context) ([]catDom.Category, error)
 }
@@ -43,6 +45,9 @@ func New(d *Deps) *Service {
 }
 
 func (s *Service) CreateCategory(ctx context.Context, cat *catDom.Category) (*catDom.Category, error) {
+	ctx, span := telemetry.Start(ctx, "service.category.CreateCategory")
+	defer span.End()
+
 	if err := cat.Validate(); err != nil {
 		return nil, err
 	}
@@ -58,6 +63,9 @@ func (s *Service) CreateCategory(ctx context.Context, cat *catDom.Category) (*ca
 }
 
 func (s *Service) GetCategory(ctx context.Context, id int64) (*catDom.Category, error) {
+	ctx, span := telemetry.Start(ctx, "service.category.GetCategory")
+	defer span.End()
+
 	cat, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, utils.ErrorHandler(s.log, "service.category.GetCategory", err, map[error]error{
@@ -68,6 +76,9 @@ func (s *Service) GetCategory(ctx context.Context, id int64) (*catDom.Category,
 }
 
 func (s *Service) UpdateCategory(ctx context.Context, cat *catDom.Category) (*catDom.Category, error) {
+	ctx, span := telemetry.Start(ctx, "service.category.UpdateCategory")
+	defer span.End()
+
 	if err := cat.Validate(); err != nil {
 		return nil, err
 	}
@@ -75,24 +86,59 @@ func (s *Service) UpdateCategory(ctx context.Context, cat *catDom.Category) (*ca
 	updated, err := s.repo.Update(ctx, cat)
 	if err != nil {
 		return nil, utils.ErrorHandler(s.log, "service.category.UpdateCategory", err, map[error]error{
-			catDom.ErrCategoryNotFound: catDom.ErrCategoryNotFound,
+			catDom.ErrCategoryNotFound:   catDom.ErrCategoryNotFound,
+			app_error.ErrVersionConflict: app_error.ErrVersionConflict,
+		})
+	}
+	return updated, nil
+}
+
+// PatchCategory записывает только изменённые поля категории.
+func (s *Service) PatchCategory(ctx context.Context, p *catDom.Patch) (*catDom.Category, error) {
+	ctx, span := telemetry.Start(ctx, "service.category.PatchCategory")
+	defer span.End()
+
+	if p.Empty() {
+		return s.GetCategory(ctx, p.ID)
+	}
+	if p.Name.Set {
+		cat := catDom.Category{Name: p.Name.Value}
+		if err := cat.Validate(); err != nil {
+			return nil, err
+		}
+		p.Name.Value = cat.Name
+	}
+
+	updated, err := s.repo.Patch(ctx, p)
+	if err != nil {
+		return nil, utils.ErrorHandler(s.log, "service.category.PatchCategory", err, map[error]error{
+			app_error.ErrVersionConflict: app_error.ErrVersionConflict,
+			app_error.ErrNotFound:        catDom.ErrCategoryNotFound,
+			app_error.ErrConflict:        catDom.ErrCategoryNameExists,
 		})
 	}
 	return updated, nil
 }
 
 func (s *Service) DeleteCategory(ctx context.Context, id int64) error {
+	ctx, span := telemetry.Start(ctx, "service.category.DeleteCategory")
+	defer span.End()
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		return utils.ErrorHandler(s.log, "service.category.DeleteCategory", err, map[error]error{
-			catDom.ErrCategoryNotFound: nil,
-			catDom.ErrCategoryInUse:    catDom.ErrCategoryInUse,
+			catDom.ErrCategoryNotFound:   nil,
+			catDom.ErrCategoryInUse:      catDom.ErrCategoryInUse,
+			app_error.ErrVersionConflict: app_error.ErrVersionConflict,
 		})
 	}
 	return nil
 }
 
 func (s *Service) ListCategories(ctx context.Context) ([]catDom.Category, error) {
+	ctx, span := telemetry.Start(ctx, "service.category.ListCategories")
+	defer span.End()
+
 	cats, err := s.repo.List(ctx)
 	if err != nil {
 		return nil, utils.ErrorHandler(s.log, "service.category.ListCategories", err, nil)
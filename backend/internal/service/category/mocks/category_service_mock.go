@@ -293,6 +293,74 @@ func (_c *MockCategoryRepository_List_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// Patch provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) Patch(ctx context.Context, p *category.Patch) (*category.Category, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *category.Patch) (*category.Category, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *category.Patch) *category.Category); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *category.Patch) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCategoryRepository_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockCategoryRepository_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *category.Patch
+func (_e *MockCategoryRepository_Expecter) Patch(ctx interface{}, p interface{}) *MockCategoryRepository_Patch_Call {
+	return &MockCategoryRepository_Patch_Call{Call: _e.mock.On("Patch", ctx, p)}
+}
+
+func (_c *MockCategoryRepository_Patch_Call) Run(run func(ctx context.Context, p *category.Patch)) *MockCategoryRepository_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *category.Patch
+		if args[1] != nil {
+			arg1 = args[1].(*category.Patch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_Patch_Call) Return(category1 *category.Category, err error) *MockCategoryRepository_Patch_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_Patch_Call) RunAndReturn(run func(ctx context.Context, p *category.Patch) (*category.Category, error)) *MockCategoryRepository_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockCategoryRepository
 func (_mock *MockCategoryRepository) Update(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	ret := _mock.Called(ctx, cat)
@@ -8,6 +8,7 @@ import (
 	domCoeff "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
 	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 )
 
 type CoefficientRepository interface {
@@ -15,6 +16,7 @@ type CoefficientRepository interface {
 	Get(ctx context.Context, id int64) (*domCoeff.Coefficient, error)
 	List(ctx context.Context) ([]domCoeff.Coefficient, error)
 	Update(ctx context.Context, c *domCoeff.Coefficient) (*domCoeff.Coefficient, error)
+	Patch(ctx context.Context, p *domCoeff.Patch) (*domCoeff.Coefficient, error)
 	Delete(ctx context.Context, id int64) error
 }
 
@@ -48,6 +50,9 @@ func New(d *Deps) *Service {
 func (s *Service) Create(ctx context.Context, c *domCoeff.Coefficient) (*domCoeff.Coefficient, error) {
 	const op = "service.coefficients.Create"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	if err := c.Validate(); err != nil {
 		return nil, err
 	}
@@ -64,6 +69,9 @@ func (s *Service) Create(ctx context.Context, c *domCoeff.Coefficient) (*domCoef
 func (s *Service) Get(ctx context.Context, id int64) (*domCoeff.Coefficient, error) {
 	const op = "service.coefficients.Get"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	res, err := s.repo.Get(ctx, id)
 	if err != nil {
 		mapping := map[error]error{
@@ -81,14 +89,46 @@ func (s *Service) List(ctx context.Context) ([]domCoeff.Coefficient, error) {
 func (s *Service) Update(ctx context.Context, c *domCoeff.Coefficient) (*domCoeff.Coefficient, error) {
 	const op = "service.coefficients.Update"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	if err := c.Validate(); err != nil {
 		return nil, err
 	}
 	res, err := s.repo.Update(ctx, c)
 	if err != nil {
 		mapping := map[error]error{
-			der.ErrNotFound: domCoeff.ErrCoefficientNotFound,
-			der.ErrConflict: domCoeff.ErrCoefficientAlreadyExists,
+			der.ErrNotFound:        domCoeff.ErrCoefficientNotFound,
+			der.ErrConflict:        domCoeff.ErrCoefficientAlreadyExists,
+			der.ErrVersionConflict: der.ErrVersionConflict,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	return res, nil
+}
+
+// Patch записывает только изменённые поля коэффициента.
+func (s *Service) Patch(ctx context.Context, p *domCoeff.Patch) (*domCoeff.Coefficient, error) {
+	const op = "service.coefficients.Patch"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if p.Empty() {
+		return s.Get(ctx, p.ID)
+	}
+	if p.Name.Set {
+		c := domCoeff.Coefficient{Name: p.Name.Value}
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	res, err := s.repo.Patch(ctx, p)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrVersionConflict: der.ErrVersionConflict,
+			der.ErrNotFound:        domCoeff.ErrCoefficientNotFound,
+			der.ErrConflict:        domCoeff.ErrCoefficientAlreadyExists,
 		}
 		return nil, utils.ErrorHandler(log, op, err, mapping)
 	}
@@ -98,10 +138,14 @@ func (s *Service) Update(ctx context.Context, c *domCoeff.Coefficient) (*domCoef
 func (s *Service) Delete(ctx context.Context, id int64) error {
 	const op = "service.coefficients.Delete"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		mapping := map[error]error{
-			der.ErrNotFound: domCoeff.ErrCoefficientNotFound,
+			der.ErrNotFound:        domCoeff.ErrCoefficientNotFound,
+			der.ErrVersionConflict: der.ErrVersionConflict,
 		}
 		return utils.ErrorHandler(log, op, err, mapping)
 	}
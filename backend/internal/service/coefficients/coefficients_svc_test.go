@@ -32,6 +32,10 @@ func (m *MockRepo) Update(ctx context.Context, c *domCoeff.Coefficient) (*domCoe
 	args := m.Called(ctx, c)
 	return args.Get(0).(*domCoeff.Coefficient), args.Error(1)
 }
+func (m *MockRepo) Patch(ctx context.Context, p *domCoeff.Patch) (*domCoeff.Coefficient, error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).(*domCoeff.Coefficient), args.Error(1)
+}
 func (m *MockRepo) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -0,0 +1,179 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+type CurrencyRepository interface {
+	ListRates(ctx context.Context) ([]money.Rate, error)
+	GetRate(ctx context.Context, c money.Currency) (*money.Rate, error)
+	PutRate(ctx context.Context, rate *money.Rate) (*money.Rate, error)
+	DeleteRate(ctx context.Context, c money.Currency) error
+	ListOverrides(ctx context.Context, entity money.Entity, id int64) ([]money.PriceOverride, error)
+	PutOverride(ctx context.Context, o *money.PriceOverride) (*money.PriceOverride, error)
+	DeleteOverride(ctx context.Context, entity money.Entity, id int64, c money.Currency) error
+}
+
+type Service struct {
+	repo CurrencyRepository
+	log  *slog.Logger
+}
+
+type Deps struct {
+	Repo CurrencyRepository
+	Log  *slog.Logger
+}
+
+func NewDeps(repo CurrencyRepository, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("currency: missing repository")
+	}
+	if log == nil {
+		return nil, errors.New("currency: missing logger")
+	}
+	return &Deps{Repo: repo, Log: log.With("component", "service.currency")}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo: d.Repo,
+		log:  d.Log,
+	}
+}
+
+// ListRates возвращает курсы поддерживаемых валют; базовая валюта в список не входит.
+func (s *Service) ListRates(ctx context.Context) ([]money.Rate, error) {
+	const op = "service.currency.ListRates"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	rates, err := s.repo.ListRates(ctx)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return rates, nil
+}
+
+// Supported проверяет, что в валюте можно отдать цены.
+func (s *Service) Supported(ctx context.Context, c money.Currency) error {
+	const op = "service.currency.Supported"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if c == money.Base {
+		return nil
+	}
+	if _, err := s.repo.GetRate(ctx, c); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: money.ErrUnsupportedCurrency,
+		})
+	}
+	return nil
+}
+
+// PutRate создаёт или обновляет курс валюты.
+func (s *Service) PutRate(ctx context.Context, rate *money.Rate) (*money.Rate, error) {
+	const op = "service.currency.PutRate"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.PutRate(ctx, rate)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	log.Info("exchange rate saved",
+		slog.String("currency", string(rate.Currency)), slog.String("rate", rate.Rate.String()))
+	return res, nil
+}
+
+// DeleteRate выключает валюту; ручные цены в ней удаляются вместе с курсом.
+func (s *Service) DeleteRate(ctx context.Context, c money.Currency) error {
+	const op = "service.currency.DeleteRate"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if c == money.Base {
+		return money.ErrBaseCurrency
+	}
+	if err := s.repo.DeleteRate(ctx, c); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: money.ErrRateNotFound,
+		})
+	}
+	return nil
+}
+
+// ListOverrides возвращает ручные цены сущности во всех валютах.
+func (s *Service) ListOverrides(ctx context.Context, entity money.Entity, id int64) ([]money.PriceOverride, error) {
+	const op = "service.currency.ListOverrides"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if !entity.Overridable() {
+		return nil, money.ErrInvalidEntity
+	}
+	res, err := s.repo.ListOverrides(ctx, entity, id)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: money.ErrEntityNotFound,
+		})
+	}
+	return res, nil
+}
+
+// PutOverride задаёт цену сущности в валюте вместо пересчёта по курсу.
+func (s *Service) PutOverride(ctx context.Context, o *money.PriceOverride) (*money.PriceOverride, error) {
+	const op = "service.currency.PutOverride"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.Supported(ctx, o.Price.Currency); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.PutOverride(ctx, o)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: money.ErrEntityNotFound,
+		})
+	}
+	log.Info("price override saved",
+		slog.String("entity", string(o.Entity)), slog.Int64("entity_id", o.EntityID), slog.String("price", o.Price.String()))
+	return res, nil
+}
+
+// DeleteOverride удаляет ручную цену; дальше цена считается по курсу.
+func (s *Service) DeleteOverride(ctx context.Context, entity money.Entity, id int64, c money.Currency) error {
+	const op = "service.currency.DeleteOverride"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if !entity.Overridable() {
+		return money.ErrInvalidEntity
+	}
+	if err := s.repo.DeleteOverride(ctx, entity, id, c); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: money.ErrOverrideNotFound,
+		})
+	}
+	return nil
+}
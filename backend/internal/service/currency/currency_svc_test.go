@@ -0,0 +1,115 @@
+package currency_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	curservice "github.com/Neimess/zorkin-store-project/internal/service/currency"
+	"github.com/Neimess/zorkin-store-project/internal/service/currency/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type CurrencyServiceSuite struct {
+	suite.Suite
+	svc      *curservice.Service
+	mockRepo *mocks.MockCurrencyRepository
+}
+
+func (s *CurrencyServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockCurrencyRepository(s.T())
+	deps, err := curservice.NewDeps(s.mockRepo, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = curservice.New(deps)
+}
+
+func (s *CurrencyServiceSuite) TestSupported() {
+	s.NoError(s.svc.Supported(context.Background(), money.Base))
+
+	s.mockRepo.EXPECT().GetRate(mock.Anything, money.USD).Return(nil, der.ErrNotFound).Once()
+	s.ErrorIs(s.svc.Supported(context.Background(), money.USD), money.ErrUnsupportedCurrency)
+}
+
+func (s *CurrencyServiceSuite) TestPutRate_Validation() {
+	cases := []struct {
+		name string
+		rate money.Rate
+		want error
+	}{
+		{"base currency", money.Rate{Currency: money.Base, Rate: decimal.NewFromInt(1)}, money.ErrBaseCurrency},
+		{"malformed code", money.Rate{Currency: "kz", Rate: decimal.NewFromInt(5)}, money.ErrInvalidCurrency},
+		{"zero rate", money.Rate{Currency: money.KZT}, money.ErrInvalidRate},
+		{"negative rate", money.Rate{Currency: money.KZT, Rate: decimal.NewFromInt(-5)}, money.ErrInvalidRate},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			_, err := s.svc.PutRate(context.Background(), &tc.rate)
+			s.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func (s *CurrencyServiceSuite) TestDeleteRate() {
+	s.ErrorIs(s.svc.DeleteRate(context.Background(), money.Base), money.ErrBaseCurrency)
+
+	s.mockRepo.EXPECT().DeleteRate(mock.Anything, money.EUR).Return(der.ErrNotFound).Once()
+	s.ErrorIs(s.svc.DeleteRate(context.Background(), money.EUR), money.ErrRateNotFound)
+}
+
+func (s *CurrencyServiceSuite) TestPutOverride() {
+	override := func(price string, c money.Currency) *money.PriceOverride {
+		return &money.PriceOverride{
+			Entity:   money.EntityProduct,
+			EntityID: 7,
+			Price:    money.New(decimal.RequireFromString(price), c),
+		}
+	}
+
+	s.Run("saved", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().GetRate(mock.Anything, money.KZT).Return(&money.Rate{Currency: money.KZT}, nil).Once()
+		s.mockRepo.EXPECT().PutOverride(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, o *money.PriceOverride) (*money.PriceOverride, error) {
+				return o, nil
+			}).Once()
+		_, err := s.svc.PutOverride(context.Background(), override("19000", money.KZT))
+		s.NoError(err)
+	})
+	s.Run("currency without a rate", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().GetRate(mock.Anything, money.USD).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.PutOverride(context.Background(), override("40", money.USD))
+		s.ErrorIs(err, money.ErrUnsupportedCurrency)
+	})
+	s.Run("entity not found", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().GetRate(mock.Anything, money.KZT).Return(&money.Rate{Currency: money.KZT}, nil).Once()
+		s.mockRepo.EXPECT().PutOverride(mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.PutOverride(context.Background(), override("19000", money.KZT))
+		s.ErrorIs(err, money.ErrEntityNotFound)
+	})
+	s.Run("sub-cent price", func() {
+		s.SetupTest()
+		_, err := s.svc.PutOverride(context.Background(), override("10.005", money.KZT))
+		s.ErrorIs(err, money.ErrInvalidPrice)
+	})
+	s.Run("base currency", func() {
+		s.SetupTest()
+		_, err := s.svc.PutOverride(context.Background(), override("100", money.Base))
+		s.ErrorIs(err, money.ErrBaseCurrency)
+	})
+}
+
+func (s *CurrencyServiceSuite) TestDeleteOverride_NotFound() {
+	s.mockRepo.EXPECT().DeleteOverride(mock.Anything, money.EntityService, int64(3), money.USD).Return(der.ErrNotFound).Once()
+	s.ErrorIs(s.svc.DeleteOverride(context.Background(), money.EntityService, 3, money.USD), money.ErrOverrideNotFound)
+}
+
+func TestCurrencyServiceSuite(t *testing.T) {
+	suite.Run(t, new(CurrencyServiceSuite))
+}
@@ -0,0 +1,234 @@
+package discount
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+type DiscountRepository interface {
+	ListRules(ctx context.Context) ([]domDiscount.Rule, error)
+	GetRule(ctx context.Context, id int64) (*domDiscount.Rule, error)
+	CreateRule(ctx context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error)
+	UpdateRule(ctx context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	ListPromoCodes(ctx context.Context, ruleID int64) ([]domDiscount.PromoCode, error)
+	GetPromoCode(ctx context.Context, code string) (*domDiscount.PromoCode, error)
+	CreatePromoCode(ctx context.Context, p *domDiscount.PromoCode) (*domDiscount.PromoCode, error)
+	DeletePromoCode(ctx context.Context, code string) error
+}
+
+type Service struct {
+	repo DiscountRepository
+	log  *slog.Logger
+	now  func() time.Time
+}
+
+type Deps struct {
+	Repo DiscountRepository
+	Log  *slog.Logger
+}
+
+func NewDeps(repo DiscountRepository, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("discount: missing repository")
+	}
+	if log == nil {
+		return nil, errors.New("discount: missing logger")
+	}
+	return &Deps{Repo: repo, Log: log.With("component", "service.discount")}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo: d.Repo,
+		log:  d.Log,
+		now:  time.Now,
+	}
+}
+
+func (s *Service) ListRules(ctx context.Context) ([]domDiscount.Rule, error) {
+	const op = "service.discount.ListRules"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	rules, err := s.repo.ListRules(ctx)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return rules, nil
+}
+
+func (s *Service) GetRule(ctx context.Context, id int64) (*domDiscount.Rule, error) {
+	const op = "service.discount.GetRule"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	rule, err := s.repo.GetRule(ctx, id)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domDiscount.ErrRuleNotFound,
+		})
+	}
+	return rule, nil
+}
+
+func (s *Service) CreateRule(ctx context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error) {
+	const op = "service.discount.CreateRule"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.CreateRule(ctx, r)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domDiscount.ErrTargetNotFound,
+		})
+	}
+	log.Info("discount rule created", slog.Int64("rule_id", res.ID),
+		slog.String("target", string(res.Target)), slog.Int64("target_id", res.TargetID))
+	return res, nil
+}
+
+// UpdateRule перезаписывает правило. Уже выданные промокоды остаются
+// привязанными к нему, даже если правило перестало быть PromoOnly.
+func (s *Service) UpdateRule(ctx context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error) {
+	const op = "service.discount.UpdateRule"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetRule(ctx, r.ID); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.UpdateRule(ctx, r)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domDiscount.ErrTargetNotFound,
+		})
+	}
+	log.Info("discount rule updated", slog.Int64("rule_id", res.ID))
+	return res, nil
+}
+
+// DeleteRule удаляет правило вместе с его промокодами.
+func (s *Service) DeleteRule(ctx context.Context, id int64) error {
+	const op = "service.discount.DeleteRule"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domDiscount.ErrRuleNotFound,
+		})
+	}
+	log.Info("discount rule deleted", slog.Int64("rule_id", id))
+	return nil
+}
+
+func (s *Service) ListPromoCodes(ctx context.Context, ruleID int64) ([]domDiscount.PromoCode, error) {
+	const op = "service.discount.ListPromoCodes"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if _, err := s.GetRule(ctx, ruleID); err != nil {
+		return nil, err
+	}
+	codes, err := s.repo.ListPromoCodes(ctx, ruleID)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return codes, nil
+}
+
+// CreatePromoCode выдаёт промокод к правилу. Коды выдаются только
+// к правилам с PromoOnly: остальные и так действуют для всех.
+func (s *Service) CreatePromoCode(ctx context.Context, p *domDiscount.PromoCode) (*domDiscount.PromoCode, error) {
+	const op = "service.discount.CreatePromoCode"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	rule, err := s.GetRule(ctx, p.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	if !rule.PromoOnly {
+		return nil, domDiscount.ErrPromoCodeNotPromo
+	}
+	res, err := s.repo.CreatePromoCode(ctx, p)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrConflict: domDiscount.ErrPromoCodeExists,
+			der.ErrNotFound: domDiscount.ErrRuleNotFound,
+		})
+	}
+	log.Info("promo code created", slog.String("code", res.Code), slog.Int64("rule_id", res.RuleID))
+	return res, nil
+}
+
+func (s *Service) DeletePromoCode(ctx context.Context, code string) error {
+	const op = "service.discount.DeletePromoCode"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	code, err := domDiscount.NormalizePromoCode(code)
+	if err != nil {
+		return domDiscount.ErrPromoCodeNotFound
+	}
+	if err := s.repo.DeletePromoCode(ctx, code); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domDiscount.ErrPromoCodeNotFound,
+		})
+	}
+	log.Info("promo code deleted", slog.String("code", code))
+	return nil
+}
+
+// CheckPromoCode возвращает промокод и его правило, если код сейчас
+// можно применить: правило действует и лимит погашений не исчерпан.
+func (s *Service) CheckPromoCode(ctx context.Context, code string) (*domDiscount.PromoCode, *domDiscount.Rule, error) {
+	const op = "service.discount.CheckPromoCode"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	code, err := domDiscount.NormalizePromoCode(code)
+	if err != nil {
+		return nil, nil, domDiscount.ErrPromoCodeNotFound
+	}
+	promo, err := s.repo.GetPromoCode(ctx, code)
+	if err != nil {
+		return nil, nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domDiscount.ErrPromoCodeNotFound,
+		})
+	}
+	rule, err := s.GetRule(ctx, promo.RuleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if promo.Exhausted() || !rule.ActiveAt(s.now()) {
+		return nil, nil, domDiscount.ErrPromoCodeUnavailable
+	}
+	return promo, rule, nil
+}
@@ -0,0 +1,173 @@
+package discount_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	discountSvc "github.com/Neimess/zorkin-store-project/internal/service/discount"
+	"github.com/Neimess/zorkin-store-project/internal/service/discount/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type DiscountServiceSuite struct {
+	suite.Suite
+	svc      *discountSvc.Service
+	mockRepo *mocks.MockDiscountRepository
+}
+
+func (s *DiscountServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockDiscountRepository(s.T())
+	deps, err := discountSvc.NewDeps(s.mockRepo, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = discountSvc.New(deps)
+}
+
+func validRule() domDiscount.Rule {
+	return domDiscount.Rule{
+		Name:     "Весенняя распродажа",
+		Kind:     domDiscount.KindPercent,
+		Value:    decimal.NewFromInt(10),
+		Target:   domDiscount.TargetCategory,
+		TargetID: 3,
+	}
+}
+
+func (s *DiscountServiceSuite) TestCreateRule_Validation() {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		modify func(r *domDiscount.Rule)
+		want   error
+	}{
+		{"empty name", func(r *domDiscount.Rule) { r.Name = "  " }, domDiscount.ErrEmptyName},
+		{"bad kind", func(r *domDiscount.Rule) { r.Kind = "bogo" }, domDiscount.ErrInvalidKind},
+		{"percent over 100", func(r *domDiscount.Rule) { r.Value = decimal.NewFromInt(101) }, domDiscount.ErrInvalidValue},
+		{"fixed with fractions of kopeck", func(r *domDiscount.Rule) {
+			r.Kind = domDiscount.KindFixed
+			r.Value = decimal.RequireFromString("10.005")
+		}, domDiscount.ErrInvalidValue},
+		{"bad target", func(r *domDiscount.Rule) { r.Target = "brand" }, domDiscount.ErrInvalidTarget},
+		{"ends before start", func(r *domDiscount.Rule) {
+			end := start.Add(-time.Hour)
+			r.StartsAt, r.EndsAt = &start, &end
+		}, domDiscount.ErrInvalidPeriod},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			r := validRule()
+			tc.modify(&r)
+			_, err := s.svc.CreateRule(context.Background(), &r)
+			s.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func (s *DiscountServiceSuite) TestCreateRule_MissingTarget() {
+	r := validRule()
+	s.mockRepo.EXPECT().CreateRule(mock.Anything, &r).Return(nil, der.ErrNotFound).Once()
+	_, err := s.svc.CreateRule(context.Background(), &r)
+	s.ErrorIs(err, domDiscount.ErrTargetNotFound)
+}
+
+func (s *DiscountServiceSuite) TestUpdateRule_NotFound() {
+	r := validRule()
+	r.ID = 7
+	s.mockRepo.EXPECT().GetRule(mock.Anything, int64(7)).Return(nil, der.ErrNotFound).Once()
+	_, err := s.svc.UpdateRule(context.Background(), &r)
+	s.ErrorIs(err, domDiscount.ErrRuleNotFound)
+}
+
+func (s *DiscountServiceSuite) TestCreatePromoCode() {
+	s.Run("normalizes code", func() {
+		limit := 100
+		rule := validRule()
+		rule.ID, rule.PromoOnly = 1, true
+		s.mockRepo.EXPECT().GetRule(mock.Anything, int64(1)).Return(&rule, nil).Once()
+		s.mockRepo.EXPECT().CreatePromoCode(mock.Anything, mock.MatchedBy(func(p *domDiscount.PromoCode) bool {
+			return p.Code == "SPRING10"
+		})).RunAndReturn(func(_ context.Context, p *domDiscount.PromoCode) (*domDiscount.PromoCode, error) {
+			return p, nil
+		}).Once()
+
+		res, err := s.svc.CreatePromoCode(context.Background(), &domDiscount.PromoCode{Code: " spring10 ", RuleID: 1, UsageLimit: &limit})
+		s.Require().NoError(err)
+		s.Equal("SPRING10", res.Code)
+	})
+
+	s.Run("rule is not promo-only", func() {
+		rule := validRule()
+		rule.ID = 2
+		s.mockRepo.EXPECT().GetRule(mock.Anything, int64(2)).Return(&rule, nil).Once()
+		_, err := s.svc.CreatePromoCode(context.Background(), &domDiscount.PromoCode{Code: "SUMMER", RuleID: 2})
+		s.ErrorIs(err, domDiscount.ErrPromoCodeNotPromo)
+	})
+
+	s.Run("duplicate code", func() {
+		rule := validRule()
+		rule.ID, rule.PromoOnly = 3, true
+		s.mockRepo.EXPECT().GetRule(mock.Anything, int64(3)).Return(&rule, nil).Once()
+		s.mockRepo.EXPECT().CreatePromoCode(mock.Anything, mock.Anything).Return(nil, der.ErrConflict).Once()
+		_, err := s.svc.CreatePromoCode(context.Background(), &domDiscount.PromoCode{Code: "SUMMER", RuleID: 3})
+		s.ErrorIs(err, domDiscount.ErrPromoCodeExists)
+	})
+
+	s.Run("bad limit", func() {
+		zero := 0
+		_, err := s.svc.CreatePromoCode(context.Background(), &domDiscount.PromoCode{Code: "SUMMER", RuleID: 3, UsageLimit: &zero})
+		s.ErrorIs(err, domDiscount.ErrInvalidUsageLimit)
+	})
+}
+
+func (s *DiscountServiceSuite) TestCheckPromoCode() {
+	limit := 5
+	rule := validRule()
+	rule.ID, rule.PromoOnly = 4, true
+
+	s.Run("available", func() {
+		s.mockRepo.EXPECT().GetPromoCode(mock.Anything, "WELCOME").
+			Return(&domDiscount.PromoCode{Code: "WELCOME", RuleID: 4, UsageLimit: &limit, UsedCount: 4}, nil).Once()
+		s.mockRepo.EXPECT().GetRule(mock.Anything, int64(4)).Return(&rule, nil).Once()
+
+		promo, got, err := s.svc.CheckPromoCode(context.Background(), "welcome")
+		s.Require().NoError(err)
+		s.Equal(1, *promo.Remaining())
+		s.Equal(int64(4), got.ID)
+	})
+
+	s.Run("exhausted", func() {
+		s.mockRepo.EXPECT().GetPromoCode(mock.Anything, "WELCOME").
+			Return(&domDiscount.PromoCode{Code: "WELCOME", RuleID: 4, UsageLimit: &limit, UsedCount: 5}, nil).Once()
+		s.mockRepo.EXPECT().GetRule(mock.Anything, int64(4)).Return(&rule, nil).Once()
+
+		_, _, err := s.svc.CheckPromoCode(context.Background(), "WELCOME")
+		s.ErrorIs(err, domDiscount.ErrPromoCodeUnavailable)
+	})
+
+	s.Run("expired", func() {
+		ended := rule
+		end := time.Now().Add(-time.Hour)
+		ended.EndsAt = &end
+		s.mockRepo.EXPECT().GetPromoCode(mock.Anything, "WELCOME").
+			Return(&domDiscount.PromoCode{Code: "WELCOME", RuleID: 4}, nil).Once()
+		s.mockRepo.EXPECT().GetRule(mock.Anything, int64(4)).Return(&ended, nil).Once()
+
+		_, _, err := s.svc.CheckPromoCode(context.Background(), "WELCOME")
+		s.ErrorIs(err, domDiscount.ErrPromoCodeUnavailable)
+	})
+
+	s.Run("malformed", func() {
+		_, _, err := s.svc.CheckPromoCode(context.Background(), "!")
+		s.ErrorIs(err, domDiscount.ErrPromoCodeNotFound)
+	})
+}
+
+func TestDiscountServiceSuite(t *testing.T) {
+	suite.Run(t, new(DiscountServiceSuite))
+}
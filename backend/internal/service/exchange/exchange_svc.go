@@ -0,0 +1,369 @@
+package exchange
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+type ExchangeRepository interface {
+	ImportCatalog(ctx context.Context, source string, c *domExchange.Catalog) (domExchange.ImportResult, error)
+	ImportOffers(ctx context.Context, source string, offers []domExchange.Offer) (domExchange.ImportResult, error)
+	PendingOrders(ctx context.Context, source string, limit int) ([]domExchange.Order, error)
+	MarkOrdersExported(ctx context.Context, ids []int64, at time.Time) error
+}
+
+// Codec разбирает файлы обмена и формирует выгрузку заказов (CommerceML).
+type Codec interface {
+	Decode(r io.Reader) (*domExchange.Package, error)
+	EncodeOrders(w io.Writer, orders []domExchange.Order, at time.Time) error
+}
+
+// Options — параметры обмена; пустой Login выключает обмен.
+type Options struct {
+	Login    string
+	Password string
+	// Dir — каталог для принятых файлов, у каждой сессии свой подкаталог.
+	Dir string
+	// FileLimit — максимальный размер одной части файла, 1С делит файлы по нему.
+	FileLimit int64
+	// SessionTTL — сколько живёт сессия после последнего запроса.
+	SessionTTL time.Duration
+	// OrdersLimit — сколько заказов отдаётся за один запрос 1С.
+	OrdersLimit int
+}
+
+var defaultOptions = Options{
+	Dir:         filepath.Join(os.TempDir(), "store-1c-exchange"),
+	FileLimit:   50 << 20,
+	SessionTTL:  time.Hour,
+	OrdersLimit: 500,
+}
+
+func (o Options) withDefaults() Options {
+	d := defaultOptions
+	d.Login, d.Password = o.Login, o.Password
+	if o.Dir != "" {
+		d.Dir = o.Dir
+	}
+	if o.FileLimit > 0 {
+		d.FileLimit = o.FileLimit
+	}
+	if o.SessionTTL > 0 {
+		d.SessionTTL = o.SessionTTL
+	}
+	if o.OrdersLimit > 0 {
+		d.OrdersLimit = o.OrdersLimit
+	}
+	return d
+}
+
+// session — один сеанс обмена от checkauth до конца загрузки.
+type session struct {
+	dir     string
+	expires time.Time
+	// заказы последней выгрузки ждут подтверждения mode=success
+	orders    []int64
+	queriedAt time.Time
+}
+
+type Service struct {
+	repo  ExchangeRepository
+	codec Codec
+	opts  Options
+	log   *slog.Logger
+	now   func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+type Deps struct {
+	Repo    ExchangeRepository
+	Codec   Codec
+	Options Options
+	Log     *slog.Logger
+}
+
+func NewDeps(repo ExchangeRepository, codec Codec, opts Options, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("exchange: missing repository")
+	}
+	if codec == nil {
+		return nil, errors.New("exchange: missing codec")
+	}
+	if log == nil {
+		return nil, errors.New("exchange: missing logger")
+	}
+	return &Deps{
+		Repo:    repo,
+		Codec:   codec,
+		Options: opts.withDefaults(),
+		Log:     log.With("component", "service.exchange"),
+	}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo:     d.Repo,
+		codec:    d.Codec,
+		opts:     d.Options,
+		log:      d.Log,
+		now:      time.Now,
+		sessions: make(map[string]*session),
+	}
+}
+
+// FileLimit — размер части файла, который сообщается 1С в mode=init.
+func (s *Service) FileLimit() int64 {
+	return s.opts.FileLimit
+}
+
+// CheckAuth проверяет логин и пароль 1С и открывает сессию обмена.
+func (s *Service) CheckAuth(login, password string) (string, error) {
+	const op = "service.exchange.CheckAuth"
+	log := s.log.With("op", op)
+
+	if s.opts.Login == "" ||
+		subtle.ConstantTimeCompare([]byte(login), []byte(s.opts.Login)) != 1 ||
+		subtle.ConstantTimeCompare([]byte(password), []byte(s.opts.Password)) != 1 {
+		log.Warn("exchange auth failed", slog.String("login", login))
+		return "", domExchange.ErrUnauthorized
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", utils.ErrorHandler(log, op, err, nil)
+	}
+	id := hex.EncodeToString(buf)
+	dir := filepath.Join(s.opts.Dir, id)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", utils.ErrorHandler(log, op, err, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	s.sessions[id] = &session{dir: dir, expires: s.now().Add(s.opts.SessionTTL)}
+	log.Info("exchange session opened")
+	return id, nil
+}
+
+// Init начинает загрузку: файлы прошлой загрузки в этой сессии удаляются.
+func (s *Service) Init(sessionID string) error {
+	const op = "service.exchange.Init"
+	log := s.log.With("op", op)
+
+	sess, err := s.session(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(sess.dir); err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+	if err := os.MkdirAll(sess.dir, 0o750); err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+	return nil
+}
+
+// SaveFile дописывает очередную часть файла: 1С передаёт большие файлы
+// несколькими запросами с одним и тем же именем.
+func (s *Service) SaveFile(sessionID, filename string, r io.Reader) error {
+	const op = "service.exchange.SaveFile"
+	log := s.log.With("op", op)
+
+	sess, err := s.session(sessionID)
+	if err != nil {
+		return err
+	}
+	p, err := sess.path(filename)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(r, s.opts.FileLimit+1))
+	if err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+	if n > s.opts.FileLimit {
+		return domExchange.ErrFileTooLarge
+	}
+	log.Debug("exchange file part saved", slog.String("file", filename), slog.Int64("bytes", n))
+	return nil
+}
+
+// Import загружает принятый XML-файл: каталог (import.xml) и/или
+// предложения (offers.xml). Остальные файлы (картинки) не загружаются.
+func (s *Service) Import(ctx context.Context, sessionID, filename string) (domExchange.ImportResult, error) {
+	const op = "service.exchange.Import"
+	log := s.log.With("op", op, slog.String("file", filename))
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	var res domExchange.ImportResult
+	sess, err := s.session(sessionID)
+	if err != nil {
+		return res, err
+	}
+	p, err := sess.path(filename)
+	if err != nil {
+		return res, err
+	}
+	if !strings.EqualFold(filepath.Ext(p), ".xml") {
+		return res, nil
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return res, domExchange.ErrFileNotFound
+	}
+	if err != nil {
+		return res, utils.ErrorHandler(log, op, err, nil)
+	}
+	defer f.Close()
+
+	pkg, err := s.codec.Decode(f)
+	if err != nil {
+		return res, utils.ErrorHandler(log, op, err, map[error]error{
+			domExchange.ErrMalformedDocument: err,
+			domExchange.ErrUnknownDocument:   domExchange.ErrUnknownDocument,
+		})
+	}
+	if pkg.Catalog != nil {
+		r, err := s.repo.ImportCatalog(ctx, domExchange.Source1C, pkg.Catalog)
+		if err != nil {
+			return res, utils.ErrorHandler(log, op, err, nil)
+		}
+		res.Add(r)
+	}
+	if len(pkg.Offers) > 0 {
+		r, err := s.repo.ImportOffers(ctx, domExchange.Source1C, pkg.Offers)
+		if err != nil {
+			return res, utils.ErrorHandler(log, op, err, nil)
+		}
+		res.Add(r)
+	}
+	log.Info("exchange file imported",
+		slog.Int("categories", res.Categories), slog.Int("attributes", res.Attributes),
+		slog.Int("products", res.Products), slog.Int("deleted", res.Deleted),
+		slog.Int("offers", res.Offers), slog.Int("skipped", res.Skipped))
+	return res, nil
+}
+
+// QueryOrders пишет в w заказы, ещё не выгруженные в 1С. Выгруженными они
+// считаются только после ConfirmOrders — иначе уйдут в следующий раз.
+func (s *Service) QueryOrders(ctx context.Context, sessionID string, w io.Writer) error {
+	const op = "service.exchange.QueryOrders"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	sess, err := s.session(sessionID)
+	if err != nil {
+		return err
+	}
+	at := s.now()
+	orders, err := s.repo.PendingOrders(ctx, domExchange.Source1C, s.opts.OrdersLimit)
+	if err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+	if err := s.codec.EncodeOrders(w, orders, at); err != nil {
+		return utils.ErrorHandler(log, op, err, nil)
+	}
+
+	ids := make([]int64, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.Lead.ID)
+	}
+	s.mu.Lock()
+	sess.orders, sess.queriedAt = ids, at
+	s.mu.Unlock()
+	log.Info("orders exported", slog.Int("count", len(ids)))
+	return nil
+}
+
+// ConfirmOrders отмечает заказы последней выгрузки сессии как принятые 1С.
+func (s *Service) ConfirmOrders(ctx context.Context, sessionID string) error {
+	const op = "service.exchange.ConfirmOrders"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	sess, err := s.session(sessionID)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	ids, at := sess.orders, sess.queriedAt
+	sess.orders = nil
+	s.mu.Unlock()
+
+	if err := s.repo.MarkOrdersExported(ctx, ids, at); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrCanceled: der.ErrCanceled,
+		})
+	}
+	return nil
+}
+
+// session находит живую сессию и продлевает её.
+func (s *Service) session(id string) (*session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok || s.now().After(sess.expires) {
+		return nil, domExchange.ErrSessionNotFound
+	}
+	sess.expires = s.now().Add(s.opts.SessionTTL)
+	return sess, nil
+}
+
+// pruneLocked удаляет истёкшие сессии вместе с их файлами.
+func (s *Service) pruneLocked() {
+	now := s.now()
+	for id, sess := range s.sessions {
+		if now.After(sess.expires) {
+			if err := os.RemoveAll(sess.dir); err != nil {
+				s.log.Warn("exchange session cleanup failed", slog.Any("error", err))
+			}
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// path переводит имя файла от 1С (например, "import_files/ab/abc.jpg")
+// в путь внутри каталога сессии.
+func (sess *session) path(filename string) (string, error) {
+	name := strings.ReplaceAll(strings.TrimSpace(filename), `\`, "/")
+	if name == "" || strings.HasPrefix(name, "/") {
+		return "", domExchange.ErrInvalidFilename
+	}
+	clean := path.Clean(name)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", domExchange.ErrInvalidFilename
+	}
+	return filepath.Join(sess.dir, filepath.FromSlash(clean)), nil
+}
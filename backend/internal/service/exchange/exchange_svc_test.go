@@ -0,0 +1,153 @@
+package exchange_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	exchangeSvc "github.com/Neimess/zorkin-store-project/internal/service/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/service/exchange/mocks"
+)
+
+// fakeCodec отдаёт заранее заданный пакет и запоминает разобранный текст.
+type fakeCodec struct {
+	pkg     *domExchange.Package
+	decoded string
+}
+
+func (c *fakeCodec) Decode(r io.Reader) (*domExchange.Package, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c.decoded = string(b)
+	if c.pkg == nil {
+		return nil, domExchange.ErrUnknownDocument
+	}
+	return c.pkg, nil
+}
+
+func (c *fakeCodec) EncodeOrders(w io.Writer, orders []domExchange.Order, _ time.Time) error {
+	for _, o := range orders {
+		fmt.Fprintf(w, "%d;", o.Lead.ID)
+	}
+	return nil
+}
+
+type ExchangeServiceSuite struct {
+	suite.Suite
+	svc      *exchangeSvc.Service
+	mockRepo *mocks.MockExchangeRepository
+	codec    *fakeCodec
+}
+
+func (s *ExchangeServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockExchangeRepository(s.T())
+	s.codec = &fakeCodec{}
+	deps, err := exchangeSvc.NewDeps(s.mockRepo, s.codec, exchangeSvc.Options{
+		Login: "1c", Password: "secret", Dir: s.T().TempDir(), FileLimit: 16,
+	}, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = exchangeSvc.New(deps)
+}
+
+func (s *ExchangeServiceSuite) login() string {
+	id, err := s.svc.CheckAuth("1c", "secret")
+	s.Require().NoError(err)
+	s.Require().NotEmpty(id)
+	return id
+}
+
+func (s *ExchangeServiceSuite) TestCheckAuth() {
+	_, err := s.svc.CheckAuth("1c", "wrong")
+	s.ErrorIs(err, domExchange.ErrUnauthorized)
+
+	s.ErrorIs(s.svc.Init("unknown"), domExchange.ErrSessionNotFound)
+	s.NoError(s.svc.Init(s.login()))
+}
+
+func (s *ExchangeServiceSuite) TestSaveFile() {
+	id := s.login()
+
+	s.Run("parts are appended", func() {
+		s.Require().NoError(s.svc.SaveFile(id, "import.xml", strings.NewReader("<a>")))
+		s.Require().NoError(s.svc.SaveFile(id, "import.xml", strings.NewReader("</a>")))
+
+		s.codec.pkg = &domExchange.Package{Catalog: &domExchange.Catalog{}}
+		s.mockRepo.EXPECT().ImportCatalog(mock.Anything, domExchange.Source1C, s.codec.pkg.Catalog).
+			Return(domExchange.ImportResult{Products: 1}, nil).Once()
+		res, err := s.svc.Import(context.Background(), id, "import.xml")
+		s.Require().NoError(err)
+		s.Equal(1, res.Products)
+		s.Equal("<a></a>", s.codec.decoded)
+	})
+	s.Run("path traversal", func() {
+		for _, name := range []string{"../x.xml", "/etc/passwd", `..\..\x.xml`, ""} {
+			s.ErrorIs(s.svc.SaveFile(id, name, strings.NewReader("x")), domExchange.ErrInvalidFilename, name)
+		}
+		s.NoError(s.svc.SaveFile(id, "import_files/ab/photo.jpg", strings.NewReader("jpg")))
+	})
+	s.Run("part over limit", func() {
+		s.ErrorIs(s.svc.SaveFile(id, "big.xml", strings.NewReader(strings.Repeat("x", 17))), domExchange.ErrFileTooLarge)
+	})
+	s.Run("init drops previous files", func() {
+		s.Require().NoError(s.svc.Init(id))
+		_, err := s.svc.Import(context.Background(), id, "import.xml")
+		s.ErrorIs(err, domExchange.ErrFileNotFound)
+	})
+}
+
+func (s *ExchangeServiceSuite) TestImport() {
+	id := s.login()
+
+	s.Run("images are ignored", func() {
+		res, err := s.svc.Import(context.Background(), id, "import_files/ab/photo.jpg")
+		s.NoError(err)
+		s.Zero(res)
+	})
+	s.Run("offers", func() {
+		s.Require().NoError(s.svc.SaveFile(id, "offers.xml", strings.NewReader("<o/>")))
+		s.codec.pkg = &domExchange.Package{Offers: []domExchange.Offer{{ProductID: "i-1"}}}
+		s.mockRepo.EXPECT().ImportOffers(mock.Anything, domExchange.Source1C, s.codec.pkg.Offers).
+			Return(domExchange.ImportResult{Offers: 1}, nil).Once()
+		res, err := s.svc.Import(context.Background(), id, "offers.xml")
+		s.NoError(err)
+		s.Equal(1, res.Offers)
+	})
+	s.Run("unknown document", func() {
+		s.codec.pkg = nil
+		_, err := s.svc.Import(context.Background(), id, "offers.xml")
+		s.ErrorIs(err, domExchange.ErrUnknownDocument)
+	})
+}
+
+func (s *ExchangeServiceSuite) TestOrders() {
+	id := s.login()
+	orders := []domExchange.Order{{Lead: lead.Lead{ID: 7}}, {Lead: lead.Lead{ID: 9}}}
+	s.mockRepo.EXPECT().PendingOrders(mock.Anything, domExchange.Source1C, 500).Return(orders, nil).Once()
+
+	var buf bytes.Buffer
+	s.Require().NoError(s.svc.QueryOrders(context.Background(), id, &buf))
+	s.Equal("7;9;", buf.String())
+
+	s.mockRepo.EXPECT().MarkOrdersExported(mock.Anything, []int64{7, 9}, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	s.Require().NoError(s.svc.ConfirmOrders(context.Background(), id))
+
+	// повторное подтверждение ничего не отмечает
+	s.mockRepo.EXPECT().MarkOrdersExported(mock.Anything, []int64(nil), mock.AnythingOfType("time.Time")).Return(nil).Once()
+	s.NoError(s.svc.ConfirmOrders(context.Background(), id))
+}
+
+func TestExchangeServiceSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeServiceSuite))
+}
@@ -0,0 +1,310 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+type ExternalRepository interface {
+	Resolve(ctx context.Context, source string, entity domExternal.Entity, externalID string) (int64, error)
+	Bind(ctx context.Context, ref *domExternal.Ref) (*domExternal.Ref, error)
+	Unbind(ctx context.Context, source string, entity domExternal.Entity, externalID string) error
+	ListByEntity(ctx context.Context, entity domExternal.Entity, entityID int64) ([]domExternal.Ref, error)
+	Lock(ctx context.Context, source string, entity domExternal.Entity, externalID string) (func(), error)
+}
+
+// Создание и обновление идут через сервисы каталога, чтобы upsert
+// проверял данные так же, как обычные POST и PUT.
+type ProductService interface {
+	Create(ctx context.Context, p *prodDom.Product) (*prodDom.Product, error)
+	GetDetailed(ctx context.Context, id int64) (*prodDom.Product, error)
+	Update(ctx context.Context, p *prodDom.Product) (*prodDom.Product, error)
+}
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
+	GetCategory(ctx context.Context, id int64) (*catDom.Category, error)
+	UpdateCategory(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
+}
+
+type ServiceService interface {
+	Create(ctx context.Context, s *domService.Service) (*domService.Service, error)
+	Get(ctx context.Context, id int64) (*domService.Service, error)
+	Update(ctx context.Context, s *domService.Service) (*domService.Service, error)
+}
+
+type Service struct {
+	repo       ExternalRepository
+	products   ProductService
+	categories CategoryService
+	services   ServiceService
+	log        *slog.Logger
+}
+
+type Deps struct {
+	Repo       ExternalRepository
+	Products   ProductService
+	Categories CategoryService
+	Services   ServiceService
+	Log        *slog.Logger
+}
+
+func NewDeps(repo ExternalRepository, products ProductService, categories CategoryService, services ServiceService, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("external: missing repository")
+	}
+	if products == nil {
+		return nil, errors.New("external: missing product service")
+	}
+	if categories == nil {
+		return nil, errors.New("external: missing category service")
+	}
+	if services == nil {
+		return nil, errors.New("external: missing service service")
+	}
+	if log == nil {
+		return nil, errors.New("external: missing logger")
+	}
+	return &Deps{
+		Repo:       repo,
+		Products:   products,
+		Categories: categories,
+		Services:   services,
+		Log:        log.With("component", "service.external"),
+	}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo:       d.Repo,
+		products:   d.Products,
+		categories: d.Categories,
+		services:   d.Services,
+		log:        d.Log,
+	}
+}
+
+// UpsertProduct создаёт товар с внешним идентификатором или обновляет уже
+// привязанный. created сообщает, был ли товар создан.
+func (s *Service) UpsertProduct(ctx context.Context, source, externalID string, p *prodDom.Product) (*prodDom.Product, bool, error) {
+	return upsert(ctx, s, "service.external.UpsertProduct", source, domExternal.EntityProduct, externalID,
+		func() (*prodDom.Product, int64, error) {
+			res, err := s.products.Create(ctx, p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res, res.ID, nil
+		},
+		func(id int64) (*prodDom.Product, error) {
+			p.ID = id
+			return s.products.Update(ctx, p)
+		},
+		prodDom.ErrProductNotFound)
+}
+
+func (s *Service) GetProduct(ctx context.Context, source, externalID string) (*prodDom.Product, error) {
+	return get(ctx, s, "service.external.GetProduct", source, domExternal.EntityProduct, externalID,
+		func(id int64) (*prodDom.Product, error) { return s.products.GetDetailed(ctx, id) },
+		prodDom.ErrProductNotFound)
+}
+
+func (s *Service) UpsertCategory(ctx context.Context, source, externalID string, c *catDom.Category) (*catDom.Category, bool, error) {
+	return upsert(ctx, s, "service.external.UpsertCategory", source, domExternal.EntityCategory, externalID,
+		func() (*catDom.Category, int64, error) {
+			res, err := s.categories.CreateCategory(ctx, c)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res, res.ID, nil
+		},
+		func(id int64) (*catDom.Category, error) {
+			c.ID = id
+			return s.categories.UpdateCategory(ctx, c)
+		},
+		catDom.ErrCategoryNotFound)
+}
+
+func (s *Service) GetCategory(ctx context.Context, source, externalID string) (*catDom.Category, error) {
+	return get(ctx, s, "service.external.GetCategory", source, domExternal.EntityCategory, externalID,
+		func(id int64) (*catDom.Category, error) { return s.categories.GetCategory(ctx, id) },
+		catDom.ErrCategoryNotFound)
+}
+
+func (s *Service) UpsertService(ctx context.Context, source, externalID string, sv *domService.Service) (*domService.Service, bool, error) {
+	return upsert(ctx, s, "service.external.UpsertService", source, domExternal.EntityService, externalID,
+		func() (*domService.Service, int64, error) {
+			res, err := s.services.Create(ctx, sv)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res, res.ID, nil
+		},
+		func(id int64) (*domService.Service, error) {
+			sv.ID = id
+			return s.services.Update(ctx, sv)
+		},
+		domService.ErrServiceNotFound)
+}
+
+func (s *Service) GetService(ctx context.Context, source, externalID string) (*domService.Service, error) {
+	return get(ctx, s, "service.external.GetService", source, domExternal.EntityService, externalID,
+		func(id int64) (*domService.Service, error) { return s.services.Get(ctx, id) },
+		domService.ErrServiceNotFound)
+}
+
+// Bind привязывает внешний идентификатор к существующей сущности — например,
+// к товару, заведённому вручную до начала синхронизации.
+func (s *Service) Bind(ctx context.Context, ref *domExternal.Ref) (*domExternal.Ref, error) {
+	const op = "service.external.Bind"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.Bind(ctx, ref)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domExternal.ErrEntityNotFound,
+		})
+	}
+	log.Info("external id bound",
+		slog.String("source", ref.Source), slog.String("entity", string(ref.Entity)), slog.Int64("entity_id", ref.EntityID))
+	return res, nil
+}
+
+func (s *Service) Unbind(ctx context.Context, source string, entity domExternal.Entity, externalID string) error {
+	const op = "service.external.Unbind"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := (domExternal.Ref{Source: source, Entity: entity, ExternalID: externalID}).Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.Unbind(ctx, source, entity, externalID); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domExternal.ErrRefNotFound,
+		})
+	}
+	return nil
+}
+
+// List — внешние идентификаторы сущности во всех источниках.
+func (s *Service) List(ctx context.Context, entity domExternal.Entity, entityID int64) ([]domExternal.Ref, error) {
+	const op = "service.external.List"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if !entity.Valid() {
+		return nil, domExternal.ErrInvalidEntity
+	}
+	refs, err := s.repo.ListByEntity(ctx, entity, entityID)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return refs, nil
+}
+
+// upsert под блокировкой внешнего идентификатора: одновременные запросы
+// с одним идентификатором выполняются по очереди, второй обновляет
+// созданное первым. notFound — ошибка сервиса сущности, если привязанная
+// запись пропала: тогда она создаётся заново.
+//
+// Условие из database.WithPrecondition: без заголовков существующая
+// сущность не перезаписывается (ErrPreconditionRequired), If-None-Match: *
+// только создаёт, If-Match только обновляет (иначе ErrVersionConflict).
+func upsert[T any](
+	ctx context.Context, s *Service, op, source string, entity domExternal.Entity, externalID string,
+	create func() (T, int64, error), update func(id int64) (T, error), notFound error,
+) (T, bool, error) {
+	log := s.log.With("op", op, slog.String("source", source), slog.String("external_id", externalID))
+	var zero T
+
+	if err := domExternal.ValidateKey(source, externalID); err != nil {
+		return zero, false, err
+	}
+	unlock, err := s.repo.Lock(ctx, source, entity, externalID)
+	if err != nil {
+		return zero, false, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrCanceled: der.ErrCanceled,
+			der.ErrTimeout:  der.ErrTimeout,
+		})
+	}
+	defer unlock()
+
+	// условие запроса (If-Match / If-None-Match) проверяется, только если
+	// его выставил транспорт: внутренние вызовы пишут без условий
+	cond, checked := database.PreconditionFrom(ctx)
+
+	id, err := s.repo.Resolve(ctx, source, entity, externalID)
+	switch {
+	case err == nil:
+		if checked && cond == database.PreconditionNone {
+			return zero, false, der.ErrPreconditionRequired
+		}
+		if checked && cond == database.PreconditionIfNoneMatch {
+			return zero, false, der.ErrVersionConflict
+		}
+		res, err := update(id)
+		if err == nil {
+			log.Info("entity updated by external id", slog.Int64("entity_id", id))
+			return res, false, nil
+		}
+		if !errors.Is(err, notFound) {
+			return zero, false, err
+		}
+	case !errors.Is(err, der.ErrNotFound):
+		return zero, false, utils.ErrorHandler(log, op, err, nil)
+	}
+
+	// If-Match обещает, что сущность есть: создавать её нельзя
+	if checked && cond == database.PreconditionIfMatch {
+		return zero, false, der.ErrVersionConflict
+	}
+	res, id, err := create()
+	if err != nil {
+		return zero, false, err
+	}
+	if _, err := s.repo.Bind(ctx, &domExternal.Ref{Source: source, Entity: entity, ExternalID: externalID, EntityID: id}); err != nil {
+		log.Error("entity created but external id not bound", slog.Int64("entity_id", id))
+		return zero, false, utils.ErrorHandler(log, op, err, nil)
+	}
+	log.Info("entity created by external id", slog.Int64("entity_id", id))
+	return res, true, nil
+}
+
+func get[T any](
+	ctx context.Context, s *Service, op, source string, entity domExternal.Entity, externalID string,
+	fetch func(id int64) (T, error), notFound error,
+) (T, error) {
+	log := s.log.With("op", op)
+	var zero T
+
+	if err := domExternal.ValidateKey(source, externalID); err != nil {
+		return zero, err
+	}
+	id, err := s.repo.Resolve(ctx, source, entity, externalID)
+	if err != nil {
+		return zero, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domExternal.ErrRefNotFound,
+		})
+	}
+	res, err := fetch(id)
+	if errors.Is(err, notFound) {
+		return zero, domExternal.ErrRefNotFound
+	}
+	return res, err
+}
@@ -0,0 +1,218 @@
+package external_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	externalSvc "github.com/Neimess/zorkin-store-project/internal/service/external"
+	"github.com/Neimess/zorkin-store-project/internal/service/external/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+)
+
+// fakeProducts хранит товары в памяти: upsert проверяется по тому, что
+// в итоге оказалось в каталоге.
+type fakeProducts struct {
+	items  map[int64]prodDom.Product
+	nextID int64
+}
+
+func (f *fakeProducts) Create(_ context.Context, p *prodDom.Product) (*prodDom.Product, error) {
+	f.nextID++
+	p.ID = f.nextID
+	f.items[p.ID] = *p
+	return p, nil
+}
+
+func (f *fakeProducts) GetDetailed(_ context.Context, id int64) (*prodDom.Product, error) {
+	p, ok := f.items[id]
+	if !ok {
+		return nil, prodDom.ErrProductNotFound
+	}
+	return &p, nil
+}
+
+func (f *fakeProducts) Update(_ context.Context, p *prodDom.Product) (*prodDom.Product, error) {
+	if _, ok := f.items[p.ID]; !ok {
+		return nil, prodDom.ErrProductNotFound
+	}
+	f.items[p.ID] = *p
+	return p, nil
+}
+
+type noCategories struct{}
+
+func (noCategories) CreateCategory(context.Context, *catDom.Category) (*catDom.Category, error) {
+	return nil, catDom.ErrCategoryNameExists
+}
+func (noCategories) GetCategory(context.Context, int64) (*catDom.Category, error) {
+	return nil, catDom.ErrCategoryNotFound
+}
+func (noCategories) UpdateCategory(context.Context, *catDom.Category) (*catDom.Category, error) {
+	return nil, catDom.ErrCategoryNotFound
+}
+
+type noServices struct{}
+
+func (noServices) Create(context.Context, *domService.Service) (*domService.Service, error) {
+	return nil, domService.ErrServiceAlreadyExists
+}
+func (noServices) Get(context.Context, int64) (*domService.Service, error) {
+	return nil, domService.ErrServiceNotFound
+}
+func (noServices) Update(context.Context, *domService.Service) (*domService.Service, error) {
+	return nil, domService.ErrServiceNotFound
+}
+
+type ExternalServiceSuite struct {
+	suite.Suite
+	svc      *externalSvc.Service
+	mockRepo *mocks.MockExternalRepository
+	products *fakeProducts
+	unlocked int
+}
+
+func (s *ExternalServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockExternalRepository(s.T())
+	s.products = &fakeProducts{items: map[int64]prodDom.Product{}}
+	s.unlocked = 0
+	deps, err := externalSvc.NewDeps(s.mockRepo, s.products, noCategories{}, noServices{}, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = externalSvc.New(deps)
+}
+
+func (s *ExternalServiceSuite) expectLock(externalID string) {
+	s.mockRepo.EXPECT().Lock(mock.Anything, "pim", domExternal.EntityProduct, externalID).
+		Return(func() { s.unlocked++ }, nil).Once()
+}
+
+func (s *ExternalServiceSuite) TestUpsertProduct_Create() {
+	s.expectLock("sku-1")
+	s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-1").Return(0, der.ErrNotFound).Once()
+	s.mockRepo.EXPECT().Bind(mock.Anything, &domExternal.Ref{
+		Source: "pim", Entity: domExternal.EntityProduct, ExternalID: "sku-1", EntityID: 1,
+	}).RunAndReturn(func(_ context.Context, ref *domExternal.Ref) (*domExternal.Ref, error) { return ref, nil }).Once()
+
+	p, created, err := s.svc.UpsertProduct(context.Background(), "pim", "sku-1", &prodDom.Product{Name: "Плитка"})
+	s.Require().NoError(err)
+	s.True(created)
+	s.Equal(int64(1), p.ID)
+	s.Equal(1, s.unlocked)
+}
+
+func (s *ExternalServiceSuite) TestUpsertProduct_Update() {
+	s.products.items[7] = prodDom.Product{ID: 7, Name: "Старое"}
+	s.expectLock("sku-7")
+	s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-7").Return(7, nil).Once()
+
+	p, created, err := s.svc.UpsertProduct(context.Background(), "pim", "sku-7", &prodDom.Product{Name: "Новое"})
+	s.Require().NoError(err)
+	s.False(created)
+	s.Equal(int64(7), p.ID)
+	s.Equal("Новое", s.products.items[7].Name)
+	s.Len(s.products.items, 1)
+	s.Equal(1, s.unlocked)
+}
+
+func (s *ExternalServiceSuite) TestUpsertProduct_StaleBindingRecreates() {
+	s.expectLock("sku-9")
+	s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-9").Return(9, nil).Once()
+	s.mockRepo.EXPECT().Bind(mock.Anything, mock.MatchedBy(func(ref *domExternal.Ref) bool { return ref.EntityID == 1 })).
+		RunAndReturn(func(_ context.Context, ref *domExternal.Ref) (*domExternal.Ref, error) { return ref, nil }).Once()
+
+	_, created, err := s.svc.UpsertProduct(context.Background(), "pim", "sku-9", &prodDom.Product{Name: "Плитка"})
+	s.Require().NoError(err)
+	s.True(created)
+}
+
+func (s *ExternalServiceSuite) TestUpsertProduct_Precondition() {
+	bound := func() {
+		s.products.items[7] = prodDom.Product{ID: 7, Name: "Старое"}
+		s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-7").Return(7, nil).Once()
+	}
+	missing := func() {
+		s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-7").Return(0, der.ErrNotFound).Once()
+	}
+	for _, tc := range []struct {
+		name  string
+		cond  database.Precondition
+		setup func()
+		want  error
+	}{
+		{"overwrite without If-Match", database.PreconditionNone, bound, der.ErrPreconditionRequired},
+		{"If-None-Match on existing", database.PreconditionIfNoneMatch, bound, der.ErrVersionConflict},
+		{"If-Match on missing", database.PreconditionIfMatch, missing, der.ErrVersionConflict},
+	} {
+		s.Run(tc.name, func() {
+			s.expectLock("sku-7")
+			tc.setup()
+			ctx := database.WithPrecondition(context.Background(), tc.cond)
+			_, _, err := s.svc.UpsertProduct(ctx, "pim", "sku-7", &prodDom.Product{Name: "Новое"})
+			s.ErrorIs(err, tc.want)
+		})
+	}
+	s.Equal("Старое", s.products.items[7].Name)
+
+	s.Run("create without headers", func() {
+		s.expectLock("sku-8")
+		s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-8").Return(0, der.ErrNotFound).Once()
+		s.mockRepo.EXPECT().Bind(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, ref *domExternal.Ref) (*domExternal.Ref, error) { return ref, nil }).Once()
+		ctx := database.WithPrecondition(context.Background(), database.PreconditionNone)
+		_, created, err := s.svc.UpsertProduct(ctx, "pim", "sku-8", &prodDom.Product{Name: "Плитка"})
+		s.Require().NoError(err)
+		s.True(created)
+	})
+}
+
+func (s *ExternalServiceSuite) TestUpsertProduct_InvalidKey() {
+	for _, tc := range []struct {
+		source, id string
+		want       error
+	}{
+		{"", "x", domExternal.ErrInvalidSource},
+		{"PIM", "x", domExternal.ErrInvalidSource},
+		{"pim", "", domExternal.ErrInvalidExternalID},
+		{"pim", "a\nb", domExternal.ErrInvalidExternalID},
+	} {
+		_, _, err := s.svc.UpsertProduct(context.Background(), tc.source, tc.id, &prodDom.Product{})
+		s.ErrorIs(err, tc.want, tc.source+"/"+tc.id)
+	}
+}
+
+func (s *ExternalServiceSuite) TestGetProduct() {
+	s.products.items[3] = prodDom.Product{ID: 3}
+	s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "sku-3").Return(3, nil).Once()
+	p, err := s.svc.GetProduct(context.Background(), "pim", "sku-3")
+	s.Require().NoError(err)
+	s.Equal(int64(3), p.ID)
+
+	s.mockRepo.EXPECT().Resolve(mock.Anything, "pim", domExternal.EntityProduct, "nope").Return(0, der.ErrNotFound).Once()
+	_, err = s.svc.GetProduct(context.Background(), "pim", "nope")
+	s.ErrorIs(err, domExternal.ErrRefNotFound)
+}
+
+func (s *ExternalServiceSuite) TestBind() {
+	s.Run("missing entity", func() {
+		ref := &domExternal.Ref{Source: "1c", Entity: domExternal.EntityService, ExternalID: "g", EntityID: 5}
+		s.mockRepo.EXPECT().Bind(mock.Anything, ref).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.Bind(context.Background(), ref)
+		s.ErrorIs(err, domExternal.ErrEntityNotFound)
+	})
+	s.Run("invalid entity", func() {
+		_, err := s.svc.Bind(context.Background(), &domExternal.Ref{Source: "1c", Entity: "attribute", ExternalID: "g", EntityID: 5})
+		s.ErrorIs(err, domExternal.ErrInvalidEntity)
+	})
+}
+
+func TestExternalServiceSuite(t *testing.T) {
+	suite.Run(t, new(ExternalServiceSuite))
+}
@@ -0,0 +1,141 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+const DefaultTTL = 24 * time.Hour
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, key, fingerprint string, expiredBefore time.Time) (*domIdempotency.Record, bool, error)
+	Complete(ctx context.Context, key string, resp domIdempotency.Response) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Service struct {
+	repo IdempotencyRepository
+	ttl  time.Duration
+	log  *slog.Logger
+	now  func() time.Time
+
+	mu      sync.Mutex
+	purgeAt time.Time
+}
+
+type Deps struct {
+	Repo IdempotencyRepository
+	// TTL — сколько хранится ответ; повтор с тем же ключом позже выполняется заново.
+	TTL time.Duration
+	Log *slog.Logger
+}
+
+func NewDeps(repo IdempotencyRepository, ttl time.Duration, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("idempotency: missing repository")
+	}
+	if log == nil {
+		return nil, errors.New("idempotency: missing logger")
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Deps{Repo: repo, TTL: ttl, Log: log.With("component", "service.idempotency")}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo: d.Repo,
+		ttl:  d.TTL,
+		log:  d.Log,
+		now:  time.Now,
+	}
+}
+
+// Begin занимает ключ под запрос. nil, nil — запрос выполняется впервые и
+// после него нужно вызвать Complete или Release. Завершённый запрос с тем же
+// отпечатком возвращает сохранённый ответ; другой отпечаток — ErrKeyReused,
+// незавершённый — ErrInProgress.
+func (s *Service) Begin(ctx context.Context, key, fingerprint string) (*domIdempotency.Response, error) {
+	const op = "service.idempotency.Begin"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := domIdempotency.ValidateKey(key); err != nil {
+		return nil, err
+	}
+	s.purge(ctx)
+
+	rec, reserved, err := s.repo.Reserve(ctx, key, fingerprint, s.now().Add(-s.ttl))
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrCanceled: der.ErrCanceled,
+		})
+	}
+	switch {
+	case reserved:
+		return nil, nil
+	case rec.Fingerprint != fingerprint:
+		return nil, domIdempotency.ErrKeyReused
+	case !rec.Completed():
+		return nil, domIdempotency.ErrInProgress
+	}
+	log.Info("idempotent request replayed", slog.String("key", key))
+	return rec.Response, nil
+}
+
+// Complete сохраняет ответ на запрос, начатый Begin.
+func (s *Service) Complete(ctx context.Context, key string, resp domIdempotency.Response) error {
+	const op = "service.idempotency.Complete"
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repo.Complete(ctx, key, resp); err != nil {
+		return utils.ErrorHandler(s.log.With("op", op), op, err, nil)
+	}
+	return nil
+}
+
+// Release освобождает ключ, если ответ сохранять не нужно (ошибка сервера):
+// клиент сможет повторить запрос с тем же ключом.
+func (s *Service) Release(ctx context.Context, key string) error {
+	const op = "service.idempotency.Release"
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repo.Release(ctx, key); err != nil {
+		return utils.ErrorHandler(s.log.With("op", op), op, err, nil)
+	}
+	return nil
+}
+
+// purge удаляет истёкшие ключи не чаще раза в час.
+func (s *Service) purge(ctx context.Context) {
+	now := s.now()
+	s.mu.Lock()
+	if now.Before(s.purgeAt) {
+		s.mu.Unlock()
+		return
+	}
+	s.purgeAt = now.Add(time.Hour)
+	s.mu.Unlock()
+
+	n, err := s.repo.DeleteExpired(ctx, now.Add(-s.ttl))
+	if err != nil {
+		s.log.Warn("idempotency keys cleanup failed", slog.Any("error", err))
+		return
+	}
+	if n > 0 {
+		s.log.Info("expired idempotency keys deleted", slog.Int64("count", n))
+	}
+}
@@ -0,0 +1,66 @@
+package idempotency_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	idempotencySvc "github.com/Neimess/zorkin-store-project/internal/service/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/service/idempotency/mocks"
+)
+
+type IdempotencyServiceSuite struct {
+	suite.Suite
+	svc      *idempotencySvc.Service
+	mockRepo *mocks.MockIdempotencyRepository
+}
+
+func (s *IdempotencyServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockIdempotencyRepository(s.T())
+	deps, err := idempotencySvc.NewDeps(s.mockRepo, time.Hour, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = idempotencySvc.New(deps)
+	s.mockRepo.EXPECT().DeleteExpired(mock.Anything, mock.AnythingOfType("time.Time")).Return(0, nil).Maybe()
+}
+
+func (s *IdempotencyServiceSuite) TestBegin() {
+	done := &domIdempotency.Response{StatusCode: 201, Body: []byte(`{"id":1}`)}
+	cases := []struct {
+		name     string
+		rec      *domIdempotency.Record
+		reserved bool
+		want     *domIdempotency.Response
+		wantErr  error
+	}{
+		{"first request", &domIdempotency.Record{Key: "k", Fingerprint: "fp"}, true, nil, nil},
+		{"replay", &domIdempotency.Record{Key: "k", Fingerprint: "fp", Response: done}, false, done, nil},
+		{"other request", &domIdempotency.Record{Key: "k", Fingerprint: "other", Response: done}, false, nil, domIdempotency.ErrKeyReused},
+		{"in progress", &domIdempotency.Record{Key: "k", Fingerprint: "fp"}, false, nil, domIdempotency.ErrInProgress},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.mockRepo.EXPECT().Reserve(mock.Anything, "k", "fp", mock.AnythingOfType("time.Time")).
+				Return(tc.rec, tc.reserved, nil).Once()
+			got, err := s.svc.Begin(context.Background(), "k", "fp")
+			s.ErrorIs(err, tc.wantErr)
+			s.Equal(tc.want, got)
+		})
+	}
+}
+
+func (s *IdempotencyServiceSuite) TestBegin_InvalidKey() {
+	for _, key := range []string{"", strings.Repeat("a", 256), "ключ"} {
+		_, err := s.svc.Begin(context.Background(), key, "fp")
+		s.ErrorIs(err, domIdempotency.ErrInvalidKey)
+	}
+}
+
+func TestIdempotencyServiceSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceSuite))
+}
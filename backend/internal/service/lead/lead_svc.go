@@ -0,0 +1,196 @@
+package lead
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+// defaultNotifyTimeout ограничивает ожидание каналов уведомлений, чтобы
+// зависший SMTP не держал фоновую отправку и остановку сервиса.
+const defaultNotifyTimeout = 10 * time.Second
+
+type LeadRepository interface {
+	Create(ctx context.Context, l *domLead.Lead) (*domLead.Lead, error)
+	Get(ctx context.Context, id int64) (*domLead.Lead, error)
+	List(ctx context.Context, status domLead.Status) ([]domLead.Lead, error)
+	UpdateStatus(ctx context.Context, l *domLead.Lead, prev domLead.Status) error
+}
+
+type Notifier interface {
+	NotifyLead(ctx context.Context, l *domLead.Lead) error
+}
+
+type Service struct {
+	repo          LeadRepository
+	notifier      Notifier
+	notifyTimeout time.Duration
+	// notifying — уведомления, которые ещё отправляются в фоне
+	notifying sync.WaitGroup
+	log       *slog.Logger
+}
+
+type Deps struct {
+	Repo          LeadRepository
+	Notifier      Notifier
+	NotifyTimeout time.Duration
+	Log           *slog.Logger
+}
+
+func NewDeps(repo LeadRepository, notifier Notifier, notifyTimeout time.Duration, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("lead: missing repository")
+	}
+	if notifier == nil {
+		return nil, errors.New("lead: missing notifier")
+	}
+	if log == nil {
+		return nil, errors.New("lead: missing logger")
+	}
+	if notifyTimeout <= 0 {
+		notifyTimeout = defaultNotifyTimeout
+	}
+	return &Deps{
+		Repo:          repo,
+		Notifier:      notifier,
+		NotifyTimeout: notifyTimeout,
+		Log:           log.With("component", "service.lead"),
+	}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo:          d.Repo,
+		notifier:      d.Notifier,
+		notifyTimeout: d.NotifyTimeout,
+		log:           d.Log,
+	}
+}
+
+// Create сохраняет заявку и уведомляет менеджеров. Заявки, похожие на спам,
+// сохраняются со статусом spam без уведомления и без погашения промокода.
+// Уведомление уходит в фоне, клиент его не ждёт; ошибка уведомления не
+// отменяет заявку — она уже сохранена и видна в админке.
+func (s *Service) Create(ctx context.Context, l *domLead.Lead) (*domLead.Lead, error) {
+	const op = "service.lead.Create"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	l.Name = strings.TrimSpace(l.Name)
+	l.Phone = strings.TrimSpace(l.Phone)
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	l.Status = domLead.StatusNew
+	if l.LooksLikeSpam() {
+		l.Status = domLead.StatusSpam
+		l.PromoCode = nil
+	}
+
+	res, err := s.repo.Create(ctx, l)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound:                     domLead.ErrBadReference,
+			domDiscount.ErrPromoCodeUnavailable: domDiscount.ErrPromoCodeUnavailable,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	log.Info("lead received", slog.Int64("lead_id", res.ID), slog.String("kind", string(res.Kind)), slog.String("status", string(res.Status)))
+
+	if res.Status == domLead.StatusNew {
+		s.notify(context.WithoutCancel(ctx), log, res)
+	}
+	return res, nil
+}
+
+// notify отправляет уведомление о заявке в фоне. Заявка копируется: вызывающий
+// может менять возвращённую ему структуру.
+func (s *Service) notify(ctx context.Context, log *slog.Logger, l *domLead.Lead) {
+	lead := *l
+	s.notifying.Add(1)
+	go func() {
+		defer s.notifying.Done()
+		nctx, cancel := context.WithTimeout(ctx, s.notifyTimeout)
+		defer cancel()
+		if err := s.notifier.NotifyLead(nctx, &lead); err != nil {
+			log.Error("lead notification failed", slog.Int64("lead_id", lead.ID), slog.Any("error", err))
+		}
+	}()
+}
+
+// Wait дожидается уведомлений, которые ещё отправляются в фоне. Вызывается
+// при остановке после того, как HTTP-сервер перестал принимать заявки;
+// каждое уведомление ограничено notifyTimeout.
+func (s *Service) Wait() {
+	s.notifying.Wait()
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (*domLead.Lead, error) {
+	const op = "service.lead.Get"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	res, err := s.repo.Get(ctx, id)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domLead.ErrLeadNotFound,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	return res, nil
+}
+
+// List возвращает заявки с указанным статусом; пустой статус — все заявки.
+func (s *Service) List(ctx context.Context, status domLead.Status) ([]domLead.Lead, error) {
+	const op = "service.lead.List"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if status != "" && !status.Valid() {
+		return nil, domLead.ErrInvalidStatus
+	}
+	res, err := s.repo.List(ctx, status)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return res, nil
+}
+
+// UpdateStatus переводит заявку по workflow: new → in_progress → done,
+// из new и in_progress можно отменить, из new — пометить как спам.
+func (s *Service) UpdateStatus(ctx context.Context, id int64, status domLead.Status, note *string) (*domLead.Lead, error) {
+	const op = "service.lead.UpdateStatus"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	l, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	prev := l.Status
+	if err := l.TransitionTo(status, note, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateStatus(ctx, l, prev); err != nil {
+		// статус успели поменять параллельно
+		mapping := map[error]error{
+			der.ErrNotFound: domLead.ErrInvalidTransition,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	log.Info("lead status changed", slog.Int64("lead_id", id), slog.String("from", string(prev)), slog.String("to", string(status)))
+	return l, nil
+}
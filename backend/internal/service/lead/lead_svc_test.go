@@ -0,0 +1,212 @@
+package lead_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	leadservice "github.com/Neimess/zorkin-store-project/internal/service/lead"
+	"github.com/Neimess/zorkin-store-project/internal/service/lead/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeNotifier struct {
+	mu   sync.Mutex
+	sent []int64
+	err  error
+	// block, если задан, держит отправку до закрытия канала
+	block chan struct{}
+}
+
+func (f *fakeNotifier) NotifyLead(ctx context.Context, l *domLead.Lead) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("notifier called without deadline")
+	}
+	if f.block != nil {
+		<-f.block
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, l.ID)
+	return f.err
+}
+
+func (f *fakeNotifier) Sent() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sent
+}
+
+type LeadServiceSuite struct {
+	suite.Suite
+	svc      *leadservice.Service
+	mockRepo *mocks.MockLeadRepository
+	notifier *fakeNotifier
+}
+
+func (s *LeadServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockLeadRepository(s.T())
+	s.notifier = &fakeNotifier{}
+	deps, err := leadservice.NewDeps(s.mockRepo, s.notifier, time.Second, slog.Default())
+	s.Require().NoError(err)
+	s.svc = leadservice.New(deps)
+}
+
+func validLead() *domLead.Lead {
+	return &domLead.Lead{Kind: domLead.KindCallback, Name: " Иван ", Phone: "+7 (900) 123-45-67"}
+}
+
+func (s *LeadServiceSuite) expectCreate(id int64) {
+	s.mockRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, l *domLead.Lead) (*domLead.Lead, error) {
+			l.ID = id
+			return l, nil
+		}).Once()
+}
+
+func (s *LeadServiceSuite) TestCreate_NotifiesManagers() {
+	s.expectCreate(1)
+	res, err := s.svc.Create(context.Background(), validLead())
+	s.NoError(err)
+	s.Equal(domLead.StatusNew, res.Status)
+	s.Equal("Иван", res.Name)
+	s.svc.Wait()
+	s.Equal([]int64{1}, s.notifier.Sent())
+}
+
+func (s *LeadServiceSuite) TestCreate_DoesNotWaitForNotification() {
+	s.notifier.block = make(chan struct{})
+	s.expectCreate(5)
+	res, err := s.svc.Create(context.Background(), validLead())
+	s.NoError(err)
+	s.Equal(int64(5), res.ID)
+	s.Empty(s.notifier.Sent(), "notification must not block the response")
+
+	close(s.notifier.block)
+	s.svc.Wait()
+	s.Equal([]int64{5}, s.notifier.Sent())
+}
+
+func (s *LeadServiceSuite) TestCreate_NotifierFailureIsNotFatal() {
+	s.notifier.err = errors.New("smtp down")
+	s.expectCreate(2)
+	res, err := s.svc.Create(context.Background(), validLead())
+	s.NoError(err)
+	s.Equal(int64(2), res.ID)
+	s.svc.Wait()
+}
+
+func (s *LeadServiceSuite) TestCreate_SpamIsStoredSilently() {
+	msg := strings.Repeat("buy now https://spam.example ", 3)
+	code := "SPRING10"
+	l := validLead()
+	l.Message = &msg
+	l.PromoCode = &code
+	s.expectCreate(3)
+	res, err := s.svc.Create(context.Background(), l)
+	s.NoError(err)
+	s.Equal(domLead.StatusSpam, res.Status)
+	s.Nil(res.PromoCode, "spam must not redeem promo codes")
+	s.svc.Wait()
+	s.Empty(s.notifier.Sent())
+}
+
+func (s *LeadServiceSuite) TestCreate_PromoCode() {
+	s.Run("normalized before saving", func() {
+		code := " spring10 "
+		l := validLead()
+		l.PromoCode = &code
+		s.expectCreate(4)
+		res, err := s.svc.Create(context.Background(), l)
+		s.NoError(err)
+		s.Equal("SPRING10", *res.PromoCode)
+	})
+	s.Run("unavailable code rejects the lead", func() {
+		code := "SPRING10"
+		l := validLead()
+		l.PromoCode = &code
+		s.mockRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, domDiscount.ErrPromoCodeUnavailable).Once()
+		_, err := s.svc.Create(context.Background(), l)
+		s.ErrorIs(err, domDiscount.ErrPromoCodeUnavailable)
+	})
+}
+
+func (s *LeadServiceSuite) TestCreate_Validation() {
+	cases := []struct {
+		name    string
+		mutate  func(l *domLead.Lead)
+		wantErr error
+	}{
+		{"bad kind", func(l *domLead.Lead) { l.Kind = "order" }, domLead.ErrInvalidKind},
+		{"empty name", func(l *domLead.Lead) { l.Name = "  " }, domLead.ErrEmptyName},
+		{"bad phone", func(l *domLead.Lead) { l.Phone = "call me" }, domLead.ErrInvalidPhone},
+		{"bad email", func(l *domLead.Lead) { e := "nope"; l.Email = &e }, domLead.ErrInvalidEmail},
+		{"bad promo code", func(l *domLead.Lead) { c := "со скидкой"; l.PromoCode = &c }, domDiscount.ErrInvalidPromoCode},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			l := validLead()
+			tc.mutate(l)
+			_, err := s.svc.Create(context.Background(), l)
+			s.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
+
+func (s *LeadServiceSuite) TestCreate_BadReference() {
+	s.mockRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+	_, err := s.svc.Create(context.Background(), validLead())
+	s.ErrorIs(err, domLead.ErrBadReference)
+}
+
+func (s *LeadServiceSuite) TestUpdateStatus() {
+	s.Run("allowed transition", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).
+			Return(&domLead.Lead{ID: 1, Status: domLead.StatusNew}, nil).Once()
+		s.mockRepo.EXPECT().UpdateStatus(mock.Anything, mock.MatchedBy(func(l *domLead.Lead) bool {
+			return l.Status == domLead.StatusInProgress
+		}), domLead.StatusNew).Return(nil).Once()
+		res, err := s.svc.UpdateStatus(context.Background(), 1, domLead.StatusInProgress, nil)
+		s.NoError(err)
+		s.Equal(domLead.StatusInProgress, res.Status)
+	})
+	s.Run("terminal status", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).
+			Return(&domLead.Lead{ID: 1, Status: domLead.StatusDone}, nil).Once()
+		_, err := s.svc.UpdateStatus(context.Background(), 1, domLead.StatusInProgress, nil)
+		s.ErrorIs(err, domLead.ErrInvalidTransition)
+	})
+	s.Run("changed concurrently", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).
+			Return(&domLead.Lead{ID: 1, Status: domLead.StatusNew}, nil).Once()
+		s.mockRepo.EXPECT().UpdateStatus(mock.Anything, mock.Anything, domLead.StatusNew).Return(der.ErrNotFound).Once()
+		_, err := s.svc.UpdateStatus(context.Background(), 1, domLead.StatusSpam, nil)
+		s.ErrorIs(err, domLead.ErrInvalidTransition)
+	})
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(9)).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.UpdateStatus(context.Background(), 9, domLead.StatusDone, nil)
+		s.ErrorIs(err, domLead.ErrLeadNotFound)
+	})
+}
+
+func (s *LeadServiceSuite) TestList_InvalidStatus() {
+	_, err := s.svc.List(context.Background(), "archived")
+	s.ErrorIs(err, domLead.ErrInvalidStatus)
+}
+
+func TestLeadServiceSuite(t *testing.T) {
+	suite.Run(t, new(LeadServiceSuite))
+}
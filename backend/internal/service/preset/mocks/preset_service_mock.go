@@ -355,6 +355,74 @@ func (_c *MockPresetRepository_ListShort_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// Patch provides a mock function for the type MockPresetRepository
+func (_mock *MockPresetRepository) Patch(ctx context.Context, p *preset.Patch) (*preset.Preset, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *preset.Preset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *preset.Patch) (*preset.Preset, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *preset.Patch) *preset.Preset); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preset.Preset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *preset.Patch) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPresetRepository_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockPresetRepository_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *preset.Patch
+func (_e *MockPresetRepository_Expecter) Patch(ctx interface{}, p interface{}) *MockPresetRepository_Patch_Call {
+	return &MockPresetRepository_Patch_Call{Call: _e.mock.On("Patch", ctx, p)}
+}
+
+func (_c *MockPresetRepository_Patch_Call) Run(run func(ctx context.Context, p *preset.Patch)) *MockPresetRepository_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *preset.Patch
+		if args[1] != nil {
+			arg1 = args[1].(*preset.Patch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPresetRepository_Patch_Call) Return(preset1 *preset.Preset, err error) *MockPresetRepository_Patch_Call {
+	_c.Call.Return(preset1, err)
+	return _c
+}
+
+func (_c *MockPresetRepository_Patch_Call) RunAndReturn(run func(ctx context.Context, p *preset.Patch) (*preset.Preset, error)) *MockPresetRepository_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockPresetRepository
 func (_mock *MockPresetRepository) Update(ctx context.Context, p *preset.Preset) (*preset.Preset, error) {
 	ret := _mock.Called(ctx, p)
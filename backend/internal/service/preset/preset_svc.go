@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Neimess/zorkin-store-project/internal/domain/preset"
 	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 )
 
 type PresetRepository interface {
@@ -16,10 +19,13 @@ type PresetRepository interface {
 	Get(ctx context.Context, id int64) (*preset.Preset, error)
 	Delete(ctx context.Context, id int64) error
 	Update(ctx context.Context, p *preset.Preset) (*preset.Preset, error)
+	Patch(ctx context.Context, p *preset.Patch) (*preset.Preset, error)
 	ListDetailed(ctx context.Context) ([]preset.Preset, error)
 	ListShort(ctx context.Context) ([]preset.Preset, error)
 }
 
+const cloneSuffix = " (копия)"
+
 type Service struct {
 	repo PresetRepository
 	log  *slog.Logger
@@ -50,6 +56,8 @@ func New(d *Deps) *Service {
 func (s *Service) Create(ctx context.Context, p *preset.Preset) (*preset.Preset, error) {
 	const op = "service.preset.Create"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	if err := p.Validate(); err != nil {
 		return nil, err
@@ -71,6 +79,8 @@ func (s *Service) Create(ctx context.Context, p *preset.Preset) (*preset.Preset,
 func (s *Service) Get(ctx context.Context, id int64) (*preset.Preset, error) {
 	const op = "service.preset.Get"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	p, err := s.repo.Get(ctx, id)
 	if err != nil {
@@ -88,11 +98,14 @@ func (s *Service) Get(ctx context.Context, id int64) (*preset.Preset, error) {
 func (s *Service) Delete(ctx context.Context, id int64) error {
 	const op = "service.preset.Delete"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		mapping := map[error]error{
-			der.ErrNotFound: nil,
+			der.ErrNotFound:        nil,
+			der.ErrVersionConflict: der.ErrVersionConflict,
 		}
 		return utils.ErrorHandler(log, op, err, mapping)
 	}
@@ -104,6 +117,8 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 func (s *Service) ListDetailed(ctx context.Context) ([]preset.Preset, error) {
 	const op = "service.preset.ListDetailed"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	list, err := s.repo.ListDetailed(ctx)
 	if err != nil {
@@ -118,6 +133,8 @@ func (s *Service) ListDetailed(ctx context.Context) ([]preset.Preset, error) {
 func (s *Service) ListShort(ctx context.Context) ([]preset.Preset, error) {
 	const op = "service.preset.ListShort"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	list, err := s.repo.ListShort(ctx)
 	if err != nil {
@@ -132,12 +149,15 @@ func (s *Service) ListShort(ctx context.Context) ([]preset.Preset, error) {
 func (s *Service) Update(ctx context.Context, p *preset.Preset) (*preset.Preset, error) {
 	const op = "service.preset.Update"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	res, err := s.repo.Update(ctx, p)
 	if err != nil {
 		mapping := map[error]error{
-			der.ErrConflict: preset.ErrPresetAlreadyExists,
-			der.ErrNotFound: preset.ErrPresetNotFound,
+			der.ErrConflict:        preset.ErrPresetAlreadyExists,
+			der.ErrNotFound:        preset.ErrPresetNotFound,
+			der.ErrVersionConflict: der.ErrVersionConflict,
 		}
 		return nil, utils.ErrorHandler(log, op, err, mapping)
 	}
@@ -145,3 +165,128 @@ func (s *Service) Update(ctx context.Context, p *preset.Preset) (*preset.Preset,
 	log.Info("preset updated", slog.Int64("preset_id", res.ID))
 	return res, nil
 }
+
+// Patch записывает только изменённые поля пресета. Пустой патч ничего не пишет.
+func (s *Service) Patch(ctx context.Context, p *preset.Patch) (*preset.Preset, error) {
+	const op = "service.preset.Patch"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if p.Empty() {
+		return s.Get(ctx, p.ID)
+	}
+
+	res, err := s.repo.Patch(ctx, p)
+	if err != nil {
+		mapping := map[error]error{
+			preset.ErrPresetNotFound: preset.ErrPresetNotFound,
+			der.ErrVersionConflict:   der.ErrVersionConflict,
+			der.ErrConflict:          preset.ErrPresetAlreadyExists,
+			der.ErrNotFound:          preset.ErrInvalidProductID,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	log.Info("preset patched", slog.Int64("preset_id", res.ID), slog.Int64("version", res.Version))
+	return res, nil
+}
+
+// Clone создаёт глубокую копию пресета вместе с позициями.
+// Если name пустой, к имени исходного пресета добавляется суффикс копии.
+func (s *Service) Clone(ctx context.Context, id int64, name *string) (*preset.Preset, error) {
+	const op = "service.preset.Clone"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	src, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := src.Clone()
+	if name != nil && *name != "" {
+		cp.Name = *name
+	} else {
+		cp.Name = cloneName(src.Name)
+	}
+
+	res, err := s.Create(ctx, cp)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("preset cloned", slog.Int64("source_id", id), slog.Int64("preset_id", res.ID))
+	return res, nil
+}
+
+// cloneName — имя копии по умолчанию: исходное с суффиксом. Длинное исходное
+// имя обрезается по символам, чтобы копия не упёрлась в ErrNameTooLong.
+func cloneName(name string) string {
+	name = strings.TrimSpace(name)
+	if limit := preset.MaxNameLength - utf8.RuneCountInString(cloneSuffix); utf8.RuneCountInString(name) > limit {
+		name = strings.TrimSpace(string([]rune(name)[:limit]))
+	}
+	return name + cloneSuffix
+}
+
+// Instantiate разворачивает шаблон под размеры помещения и сохраняет
+// результат как новый обычный пресет.
+func (s *Service) Instantiate(ctx context.Context, id int64, room preset.RoomParams, name *string) (*preset.Preset, error) {
+	const op = "service.preset.Instantiate"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	tpl, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := tpl.Instantiate(room)
+	if err != nil {
+		return nil, err
+	}
+	if name != nil && *name != "" {
+		p.Name = *name
+	}
+
+	res, err := s.Create(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("preset instantiated from template",
+		slog.Int64("template_id", id),
+		slog.Int64("preset_id", res.ID),
+		slog.String("total_price", res.TotalPrice.String()),
+	)
+	return res, nil
+}
+
+// Configure собирает комплект пресета по выбору клиента в слотах.
+// Ничего не сохраняет: результат — расчёт для конфигуратора.
+func (s *Service) Configure(ctx context.Context, id int64, choices []preset.Choice) (*preset.Configuration, error) {
+	const op = "service.preset.Configure"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	p, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := p.Configure(choices)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("preset configured",
+		slog.Int64("preset_id", id),
+		slog.Int("choices", len(choices)),
+		slog.String("total_price", cfg.TotalPrice.String()),
+	)
+	return cfg, nil
+}
@@ -3,12 +3,15 @@ package preset_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"log/slog"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	"github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	"github.com/Neimess/zorkin-store-project/internal/domain/product"
 	presetservice "github.com/Neimess/zorkin-store-project/internal/service/preset"
 	"github.com/Neimess/zorkin-store-project/internal/service/preset/mocks"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
@@ -37,7 +40,7 @@ func validPreset() *preset.Preset {
 		ID:          1,
 		Name:        "Test",
 		Description: &desc,
-		TotalPrice:  100.0,
+		TotalPrice:  money.FromFloat(100.0, money.Base),
 		ImageURL:    &img,
 		CreatedAt:   time.Now(),
 		Items:       []preset.PresetItem{{ID: 1, ProductID: 1}},
@@ -375,6 +378,413 @@ func (s *PresetServiceSuite) TestListShort() {
 	}
 }
 
+func strPtr(s string) *string { return &s }
+
+func templatePreset() *preset.Preset {
+	return &preset.Preset{
+		ID:         7,
+		Name:       "Bathroom template",
+		IsTemplate: true,
+		Items: []preset.PresetItem{
+			{ProductID: 1, Product: &product.ProductSummary{ID: 1, Price: money.FromFloat(1000, money.Base)}, QuantityFormula: strPtr("ceil(floor_area / 1.44 * 1.1)")},
+			{ProductID: 2, Product: &product.ProductSummary{ID: 2, Price: money.FromFloat(500, money.Base)}, QuantityFormula: strPtr("ceil(wall_area / 1.2)")},
+			{ProductID: 3, Product: &product.ProductSummary{ID: 3, Price: money.FromFloat(15000, money.Base)}, Quantity: 1},
+		},
+	}
+}
+
+func (s *PresetServiceSuite) TestClone() {
+	type testCase struct {
+		name      string
+		id        int64
+		newName   *string
+		mockSetup func()
+		wantName  string
+		expectErr error
+	}
+
+	tests := []testCase{
+		{
+			name: "success with default name",
+			id:   1,
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(1)).Return(validPreset(), nil).Once()
+				s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *preset.Preset) bool {
+					return p.ID == 0 && len(p.Items) == 1 && p.Items[0].ID == 0 && p.Items[0].ProductID == 1
+				})).Return(func(_ context.Context, p *preset.Preset) (*preset.Preset, error) {
+					p.ID = 2
+					return p, nil
+				}).Once()
+			},
+			wantName: "Test (копия)",
+		},
+		{
+			name: "long name is truncated",
+			id:   1,
+			mockSetup: func() {
+				src := validPreset()
+				src.Name = strings.Repeat("ж", 120)
+				s.mockRepo.On("Get", mock.Anything, int64(1)).Return(src, nil).Once()
+				s.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*preset.Preset")).
+					Return(func(_ context.Context, p *preset.Preset) (*preset.Preset, error) {
+						p.ID = 2
+						return p, nil
+					}).Once()
+			},
+			wantName: strings.Repeat("ж", 92) + " (копия)",
+		},
+		{
+			name:    "success with explicit name",
+			id:      1,
+			newName: strPtr("Client copy"),
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(1)).Return(validPreset(), nil).Once()
+				s.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*preset.Preset")).
+					Return(func(_ context.Context, p *preset.Preset) (*preset.Preset, error) {
+						p.ID = 2
+						return p, nil
+					}).Once()
+			},
+			wantName: "Client copy",
+		},
+		{
+			name: "source not found",
+			id:   5,
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(5)).Return(nil, der.ErrNotFound).Once()
+			},
+			expectErr: preset.ErrPresetNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.mockSetup()
+			res, err := s.svc.Clone(context.Background(), tc.id, tc.newName)
+			if tc.expectErr == nil {
+				s.Require().NoError(err)
+				s.Equal(int64(2), res.ID)
+				s.Equal(tc.wantName, res.Name)
+			} else {
+				s.ErrorIs(err, tc.expectErr)
+			}
+			s.mockRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *PresetServiceSuite) TestInstantiate() {
+	room := preset.RoomParams{Length: 2.5, Width: 1.8, Height: 2.7, OpeningsArea: 1.6}
+
+	type testCase struct {
+		name      string
+		room      preset.RoomParams
+		mockSetup func()
+		check     func(p *preset.Preset)
+		expectErr error
+	}
+
+	tests := []testCase{
+		{
+			name: "success",
+			room: room,
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(7)).Return(templatePreset(), nil).Once()
+				s.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*preset.Preset")).
+					Return(func(_ context.Context, p *preset.Preset) (*preset.Preset, error) {
+						p.ID = 8
+						return p, nil
+					}).Once()
+			},
+			check: func(p *preset.Preset) {
+				s.False(p.IsTemplate)
+				s.Require().Len(p.Items, 3)
+				// floor 4.5 м² -> ceil(4.5/1.44*1.1) = 4
+				s.Equal(4.0, p.Items[0].Quantity)
+				// walls 2*(2.5+1.8)*2.7-1.6 = 21.62 м² -> ceil(21.62/1.2) = 19
+				s.Equal(19.0, p.Items[1].Quantity)
+				s.Equal(1.0, p.Items[2].Quantity)
+				s.Nil(p.Items[0].QuantityFormula)
+				s.Equal("28500.00 RUB", p.TotalPrice.String())
+			},
+		},
+		{
+			// 10.05 * 0.5 = 5.025: во float64 это 5.02499…, и итог терял копейку
+			name: "total is rounded without float error",
+			room: room,
+			mockSetup: func() {
+				tpl := templatePreset()
+				tpl.Items = []preset.PresetItem{{
+					ProductID: 1,
+					Product:   &product.ProductSummary{ID: 1, Price: money.FromFloat(10.05, money.Base)},
+					Quantity:  0.5,
+				}}
+				s.mockRepo.On("Get", mock.Anything, int64(7)).Return(tpl, nil).Once()
+				s.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*preset.Preset")).
+					Return(func(_ context.Context, p *preset.Preset) (*preset.Preset, error) {
+						p.ID = 8
+						return p, nil
+					}).Once()
+			},
+			check: func(p *preset.Preset) {
+				s.Equal("5.03 RUB", p.TotalPrice.String())
+			},
+		},
+		{
+			name: "not a template",
+			room: room,
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(7)).Return(validPreset(), nil).Once()
+			},
+			expectErr: preset.ErrNotTemplate,
+		},
+		{
+			name: "invalid room params",
+			room: preset.RoomParams{Length: 2, Width: 0, Height: 2.5},
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(7)).Return(templatePreset(), nil).Once()
+			},
+			expectErr: preset.ErrInvalidRoomParams,
+		},
+		{
+			name: "broken formula",
+			room: room,
+			mockSetup: func() {
+				tpl := templatePreset()
+				tpl.Items[0].QuantityFormula = strPtr("floor_area / (length - length)")
+				s.mockRepo.On("Get", mock.Anything, int64(7)).Return(tpl, nil).Once()
+			},
+			expectErr: preset.ErrInvalidFormula,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.mockSetup()
+			res, err := s.svc.Instantiate(context.Background(), 7, tc.room, nil)
+			if tc.expectErr == nil {
+				s.Require().NoError(err)
+				s.Equal(int64(8), res.ID)
+				tc.check(res)
+			} else {
+				s.ErrorIs(err, tc.expectErr)
+			}
+			s.mockRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *PresetServiceSuite) TestCreateTemplateValidation() {
+	tests := []struct {
+		name      string
+		mutate    func(p *preset.Preset)
+		expectErr error
+	}{
+		{
+			name:      "unknown variable",
+			mutate:    func(p *preset.Preset) { p.Items[0].QuantityFormula = strPtr("ceil(ceiling_area)") },
+			expectErr: preset.ErrUnknownVariable,
+		},
+		{
+			name:      "syntax error",
+			mutate:    func(p *preset.Preset) { p.Items[0].QuantityFormula = strPtr("floor_area *") },
+			expectErr: preset.ErrInvalidFormula,
+		},
+		{
+			name: "formula outside template",
+			mutate: func(p *preset.Preset) {
+				p.IsTemplate = false
+			},
+			expectErr: preset.ErrFormulaNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			p := templatePreset()
+			tc.mutate(p)
+			_, err := s.svc.Create(context.Background(), p)
+			s.ErrorIs(err, tc.expectErr)
+			s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// bathroomPreset — комплект 10000 (со скидкой на комплект 1000) из трёх
+// слотов: раковина с заменами, смеситель и необязательное зеркало.
+func bathroomPreset() *preset.Preset {
+	summary := func(id int64, price float64) *product.ProductSummary {
+		return &product.ProductSummary{ID: id, Price: money.FromFloat(price, money.Base)}
+	}
+	return &preset.Preset{
+		ID:         3,
+		Name:       "Ванная",
+		TotalPrice: money.FromFloat(10000, money.Base),
+		Items: []preset.PresetItem{
+			{
+				ID: 31, ProductID: 1, Product: summary(1, 5000), Quantity: 1, SlotName: strPtr("Раковина"),
+				Alternatives: []preset.Alternative{
+					{ProductID: 11, Product: summary(11, 7500)},
+					{ProductID: 12, Product: summary(12, 4200)},
+				},
+			},
+			{ID: 32, ProductID: 2, Product: summary(2, 1500), Quantity: 2},
+			{ID: 33, ProductID: 3, Product: summary(3, 3000), Quantity: 1, Optional: true},
+		},
+	}
+}
+
+func (s *PresetServiceSuite) TestConfigure() {
+	tests := []struct {
+		name      string
+		choices   []preset.Choice
+		mutate    func(p *preset.Preset)
+		total     string
+		products  []int64
+		expectErr error
+	}{
+		{
+			name:     "defaults",
+			total:    "10000.00 RUB",
+			products: []int64{1, 2, 3},
+		},
+		{
+			name:     "replace and omit",
+			choices:  []preset.Choice{{SlotID: 31, ProductID: 11}, {SlotID: 33, Omit: true}},
+			total:    "9500.00 RUB",
+			products: []int64{11, 2},
+		},
+		{
+			name:     "cheaper alternative",
+			choices:  []preset.Choice{{SlotID: 31, ProductID: 12}},
+			total:    "9200.00 RUB",
+			products: []int64{12, 2, 3},
+		},
+		{
+			name:     "default product chosen explicitly",
+			choices:  []preset.Choice{{SlotID: 31, ProductID: 1}},
+			total:    "10000.00 RUB",
+			products: []int64{1, 2, 3},
+		},
+		{
+			name:      "product not allowed",
+			choices:   []preset.Choice{{SlotID: 32, ProductID: 11}},
+			expectErr: preset.ErrProductNotAllowed,
+		},
+		{
+			name:      "slot not optional",
+			choices:   []preset.Choice{{SlotID: 31, Omit: true}},
+			expectErr: preset.ErrSlotNotOptional,
+		},
+		{
+			name:      "unknown slot",
+			choices:   []preset.Choice{{SlotID: 99}},
+			expectErr: preset.ErrUnknownSlot,
+		},
+		{
+			name:      "duplicate choice",
+			choices:   []preset.Choice{{SlotID: 31, ProductID: 11}, {SlotID: 31, ProductID: 12}},
+			expectErr: preset.ErrDuplicateChoice,
+		},
+		{
+			name:      "template",
+			mutate:    func(p *preset.Preset) { p.IsTemplate = true },
+			expectErr: preset.ErrNotConfigurable,
+		},
+		{
+			name:      "nothing left",
+			mutate:    func(p *preset.Preset) { p.Items = p.Items[2:] },
+			choices:   []preset.Choice{{SlotID: 33, Omit: true}},
+			expectErr: preset.ErrNoItems,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			p := bathroomPreset()
+			if tc.mutate != nil {
+				tc.mutate(p)
+			}
+			s.mockRepo.On("Get", mock.Anything, int64(3)).Return(p, nil).Once()
+
+			res, err := s.svc.Configure(context.Background(), 3, tc.choices)
+			if tc.expectErr != nil {
+				s.ErrorIs(err, tc.expectErr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.total, res.TotalPrice.String())
+			var products []int64
+			for _, it := range res.Items {
+				products = append(products, it.Product.ID)
+				s.Equal(it.Product.ID != p.Items[slotIndex(p, it.SlotID)].ProductID, it.Replaced)
+			}
+			s.Equal(tc.products, products)
+		})
+	}
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mockRepo.On("Get", mock.Anything, int64(3)).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.Configure(context.Background(), 3, nil)
+		s.ErrorIs(err, preset.ErrPresetNotFound)
+	})
+}
+
+func slotIndex(p *preset.Preset, slotID int64) int {
+	for i, it := range p.Items {
+		if it.ID == slotID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *PresetServiceSuite) TestCreateSlotValidation() {
+	tests := []struct {
+		name      string
+		mutate    func(p *preset.Preset)
+		expectErr error
+	}{
+		{
+			name: "alternative equals default",
+			mutate: func(p *preset.Preset) {
+				p.Items[0].Alternatives = append(p.Items[0].Alternatives, preset.Alternative{ProductID: 1})
+			},
+			expectErr: preset.ErrInvalidAlternative,
+		},
+		{
+			name: "duplicate alternative",
+			mutate: func(p *preset.Preset) {
+				p.Items[0].Alternatives = append(p.Items[0].Alternatives, preset.Alternative{ProductID: 11})
+			},
+			expectErr: preset.ErrInvalidAlternative,
+		},
+		{
+			name: "invalid alternative id",
+			mutate: func(p *preset.Preset) {
+				p.Items[0].Alternatives[0].ProductID = 0
+			},
+			expectErr: preset.ErrInvalidProductID,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			p := bathroomPreset()
+			tc.mutate(p)
+			_, err := s.svc.Create(context.Background(), p)
+			s.ErrorIs(err, tc.expectErr)
+			s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestPresetServiceSuite(t *testing.T) {
 	suite.Run(t, new(PresetServiceSuite))
 }
@@ -106,6 +106,74 @@ func (_c *MockProductRepository_Create_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// CreateRelation provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) CreateRelation(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error) {
+	ret := _mock.Called(ctx, rel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRelation")
+	}
+
+	var r0 *product.ProductRelation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) (*product.ProductRelation, error)); ok {
+		return returnFunc(ctx, rel)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) *product.ProductRelation); ok {
+		r0 = returnFunc(ctx, rel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.ProductRelation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *product.ProductRelation) error); ok {
+		r1 = returnFunc(ctx, rel)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_CreateRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRelation'
+type MockProductRepository_CreateRelation_Call struct {
+	*mock.Call
+}
+
+// CreateRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rel *product.ProductRelation
+func (_e *MockProductRepository_Expecter) CreateRelation(ctx interface{}, rel interface{}) *MockProductRepository_CreateRelation_Call {
+	return &MockProductRepository_CreateRelation_Call{Call: _e.mock.On("CreateRelation", ctx, rel)}
+}
+
+func (_c *MockProductRepository_CreateRelation_Call) Run(run func(ctx context.Context, rel *product.ProductRelation)) *MockProductRepository_CreateRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *product.ProductRelation
+		if args[1] != nil {
+			arg1 = args[1].(*product.ProductRelation)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_CreateRelation_Call) Return(productRelation *product.ProductRelation, err error) *MockProductRepository_CreateRelation_Call {
+	_c.Call.Return(productRelation, err)
+	return _c
+}
+
+func (_c *MockProductRepository_CreateRelation_Call) RunAndReturn(run func(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error)) *MockProductRepository_CreateRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateWithAttrs provides a mock function for the type MockProductRepository
 func (_mock *MockProductRepository) CreateWithAttrs(ctx context.Context, p *product.Product) (*product.Product, error) {
 	ret := _mock.Called(ctx, p)
@@ -231,6 +299,69 @@ func (_c *MockProductRepository_Delete_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// DeleteRelation provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) DeleteRelation(ctx context.Context, productID int64, relationID int64) error {
+	ret := _mock.Called(ctx, productID, relationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = returnFunc(ctx, productID, relationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_DeleteRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelation'
+type MockProductRepository_DeleteRelation_Call struct {
+	*mock.Call
+}
+
+// DeleteRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+//   - relationID int64
+func (_e *MockProductRepository_Expecter) DeleteRelation(ctx interface{}, productID interface{}, relationID interface{}) *MockProductRepository_DeleteRelation_Call {
+	return &MockProductRepository_DeleteRelation_Call{Call: _e.mock.On("DeleteRelation", ctx, productID, relationID)}
+}
+
+func (_c *MockProductRepository_DeleteRelation_Call) Run(run func(ctx context.Context, productID int64, relationID int64)) *MockProductRepository_DeleteRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_DeleteRelation_Call) Return(err error) *MockProductRepository_DeleteRelation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_DeleteRelation_Call) RunAndReturn(run func(ctx context.Context, productID int64, relationID int64) error) *MockProductRepository_DeleteRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type MockProductRepository
 func (_mock *MockProductRepository) Get(ctx context.Context, id int64) (*product.Product, error) {
 	ret := _mock.Called(ctx, id)
@@ -299,9 +430,83 @@ func (_c *MockProductRepository_Get_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// ListBoughtTogether provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ListBoughtTogether(ctx context.Context, productID int64, limit int) ([]product.ProductSummary, error) {
+	ret := _mock.Called(ctx, productID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBoughtTogether")
+	}
+
+	var r0 []product.ProductSummary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]product.ProductSummary, error)); ok {
+		return returnFunc(ctx, productID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []product.ProductSummary); ok {
+		r0 = returnFunc(ctx, productID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.ProductSummary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, productID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ListBoughtTogether_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBoughtTogether'
+type MockProductRepository_ListBoughtTogether_Call struct {
+	*mock.Call
+}
+
+// ListBoughtTogether is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+//   - limit int
+func (_e *MockProductRepository_Expecter) ListBoughtTogether(ctx interface{}, productID interface{}, limit interface{}) *MockProductRepository_ListBoughtTogether_Call {
+	return &MockProductRepository_ListBoughtTogether_Call{Call: _e.mock.On("ListBoughtTogether", ctx, productID, limit)}
+}
+
+func (_c *MockProductRepository_ListBoughtTogether_Call) Run(run func(ctx context.Context, productID int64, limit int)) *MockProductRepository_ListBoughtTogether_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ListBoughtTogether_Call) Return(productSummarys []product.ProductSummary, err error) *MockProductRepository_ListBoughtTogether_Call {
+	_c.Call.Return(productSummarys, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ListBoughtTogether_Call) RunAndReturn(run func(ctx context.Context, productID int64, limit int) ([]product.ProductSummary, error)) *MockProductRepository_ListBoughtTogether_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListByCategory provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) ListByCategory(ctx context.Context, catID int64) ([]product.Product, error) {
-	ret := _mock.Called(ctx, catID)
+func (_mock *MockProductRepository) ListByCategory(ctx context.Context, catID int64, sort product.SortOrder) ([]product.Product, error) {
+	ret := _mock.Called(ctx, catID, sort)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListByCategory")
@@ -309,18 +514,18 @@ func (_mock *MockProductRepository) ListByCategory(ctx context.Context, catID in
 
 	var r0 []product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]product.Product, error)); ok {
-		return returnFunc(ctx, catID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, product.SortOrder) ([]product.Product, error)); ok {
+		return returnFunc(ctx, catID, sort)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []product.Product); ok {
-		r0 = returnFunc(ctx, catID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, product.SortOrder) []product.Product); ok {
+		r0 = returnFunc(ctx, catID, sort)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, catID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, product.SortOrder) error); ok {
+		r1 = returnFunc(ctx, catID, sort)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -335,11 +540,12 @@ type MockProductRepository_ListByCategory_Call struct {
 // ListByCategory is a helper method to define mock.On call
 //   - ctx context.Context
 //   - catID int64
-func (_e *MockProductRepository_Expecter) ListByCategory(ctx interface{}, catID interface{}) *MockProductRepository_ListByCategory_Call {
-	return &MockProductRepository_ListByCategory_Call{Call: _e.mock.On("ListByCategory", ctx, catID)}
+//   - sort product.SortOrder
+func (_e *MockProductRepository_Expecter) ListByCategory(ctx interface{}, catID interface{}, sort interface{}) *MockProductRepository_ListByCategory_Call {
+	return &MockProductRepository_ListByCategory_Call{Call: _e.mock.On("ListByCategory", ctx, catID, sort)}
 }
 
-func (_c *MockProductRepository_ListByCategory_Call) Run(run func(ctx context.Context, catID int64)) *MockProductRepository_ListByCategory_Call {
+func (_c *MockProductRepository_ListByCategory_Call) Run(run func(ctx context.Context, catID int64, sort product.SortOrder)) *MockProductRepository_ListByCategory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -349,9 +555,14 @@ func (_c *MockProductRepository_ListByCategory_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 product.SortOrder
+		if args[2] != nil {
+			arg2 = args[2].(product.SortOrder)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -362,7 +573,211 @@ func (_c *MockProductRepository_ListByCategory_Call) Return(products []product.P
 	return _c
 }
 
-func (_c *MockProductRepository_ListByCategory_Call) RunAndReturn(run func(ctx context.Context, catID int64) ([]product.Product, error)) *MockProductRepository_ListByCategory_Call {
+func (_c *MockProductRepository_ListByCategory_Call) RunAndReturn(run func(ctx context.Context, catID int64, sort product.SortOrder) ([]product.Product, error)) *MockProductRepository_ListByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRelations provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ListRelations(ctx context.Context, productID int64) ([]product.ProductRelation, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRelations")
+	}
+
+	var r0 []product.ProductRelation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]product.ProductRelation, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []product.ProductRelation); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.ProductRelation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ListRelations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRelations'
+type MockProductRepository_ListRelations_Call struct {
+	*mock.Call
+}
+
+// ListRelations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+func (_e *MockProductRepository_Expecter) ListRelations(ctx interface{}, productID interface{}) *MockProductRepository_ListRelations_Call {
+	return &MockProductRepository_ListRelations_Call{Call: _e.mock.On("ListRelations", ctx, productID)}
+}
+
+func (_c *MockProductRepository_ListRelations_Call) Run(run func(ctx context.Context, productID int64)) *MockProductRepository_ListRelations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ListRelations_Call) Return(productRelations []product.ProductRelation, err error) *MockProductRepository_ListRelations_Call {
+	_c.Call.Return(productRelations, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ListRelations_Call) RunAndReturn(run func(ctx context.Context, productID int64) ([]product.ProductRelation, error)) *MockProductRepository_ListRelations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) Patch(ctx context.Context, p *product.Patch) (*product.Product, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.Patch) (*product.Product, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.Patch) *product.Product); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *product.Patch) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockProductRepository_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *product.Patch
+func (_e *MockProductRepository_Expecter) Patch(ctx interface{}, p interface{}) *MockProductRepository_Patch_Call {
+	return &MockProductRepository_Patch_Call{Call: _e.mock.On("Patch", ctx, p)}
+}
+
+func (_c *MockProductRepository_Patch_Call) Run(run func(ctx context.Context, p *product.Patch)) *MockProductRepository_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *product.Patch
+		if args[1] != nil {
+			arg1 = args[1].(*product.Patch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_Patch_Call) Return(product1 *product.Product, err error) *MockProductRepository_Patch_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *MockProductRepository_Patch_Call) RunAndReturn(run func(ctx context.Context, p *product.Patch) (*product.Product, error)) *MockProductRepository_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRelation provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) UpdateRelation(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error) {
+	ret := _mock.Called(ctx, rel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRelation")
+	}
+
+	var r0 *product.ProductRelation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) (*product.ProductRelation, error)); ok {
+		return returnFunc(ctx, rel)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) *product.ProductRelation); ok {
+		r0 = returnFunc(ctx, rel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.ProductRelation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *product.ProductRelation) error); ok {
+		r1 = returnFunc(ctx, rel)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_UpdateRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRelation'
+type MockProductRepository_UpdateRelation_Call struct {
+	*mock.Call
+}
+
+// UpdateRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rel *product.ProductRelation
+func (_e *MockProductRepository_Expecter) UpdateRelation(ctx interface{}, rel interface{}) *MockProductRepository_UpdateRelation_Call {
+	return &MockProductRepository_UpdateRelation_Call{Call: _e.mock.On("UpdateRelation", ctx, rel)}
+}
+
+func (_c *MockProductRepository_UpdateRelation_Call) Run(run func(ctx context.Context, rel *product.ProductRelation)) *MockProductRepository_UpdateRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *product.ProductRelation
+		if args[1] != nil {
+			arg1 = args[1].(*product.ProductRelation)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_UpdateRelation_Call) Return(productRelation *product.ProductRelation, err error) *MockProductRepository_UpdateRelation_Call {
+	_c.Call.Return(productRelation, err)
+	return _c
+}
+
+func (_c *MockProductRepository_UpdateRelation_Call) RunAndReturn(run func(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error)) *MockProductRepository_UpdateRelation_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,20 +6,28 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	"github.com/Neimess/zorkin-store-project/internal/domain/category"
 	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
 	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 )
 
 type ProductRepository interface {
 	Create(ctx context.Context, p *domProduct.Product) (*domProduct.Product, error)
 	CreateWithAttrs(ctx context.Context, p *domProduct.Product) (*domProduct.Product, error)
 	Get(ctx context.Context, id int64) (*domProduct.Product, error)
-	ListByCategory(ctx context.Context, catID int64) ([]domProduct.Product, error)
+	ListByCategory(ctx context.Context, catID int64, sort domProduct.SortOrder) ([]domProduct.Product, error)
 	UpdateWithAttrs(ctx context.Context, p *domProduct.Product) (*domProduct.Product, error)
+	Patch(ctx context.Context, p *domProduct.Patch) (*domProduct.Product, error)
 	Delete(ctx context.Context, id int64) error
+	ListRelations(ctx context.Context, productID int64) ([]domProduct.ProductRelation, error)
+	CreateRelation(ctx context.Context, rel *domProduct.ProductRelation) (*domProduct.ProductRelation, error)
+	UpdateRelation(ctx context.Context, rel *domProduct.ProductRelation) (*domProduct.ProductRelation, error)
+	DeleteRelation(ctx context.Context, productID, relationID int64) error
+	ListBoughtTogether(ctx context.Context, productID int64, limit int) ([]domProduct.ProductSummary, error)
 }
 
 type ServiceRepository interface {
@@ -29,31 +37,37 @@ type ServiceRepository interface {
 type Service struct {
 	repoPrd ProductRepository
 	repoSvc ServiceRepository
+	tx      utils.Transactor
 	log     *slog.Logger
 }
 
 type Deps struct {
 	repoPrd ProductRepository
 	repoSvc ServiceRepository
+	tx      utils.Transactor
 	log     *slog.Logger
 }
 
-func NewDeps(repoPrd ProductRepository, repoSvc ServiceRepository, log *slog.Logger) (*Deps, error) {
+func NewDeps(repoPrd ProductRepository, repoSvc ServiceRepository, tx utils.Transactor, log *slog.Logger) (*Deps, error) {
 	if repoPrd == nil {
 		return nil, errors.New("product service: missing ProductRepository")
 	}
 	if repoSvc == nil {
 		return nil, errors.New("product service: missing ServiceRepository")
 	}
+	if tx == nil {
+		return nil, errors.New("product service: missing Transactor")
+	}
 	if log == nil {
 		return nil, errors.New("product service: missing logger")
 	}
-	return &Deps{repoPrd: repoPrd, repoSvc: repoSvc, log: log.With("component", "service.product")}, nil
+	return &Deps{repoPrd: repoPrd, repoSvc: repoSvc, tx: tx, log: log.With("component", "service.product")}, nil
 }
 func New(d *Deps) *Service {
 	return &Service{
 		repoPrd: d.repoPrd,
 		repoSvc: d.repoSvc,
+		tx:      d.tx,
 		log:     d.log,
 	}
 }
@@ -61,6 +75,8 @@ func New(d *Deps) *Service {
 func (s *Service) Create(ctx context.Context, p *domProduct.Product) (*domProduct.Product, error) {
 	const op = "service.product.Create"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	prod, err := s.repoPrd.CreateWithAttrs(ctx, p)
 	if err != nil {
@@ -89,6 +105,8 @@ func (s *Service) Create(ctx context.Context, p *domProduct.Product) (*domProduc
 func (s *Service) CreateWithAttrs(ctx context.Context, p *domProduct.Product) (*domProduct.Product, error) {
 	const op = "service.product.CreateWithAttrs"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	prod, err := s.repoPrd.CreateWithAttrs(ctx, p)
 	if err != nil {
@@ -114,6 +132,8 @@ func (s *Service) CreateWithAttrs(ctx context.Context, p *domProduct.Product) (*
 func (s *Service) GetDetailed(ctx context.Context, id int64) (*domProduct.Product, error) {
 	const op = "service.product.GetDetailed"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	product, err := s.repoPrd.Get(ctx, id)
 	if err != nil {
@@ -128,11 +148,17 @@ func (s *Service) GetDetailed(ctx context.Context, id int64) (*domProduct.Produc
 	return product, nil
 }
 
-func (s *Service) GetByCategoryID(ctx context.Context, catID int64) ([]domProduct.Product, error) {
+func (s *Service) GetByCategoryID(ctx context.Context, catID int64, sort domProduct.SortOrder) ([]domProduct.Product, error) {
 	const op = "service.product.GetByCategoryID"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if !sort.Valid() {
+		return nil, domProduct.ErrInvalidSort
+	}
 
-	products, err := s.repoPrd.ListByCategory(ctx, catID)
+	products, err := s.repoPrd.ListByCategory(ctx, catID, sort)
 	if err != nil {
 		if errors.Is(err, der.ErrNotFound) || errors.Is(err, category.ErrCategoryNotFound) {
 			return nil, domProduct.ErrBadCategoryID
@@ -150,12 +176,15 @@ func (s *Service) GetByCategoryID(ctx context.Context, catID int64) ([]domProduc
 func (s *Service) Update(ctx context.Context, p *domProduct.Product) (*domProduct.Product, error) {
 	const op = "service.product.Update"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	prod, err := s.repoPrd.UpdateWithAttrs(ctx, p)
 	if err != nil {
 		mapping := map[error]error{
 			der.ErrNotFound:               domProduct.ErrProductNotFound,
 			domProduct.ErrProductNotFound: domProduct.ErrProductNotFound,
+			der.ErrVersionConflict:        der.ErrVersionConflict,
 			der.ErrValidation:             domProduct.ErrInvalidAttribute,
 			der.ErrBadRequest:             domProduct.ErrInvalidAttribute,
 		}
@@ -172,13 +201,45 @@ func (s *Service) Update(ctx context.Context, p *domProduct.Product) (*domProduc
 	return prod, nil
 }
 
+// Patch записывает только изменённые поля товара. Пустой патч ничего не пишет
+// и возвращает товар как есть.
+func (s *Service) Patch(ctx context.Context, p *domProduct.Patch) (*domProduct.Product, error) {
+	const op = "service.product.Patch"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if p.Empty() {
+		return s.GetDetailed(ctx, p.ID)
+	}
+
+	prod, err := s.repoPrd.Patch(ctx, p)
+	if err != nil {
+		mapping := map[error]error{
+			domProduct.ErrProductNotFound: domProduct.ErrProductNotFound,
+			der.ErrVersionConflict:        der.ErrVersionConflict,
+			der.ErrNotFound:               domProduct.ErrBadCategoryID,
+			domService.ErrServiceNotFound: domProduct.ErrBadServiceID,
+			der.ErrValidation:             domProduct.ErrInvalidAttribute,
+			der.ErrBadRequest:             domProduct.ErrInvalidAttribute,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	log.Info("product patched", slog.Int64("product_id", prod.ID), slog.Int64("version", prod.Version))
+	return prod, nil
+}
+
 func (s *Service) Delete(ctx context.Context, id int64) error {
 	const op = "service.product.Delete"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
 
 	if err := s.repoPrd.Delete(ctx, id); err != nil {
 		mapping := map[error]error{
-			der.ErrNotFound: nil,
+			der.ErrNotFound:        nil,
+			der.ErrVersionConflict: der.ErrVersionConflict,
 		}
 		return utils.ErrorHandler(log, op, err, mapping)
 	}
@@ -187,6 +248,32 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Batch применяет пакет операций над товарами: create и update — как Create и Update,
+// delete — как Delete. С atomic пакет выполняется целиком или не выполняется.
+func (s *Service) Batch(ctx context.Context, items []batch.Item[*domProduct.Product], atomic bool) ([]batch.Result[*domProduct.Product], error) {
+	const op = "service.product.Batch"
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	results, err := utils.RunVersionedBatch(ctx, s.tx, items, atomic, func(ctx context.Context, it batch.Item[*domProduct.Product]) (*domProduct.Product, error) {
+		switch it.Op {
+		case batch.OpCreate:
+			return s.Create(ctx, it.Value)
+		case batch.OpUpdate:
+			it.Value.ID = it.ID
+			return s.Update(ctx, it.Value)
+		default:
+			return nil, s.Delete(ctx, it.ID)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("batch finished", slog.String("op", op), slog.Int("count", len(results)), slog.Bool("atomic", atomic))
+	return results, nil
+}
+
 func (s *Service) fetchServices(ctx context.Context, p *domProduct.Product) error {
 	var services []domService.Service
 	for _, svc := range p.Services {
@@ -8,12 +8,16 @@ import (
 
 	"log/slog"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	catdomain "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
 	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
 	productservice "github.com/Neimess/zorkin-store-project/internal/service/product"
 	"github.com/Neimess/zorkin-store-project/internal/service/product/mocks"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -31,10 +35,17 @@ func (m *Mock) Get(ctx context.Context, id int64) (*domService.Service, error) {
 	return nil, nil
 }
 
+// inTx выполняет fn без транзакции.
+type inTx struct{}
+
+func (inTx) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func (s *ProductServiceSuite) SetupTest() {
 	s.mockRepo = new(mocks.MockProductRepository)
 	s.logger = slog.New(slog.DiscardHandler)
-	deps, _ := productservice.NewDeps(s.mockRepo, &Mock{}, s.logger)
+	deps, _ := productservice.NewDeps(s.mockRepo, &Mock{}, inTx{}, s.logger)
 	s.svc = productservice.New(deps)
 }
 
@@ -44,7 +55,7 @@ func validProduct() *domProduct.Product {
 	return &domProduct.Product{
 		ID:          1,
 		Name:        "Test",
-		Price:       100.0,
+		Price:       money.FromFloat(100.0, money.Base),
 		Description: &desc,
 		CategoryID:  1,
 		ImageURL:    &img,
@@ -195,7 +206,7 @@ func (s *ProductServiceSuite) TestGetByCategoryID() {
 			name:  "success",
 			catID: 1,
 			mockSetup: func() {
-				s.mockRepo.On("ListByCategory", mock.Anything, int64(1)).Return([]domProduct.Product{*p}, nil).Once()
+				s.mockRepo.On("ListByCategory", mock.Anything, int64(1), domProduct.SortDefault).Return([]domProduct.Product{*p}, nil).Once()
 			},
 			expect:    []domProduct.Product{*p},
 			expectErr: nil,
@@ -204,7 +215,7 @@ func (s *ProductServiceSuite) TestGetByCategoryID() {
 			name:  "not found",
 			catID: 2,
 			mockSetup: func() {
-				s.mockRepo.On("ListByCategory", mock.Anything, int64(2)).Return(nil, der.ErrNotFound).Once()
+				s.mockRepo.On("ListByCategory", mock.Anything, int64(2), domProduct.SortDefault).Return(nil, der.ErrNotFound).Once()
 			},
 			expect:    nil,
 			expectErr: domProduct.ErrBadCategoryID,
@@ -213,7 +224,7 @@ func (s *ProductServiceSuite) TestGetByCategoryID() {
 			name:  "not found (category.ErrCategoryNotFound)",
 			catID: 3,
 			mockSetup: func() {
-				s.mockRepo.On("ListByCategory", mock.Anything, int64(3)).Return(nil, catdomain.ErrCategoryNotFound).Once()
+				s.mockRepo.On("ListByCategory", mock.Anything, int64(3), domProduct.SortDefault).Return(nil, catdomain.ErrCategoryNotFound).Once()
 			},
 			expect:    nil,
 			expectErr: domProduct.ErrBadCategoryID,
@@ -222,7 +233,7 @@ func (s *ProductServiceSuite) TestGetByCategoryID() {
 			name:  "repo error",
 			catID: 4,
 			mockSetup: func() {
-				s.mockRepo.On("ListByCategory", mock.Anything, int64(4)).Return(nil, errors.New("db fail")).Once()
+				s.mockRepo.On("ListByCategory", mock.Anything, int64(4), domProduct.SortDefault).Return(nil, errors.New("db fail")).Once()
 			},
 			expect:    nil,
 			expectErr: errors.New("db fail"),
@@ -233,7 +244,7 @@ func (s *ProductServiceSuite) TestGetByCategoryID() {
 		s.Run(tc.name, func() {
 			s.SetupTest()
 			tc.mockSetup()
-			res, err := s.svc.GetByCategoryID(context.Background(), tc.catID)
+			res, err := s.svc.GetByCategoryID(context.Background(), tc.catID, domProduct.SortDefault)
 			if tc.expectErr == nil {
 				s.NoError(err)
 				s.Equal(tc.expect, res)
@@ -320,6 +331,74 @@ func (s *ProductServiceSuite) TestUpdate() {
 	}
 }
 
+func (s *ProductServiceSuite) TestPatch() {
+	type testCase struct {
+		name      string
+		input     *domProduct.Patch
+		mockSetup func()
+		expectErr error
+	}
+
+	p := validProduct()
+	rename := &domProduct.Patch{ID: 1, Version: 2, Name: patch.To("Ламинат")}
+
+	tests := []testCase{
+		{
+			name:  "success",
+			input: rename,
+			mockSetup: func() {
+				s.mockRepo.On("Patch", mock.Anything, rename).Return(p, nil).Once()
+			},
+		},
+		{
+			name:  "empty patch reads without writing",
+			input: &domProduct.Patch{ID: 1, Version: 2},
+			mockSetup: func() {
+				s.mockRepo.On("Get", mock.Anything, int64(1)).Return(p, nil).Once()
+			},
+		},
+		{
+			name:  "version conflict",
+			input: rename,
+			mockSetup: func() {
+				s.mockRepo.On("Patch", mock.Anything, rename).Return(nil, der.ErrVersionConflict).Once()
+			},
+			expectErr: der.ErrVersionConflict,
+		},
+		{
+			name:  "not found",
+			input: rename,
+			mockSetup: func() {
+				s.mockRepo.On("Patch", mock.Anything, rename).Return(nil, domProduct.ErrProductNotFound).Once()
+			},
+			expectErr: domProduct.ErrProductNotFound,
+		},
+		{
+			name:  "unknown category",
+			input: &domProduct.Patch{ID: 1, Version: 2, CategoryID: patch.To(int64(99))},
+			mockSetup: func() {
+				s.mockRepo.On("Patch", mock.Anything, mock.AnythingOfType("*product.Patch")).Return(nil, der.ErrNotFound).Once()
+			},
+			expectErr: domProduct.ErrBadCategoryID,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.mockSetup()
+			res, err := s.svc.Patch(context.Background(), tc.input)
+			if tc.expectErr == nil {
+				s.NoError(err)
+				s.Equal(p.ID, res.ID)
+			} else {
+				s.ErrorIs(err, tc.expectErr)
+			}
+			s.mockRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
 func (s *ProductServiceSuite) TestDelete() {
 	type testCase struct {
 		name      string
@@ -369,6 +448,101 @@ func (s *ProductServiceSuite) TestDelete() {
 	}
 }
 
+func (s *ProductServiceSuite) TestBatch() {
+	ctx := context.Background()
+	items := func() []batch.Item[*domProduct.Product] {
+		return []batch.Item[*domProduct.Product]{
+			{Op: batch.OpCreate, Value: validProduct()},
+			{Op: batch.OpUpdate, ID: 5, Version: 1, Value: validProduct()},
+			{Op: batch.OpDelete, ID: 6, Version: 1},
+		}
+	}
+
+	s.Run("independent", func() {
+		s.SetupTest()
+		s.mockRepo.On("CreateWithAttrs", mock.Anything, mock.Anything).Return(validProduct(), nil).Once()
+		s.mockRepo.On("UpdateWithAttrs", mock.Anything, mock.MatchedBy(func(p *domProduct.Product) bool { return p.ID == 5 })).
+			Return(nil, domProduct.ErrProductNotFound).Once()
+		s.mockRepo.On("Delete", mock.Anything, int64(6)).Return(nil).Once()
+
+		res, err := s.svc.Batch(ctx, items(), false)
+		s.Require().NoError(err)
+		s.Equal(batch.StatusOK, res[0].Status)
+		s.Equal(batch.StatusFailed, res[1].Status)
+		s.ErrorIs(res[1].Err, domProduct.ErrProductNotFound)
+		s.Equal(batch.StatusOK, res[2].Status)
+		s.mockRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("atomic", func() {
+		s.SetupTest()
+		s.mockRepo.On("CreateWithAttrs", mock.Anything, mock.Anything).Return(validProduct(), nil).Once()
+		s.mockRepo.On("UpdateWithAttrs", mock.Anything, mock.Anything).Return(nil, domProduct.ErrProductNotFound).Once()
+
+		res, err := s.svc.Batch(ctx, items(), true)
+		s.Require().NoError(err)
+		s.Equal(batch.StatusRolledBack, res[0].Status)
+		s.Nil(res[0].Value)
+		s.Equal(batch.StatusFailed, res[1].Status)
+		s.Equal(batch.StatusSkipped, res[2].Status)
+		s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+
+	s.Run("version", func() {
+		s.SetupTest()
+		expects := func(v int64) func(context.Context) bool {
+			return func(ctx context.Context) bool {
+				versions, ok := database.ExpectedVersions(ctx)
+				return ok && len(versions) == 1 && versions[0] == v
+			}
+		}
+		s.mockRepo.On("UpdateWithAttrs", mock.MatchedBy(expects(3)), mock.Anything).Return(nil, der.ErrVersionConflict).Once()
+		s.mockRepo.On("Delete", mock.MatchedBy(expects(4)), int64(6)).Return(nil).Once()
+
+		res, err := s.svc.Batch(ctx, []batch.Item[*domProduct.Product]{
+			{Op: batch.OpUpdate, ID: 5, Version: 3, Value: validProduct()},
+			{Op: batch.OpDelete, ID: 6, Version: 4},
+			{Op: batch.OpCreate, Version: 1, Value: validProduct()},
+		}, false)
+		s.Require().NoError(err)
+		s.ErrorIs(res[0].Err, der.ErrVersionConflict)
+		s.Equal(batch.StatusOK, res[1].Status)
+		s.ErrorIs(res[2].Err, batch.ErrInvalidVersion)
+		s.mockRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("version required", func() {
+		s.SetupTest()
+		s.mockRepo.On("CreateWithAttrs", mock.Anything, mock.Anything).Return(validProduct(), nil).Once()
+
+		res, err := s.svc.Batch(ctx, []batch.Item[*domProduct.Product]{
+			{Op: batch.OpCreate, Value: validProduct()},
+			{Op: batch.OpUpdate, ID: 5, Value: validProduct()},
+			{Op: batch.OpDelete, ID: 6},
+		}, false)
+		s.Require().NoError(err)
+		s.Equal(batch.StatusOK, res[0].Status)
+		s.ErrorIs(res[1].Err, der.ErrPreconditionRequired)
+		s.ErrorIs(res[2].Err, der.ErrPreconditionRequired)
+		s.mockRepo.AssertNotCalled(s.T(), "UpdateWithAttrs", mock.Anything, mock.Anything)
+		s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+
+		// атомарный пакет без version даже не начинается
+		res, err = s.svc.Batch(ctx, []batch.Item[*domProduct.Product]{
+			{Op: batch.OpCreate, Value: validProduct()},
+			{Op: batch.OpDelete, ID: 6},
+		}, true)
+		s.Require().NoError(err)
+		s.Equal(batch.StatusSkipped, res[0].Status)
+		s.ErrorIs(res[1].Err, der.ErrPreconditionRequired)
+	})
+
+	s.Run("too large", func() {
+		_, err := s.svc.Batch(ctx, make([]batch.Item[*domProduct.Product], batch.MaxItems+1), false)
+		s.ErrorIs(err, batch.ErrTooLarge)
+	})
+}
+
 func TestProductServiceSuite(t *testing.T) {
 	suite.Run(t, new(ProductServiceSuite))
 }
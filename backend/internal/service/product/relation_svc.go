@@ -0,0 +1,163 @@
+package product
+
+import (
+	"context"
+	"log/slog"
+
+	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+const (
+	DefaultBoughtTogetherLimit = 8
+	MaxBoughtTogetherLimit     = 50
+)
+
+func (s *Service) ListRelations(ctx context.Context, productID int64) ([]domProduct.ProductRelation, error) {
+	const op = "service.product.ListRelations"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	rels, err := s.repoPrd.ListRelations(ctx, productID)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domProduct.ErrProductNotFound,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	log.Info("product relations retrieved", slog.Int64("product_id", productID), slog.Int("count", len(rels)))
+	return rels, nil
+}
+
+func (s *Service) CreateRelation(ctx context.Context, rel *domProduct.ProductRelation) (*domProduct.ProductRelation, error) {
+	const op = "service.product.CreateRelation"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := rel.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repoPrd.CreateRelation(ctx, rel)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrConflict:   domProduct.ErrRelationAlreadyExists,
+			der.ErrNotFound:   domProduct.ErrBadRelatedProduct,
+			der.ErrValidation: domProduct.ErrInvalidRelationType,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	log.Info("product relation created",
+		slog.Int64("relation_id", res.ID),
+		slog.Int64("product_id", res.ProductID),
+		slog.Int64("related_id", res.RelatedID),
+		slog.String("type", string(res.Type)),
+	)
+	return res, nil
+}
+
+func (s *Service) UpdateRelation(ctx context.Context, rel *domProduct.ProductRelation) (*domProduct.ProductRelation, error) {
+	const op = "service.product.UpdateRelation"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := rel.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repoPrd.UpdateRelation(ctx, rel)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrConflict:   domProduct.ErrRelationAlreadyExists,
+			der.ErrNotFound:   domProduct.ErrRelationNotFound,
+			der.ErrValidation: domProduct.ErrInvalidRelationType,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	log.Info("product relation updated", slog.Int64("relation_id", res.ID))
+	return res, nil
+}
+
+func (s *Service) DeleteRelation(ctx context.Context, productID, relationID int64) error {
+	const op = "service.product.DeleteRelation"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repoPrd.DeleteRelation(ctx, productID, relationID); err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domProduct.ErrRelationNotFound,
+		}
+		return utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	log.Info("product relation deleted", slog.Int64("product_id", productID), slog.Int64("relation_id", relationID))
+	return nil
+}
+
+// FrequentlyBoughtTogether подбирает товары для блока «с этим покупают»:
+// сначала обязательные и сопутствующие из явных связей, затем товары,
+// чаще всего встречающиеся вместе с этим в пресетах.
+func (s *Service) FrequentlyBoughtTogether(ctx context.Context, productID int64, limit int) ([]domProduct.ProductSummary, error) {
+	const op = "service.product.FrequentlyBoughtTogether"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if limit <= 0 {
+		limit = DefaultBoughtTogetherLimit
+	}
+	if limit > MaxBoughtTogetherLimit {
+		limit = MaxBoughtTogetherLimit
+	}
+
+	rels, err := s.repoPrd.ListRelations(ctx, productID)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domProduct.ErrProductNotFound,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+
+	out := make([]domProduct.ProductSummary, 0, limit)
+	seen := make(map[int64]struct{}, limit)
+	add := func(ps domProduct.ProductSummary) {
+		if len(out) >= limit {
+			return
+		}
+		if _, ok := seen[ps.ID]; ok {
+			return
+		}
+		seen[ps.ID] = struct{}{}
+		out = append(out, ps)
+	}
+
+	for _, t := range []domProduct.RelationType{domProduct.RelationRequired, domProduct.RelationAccessory} {
+		for _, rel := range rels {
+			if rel.Type == t && rel.Related != nil {
+				add(*rel.Related)
+			}
+		}
+	}
+
+	if len(out) < limit {
+		together, err := s.repoPrd.ListBoughtTogether(ctx, productID, limit)
+		if err != nil {
+			return nil, utils.ErrorHandler(log, op, err, nil)
+		}
+		for _, ps := range together {
+			add(ps)
+		}
+	}
+
+	log.Info("frequently bought together retrieved", slog.Int64("product_id", productID), slog.Int("count", len(out)))
+	return out, nil
+}
@@ -0,0 +1,151 @@
+package product_test
+
+import (
+	"context"
+	"errors"
+
+	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	productservice "github.com/Neimess/zorkin-store-project/internal/service/product"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *ProductServiceSuite) TestCreateRelation() {
+	type testCase struct {
+		name      string
+		input     *domProduct.ProductRelation
+		mockSetup func()
+		expectErr error
+	}
+
+	valid := func() *domProduct.ProductRelation {
+		return &domProduct.ProductRelation{ProductID: 1, RelatedID: 2, Type: domProduct.RelationAccessory}
+	}
+
+	tests := []testCase{
+		{
+			name:  "success",
+			input: valid(),
+			mockSetup: func() {
+				s.mockRepo.On("CreateRelation", mock.Anything, mock.AnythingOfType("*product.ProductRelation")).
+					Return(&domProduct.ProductRelation{ID: 5, ProductID: 1, RelatedID: 2, Type: domProduct.RelationAccessory}, nil).Once()
+			},
+		},
+		{
+			name:      "self relation",
+			input:     &domProduct.ProductRelation{ProductID: 1, RelatedID: 1, Type: domProduct.RelationRequired},
+			mockSetup: func() {},
+			expectErr: domProduct.ErrSelfRelation,
+		},
+		{
+			name:      "unknown type",
+			input:     &domProduct.ProductRelation{ProductID: 1, RelatedID: 2, Type: "sibling"},
+			mockSetup: func() {},
+			expectErr: domProduct.ErrInvalidRelationType,
+		},
+		{
+			name:  "duplicate",
+			input: valid(),
+			mockSetup: func() {
+				s.mockRepo.On("CreateRelation", mock.Anything, mock.Anything).Return(nil, der.ErrConflict).Once()
+			},
+			expectErr: domProduct.ErrRelationAlreadyExists,
+		},
+		{
+			name:  "related product missing",
+			input: valid(),
+			mockSetup: func() {
+				s.mockRepo.On("CreateRelation", mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+			},
+			expectErr: domProduct.ErrBadRelatedProduct,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.mockSetup()
+			res, err := s.svc.CreateRelation(context.Background(), tc.input)
+			if tc.expectErr == nil {
+				s.NoError(err)
+				s.Equal(int64(5), res.ID)
+			} else {
+				s.ErrorIs(err, tc.expectErr)
+			}
+			s.mockRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *ProductServiceSuite) TestUpdateAndDeleteRelation() {
+	s.Run("update not found", func() {
+		s.SetupTest()
+		s.mockRepo.On("UpdateRelation", mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.UpdateRelation(context.Background(), &domProduct.ProductRelation{ID: 9, ProductID: 1, RelatedID: 2, Type: domProduct.RelationAlternative})
+		s.ErrorIs(err, domProduct.ErrRelationNotFound)
+	})
+
+	s.Run("delete not found", func() {
+		s.SetupTest()
+		s.mockRepo.On("DeleteRelation", mock.Anything, int64(1), int64(9)).Return(der.ErrNotFound).Once()
+		s.ErrorIs(s.svc.DeleteRelation(context.Background(), 1, 9), domProduct.ErrRelationNotFound)
+	})
+
+	s.Run("delete repo error", func() {
+		s.SetupTest()
+		s.mockRepo.On("DeleteRelation", mock.Anything, int64(1), int64(9)).Return(errors.New("db fail")).Once()
+		s.Error(s.svc.DeleteRelation(context.Background(), 1, 9))
+	})
+}
+
+func (s *ProductServiceSuite) TestFrequentlyBoughtTogether() {
+	rels := []domProduct.ProductRelation{
+		{ID: 1, ProductID: 1, RelatedID: 10, Type: domProduct.RelationAlternative, Related: &domProduct.ProductSummary{ID: 10}},
+		{ID: 2, ProductID: 1, RelatedID: 11, Type: domProduct.RelationAccessory, Related: &domProduct.ProductSummary{ID: 11}},
+		{ID: 3, ProductID: 1, RelatedID: 12, Type: domProduct.RelationRequired, Related: &domProduct.ProductSummary{ID: 12}},
+	}
+
+	s.Run("explicit relations first, then co-occurrence without duplicates", func() {
+		s.SetupTest()
+		s.mockRepo.On("ListRelations", mock.Anything, int64(1)).Return(rels, nil).Once()
+		s.mockRepo.On("ListBoughtTogether", mock.Anything, int64(1), 4).
+			Return([]domProduct.ProductSummary{{ID: 11}, {ID: 20}, {ID: 21}, {ID: 22}}, nil).Once()
+
+		res, err := s.svc.FrequentlyBoughtTogether(context.Background(), 1, 4)
+		s.Require().NoError(err)
+		ids := make([]int64, len(res))
+		for i, ps := range res {
+			ids[i] = ps.ID
+		}
+		s.Equal([]int64{12, 11, 20, 21}, ids)
+	})
+
+	s.Run("limit filled by relations skips co-occurrence query", func() {
+		s.SetupTest()
+		s.mockRepo.On("ListRelations", mock.Anything, int64(1)).Return(rels, nil).Once()
+
+		res, err := s.svc.FrequentlyBoughtTogether(context.Background(), 1, 1)
+		s.Require().NoError(err)
+		s.Len(res, 1)
+		s.mockRepo.AssertNotCalled(s.T(), "ListBoughtTogether", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("default limit", func() {
+		s.SetupTest()
+		s.mockRepo.On("ListRelations", mock.Anything, int64(1)).Return(nil, nil).Once()
+		s.mockRepo.On("ListBoughtTogether", mock.Anything, int64(1), productservice.DefaultBoughtTogetherLimit).
+			Return([]domProduct.ProductSummary{}, nil).Once()
+
+		res, err := s.svc.FrequentlyBoughtTogether(context.Background(), 1, 0)
+		s.Require().NoError(err)
+		s.Empty(res)
+	})
+
+	s.Run("product not found", func() {
+		s.SetupTest()
+		s.mockRepo.On("ListRelations", mock.Anything, int64(9)).Return(nil, der.ErrNotFound).Once()
+
+		_, err := s.svc.FrequentlyBoughtTogether(context.Background(), 9, 0)
+		s.ErrorIs(err, domProduct.ErrProductNotFound)
+	})
+}
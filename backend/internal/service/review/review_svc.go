@@ -0,0 +1,155 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, rv *domReview.Review) (*domReview.Review, error)
+	Get(ctx context.Context, id int64) (*domReview.Review, error)
+	ListByProduct(ctx context.Context, productID int64, status domReview.Status) ([]domReview.Review, error)
+	ListByStatus(ctx context.Context, status domReview.Status) ([]domReview.Review, error)
+	UpdateStatus(ctx context.Context, rv *domReview.Review) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type Service struct {
+	repo ReviewRepository
+	log  *slog.Logger
+}
+
+type Deps struct {
+	Repo ReviewRepository
+	Log  *slog.Logger
+}
+
+func NewDeps(repo ReviewRepository, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("review: missing repository")
+	}
+	if log == nil {
+		return nil, errors.New("review: missing logger")
+	}
+	return &Deps{Repo: repo, Log: log.With("component", "service.review")}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo: d.Repo,
+		log:  d.Log,
+	}
+}
+
+// Create принимает отзыв покупателя. Отзыв попадает в очередь модерации
+// и не учитывается в рейтинге товара до одобрения.
+func (s *Service) Create(ctx context.Context, rv *domReview.Review) (*domReview.Review, error) {
+	const op = "service.review.Create"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	rv.AuthorName = strings.TrimSpace(rv.AuthorName)
+	rv.Status = domReview.StatusPending
+	if err := rv.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.Create(ctx, rv)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domReview.ErrProductNotFound,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	log.Info("review submitted", slog.Int64("review_id", res.ID), slog.Int64("product_id", res.ProductID))
+	return res, nil
+}
+
+// ListApproved возвращает опубликованные отзывы товара.
+func (s *Service) ListApproved(ctx context.Context, productID int64) ([]domReview.Review, error) {
+	const op = "service.review.ListApproved"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	res, err := s.repo.ListByProduct(ctx, productID, domReview.StatusApproved)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return res, nil
+}
+
+// ListByStatus возвращает отзывы для админки; по умолчанию — очередь модерации.
+func (s *Service) ListByStatus(ctx context.Context, status domReview.Status) ([]domReview.Review, error) {
+	const op = "service.review.ListByStatus"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if status == "" {
+		status = domReview.StatusPending
+	}
+	if !status.Valid() {
+		return nil, domReview.ErrInvalidStatus
+	}
+	res, err := s.repo.ListByStatus(ctx, status)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return res, nil
+}
+
+func (s *Service) Approve(ctx context.Context, id int64) (*domReview.Review, error) {
+	return s.moderate(ctx, "service.review.Approve", id, domReview.StatusApproved, nil)
+}
+
+func (s *Service) Reject(ctx context.Context, id int64, reason *string) (*domReview.Review, error) {
+	return s.moderate(ctx, "service.review.Reject", id, domReview.StatusRejected, reason)
+}
+
+func (s *Service) moderate(ctx context.Context, op string, id int64, status domReview.Status, reason *string) (*domReview.Review, error) {
+	log := s.log.With("op", op)
+
+	rv, err := s.repo.Get(ctx, id)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domReview.ErrReviewNotFound,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	if err := rv.Moderate(status, reason, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateStatus(ctx, rv); err != nil {
+		// отзыв уже промодерирован параллельным запросом
+		mapping := map[error]error{
+			der.ErrNotFound: domReview.ErrAlreadyModerated,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	log.Info("review moderated", slog.Int64("review_id", id), slog.String("status", string(status)))
+	return rv, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	const op = "service.review.Delete"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		mapping := map[error]error{
+			der.ErrNotFound: domReview.ErrReviewNotFound,
+		}
+		return utils.ErrorHandler(log, op, err, mapping)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	reviewservice "github.com/Neimess/zorkin-store-project/internal/service/review"
+	"github.com/Neimess/zorkin-store-project/internal/service/review/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewServiceSuite struct {
+	suite.Suite
+	svc      *reviewservice.Service
+	mockRepo *mocks.MockReviewRepository
+}
+
+func (s *ReviewServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockReviewRepository(s.T())
+	deps, _ := reviewservice.NewDeps(s.mockRepo, slog.Default())
+	s.svc = reviewservice.New(deps)
+}
+
+func pendingReview() *domReview.Review {
+	return &domReview.Review{ID: 1, ProductID: 10, AuthorName: "Анна", Rating: 4, Status: domReview.StatusPending}
+}
+
+func (s *ReviewServiceSuite) TestCreate() {
+	cases := []struct {
+		name    string
+		in      *domReview.Review
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "goes to moderation queue",
+			in:   &domReview.Review{ProductID: 10, AuthorName: "  Анна ", Rating: 5, Status: domReview.StatusApproved},
+			setup: func() {
+				s.mockRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(rv *domReview.Review) bool {
+					return rv.Status == domReview.StatusPending && rv.AuthorName == "Анна"
+				})).RunAndReturn(func(_ context.Context, rv *domReview.Review) (*domReview.Review, error) {
+					rv.ID = 7
+					return rv, nil
+				}).Once()
+			},
+		},
+		{
+			name:    "rating out of range",
+			in:      &domReview.Review{ProductID: 10, AuthorName: "Анна", Rating: 6},
+			wantErr: domReview.ErrInvalidRating,
+		},
+		{
+			name:    "too many photos",
+			in:      &domReview.Review{ProductID: 10, AuthorName: "Анна", Rating: 3, Photos: make([]string, domReview.MaxPhotos+1)},
+			wantErr: domReview.ErrTooManyPhotos,
+		},
+		{
+			name: "unknown product",
+			in:   &domReview.Review{ProductID: 99, AuthorName: "Анна", Rating: 3},
+			setup: func() {
+				s.mockRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+			},
+			wantErr: domReview.ErrProductNotFound,
+		},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			if tc.setup != nil {
+				tc.setup()
+			}
+			res, err := s.svc.Create(context.Background(), tc.in)
+			if tc.wantErr != nil {
+				s.ErrorIs(err, tc.wantErr)
+				s.Nil(res)
+				return
+			}
+			s.NoError(err)
+			s.Equal(int64(7), res.ID)
+		})
+	}
+}
+
+func (s *ReviewServiceSuite) TestListByStatus() {
+	s.mockRepo.EXPECT().ListByStatus(mock.Anything, domReview.StatusPending).
+		Return([]domReview.Review{*pendingReview()}, nil).Once()
+	res, err := s.svc.ListByStatus(context.Background(), "")
+	s.NoError(err)
+	s.Len(res, 1)
+
+	_, err = s.svc.ListByStatus(context.Background(), "spam")
+	s.ErrorIs(err, domReview.ErrInvalidStatus)
+}
+
+func (s *ReviewServiceSuite) TestApprove() {
+	s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).Return(pendingReview(), nil).Once()
+	s.mockRepo.EXPECT().UpdateStatus(mock.Anything, mock.MatchedBy(func(rv *domReview.Review) bool {
+		return rv.Status == domReview.StatusApproved && rv.ModeratedAt != nil && rv.RejectReason == nil
+	})).Return(nil).Once()
+
+	res, err := s.svc.Approve(context.Background(), 1)
+	s.NoError(err)
+	s.Equal(domReview.StatusApproved, res.Status)
+}
+
+func (s *ReviewServiceSuite) TestReject() {
+	reason := "спам"
+	s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).Return(pendingReview(), nil).Once()
+	s.mockRepo.EXPECT().UpdateStatus(mock.Anything, mock.Anything).Return(nil).Once()
+
+	res, err := s.svc.Reject(context.Background(), 1, &reason)
+	s.NoError(err)
+	s.Equal(domReview.StatusRejected, res.Status)
+	s.Equal(&reason, res.RejectReason)
+}
+
+func (s *ReviewServiceSuite) TestModerateErrors() {
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(2)).Return(nil, der.ErrNotFound).Once()
+		_, err := s.svc.Approve(context.Background(), 2)
+		s.ErrorIs(err, domReview.ErrReviewNotFound)
+	})
+	s.Run("already moderated", func() {
+		s.SetupTest()
+		rv := pendingReview()
+		rv.Status = domReview.StatusRejected
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).Return(rv, nil).Once()
+		_, err := s.svc.Approve(context.Background(), 1)
+		s.ErrorIs(err, domReview.ErrAlreadyModerated)
+	})
+	s.Run("concurrent moderation", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).Return(pendingReview(), nil).Once()
+		s.mockRepo.EXPECT().UpdateStatus(mock.Anything, mock.Anything).Return(der.ErrNotFound).Once()
+		_, err := s.svc.Approve(context.Background(), 1)
+		s.ErrorIs(err, domReview.ErrAlreadyModerated)
+	})
+	s.Run("db failure", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Get(mock.Anything, int64(1)).Return(nil, errors.New("db down")).Once()
+		_, err := s.svc.Approve(context.Background(), 1)
+		s.Error(err)
+	})
+}
+
+func (s *ReviewServiceSuite) TestDelete() {
+	s.mockRepo.EXPECT().Delete(mock.Anything, int64(3)).Return(der.ErrNotFound).Once()
+	s.ErrorIs(s.svc.Delete(context.Background(), 3), domReview.ErrReviewNotFound)
+}
+
+func TestReviewServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReviewServiceSuite))
+}
@@ -5,14 +5,26 @@ package service
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/Neimess/zorkin-store-project/internal/service/attribute"
 	"github.com/Neimess/zorkin-store-project/internal/service/auth"
 	"github.com/Neimess/zorkin-store-project/internal/service/category"
 	"github.com/Neimess/zorkin-store-project/internal/service/coefficients"
+	"github.com/Neimess/zorkin-store-project/internal/service/currency"
+	"github.com/Neimess/zorkin-store-project/internal/service/discount"
+	"github.com/Neimess/zorkin-store-project/internal/service/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/service/external"
+	"github.com/Neimess/zorkin-store-project/internal/service/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/service/lead"
 	"github.com/Neimess/zorkin-store-project/internal/service/preset"
 	"github.com/Neimess/zorkin-store-project/internal/service/product"
+	"github.com/Neimess/zorkin-store-project/internal/service/review"
 	serviceSvc "github.com/Neimess/zorkin-store-project/internal/service/service"
+	"github.com/Neimess/zorkin-store-project/internal/service/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/service/translation"
+	"github.com/Neimess/zorkin-store-project/internal/service/webhook"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 )
 
 type Deps struct {
@@ -24,6 +36,34 @@ type Deps struct {
 	Logger          *slog.Logger
 	CoefficientRepo coefficients.CoefficientRepository
 	ServiceRepo     serviceSvc.ServiceRepository
+	ReviewRepo      review.ReviewRepository
+	LeadRepo        lead.LeadRepository
+	LeadNotifier    lead.Notifier
+	NotifyTimeout   time.Duration
+	TranslationRepo translation.TranslationRepository
+	CurrencyRepo    currency.CurrencyRepository
+	DiscountRepo    discount.DiscountRepository
+	WebhookRepo     WebhookRepository
+	WebhookSender   webhook.Sender
+	WebhookOptions  webhook.DispatcherOptions
+	ExchangeRepo    exchange.ExchangeRepository
+	ExchangeCodec   exchange.Codec
+	ExchangeOptions exchange.Options
+	ExternalRepo    external.ExternalRepository
+	IdempotencyRepo idempotency.IdempotencyRepository
+	IdempotencyTTL  time.Duration
+	SnapshotRepo    snapshot.SnapshotRepository
+	SnapshotCodec   snapshot.Codec
+	// SchemaVersion — последняя версия миграций, пишется в заголовок снимка.
+	SchemaVersion uint
+	// Transactor объединяет вызовы репозиториев в одну транзакцию (атомарные пакеты).
+	Transactor utils.Transactor
+}
+
+// WebhookRepository — подписки на вебхуки и очередь их доставки.
+type WebhookRepository interface {
+	webhook.WebhookRepository
+	webhook.Outbox
 }
 
 func NewDeps(
@@ -35,6 +75,26 @@ func NewDeps(
 	attributeRepo attribute.AttributeRepository,
 	coefficientRepo coefficients.CoefficientRepository,
 	serviceRepo serviceSvc.ServiceRepository,
+	reviewRepo review.ReviewRepository,
+	leadRepo lead.LeadRepository,
+	leadNotifier lead.Notifier,
+	notifyTimeout time.Duration,
+	translationRepo translation.TranslationRepository,
+	currencyRepo currency.CurrencyRepository,
+	discountRepo discount.DiscountRepository,
+	webhookRepo WebhookRepository,
+	webhookSender webhook.Sender,
+	webhookOptions webhook.DispatcherOptions,
+	exchangeRepo exchange.ExchangeRepository,
+	exchangeCodec exchange.Codec,
+	exchangeOptions exchange.Options,
+	externalRepo external.ExternalRepository,
+	idempotencyRepo idempotency.IdempotencyRepository,
+	idempotencyTTL time.Duration,
+	snapshotRepo snapshot.SnapshotRepository,
+	snapshotCodec snapshot.Codec,
+	schemaVersion uint,
+	transactor utils.Transactor,
 ) Deps {
 	return Deps{
 		ProductRepo:     productRepo,
@@ -45,6 +105,26 @@ func NewDeps(
 		Logger:          logger,
 		CoefficientRepo: coefficientRepo,
 		ServiceRepo:     serviceRepo,
+		ReviewRepo:      reviewRepo,
+		LeadRepo:        leadRepo,
+		LeadNotifier:    leadNotifier,
+		NotifyTimeout:   notifyTimeout,
+		TranslationRepo: translationRepo,
+		CurrencyRepo:    currencyRepo,
+		DiscountRepo:    discountRepo,
+		WebhookRepo:     webhookRepo,
+		WebhookSender:   webhookSender,
+		WebhookOptions:  webhookOptions,
+		ExchangeRepo:    exchangeRepo,
+		ExchangeCodec:   exchangeCodec,
+		ExchangeOptions: exchangeOptions,
+		ExternalRepo:    externalRepo,
+		IdempotencyRepo: idempotencyRepo,
+		IdempotencyTTL:  idempotencyTTL,
+		SnapshotRepo:    snapshotRepo,
+		SnapshotCodec:   snapshotCodec,
+		SchemaVersion:   schemaVersion,
+		Transactor:      transactor,
 	}
 }
 
@@ -56,10 +136,23 @@ type Service struct {
 	AttributeService   *attribute.Service
 	CoefficientService *coefficients.Service
 	ServiceService     *serviceSvc.ServiceSvc
+	ReviewService      *review.Service
+	LeadService        *lead.Service
+	TranslationService *translation.Service
+	CurrencyService    *currency.Service
+	DiscountService    *discount.Service
+	WebhookService     *webhook.Service
+	// WebhookDispatcher рассылает события outbox; запускается приложением.
+	WebhookDispatcher *webhook.Dispatcher
+	ExchangeService   *exchange.Service
+	ExternalService   *external.Service
+	// IdempotencyService хранит ответы на админские POST с Idempotency-Key.
+	IdempotencyService *idempotency.Service
+	SnapshotService    *snapshot.Service
 }
 
 func New(d Deps) (*Service, error) {
-	prodDeps, err := product.NewDeps(d.ProductRepo, d.ServiceRepo, d.Logger)
+	prodDeps, err := product.NewDeps(d.ProductRepo, d.ServiceRepo, d.Transactor, d.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("product service init: %w", err)
 	}
@@ -83,7 +176,7 @@ func New(d Deps) (*Service, error) {
 	}
 	presetSvc := preset.New(presetDeps)
 
-	attrDeps, err := attribute.NewDeps(d.AttributeRepo, d.CategoryRepo, d.Logger)
+	attrDeps, err := attribute.NewDeps(d.AttributeRepo, d.CategoryRepo, d.Transactor, d.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("attribute service init: %w", err)
 	}
@@ -95,12 +188,78 @@ func New(d Deps) (*Service, error) {
 	}
 	coeffSvc := coefficients.New(coeffDeps)
 
-	serviceDeps, err := serviceSvc.NewDeps(d.ServiceRepo, d.Logger)
+	serviceDeps, err := serviceSvc.NewDeps(d.ServiceRepo, d.Transactor, d.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("service service init: %w", err)
 	}
 	serviceSvcObj := serviceSvc.New(serviceDeps)
 
+	reviewDeps, err := review.NewDeps(d.ReviewRepo, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("review service init: %w", err)
+	}
+	reviewSvc := review.New(reviewDeps)
+
+	leadDeps, err := lead.NewDeps(d.LeadRepo, d.LeadNotifier, d.NotifyTimeout, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("lead service init: %w", err)
+	}
+	leadSvc := lead.New(leadDeps)
+
+	trDeps, err := translation.NewDeps(d.TranslationRepo, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("translation service init: %w", err)
+	}
+	trSvc := translation.New(trDeps)
+
+	curDeps, err := currency.NewDeps(d.CurrencyRepo, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("currency service init: %w", err)
+	}
+	curSvc := currency.New(curDeps)
+
+	discountDeps, err := discount.NewDeps(d.DiscountRepo, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("discount service init: %w", err)
+	}
+	discountSvc := discount.New(discountDeps)
+
+	webhookDeps, err := webhook.NewDeps(d.WebhookRepo, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("webhook service init: %w", err)
+	}
+	webhookSvc := webhook.New(webhookDeps)
+
+	dispatcherDeps, err := webhook.NewDispatcherDeps(d.WebhookRepo, d.WebhookSender, d.WebhookOptions, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("webhook dispatcher init: %w", err)
+	}
+	webhookDispatcher := webhook.NewDispatcher(dispatcherDeps)
+
+	exchangeDeps, err := exchange.NewDeps(d.ExchangeRepo, d.ExchangeCodec, d.ExchangeOptions, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("exchange service init: %w", err)
+	}
+	exchangeSvc := exchange.New(exchangeDeps)
+
+	externalDeps, err := external.NewDeps(d.ExternalRepo, prodSvc, catSvc, serviceSvcObj, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("external service init: %w", err)
+	}
+	externalSvc := external.New(externalDeps)
+
+	idempotencyDeps, err := idempotency.NewDeps(d.IdempotencyRepo, d.IdempotencyTTL, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("idempotency service init: %w", err)
+	}
+	idempotencySvc := idempotency.New(idempotencyDeps)
+
+	snapshotDeps, err := snapshot.NewDeps(d.SnapshotRepo, d.SnapshotCodec, d.SchemaVersion, d.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot service init: %w", err)
+	}
+	snapshotSvc := snapshot.New(snapshotDeps)
+
 	return &Service{
 		ProductService:     prodSvc,
 		CategoryService:    catSvc,
@@ -109,5 +268,16 @@ func New(d Deps) (*Service, error) {
 		AttributeService:   attrSvc,
 		CoefficientService: coeffSvc,
 		ServiceService:     serviceSvcObj,
+		ReviewService:      reviewSvc,
+		LeadService:        leadSvc,
+		TranslationService: trSvc,
+		CurrencyService:    curSvc,
+		DiscountService:    discountSvc,
+		WebhookService:     webhookSvc,
+		WebhookDispatcher:  webhookDispatcher,
+		ExchangeService:    exchangeSvc,
+		ExternalService:    externalSvc,
+		IdempotencyService: idempotencySvc,
+		SnapshotService:    snapshotSvc,
 	}, nil
 }
@@ -3,11 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
 	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
 	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 )
 
 type ServiceRepository interface {
@@ -15,6 +18,7 @@ type ServiceRepository interface {
 	Get(ctx context.Context, id int64) (*domService.Service, error)
 	List(ctx context.Context) ([]domService.Service, error)
 	Update(ctx context.Context, s *domService.Service) (*domService.Service, error)
+	Patch(ctx context.Context, p *domService.Patch) (*domService.Service, error)
 	Delete(ctx context.Context, id int64) error
 	AddServicesToProduct(ctx context.Context, productID int64, serviceIDs []int64) error
 	GetServicesByProduct(ctx context.Context, productID int64) ([]domService.Service, error)
@@ -22,27 +26,33 @@ type ServiceRepository interface {
 
 type ServiceSvc struct {
 	repo ServiceRepository
+	tx   utils.Transactor
 	log  *slog.Logger
 }
 
 type Deps struct {
 	Repo ServiceRepository
+	Tx   utils.Transactor
 	Log  *slog.Logger
 }
 
-func NewDeps(repo ServiceRepository, log *slog.Logger) (*Deps, error) {
+func NewDeps(repo ServiceRepository, tx utils.Transactor, log *slog.Logger) (*Deps, error) {
 	if repo == nil {
 		return nil, errors.New("service: missing repository")
 	}
+	if tx == nil {
+		return nil, errors.New("service: missing transactor")
+	}
 	if log == nil {
 		return nil, errors.New("service: missing logger")
 	}
-	return &Deps{Repo: repo, Log: log.With("component", "service.service")}, nil
+	return &Deps{Repo: repo, Tx: tx, Log: log.With("component", "service.service")}, nil
 }
 
 func New(d *Deps) *ServiceSvc {
 	return &ServiceSvc{
 		repo: d.Repo,
+		tx:   d.Tx,
 		log:  d.Log,
 	}
 }
@@ -50,6 +60,9 @@ func New(d *Deps) *ServiceSvc {
 func (s *ServiceSvc) Create(ctx context.Context, serv *domService.Service) (*domService.Service, error) {
 	const op = "service.service.Create"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	if serv.Name == "" {
 		return nil, domService.ErrEmptyName
 	}
@@ -66,6 +79,9 @@ func (s *ServiceSvc) Create(ctx context.Context, serv *domService.Service) (*dom
 func (s *ServiceSvc) Get(ctx context.Context, id int64) (*domService.Service, error) {
 	const op = "service.service.Get"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	res, err := s.repo.Get(ctx, id)
 	if err != nil {
 		mapping := map[error]error{
@@ -83,14 +99,43 @@ func (s *ServiceSvc) List(ctx context.Context) ([]domService.Service, error) {
 func (s *ServiceSvc) Update(ctx context.Context, serv *domService.Service) (*domService.Service, error) {
 	const op = "service.service.Update"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	if serv.Name == "" {
 		return nil, domService.ErrEmptyName
 	}
 	res, err := s.repo.Update(ctx, serv)
 	if err != nil {
 		mapping := map[error]error{
-			der.ErrNotFound: domService.ErrServiceNotFound,
-			der.ErrConflict: domService.ErrServiceAlreadyExists,
+			der.ErrNotFound:        domService.ErrServiceNotFound,
+			der.ErrConflict:        domService.ErrServiceAlreadyExists,
+			der.ErrVersionConflict: der.ErrVersionConflict,
+		}
+		return nil, utils.ErrorHandler(log, op, err, mapping)
+	}
+	return res, nil
+}
+
+// Patch записывает только изменённые поля услуги.
+func (s *ServiceSvc) Patch(ctx context.Context, p *domService.Patch) (*domService.Service, error) {
+	const op = "service.service.Patch"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if p.Empty() {
+		return s.Get(ctx, p.ID)
+	}
+	if p.Name.Set && p.Name.Value == "" {
+		return nil, domService.ErrEmptyName
+	}
+	res, err := s.repo.Patch(ctx, p)
+	if err != nil {
+		mapping := map[error]error{
+			der.ErrVersionConflict: der.ErrVersionConflict,
+			der.ErrNotFound:        domService.ErrServiceNotFound,
+			der.ErrConflict:        domService.ErrServiceAlreadyExists,
 		}
 		return nil, utils.ErrorHandler(log, op, err, mapping)
 	}
@@ -100,16 +145,45 @@ func (s *ServiceSvc) Update(ctx context.Context, serv *domService.Service) (*dom
 func (s *ServiceSvc) Delete(ctx context.Context, id int64) error {
 	const op = "service.service.Delete"
 	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		mapping := map[error]error{
-			der.ErrNotFound: domService.ErrServiceNotFound,
+			der.ErrNotFound:        domService.ErrServiceNotFound,
+			der.ErrVersionConflict: der.ErrVersionConflict,
 		}
 		return utils.ErrorHandler(log, op, err, mapping)
 	}
 	return nil
 }
 
+// Batch применяет пакет операций над услугами. С atomic пакет выполняется
+// целиком или не выполняется.
+func (s *ServiceSvc) Batch(ctx context.Context, items []batch.Item[*domService.Service], atomic bool) ([]batch.Result[*domService.Service], error) {
+	const op = "service.service.Batch"
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	results, err := utils.RunVersionedBatch(ctx, s.tx, items, atomic, func(ctx context.Context, it batch.Item[*domService.Service]) (*domService.Service, error) {
+		switch it.Op {
+		case batch.OpCreate:
+			return s.Create(ctx, it.Value)
+		case batch.OpUpdate:
+			it.Value.ID = it.ID
+			return s.Update(ctx, it.Value)
+		default:
+			return nil, s.Delete(ctx, it.ID)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.log.Info("batch finished", slog.String("op", op), slog.Int("count", len(results)), slog.Bool("atomic", atomic))
+	return results, nil
+}
+
 func (s *ServiceSvc) AddServicesToProduct(ctx context.Context, productID int64, serviceIDs []int64) error {
 	return s.repo.AddServicesToProduct(ctx, productID, serviceIDs)
 }
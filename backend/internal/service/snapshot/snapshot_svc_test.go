@@ -0,0 +1,115 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	snapshotInfra "github.com/Neimess/zorkin-store-project/internal/infrastructure/snapshot"
+	snapshotSvc "github.com/Neimess/zorkin-store-project/internal/service/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/service/snapshot/mocks"
+)
+
+type SnapshotServiceSuite struct {
+	suite.Suite
+	svc      *snapshotSvc.Service
+	mockRepo *mocks.MockSnapshotRepository
+}
+
+func (s *SnapshotServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockSnapshotRepository(s.T())
+	deps, err := snapshotSvc.NewDeps(s.mockRepo, snapshotInfra.NewCodec(), 21, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = snapshotSvc.New(deps)
+}
+
+func (s *SnapshotServiceSuite) export(records ...domSnapshot.Record) string {
+	s.mockRepo.EXPECT().Export(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, emit func(domSnapshot.Record) error) error {
+			for _, rec := range records {
+				if err := emit(rec); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+	var buf bytes.Buffer
+	_, err := s.svc.Export(context.Background(), &buf)
+	s.Require().NoError(err)
+	return buf.String()
+}
+
+func (s *SnapshotServiceSuite) TestExportRestore() {
+	parent := int64(1)
+	out := s.export(
+		domSnapshot.Record{Kind: domSnapshot.KindCategory, Data: &domSnapshot.Category{ID: 1, Name: "Плитка"}},
+		domSnapshot.Record{Kind: domSnapshot.KindCategory, Data: &domSnapshot.Category{ID: 2, Name: "Керамогранит", ParentID: &parent}},
+	)
+	s.Contains(out, `"format":"zorkin-catalog","version":1,"schema_version":21`)
+	s.Contains(out, `{"type":"end","data":{"counts":{"category":2}}}`)
+
+	var got []domSnapshot.Record
+	s.mockRepo.EXPECT().Restore(mock.Anything, domSnapshot.ModeReplace, mock.Anything).
+		RunAndReturn(func(_ context.Context, mode domSnapshot.Mode, next func() (domSnapshot.Record, error)) (domSnapshot.Result, error) {
+			for {
+				rec, err := next()
+				if err != nil {
+					return domSnapshot.Result{Mode: mode, Created: domSnapshot.Counts{domSnapshot.KindCategory: len(got)}}, ignoreEOF(err)
+				}
+				got = append(got, rec)
+			}
+		}).Once()
+	res, err := s.svc.Restore(context.Background(), strings.NewReader(out), domSnapshot.ModeReplace)
+	s.Require().NoError(err)
+	s.Equal(2, res.Created[domSnapshot.KindCategory])
+	s.Require().Len(got, 2)
+	s.Equal(&domSnapshot.Category{ID: 2, Name: "Керамогранит", ParentID: &parent}, got[1].Data)
+}
+
+func (s *SnapshotServiceSuite) TestExportFailure() {
+	s.mockRepo.EXPECT().Export(mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+	var buf bytes.Buffer
+	_, err := s.svc.Export(context.Background(), &buf)
+	s.Error(err)
+	s.NotContains(buf.String(), `"type":"end"`)
+}
+
+func (s *SnapshotServiceSuite) TestRestoreRejects() {
+	cases := []struct {
+		name  string
+		input string
+		mode  domSnapshot.Mode
+		err   error
+	}{
+		{"empty", "", domSnapshot.ModeAppend, domSnapshot.ErrUnsupportedFormat},
+		{"not json", "id;name\n1;Плитка\n", domSnapshot.ModeAppend, domSnapshot.ErrUnsupportedFormat},
+		{"other format", `{"format":"pg_dump","version":1}`, domSnapshot.ModeAppend, domSnapshot.ErrUnsupportedFormat},
+		{"future version", `{"format":"zorkin-catalog","version":2}`, domSnapshot.ModeAppend, domSnapshot.ErrUnsupportedVersion},
+		{"bad mode", `{"format":"zorkin-catalog","version":1}`, "merge", domSnapshot.ErrInvalidMode},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			_, err := s.svc.Restore(context.Background(), strings.NewReader(tc.input), tc.mode)
+			s.ErrorIs(err, tc.err)
+		})
+	}
+}
+
+func ignoreEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func TestSnapshotServiceSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotServiceSuite))
+}
@@ -0,0 +1,107 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+type TranslationRepository interface {
+	List(ctx context.Context, entity domTr.Entity, id int64) ([]domTr.Translation, error)
+	Put(ctx context.Context, t *domTr.Translation) (*domTr.Translation, error)
+	Delete(ctx context.Context, entity domTr.Entity, id int64, locale i18n.Locale) error
+}
+
+type Service struct {
+	repo TranslationRepository
+	log  *slog.Logger
+}
+
+type Deps struct {
+	Repo TranslationRepository
+	Log  *slog.Logger
+}
+
+func NewDeps(repo TranslationRepository, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("translation: missing repository")
+	}
+	if log == nil {
+		return nil, errors.New("translation: missing logger")
+	}
+	return &Deps{Repo: repo, Log: log.With("component", "service.translation")}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo: d.Repo,
+		log:  d.Log,
+	}
+}
+
+// List возвращает переводы сущности на все локали, кроме языка по умолчанию.
+func (s *Service) List(ctx context.Context, entity domTr.Entity, id int64) ([]domTr.Translation, error) {
+	const op = "service.translation.List"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if !entity.Valid() {
+		return nil, domTr.ErrInvalidEntity
+	}
+	res, err := s.repo.List(ctx, entity, id)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domTr.ErrEntityNotFound,
+		})
+	}
+	return res, nil
+}
+
+// Put заменяет перевод сущности на локали целиком.
+func (s *Service) Put(ctx context.Context, t *domTr.Translation) (*domTr.Translation, error) {
+	const op = "service.translation.Put"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.Put(ctx, t)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domTr.ErrEntityNotFound,
+		})
+	}
+	log.Info("translation saved",
+		slog.String("entity", string(t.Entity)), slog.Int64("entity_id", t.EntityID), slog.String("locale", string(t.Locale)))
+	return res, nil
+}
+
+// Delete удаляет перевод; после этого на локали отдаётся контент по умолчанию.
+func (s *Service) Delete(ctx context.Context, entity domTr.Entity, id int64, locale i18n.Locale) error {
+	const op = "service.translation.Delete"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if !entity.Valid() {
+		return domTr.ErrInvalidEntity
+	}
+	if err := domTr.ValidateLocale(locale); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, entity, id, locale); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domTr.ErrTranslationNotFound,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package translation_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	trservice "github.com/Neimess/zorkin-store-project/internal/service/translation"
+	"github.com/Neimess/zorkin-store-project/internal/service/translation/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TranslationServiceSuite struct {
+	suite.Suite
+	svc      *trservice.Service
+	mockRepo *mocks.MockTranslationRepository
+}
+
+func (s *TranslationServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockTranslationRepository(s.T())
+	deps, err := trservice.NewDeps(s.mockRepo, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = trservice.New(deps)
+}
+
+func (s *TranslationServiceSuite) TestPut_TrimsAndSaves() {
+	s.mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(t *domTr.Translation) bool {
+		return t.Fields["name"] == "Porcelain tile" && t.Fields["description"] == "Matte"
+	})).RunAndReturn(func(_ context.Context, t *domTr.Translation) (*domTr.Translation, error) {
+		return t, nil
+	}).Once()
+
+	_, err := s.svc.Put(context.Background(), &domTr.Translation{
+		Entity:   domTr.EntityProduct,
+		EntityID: 1,
+		Locale:   i18n.EN,
+		Fields:   map[string]string{"name": "  Porcelain tile ", "description": "Matte"},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *TranslationServiceSuite) TestPut_Validation() {
+	cases := []struct {
+		name string
+		tr   domTr.Translation
+		want error
+	}{
+		{"default locale", domTr.Translation{Entity: domTr.EntityProduct, Locale: i18n.RU, Fields: map[string]string{"name": "x"}}, domTr.ErrDefaultLocale},
+		{"unsupported locale", domTr.Translation{Entity: domTr.EntityProduct, Locale: "de", Fields: map[string]string{"name": "x"}}, domTr.ErrInvalidLocale},
+		{"unknown entity", domTr.Translation{Entity: "lead", Locale: i18n.EN, Fields: map[string]string{"name": "x"}}, domTr.ErrInvalidEntity},
+		{"no fields", domTr.Translation{Entity: domTr.EntityProduct, Locale: i18n.EN}, domTr.ErrEmptyFields},
+		{"field of another entity", domTr.Translation{Entity: domTr.EntityCategory, Locale: i18n.KZ, Fields: map[string]string{"unit": "м²"}}, domTr.ErrUnknownField},
+		{"blank value", domTr.Translation{Entity: domTr.EntityService, Locale: i18n.EN, Fields: map[string]string{"name": "  "}}, domTr.ErrEmptyValue},
+		{"too long", domTr.Translation{Entity: domTr.EntityPreset, Locale: i18n.EN, Fields: map[string]string{"description": strings.Repeat("я", domTr.MaxValueLength+1)}}, domTr.ErrValueTooLong},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			_, err := s.svc.Put(context.Background(), &tc.tr)
+			s.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func (s *TranslationServiceSuite) TestPut_EntityNotFound() {
+	s.mockRepo.EXPECT().Put(mock.Anything, mock.Anything).Return(nil, der.ErrNotFound).Once()
+	_, err := s.svc.Put(context.Background(), &domTr.Translation{
+		Entity: domTr.EntityAttribute, EntityID: 404, Locale: i18n.EN, Fields: map[string]string{"unit": "m²"},
+	})
+	s.ErrorIs(err, domTr.ErrEntityNotFound)
+}
+
+func (s *TranslationServiceSuite) TestList_EntityNotFound() {
+	s.mockRepo.EXPECT().List(mock.Anything, domTr.EntityProduct, int64(404)).Return(nil, der.ErrNotFound).Once()
+	_, err := s.svc.List(context.Background(), domTr.EntityProduct, 404)
+	s.ErrorIs(err, domTr.ErrEntityNotFound)
+}
+
+func (s *TranslationServiceSuite) TestDelete() {
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mockRepo.EXPECT().Delete(mock.Anything, domTr.EntityProduct, int64(1), i18n.EN).Return(der.ErrNotFound).Once()
+		s.ErrorIs(s.svc.Delete(context.Background(), domTr.EntityProduct, 1, i18n.EN), domTr.ErrTranslationNotFound)
+	})
+	s.Run("default locale is rejected", func() {
+		s.SetupTest()
+		s.ErrorIs(s.svc.Delete(context.Background(), domTr.EntityProduct, 1, i18n.RU), domTr.ErrDefaultLocale)
+	})
+}
+
+func TestTranslationServiceSuite(t *testing.T) {
+	suite.Run(t, new(TranslationServiceSuite))
+}
@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+// Outbox — очередь событий и доставок, которую разбирает диспетчер.
+type Outbox interface {
+	FanOut(ctx context.Context, limit int) (int, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domWebhook.Job, error)
+	SaveAttempt(ctx context.Context, d *domWebhook.Delivery) error
+}
+
+// Sender отправляет одно событие подписчику и возвращает код ответа.
+type Sender interface {
+	Send(ctx context.Context, job domWebhook.Job) (int, error)
+}
+
+// DispatcherOptions — параметры рассылки; нулевые значения заменяются значениями по умолчанию.
+type DispatcherOptions struct {
+	// Interval — пауза между проходами по очереди.
+	Interval time.Duration
+	// BatchSize — сколько событий и доставок берётся за проход.
+	BatchSize int
+	// MaxAttempts — после стольких неудачных попыток доставка помечается failed.
+	MaxAttempts int
+	// BackoffBase и BackoffMax задают экспоненциальную паузу между попытками.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// Timeout ограничивает один запрос к подписчику.
+	Timeout time.Duration
+}
+
+var defaultDispatcherOptions = DispatcherOptions{
+	Interval:    5 * time.Second,
+	BatchSize:   50,
+	MaxAttempts: 8,
+	BackoffBase: 30 * time.Second,
+	BackoffMax:  6 * time.Hour,
+	Timeout:     10 * time.Second,
+}
+
+func (o DispatcherOptions) withDefaults() DispatcherOptions {
+	d := defaultDispatcherOptions
+	if o.Interval > 0 {
+		d.Interval = o.Interval
+	}
+	if o.BatchSize > 0 {
+		d.BatchSize = o.BatchSize
+	}
+	if o.MaxAttempts > 0 {
+		d.MaxAttempts = o.MaxAttempts
+	}
+	if o.BackoffBase > 0 {
+		d.BackoffBase = o.BackoffBase
+	}
+	if o.BackoffMax > 0 {
+		d.BackoffMax = o.BackoffMax
+	}
+	if o.Timeout > 0 {
+		d.Timeout = o.Timeout
+	}
+	return d
+}
+
+// Dispatcher раскладывает события outbox по подпискам и доставляет их
+// с повторами. Доставка «хотя бы один раз»: подписчик должен
+// игнорировать повторы по id события.
+type Dispatcher struct {
+	outbox Outbox
+	sender Sender
+	opts   DispatcherOptions
+	log    *slog.Logger
+	now    func() time.Time
+}
+
+type DispatcherDeps struct {
+	Outbox  Outbox
+	Sender  Sender
+	Options DispatcherOptions
+	Log     *slog.Logger
+}
+
+func NewDispatcherDeps(outbox Outbox, sender Sender, opts DispatcherOptions, log *slog.Logger) (*DispatcherDeps, error) {
+	if outbox == nil {
+		return nil, errors.New("webhook dispatcher: missing outbox")
+	}
+	if sender == nil {
+		return nil, errors.New("webhook dispatcher: missing sender")
+	}
+	if log == nil {
+		return nil, errors.New("webhook dispatcher: missing logger")
+	}
+	return &DispatcherDeps{
+		Outbox:  outbox,
+		Sender:  sender,
+		Options: opts.withDefaults(),
+		Log:     log.With("component", "service.webhook.dispatcher"),
+	}, nil
+}
+
+func NewDispatcher(d *DispatcherDeps) *Dispatcher {
+	return &Dispatcher{
+		outbox: d.Outbox,
+		sender: d.Sender,
+		opts:   d.Options,
+		log:    d.Log,
+		now:    time.Now,
+	}
+}
+
+// Run обрабатывает очередь каждые Interval, пока не отменён ctx.
+func (d *Dispatcher) Run(ctx context.Context) {
+	const op = "service.webhook.Dispatcher.Run"
+	log := d.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	log.Info("webhook dispatcher started", slog.Duration("interval", d.opts.Interval))
+
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := d.Tick(ctx); err != nil && ctx.Err() == nil {
+			log.Error("webhook dispatch failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			log.Info("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick выполняет один проход: раскладывает новые события по подпискам
+// и отправляет доставки, срок которых наступил. Доставки одного прохода
+// отправляются параллельно, чтобы медленный подписчик не задерживал остальных.
+func (d *Dispatcher) Tick(ctx context.Context) error {
+	for {
+		n, err := d.outbox.FanOut(ctx, d.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		if n < d.opts.BatchSize {
+			break
+		}
+	}
+
+	// пока идёт попытка, доставка не должна попасть в следующий проход
+	lease := d.opts.Timeout + d.opts.Interval
+	jobs, err := d.outbox.ClaimDue(ctx, d.now(), lease, d.opts.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job domWebhook.Job) {
+			defer wg.Done()
+			d.deliver(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, job domWebhook.Job) {
+	const op = "service.webhook.Dispatcher.deliver"
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	log := d.log.With("op", op,
+		slog.Int64("delivery_id", job.Delivery.ID),
+		slog.Int64("subscription_id", job.Delivery.SubscriptionID),
+		slog.String("event", string(job.Event.Type)),
+		slog.Int("attempt", job.Delivery.Attempts),
+	)
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	code, sendErr := d.sender.Send(sendCtx, job)
+	cancel()
+
+	res := d.outcome(job.Delivery, code, sendErr)
+	// результат сохраняется и при остановке приложения, иначе попытка повторится после lease
+	saveCtx, cancelSave := context.WithTimeout(context.WithoutCancel(ctx), d.opts.Timeout)
+	defer cancelSave()
+	if err := d.outbox.SaveAttempt(saveCtx, &res); err != nil {
+		log.Error("failed to save webhook attempt", slog.Any("error", err))
+		return
+	}
+
+	switch res.Status {
+	case domWebhook.DeliveryDelivered:
+		log.Debug("webhook delivered", slog.Int("status", code))
+	case domWebhook.DeliveryFailed:
+		log.Warn("webhook delivery failed, attempts exhausted", slog.Any("error", sendErr))
+	default:
+		log.Info("webhook delivery will be retried",
+			slog.Any("error", sendErr), slog.Time("next_attempt_at", res.NextAttemptAt))
+	}
+}
+
+// outcome возвращает доставку с результатом попытки.
+func (d *Dispatcher) outcome(del domWebhook.Delivery, code int, sendErr error) domWebhook.Delivery {
+	now := d.now().UTC()
+	del.UpdatedAt = now
+	del.LastStatusCode = nil
+	if code != 0 {
+		del.LastStatusCode = &code
+	}
+
+	if sendErr == nil {
+		del.Status = domWebhook.DeliveryDelivered
+		del.LastError = nil
+		del.DeliveredAt = &now
+		del.NextAttemptAt = now
+		return del
+	}
+
+	msg := sendErr.Error()
+	del.LastError = &msg
+	del.NextAttemptAt = now
+	if del.Attempts >= d.opts.MaxAttempts {
+		del.Status = domWebhook.DeliveryFailed
+		return del
+	}
+	del.Status = domWebhook.DeliveryPending
+	del.NextAttemptAt = now.Add(domWebhook.Backoff(del.Attempts, d.opts.BackoffBase, d.opts.BackoffMax))
+	return del
+}
@@ -0,0 +1,140 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	webhookSvc "github.com/Neimess/zorkin-store-project/internal/service/webhook"
+)
+
+// fakeOutbox отдаёт заранее заданные доставки и запоминает результаты попыток.
+type fakeOutbox struct {
+	mu      sync.Mutex
+	pending int
+	fanOuts int
+	jobs    []domWebhook.Job
+	lease   time.Duration
+	saved   map[int64]domWebhook.Delivery
+}
+
+func (f *fakeOutbox) FanOut(_ context.Context, limit int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fanOuts++
+	n := min(f.pending, limit)
+	f.pending -= n
+	return n, nil
+}
+
+func (f *fakeOutbox) ClaimDue(_ context.Context, _ time.Time, lease time.Duration, limit int) ([]domWebhook.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lease = lease
+	n := min(len(f.jobs), limit)
+	jobs := f.jobs[:n]
+	f.jobs = f.jobs[n:]
+	return jobs, nil
+}
+
+func (f *fakeOutbox) SaveAttempt(_ context.Context, d *domWebhook.Delivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.saved == nil {
+		f.saved = map[int64]domWebhook.Delivery{}
+	}
+	f.saved[d.ID] = *d
+	return nil
+}
+
+// fakeSender отвечает кодом из codes по ID доставки; код не из 2xx — ошибка.
+type fakeSender struct {
+	codes map[int64]int
+}
+
+func (f *fakeSender) Send(_ context.Context, job domWebhook.Job) (int, error) {
+	code := f.codes[job.Delivery.ID]
+	switch {
+	case code == 0:
+		return 0, errors.New("connection refused")
+	case code >= 300:
+		return code, errors.New("bad status")
+	}
+	return code, nil
+}
+
+func job(id int64, attempts int) domWebhook.Job {
+	return domWebhook.Job{
+		Delivery: domWebhook.Delivery{ID: id, SubscriptionID: 1, EventID: id, Attempts: attempts},
+		URL:      "https://example.com/hook",
+		Secret:   "0123456789abcdef",
+		Event:    domWebhook.Event{ID: id, Type: domWebhook.EventProductUpdated},
+	}
+}
+
+func newDispatcher(t *testing.T, outbox *fakeOutbox, sender *fakeSender) *webhookSvc.Dispatcher {
+	deps, err := webhookSvc.NewDispatcherDeps(outbox, sender, webhookSvc.DispatcherOptions{
+		Interval:    time.Second,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BackoffBase: time.Minute,
+		BackoffMax:  time.Hour,
+		Timeout:     2 * time.Second,
+	}, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	return webhookSvc.NewDispatcher(deps)
+}
+
+func TestDispatcher_Tick(t *testing.T) {
+	outbox := &fakeOutbox{
+		pending: 25,
+		jobs:    []domWebhook.Job{job(1, 1), job(2, 2), job(3, 3), job(4, 1)},
+	}
+	sender := &fakeSender{codes: map[int64]int{1: 204, 2: 500, 3: 503}}
+	start := time.Now()
+
+	require.NoError(t, newDispatcher(t, outbox, sender).Tick(context.Background()))
+
+	// 25 событий при пачке 10 — три прохода FanOut
+	require.Equal(t, 3, outbox.fanOuts)
+	require.Equal(t, 3*time.Second, outbox.lease)
+	require.Len(t, outbox.saved, 4)
+
+	ok := outbox.saved[1]
+	require.Equal(t, domWebhook.DeliveryDelivered, ok.Status)
+	require.NotNil(t, ok.DeliveredAt)
+	require.Equal(t, 204, *ok.LastStatusCode)
+	require.Nil(t, ok.LastError)
+
+	// вторая неудачная попытка: пауза base·2
+	retry := outbox.saved[2]
+	require.Equal(t, domWebhook.DeliveryPending, retry.Status)
+	require.Equal(t, 500, *retry.LastStatusCode)
+	require.NotNil(t, retry.LastError)
+	require.WithinDuration(t, start.Add(2*time.Minute), retry.NextAttemptAt, 5*time.Second)
+
+	// попытки исчерпаны
+	require.Equal(t, domWebhook.DeliveryFailed, outbox.saved[3].Status)
+
+	// ответа не было — кода нет, но ошибка записана
+	noResp := outbox.saved[4]
+	require.Equal(t, domWebhook.DeliveryPending, noResp.Status)
+	require.Nil(t, noResp.LastStatusCode)
+	require.Equal(t, "connection refused", *noResp.LastError)
+	require.WithinDuration(t, start.Add(time.Minute), noResp.NextAttemptAt, 5*time.Second)
+}
+
+func TestBackoff(t *testing.T) {
+	base, limit := 30*time.Second, 10*time.Minute
+	require.Equal(t, 30*time.Second, domWebhook.Backoff(1, base, limit))
+	require.Equal(t, time.Minute, domWebhook.Backoff(2, base, limit))
+	require.Equal(t, 8*time.Minute, domWebhook.Backoff(5, base, limit))
+	require.Equal(t, limit, domWebhook.Backoff(6, base, limit))
+	require.Equal(t, limit, domWebhook.Backoff(100, base, limit))
+}
@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	utils "github.com/Neimess/zorkin-store-project/internal/utils/svc"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+)
+
+const (
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 500
+)
+
+type WebhookRepository interface {
+	ListSubscriptions(ctx context.Context) ([]domWebhook.Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*domWebhook.Subscription, error)
+	CreateSubscription(ctx context.Context, s *domWebhook.Subscription) (*domWebhook.Subscription, error)
+	UpdateSubscription(ctx context.Context, s *domWebhook.Subscription) (*domWebhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domWebhook.Delivery, error)
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID int64) error
+}
+
+type Service struct {
+	repo WebhookRepository
+	log  *slog.Logger
+}
+
+type Deps struct {
+	Repo WebhookRepository
+	Log  *slog.Logger
+}
+
+func NewDeps(repo WebhookRepository, log *slog.Logger) (*Deps, error) {
+	if repo == nil {
+		return nil, errors.New("webhook: missing repository")
+	}
+	if log == nil {
+		return nil, errors.New("webhook: missing logger")
+	}
+	return &Deps{Repo: repo, Log: log.With("component", "service.webhook")}, nil
+}
+
+func New(d *Deps) *Service {
+	return &Service{
+		repo: d.Repo,
+		log:  d.Log,
+	}
+}
+
+func (s *Service) ListSubscriptions(ctx context.Context) ([]domWebhook.Subscription, error) {
+	const op = "service.webhook.ListSubscriptions"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return subs, nil
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id int64) (*domWebhook.Subscription, error) {
+	const op = "service.webhook.GetSubscription"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	sub, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domWebhook.ErrSubscriptionNotFound,
+		})
+	}
+	return sub, nil
+}
+
+// CreateSubscription сохраняет подписку. Если секрет не задан,
+// генерируется случайный; он возвращается в ответе один раз.
+func (s *Service) CreateSubscription(ctx context.Context, sub *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+	const op = "service.webhook.CreateSubscription"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, utils.ErrorHandler(log, op, err, nil)
+		}
+		sub.Secret = secret
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.CreateSubscription(ctx, sub)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	log.Info("webhook subscription created", slog.Int64("subscription_id", res.ID), slog.String("url", res.URL))
+	return res, nil
+}
+
+// UpdateSubscription перезаписывает подписку; пустой секрет оставляет прежний.
+func (s *Service) UpdateSubscription(ctx context.Context, sub *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+	const op = "service.webhook.UpdateSubscription"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	cur, err := s.GetSubscription(ctx, sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		sub.Secret = cur.Secret
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.UpdateSubscription(ctx, sub)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domWebhook.ErrSubscriptionNotFound,
+		})
+	}
+	log.Info("webhook subscription updated", slog.Int64("subscription_id", res.ID))
+	return res, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с журналом доставок.
+func (s *Service) DeleteSubscription(ctx context.Context, id int64) error {
+	const op = "service.webhook.DeleteSubscription"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domWebhook.ErrSubscriptionNotFound,
+		})
+	}
+	log.Info("webhook subscription deleted", slog.Int64("subscription_id", id))
+	return nil
+}
+
+// ListDeliveries возвращает последние доставки подписки; limit вне
+// диапазона 1..MaxDeliveriesLimit заменяется значением по умолчанию.
+func (s *Service) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domWebhook.Delivery, error) {
+	const op = "service.webhook.ListDeliveries"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if limit <= 0 || limit > MaxDeliveriesLimit {
+		limit = DefaultDeliveriesLimit
+	}
+	if _, err := s.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.ListDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, utils.ErrorHandler(log, op, err, nil)
+	}
+	return res, nil
+}
+
+// RetryDelivery ставит доставку в очередь заново, например после того,
+// как подписчик починил свой обработчик.
+func (s *Service) RetryDelivery(ctx context.Context, subscriptionID, deliveryID int64) error {
+	const op = "service.webhook.RetryDelivery"
+	log := s.log.With("op", op)
+	ctx, span := telemetry.Start(ctx, op)
+	defer span.End()
+
+	if err := s.repo.RetryDelivery(ctx, subscriptionID, deliveryID); err != nil {
+		return utils.ErrorHandler(log, op, err, map[error]error{
+			der.ErrNotFound: domWebhook.ErrDeliveryNotFound,
+		})
+	}
+	log.Info("webhook delivery requeued", slog.Int64("subscription_id", subscriptionID),
+		slog.Int64("delivery_id", deliveryID))
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
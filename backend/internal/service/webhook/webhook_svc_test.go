@@ -0,0 +1,122 @@
+package webhook_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	webhookSvc "github.com/Neimess/zorkin-store-project/internal/service/webhook"
+	"github.com/Neimess/zorkin-store-project/internal/service/webhook/mocks"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+)
+
+type WebhookServiceSuite struct {
+	suite.Suite
+	svc      *webhookSvc.Service
+	mockRepo *mocks.MockWebhookRepository
+}
+
+func (s *WebhookServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockWebhookRepository(s.T())
+	deps, err := webhookSvc.NewDeps(s.mockRepo, slog.New(slog.DiscardHandler))
+	s.Require().NoError(err)
+	s.svc = webhookSvc.New(deps)
+}
+
+func validSubscription() domWebhook.Subscription {
+	return domWebhook.Subscription{
+		URL:    "https://example.com/hooks/store",
+		Secret: "0123456789abcdef",
+		Events: []domWebhook.EventType{domWebhook.EventProductUpdated, domWebhook.EventPriceUpdated},
+		Active: true,
+	}
+}
+
+func (s *WebhookServiceSuite) TestCreateSubscription_Validation() {
+	cases := []struct {
+		name   string
+		modify func(sub *domWebhook.Subscription)
+		want   error
+	}{
+		{"relative url", func(sub *domWebhook.Subscription) { sub.URL = "/hooks" }, domWebhook.ErrInvalidURL},
+		{"ftp url", func(sub *domWebhook.Subscription) { sub.URL = "ftp://example.com" }, domWebhook.ErrInvalidURL},
+		{"short secret", func(sub *domWebhook.Subscription) { sub.Secret = "short" }, domWebhook.ErrSecretTooShort},
+		{"no events", func(sub *domWebhook.Subscription) { sub.Events = nil }, domWebhook.ErrNoEvents},
+		{"unknown event", func(sub *domWebhook.Subscription) {
+			sub.Events = []domWebhook.EventType{"order.shipped"}
+		}, domWebhook.ErrUnknownEvent},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			sub := validSubscription()
+			tc.modify(&sub)
+			_, err := s.svc.CreateSubscription(context.Background(), &sub)
+			s.ErrorIs(err, tc.want)
+		})
+	}
+}
+
+func (s *WebhookServiceSuite) TestCreateSubscription_GeneratesSecret() {
+	sub := validSubscription()
+	sub.Secret = ""
+	sub.Events = []domWebhook.EventType{domWebhook.EventAll}
+	s.mockRepo.EXPECT().CreateSubscription(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, in *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+			in.ID = 1
+			return in, nil
+		}).Once()
+
+	res, err := s.svc.CreateSubscription(context.Background(), &sub)
+	s.Require().NoError(err)
+	s.Len(res.Secret, 64)
+}
+
+func (s *WebhookServiceSuite) TestUpdateSubscription_KeepsSecret() {
+	cur := validSubscription()
+	cur.ID = 5
+	s.mockRepo.EXPECT().GetSubscription(mock.Anything, int64(5)).Return(&cur, nil).Once()
+	s.mockRepo.EXPECT().UpdateSubscription(mock.Anything, mock.MatchedBy(func(sub *domWebhook.Subscription) bool {
+		return sub.Secret == cur.Secret && !sub.Active
+	})).RunAndReturn(func(_ context.Context, sub *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+		return sub, nil
+	}).Once()
+
+	upd := validSubscription()
+	upd.ID, upd.Secret, upd.Active = 5, "", false
+	_, err := s.svc.UpdateSubscription(context.Background(), &upd)
+	s.NoError(err)
+}
+
+func (s *WebhookServiceSuite) TestUpdateSubscription_NotFound() {
+	s.mockRepo.EXPECT().GetSubscription(mock.Anything, int64(9)).Return(nil, der.ErrNotFound).Once()
+
+	sub := validSubscription()
+	sub.ID = 9
+	_, err := s.svc.UpdateSubscription(context.Background(), &sub)
+	s.ErrorIs(err, domWebhook.ErrSubscriptionNotFound)
+}
+
+func (s *WebhookServiceSuite) TestListDeliveries_ClampsLimit() {
+	sub := validSubscription()
+	s.mockRepo.EXPECT().GetSubscription(mock.Anything, int64(2)).Return(&sub, nil).Once()
+	s.mockRepo.EXPECT().ListDeliveries(mock.Anything, int64(2), webhookSvc.DefaultDeliveriesLimit).
+		Return([]domWebhook.Delivery{}, nil).Once()
+
+	_, err := s.svc.ListDeliveries(context.Background(), 2, 100000)
+	s.NoError(err)
+}
+
+func (s *WebhookServiceSuite) TestRetryDelivery_NotFound() {
+	s.mockRepo.EXPECT().RetryDelivery(mock.Anything, int64(2), int64(7)).Return(der.ErrNotFound).Once()
+
+	err := s.svc.RetryDelivery(context.Background(), 2, 7)
+	s.ErrorIs(err, domWebhook.ErrDeliveryNotFound)
+}
+
+func TestWebhookServiceSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceSuite))
+}
@@ -9,12 +9,14 @@ import (
 	"log/slog"
 
 	attr "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	domBatch "github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/attribute/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/batch"
 	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
 type AttributeService interface {
-	CreateAttributesBatch(ctx context.Context, category_id int64, input []attr.Attribute) error
+	Batch(ctx context.Context, categoryID int64, items []domBatch.Item[*attr.Attribute], atomic bool) ([]domBatch.Result[*attr.Attribute], error)
 	CreateAttribute(ctx context.Context, category_id int64, in *attr.Attribute) (*attr.Attribute, error)
 	GetAttribute(ctx context.Context, categoryID, id int64) (*attr.Attribute, error)
 	ListAttributes(ctx context.Context, categoryID int64) ([]attr.Attribute, error)
@@ -53,39 +55,46 @@ func New(d Deps) *Handler {
 	}
 }
 
-// CreateAttributesBatch godoc
-// @Summary      Batch create attributes
-// @Description  Создать несколько атрибутов в одной категории
+// Batch godoc
+// @Summary      Batch create, update and delete attributes
+// @Description  Пакет операций над атрибутами категории: create, update (по id) и delete (по id).
+// @Description  У каждой операции свой результат. С atomic=true пакет выполняется в одной транзакции:
+// @Description  при первой ошибке всё откатывается (rolled_back), а оставшиеся операции пропускаются (skipped).
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        categoryID path int true "Category ID"
-// @Param        data body dto.CreateAttributesBatchRequest true "Batch input"
-// @Success      204 "No Content"
+// @Param        data body batch.Request[dto.AttributeRequest] true "Batch operations"
+// @Success      200 {object} batch.Response "All operations succeeded"
+// @Success      207 {object} batch.Response "Some operations failed"
 // @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
 // @Failure      422 {object} http_utils.ErrorResponse
 // @Failure      500 {object} http_utils.ErrorResponse
 // @Router       /api/admin/category/{categoryID}/attribute/batch [post]
-func (h *Handler) CreateAttributesBatch(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	categoryID, ok := h.parseCategoryID(w, r)
 	if !ok {
 		return
 	}
 
-	req, ok := http_utils.DecodeAndValidate[dto.CreateAttributesBatchRequest](w, r, h.log)
+	req, ok := http_utils.DecodeAndValidate[batch.Request[dto.AttributeRequest]](w, r, h.log)
 	if !ok {
 		return
 	}
 
-	attrs := req.MapToDomainBatch()
-	if err := h.srv.CreateAttributesBatch(ctx, categoryID, attrs); err != nil {
-		h.handleServiceError(w, err)
+	items := batch.ToDomain(req, func(a *dto.AttributeRequest) *attr.Attribute { return a.MapToDomain() })
+	results, err := h.srv.Batch(ctx, categoryID, items, req.Atomic)
+	if err != nil {
+		h.handleServiceError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	batch.Write(w, r, h.log, req.Atomic, results, func(a *attr.Attribute) (int64, any) {
+		return a.ID, dto.MapToAttributeResponse(a)
+	})
 }
 
 // CreateAttribute godoc
@@ -117,7 +126,7 @@ func (h *Handler) CreateAttribute(w http.ResponseWriter, r *http.Request) {
 	attr.CategoryID = categoryID
 	created, err := h.srv.CreateAttribute(ctx, categoryID, attr)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	w.Header().Set("Location", fmt.Sprintf("/api/admin/category/%d/attribute/%d", categoryID, created.ID))
@@ -144,7 +153,7 @@ func (h *Handler) ListAttributes(w http.ResponseWriter, r *http.Request) {
 
 	attrs, err := h.srv.ListAttributes(ctx, categoryID)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -185,7 +194,7 @@ func (h *Handler) GetAttribute(w http.ResponseWriter, r *http.Request) {
 
 	attr, err := h.srv.GetAttribute(ctx, categoryID, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -233,7 +242,7 @@ func (h *Handler) UpdateAttribute(w http.ResponseWriter, r *http.Request) {
 
 	updated, err := h.srv.UpdateAttribute(ctx, attr)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	http_utils.WriteJSON(w, http.StatusOK, dto.MapToAttributeResponse(updated))
@@ -259,7 +268,7 @@ func (h *Handler) DeleteAttribute(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.srv.DeleteAttribute(ctx, id); err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -15,9 +15,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	domBatch "github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/attribute/dto"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/attribute/mocks"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/batch"
 	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
@@ -38,30 +40,75 @@ func withChiParams(r *http.Request, params map[string]string) *http.Request {
 	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
 }
 
-func TestCreateAttributesBatch_Success(t *testing.T) {
+func TestBatch_Success(t *testing.T) {
 	mockSvc := mocks.NewMockAttributeService(t)
 	h := newHandler(mockSvc)
 
-	input := []map[string]interface{}{{"name": "A", "unit": "u"}}
-	body, err := json.Marshal(map[string]interface{}{"data": input})
-	require.NoError(t, err)
+	body := `{"atomic":false,"items":[
+		{"op":"create","data":{"name":"A","unit":"u"}},
+		{"op":"update","id":5,"data":{"name":"B"}},
+		{"op":"delete","id":6}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/batch", bytes.NewReader([]byte(body)))
+	req = withChiParams(req, map[string]string{"categoryID": "1"})
+	w := httptest.NewRecorder()
+
+	mockSvc.
+		On("Batch", mock.Anything, int64(1), mock.MatchedBy(func(items []domBatch.Item[*attrDom.Attribute]) bool {
+			return len(items) == 3 &&
+				items[0].Op == domBatch.OpCreate && items[0].Value.Name == "A" &&
+				items[1].ID == 5 && items[1].Value.Name == "B" &&
+				items[2].Op == domBatch.OpDelete && items[2].Value == nil
+		}), false).
+		Return([]domBatch.Result[*attrDom.Attribute]{
+			{Op: domBatch.OpCreate, Status: domBatch.StatusOK, Value: &attrDom.Attribute{ID: 10, Name: "A", CategoryID: 1}},
+			{Op: domBatch.OpUpdate, ID: 5, Status: domBatch.StatusFailed, Err: attrDom.ErrAttributeNotFound},
+			{Op: domBatch.OpDelete, ID: 6, Status: domBatch.StatusOK},
+		}, nil).
+		Once()
+
+	h.Batch(w, req)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	var resp batch.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Succeeded)
+	assert.Equal(t, 1, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, int64(10), resp.Results[0].ID)
+	assert.Equal(t, "ok", resp.Results[0].Status)
+	assert.NotNil(t, resp.Results[0].Data)
+	assert.Equal(t, "failed", resp.Results[1].Status)
+	require.NotNil(t, resp.Results[1].Error)
+	assert.Equal(t, "attribute.not_found", resp.Results[1].Error.Code)
+	assert.Equal(t, http.StatusNotFound, resp.Results[1].Error.Status)
+	assert.Equal(t, int64(6), resp.Results[2].ID)
+	assert.Nil(t, resp.Results[2].Data)
+}
 
-	req := httptest.NewRequest(http.MethodPost, "/batch", bytes.NewReader(body))
+func TestBatch_AllOK(t *testing.T) {
+	mockSvc := mocks.NewMockAttributeService(t)
+	h := newHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPost, "/batch", bytes.NewReader([]byte(`{"atomic":true,"items":[{"op":"delete","id":6}]}`)))
 	req = withChiParams(req, map[string]string{"categoryID": "1"})
 	w := httptest.NewRecorder()
 
 	mockSvc.
-		On("CreateAttributesBatch", mock.Anything, int64(1), mock.Anything).
-		Return(nil).
+		On("Batch", mock.Anything, int64(1), mock.Anything, true).
+		Return([]domBatch.Result[*attrDom.Attribute]{{Op: domBatch.OpDelete, ID: 6, Status: domBatch.StatusOK}}, nil).
 		Once()
 
-	h.CreateAttributesBatch(w, req)
+	h.Batch(w, req)
 
-	assert.Equal(t, http.StatusNoContent, w.Code)
-	mockSvc.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp batch.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Atomic)
+	assert.Equal(t, 1, resp.Succeeded)
 }
 
-func TestCreateAttributesBatch_Errors(t *testing.T) {
+func TestBatch_Errors(t *testing.T) {
 	cases := []struct {
 		name           string
 		body           string
@@ -69,7 +116,7 @@ func TestCreateAttributesBatch_Errors(t *testing.T) {
 		svcErr         error
 		wantCode       int
 		wantMsg        string
-		wantValidation bool
+		wantValidation []string
 	}{
 		{
 			name:     "invalid JSON",
@@ -80,42 +127,33 @@ func TestCreateAttributesBatch_Errors(t *testing.T) {
 		},
 		{
 			name:     "invalid category id",
-			body:     `{"data":[{}]}`,
+			body:     `{"items":[{"op":"delete","id":1}]}`,
 			vars:     map[string]string{"categoryID": "abc"},
 			wantCode: http.StatusBadRequest,
 			wantMsg:  "invalid category id",
 		},
 		{
-			name:           "validation error",
-			body:           `{"data":[{"name":""}]}`,
+			name:           "empty batch",
+			body:           `{"items":[]}`,
 			vars:           map[string]string{"categoryID": "1"},
 			wantCode:       http.StatusUnprocessableEntity,
-			wantValidation: true,
+			wantValidation: []string{"items"},
 		},
 		{
-			name:     "service batch empty",
-			body:     `{"data":[{"name":"A"}]}`,
-			vars:     map[string]string{"categoryID": "2"},
-			svcErr:   attrDom.ErrBatchEmpty,
-			wantCode: http.StatusBadRequest,
-			wantMsg:  "no attributes provided for batch",
+			name:           "invalid items",
+			body:           `{"items":[{"op":"create","data":{"name":""}},{"op":"update","data":{"name":"A"}},{"op":"merge"},{"op":"create"}]}`,
+			vars:           map[string]string{"categoryID": "1"},
+			wantCode:       http.StatusUnprocessableEntity,
+			wantValidation: []string{"items[0].data.name", "items[1].id", "items[2].op", "items[3].data"},
 		},
 		{
 			name:     "service category not found",
-			body:     `{"data":[{"name":"A"}]}`,
+			body:     `{"items":[{"op":"create","data":{"name":"A"}}]}`,
 			vars:     map[string]string{"categoryID": "3"},
 			svcErr:   catDom.ErrCategoryNotFound,
 			wantCode: http.StatusNotFound,
 			wantMsg:  "category not found",
 		},
-		{
-			name:     "service conflict",
-			body:     `{"data":[{"name":"A"}]}`,
-			vars:     map[string]string{"categoryID": "4"},
-			svcErr:   attrDom.ErrAttributeAlreadyExists,
-			wantCode: http.StatusConflict,
-			wantMsg:  "attribute already exists",
-		},
 	}
 
 	for _, tc := range cases {
@@ -132,19 +170,23 @@ func TestCreateAttributesBatch_Errors(t *testing.T) {
 
 			if tc.svcErr != nil {
 				mockSvc.
-					On("CreateAttributesBatch", mock.Anything, mock.Anything, mock.Anything).
-					Return(tc.svcErr).
+					On("Batch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, tc.svcErr).
 					Once()
 			}
 
-			h.CreateAttributesBatch(w, req)
+			h.Batch(w, req)
 			assert.Equal(t, tc.wantCode, w.Code)
 
-			if tc.wantValidation {
+			if tc.wantValidation != nil {
 				var resp http_utils.ValidationErrorResponse
 				err := json.Unmarshal(w.Body.Bytes(), &resp)
 				require.NoError(t, err)
-				assert.NotEmpty(t, resp.Errors)
+				fields := make([]string, len(resp.Errors))
+				for i, fe := range resp.Errors {
+					fields[i] = fe.Field
+				}
+				assert.Equal(t, tc.wantValidation, fields)
 			} else {
 				var resp http_utils.ErrorResponse
 				err := json.Unmarshal(w.Body.Bytes(), &resp)
@@ -1,12 +1,9 @@
 package attribute
 
 import (
-	"errors"
-	"log/slog"
 	"net/http"
 
-	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
-	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
@@ -28,27 +25,6 @@ func (h *Handler) parseAttributeID(w http.ResponseWriter, r *http.Request) (int6
 	return id, true
 }
 
-func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	// атрибут не найден
-	case errors.Is(err, attrDom.ErrAttributeNotFound):
-		http_utils.WriteError(w, http.StatusNotFound, "attribute not found")
-
-	// конфликт уникальности имени атрибута
-	case errors.Is(err, attrDom.ErrAttributeAlreadyExists):
-		http_utils.WriteError(w, http.StatusConflict, "attribute already exists")
-
-	// категория не найдена
-	case errors.Is(err, catDom.ErrCategoryNotFound):
-		http_utils.WriteError(w, http.StatusNotFound, "category not found")
-
-	// пустой батч
-	case errors.Is(err, attrDom.ErrBatchEmpty):
-		http_utils.WriteError(w, http.StatusBadRequest, "no attributes provided for batch")
-
-	// всё прочее — 500
-	default:
-		h.log.Error("service error", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusInternalServerError, "internal server error")
-	}
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
 }
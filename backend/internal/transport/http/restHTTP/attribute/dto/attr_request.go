@@ -1,10 +1,9 @@
 package dto
 
 import (
-	"fmt"
-
 	attr "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
 	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -25,20 +24,11 @@ func (r AttributeRequest) Validate() error {
 		for _, e := range validationErrors {
 			switch e.Field() {
 			case "Name":
-				errs = append(errs, ve.FieldError{
-					Field:   "name",
-					Message: "name is required and must be between 1 and 255 characters",
-				})
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgName1To255))
 			case "Unit":
-				errs = append(errs, ve.FieldError{
-					Field:   "unit",
-					Message: "unit must not exceed 50 characters",
-				})
+				errs = append(errs, ve.NewFieldError("unit", i18n.MsgUnitTooLong))
 			default:
-				errs = append(errs, ve.FieldError{
-					Field:   e.Field(),
-					Message: "invalid field",
-				})
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
 			}
 		}
 	}
@@ -51,59 +41,9 @@ func (r AttributeRequest) Validate() error {
 	return nil
 }
 
-type CreateAttributesBatchRequest struct {
-	Items []AttributeRequest `json:"data" validate:"required,min=1,dive"`
-}
-
-func (r CreateAttributesBatchRequest) Validate() error {
-	var errs []ve.FieldError
-	if err := validate.Struct(r); err != nil {
-		if _, ok := err.(*validator.InvalidValidationError); ok {
-			return err
-		}
-		validationErrors := err.(validator.ValidationErrors)
-		for _, e := range validationErrors {
-			errs = append(errs, ve.FieldError{
-				Field:   e.Field(),
-				Message: e.Error(),
-			})
-		}
-	}
-	for idx, item := range r.Items {
-		if err := item.Validate(); err != nil {
-			if veResp, ok := err.(ve.ValidationErrorResponse); ok {
-				for _, ferr := range veResp.Errors {
-					ferr.Field = fmt.Sprintf("data[%d].%s", idx, ferr.Field)
-					errs = append(errs, ferr)
-				}
-			} else {
-				errs = append(errs, ve.FieldError{
-					Field:   fmt.Sprintf("data[%d]", idx),
-					Message: err.Error(),
-				})
-			}
-		}
-	}
-	if len(errs) > 0 {
-		return ve.ValidationErrorResponse{Errors: errs}
-	}
-	return nil
-}
-
 func (r AttributeRequest) MapToDomain() *attr.Attribute {
 	return &attr.Attribute{
 		Name: r.Name,
 		Unit: r.Unit,
 	}
 }
-
-func (r CreateAttributesBatchRequest) MapToDomainBatch() []attr.Attribute {
-	out := make([]attr.Attribute, len(r.Items))
-	for i, it := range r.Items {
-		out[i] = attr.Attribute{
-			Name: it.Name,
-			Unit: it.Unit,
-		}
-	}
-	return out
-}
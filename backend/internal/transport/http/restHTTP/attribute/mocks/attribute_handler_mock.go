@@ -7,7 +7,8 @@ package mocks
 import (
 	"context"
 
-	"github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	attr "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,48 +39,49 @@ func (_m *MockAttributeService) EXPECT() *MockAttributeService_Expecter {
 	return &MockAttributeService_Expecter{mock: &_m.Mock}
 }
 
-// CreateAttribute provides a mock function for the type MockAttributeService
-func (_mock *MockAttributeService) CreateAttribute(ctx context.Context, category_id int64, in *attr.Attribute) (*attr.Attribute, error) {
-	ret := _mock.Called(ctx, category_id, in)
+// Batch provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) Batch(ctx context.Context, categoryID int64, items []batch.Item[*attr.Attribute], atomic bool) ([]batch.Result[*attr.Attribute], error) {
+	ret := _mock.Called(ctx, categoryID, items, atomic)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAttribute")
+		panic("no return value specified for Batch")
 	}
 
-	var r0 *attr.Attribute
+	var r0 []batch.Result[*attr.Attribute]
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *attr.Attribute) (*attr.Attribute, error)); ok {
-		return returnFunc(ctx, category_id, in)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []batch.Item[*attr.Attribute], bool) ([]batch.Result[*attr.Attribute], error)); ok {
+		return returnFunc(ctx, categoryID, items, atomic)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *attr.Attribute) *attr.Attribute); ok {
-		r0 = returnFunc(ctx, category_id, in)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []batch.Item[*attr.Attribute], bool) []batch.Result[*attr.Attribute]); ok {
+		r0 = returnFunc(ctx, categoryID, items, atomic)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*attr.Attribute)
+			r0 = ret.Get(0).([]batch.Result[*attr.Attribute])
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, *attr.Attribute) error); ok {
-		r1 = returnFunc(ctx, category_id, in)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []batch.Item[*attr.Attribute], bool) error); ok {
+		r1 = returnFunc(ctx, categoryID, items, atomic)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAttributeService_CreateAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttribute'
-type MockAttributeService_CreateAttribute_Call struct {
+// MockAttributeService_Batch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Batch'
+type MockAttributeService_Batch_Call struct {
 	*mock.Call
 }
 
-// CreateAttribute is a helper method to define mock.On call
+// Batch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - category_id int64
-//   - in *attr.Attribute
-func (_e *MockAttributeService_Expecter) CreateAttribute(ctx interface{}, category_id interface{}, in interface{}) *MockAttributeService_CreateAttribute_Call {
-	return &MockAttributeService_CreateAttribute_Call{Call: _e.mock.On("CreateAttribute", ctx, category_id, in)}
+//   - categoryID int64
+//   - items []batch.Item[*attr.Attribute]
+//   - atomic bool
+func (_e *MockAttributeService_Expecter) Batch(ctx interface{}, categoryID interface{}, items interface{}, atomic interface{}) *MockAttributeService_Batch_Call {
+	return &MockAttributeService_Batch_Call{Call: _e.mock.On("Batch", ctx, categoryID, items, atomic)}
 }
 
-func (_c *MockAttributeService_CreateAttribute_Call) Run(run func(ctx context.Context, category_id int64, in *attr.Attribute)) *MockAttributeService_CreateAttribute_Call {
+func (_c *MockAttributeService_Batch_Call) Run(run func(ctx context.Context, categoryID int64, items []batch.Item[*attr.Attribute], atomic bool)) *MockAttributeService_Batch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -89,60 +91,76 @@ func (_c *MockAttributeService_CreateAttribute_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 *attr.Attribute
+		var arg2 []batch.Item[*attr.Attribute]
 		if args[2] != nil {
-			arg2 = args[2].(*attr.Attribute)
+			arg2 = args[2].([]batch.Item[*attr.Attribute])
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAttributeService_CreateAttribute_Call) Return(attribute *attr.Attribute, err error) *MockAttributeService_CreateAttribute_Call {
-	_c.Call.Return(attribute, err)
+func (_c *MockAttributeService_Batch_Call) Return(results []batch.Result[*attr.Attribute], err error) *MockAttributeService_Batch_Call {
+	_c.Call.Return(results, err)
 	return _c
 }
 
-func (_c *MockAttributeService_CreateAttribute_Call) RunAndReturn(run func(ctx context.Context, category_id int64, in *attr.Attribute) (*attr.Attribute, error)) *MockAttributeService_CreateAttribute_Call {
+func (_c *MockAttributeService_Batch_Call) RunAndReturn(run func(ctx context.Context, categoryID int64, items []batch.Item[*attr.Attribute], atomic bool) ([]batch.Result[*attr.Attribute], error)) *MockAttributeService_Batch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateAttributesBatch provides a mock function for the type MockAttributeService
-func (_mock *MockAttributeService) CreateAttributesBatch(ctx context.Context, category_id int64, input []attr.Attribute) error {
-	ret := _mock.Called(ctx, category_id, input)
+// CreateAttribute provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) CreateAttribute(ctx context.Context, category_id int64, in *attr.Attribute) (*attr.Attribute, error) {
+	ret := _mock.Called(ctx, category_id, in)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAttributesBatch")
+		panic("no return value specified for CreateAttribute")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []attr.Attribute) error); ok {
-		r0 = returnFunc(ctx, category_id, input)
+	var r0 *attr.Attribute
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *attr.Attribute) (*attr.Attribute, error)); ok {
+		return returnFunc(ctx, category_id, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *attr.Attribute) *attr.Attribute); ok {
+		r0 = returnFunc(ctx, category_id, in)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attr.Attribute)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, *attr.Attribute) error); ok {
+		r1 = returnFunc(ctx, category_id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockAttributeService_CreateAttributesBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttributesBatch'
-type MockAttributeService_CreateAttributesBatch_Call struct {
+// MockAttributeService_CreateAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttribute'
+type MockAttributeService_CreateAttribute_Call struct {
 	*mock.Call
 }
 
-// CreateAttributesBatch is a helper method to define mock.On call
+// CreateAttribute is a helper method to define mock.On call
 //   - ctx context.Context
 //   - category_id int64
-//   - input []attr.Attribute
-func (_e *MockAttributeService_Expecter) CreateAttributesBatch(ctx interface{}, category_id interface{}, input interface{}) *MockAttributeService_CreateAttributesBatch_Call {
-	return &MockAttributeService_CreateAttributesBatch_Call{Call: _e.mock.On("CreateAttributesBatch", ctx, category_id, input)}
+//   - in *attr.Attribute
+func (_e *MockAttributeService_Expecter) CreateAttribute(ctx interface{}, category_id interface{}, in interface{}) *MockAttributeService_CreateAttribute_Call {
+	return &MockAttributeService_CreateAttribute_Call{Call: _e.mock.On("CreateAttribute", ctx, category_id, in)}
 }
 
-func (_c *MockAttributeService_CreateAttributesBatch_Call) Run(run func(ctx context.Context, category_id int64, input []attr.Attribute)) *MockAttributeService_CreateAttributesBatch_Call {
+func (_c *MockAttributeService_CreateAttribute_Call) Run(run func(ctx context.Context, category_id int64, in *attr.Attribute)) *MockAttributeService_CreateAttribute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -152,9 +170,9 @@ func (_c *MockAttributeService_CreateAttributesBatch_Call) Run(run func(ctx cont
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 []attr.Attribute
+		var arg2 *attr.Attribute
 		if args[2] != nil {
-			arg2 = args[2].([]attr.Attribute)
+			arg2 = args[2].(*attr.Attribute)
 		}
 		run(
 			arg0,
@@ -165,12 +183,12 @@ func (_c *MockAttributeService_CreateAttributesBatch_Call) Run(run func(ctx cont
 	return _c
 }
 
-func (_c *MockAttributeService_CreateAttributesBatch_Call) Return(err error) *MockAttributeService_CreateAttributesBatch_Call {
-	_c.Call.Return(err)
+func (_c *MockAttributeService_CreateAttribute_Call) Return(attribute *attr.Attribute, err error) *MockAttributeService_CreateAttribute_Call {
+	_c.Call.Return(attribute, err)
 	return _c
 }
 
-func (_c *MockAttributeService_CreateAttributesBatch_Call) RunAndReturn(run func(ctx context.Context, category_id int64, input []attr.Attribute) error) *MockAttributeService_CreateAttributesBatch_Call {
+func (_c *MockAttributeService_CreateAttribute_Call) RunAndReturn(run func(ctx context.Context, category_id int64, in *attr.Attribute) (*attr.Attribute, error)) *MockAttributeService_CreateAttribute_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -52,6 +52,7 @@ func New(d Deps) *Handler {
 // @Param        secret_admin_key  path      string  true  "Secret admin key for login, injected via route"
 // @Success      201  {object}  dto.TokenResponse  "Returns generated token"
 // @Failure      401  {object}  http_utils.ErrorResponse  "Unauthorized access"
+// @Failure      429  {object}  http_utils.ErrorResponse  "Too many login attempts"
 // @Failure      500  {object}  http_utils.ErrorResponse  "Internal server error"
 // @Router       /api/admin/auth/{secret_admin_key} [get]
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
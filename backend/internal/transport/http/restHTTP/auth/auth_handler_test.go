@@ -40,6 +40,11 @@ func TestLogin(t *testing.T) {
 			mockErr:    errors.New("something went wrong"),
 			wantStatus: http.StatusInternalServerError,
 			wantBodyStruct: &http_utils.ErrorResponse{
+				Type:    "/api/errors/internal",
+				Title:   "internal server error",
+				Status:  http.StatusInternalServerError,
+				Code:    "internal",
+				Detail:  "failed to generate token",
 				Message: "failed to generate token",
 			},
 		},
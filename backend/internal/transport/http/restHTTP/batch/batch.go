@@ -0,0 +1,146 @@
+// Package batch — общий формат пакетных эндпоинтов: запрос со списком
+// операций create/update/delete и ответ с результатом каждой из них.
+package batch
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	domBatch "github.com/Neimess/zorkin-store-project/internal/domain/batch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// Item — одна операция. id обязателен для update и delete, data — для create и update.
+// version — ETag сущности для update и delete: если её успели изменить, операция
+// получает 412, как одиночный запрос с If-Match. У товаров и услуг version
+// обязателен, без него операция получает 428.
+type Item[T http_utils.Validatable] struct {
+	Op      string `json:"op" example:"create" enums:"create,update,delete"`
+	ID      int64  `json:"id,omitempty" example:"0"`
+	Version int64  `json:"version,omitempty" example:"0"`
+	Data    *T     `json:"data,omitempty"`
+}
+
+type Request[T http_utils.Validatable] struct {
+	// Atomic — выполнить все операции в одной транзакции: при первой
+	// ошибке ни одна не применяется.
+	Atomic bool      `json:"atomic" example:"false"`
+	Items  []Item[T] `json:"items"`
+}
+
+func (r Request[T]) Validate() error {
+	var errs []http_utils.FieldError
+	switch {
+	case len(r.Items) == 0:
+		errs = append(errs, http_utils.NewFieldError("items", i18n.MsgBatchEmpty))
+	case len(r.Items) > domBatch.MaxItems:
+		errs = append(errs, http_utils.NewFieldError("items", i18n.MsgBatchTooMany.With(domBatch.MaxItems)))
+	}
+
+	for i, it := range r.Items {
+		prefix := fmt.Sprintf("items[%d]", i)
+		op := domBatch.Op(it.Op)
+		if !op.Valid() {
+			errs = append(errs, http_utils.NewFieldError(prefix+".op", i18n.MsgBatchOp))
+			continue
+		}
+		if op != domBatch.OpCreate && it.ID <= 0 {
+			errs = append(errs, http_utils.NewFieldError(prefix+".id", i18n.MsgBatchIDRequired))
+		}
+		switch {
+		case it.Version < 0:
+			errs = append(errs, http_utils.NewFieldError(prefix+".version", i18n.MsgVersionPositive))
+		case op == domBatch.OpCreate && it.Version != 0:
+			errs = append(errs, http_utils.NewFieldError(prefix+".version", i18n.MsgBatchVersionCreate))
+		}
+		if op == domBatch.OpDelete {
+			continue
+		}
+		if it.Data == nil {
+			errs = append(errs, http_utils.NewFieldError(prefix+".data", i18n.MsgBatchDataRequired))
+			continue
+		}
+		if err := (*it.Data).Validate(); err != nil {
+			if ve, ok := err.(http_utils.ValidationErrorResponse); ok {
+				for _, fe := range ve.Errors {
+					fe.Field = prefix + ".data." + fe.Field
+					errs = append(errs, fe)
+				}
+			} else {
+				errs = append(errs, http_utils.NewFieldError(prefix+".data", i18n.Raw(err.Error())))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return http_utils.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
+
+// ToDomain переводит операции в доменные; toDomain вызывается для create и update.
+func ToDomain[T http_utils.Validatable, V any](r *Request[T], toDomain func(*T) V) []domBatch.Item[V] {
+	out := make([]domBatch.Item[V], len(r.Items))
+	for i, it := range r.Items {
+		out[i] = domBatch.Item[V]{Op: domBatch.Op(it.Op), ID: it.ID, Version: it.Version}
+		if it.Data != nil {
+			out[i].Value = toDomain(it.Data)
+		}
+	}
+	return out
+}
+
+type ItemResult struct {
+	Index int    `json:"index" example:"0"`
+	Op    string `json:"op" example:"create"`
+	// ID — id созданной или изменённой сущности.
+	ID     int64  `json:"id,omitempty" example:"42"`
+	Status string `json:"status" example:"ok" enums:"ok,failed,rolled_back,skipped"`
+	// Data — сущность после create/update в том же виде, что отдаёт одиночный эндпоинт.
+	Data  any                       `json:"data,omitempty"`
+	Error *http_utils.ErrorResponse `json:"error,omitempty"`
+}
+
+type Response struct {
+	Atomic    bool         `json:"atomic" example:"false"`
+	Succeeded int          `json:"succeeded" example:"2"`
+	Failed    int          `json:"failed" example:"1"`
+	Results   []ItemResult `json:"results"`
+}
+
+// Write отвечает результатами пакета: 200, если все операции выполнены, иначе 207.
+// view превращает сущность после create/update в её id и тело ответа.
+func Write[V any](
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	atomic bool,
+	results []domBatch.Result[V],
+	view func(V) (int64, any),
+) {
+	resp := Response{Atomic: atomic, Results: make([]ItemResult, len(results))}
+	for i, res := range results {
+		item := ItemResult{Index: i, Op: string(res.Op), ID: res.ID, Status: string(res.Status)}
+		switch res.Status {
+		case domBatch.StatusOK:
+			resp.Succeeded++
+			if res.Op != domBatch.OpDelete {
+				item.ID, item.Data = view(res.Value)
+			}
+		case domBatch.StatusFailed:
+			resp.Failed++
+			p := problems.For(r, log, res.Err)
+			item.Error = &p
+		}
+		resp.Results[i] = item
+	}
+
+	status := http.StatusOK
+	if resp.Succeeded != len(results) {
+		status = http.StatusMultiStatus
+	}
+	http_utils.WriteJSON(w, status, resp)
+}
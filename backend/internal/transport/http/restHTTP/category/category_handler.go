@@ -9,6 +9,8 @@ import (
 
 	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/category/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/patch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
@@ -16,6 +18,7 @@ type CategoryService interface {
 	CreateCategory(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
 	GetCategory(ctx context.Context, id int64) (*catDom.Category, error)
 	UpdateCategory(ctx context.Context, cat *catDom.Category) (*catDom.Category, error)
+	PatchCategory(ctx context.Context, p *catDom.Patch) (*catDom.Category, error)
 	DeleteCategory(ctx context.Context, id int64) error
 	ListCategories(ctx context.Context) ([]catDom.Category, error)
 }
@@ -75,7 +78,7 @@ func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 	cat := req.ToDomainCreate()
 	created, err := h.srv.CreateCategory(ctx, cat)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	w.Header().Set("Location", fmt.Sprintf("/api/category/%d", created.ID))
@@ -104,10 +107,11 @@ func (h *Handler) GetCategory(w http.ResponseWriter, r *http.Request) {
 	}
 	cat, err := h.srv.GetCategory(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.ToDTOResponse(cat)
+	http_utils.SetETag(w, cat.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
@@ -124,7 +128,7 @@ func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cats, err := h.srv.ListCategories(ctx)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := make([]dto.CategoryResponse, 0, len(cats))
@@ -143,10 +147,13 @@ func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
 //		@Produce		json
 //	 	@Security       BearerAuth
 //		@Param			id			path	int							true	"Category ID"
+//		@Param			If-Match	header	string	true	"ETag текущей версии или *"
 //		@Param			category	body	dto.CategoryRequest	true	"New name"
 //		@Success		200	{object}	dto.CategoryResponse
 //		@Failure		400	{object}	http_utils.ErrorResponse
 //		@Failure		404	{object}	http_utils.ErrorResponse
+//		@Failure		412	{object}	http_utils.ErrorResponse
+//		@Failure		428	{object}	http_utils.ErrorResponse
 //		@Failure		500	{object}	http_utils.ErrorResponse
 //		@Router			/api/admin/category/{id} [put]
 func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
@@ -167,9 +174,61 @@ func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
 
 	updated, err := h.srv.UpdateCategory(ctx, cat)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
+	http_utils.SetETag(w, updated.Version)
+	http_utils.WriteJSON(w, http.StatusOK, dto.ToDTOResponse(updated))
+}
+
+// -----------------------------------------------------------------------------
+// PatchCategory godoc
+//
+//		@Summary		Partially update category
+//		@Description	JSON Merge Patch (application/merge-patch+json, application/json) или JSON Patch (application/json-patch+json)
+//		@Tags			categories
+//		@Accept			json
+//		@Produce		json
+//	 	@Security       BearerAuth
+//		@Param			id		path	int		true	"Category ID"
+//		@Param			If-Match	header	string	true	"ETag текущей версии или *"
+//		@Param			patch	body	object	true	"Merge patch or JSON Patch document"
+//		@Success		200	{object}	dto.CategoryResponse
+//		@Failure		400	{object}	http_utils.ErrorResponse
+//		@Failure		404	{object}	http_utils.ErrorResponse
+//		@Failure		409	{object}	http_utils.ErrorResponse
+//		@Failure		415	{object}	http_utils.ErrorResponse
+//		@Failure		422	{object}	http_utils.ErrorResponse
+//		@Failure		412	{object}	http_utils.ErrorResponse
+//		@Failure		428	{object}	http_utils.ErrorResponse
+//		@Failure		500	{object}	http_utils.ErrorResponse
+//		@Router			/api/admin/category/{id} [patch]
+func (h *Handler) PatchCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "PatchCategory")
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid category id")
+		return
+	}
+
+	cur, err := h.srv.GetCategory(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	before := dto.ToRequest(cur)
+	after, ok := patch.Apply(w, r, log, &before)
+	if !ok {
+		return
+	}
+
+	updated, err := h.srv.PatchCategory(ctx, dto.ToDomainPatch(cur.ID, cur.Version, &before, after))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, updated.Version)
 	http_utils.WriteJSON(w, http.StatusOK, dto.ToDTOResponse(updated))
 }
 
@@ -181,9 +240,12 @@ func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
 //		@Produce		json
 //	 	@Security       BearerAuth
 //		@Param			id	path	int	true	"Category ID"
+//		@Param			If-Match	header	string	true	"ETag текущей версии или *"
 //		@Success		204
 //		@Failure		400	{object}	http_utils.ErrorResponse
 //		@Failure		404	{object}	http_utils.ErrorResponse
+//		@Failure		412	{object}	http_utils.ErrorResponse
+//		@Failure		428	{object}	http_utils.ErrorResponse
 //		@Failure		500	{object}	http_utils.ErrorResponse
 //		@Router			/api/admin/category/{id} [delete]
 func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
@@ -194,32 +256,12 @@ func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.srv.DeleteCategory(ctx, id); err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, catDom.ErrCategoryNotFound):
-		http_utils.WriteError(w, http.StatusNotFound, "category not found")
-
-	case errors.Is(err, catDom.ErrCategoryNameEmpty):
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "category name cannot be empty")
-
-	// Слишком длинное имя
-	case errors.Is(err, catDom.ErrCategoryNameTooLong):
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "category name must be at most 255 characters")
-
-	// Попытка удалить категорию, которая используется
-	case errors.Is(err, catDom.ErrCategoryInUse):
-		http_utils.WriteError(w, http.StatusConflict, "category is in use and cannot be deleted")
-	case errors.Is(err, catDom.ErrCategoryNameExists):
-		http_utils.WriteError(w, http.StatusConflict, "category name already exist")
-	// Всё прочее — Internal Server Error
-	default:
-		h.log.Error("service error", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusInternalServerError, "internal server error")
-	}
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
 }
@@ -2,6 +2,7 @@ package dto
 
 import (
 	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -25,20 +26,11 @@ func (r CategoryRequest) Validate() error {
 		for _, e := range validationErrors {
 			switch e.Field() {
 			case "Name":
-				errs = append(errs, ve.FieldError{
-					Field:   "name",
-					Message: "name is required and must be between 2 and 255 characters",
-				})
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgName2To255))
 			case "ParentID":
-				errs = append(errs, ve.FieldError{
-					Field:   "parent_id",
-					Message: "parent_id may be does not exists",
-				})
+				errs = append(errs, ve.NewFieldError("parent_id", i18n.MsgParentID))
 			default:
-				errs = append(errs, ve.FieldError{
-					Field:   e.Field(),
-					Message: "invalid field",
-				})
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
 			}
 		}
 	}
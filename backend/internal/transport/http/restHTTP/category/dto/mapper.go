@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/Neimess/zorkin-store-project/internal/domain/category"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
 )
 
 func (in *CategoryRequest) ToDomainCreate() *category.Category {
@@ -34,3 +35,21 @@ func ToDTOList(cats []category.Category, total int64) []CategoryResponse {
 	}
 	return out
 }
+
+// ToRequest — тело PUT для текущей категории, к нему применяется PATCH.
+func ToRequest(cat *category.Category) CategoryRequest {
+	return CategoryRequest{
+		Name:     cat.Name,
+		ParentID: cat.ParentID,
+	}
+}
+
+// ToDomainPatch оставляет в патче только изменившиеся поля.
+func ToDomainPatch(id, version int64, before, after *CategoryRequest) *category.Patch {
+	return &category.Patch{
+		ID:       id,
+		Version:  version,
+		Name:     patch.Diff(before.Name, after.Name),
+		ParentID: patch.DiffPtr(before.ParentID, after.ParentID),
+	}
+}
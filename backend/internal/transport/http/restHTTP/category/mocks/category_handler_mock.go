@@ -293,6 +293,74 @@ func (_c *MockCategoryService_ListCategories_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// PatchCategory provides a mock function for the type MockCategoryService
+func (_mock *MockCategoryService) PatchCategory(ctx context.Context, p *category.Patch) (*category.Category, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchCategory")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *category.Patch) (*category.Category, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *category.Patch) *category.Category); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *category.Patch) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCategoryService_PatchCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchCategory'
+type MockCategoryService_PatchCategory_Call struct {
+	*mock.Call
+}
+
+// PatchCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *category.Patch
+func (_e *MockCategoryService_Expecter) PatchCategory(ctx interface{}, p interface{}) *MockCategoryService_PatchCategory_Call {
+	return &MockCategoryService_PatchCategory_Call{Call: _e.mock.On("PatchCategory", ctx, p)}
+}
+
+func (_c *MockCategoryService_PatchCategory_Call) Run(run func(ctx context.Context, p *category.Patch)) *MockCategoryService_PatchCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *category.Patch
+		if args[1] != nil {
+			arg1 = args[1].(*category.Patch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryService_PatchCategory_Call) Return(category1 *category.Category, err error) *MockCategoryService_PatchCategory_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *MockCategoryService_PatchCategory_Call) RunAndReturn(run func(ctx context.Context, p *category.Patch) (*category.Category, error)) *MockCategoryService_PatchCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateCategory provides a mock function for the type MockCategoryService
 func (_mock *MockCategoryService) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	ret := _mock.Called(ctx, cat)
@@ -9,6 +9,8 @@ import (
 
 	domCoeff "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/coefficients/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/patch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
@@ -17,6 +19,7 @@ type CoefficientService interface {
 	Get(ctx context.Context, id int64) (*domCoeff.Coefficient, error)
 	List(ctx context.Context) ([]domCoeff.Coefficient, error)
 	Update(ctx context.Context, c *domCoeff.Coefficient) (*domCoeff.Coefficient, error)
+	Patch(ctx context.Context, p *domCoeff.Patch) (*domCoeff.Coefficient, error)
 	Delete(ctx context.Context, id int64) error
 }
 
@@ -69,7 +72,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	coeff := dto.MapToDomain(req)
 	created, err := h.srv.Create(ctx, coeff)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapToResponse(created)
@@ -98,10 +101,11 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 	}
 	coeff, err := h.srv.Get(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapToResponse(coeff)
+	http_utils.SetETag(w, coeff.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
@@ -134,11 +138,14 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Coefficient ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
 // @Param        data body dto.CoefficientRequest true "Coefficient data"
 // @Success      200 {object} dto.CoefficientResponse
 // @Failure      400 {object} http_utils.ErrorResponse
 // @Failure      409 {object} http_utils.ErrorResponse
 // @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
 // @Failure      500 {object} http_utils.ErrorResponse
 // @Router       /api/admin/coefficients/{id} [put]
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
@@ -156,22 +163,73 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	coeff.ID = id
 	updated, err := h.srv.Update(ctx, coeff)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapToResponse(updated)
+	http_utils.SetETag(w, updated.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
+// Patch godoc
+// @Summary      Partially update coefficient
+// @Description  Частично обновить коэффициент: JSON Merge Patch (application/merge-patch+json, application/json)
+// @Description  или JSON Patch (application/json-patch+json).
+// @Tags         coefficients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Coefficient ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
+// @Param        patch body object true "Merge patch or JSON Patch document"
+// @Success      200 {object} dto.CoefficientResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      415 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/coefficients/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	cur, err := h.srv.Get(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	before := dto.MapToRequest(cur)
+	after, ok := patch.Apply(w, r, h.log, &before)
+	if !ok {
+		return
+	}
+	updated, err := h.srv.Patch(ctx, dto.MapToPatch(cur.ID, cur.Version, &before, after))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, updated.Version)
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponse(updated))
+}
+
 // Delete godoc
 // @Summary      Delete coefficient
 // @Description  Удалить коэффициент по ID
 // @Tags         coefficients
 // @Security     BearerAuth
 // @Param        id path int true "Coefficient ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
 // @Success      204
 // @Failure      400 {object} http_utils.ErrorResponse
 // @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
 // @Failure      500 {object} http_utils.ErrorResponse
 // @Router       /api/admin/coefficients/{id} [delete]
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -183,24 +241,12 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	err = h.srv.Delete(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, domCoeff.ErrCoefficientNotFound):
-		http_utils.WriteError(w, http.StatusNotFound, "coefficient not found")
-	case errors.Is(err, domCoeff.ErrCoefficientAlreadyExists):
-		http_utils.WriteError(w, http.StatusConflict, "coefficient already exists")
-	case errors.Is(err, domCoeff.ErrEmptyName):
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "name must not be empty")
-	case errors.Is(err, domCoeff.ErrNameTooLong):
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "name is too long")
-	default:
-		h.log.Error("service error", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusInternalServerError, "internal server error")
-	}
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
 }
@@ -37,6 +37,10 @@ func (m *MockService) Update(ctx context.Context, c *domCoeff.Coefficient) (*dom
 	args := m.Called(ctx, c)
 	return args.Get(0).(*domCoeff.Coefficient), args.Error(1)
 }
+func (m *MockService) Patch(ctx context.Context, p *domCoeff.Patch) (*domCoeff.Coefficient, error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).(*domCoeff.Coefficient), args.Error(1)
+}
 func (m *MockService) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
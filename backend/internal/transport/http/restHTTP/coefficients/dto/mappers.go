@@ -1,6 +1,9 @@
 package dto
 
-import domCoeff "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
+import (
+	domCoeff "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
+)
 
 func MapToDomain(r *CoefficientRequest) *domCoeff.Coefficient {
 	return &domCoeff.Coefficient{
@@ -24,3 +27,18 @@ func MapToResponseList(list []domCoeff.Coefficient) []CoefficientResponse {
 	}
 	return resp
 }
+
+// MapToRequest — текущий коэффициент в виде тела PUT, к нему применяется PATCH.
+func MapToRequest(c *domCoeff.Coefficient) CoefficientRequest {
+	return CoefficientRequest{Name: c.Name, Value: c.Value}
+}
+
+// MapToPatch оставляет в патче только изменившиеся поля.
+func MapToPatch(id, version int64, before, after *CoefficientRequest) *domCoeff.Patch {
+	return &domCoeff.Patch{
+		ID:      id,
+		Version: version,
+		Name:    patch.Diff(before.Name, after.Name),
+		Value:   patch.Diff(before.Value, after.Value),
+	}
+}
@@ -0,0 +1,269 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+// CurrencyQueryParam — параметр запроса с валютой цен в ответе.
+const CurrencyQueryParam = "currency"
+
+type CurrencyService interface {
+	ListRates(ctx context.Context) ([]money.Rate, error)
+	Supported(ctx context.Context, c money.Currency) error
+	PutRate(ctx context.Context, rate *money.Rate) (*money.Rate, error)
+	DeleteRate(ctx context.Context, c money.Currency) error
+	ListOverrides(ctx context.Context, entity money.Entity, id int64) ([]money.PriceOverride, error)
+	PutOverride(ctx context.Context, o *money.PriceOverride) (*money.PriceOverride, error)
+	DeleteOverride(ctx context.Context, entity money.Entity, id int64, c money.Currency) error
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv CurrencyService
+}
+
+func NewDeps(log *slog.Logger, srv CurrencyService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("currency: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("currency: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.currency"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv CurrencyService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// ResolveCurrency — middleware публичных маршрутов: разбирает ?currency=,
+// проверяет, что для валюты задан курс, и кладёт её в контекст. Без
+// параметра цены отдаются в базовой валюте.
+func (h *Handler) ResolveCurrency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := r.URL.Query().Get(CurrencyQueryParam)
+		if raw == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		c, err := money.ParseCurrency(raw)
+		if err == nil {
+			err = h.srv.Supported(r.Context(), c)
+		}
+		if err != nil {
+			h.handleServiceError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(money.WithCurrency(r.Context(), c)))
+	})
+}
+
+// List godoc
+// @Summary      List currencies
+// @Description  Валюты, в которых можно запросить цены каталога (?currency=); базовая — первой
+// @Tags         currencies
+// @Produce      json
+// @Success      200 {array}  dto.CurrencyResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/currencies [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.srv.ListRates(r.Context())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRatesToResponse(rates))
+}
+
+// PutRate godoc
+// @Summary      Set exchange rate
+// @Description  Создаёт или обновляет курс: сколько единиц валюты стоит 1 единица базовой
+// @Tags         currencies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        currency  path  string           true  "ISO 4217 code, e.g. KZT"
+// @Param        data      body  dto.RateRequest  true  "Exchange rate"
+// @Success      200 {object} dto.CurrencyResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/currencies/{currency} [put]
+func (h *Handler) PutRate(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "PutRate")
+
+	c, ok := h.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.RateRequest](w, r, log)
+	if !ok {
+		return
+	}
+	saved, err := h.srv.PutRate(r.Context(), req.MapToDomain(c))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRateToResponse(saved))
+}
+
+// DeleteRate godoc
+// @Summary      Delete exchange rate
+// @Description  Выключает валюту; ручные цены в ней удаляются
+// @Tags         currencies
+// @Security     BearerAuth
+// @Param        currency  path  string  true  "ISO 4217 code"
+// @Success      204 "No Content"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/currencies/{currency} [delete]
+func (h *Handler) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+	if err := h.srv.DeleteRate(r.Context(), c); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOverrides godoc
+// @Summary      List price overrides
+// @Description  Ручные цены товара или услуги во всех валютах
+// @Tags         currencies
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity  path  string  true  "Entity: product, service"
+// @Param        id      path  int     true  "Entity ID"
+// @Success      200 {array}  dto.PriceOverrideResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/prices/{entity}/{id} [get]
+func (h *Handler) ListOverrides(w http.ResponseWriter, r *http.Request) {
+	entity, id, ok := h.parseTarget(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.srv.ListOverrides(r.Context(), entity, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapOverridesToResponse(list))
+}
+
+// PutOverride godoc
+// @Summary      Set price override
+// @Description  Цена в валюте, которая отдаётся вместо пересчёта по курсу
+// @Tags         currencies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity    path  string                    true  "Entity: product, service"
+// @Param        id        path  int                       true  "Entity ID"
+// @Param        currency  path  string                    true  "ISO 4217 code"
+// @Param        data      body  dto.PriceOverrideRequest  true  "Price"
+// @Success      200 {object} dto.PriceOverrideResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/prices/{entity}/{id}/{currency} [put]
+func (h *Handler) PutOverride(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "PutOverride")
+
+	entity, id, ok := h.parseTarget(w, r)
+	if !ok {
+		return
+	}
+	c, ok := h.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.PriceOverrideRequest](w, r, log)
+	if !ok {
+		return
+	}
+	saved, err := h.srv.PutOverride(r.Context(), req.MapToDomain(entity, id, c))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapOverrideToResponse(saved))
+}
+
+// DeleteOverride godoc
+// @Summary      Delete price override
+// @Description  После удаления цена в валюте снова считается по курсу
+// @Tags         currencies
+// @Security     BearerAuth
+// @Param        entity    path  string  true  "Entity: product, service"
+// @Param        id        path  int     true  "Entity ID"
+// @Param        currency  path  string  true  "ISO 4217 code"
+// @Success      204 "No Content"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/prices/{entity}/{id}/{currency} [delete]
+func (h *Handler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	entity, id, ok := h.parseTarget(w, r)
+	if !ok {
+		return
+	}
+	c, ok := h.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+	if err := h.srv.DeleteOverride(r.Context(), entity, id, c); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) parseTarget(w http.ResponseWriter, r *http.Request) (money.Entity, int64, bool) {
+	entity := money.Entity(chi.URLParam(r, "entity"))
+	if !entity.Overridable() {
+		h.handleServiceError(w, r, money.ErrInvalidEntity)
+		return "", 0, false
+	}
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid entity id")
+		return "", 0, false
+	}
+	return entity, id, true
+}
+
+func (h *Handler) parseCurrency(w http.ResponseWriter, r *http.Request) (money.Currency, bool) {
+	c, err := money.ParseCurrency(chi.URLParam(r, "currency"))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return "", false
+	}
+	return c, true
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
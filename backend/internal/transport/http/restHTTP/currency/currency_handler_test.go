@@ -0,0 +1,194 @@
+package currency_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency/mocks"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+type CurrencyHandlerSuite struct {
+	suite.Suite
+	h   *currency.Handler
+	svc *mocks.MockCurrencyService
+}
+
+func (s *CurrencyHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockCurrencyService(s.T())
+	deps, err := currency.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = currency.New(deps)
+}
+
+func withChiParams(r *http.Request, kv ...string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	for i := 0; i+1 < len(kv); i += 2 {
+		chiCtx.URLParams.Add(kv[i], kv[i+1])
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func (s *CurrencyHandlerSuite) TestResolveCurrency() {
+	var got money.Currency
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = money.CurrencyFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name        string
+		query       string
+		setup       func()
+		wantCode    int
+		want        money.Currency
+		wantProblem string
+	}{
+		{name: "no parameter means base currency", query: "", wantCode: http.StatusOK, want: money.Base},
+		{
+			name:  "supported currency, case-insensitive",
+			query: "?currency=kzt",
+			setup: func() {
+				s.svc.EXPECT().Supported(mock.Anything, money.KZT).Return(nil).Once()
+			},
+			wantCode: http.StatusOK,
+			want:     money.KZT,
+		},
+		{
+			name:  "unknown currency",
+			query: "?currency=JPY",
+			setup: func() {
+				s.svc.EXPECT().Supported(mock.Anything, money.Currency("JPY")).Return(money.ErrUnsupportedCurrency).Once()
+			},
+			wantCode:    http.StatusBadRequest,
+			wantProblem: "currency.unsupported",
+		},
+		{name: "malformed code", query: "?currency=rubles", wantCode: http.StatusBadRequest, wantProblem: "currency.invalid"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			got = ""
+			if tc.setup != nil {
+				tc.setup()
+			}
+			w := httptest.NewRecorder()
+			s.h.ResolveCurrency(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/products/1"+tc.query, nil))
+
+			s.Equal(tc.wantCode, w.Code)
+			if tc.wantProblem != "" {
+				var p http_utils.ErrorResponse
+				s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &p))
+				s.Equal(tc.wantProblem, p.Code)
+				return
+			}
+			s.Equal(tc.want, got)
+		})
+	}
+}
+
+func (s *CurrencyHandlerSuite) TestList_BaseFirst() {
+	updated := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.svc.EXPECT().ListRates(mock.Anything).Return([]money.Rate{
+		{Currency: money.KZT, Rate: decimal.RequireFromString("5.42"), UpdatedAt: updated},
+	}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.h.List(w, httptest.NewRequest(http.MethodGet, "/api/currencies", nil))
+
+	s.Require().Equal(http.StatusOK, w.Code)
+	var resp []dto.CurrencyResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Require().Len(resp, 2)
+	s.Equal(dto.CurrencyResponse{Code: "RUB", Rate: 1, Base: true}, resp[0])
+	s.Equal("KZT", resp[1].Code)
+	s.Equal(5.42, resp[1].Rate)
+}
+
+func (s *CurrencyHandlerSuite) TestPutRate() {
+	s.Run("saved", func() {
+		s.svc.EXPECT().PutRate(mock.Anything, mock.MatchedBy(func(r *money.Rate) bool {
+			return r.Currency == money.KZT && r.Rate.Equal(decimal.RequireFromString("5.42"))
+		})).RunAndReturn(func(_ context.Context, r *money.Rate) (*money.Rate, error) {
+			return r, nil
+		}).Once()
+
+		req := withChiParams(httptest.NewRequest(http.MethodPut, "/api/admin/currencies/KZT",
+			bytes.NewBufferString(`{"rate":5.42}`)), "currency", "KZT")
+		w := httptest.NewRecorder()
+		s.h.PutRate(w, req)
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"code":"KZT"`)
+	})
+	s.Run("zero rate is a validation error", func() {
+		req := withChiParams(httptest.NewRequest(http.MethodPut, "/api/admin/currencies/KZT",
+			bytes.NewBufferString(`{"rate":0}`)), "currency", "KZT")
+		w := httptest.NewRecorder()
+		s.h.PutRate(w, req)
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+		s.Contains(w.Body.String(), `"field":"rate"`)
+	})
+	s.Run("base currency", func() {
+		s.svc.EXPECT().PutRate(mock.Anything, mock.Anything).Return(nil, money.ErrBaseCurrency).Once()
+		req := withChiParams(httptest.NewRequest(http.MethodPut, "/api/admin/currencies/RUB",
+			bytes.NewBufferString(`{"rate":1}`)), "currency", "RUB")
+		w := httptest.NewRecorder()
+		s.h.PutRate(w, req)
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"currency.base"`)
+	})
+}
+
+func (s *CurrencyHandlerSuite) TestPutOverride() {
+	s.Run("saved", func() {
+		s.svc.EXPECT().PutOverride(mock.Anything, mock.MatchedBy(func(o *money.PriceOverride) bool {
+			return o.Entity == money.EntityProduct && o.EntityID == 7 &&
+				o.Price.Equal(money.New(decimal.RequireFromString("19000"), money.KZT))
+		})).RunAndReturn(func(_ context.Context, o *money.PriceOverride) (*money.PriceOverride, error) {
+			return o, nil
+		}).Once()
+
+		req := withChiParams(httptest.NewRequest(http.MethodPut, "/api/admin/prices/product/7/KZT",
+			bytes.NewBufferString(`{"price":19000}`)), "entity", "product", "id", "7", "currency", "kzt")
+		w := httptest.NewRecorder()
+		s.h.PutOverride(w, req)
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"currency":"KZT"`)
+	})
+	s.Run("presets have no overrides", func() {
+		req := withChiParams(httptest.NewRequest(http.MethodPut, "/api/admin/prices/preset/7/KZT",
+			bytes.NewBufferString(`{"price":19000}`)), "entity", "preset", "id", "7", "currency", "KZT")
+		w := httptest.NewRecorder()
+		s.h.PutOverride(w, req)
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"currency.invalid_entity"`)
+	})
+}
+
+func (s *CurrencyHandlerSuite) TestDeleteOverride_NotFound() {
+	s.svc.EXPECT().DeleteOverride(mock.Anything, money.EntityService, int64(3), money.USD).
+		Return(money.ErrOverrideNotFound).Once()
+
+	req := withChiParams(httptest.NewRequest(http.MethodDelete, "/api/admin/prices/service/3/USD", nil),
+		"entity", "service", "id", "3", "currency", "USD")
+	w := httptest.NewRecorder()
+	s.h.DeleteOverride(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestCurrencyHandlerSuite(t *testing.T) {
+	suite.Run(t, new(CurrencyHandlerSuite))
+}
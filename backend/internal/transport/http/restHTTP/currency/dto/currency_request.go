@@ -0,0 +1,43 @@
+package dto
+
+import (
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate *validator.Validate = validator.New()
+
+// RateRequest — курс валюты: сколько её единиц стоит 1 единица базовой валюты.
+type RateRequest struct {
+	Rate float64 `json:"rate" example:"5.42" validate:"required,gt=0"`
+}
+
+func (r RateRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		return ve.ValidationErrorResponse{Errors: []ve.FieldError{
+			ve.NewFieldError("rate", i18n.MsgRatePositive),
+		}}
+	}
+	return nil
+}
+
+// PriceOverrideRequest — цена сущности в валюте, заданная вручную.
+type PriceOverrideRequest struct {
+	Price float64 `json:"price" example:"19000" validate:"required,gt=0"`
+}
+
+func (r PriceOverrideRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		return ve.ValidationErrorResponse{Errors: []ve.FieldError{
+			ve.NewFieldError("price", i18n.MsgPricePositive),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// CurrencyResponse — валюта, в которой можно запросить цены (?currency=).
+type CurrencyResponse struct {
+	Code      string     `json:"code" example:"KZT"`
+	Rate      float64    `json:"rate" example:"5.42"`
+	Base      bool       `json:"base" example:"false"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" example:"2025-06-01T12:00:00Z"`
+}
+
+type PriceOverrideResponse struct {
+	Currency  string    `json:"currency" example:"KZT"`
+	Price     float64   `json:"price" example:"19000"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-06-01T12:00:00Z"`
+}
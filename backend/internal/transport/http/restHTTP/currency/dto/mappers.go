@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+)
+
+func (r *RateRequest) MapToDomain(c money.Currency) *money.Rate {
+	return &money.Rate{Currency: c, Rate: decimal.NewFromFloat(r.Rate)}
+}
+
+func (r *PriceOverrideRequest) MapToDomain(entity money.Entity, id int64, c money.Currency) *money.PriceOverride {
+	return &money.PriceOverride{
+		Entity:   entity,
+		EntityID: id,
+		Price:    money.FromFloat(r.Price, c),
+	}
+}
+
+func MapRateToResponse(r *money.Rate) CurrencyResponse {
+	updated := r.UpdatedAt
+	return CurrencyResponse{
+		Code:      string(r.Currency),
+		Rate:      r.Rate.InexactFloat64(),
+		UpdatedAt: &updated,
+	}
+}
+
+// MapRatesToResponse строит список валют; базовая валюта идёт первой с курсом 1.
+func MapRatesToResponse(rates []money.Rate) []CurrencyResponse {
+	res := make([]CurrencyResponse, 0, len(rates)+1)
+	res = append(res, CurrencyResponse{Code: string(money.Base), Rate: 1, Base: true})
+	for i := range rates {
+		res = append(res, MapRateToResponse(&rates[i]))
+	}
+	return res
+}
+
+func MapOverrideToResponse(o *money.PriceOverride) PriceOverrideResponse {
+	return PriceOverrideResponse{
+		Currency:  string(o.Price.Currency),
+		Price:     o.Price.Float64(),
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+func MapOverridesToResponse(list []money.PriceOverride) []PriceOverrideResponse {
+	res := make([]PriceOverrideResponse, len(list))
+	for i := range list {
+		res[i] = MapOverrideToResponse(&list[i])
+	}
+	return res
+}
@@ -0,0 +1,309 @@
+package discount
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+// PromoQueryParam — параметр запроса с промокодом покупателя.
+const PromoQueryParam = "promo"
+
+type DiscountService interface {
+	ListRules(ctx context.Context) ([]domDiscount.Rule, error)
+	GetRule(ctx context.Context, id int64) (*domDiscount.Rule, error)
+	CreateRule(ctx context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error)
+	UpdateRule(ctx context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	ListPromoCodes(ctx context.Context, ruleID int64) ([]domDiscount.PromoCode, error)
+	CreatePromoCode(ctx context.Context, p *domDiscount.PromoCode) (*domDiscount.PromoCode, error)
+	DeletePromoCode(ctx context.Context, code string) error
+	CheckPromoCode(ctx context.Context, code string) (*domDiscount.PromoCode, *domDiscount.Rule, error)
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv DiscountService
+}
+
+func NewDeps(log *slog.Logger, srv DiscountService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("discount: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("discount: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.discount"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv DiscountService
+	log *slog.Logger
+	now func() time.Time
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log, now: time.Now}
+}
+
+// ResolvePricing — middleware публичных маршрутов: включает скидки в ценах
+// каталога и передаёт промокод из ?promo=. Неизвестный или некорректный код
+// не ломает запрос — цены просто считаются без него.
+func (h *Handler) ResolvePricing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pricing domDiscount.Pricing
+		if raw := r.URL.Query().Get(PromoQueryParam); raw != "" {
+			if code, err := domDiscount.NormalizePromoCode(raw); err == nil {
+				pricing.PromoCode = code
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(domDiscount.WithPricing(r.Context(), pricing)))
+	})
+}
+
+// CheckPromoCode godoc
+// @Summary      Check promo code
+// @Description  Проверяет, что промокод действует, и возвращает его скидку и остаток погашений
+// @Tags         discounts
+// @Produce      json
+// @Param        code  path  string  true  "Promo code"
+// @Success      200 {object} dto.PromoCheckResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/promo-codes/{code} [get]
+func (h *Handler) CheckPromoCode(w http.ResponseWriter, r *http.Request) {
+	promo, rule, err := h.srv.CheckPromoCode(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapPromoCheckToResponse(promo, rule))
+}
+
+// ListRules godoc
+// @Summary      List discount rules
+// @Description  Все правила скидок, включая будущие и завершившиеся
+// @Tags         discounts
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array}  dto.RuleResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts [get]
+func (h *Handler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.srv.ListRules(r.Context())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRulesToResponse(rules, h.now()))
+}
+
+// GetRule godoc
+// @Summary      Get discount rule
+// @Tags         discounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Rule ID"
+// @Success      200 {object} dto.RuleResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts/{id} [get]
+func (h *Handler) GetRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+	rule, err := h.srv.GetRule(r.Context(), id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRuleToResponse(rule, h.now()))
+}
+
+// CreateRule godoc
+// @Summary      Create discount rule
+// @Description  Скидка в процентах или фиксированной суммой на товар, категорию (с подкатегориями), пресет или услугу
+// @Tags         discounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        data  body  dto.RuleRequest  true  "Discount rule"
+// @Success      201 {object} dto.RuleResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts [post]
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "CreateRule")
+
+	req, ok := http_utils.DecodeAndValidate[dto.RuleRequest](w, r, log)
+	if !ok {
+		return
+	}
+	rule, err := h.srv.CreateRule(r.Context(), req.MapToDomain())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusCreated, dto.MapRuleToResponse(rule, h.now()))
+}
+
+// UpdateRule godoc
+// @Summary      Update discount rule
+// @Tags         discounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  int              true  "Rule ID"
+// @Param        data  body  dto.RuleRequest  true  "Discount rule"
+// @Success      200 {object} dto.RuleResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts/{id} [put]
+func (h *Handler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "UpdateRule")
+
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.RuleRequest](w, r, log)
+	if !ok {
+		return
+	}
+	rule := req.MapToDomain()
+	rule.ID = id
+	saved, err := h.srv.UpdateRule(r.Context(), rule)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRuleToResponse(saved, h.now()))
+}
+
+// DeleteRule godoc
+// @Summary      Delete discount rule
+// @Description  Удаляет правило вместе с его промокодами
+// @Tags         discounts
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Rule ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts/{id} [delete]
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.srv.DeleteRule(r.Context(), id); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPromoCodes godoc
+// @Summary      List promo codes of a rule
+// @Tags         discounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Rule ID"
+// @Success      200 {array}  dto.PromoCodeResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts/{id}/promo-codes [get]
+func (h *Handler) ListPromoCodes(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+	codes, err := h.srv.ListPromoCodes(r.Context(), id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapPromoCodesToResponse(codes))
+}
+
+// CreatePromoCode godoc
+// @Summary      Create promo code
+// @Description  Выдаёт промокод к правилу с promo_only; код хранится в верхнем регистре
+// @Tags         discounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  int                   true  "Rule ID"
+// @Param        data  body  dto.PromoCodeRequest  true  "Promo code"
+// @Success      201 {object} dto.PromoCodeResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/discounts/{id}/promo-codes [post]
+func (h *Handler) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "CreatePromoCode")
+
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.PromoCodeRequest](w, r, log)
+	if !ok {
+		return
+	}
+	promo, err := h.srv.CreatePromoCode(r.Context(), req.MapToDomain(id))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusCreated, dto.MapPromoCodeToResponse(promo))
+}
+
+// DeletePromoCode godoc
+// @Summary      Delete promo code
+// @Tags         discounts
+// @Security     BearerAuth
+// @Param        code  path  string  true  "Promo code"
+// @Success      204 "No Content"
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/promo-codes/{code} [delete]
+func (h *Handler) DeletePromoCode(w http.ResponseWriter, r *http.Request) {
+	if err := h.srv.DeletePromoCode(r.Context(), chi.URLParam(r, "code")); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) parseID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid rule id")
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
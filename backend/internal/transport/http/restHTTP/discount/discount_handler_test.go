@@ -0,0 +1,174 @@
+package discount_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/mocks"
+)
+
+type DiscountHandlerSuite struct {
+	suite.Suite
+	h   *discount.Handler
+	svc *mocks.MockDiscountService
+}
+
+func (s *DiscountHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockDiscountService(s.T())
+	deps, err := discount.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = discount.New(deps)
+}
+
+func withChiParams(r *http.Request, kv ...string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	for i := 0; i+1 < len(kv); i += 2 {
+		chiCtx.URLParams.Add(kv[i], kv[i+1])
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func (s *DiscountHandlerSuite) TestResolvePricing() {
+	var (
+		got     domDiscount.Pricing
+		enabled bool
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, enabled = domDiscount.PricingFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"no promo", "", ""},
+		{"promo is normalized", "?promo=%20spring10", "SPRING10"},
+		{"malformed promo is ignored", "?promo=!!", ""},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			w := httptest.NewRecorder()
+			s.h.ResolvePricing(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/products/1"+tc.query, nil))
+			s.Equal(http.StatusOK, w.Code)
+			s.True(enabled)
+			s.Equal(tc.want, got.PromoCode)
+		})
+	}
+}
+
+func (s *DiscountHandlerSuite) TestCreateRule() {
+	s.Run("created", func() {
+		s.svc.EXPECT().CreateRule(mock.Anything, mock.MatchedBy(func(r *domDiscount.Rule) bool {
+			return r.Kind == domDiscount.KindPercent && r.Value.Equal(decimal.NewFromInt(15)) &&
+				r.Target == domDiscount.TargetCategory && r.TargetID == 3
+		})).RunAndReturn(func(_ context.Context, r *domDiscount.Rule) (*domDiscount.Rule, error) {
+			r.ID = 1
+			return r, nil
+		}).Once()
+
+		body := `{"name":"Весна","kind":"percent","value":15,"target":"category","target_id":3}`
+		w := httptest.NewRecorder()
+		s.h.CreateRule(w, httptest.NewRequest(http.MethodPost, "/api/admin/discounts", bytes.NewBufferString(body)))
+
+		s.Require().Equal(http.StatusCreated, w.Code)
+		var resp dto.RuleResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(int64(1), resp.ID)
+		s.True(resp.Active)
+	})
+	s.Run("unknown target type", func() {
+		body := `{"name":"Весна","kind":"percent","value":15,"target":"brand","target_id":3}`
+		w := httptest.NewRecorder()
+		s.h.CreateRule(w, httptest.NewRequest(http.MethodPost, "/api/admin/discounts", bytes.NewBufferString(body)))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+		s.Contains(w.Body.String(), `"field":"target"`)
+	})
+	s.Run("missing target", func() {
+		s.svc.EXPECT().CreateRule(mock.Anything, mock.Anything).Return(nil, domDiscount.ErrTargetNotFound).Once()
+		body := `{"name":"Весна","kind":"fixed","value":500,"target":"product","target_id":999}`
+		w := httptest.NewRecorder()
+		s.h.CreateRule(w, httptest.NewRequest(http.MethodPost, "/api/admin/discounts", bytes.NewBufferString(body)))
+		s.Equal(http.StatusNotFound, w.Code)
+		s.Contains(w.Body.String(), `"code":"discount.target_not_found"`)
+	})
+}
+
+func (s *DiscountHandlerSuite) TestCreatePromoCode_Duplicate() {
+	s.svc.EXPECT().CreatePromoCode(mock.Anything, mock.MatchedBy(func(p *domDiscount.PromoCode) bool {
+		return p.RuleID == 4 && p.Code == "SPRING10" && *p.UsageLimit == 100
+	})).Return(nil, domDiscount.ErrPromoCodeExists).Once()
+
+	req := withChiParams(httptest.NewRequest(http.MethodPost, "/api/admin/discounts/4/promo-codes",
+		bytes.NewBufferString(`{"code":"SPRING10","usage_limit":100}`)), "id", "4")
+	w := httptest.NewRecorder()
+	s.h.CreatePromoCode(w, req)
+	s.Equal(http.StatusConflict, w.Code)
+	s.Contains(w.Body.String(), `"code":"discount.promo_exists"`)
+}
+
+func (s *DiscountHandlerSuite) TestCheckPromoCode() {
+	s.Run("available", func() {
+		limit := 10
+		s.svc.EXPECT().CheckPromoCode(mock.Anything, "spring10").Return(
+			&domDiscount.PromoCode{Code: "SPRING10", RuleID: 4, UsageLimit: &limit, UsedCount: 3},
+			&domDiscount.Rule{ID: 4, Name: "Весна", Kind: domDiscount.KindFixed, Value: decimal.NewFromInt(500),
+				Target: domDiscount.TargetPreset, TargetID: 2, PromoOnly: true},
+			nil).Once()
+
+		req := withChiParams(httptest.NewRequest(http.MethodGet, "/api/promo-codes/spring10", nil), "code", "spring10")
+		w := httptest.NewRecorder()
+		s.h.CheckPromoCode(w, req)
+
+		s.Require().Equal(http.StatusOK, w.Code)
+		var resp dto.PromoCheckResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(7, *resp.Remaining)
+		s.Equal("fixed", resp.Discount.Kind)
+		s.Equal(500.0, resp.Discount.Value)
+	})
+	s.Run("exhausted", func() {
+		s.svc.EXPECT().CheckPromoCode(mock.Anything, "OLD").Return(nil, nil, domDiscount.ErrPromoCodeUnavailable).Once()
+		req := withChiParams(httptest.NewRequest(http.MethodGet, "/api/promo-codes/OLD", nil), "code", "OLD")
+		w := httptest.NewRecorder()
+		s.h.CheckPromoCode(w, req)
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+		s.Contains(w.Body.String(), `"code":"discount.promo_unavailable"`)
+	})
+}
+
+func (s *DiscountHandlerSuite) TestMapSale() {
+	old, applied := dto.MapSale(nil)
+	s.Nil(old)
+	s.Nil(applied)
+
+	code := "SPRING10"
+	old, applied = dto.MapSale(&domDiscount.Sale{
+		OldPrice:  money.New(decimal.RequireFromString("3990"), money.Base),
+		Rule:      domDiscount.Rule{ID: 4, Kind: domDiscount.KindPercent, Value: decimal.NewFromInt(15), Target: domDiscount.TargetCategory, TargetID: 3},
+		PromoCode: &code,
+	})
+	s.Equal(3990.0, *old)
+	s.Equal(int64(4), applied.RuleID)
+	s.Equal("category", applied.Target)
+	s.Equal(&code, applied.PromoCode)
+}
+
+func TestDiscountHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DiscountHandlerSuite))
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate *validator.Validate = validator.New()
+
+// RuleRequest — правило скидки. Value — процент (0–100] для percent
+// или сумма в базовой валюте для fixed.
+type RuleRequest struct {
+	Name      string     `json:"name" validate:"required,min=1,max=255" example:"Весенняя распродажа"`
+	Kind      string     `json:"kind" validate:"required,oneof=percent fixed" example:"percent"`
+	Value     float64    `json:"value" validate:"required,gt=0" example:"15"`
+	Target    string     `json:"target" validate:"required,oneof=product category preset service" example:"category"`
+	TargetID  int64      `json:"target_id" validate:"required,gt=0" example:"3"`
+	StartsAt  *time.Time `json:"starts_at,omitempty" example:"2025-03-01T00:00:00Z"`
+	EndsAt    *time.Time `json:"ends_at,omitempty" example:"2025-04-01T00:00:00Z"`
+	PromoOnly bool       `json:"promo_only" example:"false"`
+}
+
+func (r RuleRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		for _, e := range err.(validator.ValidationErrors) {
+			switch e.Field() {
+			case "Name":
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgName1To255))
+			case "Kind":
+				errs = append(errs, ve.NewFieldError("kind", i18n.MsgDiscountKind))
+			case "Value":
+				errs = append(errs, ve.NewFieldError("value", i18n.MsgValuePositive))
+			case "Target":
+				errs = append(errs, ve.NewFieldError("target", i18n.MsgDiscountTarget))
+			case "TargetID":
+				errs = append(errs, ve.NewFieldError("target_id", i18n.MsgDiscountTargetID))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
+
+// PromoCodeRequest — промокод к правилу; без usage_limit погашается без ограничений.
+type PromoCodeRequest struct {
+	Code       string `json:"code" validate:"required,min=3,max=32" example:"SPRING10"`
+	UsageLimit *int   `json:"usage_limit,omitempty" validate:"omitempty,gt=0" example:"100"`
+}
+
+func (r PromoCodeRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		for _, e := range err.(validator.ValidationErrors) {
+			switch e.Field() {
+			case "Code":
+				errs = append(errs, ve.NewFieldError("code", i18n.MsgPromoCodeCode))
+			case "UsageLimit":
+				errs = append(errs, ve.NewFieldError("usage_limit", i18n.MsgUsageLimit))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
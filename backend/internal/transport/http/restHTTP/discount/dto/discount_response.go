@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+type RuleResponse struct {
+	ID        int64      `json:"id" example:"1"`
+	Name      string     `json:"name" example:"Весенняя распродажа"`
+	Kind      string     `json:"kind" example:"percent"`
+	Value     float64    `json:"value" example:"15"`
+	Target    string     `json:"target" example:"category"`
+	TargetID  int64      `json:"target_id" example:"3"`
+	StartsAt  *time.Time `json:"starts_at,omitempty" example:"2025-03-01T00:00:00Z"`
+	EndsAt    *time.Time `json:"ends_at,omitempty" example:"2025-04-01T00:00:00Z"`
+	PromoOnly bool       `json:"promo_only" example:"false"`
+	// Active — правило действует прямо сейчас.
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2025-02-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-02-20T12:00:00Z"`
+}
+
+type PromoCodeResponse struct {
+	Code       string    `json:"code" example:"SPRING10"`
+	RuleID     int64     `json:"rule_id" example:"1"`
+	UsageLimit *int      `json:"usage_limit,omitempty" example:"100"`
+	UsedCount  int       `json:"used_count" example:"12"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-02-20T12:00:00Z"`
+}
+
+// PromoCheckResponse — результат проверки промокода покупателем.
+type PromoCheckResponse struct {
+	Code string `json:"code" example:"SPRING10"`
+	// Remaining — сколько раз код ещё можно использовать; нет поля — без ограничений.
+	Remaining *int                    `json:"remaining,omitempty" example:"88"`
+	Discount  AppliedDiscountResponse `json:"discount"`
+}
+
+// AppliedDiscountResponse объясняет цену со скидкой в ответах каталога:
+// какое правило применено и к чему оно привязано.
+type AppliedDiscountResponse struct {
+	RuleID    int64      `json:"rule_id" example:"1"`
+	Name      string     `json:"name" example:"Весенняя распродажа"`
+	Kind      string     `json:"kind" example:"percent"`
+	Value     float64    `json:"value" example:"15"`
+	Target    string     `json:"target" example:"category"`
+	TargetID  int64      `json:"target_id" example:"3"`
+	EndsAt    *time.Time `json:"ends_at,omitempty" example:"2025-04-01T00:00:00Z"`
+	PromoCode *string    `json:"promo_code,omitempty" example:"SPRING10"`
+}
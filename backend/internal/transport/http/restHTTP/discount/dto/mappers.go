@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	domDiscount "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+)
+
+func (r *RuleRequest) MapToDomain() *domDiscount.Rule {
+	return &domDiscount.Rule{
+		Name:      r.Name,
+		Kind:      domDiscount.Kind(r.Kind),
+		Value:     decimal.NewFromFloat(r.Value),
+		Target:    domDiscount.Target(r.Target),
+		TargetID:  r.TargetID,
+		StartsAt:  r.StartsAt,
+		EndsAt:    r.EndsAt,
+		PromoOnly: r.PromoOnly,
+	}
+}
+
+func (r *PromoCodeRequest) MapToDomain(ruleID int64) *domDiscount.PromoCode {
+	return &domDiscount.PromoCode{
+		Code:       r.Code,
+		RuleID:     ruleID,
+		UsageLimit: r.UsageLimit,
+	}
+}
+
+func MapRuleToResponse(r *domDiscount.Rule, now time.Time) RuleResponse {
+	return RuleResponse{
+		ID:        r.ID,
+		Name:      r.Name,
+		Kind:      string(r.Kind),
+		Value:     r.Value.InexactFloat64(),
+		Target:    string(r.Target),
+		TargetID:  r.TargetID,
+		StartsAt:  r.StartsAt,
+		EndsAt:    r.EndsAt,
+		PromoOnly: r.PromoOnly,
+		Active:    r.ActiveAt(now),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func MapRulesToResponse(list []domDiscount.Rule, now time.Time) []RuleResponse {
+	res := make([]RuleResponse, len(list))
+	for i := range list {
+		res[i] = MapRuleToResponse(&list[i], now)
+	}
+	return res
+}
+
+func MapPromoCodeToResponse(p *domDiscount.PromoCode) PromoCodeResponse {
+	return PromoCodeResponse{
+		Code:       p.Code,
+		RuleID:     p.RuleID,
+		UsageLimit: p.UsageLimit,
+		UsedCount:  p.UsedCount,
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+func MapPromoCodesToResponse(list []domDiscount.PromoCode) []PromoCodeResponse {
+	res := make([]PromoCodeResponse, len(list))
+	for i := range list {
+		res[i] = MapPromoCodeToResponse(&list[i])
+	}
+	return res
+}
+
+func MapPromoCheckToResponse(p *domDiscount.PromoCode, r *domDiscount.Rule) PromoCheckResponse {
+	return PromoCheckResponse{
+		Code:      p.Code,
+		Remaining: p.Remaining(),
+		Discount:  mapApplied(r, &p.Code),
+	}
+}
+
+// MapSale возвращает цену до скидки и объяснение скидки для ответов
+// каталога; без скидки оба значения nil.
+func MapSale(s *domDiscount.Sale) (*float64, *AppliedDiscountResponse) {
+	if s == nil {
+		return nil, nil
+	}
+	old := s.OldPrice.Float64()
+	applied := mapApplied(&s.Rule, s.PromoCode)
+	return &old, &applied
+}
+
+func mapApplied(r *domDiscount.Rule, code *string) AppliedDiscountResponse {
+	return AppliedDiscountResponse{
+		RuleID:    r.ID,
+		Name:      r.Name,
+		Kind:      string(r.Kind),
+		Value:     r.Value.InexactFloat64(),
+		Target:    string(r.Target),
+		TargetID:  r.TargetID,
+		EndsAt:    r.EndsAt,
+		PromoCode: code,
+	}
+}
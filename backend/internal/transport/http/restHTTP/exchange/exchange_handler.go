@@ -0,0 +1,197 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+)
+
+// SessionCookie — cookie сессии обмена, её имя и значение 1С получает
+// в ответе на checkauth и присылает в следующих запросах.
+const SessionCookie = "store_1c_session"
+
+type ExchangeService interface {
+	FileLimit() int64
+	CheckAuth(login, password string) (string, error)
+	Init(sessionID string) error
+	SaveFile(sessionID, filename string, r io.Reader) error
+	Import(ctx context.Context, sessionID, filename string) (domExchange.ImportResult, error)
+	QueryOrders(ctx context.Context, sessionID string, w io.Writer) error
+	ConfirmOrders(ctx context.Context, sessionID string) error
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv ExchangeService
+}
+
+func NewDeps(log *slog.Logger, srv ExchangeService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("exchange: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("exchange: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.exchange"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv ExchangeService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// Exchange godoc
+// @Summary      1C CommerceML exchange
+// @Description  Протокол обмена 1С:Предприятие (CommerceML 2). type=catalog: checkauth, init,
+// @Description  file (POST, тело — файл или его часть), import; type=sale: checkauth, init,
+// @Description  query (выгрузка заказов), success, file. checkauth — по Basic Auth из конфига,
+// @Description  остальные запросы — по cookie из ответа checkauth. Ответы — text/plain:
+// @Description  "success" или "failure" и причина следующей строкой.
+// @Tags         exchange
+// @Accept       octet-stream
+// @Produce      plain
+// @Security     BasicAuth
+// @Param        type      query string true  "catalog | sale"
+// @Param        mode      query string true  "checkauth | init | file | import | query | success"
+// @Param        filename  query string false "Имя файла для file и import"
+// @Success      200 {string} string "success"
+// @Failure      400 {string} string "failure"
+// @Failure      401 {string} string "failure"
+// @Router       /api/admin/1c/exchange [get]
+// @Router       /api/admin/1c/exchange [post]
+func (h *Handler) Exchange(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	typ, mode := q.Get("type"), q.Get("mode")
+	if typ != "catalog" && typ != "sale" {
+		h.failure(w, http.StatusBadRequest, fmt.Sprintf("unsupported type %q", typ))
+		return
+	}
+	if mode == "checkauth" {
+		h.checkAuth(w, r)
+		return
+	}
+
+	var sessionID string
+	if c, err := r.Cookie(SessionCookie); err == nil {
+		sessionID = c.Value
+	}
+
+	switch {
+	case mode == "init":
+		h.init(w, sessionID)
+	case mode == "file":
+		h.file(w, r, sessionID, q.Get("filename"))
+	case typ == "catalog" && mode == "import":
+		h.importFile(w, r, sessionID, q.Get("filename"))
+	case typ == "sale" && mode == "query":
+		h.query(w, r, sessionID)
+	case typ == "sale" && mode == "success":
+		if err := h.srv.ConfirmOrders(r.Context(), sessionID); err != nil {
+			h.handleServiceError(w, err)
+			return
+		}
+		h.success(w)
+	default:
+		h.failure(w, http.StatusBadRequest, fmt.Sprintf("unsupported mode %q for type %q", mode, typ))
+	}
+}
+
+func (h *Handler) checkAuth(w http.ResponseWriter, r *http.Request) {
+	login, password, ok := r.BasicAuth()
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="1c-exchange"`)
+		h.failure(w, http.StatusUnauthorized, domExchange.ErrUnauthorized.Error())
+		return
+	}
+	id, err := h.srv.CheckAuth(login, password)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	writeText(w, http.StatusOK, "success\n"+SessionCookie+"\n"+id+"\n")
+}
+
+func (h *Handler) init(w http.ResponseWriter, sessionID string) {
+	if err := h.srv.Init(sessionID); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	writeText(w, http.StatusOK, fmt.Sprintf("zip=no\nfile_limit=%d\n", h.srv.FileLimit()))
+}
+
+func (h *Handler) file(w http.ResponseWriter, r *http.Request, sessionID, filename string) {
+	if r.Method != http.MethodPost {
+		h.failure(w, http.StatusMethodNotAllowed, "file must be sent with POST")
+		return
+	}
+	if err := h.srv.SaveFile(sessionID, filename, r.Body); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	h.success(w)
+}
+
+func (h *Handler) importFile(w http.ResponseWriter, r *http.Request, sessionID, filename string) {
+	if _, err := h.srv.Import(r.Context(), sessionID, filename); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	h.success(w)
+}
+
+func (h *Handler) query(w http.ResponseWriter, r *http.Request, sessionID string) {
+	// документ собирается целиком, чтобы ошибка не оборвала ответ на середине
+	var buf bytes.Buffer
+	if err := h.srv.QueryOrders(r.Context(), sessionID, &buf); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		h.log.Warn("orders response write failed", slog.Any("error", err))
+	}
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domExchange.ErrUnauthorized),
+		errors.Is(err, domExchange.ErrSessionNotFound):
+		h.failure(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domExchange.ErrInvalidFilename),
+		errors.Is(err, domExchange.ErrFileNotFound),
+		errors.Is(err, domExchange.ErrUnknownDocument),
+		errors.Is(err, domExchange.ErrMalformedDocument):
+		h.failure(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domExchange.ErrFileTooLarge):
+		h.failure(w, http.StatusRequestEntityTooLarge, err.Error())
+	default:
+		h.log.Error("exchange failed", slog.Any("error", err))
+		h.failure(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func (h *Handler) success(w http.ResponseWriter) {
+	writeText(w, http.StatusOK, "success\n")
+}
+
+// failure — ответ в формате протокола: 1С показывает вторую строку пользователю.
+func (h *Handler) failure(w http.ResponseWriter, status int, reason string) {
+	writeText(w, status, "failure\n"+reason+"\n")
+}
+
+func writeText(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body)
+}
@@ -0,0 +1,125 @@
+package exchange_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domExchange "github.com/Neimess/zorkin-store-project/internal/domain/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/exchange/mocks"
+)
+
+type ExchangeHandlerSuite struct {
+	suite.Suite
+	h   *exchange.Handler
+	svc *mocks.MockExchangeService
+}
+
+func (s *ExchangeHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockExchangeService(s.T())
+	deps, err := exchange.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = exchange.New(deps)
+}
+
+func (s *ExchangeHandlerSuite) do(method, query, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/admin/1c/exchange?"+query, strings.NewReader(body))
+	req.AddCookie(&http.Cookie{Name: exchange.SessionCookie, Value: "sess"})
+	w := httptest.NewRecorder()
+	s.h.Exchange(w, req)
+	return w
+}
+
+func (s *ExchangeHandlerSuite) TestCheckAuth() {
+	s.Run("success", func() {
+		s.svc.EXPECT().CheckAuth("1c", "secret").Return("abc", nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/1c/exchange?type=catalog&mode=checkauth", nil)
+		req.SetBasicAuth("1c", "secret")
+		w := httptest.NewRecorder()
+		s.h.Exchange(w, req)
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("success\n"+exchange.SessionCookie+"\nabc\n", w.Body.String())
+	})
+	s.Run("no credentials", func() {
+		w := s.do(http.MethodGet, "type=sale&mode=checkauth", "")
+		s.Equal(http.StatusUnauthorized, w.Code)
+		s.True(strings.HasPrefix(w.Body.String(), "failure\n"))
+		s.NotEmpty(w.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func (s *ExchangeHandlerSuite) TestCatalogFlow() {
+	s.svc.EXPECT().Init("sess").Return(nil).Once()
+	s.svc.EXPECT().FileLimit().Return(int64(1024)).Once()
+	w := s.do(http.MethodGet, "type=catalog&mode=init", "")
+	s.Equal("zip=no\nfile_limit=1024\n", w.Body.String())
+
+	s.svc.EXPECT().SaveFile("sess", "import.xml", mock.Anything).RunAndReturn(func(_, _ string, r io.Reader) error {
+		b, _ := io.ReadAll(r)
+		s.Equal("<xml/>", string(b))
+		return nil
+	}).Once()
+	w = s.do(http.MethodPost, "type=catalog&mode=file&filename=import.xml", "<xml/>")
+	s.Equal("success\n", w.Body.String())
+
+	s.svc.EXPECT().Import(mock.Anything, "sess", "import.xml").Return(domExchange.ImportResult{Products: 3}, nil).Once()
+	w = s.do(http.MethodGet, "type=catalog&mode=import&filename=import.xml", "")
+	s.Equal("success\n", w.Body.String())
+}
+
+func (s *ExchangeHandlerSuite) TestErrors() {
+	cases := []struct {
+		name   string
+		method string
+		query  string
+		setup  func()
+		status int
+	}{
+		{"unknown type", http.MethodGet, "type=stock&mode=init", nil, http.StatusBadRequest},
+		{"unknown mode", http.MethodGet, "type=catalog&mode=query", nil, http.StatusBadRequest},
+		{"file via GET", http.MethodGet, "type=catalog&mode=file&filename=a.xml", nil, http.StatusMethodNotAllowed},
+		{"expired session", http.MethodGet, "type=catalog&mode=init", func() {
+			s.svc.EXPECT().Init("sess").Return(domExchange.ErrSessionNotFound).Once()
+		}, http.StatusUnauthorized},
+		{"malformed document", http.MethodGet, "type=catalog&mode=import&filename=import.xml", func() {
+			s.svc.EXPECT().Import(mock.Anything, "sess", "import.xml").Return(domExchange.ImportResult{}, domExchange.ErrMalformedDocument).Once()
+		}, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			if tc.setup != nil {
+				tc.setup()
+			}
+			w := s.do(tc.method, tc.query, "")
+			s.Equal(tc.status, w.Code)
+			s.True(strings.HasPrefix(w.Body.String(), "failure\n"), w.Body.String())
+		})
+	}
+}
+
+func (s *ExchangeHandlerSuite) TestSaleFlow() {
+	s.svc.EXPECT().QueryOrders(mock.Anything, "sess", mock.Anything).RunAndReturn(func(_ context.Context, _ string, w io.Writer) error {
+		_, err := io.WriteString(w, "<orders/>")
+		return err
+	}).Once()
+	w := s.do(http.MethodGet, "type=sale&mode=query", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Equal("<orders/>", w.Body.String())
+
+	s.svc.EXPECT().ConfirmOrders(mock.Anything, "sess").Return(nil).Once()
+	w = s.do(http.MethodGet, "type=sale&mode=success", "")
+	s.Equal("success\n", w.Body.String())
+}
+
+func TestExchangeHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeHandlerSuite))
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// BindRequest — сущность, к которой привязывается внешний идентификатор.
+type BindRequest struct {
+	EntityID int64 `json:"entity_id" example:"42"`
+}
+
+func (r BindRequest) Validate() error {
+	if r.EntityID <= 0 {
+		return ve.ValidationErrorResponse{Errors: []ve.FieldError{
+			ve.NewFieldError("entity_id", i18n.MsgEntityIDPositive),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+)
+
+type RefResponse struct {
+	Source     string    `json:"source" example:"1c"`
+	Entity     string    `json:"entity" example:"product"`
+	ExternalID string    `json:"external_id" example:"9f1c2e0a-51c4-11ee-8c99-0242ac120002"`
+	EntityID   int64     `json:"entity_id" example:"42"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2025-02-20T12:00:00Z"`
+}
+
+func MapRefToResponse(r *domExternal.Ref) RefResponse {
+	return RefResponse{
+		Source:     r.Source,
+		Entity:     string(r.Entity),
+		ExternalID: r.ExternalID,
+		EntityID:   r.EntityID,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+func MapRefsToResponse(refs []domExternal.Ref) []RefResponse {
+	resp := make([]RefResponse, len(refs))
+	for i := range refs {
+		resp[i] = MapRefToResponse(&refs[i])
+	}
+	return resp
+}
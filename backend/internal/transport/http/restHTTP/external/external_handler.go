@@ -0,0 +1,380 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	catDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/category/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	prodDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/dto"
+	svcDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/service/dto"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type ExternalService interface {
+	UpsertProduct(ctx context.Context, source, externalID string, p *prodDom.Product) (*prodDom.Product, bool, error)
+	GetProduct(ctx context.Context, source, externalID string) (*prodDom.Product, error)
+	UpsertCategory(ctx context.Context, source, externalID string, c *catDom.Category) (*catDom.Category, bool, error)
+	GetCategory(ctx context.Context, source, externalID string) (*catDom.Category, error)
+	UpsertService(ctx context.Context, source, externalID string, s *domService.Service) (*domService.Service, bool, error)
+	GetService(ctx context.Context, source, externalID string) (*domService.Service, error)
+	Bind(ctx context.Context, ref *domExternal.Ref) (*domExternal.Ref, error)
+	Unbind(ctx context.Context, source string, entity domExternal.Entity, externalID string) error
+	List(ctx context.Context, entity domExternal.Entity, entityID int64) ([]domExternal.Ref, error)
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv ExternalService
+}
+
+func NewDeps(log *slog.Logger, srv ExternalService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("external: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("external: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.external"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv ExternalService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// UpsertProduct godoc
+// @Summary      Upsert product by external ID
+// @Description  Создаёт товар с внешним идентификатором (source — код внешней системы, например 1c)
+// @Description  или обновляет уже привязанный. Повторная отправка того же товара не создаёт дубль.
+// @Description  Обновление требует If-Match, без заголовков товар можно только создать (иначе 428).
+// @Tags         external-ids
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source   path  string              true  "Источник"
+// @Param        id       path  string              true  "Идентификатор во внешней системе"
+// @Param        product  body  prodDto.ProductRequest  true  "Product"
+// @Param        If-Match       header  string  false  "ETag текущей версии или * — только обновление"
+// @Param        If-None-Match  header  string  false  "* — только создание"
+// @Success      200 {object} prodDto.ProductResponse "Updated"
+// @Success      201 {object} prodDto.ProductResponse "Created"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412 {object} http_utils.ErrorResponse
+// @Failure      428 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/product/by-external/{source}/{id} [put]
+func (h *Handler) UpsertProduct(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "UpsertProduct")
+
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[prodDto.ProductRequest](w, r, log)
+	if !ok {
+		return
+	}
+	p, created, err := h.srv.UpsertProduct(r.Context(), source, externalID, req.MapCreateToDomain())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	h.writeUpserted(w, created, p.Version, fmt.Sprintf("/api/product/%d", p.ID), prodDto.MapDomainToProductResponse(p))
+}
+
+// GetProduct godoc
+// @Summary      Get product by external ID
+// @Tags         external-ids
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  path  string  true  "Источник"
+// @Param        id      path  string  true  "Идентификатор во внешней системе"
+// @Success      200 {object} prodDto.ProductResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/product/by-external/{source}/{id} [get]
+func (h *Handler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	p, err := h.srv.GetProduct(r.Context(), source, externalID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, p.Version)
+	http_utils.WriteJSON(w, http.StatusOK, prodDto.MapDomainToProductResponse(p))
+}
+
+// UpsertCategory godoc
+// @Summary      Upsert category by external ID
+// @Description  Создаёт категорию с внешним идентификатором или обновляет уже привязанную
+// @Tags         external-ids
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source    path  string               true  "Источник"
+// @Param        id        path  string               true  "Идентификатор во внешней системе"
+// @Param        category  body  catDto.CategoryRequest  true  "Category"
+// @Param        If-Match       header  string  false  "ETag текущей версии или * — только обновление"
+// @Param        If-None-Match  header  string  false  "* — только создание"
+// @Success      200 {object} catDto.CategoryResponse "Updated"
+// @Success      201 {object} catDto.CategoryResponse "Created"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412 {object} http_utils.ErrorResponse
+// @Failure      428 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/category/by-external/{source}/{id} [put]
+func (h *Handler) UpsertCategory(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "UpsertCategory")
+
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[catDto.CategoryRequest](w, r, log)
+	if !ok {
+		return
+	}
+	c, created, err := h.srv.UpsertCategory(r.Context(), source, externalID, req.ToDomainCreate())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	h.writeUpserted(w, created, c.Version, fmt.Sprintf("/api/category/%d", c.ID), catDto.ToDTOResponse(c))
+}
+
+// GetCategory godoc
+// @Summary      Get category by external ID
+// @Tags         external-ids
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  path  string  true  "Источник"
+// @Param        id      path  string  true  "Идентификатор во внешней системе"
+// @Success      200 {object} catDto.CategoryResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/category/by-external/{source}/{id} [get]
+func (h *Handler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	c, err := h.srv.GetCategory(r.Context(), source, externalID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, c.Version)
+	http_utils.WriteJSON(w, http.StatusOK, catDto.ToDTOResponse(c))
+}
+
+// UpsertService godoc
+// @Summary      Upsert service by external ID
+// @Description  Создаёт услугу с внешним идентификатором или обновляет уже привязанную
+// @Tags         external-ids
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  path  string              true  "Источник"
+// @Param        id      path  string              true  "Идентификатор во внешней системе"
+// @Param        data    body  svcDto.ServiceRequest  true  "Service"
+// @Param        If-Match       header  string  false  "ETag текущей версии или * — только обновление"
+// @Param        If-None-Match  header  string  false  "* — только создание"
+// @Success      200 {object} svcDto.ServiceResponse "Updated"
+// @Success      201 {object} svcDto.ServiceResponse "Created"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412 {object} http_utils.ErrorResponse
+// @Failure      428 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/services/by-external/{source}/{id} [put]
+func (h *Handler) UpsertService(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "UpsertService")
+
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[svcDto.ServiceRequest](w, r, log)
+	if !ok {
+		return
+	}
+	s, created, err := h.srv.UpsertService(r.Context(), source, externalID, svcDto.MapToDomain(req))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	h.writeUpserted(w, created, s.Version, fmt.Sprintf("/api/services/%d", s.ID), svcDto.MapToResponse(s))
+}
+
+// GetService godoc
+// @Summary      Get service by external ID
+// @Tags         external-ids
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  path  string  true  "Источник"
+// @Param        id      path  string  true  "Идентификатор во внешней системе"
+// @Success      200 {object} svcDto.ServiceResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/services/by-external/{source}/{id} [get]
+func (h *Handler) GetService(w http.ResponseWriter, r *http.Request) {
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	s, err := h.srv.GetService(r.Context(), source, externalID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, s.Version)
+	http_utils.WriteJSON(w, http.StatusOK, svcDto.MapToResponse(s))
+}
+
+// List godoc
+// @Summary      List external IDs of entity
+// @Description  Внешние идентификаторы сущности во всех источниках
+// @Tags         external-ids
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity     query  string  true  "category | product | service"
+// @Param        entity_id  query  int     true  "ID сущности"
+// @Success      200 {array}  dto.RefResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/external-ids [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	entityID, err := http_utils.QueryInt64Param(r, "entity_id")
+	if err != nil || entityID <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid entity_id")
+		return
+	}
+	refs, err := h.srv.List(r.Context(), domExternal.Entity(r.URL.Query().Get("entity")), entityID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRefsToResponse(refs))
+}
+
+// Bind godoc
+// @Summary      Bind external ID
+// @Description  Привязывает внешний идентификатор к существующей сущности, например к товару,
+// @Description  заведённому вручную до начала синхронизации. Занятый идентификатор перепривязывается.
+// @Tags         external-ids
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  path  string           true  "Источник"
+// @Param        entity  path  string           true  "category | product | service"
+// @Param        id      path  string           true  "Идентификатор во внешней системе"
+// @Param        data    body  dto.BindRequest  true  "Entity"
+// @Success      200 {object} dto.RefResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/external-ids/{source}/{entity}/{id} [put]
+func (h *Handler) Bind(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "Bind")
+
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.BindRequest](w, r, log)
+	if !ok {
+		return
+	}
+	ref, err := h.srv.Bind(r.Context(), &domExternal.Ref{
+		Source:     source,
+		Entity:     domExternal.Entity(chi.URLParam(r, "entity")),
+		ExternalID: externalID,
+		EntityID:   req.EntityID,
+	})
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRefToResponse(ref))
+}
+
+// Unbind godoc
+// @Summary      Unbind external ID
+// @Description  Удаляет привязку; сама сущность не удаляется
+// @Tags         external-ids
+// @Security     BearerAuth
+// @Param        source  path  string  true  "Источник"
+// @Param        entity  path  string  true  "category | product | service"
+// @Param        id      path  string  true  "Идентификатор во внешней системе"
+// @Success      204 "No Content"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/external-ids/{source}/{entity}/{id} [delete]
+func (h *Handler) Unbind(w http.ResponseWriter, r *http.Request) {
+	source, externalID, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+	entity := domExternal.Entity(chi.URLParam(r, "entity"))
+	if err := h.srv.Unbind(r.Context(), source, entity, externalID); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// key достаёт источник и внешний идентификатор из пути. Если в пути есть
+// экранированный "/" ("%2F"), chi маршрутизирует по RawPath и отдаёт
+// параметры неразэкранированными.
+func (h *Handler) key(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	source, externalID := chi.URLParam(r, "source"), chi.URLParam(r, "externalID")
+	if r.URL.RawPath != "" {
+		var err error
+		if externalID, err = url.PathUnescape(externalID); err != nil {
+			http_utils.WriteError(w, http.StatusBadRequest, "invalid external id")
+			return "", "", false
+		}
+	}
+	return source, externalID, true
+}
+
+func (h *Handler) writeUpserted(w http.ResponseWriter, created bool, version int64, location string, resp any) {
+	http_utils.SetETag(w, version)
+	if created {
+		w.Header().Set("Location", location)
+		http_utils.WriteJSON(w, http.StatusCreated, resp)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
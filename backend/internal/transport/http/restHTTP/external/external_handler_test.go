@@ -0,0 +1,105 @@
+package external_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domExternal "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external/mocks"
+	prodDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/dto"
+)
+
+type ExternalHandlerSuite struct {
+	suite.Suite
+	router chi.Router
+	svc    *mocks.MockExternalService
+}
+
+func (s *ExternalHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockExternalService(s.T())
+	deps, err := external.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	h := external.New(deps)
+
+	// через роутер, чтобы проверить разбор экранированного идентификатора
+	s.router = chi.NewRouter()
+	s.router.Put("/product/by-external/{source}/{externalID}", h.UpsertProduct)
+	s.router.Get("/product/by-external/{source}/{externalID}", h.GetProduct)
+	s.router.Put("/external-ids/{source}/{entity}/{externalID}", h.Bind)
+}
+
+func (s *ExternalHandlerSuite) do(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+	return w
+}
+
+func (s *ExternalHandlerSuite) TestUpsertProduct() {
+	body := `{"name":"Керамогранит","price":3490,"category_id":2}`
+	matches := mock.MatchedBy(func(p *prodDom.Product) bool { return p.Name == "Керамогранит" && p.CategoryID == 2 })
+
+	s.Run("created", func() {
+		s.svc.EXPECT().UpsertProduct(mock.Anything, "pim", "A-1/2", matches).
+			RunAndReturn(func(_ context.Context, _, _ string, p *prodDom.Product) (*prodDom.Product, bool, error) {
+				p.ID = 11
+				return p, true, nil
+			}).Once()
+		w := s.do(http.MethodPut, "/product/by-external/pim/A-1%2F2", body)
+		s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+		s.Equal("/api/product/11", w.Header().Get("Location"))
+		var resp prodDto.ProductResponse
+		s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.Equal(int64(11), resp.ProductID)
+	})
+	s.Run("updated", func() {
+		s.svc.EXPECT().UpsertProduct(mock.Anything, "pim", "A-1", matches).
+			RunAndReturn(func(_ context.Context, _, _ string, p *prodDom.Product) (*prodDom.Product, bool, error) {
+				p.ID = 11
+				return p, false, nil
+			}).Once()
+		w := s.do(http.MethodPut, "/product/by-external/pim/A-1", body)
+		s.Equal(http.StatusOK, w.Code)
+		s.Empty(w.Header().Get("Location"))
+	})
+	s.Run("invalid body", func() {
+		w := s.do(http.MethodPut, "/product/by-external/pim/A-1", `{"name":""}`)
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+	s.Run("invalid source", func() {
+		s.svc.EXPECT().UpsertProduct(mock.Anything, "PIM", "A-1", matches).Return(nil, false, domExternal.ErrInvalidSource).Once()
+		w := s.do(http.MethodPut, "/product/by-external/PIM/A-1", body)
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (s *ExternalHandlerSuite) TestGetProduct_NotFound() {
+	s.svc.EXPECT().GetProduct(mock.Anything, "1c", "guid").Return(nil, domExternal.ErrRefNotFound).Once()
+	w := s.do(http.MethodGet, "/product/by-external/1c/guid", "")
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ExternalHandlerSuite) TestBind() {
+	s.svc.EXPECT().Bind(mock.Anything, &domExternal.Ref{
+		Source: "1c", Entity: domExternal.EntityProduct, ExternalID: "guid", EntityID: 5,
+	}).RunAndReturn(func(_ context.Context, ref *domExternal.Ref) (*domExternal.Ref, error) { return ref, nil }).Once()
+	w := s.do(http.MethodPut, "/external-ids/1c/product/guid", `{"entity_id":5}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.do(http.MethodPut, "/external-ids/1c/product/guid", `{"entity_id":0}`)
+	s.Equal(http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestExternalHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExternalHandlerSuite))
+}
@@ -8,9 +8,19 @@ import (
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/auth"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/category"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/coefficients"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/exchange"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/lead"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/preset"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/service"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/translation"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/webhook"
 )
 
 type Deps struct {
@@ -22,6 +32,16 @@ type Deps struct {
 	AttributeService   attribute.AttributeService
 	CoefficientService coefficients.CoefficientService
 	ServiceService     service.ServiceService
+	ReviewService      review.ReviewService
+	LeadService        lead.LeadService
+	TranslationService translation.TranslationService
+	CurrencyService    currency.CurrencyService
+	DiscountService    discount.DiscountService
+	WebhookService     webhook.WebhookService
+	ExchangeService    exchange.ExchangeService
+	ExternalService    external.ExternalService
+	IdempotencyService idempotency.IdempotencyService
+	SnapshotService    snapshot.SnapshotService
 }
 
 func NewDeps(
@@ -33,6 +53,16 @@ func NewDeps(
 	AttributeService attribute.AttributeService,
 	CoefficientService coefficients.CoefficientService,
 	ServiceService service.ServiceService,
+	ReviewService review.ReviewService,
+	LeadService lead.LeadService,
+	TranslationService translation.TranslationService,
+	CurrencyService currency.CurrencyService,
+	DiscountService discount.DiscountService,
+	WebhookService webhook.WebhookService,
+	ExchangeService exchange.ExchangeService,
+	ExternalService external.ExternalService,
+	IdempotencyService idempotency.IdempotencyService,
+	SnapshotService snapshot.SnapshotService,
 ) (*Deps, error) {
 	if ProductService == nil {
 		return nil, fmt.Errorf("missing ProductService dependency")
@@ -55,6 +85,36 @@ func NewDeps(
 	if ServiceService == nil {
 		return nil, fmt.Errorf("missing ServiceService dependency")
 	}
+	if ReviewService == nil {
+		return nil, fmt.Errorf("missing ReviewService dependency")
+	}
+	if LeadService == nil {
+		return nil, fmt.Errorf("missing LeadService dependency")
+	}
+	if TranslationService == nil {
+		return nil, fmt.Errorf("missing TranslationService dependency")
+	}
+	if CurrencyService == nil {
+		return nil, fmt.Errorf("missing CurrencyService dependency")
+	}
+	if DiscountService == nil {
+		return nil, fmt.Errorf("missing DiscountService dependency")
+	}
+	if WebhookService == nil {
+		return nil, fmt.Errorf("missing WebhookService dependency")
+	}
+	if ExchangeService == nil {
+		return nil, fmt.Errorf("missing ExchangeService dependency")
+	}
+	if ExternalService == nil {
+		return nil, fmt.Errorf("missing ExternalService dependency")
+	}
+	if IdempotencyService == nil {
+		return nil, fmt.Errorf("missing IdempotencyService dependency")
+	}
+	if SnapshotService == nil {
+		return nil, fmt.Errorf("missing SnapshotService dependency")
+	}
 	if Logger == nil {
 		return nil, fmt.Errorf("missing Logger dependency")
 	}
@@ -67,6 +127,16 @@ func NewDeps(
 		AttributeService:   AttributeService,
 		CoefficientService: CoefficientService,
 		ServiceService:     ServiceService,
+		ReviewService:      ReviewService,
+		LeadService:        LeadService,
+		TranslationService: TranslationService,
+		CurrencyService:    CurrencyService,
+		DiscountService:    DiscountService,
+		WebhookService:     WebhookService,
+		ExchangeService:    ExchangeService,
+		ExternalService:    ExternalService,
+		IdempotencyService: IdempotencyService,
+		SnapshotService:    SnapshotService,
 	}, nil
 }
 
@@ -78,6 +148,16 @@ type Handlers struct {
 	AttributeHandler    *attribute.Handler
 	CoefficientsHandler *coefficients.Handler
 	ServiceHandler      *service.Handler
+	ReviewHandler       *review.Handler
+	LeadHandler         *lead.Handler
+	TranslationHandler  *translation.Handler
+	CurrencyHandler     *currency.Handler
+	DiscountHandler     *discount.Handler
+	WebhookHandler      *webhook.Handler
+	ExchangeHandler     *exchange.Handler
+	ExternalHandler     *external.Handler
+	IdempotencyHandler  *idempotency.Handler
+	SnapshotHandler     *snapshot.Handler
 }
 
 func New(deps *Deps) (*Handlers, error) {
@@ -134,6 +214,76 @@ func New(deps *Deps) (*Handlers, error) {
 	}
 	serviceHandler := service.New(serviceDeps)
 
+	// review handler
+	reviewDeps, err := review.NewDeps(deps.Logger, deps.ReviewService)
+	if err != nil {
+		return nil, fmt.Errorf("review handler init: %w", err)
+	}
+	reviewHandler := review.New(reviewDeps)
+
+	// lead handler
+	leadDeps, err := lead.NewDeps(deps.Logger, deps.LeadService)
+	if err != nil {
+		return nil, fmt.Errorf("lead handler init: %w", err)
+	}
+	leadHandler := lead.New(leadDeps)
+
+	// translation handler
+	trDeps, err := translation.NewDeps(deps.Logger, deps.TranslationService)
+	if err != nil {
+		return nil, fmt.Errorf("translation handler init: %w", err)
+	}
+	trHandler := translation.New(trDeps)
+
+	// currency handler
+	curDeps, err := currency.NewDeps(deps.Logger, deps.CurrencyService)
+	if err != nil {
+		return nil, fmt.Errorf("currency handler init: %w", err)
+	}
+	curHandler := currency.New(curDeps)
+
+	// discount handler
+	discountDeps, err := discount.NewDeps(deps.Logger, deps.DiscountService)
+	if err != nil {
+		return nil, fmt.Errorf("discount handler init: %w", err)
+	}
+	discountHandler := discount.New(discountDeps)
+
+	// webhook handler
+	webhookDeps, err := webhook.NewDeps(deps.Logger, deps.WebhookService)
+	if err != nil {
+		return nil, fmt.Errorf("webhook handler init: %w", err)
+	}
+	webhookHandler := webhook.New(webhookDeps)
+
+	// 1C exchange handler
+	exchangeDeps, err := exchange.NewDeps(deps.Logger, deps.ExchangeService)
+	if err != nil {
+		return nil, fmt.Errorf("exchange handler init: %w", err)
+	}
+	exchangeHandler := exchange.New(exchangeDeps)
+
+	// external ids handler
+	externalDeps, err := external.NewDeps(deps.Logger, deps.ExternalService)
+	if err != nil {
+		return nil, fmt.Errorf("external handler init: %w", err)
+	}
+	externalHandler := external.New(externalDeps)
+
+	// idempotency middleware
+	idempotencyDeps, err := idempotency.NewDeps(deps.Logger, deps.IdempotencyService)
+	if err != nil {
+		return nil, fmt.Errorf("idempotency handler init: %w", err)
+	}
+	idempotencyHandler := idempotency.New(idempotencyDeps)
+
+	// snapshot handler
+	snapshotDeps, err := snapshot.NewDeps(deps.Logger, deps.SnapshotService)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot handler init: %w", err)
+	}
+	snapshotHandler := snapshot.New(snapshotDeps)
+
 	return &Handlers{
 		ProductHandler:      prodHandler,
 		CategoryHandler:     catHandler,
@@ -142,5 +292,15 @@ func New(deps *Deps) (*Handlers, error) {
 		AttributeHandler:    attrHandler,
 		CoefficientsHandler: coeffHandler,
 		ServiceHandler:      serviceHandler,
+		ReviewHandler:       reviewHandler,
+		LeadHandler:         leadHandler,
+		TranslationHandler:  trHandler,
+		CurrencyHandler:     curHandler,
+		DiscountHandler:     discountHandler,
+		WebhookHandler:      webhookHandler,
+		ExchangeHandler:     exchangeHandler,
+		ExternalHandler:     externalHandler,
+		IdempotencyHandler:  idempotencyHandler,
+		SnapshotHandler:     snapshotHandler,
 	}, nil
 }
@@ -0,0 +1,221 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// ReplayedHeader помечает ответ, отданный из сохранённых.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// MemoryBodySize — тело до этого размера держится в памяти до передачи
+// обработчику, длиннее — во временном файле. Предел размера задаёт
+// middleware.BodyLimit маршрута.
+const MemoryBodySize = 1 << 20
+
+// replayHeaders — заголовки ответа, которые сохраняются для повтора.
+var replayHeaders = []string{"Content-Type", "Location"}
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, key, fingerprint string) (*domIdempotency.Response, error)
+	Complete(ctx context.Context, key string, resp domIdempotency.Response) error
+	Release(ctx context.Context, key string) error
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv IdempotencyService
+}
+
+func NewDeps(log *slog.Logger, srv IdempotencyService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("idempotency: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("idempotency: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.idempotency"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv IdempotencyService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// Idempotent — middleware админских POST с заголовком Idempotency-Key.
+// Первый запрос выполняется, и его ответ сохраняется; повтор с тем же
+// ключом и тем же телом получает сохранённый ответ с Idempotent-Replayed: true,
+// с другим телом — 422, пока первый выполняется — 409. Ответы 5xx не
+// сохраняются: такой запрос можно повторить с тем же ключом.
+// Запросы без заголовка и не-POST проходят как есть.
+//
+// Тело читается целиком до обработчика, поэтому лимит размера маршрута
+// (middleware.BodyLimit) должен стоять до Idempotent.
+func (h *Handler) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(domIdempotency.Header)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, fp, err := spool(r)
+		if errors.Is(err, http_utils.ErrBodyTooLarge) {
+			http_utils.WriteLocalizedError(w, r, http.StatusRequestEntityTooLarge, i18n.MsgBodyTooLarge)
+			return
+		}
+		if err != nil {
+			h.log.Warn("request body not read", slog.Any("error", err))
+			http_utils.WriteLocalizedError(w, r, http.StatusBadRequest, i18n.MsgBodyUnreadable)
+			return
+		}
+		defer body.Close()
+		r.Body = body
+
+		saved, err := h.srv.Begin(r.Context(), key, fp)
+		if err != nil {
+			problems.Write(w, r, h.log, err)
+			return
+		}
+		if saved != nil {
+			replay(w, saved)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		// ответ сохраняется и после отмены запроса клиентом
+		ctx := context.WithoutCancel(r.Context())
+		completed := false
+		defer func() {
+			if !completed {
+				if err := h.srv.Release(ctx, key); err != nil {
+					h.log.Warn("idempotency key release failed", slog.Any("error", err))
+				}
+			}
+		}()
+		next.ServeHTTP(rec, r)
+
+		if rec.status >= http.StatusInternalServerError {
+			return
+		}
+		resp := domIdempotency.Response{StatusCode: rec.status, Body: rec.body.Bytes()}
+		for _, name := range replayHeaders {
+			if v := rec.Header().Get(name); v != "" {
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]string, len(replayHeaders))
+				}
+				resp.Headers[name] = v
+			}
+		}
+		if err := h.srv.Complete(ctx, key, resp); err != nil {
+			h.log.Error("idempotent response not saved", slog.Any("error", err))
+			return
+		}
+		completed = true
+	})
+}
+
+// spool дочитывает тело, на лету считая отпечаток запроса — хеш метода,
+// пути с параметрами и тела, — и возвращает копию тела для обработчика:
+// до MemoryBodySize в памяти, длиннее — во временном файле, который
+// удаляется при Close.
+func spool(r *http.Request) (io.ReadCloser, string, error) {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	if r.Body == nil || r.Body == http.NoBody {
+		return http.NoBody, hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(io.MultiWriter(h, &buf), io.LimitReader(r.Body, MemoryBodySize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if n <= MemoryBodySize {
+		return io.NopCloser(&buf), hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	f, err := os.CreateTemp("", "idempotency-body-*")
+	if err != nil {
+		return nil, "", err
+	}
+	body := &tempBody{File: f}
+	if _, err := buf.WriteTo(f); err != nil {
+		body.Close()
+		return nil, "", err
+	}
+	if _, err := io.Copy(io.MultiWriter(h, f), r.Body); err != nil {
+		body.Close()
+		return nil, "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		body.Close()
+		return nil, "", err
+	}
+	return body, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// tempBody — тело во временном файле; Close удаляет файл, повторный Close
+// ничего не делает.
+type tempBody struct {
+	*os.File
+	closed bool
+}
+
+func (b *tempBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	err := b.File.Close()
+	if rmErr := os.Remove(b.File.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+func replay(w http.ResponseWriter, resp *domIdempotency.Response) {
+	for name, v := range resp.Headers {
+		w.Header().Set(name, v)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// recorder пишет ответ клиенту и одновременно запоминает его.
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status, rec.wroteHeader = status, true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
@@ -0,0 +1,166 @@
+package idempotency_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domIdempotency "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/idempotency"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/idempotency/mocks"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
+)
+
+type IdempotencyHandlerSuite struct {
+	suite.Suite
+	h     *idempotency.Handler
+	svc   *mocks.MockIdempotencyService
+	calls int
+	// status — что отвечает обёрнутый хендлер
+	status int
+}
+
+func (s *IdempotencyHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockIdempotencyService(s.T())
+	deps, err := idempotency.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = idempotency.New(deps)
+	s.calls, s.status = 0, http.StatusCreated
+}
+
+func (s *IdempotencyHandlerSuite) do(method, key, body string) *httptest.ResponseRecorder {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls++
+		b, _ := io.ReadAll(r.Body)
+		s.Equal(body, string(b), "body must reach the handler")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/product/1")
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(`{"product_id":1}`))
+	})
+	req := httptest.NewRequest(method, "/api/admin/product", bytes.NewBufferString(body))
+	if key != "" {
+		req.Header.Set(domIdempotency.Header, key)
+	}
+	w := httptest.NewRecorder()
+	s.h.Idempotent(next).ServeHTTP(w, req)
+	return w
+}
+
+func (s *IdempotencyHandlerSuite) TestPassThrough() {
+	s.do(http.MethodPost, "", `{}`)
+	s.do(http.MethodPut, "k", `{}`)
+	s.Equal(2, s.calls)
+}
+
+func (s *IdempotencyHandlerSuite) TestFirstRequestIsStored() {
+	s.svc.EXPECT().Begin(mock.Anything, "k", mock.AnythingOfType("string")).Return(nil, nil).Once()
+	s.svc.EXPECT().Complete(mock.Anything, "k", domIdempotency.Response{
+		StatusCode: http.StatusCreated,
+		Headers:    map[string]string{"Content-Type": "application/json", "Location": "/api/product/1"},
+		Body:       []byte(`{"product_id":1}`),
+	}).Return(nil).Once()
+
+	w := s.do(http.MethodPost, "k", `{"name":"x"}`)
+	s.Equal(http.StatusCreated, w.Code)
+	s.Empty(w.Header().Get(idempotency.ReplayedHeader))
+	s.Equal(1, s.calls)
+}
+
+func (s *IdempotencyHandlerSuite) TestReplay() {
+	s.svc.EXPECT().Begin(mock.Anything, "k", mock.AnythingOfType("string")).Return(&domIdempotency.Response{
+		StatusCode: http.StatusCreated,
+		Headers:    map[string]string{"Location": "/api/product/1"},
+		Body:       []byte(`{"product_id":1}`),
+	}, nil).Once()
+
+	w := s.do(http.MethodPost, "k", `{"name":"x"}`)
+	s.Equal(http.StatusCreated, w.Code)
+	s.Equal("true", w.Header().Get(idempotency.ReplayedHeader))
+	s.Equal("/api/product/1", w.Header().Get("Location"))
+	s.Equal(`{"product_id":1}`, w.Body.String())
+	s.Zero(s.calls)
+}
+
+func (s *IdempotencyHandlerSuite) TestServerErrorReleasesKey() {
+	s.status = http.StatusInternalServerError
+	s.svc.EXPECT().Begin(mock.Anything, "k", mock.AnythingOfType("string")).Return(nil, nil).Once()
+	s.svc.EXPECT().Release(mock.Anything, "k").Return(nil).Once()
+
+	w := s.do(http.MethodPost, "k", `{}`)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *IdempotencyHandlerSuite) TestConflicts() {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{domIdempotency.ErrKeyReused, http.StatusUnprocessableEntity},
+		{domIdempotency.ErrInProgress, http.StatusConflict},
+		{domIdempotency.ErrInvalidKey, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		s.Run(tc.err.Error(), func() {
+			s.svc.EXPECT().Begin(mock.Anything, "k", mock.AnythingOfType("string")).Return(nil, tc.err).Once()
+			w := s.do(http.MethodPost, "k", `{}`)
+			s.Equal(tc.want, w.Code)
+		})
+	}
+	s.Zero(s.calls)
+}
+
+func (s *IdempotencyHandlerSuite) TestFingerprintDependsOnBody() {
+	var fps []string
+	s.svc.EXPECT().Begin(mock.Anything, "k", mock.AnythingOfType("string")).
+		Run(func(_ context.Context, _ string, fp string) { fps = append(fps, fp) }).
+		Return(nil, domIdempotency.ErrInProgress).Times(3)
+	s.do(http.MethodPost, "k", `{"a":1}`)
+	s.do(http.MethodPost, "k", `{"a":1}`)
+	s.do(http.MethodPost, "k", `{"a":2}`)
+	s.Equal(fps[0], fps[1])
+	s.NotEqual(fps[0], fps[2])
+}
+
+func (s *IdempotencyHandlerSuite) TestLargeBodyIsSpooled() {
+	body := strings.Repeat("x", idempotency.MemoryBodySize+10)
+	var fps []string
+	s.svc.EXPECT().Begin(mock.Anything, "k", mock.AnythingOfType("string")).
+		Run(func(_ context.Context, _ string, fp string) { fps = append(fps, fp) }).
+		Return(nil, nil).Twice()
+	s.svc.EXPECT().Complete(mock.Anything, "k", mock.Anything).Return(nil).Twice()
+
+	s.do(http.MethodPost, "k", body)
+	s.do(http.MethodPost, "k", body)
+	s.Equal(2, s.calls)
+	s.Equal(fps[0], fps[1])
+
+	tmp, err := filepath.Glob(filepath.Join(os.TempDir(), "idempotency-body-*"))
+	s.Require().NoError(err)
+	s.Empty(tmp, "temporary body must be removed")
+}
+
+func (s *IdempotencyHandlerSuite) TestBodyLimit() {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { s.calls++ })
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/snapshot/restore", io.NopCloser(strings.NewReader("0123456789")))
+	req.ContentLength = -1
+	req.Header.Set(domIdempotency.Header, "k")
+	w := httptest.NewRecorder()
+	middleware.BodyLimit(4)(s.h.Idempotent(next)).ServeHTTP(w, req)
+	s.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	s.Zero(s.calls)
+}
+
+func TestIdempotencyHandlerSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyHandlerSuite))
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate *validator.Validate = validator.New()
+
+type LeadRequest struct {
+	Kind      string  `json:"kind" validate:"required,oneof=callback consultation" example:"consultation"`
+	Name      string  `json:"name" validate:"required,min=1,max=100" example:"Анна"`
+	Phone     string  `json:"phone" validate:"required,min=6,max=32" example:"+7 900 123-45-67"`
+	Email     *string `json:"email,omitempty" validate:"omitempty,email" example:"anna@example.com"`
+	Message   *string `json:"message,omitempty" validate:"omitempty,max=2000" example:"Хочу подобрать плитку для ванной"`
+	PresetID  *int64  `json:"preset_id,omitempty" validate:"omitempty,gt=0" example:"3"`
+	ProductID *int64  `json:"product_id,omitempty" validate:"omitempty,gt=0" example:"10"`
+	PromoCode *string `json:"promo_code,omitempty" validate:"omitempty,min=3,max=32" example:"SPRING10"`
+	// Website — honeypot: поле скрыто на форме, заполняют его только боты.
+	Website string `json:"website,omitempty" swaggerignore:"true"`
+}
+
+func (r LeadRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		validationErrors := err.(validator.ValidationErrors)
+		for _, e := range validationErrors {
+			switch e.Field() {
+			case "Kind":
+				errs = append(errs, ve.NewFieldError("kind", i18n.MsgLeadKind))
+			case "Name":
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgName1To100))
+			case "Phone":
+				errs = append(errs, ve.NewFieldError("phone", i18n.MsgPhoneRequired))
+			case "Email":
+				errs = append(errs, ve.NewFieldError("email", i18n.MsgEmail))
+			case "Message":
+				errs = append(errs, ve.NewFieldError("message", i18n.MsgLeadMessage))
+			case "PresetID":
+				errs = append(errs, ve.NewFieldError("preset_id", i18n.MsgPresetIDPositive))
+			case "ProductID":
+				errs = append(errs, ve.NewFieldError("product_id", i18n.MsgProductIDPositive))
+			case "PromoCode":
+				errs = append(errs, ve.NewFieldError("promo_code", i18n.MsgPromoCode))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
+
+// IsBot сообщает, что заполнено honeypot-поле.
+func (r LeadRequest) IsBot() bool {
+	return r.Website != ""
+}
+
+type LeadStatusRequest struct {
+	Status string  `json:"status" validate:"required,oneof=new in_progress done cancelled spam" example:"in_progress"`
+	Note   *string `json:"note,omitempty" validate:"omitempty,max=1000" example:"Перезвонили, ждёт замер"`
+}
+
+func (r LeadStatusRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		for _, e := range err.(validator.ValidationErrors) {
+			switch e.Field() {
+			case "Status":
+				errs = append(errs, ve.NewFieldError("status", i18n.MsgLeadStatus))
+			case "Note":
+				errs = append(errs, ve.NewFieldError("note", i18n.MsgLeadNote))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
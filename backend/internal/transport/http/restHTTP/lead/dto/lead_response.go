@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// LeadAcceptedResponse одинаков для всех принятых заявок, включая
+// отсеянные как спам, чтобы не подсказывать ботам, что их распознали.
+type LeadAcceptedResponse struct {
+	Message string `json:"message" example:"Заявка принята, мы скоро свяжемся с вами"`
+}
+
+type LeadResponse struct {
+	ID          int64     `json:"id" example:"1"`
+	Kind        string    `json:"kind" example:"consultation"`
+	Name        string    `json:"name" example:"Анна"`
+	Phone       string    `json:"phone" example:"+7 900 123-45-67"`
+	Email       *string   `json:"email,omitempty"`
+	Message     *string   `json:"message,omitempty"`
+	PresetID    *int64    `json:"preset_id,omitempty"`
+	ProductID   *int64    `json:"product_id,omitempty"`
+	PromoCode   *string   `json:"promo_code,omitempty" example:"SPRING10"`
+	Status      string    `json:"status" example:"new"`
+	ManagerNote *string   `json:"manager_note,omitempty"`
+	SourceIP    *string   `json:"source_ip,omitempty"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-06-20T15:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-06-20T15:00:00Z"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+
+const AcceptedMessage = "Заявка принята, мы скоро свяжемся с вами"
+
+func MapToDomain(r *LeadRequest) *domLead.Lead {
+	return &domLead.Lead{
+		Kind:      domLead.Kind(r.Kind),
+		Name:      r.Name,
+		Phone:     r.Phone,
+		Email:     r.Email,
+		Message:   r.Message,
+		PresetID:  r.PresetID,
+		ProductID: r.ProductID,
+		PromoCode: r.PromoCode,
+	}
+}
+
+func MapToResponse(l *domLead.Lead) *LeadResponse {
+	return &LeadResponse{
+		ID:          l.ID,
+		Kind:        string(l.Kind),
+		Name:        l.Name,
+		Phone:       l.Phone,
+		Email:       l.Email,
+		Message:     l.Message,
+		PresetID:    l.PresetID,
+		ProductID:   l.ProductID,
+		PromoCode:   l.PromoCode,
+		Status:      string(l.Status),
+		ManagerNote: l.ManagerNote,
+		SourceIP:    l.SourceIP,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
+
+func MapToResponseList(list []domLead.Lead) []LeadResponse {
+	resp := make([]LeadResponse, len(list))
+	for i := range list {
+		resp[i] = *MapToResponse(&list[i])
+	}
+	return resp
+}
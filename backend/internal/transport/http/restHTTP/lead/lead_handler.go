@@ -0,0 +1,180 @@
+package lead
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/lead/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+type LeadService interface {
+	Create(ctx context.Context, l *domLead.Lead) (*domLead.Lead, error)
+	Get(ctx context.Context, id int64) (*domLead.Lead, error)
+	List(ctx context.Context, status domLead.Status) ([]domLead.Lead, error)
+	UpdateStatus(ctx context.Context, id int64, status domLead.Status, note *string) (*domLead.Lead, error)
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv LeadService
+}
+
+func NewDeps(log *slog.Logger, srv LeadService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("lead: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("lead: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.lead"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv LeadService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// Create godoc
+// @Summary      Submit callback or consultation request
+// @Description  Заявка на обратный звонок или консультацию дизайнера, опционально по пресету или товару.
+// @Description  Ограничена по числу запросов с одного IP.
+// @Tags         leads
+// @Accept       json
+// @Produce      json
+// @Param        data body dto.LeadRequest true "Lead"
+// @Success      202 {object} dto.LeadAcceptedResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      429 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/leads [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Create")
+
+	req, ok := http_utils.DecodeAndValidate[dto.LeadRequest](w, r, log)
+	if !ok {
+		return
+	}
+	if req.IsBot() {
+		log.Warn("honeypot triggered, lead dropped", slog.String("remote_addr", r.RemoteAddr))
+		http_utils.WriteJSON(w, http.StatusAccepted, dto.LeadAcceptedResponse{Message: dto.AcceptedMessage})
+		return
+	}
+
+	l := dto.MapToDomain(req)
+	if ip := remoteIP(r); ip != "" {
+		l.SourceIP = &ip
+	}
+	if _, err := h.srv.Create(ctx, l); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusAccepted, dto.LeadAcceptedResponse{Message: dto.AcceptedMessage})
+}
+
+// List godoc
+// @Summary      List leads
+// @Description  Заявки, новые сверху; без параметра status — все
+// @Tags         leads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status query string false "Lead status" Enums(new, in_progress, done, cancelled, spam)
+// @Success      200 {array}  dto.LeadResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/leads [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := h.srv.List(ctx, domLead.Status(r.URL.Query().Get("status")))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponseList(list))
+}
+
+// Get godoc
+// @Summary      Get lead by ID
+// @Tags         leads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Lead ID"
+// @Success      200 {object} dto.LeadResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/leads/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	l, err := h.srv.Get(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponse(l))
+}
+
+// UpdateStatus godoc
+// @Summary      Change lead status
+// @Description  Workflow: new → in_progress → done; new и in_progress можно отменить, new — пометить как спам
+// @Tags         leads
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path int                   true "Lead ID"
+// @Param        data body dto.LeadStatusRequest true "New status"
+// @Success      200 {object} dto.LeadResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/leads/{id}/status [put]
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "UpdateStatus")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.LeadStatusRequest](w, r, log)
+	if !ok {
+		return
+	}
+	l, err := h.srv.UpdateStatus(ctx, id, domLead.Status(req.Status), req.Note)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponse(l))
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,135 @@
+package lead_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domLead "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/lead"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/lead/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LeadHandlerSuite struct {
+	suite.Suite
+	h   *lead.Handler
+	svc *mocks.MockLeadService
+}
+
+func (s *LeadHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockLeadService(s.T())
+	deps, err := lead.NewDeps(slog.Default(), s.svc)
+	s.Require().NoError(err)
+	s.h = lead.New(deps)
+}
+
+func withChiParam(r *http.Request, key, val string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add(key, val)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func (s *LeadHandlerSuite) TestCreate() {
+	cases := []struct {
+		name     string
+		body     string
+		setup    func()
+		wantCode int
+	}{
+		{
+			name: "accepted",
+			body: `{"kind":"consultation","name":"Анна","phone":"+79001234567","preset_id":3}`,
+			setup: func() {
+				s.svc.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *domLead.Lead) bool {
+					return l.Kind == domLead.KindConsultation && *l.PresetID == 3 && *l.SourceIP == "192.0.2.1"
+				})).Return(&domLead.Lead{ID: 1}, nil).Once()
+			},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:     "honeypot is dropped silently",
+			body:     `{"kind":"callback","name":"Bot","phone":"+79001234567","website":"http://spam"}`,
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:     "unknown kind",
+			body:     `{"kind":"order","name":"Анна","phone":"+79001234567"}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "preset does not exist",
+			body: `{"kind":"consultation","name":"Анна","phone":"+79001234567","preset_id":999}`,
+			setup: func() {
+				s.svc.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, domLead.ErrBadReference).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "invalid json",
+			body:     `{`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			if tc.setup != nil {
+				tc.setup()
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/leads", bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			s.h.Create(w, req)
+			s.Equal(tc.wantCode, w.Code)
+		})
+	}
+}
+
+func (s *LeadHandlerSuite) TestUpdateStatus() {
+	s.Run("ok", func() {
+		s.SetupTest()
+		s.svc.EXPECT().UpdateStatus(mock.Anything, int64(1), domLead.StatusInProgress, (*string)(nil)).
+			Return(&domLead.Lead{ID: 1, Status: domLead.StatusInProgress}, nil).Once()
+		req := withChiParam(httptest.NewRequest(http.MethodPut, "/api/admin/leads/1/status",
+			bytes.NewBufferString(`{"status":"in_progress"}`)), "id", "1")
+		w := httptest.NewRecorder()
+		s.h.UpdateStatus(w, req)
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"status":"in_progress"`)
+	})
+	s.Run("transition not allowed", func() {
+		s.SetupTest()
+		s.svc.EXPECT().UpdateStatus(mock.Anything, int64(1), domLead.StatusNew, (*string)(nil)).
+			Return(nil, domLead.ErrInvalidTransition).Once()
+		req := withChiParam(httptest.NewRequest(http.MethodPut, "/api/admin/leads/1/status",
+			bytes.NewBufferString(`{"status":"new"}`)), "id", "1")
+		w := httptest.NewRecorder()
+		s.h.UpdateStatus(w, req)
+		s.Equal(http.StatusConflict, w.Code)
+	})
+}
+
+func (s *LeadHandlerSuite) TestGet_NotFound() {
+	s.svc.EXPECT().Get(mock.Anything, int64(5)).Return(nil, domLead.ErrLeadNotFound).Once()
+	req := withChiParam(httptest.NewRequest(http.MethodGet, "/api/admin/leads/5", nil), "id", "5")
+	w := httptest.NewRecorder()
+	s.h.Get(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *LeadHandlerSuite) TestList() {
+	s.svc.EXPECT().List(mock.Anything, domLead.StatusNew).Return([]domLead.Lead{{ID: 1, Status: domLead.StatusNew}}, nil).Once()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/leads?status=new", nil)
+	w := httptest.NewRecorder()
+	s.h.List(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func TestLeadHandlerSuite(t *testing.T) {
+	suite.Run(t, new(LeadHandlerSuite))
+}
@@ -0,0 +1,86 @@
+// Package patch — общий разбор тела PATCH-запросов: JSON Merge Patch
+// (RFC 7396) и JSON Patch (RFC 6902) применяются к текущему состоянию
+// ресурса в виде тела PUT, а результат проходит ту же валидацию, что и PUT.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/Neimess/zorkin-store-project/pkg/jsonpatch"
+)
+
+const (
+	MediaTypeMergePatch = "application/merge-patch+json"
+	MediaTypeJSONPatch  = "application/json-patch+json"
+
+	// MaxBodySize — предел размера документа изменений.
+	MaxBodySize = 1 << 20
+)
+
+// Accept — значение заголовка Accept-Patch.
+var Accept = strings.Join([]string{MediaTypeMergePatch, MediaTypeJSONPatch}, ", ")
+
+// Apply применяет тело запроса к doc и возвращает провалидированный результат.
+// application/json считается merge patch. При ошибке ответ уже записан.
+func Apply[T http_utils.Validatable](w http.ResponseWriter, r *http.Request, log *slog.Logger, doc *T) (*T, bool) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != MediaTypeMergePatch && mediaType != MediaTypeJSONPatch && mediaType != "application/json" {
+		log.Warn("unsupported patch media type", slog.String("content_type", mediaType))
+		w.Header().Set("Accept-Patch", Accept)
+		http_utils.WriteLocalizedError(w, r, http.StatusUnsupportedMediaType, i18n.MsgPatchMediaType)
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if len(body) > MaxBodySize || errors.Is(err, http_utils.ErrBodyTooLarge) {
+		http_utils.WriteLocalizedError(w, r, http.StatusRequestEntityTooLarge, i18n.MsgBodyTooLarge)
+		return nil, false
+	}
+	if err != nil {
+		http_utils.WriteLocalizedError(w, r, http.StatusBadRequest, i18n.MsgBodyUnreadable)
+		return nil, false
+	}
+
+	current, err := json.Marshal(doc)
+	if err != nil {
+		problems.Write(w, r, log, err)
+		return nil, false
+	}
+
+	var patched []byte
+	if mediaType == MediaTypeJSONPatch {
+		var ops jsonpatch.Patch
+		if ops, err = jsonpatch.Decode(body); err == nil {
+			patched, err = ops.Apply(current)
+		}
+	} else {
+		patched, err = jsonpatch.MergePatch(current, body)
+	}
+	if err != nil {
+		problems.Write(w, r, log, err)
+		return nil, false
+	}
+
+	var out T
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&out); err != nil {
+		log.Warn("patched document does not match resource schema", slog.Any("error", err))
+		http_utils.WriteLocalizedError(w, r, http.StatusUnprocessableEntity, i18n.MsgPatchSchemaMismatch)
+		return nil, false
+	}
+	if !http_utils.Validate(w, r, log, out) {
+		return nil, false
+	}
+	return &out, true
+}
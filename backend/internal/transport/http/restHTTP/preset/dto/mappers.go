@@ -1,46 +1,105 @@
 package dto
 
 import (
+	"reflect"
 	"time"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
 	"github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	"github.com/Neimess/zorkin-store-project/internal/domain/product"
+	discountDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
 )
 
 // MapToPreset конвертирует DTO‑запрос в доменную модель Preset.
 
 func MapDomainToShortDTO(p *preset.Preset) PresetShortResponse {
-	return PresetShortResponse{
+	resp := PresetShortResponse{
 		PresetID:    p.ID,
 		Name:        p.Name,
 		Description: p.Description,
-		TotalPrice:  p.TotalPrice,
+		TotalPrice:  p.TotalPrice.Float64(),
+		Currency:    string(p.TotalPrice.Currency),
 		ImageURL:    p.ImageURL,
 		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		IsTemplate:  p.IsTemplate,
 	}
+	resp.OldTotalPrice, resp.Discount = discountDto.MapSale(p.Sale)
+	return resp
 }
 
 func MapDomainToDto(p *preset.Preset) *PresetResponse {
-	return &PresetResponse{
+	resp := &PresetResponse{
 		PresetID:    p.ID,
 		Name:        p.Name,
 		Description: p.Description,
-		TotalPrice:  p.TotalPrice,
+		TotalPrice:  p.TotalPrice.Float64(),
+		Currency:    string(p.TotalPrice.Currency),
 		ImageURL:    p.ImageURL,
 		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		IsTemplate:  p.IsTemplate,
 		Items:       mapToResponseItems(p.Items),
 	}
+	resp.OldTotalPrice, resp.Discount = discountDto.MapSale(p.Sale)
+	return resp
 }
 
 func mapToResponseItems(items []preset.PresetItem) []PresetResponseItem {
 	out := make([]PresetResponseItem, len(items))
 	for i, it := range items {
-		ps := ProductSummary{ID: it.ProductID}
-		if it.Product != nil {
-			ps.Name = it.Product.Name
-			ps.Price = it.Product.Price
-			ps.ImageURL = it.Product.ImageURL
+		out[i] = PresetResponseItem{
+			SlotID:          it.ID,
+			SlotName:        it.SlotName,
+			Optional:        it.Optional,
+			Product:         mapSummary(it.ProductID, it.Product),
+			Quantity:        it.Qty(),
+			QuantityFormula: it.QuantityFormula,
+		}
+		for _, a := range it.Alternatives {
+			out[i].Alternatives = append(out[i].Alternatives, mapSummary(a.ProductID, a.Product))
+		}
+	}
+	return out
+}
+
+func mapSummary(id int64, p *product.ProductSummary) ProductSummary {
+	ps := ProductSummary{ID: id}
+	if p != nil {
+		ps.Name = p.Name
+		ps.Price = p.Price.Float64()
+		ps.Currency = string(p.Price.Currency)
+		ps.OldPrice, ps.Discount = discountDto.MapSale(p.Sale)
+		ps.ImageURL = p.ImageURL
+	}
+	return ps
+}
+
+func MapConfigurationToDto(c *preset.Configuration) *PresetConfigurationResponse {
+	resp := &PresetConfigurationResponse{
+		PresetID:   c.PresetID,
+		TotalPrice: c.TotalPrice.Float64(),
+		Currency:   string(c.TotalPrice.Currency),
+		Items:      make([]PresetConfigurationItem, len(c.Items)),
+	}
+	for i, it := range c.Items {
+		resp.Items[i] = PresetConfigurationItem{
+			SlotID:   it.SlotID,
+			SlotName: it.SlotName,
+			Product:  mapSummary(it.Product.ID, it.Product),
+			Quantity: it.Quantity,
+			Replaced: it.Replaced,
+		}
+	}
+	return resp
+}
+
+func (r *PresetConfigureRequest) MapToChoices() []preset.Choice {
+	out := make([]preset.Choice, len(r.Choices))
+	for i, c := range r.Choices {
+		out[i] = preset.Choice{SlotID: c.SlotID, Omit: c.Omit}
+		if c.ProductID != nil {
+			out[i].ProductID = *c.ProductID
 		}
-		out[i] = PresetResponseItem{Product: ps}
 	}
 	return out
 }
@@ -49,8 +108,9 @@ func (r *PresetRequest) MapToPreset() *preset.Preset {
 	return &preset.Preset{
 		Name:        r.Name,
 		Description: r.Description,
-		TotalPrice:  r.TotalPrice,
+		TotalPrice:  money.FromFloat(r.TotalPrice, money.Base),
 		ImageURL:    r.ImageURL,
+		IsTemplate:  r.IsTemplate,
 		Items:       r.mapToPresetItems(),
 	}
 }
@@ -60,15 +120,83 @@ func (r *PresetRequest) MapUpdateToPreset(preset_id int64) *preset.Preset {
 		ID:          preset_id,
 		Name:        r.Name,
 		Description: r.Description,
-		TotalPrice:  r.TotalPrice,
+		TotalPrice:  money.FromFloat(r.TotalPrice, money.Base),
 		ImageURL:    r.ImageURL,
+		IsTemplate:  r.IsTemplate,
 		Items:       r.mapToPresetItems(),
 	}
 }
 func (r *PresetRequest) mapToPresetItems() []preset.PresetItem {
 	items := make([]preset.PresetItem, len(r.Items))
 	for i, it := range r.Items {
-		items[i] = preset.PresetItem{ProductID: it.ProductID}
+		items[i] = preset.PresetItem{
+			ProductID:       it.ProductID,
+			QuantityFormula: it.QuantityFormula,
+			SlotName:        it.SlotName,
+			Optional:        it.Optional,
+		}
+		if it.Quantity != nil {
+			items[i].Quantity = *it.Quantity
+		}
+		for _, id := range it.Alternatives {
+			items[i].Alternatives = append(items[i].Alternatives, preset.Alternative{ProductID: id})
+		}
 	}
 	return items
 }
+
+func (r *PresetInstantiateRequest) MapToRoomParams() preset.RoomParams {
+	return preset.RoomParams{
+		Length:       r.Length,
+		Width:        r.Width,
+		Height:       r.Height,
+		OpeningsArea: r.OpeningsArea,
+	}
+}
+
+// MapDomainToRequest строит тело PUT из текущего пресета — документ для PATCH.
+func MapDomainToRequest(p *preset.Preset) PresetRequest {
+	r := PresetRequest{
+		Name:        p.Name,
+		Description: p.Description,
+		TotalPrice:  p.TotalPrice.Float64(),
+		ImageURL:    p.ImageURL,
+		IsTemplate:  p.IsTemplate,
+		Items:       make([]PresetRequestItem, len(p.Items)),
+	}
+	for i, it := range p.Items {
+		r.Items[i] = PresetRequestItem{
+			ProductID:       it.ProductID,
+			QuantityFormula: it.QuantityFormula,
+			SlotName:        it.SlotName,
+			Optional:        it.Optional,
+		}
+		if it.Quantity > 0 {
+			q := it.Quantity
+			r.Items[i].Quantity = &q
+		}
+		for _, a := range it.Alternatives {
+			r.Items[i].Alternatives = append(r.Items[i].Alternatives, a.ProductID)
+		}
+	}
+	return r
+}
+
+// MapPatchToDomain оставляет в патче только поля, изменившиеся после PATCH.
+func MapPatchToDomain(id, version int64, before, after *PresetRequest) *preset.Patch {
+	p := &preset.Patch{
+		ID:          id,
+		Version:     version,
+		Name:        patch.Diff(before.Name, after.Name),
+		Description: patch.DiffPtr(before.Description, after.Description),
+		ImageURL:    patch.DiffPtr(before.ImageURL, after.ImageURL),
+		IsTemplate:  patch.Diff(before.IsTemplate, after.IsTemplate),
+	}
+	if before.TotalPrice != after.TotalPrice {
+		p.TotalPrice = patch.To(money.FromFloat(after.TotalPrice, money.Base))
+	}
+	if !reflect.DeepEqual(before.Items, after.Items) {
+		p.Items = patch.To(after.mapToPresetItems())
+	}
+	return p
+}
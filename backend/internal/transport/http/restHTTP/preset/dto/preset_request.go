@@ -2,8 +2,10 @@ package dto
 
 import (
 	"fmt"
+	"strings"
 
 	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -11,10 +13,11 @@ var validate *validator.Validate = validator.New()
 
 //swaggo:model PresetRequest
 type PresetRequest struct {
-	Name        string              `json:"name" validate:"required,min=2,max=255"`
+	Name        string              `json:"name" validate:"required,min=2,max=100"`
 	Description *string             `json:"description,omitempty"`
 	TotalPrice  float64             `json:"total_price" validate:"gt=0"`
 	ImageURL    *string             `json:"image_url,omitempty" validate:"omitempty,url"`
+	IsTemplate  bool                `json:"is_template"`
 	Items       []PresetRequestItem `json:"items" validate:"required,dive"`
 }
 
@@ -30,30 +33,15 @@ func (r PresetRequest) Validate() error {
 		for _, e := range validationErrors {
 			switch e.Field() {
 			case "Name":
-				errs = append(errs, ve.FieldError{
-					Field:   "name",
-					Message: "name is required and must be between 2 and 255 characters",
-				})
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgName2To100))
 			case "TotalPrice":
-				errs = append(errs, ve.FieldError{
-					Field:   "total_price",
-					Message: "total_price must be greater than 0",
-				})
+				errs = append(errs, ve.NewFieldError("total_price", i18n.MsgTotalPricePositive))
 			case "ImageURL":
-				errs = append(errs, ve.FieldError{
-					Field:   "image_url",
-					Message: "image_url must be a valid URL",
-				})
+				errs = append(errs, ve.NewFieldError("image_url", i18n.MsgImageURL))
 			case "Items":
-				errs = append(errs, ve.FieldError{
-					Field:   "items",
-					Message: "items must contain at least one item",
-				})
+				errs = append(errs, ve.NewFieldError("items", i18n.MsgPresetNoItems))
 			default:
-				errs = append(errs, ve.FieldError{
-					Field:   e.Field(),
-					Message: "invalid field",
-				})
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
 			}
 		}
 	}
@@ -66,10 +54,7 @@ func (r PresetRequest) Validate() error {
 					errs = append(errs, ferr)
 				}
 			} else {
-				errs = append(errs, ve.FieldError{
-					Field:   fmt.Sprintf("items[%d]", idx),
-					Message: err.Error(),
-				})
+				errs = append(errs, ve.NewFieldError(fmt.Sprintf("items[%d]", idx), i18n.Raw(err.Error())))
 			}
 		}
 	}
@@ -83,7 +68,13 @@ func (r PresetRequest) Validate() error {
 
 //swaggo:model PresetRequestItem
 type PresetRequestItem struct {
-	ProductID int64 `json:"product_id" validate:"required,gt=0"`
+	ProductID       int64    `json:"product_id" validate:"required,gt=0"`
+	Quantity        *float64 `json:"quantity,omitempty" validate:"omitempty,gt=0" example:"2"`
+	QuantityFormula *string  `json:"quantity_formula,omitempty" validate:"omitempty,max=255" example:"ceil(floor_area / 1.44 * 1.1)"`
+	SlotName        *string  `json:"slot_name,omitempty" validate:"omitempty,max=100" example:"Раковина"`
+	Optional        bool     `json:"optional,omitempty" example:"false"`
+	// Alternatives — товары, которыми клиент может заменить product_id в конфигураторе.
+	Alternatives []int64 `json:"alternatives,omitempty" validate:"omitempty,dive,gt=0" example:"11,12"`
 }
 
 func (i PresetRequestItem) Validate() error {
@@ -99,20 +90,20 @@ func (i PresetRequestItem) Validate() error {
 		for _, e := range validationErrors {
 			switch e.Field() {
 			case "ProductID":
-				errs = append(errs, ve.FieldError{
-					Field:   "product_id",
-					Message: "product_id is required",
-				})
+				errs = append(errs, ve.NewFieldError("product_id", i18n.MsgProductIDRequired))
 			case "Quantity":
-				errs = append(errs, ve.FieldError{
-					Field:   "quantity",
-					Message: "quantity must be greater than 0",
-				})
+				errs = append(errs, ve.NewFieldError("quantity", i18n.MsgQuantityPositive))
+			case "QuantityFormula":
+				errs = append(errs, ve.NewFieldError("quantity_formula", i18n.MsgQuantityFormulaLong))
+			case "SlotName":
+				errs = append(errs, ve.NewFieldError("slot_name", i18n.MsgSlotNameTooLong))
 			default:
-				errs = append(errs, ve.FieldError{
-					Field:   e.Field(),
-					Message: "invalid field",
-				})
+				// ошибки dive приходят с индексом: Alternatives[1]
+				if strings.HasPrefix(e.Field(), "Alternatives") {
+					errs = append(errs, ve.NewFieldError("alternatives", i18n.MsgAlternativesInvalid))
+					continue
+				}
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
 			}
 		}
 	}
@@ -123,3 +114,102 @@ func (i PresetRequestItem) Validate() error {
 
 	return nil
 }
+
+//swaggo:model PresetCloneRequest
+type PresetCloneRequest struct {
+	Name *string `json:"name,omitempty" validate:"omitempty,min=2,max=100" example:"Комплект для ванной (клиент Иванов)"`
+}
+
+func (r PresetCloneRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		return ve.ValidationErrorResponse{Errors: []ve.FieldError{ve.NewFieldError("name", i18n.MsgOptionalName2To100)}}
+	}
+	return nil
+}
+
+//swaggo:model PresetInstantiateRequest
+type PresetInstantiateRequest struct {
+	Name         *string `json:"name,omitempty" validate:"omitempty,min=2,max=100" example:"Ванная 2.5x1.8"`
+	Length       float64 `json:"length" validate:"gt=0" example:"2.5"`
+	Width        float64 `json:"width" validate:"gt=0" example:"1.8"`
+	Height       float64 `json:"height" validate:"gt=0" example:"2.7"`
+	OpeningsArea float64 `json:"openings_area" validate:"gte=0" example:"1.6"`
+}
+
+func (r PresetInstantiateRequest) Validate() error {
+	var errs []ve.FieldError
+
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+
+		validationErrors := err.(validator.ValidationErrors)
+		for _, e := range validationErrors {
+			switch e.Field() {
+			case "Name":
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgOptionalName2To100))
+			case "Length", "Width", "Height":
+				errs = append(errs, ve.NewFieldError(strings.ToLower(e.Field()), i18n.MsgMustBePositive.With(strings.ToLower(e.Field()))))
+			case "OpeningsArea":
+				errs = append(errs, ve.NewFieldError("openings_area", i18n.MsgOpeningsAreaNegative))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+
+	return nil
+}
+
+//swaggo:model PresetConfigureRequest
+type PresetConfigureRequest struct {
+	// Choices — выбор по слотам; слоты без выбора остаются по умолчанию.
+	Choices []PresetChoice `json:"choices" validate:"dive"`
+}
+
+//swaggo:model PresetChoice
+type PresetChoice struct {
+	SlotID    int64  `json:"slot_id" validate:"required,gt=0" example:"31"`
+	ProductID *int64 `json:"product_id,omitempty" validate:"omitempty,gt=0" example:"11"`
+	// Omit убирает необязательный слот из комплекта.
+	Omit bool `json:"omit,omitempty" example:"false"`
+}
+
+func (r PresetConfigureRequest) Validate() error {
+	var errs []ve.FieldError
+	for idx, c := range r.Choices {
+		field := fmt.Sprintf("choices[%d]", idx)
+		if err := validate.Struct(c); err != nil {
+			if _, ok := err.(*validator.InvalidValidationError); ok {
+				return err
+			}
+			for _, e := range err.(validator.ValidationErrors) {
+				switch e.Field() {
+				case "SlotID":
+					errs = append(errs, ve.NewFieldError(field+".slot_id", i18n.MsgSlotIDRequired))
+				case "ProductID":
+					errs = append(errs, ve.NewFieldError(field+".product_id", i18n.MsgProductIDPositive))
+				default:
+					errs = append(errs, ve.NewFieldError(field+"."+e.Field(), i18n.MsgInvalidField))
+				}
+			}
+		}
+		if c.Omit && c.ProductID != nil {
+			errs = append(errs, ve.NewFieldError(field, i18n.MsgProductOrOmit))
+		}
+	}
+
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+
+	return nil
+}
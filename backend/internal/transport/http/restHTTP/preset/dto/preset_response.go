@@ -1,35 +1,75 @@
 package dto
 
+import discountDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
+
 //swaggo:model PresetResponse
 type PresetResponse struct {
-	PresetID    int64                `json:"preset_id" example:"1"`
-	Name        string               `json:"name" example:"Комплект для ванной"`
-	Description *string              `json:"description,omitempty" example:"Полный комплект для ванной комнаты"`
-	TotalPrice  float64              `json:"total_price" example:"15000"`
-	ImageURL    *string              `json:"image_url,omitempty" example:"https://example.com/image.png"`
-	CreatedAt   string               `json:"created_at" example:"2025-06-20T15:00:00Z"`
-	Items       []PresetResponseItem `json:"items"`
+	PresetID    int64   `json:"preset_id" example:"1"`
+	Name        string  `json:"name" example:"Комплект для ванной"`
+	Description *string `json:"description,omitempty" example:"Полный комплект для ванной комнаты"`
+	TotalPrice  float64 `json:"total_price" example:"15000"`
+	Currency    string  `json:"currency" example:"RUB"`
+	// OldTotalPrice и Discount заполнены, если на пресет действует скидка.
+	OldTotalPrice *float64                             `json:"old_total_price,omitempty" example:"18000"`
+	Discount      *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
+	ImageURL      *string                              `json:"image_url,omitempty" example:"https://example.com/image.png"`
+	CreatedAt     string                               `json:"created_at" example:"2025-06-20T15:00:00Z"`
+	IsTemplate    bool                                 `json:"is_template" example:"false"`
+	Items         []PresetResponseItem                 `json:"items"`
 }
 
 //swaggo:model PresetShortResponse
 type PresetShortResponse struct {
-	PresetID    int64   `json:"preset_id" example:"1"`
-	Name        string  `json:"name" example:"Комплект для ванной"`
-	Description *string `json:"description,omitempty" example:"Для ванной комнаты"`
-	TotalPrice  float64 `json:"total_price,omitempty" example:"15000"` // может быть опциональным
-	ImageURL    *string `json:"image_url,omitempty" example:"https://example.com/image.png"`
-	CreatedAt   string  `json:"created_at" example:"2025-06-20T15:00:00Z"`
+	PresetID      int64                                `json:"preset_id" example:"1"`
+	Name          string                               `json:"name" example:"Комплект для ванной"`
+	Description   *string                              `json:"description,omitempty" example:"Для ванной комнаты"`
+	TotalPrice    float64                              `json:"total_price,omitempty" example:"15000"` // может быть опциональным
+	Currency      string                               `json:"currency,omitempty" example:"RUB"`
+	OldTotalPrice *float64                             `json:"old_total_price,omitempty" example:"18000"`
+	Discount      *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
+	ImageURL      *string                              `json:"image_url,omitempty" example:"https://example.com/image.png"`
+	CreatedAt     string                               `json:"created_at" example:"2025-06-20T15:00:00Z"`
+	IsTemplate    bool                                 `json:"is_template" example:"false"`
 }
 
 //swaggo:model PresetResponseItem
 type PresetResponseItem struct {
-	Product ProductSummary `json:"product"`
+	// SlotID — идентификатор слота для конфигуратора.
+	SlotID          int64          `json:"slot_id" example:"31"`
+	SlotName        *string        `json:"slot_name,omitempty" example:"Раковина"`
+	Optional        bool           `json:"optional" example:"false"`
+	Product         ProductSummary `json:"product"`
+	Quantity        float64        `json:"quantity" example:"1"`
+	QuantityFormula *string        `json:"quantity_formula,omitempty" example:"ceil(wall_area / 1.2)"`
+	// Alternatives — товары, допустимые в слоте вместо product.
+	Alternatives []ProductSummary `json:"alternatives,omitempty"`
+}
+
+//swaggo:model PresetConfigurationResponse
+type PresetConfigurationResponse struct {
+	PresetID   int64                     `json:"preset_id" example:"3"`
+	TotalPrice float64                   `json:"total_price" example:"9500"`
+	Currency   string                    `json:"currency" example:"RUB"`
+	Items      []PresetConfigurationItem `json:"items"`
+}
+
+//swaggo:model PresetConfigurationItem
+type PresetConfigurationItem struct {
+	SlotID   int64          `json:"slot_id" example:"31"`
+	SlotName *string        `json:"slot_name,omitempty" example:"Раковина"`
+	Product  ProductSummary `json:"product"`
+	Quantity float64        `json:"quantity" example:"1"`
+	// Replaced — товар по умолчанию заменён выбором клиента.
+	Replaced bool `json:"replaced" example:"true"`
 }
 
 //swaggo:model ProductSummary
 type ProductSummary struct {
-	ID       int64   `json:"id" example:"10"`
-	Name     string  `json:"name" example:"Шампунь"`
-	Price    float64 `json:"price" example:"499"`
-	ImageURL *string `json:"image_url,omitempty" example:"https://example.com/shampoo.png"`
+	ID       int64                                `json:"id" example:"10"`
+	Name     string                               `json:"name" example:"Шампунь"`
+	Price    float64                              `json:"price" example:"499"`
+	Currency string                               `json:"currency,omitempty" example:"RUB"`
+	OldPrice *float64                             `json:"old_price,omitempty" example:"599"`
+	Discount *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
+	ImageURL *string                              `json:"image_url,omitempty" example:"https://example.com/shampoo.png"`
 }
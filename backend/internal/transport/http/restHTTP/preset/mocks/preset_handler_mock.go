@@ -38,6 +38,154 @@ func (_m *MockPresetService) EXPECT() *MockPresetService_Expecter {
 	return &MockPresetService_Expecter{mock: &_m.Mock}
 }
 
+// Clone provides a mock function for the type MockPresetService
+func (_mock *MockPresetService) Clone(ctx context.Context, id int64, name *string) (*preset.Preset, error) {
+	ret := _mock.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clone")
+	}
+
+	var r0 *preset.Preset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *string) (*preset.Preset, error)); ok {
+		return returnFunc(ctx, id, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *string) *preset.Preset); ok {
+		r0 = returnFunc(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preset.Preset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, *string) error); ok {
+		r1 = returnFunc(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPresetService_Clone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clone'
+type MockPresetService_Clone_Call struct {
+	*mock.Call
+}
+
+// Clone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - name *string
+func (_e *MockPresetService_Expecter) Clone(ctx interface{}, id interface{}, name interface{}) *MockPresetService_Clone_Call {
+	return &MockPresetService_Clone_Call{Call: _e.mock.On("Clone", ctx, id, name)}
+}
+
+func (_c *MockPresetService_Clone_Call) Run(run func(ctx context.Context, id int64, name *string)) *MockPresetService_Clone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 *string
+		if args[2] != nil {
+			arg2 = args[2].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPresetService_Clone_Call) Return(preset1 *preset.Preset, err error) *MockPresetService_Clone_Call {
+	_c.Call.Return(preset1, err)
+	return _c
+}
+
+func (_c *MockPresetService_Clone_Call) RunAndReturn(run func(ctx context.Context, id int64, name *string) (*preset.Preset, error)) *MockPresetService_Clone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Configure provides a mock function for the type MockPresetService
+func (_mock *MockPresetService) Configure(ctx context.Context, id int64, choices []preset.Choice) (*preset.Configuration, error) {
+	ret := _mock.Called(ctx, id, choices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Configure")
+	}
+
+	var r0 *preset.Configuration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []preset.Choice) (*preset.Configuration, error)); ok {
+		return returnFunc(ctx, id, choices)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []preset.Choice) *preset.Configuration); ok {
+		r0 = returnFunc(ctx, id, choices)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preset.Configuration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []preset.Choice) error); ok {
+		r1 = returnFunc(ctx, id, choices)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPresetService_Configure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Configure'
+type MockPresetService_Configure_Call struct {
+	*mock.Call
+}
+
+// Configure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - choices []preset.Choice
+func (_e *MockPresetService_Expecter) Configure(ctx interface{}, id interface{}, choices interface{}) *MockPresetService_Configure_Call {
+	return &MockPresetService_Configure_Call{Call: _e.mock.On("Configure", ctx, id, choices)}
+}
+
+func (_c *MockPresetService_Configure_Call) Run(run func(ctx context.Context, id int64, choices []preset.Choice)) *MockPresetService_Configure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 []preset.Choice
+		if args[2] != nil {
+			arg2 = args[2].([]preset.Choice)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPresetService_Configure_Call) Return(configuration *preset.Configuration, err error) *MockPresetService_Configure_Call {
+	_c.Call.Return(configuration, err)
+	return _c
+}
+
+func (_c *MockPresetService_Configure_Call) RunAndReturn(run func(ctx context.Context, id int64, choices []preset.Choice) (*preset.Configuration, error)) *MockPresetService_Configure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockPresetService
 func (_mock *MockPresetService) Create(ctx context.Context, p *preset.Preset) (*preset.Preset, error) {
 	ret := _mock.Called(ctx, p)
@@ -231,6 +379,86 @@ func (_c *MockPresetService_Get_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// Instantiate provides a mock function for the type MockPresetService
+func (_mock *MockPresetService) Instantiate(ctx context.Context, id int64, room preset.RoomParams, name *string) (*preset.Preset, error) {
+	ret := _mock.Called(ctx, id, room, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Instantiate")
+	}
+
+	var r0 *preset.Preset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, preset.RoomParams, *string) (*preset.Preset, error)); ok {
+		return returnFunc(ctx, id, room, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, preset.RoomParams, *string) *preset.Preset); ok {
+		r0 = returnFunc(ctx, id, room, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preset.Preset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, preset.RoomParams, *string) error); ok {
+		r1 = returnFunc(ctx, id, room, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPresetService_Instantiate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Instantiate'
+type MockPresetService_Instantiate_Call struct {
+	*mock.Call
+}
+
+// Instantiate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - room preset.RoomParams
+//   - name *string
+func (_e *MockPresetService_Expecter) Instantiate(ctx interface{}, id interface{}, room interface{}, name interface{}) *MockPresetService_Instantiate_Call {
+	return &MockPresetService_Instantiate_Call{Call: _e.mock.On("Instantiate", ctx, id, room, name)}
+}
+
+func (_c *MockPresetService_Instantiate_Call) Run(run func(ctx context.Context, id int64, room preset.RoomParams, name *string)) *MockPresetService_Instantiate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 preset.RoomParams
+		if args[2] != nil {
+			arg2 = args[2].(preset.RoomParams)
+		}
+		var arg3 *string
+		if args[3] != nil {
+			arg3 = args[3].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPresetService_Instantiate_Call) Return(preset1 *preset.Preset, err error) *MockPresetService_Instantiate_Call {
+	_c.Call.Return(preset1, err)
+	return _c
+}
+
+func (_c *MockPresetService_Instantiate_Call) RunAndReturn(run func(ctx context.Context, id int64, room preset.RoomParams, name *string) (*preset.Preset, error)) *MockPresetService_Instantiate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDetailed provides a mock function for the type MockPresetService
 func (_mock *MockPresetService) ListDetailed(ctx context.Context) ([]preset.Preset, error) {
 	ret := _mock.Called(ctx)
@@ -355,6 +583,74 @@ func (_c *MockPresetService_ListShort_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// Patch provides a mock function for the type MockPresetService
+func (_mock *MockPresetService) Patch(ctx context.Context, p *preset.Patch) (*preset.Preset, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *preset.Preset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *preset.Patch) (*preset.Preset, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *preset.Patch) *preset.Preset); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preset.Preset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *preset.Patch) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPresetService_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockPresetService_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *preset.Patch
+func (_e *MockPresetService_Expecter) Patch(ctx interface{}, p interface{}) *MockPresetService_Patch_Call {
+	return &MockPresetService_Patch_Call{Call: _e.mock.On("Patch", ctx, p)}
+}
+
+func (_c *MockPresetService_Patch_Call) Run(run func(ctx context.Context, p *preset.Patch)) *MockPresetService_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *preset.Patch
+		if args[1] != nil {
+			arg1 = args[1].(*preset.Patch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPresetService_Patch_Call) Return(preset1 *preset.Preset, err error) *MockPresetService_Patch_Call {
+	_c.Call.Return(preset1, err)
+	return _c
+}
+
+func (_c *MockPresetService_Patch_Call) RunAndReturn(run func(ctx context.Context, p *preset.Patch) (*preset.Preset, error)) *MockPresetService_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockPresetService
 func (_mock *MockPresetService) Update(ctx context.Context, p *preset.Preset) (*preset.Preset, error) {
 	ret := _mock.Called(ctx, p)
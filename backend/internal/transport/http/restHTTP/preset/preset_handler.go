@@ -8,7 +8,9 @@ import (
 	"net/http"
 
 	"github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/patch"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/preset/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
@@ -16,9 +18,13 @@ type PresetService interface {
 	Create(ctx context.Context, p *preset.Preset) (*preset.Preset, error)
 	Get(ctx context.Context, id int64) (*preset.Preset, error)
 	Update(ctx context.Context, p *preset.Preset) (*preset.Preset, error)
+	Patch(ctx context.Context, p *preset.Patch) (*preset.Preset, error)
 	Delete(ctx context.Context, id int64) error
 	ListDetailed(ctx context.Context) ([]preset.Preset, error)
 	ListShort(ctx context.Context) ([]preset.Preset, error)
+	Clone(ctx context.Context, id int64, name *string) (*preset.Preset, error)
+	Instantiate(ctx context.Context, id int64, room preset.RoomParams, name *string) (*preset.Preset, error)
+	Configure(ctx context.Context, id int64, choices []preset.Choice) (*preset.Configuration, error)
 }
 
 type Deps struct {
@@ -77,7 +83,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	p := req.MapToPreset()
 	preset, err := h.srv.Create(ctx, p)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapDomainToDto(preset)
@@ -109,11 +115,12 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 	p, err := h.srv.Get(ctx, id)
 	if err != nil {
 
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
 	resp := dto.MapDomainToDto(p)
+	http_utils.SetETag(w, p.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
@@ -122,9 +129,12 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 // @Tags Preset
 // @Security BearerAuth
 // @Param id path int true "Preset ID"
+// @Param If-Match header string true "ETag текущей версии или *"
 // @Success 204
 // @Failure 400 {object} http_utils.ErrorResponse
 // @Failure 404 {object} http_utils.ErrorResponse
+// @Failure 412 {object} http_utils.ErrorResponse
+// @Failure 428 {object} http_utils.ErrorResponse
 // @Failure 500 {object} http_utils.ErrorResponse
 // @Router /api/admin/presets/{id} [delete]
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -140,7 +150,7 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	err = h.srv.Delete(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -202,10 +212,13 @@ func (h *Handler) ListShort(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Preset ID"
+// @Param If-Match header string true "ETag текущей версии или *"
 // @Param preset body 	 dto.PresetRequest true "Preset data"//
 // @Success 200 {object} dto.PresetResponse
 // @Failure 404 {object} http_utils.ErrorResponse
 // @Failure 422 {object} http_utils.ErrorResponse
+// @Failure 412 {object} http_utils.ErrorResponse
+// @Failure 428 {object} http_utils.ErrorResponse
 // @Failure 500 {object} http_utils.ErrorResponse
 // @Router /api/admin/presets/{id} [put]
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
@@ -226,41 +239,195 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	p := req.MapUpdateToPreset(id)
 	res, err := h.srv.Update(ctx, p)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapDomainToDto(res)
+	http_utils.SetETag(w, res.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
-func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, preset.ErrPresetNotFound):
-		h.log.Warn("Preset not found", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusNotFound, "preset not found")
+// Patch godoc
+// @Summary Partially update preset
+// @Description JSON Merge Patch (application/merge-patch+json, application/json) или JSON Patch
+// @Description (application/json-patch+json) поверх тела PUT. Позиции, если изменились, заменяются целиком.
+// @Tags Preset
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Preset ID"
+// @Param If-Match header string true "ETag текущей версии или *"
+// @Param patch body object true "Merge patch or JSON Patch document"
+// @Success 200 {object} dto.PresetResponse
+// @Failure 400 {object} http_utils.ErrorResponse
+// @Failure 404 {object} http_utils.ErrorResponse
+// @Failure 409 {object} http_utils.ErrorResponse
+// @Failure 415 {object} http_utils.ErrorResponse
+// @Failure 422 {object} http_utils.ErrorResponse
+// @Failure 412 {object} http_utils.ErrorResponse
+// @Failure 428 {object} http_utils.ErrorResponse
+// @Failure 500 {object} http_utils.ErrorResponse
+// @Router /api/admin/presets/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Patch")
+	id, err := http_utils.IDFromURL(r, "id")
 
-	case errors.Is(err, preset.ErrPresetAlreadyExists):
-		h.log.Warn("Preset already exists", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusConflict, "A preset with this name already exists")
+	if err != nil || id <= 0 {
+		log.Warn("invalid ID from URL", slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
 
-	case errors.Is(err, preset.ErrNoItems):
-		h.log.Warn("No items in preset", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "At least one item is required")
+	cur, err := h.srv.Get(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	before := dto.MapDomainToRequest(cur)
+	after, ok := patch.Apply(w, r, log, &before)
+	if !ok {
+		return
+	}
+	res, err := h.srv.Patch(ctx, dto.MapPatchToDomain(cur.ID, cur.Version, &before, after))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, res.Version)
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapDomainToDto(res))
+}
 
-	case errors.Is(err, preset.ErrNameTooLong):
-		h.log.Warn("Preset name too long", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "Name must be at most 100 characters")
+// Clone godoc
+// @Summary Clone preset
+// @Description Deep-copy a preset with its items. Name defaults to the source name with a copy suffix
+// @Tags Preset
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Preset ID"
+// @Param preset body dto.PresetCloneRequest false "Clone options"
+// @Success 201 {object} dto.PresetResponse
+// @Failure 400 {object} http_utils.ErrorResponse
+// @Failure 404 {object} http_utils.ErrorResponse
+// @Failure 422 {object} http_utils.ErrorResponse
+// @Failure 500 {object} http_utils.ErrorResponse
+// @Router /api/admin/presets/{id}/clone [post]
+func (h *Handler) Clone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Clone")
 
-	case errors.Is(err, preset.ErrTotalPriceMismatch):
-		h.log.Warn("Preset total price mismatch", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "Total price must equal sum of item prices")
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid ID from URL", slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
 
-	case errors.Is(err, preset.ErrInvalidProductID):
-		h.log.Warn("Invalid product ID in preset", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "One or more product IDs are invalid")
+	var name *string
+	req, ok := http_utils.DecodeOptional[dto.PresetCloneRequest](w, r, log)
+	if !ok {
+		return
+	}
+	if req != nil {
+		name = req.Name
+	}
 
-	default:
-		h.log.Error("Unhandled internal server error", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusInternalServerError, "internal server error")
+	res, err := h.srv.Clone(ctx, id, name)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
 	}
+	resp := dto.MapDomainToDto(res)
+	w.Header().Set("Location", fmt.Sprintf("/api/presets/%d", resp.PresetID))
+	http_utils.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// Instantiate godoc
+// @Summary Instantiate preset template
+// @Description Build a concrete preset from a template: item quantities are evaluated from room dimensions (meters).
+// @Description Formula variables: length, width, height, perimeter, floor_area, wall_area, volume, openings_area
+// @Tags Preset
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template preset ID"
+// @Param room body dto.PresetInstantiateRequest true "Room dimensions"
+// @Success 201 {object} dto.PresetResponse
+// @Failure 400 {object} http_utils.ErrorResponse
+// @Failure 404 {object} http_utils.ErrorResponse
+// @Failure 422 {object} http_utils.ErrorResponse
+// @Failure 500 {object} http_utils.ErrorResponse
+// @Router /api/admin/presets/{id}/instantiate [post]
+func (h *Handler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Instantiate")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid ID from URL", slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	req, ok := http_utils.DecodeAndValidate[dto.PresetInstantiateRequest](w, r, log)
+	if !ok {
+		return
+	}
+
+	res, err := h.srv.Instantiate(ctx, id, req.MapToRoomParams(), req.Name)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	resp := dto.MapDomainToDto(res)
+	w.Header().Set("Location", fmt.Sprintf("/api/presets/%d", resp.PresetID))
+	http_utils.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// Configure godoc
+// @Summary Configure preset
+// @Description Swap slot products for allowed alternatives and drop optional slots; returns the resulting
+// @Description composition and price. Slots without a choice keep the default product. Nothing is saved
+// @Tags Preset
+// @Accept json
+// @Produce json
+// @Param id path int true "Preset ID"
+// @Param choices body dto.PresetConfigureRequest false "Slot choices"
+// @Success 200 {object} dto.PresetConfigurationResponse
+// @Failure 400 {object} http_utils.ErrorResponse
+// @Failure 404 {object} http_utils.ErrorResponse
+// @Failure 422 {object} http_utils.ErrorResponse
+// @Failure 500 {object} http_utils.ErrorResponse
+// @Router /api/presets/{id}/configure [post]
+func (h *Handler) Configure(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Configure")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid ID from URL", slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var choices []preset.Choice
+	req, ok := http_utils.DecodeOptional[dto.PresetConfigureRequest](w, r, log)
+	if !ok {
+		return
+	}
+	if req != nil {
+		choices = req.MapToChoices()
+	}
+
+	res, err := h.srv.Configure(ctx, id, choices)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapConfigurationToDto(res))
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
 }
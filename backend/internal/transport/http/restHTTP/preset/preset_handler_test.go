@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 
 	"log/slog"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	domPreset "github.com/Neimess/zorkin-store-project/internal/domain/preset"
 	domProduct "github.com/Neimess/zorkin-store-project/internal/domain/product"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/preset/mocks"
@@ -81,10 +83,10 @@ func (s *PresetHandlerSuite) TestCreatePreset() {
 				p: &domPreset.Preset{
 					ID:         42,
 					Name:       "MyPreset",
-					TotalPrice: 42.5,
+					TotalPrice: money.FromFloat(42.5, money.Base),
 					Items: []domPreset.PresetItem{{
 						ProductID: 1, PresetID: 42,
-						Product: &domProduct.ProductSummary{ID: 1, Name: "Product 1", Price: 42.5},
+						Product: &domProduct.ProductSummary{ID: 1, Name: "Product 1", Price: money.FromFloat(42.5, money.Base)},
 					}},
 				},
 				err: nil,
@@ -165,10 +167,10 @@ func (s *PresetHandlerSuite) TestGetPreset() {
 	sample := &domPreset.Preset{
 		ID:         5,
 		Name:       "X",
-		TotalPrice: 10,
+		TotalPrice: money.FromFloat(10, money.Base),
 		Items: []domPreset.PresetItem{
-			{ProductID: 2, PresetID: 5, Product: &domProduct.ProductSummary{ID: 2, Name: "P2", Price: 2}},
-			{ProductID: 3, PresetID: 5, Product: &domProduct.ProductSummary{ID: 3, Name: "P3", Price: 8}},
+			{ProductID: 2, PresetID: 5, Product: &domProduct.ProductSummary{ID: 2, Name: "P2", Price: money.FromFloat(2, money.Base)}},
+			{ProductID: 3, PresetID: 5, Product: &domProduct.ProductSummary{ID: 3, Name: "P3", Price: money.FromFloat(8, money.Base)}},
 		},
 	}
 
@@ -347,10 +349,10 @@ func (s *PresetHandlerSuite) TestUpdatePreset() {
 				p: &domPreset.Preset{
 					ID:         10,
 					Name:       "UpdatedPreset",
-					TotalPrice: 50.0,
+					TotalPrice: money.FromFloat(50.0, money.Base),
 					Items: []domPreset.PresetItem{{
 						ProductID: 2, PresetID: 10,
-						Product: &domProduct.ProductSummary{ID: 2, Name: "Product 2", Price: 50.0},
+						Product: &domProduct.ProductSummary{ID: 2, Name: "Product 2", Price: money.FromFloat(50.0, money.Base)},
 					}},
 				},
 				err: nil,
@@ -423,6 +425,208 @@ func (s *PresetHandlerSuite) TestUpdatePreset() {
 	}
 }
 
+// === TestClonePreset ===
+
+func (s *PresetHandlerSuite) TestClonePreset() {
+	cloned := &domPreset.Preset{
+		ID:   11,
+		Name: "MyPreset (копия)",
+		Items: []domPreset.PresetItem{{
+			ProductID: 2, PresetID: 11, Quantity: 3,
+			Product: &domProduct.ProductSummary{ID: 2, Name: "Product 2", Price: money.FromFloat(50.0, money.Base)},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		idParam    string
+		body       string
+		mockName   interface{}
+		svcReturn  *domPreset.Preset
+		svcErr     error
+		wantStatus int
+	}{
+		{"success without body", "10", "", (*string)(nil), cloned, nil, http.StatusCreated},
+		{"success with name", "10", `{"name":"Client copy"}`, mock.MatchedBy(func(n *string) bool { return n != nil && *n == "Client copy" }), cloned, nil, http.StatusCreated},
+		{"invalid id", "abc", "", nil, nil, nil, http.StatusBadRequest},
+		{"invalid JSON", "10", `{`, nil, nil, nil, http.StatusBadRequest},
+		{"validation error", "10", `{"name":"x"}`, nil, nil, nil, http.StatusUnprocessableEntity},
+		{"not found", "10", "", (*string)(nil), nil, domPreset.ErrPresetNotFound, http.StatusNotFound},
+		{"internal error", "10", "", (*string)(nil), nil, errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			req := withChiParam(
+				httptest.NewRequest(http.MethodPost, "/api/admin/presets/"+tc.idParam+"/clone", strings.NewReader(tc.body)),
+				"id", tc.idParam,
+			)
+			w := httptest.NewRecorder()
+
+			if tc.mockName != nil {
+				s.mockSvc.
+					On("Clone", mock.Anything, int64(10), tc.mockName).
+					Return(tc.svcReturn, tc.svcErr).
+					Once()
+			}
+
+			s.h.Clone(w, req)
+
+			assert.Equal(s.T(), tc.wantStatus, w.Code)
+			if tc.wantStatus == http.StatusCreated {
+				assert.Equal(s.T(), "/api/presets/11", w.Header().Get("Location"))
+				var resp map[string]interface{}
+				require.NoError(s.T(), json.Unmarshal(w.Body.Bytes(), &resp))
+				items := resp["items"].([]interface{})
+				assert.Equal(s.T(), 3.0, items[0].(map[string]interface{})["quantity"])
+			}
+			s.mockSvc.AssertExpectations(s.T())
+		})
+	}
+
+	s.Run("chunked body without Content-Length", func() {
+		s.SetupTest()
+		req := withChiParam(
+			httptest.NewRequest(http.MethodPost, "/api/admin/presets/10/clone", strings.NewReader(`{"name":"Client copy"}`)),
+			"id", "10",
+		)
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		s.mockSvc.
+			On("Clone", mock.Anything, int64(10), mock.MatchedBy(func(n *string) bool { return n != nil && *n == "Client copy" })).
+			Return(cloned, nil).
+			Once()
+
+		s.h.Clone(w, req)
+
+		assert.Equal(s.T(), http.StatusCreated, w.Code)
+		s.mockSvc.AssertExpectations(s.T())
+	})
+}
+
+// === TestInstantiatePreset ===
+
+func (s *PresetHandlerSuite) TestInstantiatePreset() {
+	validBody := `{"length":2.5,"width":1.8,"height":2.7,"openings_area":1.6}`
+	created := &domPreset.Preset{
+		ID:         12,
+		Name:       "Bathroom template",
+		TotalPrice: money.FromFloat(28500, money.Base),
+		Items: []domPreset.PresetItem{{
+			ProductID: 1, PresetID: 12, Quantity: 4,
+			Product: &domProduct.ProductSummary{ID: 1, Name: "Tile", Price: money.FromFloat(1000, money.Base)},
+		}},
+	}
+	room := domPreset.RoomParams{Length: 2.5, Width: 1.8, Height: 2.7, OpeningsArea: 1.6}
+
+	tests := []struct {
+		name       string
+		idParam    string
+		body       string
+		callSvc    bool
+		svcReturn  *domPreset.Preset
+		svcErr     error
+		wantStatus int
+	}{
+		{"success", "7", validBody, true, created, nil, http.StatusCreated},
+		{"invalid id", "0", validBody, false, nil, nil, http.StatusBadRequest},
+		{"invalid JSON", "7", `{`, false, nil, nil, http.StatusBadRequest},
+		{"validation error", "7", `{"length":0,"width":1.8,"height":2.7}`, false, nil, nil, http.StatusUnprocessableEntity},
+		{"not a template", "7", validBody, true, nil, domPreset.ErrNotTemplate, http.StatusUnprocessableEntity},
+		{"broken formula", "7", validBody, true, nil, fmt.Errorf("%w: division by zero", domPreset.ErrInvalidFormula), http.StatusUnprocessableEntity},
+		{"not found", "7", validBody, true, nil, domPreset.ErrPresetNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			req := withChiParam(
+				httptest.NewRequest(http.MethodPost, "/api/admin/presets/"+tc.idParam+"/instantiate", strings.NewReader(tc.body)),
+				"id", tc.idParam,
+			)
+			w := httptest.NewRecorder()
+
+			if tc.callSvc {
+				s.mockSvc.
+					On("Instantiate", mock.Anything, int64(7), room, (*string)(nil)).
+					Return(tc.svcReturn, tc.svcErr).
+					Once()
+			}
+
+			s.h.Instantiate(w, req)
+
+			assert.Equal(s.T(), tc.wantStatus, w.Code)
+			s.mockSvc.AssertExpectations(s.T())
+		})
+	}
+}
+
+// === TestConfigurePreset ===
+
+func (s *PresetHandlerSuite) TestConfigurePreset() {
+	slot := "Раковина"
+	configured := &domPreset.Configuration{
+		PresetID:   3,
+		TotalPrice: money.FromFloat(9500, money.Base),
+		Items: []domPreset.ConfiguredItem{{
+			SlotID: 31, SlotName: &slot, Quantity: 1, Replaced: true,
+			Product: &domProduct.ProductSummary{ID: 11, Name: "Раковина 60", Price: money.FromFloat(7500, money.Base)},
+		}},
+	}
+	choices := []domPreset.Choice{{SlotID: 31, ProductID: 11}, {SlotID: 33, Omit: true}}
+
+	tests := []struct {
+		name       string
+		body       string
+		choices    interface{}
+		svcErr     error
+		wantStatus int
+	}{
+		{"success", `{"choices":[{"slot_id":31,"product_id":11},{"slot_id":33,"omit":true}]}`, choices, nil, http.StatusOK},
+		{"defaults without body", "", []domPreset.Choice(nil), nil, http.StatusOK},
+		{"invalid JSON", `{`, nil, nil, http.StatusBadRequest},
+		{"missing slot", `{"choices":[{"product_id":11}]}`, nil, nil, http.StatusUnprocessableEntity},
+		{"product and omit", `{"choices":[{"slot_id":31,"product_id":11,"omit":true}]}`, nil, nil, http.StatusUnprocessableEntity},
+		{"product not allowed", `{"choices":[{"slot_id":31,"product_id":11},{"slot_id":33,"omit":true}]}`, choices, domPreset.ErrProductNotAllowed, http.StatusUnprocessableEntity},
+		{"not found", "", []domPreset.Choice(nil), domPreset.ErrPresetNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			req := withChiParam(
+				httptest.NewRequest(http.MethodPost, "/api/presets/3/configure", strings.NewReader(tc.body)),
+				"id", "3",
+			)
+			w := httptest.NewRecorder()
+
+			if tc.choices != nil {
+				var res *domPreset.Configuration
+				if tc.svcErr == nil {
+					res = configured
+				}
+				s.mockSvc.
+					On("Configure", mock.Anything, int64(3), tc.choices).
+					Return(res, tc.svcErr).
+					Once()
+			}
+
+			s.h.Configure(w, req)
+
+			assert.Equal(s.T(), tc.wantStatus, w.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.JSONEq(s.T(), `{
+					"preset_id": 3, "total_price": 9500, "currency": "RUB",
+					"items": [{"slot_id": 31, "slot_name": "Раковина", "quantity": 1, "replaced": true,
+						"product": {"id": 11, "name": "Раковина 60", "price": 7500, "currency": "RUB"}}]
+				}`, w.Body.String())
+			}
+			s.mockSvc.AssertExpectations(s.T())
+		})
+	}
+}
+
 func TestPresetHandlerSuite(t *testing.T) {
 	suite.Run(t, new(PresetHandlerSuite))
 }
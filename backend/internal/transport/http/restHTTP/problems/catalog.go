@@ -0,0 +1,64 @@
+package problems
+
+import (
+	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// ProblemTypeResponse — описание одного кода ошибки в каталоге.
+type ProblemTypeResponse struct {
+	Type   string `json:"type" example:"/api/errors/category.not_found"`
+	Code   string `json:"code" example:"category.not_found"`
+	Status int    `json:"status" example:"404"`
+	Title  string `json:"title" example:"category not found"`
+}
+
+func toResponse(t http_utils.ProblemType, lang string) ProblemTypeResponse {
+	return ProblemTypeResponse{
+		Type:   http_utils.DefaultDocsURL + "/" + t.Code,
+		Code:   t.Code,
+		Status: t.Status,
+		Title:  t.Title(lang),
+	}
+}
+
+// Catalog godoc
+// @Summary      Error codes
+// @Description  Lists all machine-readable error codes returned in problem+json responses
+// @Tags         errors
+// @Produce      json
+// @Param        Accept-Language  header  string  false  "Title language (en, ru)"
+// @Success      200  {array}  problems.ProblemTypeResponse
+// @Router       /api/errors [get]
+func Catalog(w http.ResponseWriter, r *http.Request) {
+	lang := http_utils.Lang(r)
+	types := http_utils.Problems.Catalog()
+	resp := make([]ProblemTypeResponse, len(types))
+	for i, t := range types {
+		resp[i] = toResponse(t, lang)
+	}
+	http_utils.WriteJSON(w, http.StatusOK, resp)
+}
+
+// Describe godoc
+// @Summary      Error code
+// @Description  Describes a single error code; this is the target of the problem "type" URL
+// @Tags         errors
+// @Produce      json
+// @Param        code  path      string  true  "Error code"
+// @Success      200   {object}  problems.ProblemTypeResponse
+// @Failure      404   {object}  http_utils.ErrorResponse  "Unknown code"
+// @Router       /api/errors/{code} [get]
+func Describe(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	lang := http_utils.Lang(r)
+	for _, t := range http_utils.Problems.Catalog() {
+		if t.Code == code {
+			http_utils.WriteJSON(w, http.StatusOK, toResponse(t, lang))
+			return
+		}
+	}
+	http_utils.WriteError(w, http.StatusNotFound, "unknown error code")
+}
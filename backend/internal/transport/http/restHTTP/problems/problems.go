@@ -0,0 +1,240 @@
+// Package problems — единый реестр доменных ошибок HTTP API.
+//
+// Каждая sentinel-ошибка домена получает статус, стабильный машиночитаемый
+// код и заголовок на поддерживаемых языках. Хендлеры не сопоставляют ошибки
+// сами, а передают их в Write; каталог кодов отдаётся по GET /api/errors.
+package problems
+
+import (
+	"log/slog"
+	"net/http"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	batchDom "github.com/Neimess/zorkin-store-project/internal/domain/batch"
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	coeffDom "github.com/Neimess/zorkin-store-project/internal/domain/coefficients"
+	discountDom "github.com/Neimess/zorkin-store-project/internal/domain/discount"
+	externalDom "github.com/Neimess/zorkin-store-project/internal/domain/external"
+	idempotencyDom "github.com/Neimess/zorkin-store-project/internal/domain/idempotency"
+	leadDom "github.com/Neimess/zorkin-store-project/internal/domain/lead"
+	moneyDom "github.com/Neimess/zorkin-store-project/internal/domain/money"
+	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	reviewDom "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	snapshotDom "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	trDom "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	webhookDom "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/jsonpatch"
+)
+
+func init() {
+	http_utils.Problems.Register(catalog...)
+}
+
+// Write отвечает problem+json для ошибки сервиса и логирует её:
+// 5xx — как ошибку, остальное — как предупреждение.
+func Write(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	http_utils.WriteProblem(w, For(r, log, err))
+}
+
+// For строит problem-документ для ошибки и логирует её так же, как Write,
+// но ничего не пишет в ответ — для ошибок, вложенных в тело (пакетные операции).
+func For(r *http.Request, log *slog.Logger, err error) http_utils.ErrorResponse {
+	p := http_utils.Problems.Problem(r, err)
+	if p.Status >= http.StatusInternalServerError {
+		log.Error("service error", slog.String("code", p.Code), slog.Any("error", err))
+	} else {
+		log.Warn("request failed", slog.String("code", p.Code), slog.Any("error", err))
+	}
+	return p
+}
+
+func e(err error, status int, code, en, ru, kz string) http_utils.ProblemType {
+	return http_utils.ProblemType{
+		Err:    err,
+		Status: status,
+		Code:   code,
+		Titles: map[string]string{http_utils.LangEN: en, http_utils.LangRU: ru, http_utils.LangKZ: kz},
+	}
+}
+
+// detailed — ошибка валидации, текст которой отдаётся клиенту в detail.
+func detailed(err error, status int, code, en, ru, kz string) http_utils.ProblemType {
+	t := e(err, status, code, en, ru, kz)
+	t.ExposeDetail = true
+	return t
+}
+
+const unprocessable = http.StatusUnprocessableEntity
+
+var catalog = []http_utils.ProblemType{
+	// ── category ─────────────────────────────────────────────────────────
+	e(catDom.ErrCategoryNotFound, http.StatusNotFound, "category.not_found", "category not found", "категория не найдена", "санат табылмады"),
+	e(catDom.ErrCategoryNameEmpty, unprocessable, "category.name_empty", "category name cannot be empty", "название категории не может быть пустым", "санат атауы бос болмауы керек"),
+	e(catDom.ErrCategoryNameTooLong, unprocessable, "category.name_too_long", "category name must be at most 255 characters", "название категории не длиннее 255 символов", "санат атауы 255 таңбадан аспауы керек"),
+	e(catDom.ErrCategoryInUse, http.StatusConflict, "category.in_use", "category is in use and cannot be deleted", "категория используется и не может быть удалена", "санат пайдаланылуда, оны жою мүмкін емес"),
+	e(catDom.ErrCategoryNameExists, http.StatusConflict, "category.already_exists", "category name already exist", "категория с таким названием уже существует", "мұндай атаулы санат бұрыннан бар"),
+	e(catDom.ErrAttributeNameEmpty, unprocessable, "category.attribute_name_empty", "attribute name cannot be empty", "название атрибута не может быть пустым", "атрибут атауы бос болмауы керек"),
+
+	// ── attribute ────────────────────────────────────────────────────────
+	e(attrDom.ErrAttributeNotFound, http.StatusNotFound, "attribute.not_found", "attribute not found", "атрибут не найден", "атрибут табылмады"),
+	e(attrDom.ErrAttributeAlreadyExists, http.StatusConflict, "attribute.already_exists", "attribute already exists", "атрибут уже существует", "атрибут бұрыннан бар"),
+	e(attrDom.ErrAttributeConflict, http.StatusConflict, "attribute.conflict", "attribute conflict", "конфликт атрибутов", "атрибуттар қайшылығы"),
+	detailed(attrDom.ErrAttributeValidation, unprocessable, "attribute.invalid", "invalid attribute", "некорректный атрибут", "атрибут қате"),
+	e(attrDom.ErrInvalidCategoryID, http.StatusBadRequest, "attribute.invalid_category", "invalid category id", "некорректная категория", "санат қате"),
+
+	// ── product ──────────────────────────────────────────────────────────
+	e(prodDom.ErrProductNotFound, http.StatusNotFound, "product.not_found", "product not found", "товар не найден", "тауар табылмады"),
+	e(prodDom.ErrInvalidSort, http.StatusBadRequest, "product.invalid_sort", "invalid sort order", "некорректный порядок сортировки", "сұрыптау реті қате"),
+	e(prodDom.ErrBadCategoryID, http.StatusBadRequest, "product.bad_category", "invalid or missing category", "категория не указана или не существует", "санат көрсетілмеген немесе жоқ"),
+	e(prodDom.ErrBadServiceID, http.StatusBadRequest, "product.bad_service", "invalid service id", "некорректная услуга", "қызмет қате"),
+	e(prodDom.ErrInvalidAttribute, unprocessable, "product.invalid_attribute", "invalid attribute data", "некорректные значения атрибутов", "атрибут мәндері қате"),
+	e(prodDom.ErrRelationNotFound, http.StatusNotFound, "product.relation_not_found", "product relation not found", "связь товаров не найдена", "тауарлар байланысы табылмады"),
+	e(prodDom.ErrRelationAlreadyExists, http.StatusConflict, "product.relation_already_exists", "product relation already exists", "такая связь товаров уже есть", "мұндай тауарлар байланысы бұрыннан бар"),
+	detailed(prodDom.ErrBadRelatedProduct, unprocessable, "product.bad_related_product", "invalid related product", "некорректный связанный товар", "байланысты тауар қате"),
+	detailed(prodDom.ErrSelfRelation, unprocessable, "product.self_relation", "product cannot be related to itself", "товар нельзя связать с самим собой", "тауарды өзімен байланыстыруға болмайды"),
+	detailed(prodDom.ErrInvalidRelationType, unprocessable, "product.invalid_relation_type", "invalid product relation type", "некорректный тип связи", "байланыс түрі қате"),
+
+	// ── preset ───────────────────────────────────────────────────────────
+	e(presetDom.ErrPresetNotFound, http.StatusNotFound, "preset.not_found", "preset not found", "пресет не найден", "пресет табылмады"),
+	e(presetDom.ErrPresetAlreadyExists, http.StatusConflict, "preset.already_exists", "A preset with this name already exists", "пресет с таким названием уже существует", "мұндай атаулы пресет бұрыннан бар"),
+	e(presetDom.ErrNoItems, unprocessable, "preset.no_items", "At least one item is required", "нужна хотя бы одна позиция", "кемінде бір позиция қажет"),
+	e(presetDom.ErrNameTooLong, unprocessable, "preset.name_too_long", "Name must be at most 100 characters", "название не длиннее 100 символов", "атауы 100 таңбадан аспауы керек"),
+	e(presetDom.ErrDescriptionTooLong, unprocessable, "preset.description_too_long", "Description must be at most 500 characters", "описание не длиннее 500 символов", "сипаттамасы 500 таңбадан аспауы керек"),
+	e(presetDom.ErrItemNotFound, http.StatusNotFound, "preset.item_not_found", "preset item not found", "позиция пресета не найдена", "пресет позициясы табылмады"),
+	e(presetDom.ErrNilProductSummary, unprocessable, "preset.product_unavailable", "product of a preset item is unavailable", "товар позиции пресета недоступен", "пресет позициясының тауары қолжетімсіз"),
+	e(presetDom.ErrTotalPriceMismatch, unprocessable, "preset.total_price_mismatch", "Total price must equal sum of item prices", "итоговая цена должна совпадать с суммой позиций", "қорытынды баға позициялар сомасына тең болуы керек"),
+	e(presetDom.ErrInvalidProductID, unprocessable, "preset.invalid_product", "One or more product IDs are invalid", "один или несколько товаров не существуют", "бір немесе бірнеше тауар жоқ"),
+	e(presetDom.ErrNotTemplate, unprocessable, "preset.not_template", "Preset is not a template", "пресет не является шаблоном", "пресет үлгі емес"),
+	detailed(presetDom.ErrInvalidFormula, unprocessable, "preset.invalid_formula", "invalid quantity formula", "некорректная формула количества", "саны формуласы қате"),
+	detailed(presetDom.ErrUnknownVariable, unprocessable, "preset.unknown_variable", "unknown variable in quantity formula", "неизвестная переменная в формуле", "формулада белгісіз айнымалы бар"),
+	detailed(presetDom.ErrFormulaNotAllowed, unprocessable, "preset.formula_not_allowed", "quantity formula is allowed only in templates", "формулы допустимы только в шаблонах", "формулаларды тек үлгілерде қолдануға болады"),
+	detailed(presetDom.ErrInvalidQuantity, unprocessable, "preset.invalid_quantity", "invalid item quantity", "некорректное количество", "саны қате"),
+	detailed(presetDom.ErrInvalidRoomParams, unprocessable, "preset.invalid_room", "invalid room parameters", "некорректные размеры помещения", "бөлме өлшемдері қате"),
+	detailed(presetDom.ErrSlotNameTooLong, unprocessable, "preset.slot_name_too_long", "slot name must be at most 100 characters", "название слота не длиннее 100 символов", "слот атауы 100 таңбадан аспауы керек"),
+	detailed(presetDom.ErrInvalidAlternative, unprocessable, "preset.invalid_alternative", "invalid slot alternative", "некорректная замена в слоте", "слоттағы ауыстыру қате"),
+	e(presetDom.ErrNotConfigurable, unprocessable, "preset.not_configurable", "Template must be instantiated before configuring", "шаблон нужно сначала развернуть", "алдымен үлгіні жайып шығу керек"),
+	detailed(presetDom.ErrUnknownSlot, unprocessable, "preset.unknown_slot", "unknown preset slot", "в пресете нет такого слота", "пресетте мұндай слот жоқ"),
+	detailed(presetDom.ErrDuplicateChoice, unprocessable, "preset.duplicate_choice", "slot is chosen more than once", "слот выбран несколько раз", "слот бірнеше рет таңдалған"),
+	detailed(presetDom.ErrSlotNotOptional, unprocessable, "preset.slot_not_optional", "slot cannot be omitted", "слот нельзя убрать из комплекта", "слотты жиынтықтан алып тастауға болмайды"),
+	detailed(presetDom.ErrProductNotAllowed, unprocessable, "preset.product_not_allowed", "product is not allowed in this slot", "этот товар нельзя выбрать в слоте", "бұл тауарды слотта таңдауға болмайды"),
+
+	// ── coefficients ─────────────────────────────────────────────────────
+	e(coeffDom.ErrCoefficientNotFound, http.StatusNotFound, "coefficient.not_found", "coefficient not found", "коэффициент не найден", "коэффициент табылмады"),
+	e(coeffDom.ErrCoefficientAlreadyExists, http.StatusConflict, "coefficient.already_exists", "coefficient already exists", "коэффициент уже существует", "коэффициент бұрыннан бар"),
+	e(coeffDom.ErrEmptyName, unprocessable, "coefficient.name_empty", "name must not be empty", "название не может быть пустым", "атауы бос болмауы керек"),
+	e(coeffDom.ErrNameTooLong, unprocessable, "coefficient.name_too_long", "name is too long", "название слишком длинное", "атауы тым ұзын"),
+
+	// ── service ──────────────────────────────────────────────────────────
+	e(serviceDom.ErrServiceNotFound, http.StatusNotFound, "service.not_found", "service not found", "услуга не найдена", "қызмет табылмады"),
+	e(serviceDom.ErrServiceAlreadyExists, http.StatusConflict, "service.already_exists", "service already exists", "услуга уже существует", "қызмет бұрыннан бар"),
+	e(serviceDom.ErrEmptyName, unprocessable, "service.name_empty", "name must not be empty", "название не может быть пустым", "атауы бос болмауы керек"),
+	e(serviceDom.ErrNameTooLong, unprocessable, "service.name_too_long", "name is too long", "название слишком длинное", "атауы тым ұзын"),
+
+	// ── review ───────────────────────────────────────────────────────────
+	e(reviewDom.ErrReviewNotFound, http.StatusNotFound, "review.not_found", "review not found", "отзыв не найден", "пікір табылмады"),
+	e(reviewDom.ErrProductNotFound, http.StatusNotFound, "review.product_not_found", "product not found", "товар не найден", "тауар табылмады"),
+	e(reviewDom.ErrAlreadyModerated, http.StatusConflict, "review.already_moderated", "review has already been moderated", "отзыв уже прошёл модерацию", "пікір модерациядан өтіп қойған"),
+	e(reviewDom.ErrInvalidStatus, http.StatusBadRequest, "review.invalid_status", "invalid review status", "некорректный статус отзыва", "пікір мәртебесі қате"),
+	detailed(reviewDom.ErrInvalidRating, unprocessable, "review.invalid_rating", "invalid rating", "некорректная оценка", "баға қате"),
+	detailed(reviewDom.ErrEmptyAuthor, unprocessable, "review.author_empty", "review author must not be empty", "укажите автора отзыва", "пікір авторын көрсетіңіз"),
+	detailed(reviewDom.ErrAuthorTooLong, unprocessable, "review.author_too_long", "review author name is too long", "имя автора слишком длинное", "автор аты тым ұзын"),
+	detailed(reviewDom.ErrTextTooLong, unprocessable, "review.text_too_long", "review text is too long", "текст отзыва слишком длинный", "пікір мәтіні тым ұзын"),
+	detailed(reviewDom.ErrTooManyPhotos, unprocessable, "review.too_many_photos", "too many photos in review", "слишком много фотографий", "фотосуреттер тым көп"),
+	detailed(reviewDom.ErrReasonTooLong, unprocessable, "review.reason_too_long", "rejection reason is too long", "причина отклонения слишком длинная", "бас тарту себебі тым ұзын"),
+
+	// ── lead ─────────────────────────────────────────────────────────────
+	e(leadDom.ErrLeadNotFound, http.StatusNotFound, "lead.not_found", "lead not found", "заявка не найдена", "өтінім табылмады"),
+	e(leadDom.ErrInvalidTransition, http.StatusConflict, "lead.invalid_transition", "lead status transition is not allowed", "такая смена статуса заявки недопустима", "өтінім мәртебесін бұлай өзгертуге болмайды"),
+	e(leadDom.ErrInvalidStatus, http.StatusBadRequest, "lead.invalid_status", "invalid lead status", "некорректный статус заявки", "өтінім мәртебесі қате"),
+	e(leadDom.ErrBadReference, unprocessable, "lead.bad_reference", "referenced preset or product not found", "указанный пресет или товар не найден", "көрсетілген пресет немесе тауар табылмады"),
+	detailed(leadDom.ErrInvalidKind, unprocessable, "lead.invalid_kind", "invalid lead kind", "некорректный тип заявки", "өтінім түрі қате"),
+	detailed(leadDom.ErrEmptyName, unprocessable, "lead.name_empty", "lead name must not be empty", "укажите имя", "атыңызды көрсетіңіз"),
+	detailed(leadDom.ErrNameTooLong, unprocessable, "lead.name_too_long", "lead name is too long", "имя слишком длинное", "аты тым ұзын"),
+	detailed(leadDom.ErrInvalidPhone, unprocessable, "lead.invalid_phone", "invalid phone number", "некорректный номер телефона", "телефон нөмірі қате"),
+	detailed(leadDom.ErrInvalidEmail, unprocessable, "lead.invalid_email", "invalid email", "некорректный email", "email қате"),
+	detailed(leadDom.ErrMessageTooLong, unprocessable, "lead.message_too_long", "lead message is too long", "сообщение слишком длинное", "хабарлама тым ұзын"),
+
+	// ── translation ──────────────────────────────────────────────────────
+	e(trDom.ErrTranslationNotFound, http.StatusNotFound, "translation.not_found", "translation not found", "перевод не найден", "аударма табылмады"),
+	e(trDom.ErrEntityNotFound, http.StatusNotFound, "translation.entity_not_found", "translated entity not found", "переводимая сущность не найдена", "аударылатын нысан табылмады"),
+	e(trDom.ErrInvalidEntity, http.StatusBadRequest, "translation.invalid_entity", "entity must be one of product, category, attribute, preset, service", "сущность должна быть одной из: product, category, attribute, preset, service", "нысан мыналардың бірі болуы керек: product, category, attribute, preset, service"),
+	e(trDom.ErrInvalidLocale, http.StatusBadRequest, "translation.invalid_locale", "unsupported locale", "неподдерживаемая локаль", "тіл қолдау көрсетілмейді"),
+	e(trDom.ErrDefaultLocale, http.StatusBadRequest, "translation.default_locale", "default locale (ru) is edited via the entity itself", "язык по умолчанию (ru) правится в самой сущности", "әдепкі тіл (ru) нысанның өзінде өңделеді"),
+	detailed(trDom.ErrUnknownField, unprocessable, "translation.unknown_field", "field is not translatable", "поле не переводится", "бұл өріс аударылмайды"),
+	detailed(trDom.ErrEmptyFields, unprocessable, "translation.empty_fields", "no fields to translate", "нет полей для перевода", "аударатын өрістер жоқ"),
+	detailed(trDom.ErrEmptyValue, unprocessable, "translation.empty_value", "translation must not be empty", "перевод не может быть пустым", "аударма бос болмауы керек"),
+	detailed(trDom.ErrValueTooLong, unprocessable, "translation.value_too_long", "translation is too long", "перевод слишком длинный", "аударма тым ұзын"),
+
+	// ── currency ─────────────────────────────────────────────────────────
+	e(moneyDom.ErrInvalidCurrency, http.StatusBadRequest, "currency.invalid", "invalid currency code", "некорректный код валюты", "валюта коды қате"),
+	e(moneyDom.ErrUnsupportedCurrency, http.StatusBadRequest, "currency.unsupported", "currency is not supported", "валюта не поддерживается", "валюта қолдау көрсетілмейді"),
+	e(moneyDom.ErrBaseCurrency, http.StatusBadRequest, "currency.base", "operation is not allowed for the base currency", "операция недоступна для базовой валюты", "базалық валюта үшін бұл әрекет қолжетімсіз"),
+	e(moneyDom.ErrInvalidEntity, http.StatusBadRequest, "currency.invalid_entity", "entity must be one of product, service", "сущность должна быть одной из: product, service", "нысан мыналардың бірі болуы керек: product, service"),
+	e(moneyDom.ErrRateNotFound, http.StatusNotFound, "currency.rate_not_found", "exchange rate not found", "курс валюты не найден", "валюта бағамы табылмады"),
+	e(moneyDom.ErrOverrideNotFound, http.StatusNotFound, "currency.override_not_found", "price override not found", "цена в валюте не найдена", "валютадағы баға табылмады"),
+	e(moneyDom.ErrEntityNotFound, http.StatusNotFound, "currency.entity_not_found", "priced entity not found", "сущность с ценой не найдена", "бағасы бар нысан табылмады"),
+	detailed(moneyDom.ErrInvalidRate, unprocessable, "currency.invalid_rate", "exchange rate must be positive", "курс должен быть больше 0", "бағам 0-ден үлкен болуы керек"),
+	detailed(moneyDom.ErrInvalidPrice, unprocessable, "currency.invalid_price", "price must be positive with at most two decimals", "цена должна быть больше 0 и не точнее копеек", "баға 0-ден үлкен және екі ондық таңбадан аспауы керек"),
+	detailed(moneyDom.ErrCurrencyMismatch, unprocessable, "currency.mismatch", "currency mismatch", "валюты не совпадают", "валюталар сәйкес келмейді"),
+	detailed(moneyDom.ErrInvalidRounding, unprocessable, "currency.invalid_rounding", "invalid rounding rule", "некорректное правило округления", "дөңгелектеу ережесі қате"),
+
+	// ── discount ─────────────────────────────────────────────────────────
+	e(discountDom.ErrRuleNotFound, http.StatusNotFound, "discount.not_found", "discount rule not found", "правило скидки не найдено", "жеңілдік ережесі табылмады"),
+	e(discountDom.ErrTargetNotFound, http.StatusNotFound, "discount.target_not_found", "discount target not found", "товар, категория, пресет или услуга для скидки не найдены", "жеңілдікке арналған тауар, санат, пресет немесе қызмет табылмады"),
+	e(discountDom.ErrPromoCodeNotFound, http.StatusNotFound, "discount.promo_not_found", "promo code not found", "промокод не найден", "промокод табылмады"),
+	e(discountDom.ErrPromoCodeExists, http.StatusConflict, "discount.promo_exists", "promo code already exists", "такой промокод уже есть", "мұндай промокод бұрыннан бар"),
+	e(discountDom.ErrPromoCodeNotPromo, http.StatusConflict, "discount.promo_not_allowed", "promo codes can be attached to promo-only rules only", "промокоды выдаются только к правилам promo_only", "промокодтар тек promo_only ережелеріне беріледі"),
+	e(discountDom.ErrPromoCodeUnavailable, unprocessable, "discount.promo_unavailable", "promo code is expired or exhausted", "промокод истёк или уже использован", "промокодтың мерзімі өтті немесе ол пайдаланылып қойған"),
+	detailed(discountDom.ErrInvalidPromoCode, unprocessable, "discount.invalid_promo", "invalid promo code", "некорректный промокод", "промокод қате"),
+	detailed(discountDom.ErrInvalidUsageLimit, unprocessable, "discount.invalid_usage_limit", "promo code usage limit must be positive", "лимит использований должен быть больше 0", "пайдалану лимиті 0-ден үлкен болуы керек"),
+	detailed(discountDom.ErrEmptyName, unprocessable, "discount.name_empty", "discount name must not be empty", "укажите название скидки", "жеңілдік атауын көрсетіңіз"),
+	detailed(discountDom.ErrNameTooLong, unprocessable, "discount.name_too_long", "discount name is too long", "название скидки слишком длинное", "жеңілдік атауы тым ұзын"),
+	detailed(discountDom.ErrInvalidKind, unprocessable, "discount.invalid_kind", "invalid discount kind", "некорректный тип скидки", "жеңілдік түрі қате"),
+	detailed(discountDom.ErrInvalidValue, unprocessable, "discount.invalid_value", "invalid discount value", "некорректный размер скидки", "жеңілдік мөлшері қате"),
+	detailed(discountDom.ErrInvalidTarget, unprocessable, "discount.invalid_target", "invalid discount target", "некорректная цель скидки", "жеңілдік нысаны қате"),
+	detailed(discountDom.ErrInvalidPeriod, unprocessable, "discount.invalid_period", "discount must end after it starts", "скидка должна заканчиваться позже начала", "жеңілдік басталғаннан кейін аяқталуы керек"),
+
+	// ── webhook ──────────────────────────────────────────────────────────
+	e(webhookDom.ErrSubscriptionNotFound, http.StatusNotFound, "webhook.not_found", "webhook subscription not found", "подписка на вебхуки не найдена", "вебхуктарға жазылым табылмады"),
+	e(webhookDom.ErrDeliveryNotFound, http.StatusNotFound, "webhook.delivery_not_found", "webhook delivery not found", "доставка вебхука не найдена", "вебхук жеткізілімі табылмады"),
+	detailed(webhookDom.ErrInvalidURL, unprocessable, "webhook.invalid_url", "webhook url must be an absolute http(s) url", "адрес вебхука должен быть абсолютным http(s) URL", "вебхук мекенжайы абсолютті http(s) URL болуы керек"),
+	detailed(webhookDom.ErrSecretTooShort, unprocessable, "webhook.secret_too_short", "webhook secret must be at least 16 characters", "секрет вебхука должен быть не короче 16 символов", "вебхук құпиясы кемінде 16 таңба болуы керек"),
+	detailed(webhookDom.ErrNoEvents, unprocessable, "webhook.no_events", "webhook must subscribe to at least one event", "выберите хотя бы одно событие", "кемінде бір оқиғаны таңдаңыз"),
+	detailed(webhookDom.ErrUnknownEvent, unprocessable, "webhook.unknown_event", "unknown webhook event type", "неизвестный тип события", "оқиға түрі белгісіз"),
+
+	// ── external ids ─────────────────────────────────────────────────────
+	e(externalDom.ErrRefNotFound, http.StatusNotFound, "external.not_found", "external id not found", "внешний идентификатор не найден", "сыртқы идентификатор табылмады"),
+	e(externalDom.ErrEntityNotFound, http.StatusNotFound, "external.entity_not_found", "entity for external id not found", "сущность для внешнего идентификатора не найдена", "сыртқы идентификаторға сәйкес нысан табылмады"),
+	detailed(externalDom.ErrInvalidSource, http.StatusBadRequest, "external.invalid_source", "invalid external source", "некорректный источник", "дереккөз қате"),
+	detailed(externalDom.ErrInvalidExternalID, http.StatusBadRequest, "external.invalid_id", "invalid external id", "некорректный внешний идентификатор", "сыртқы идентификатор қате"),
+	detailed(externalDom.ErrInvalidEntity, http.StatusBadRequest, "external.invalid_entity", "entity must be one of category, product, service", "сущность должна быть одной из: category, product, service", "нысан мыналардың бірі болуы керек: category, product, service"),
+
+	// ── snapshot ─────────────────────────────────────────────────────────
+	e(snapshotDom.ErrUnsupportedFormat, http.StatusBadRequest, "snapshot.unsupported_format", "not a catalog snapshot", "файл не является снимком каталога", "файл каталог суреті емес"),
+	detailed(snapshotDom.ErrUnsupportedVersion, unprocessable, "snapshot.unsupported_version", "unsupported catalog snapshot version", "неподдерживаемая версия снимка каталога", "каталог суретінің нұсқасы қолдау көрсетілмейді"),
+	detailed(snapshotDom.ErrMalformed, http.StatusBadRequest, "snapshot.malformed", "malformed catalog snapshot", "снимок каталога повреждён", "каталог суреті бүлінген"),
+	detailed(snapshotDom.ErrTruncated, http.StatusBadRequest, "snapshot.truncated", "catalog snapshot is incomplete", "снимок каталога неполный", "каталог суреті толық емес"),
+	detailed(snapshotDom.ErrDanglingReference, unprocessable, "snapshot.dangling_reference", "snapshot record references a missing record", "запись снимка ссылается на отсутствующую запись", "суреттегі жазба жоқ жазбаға сілтеме жасайды"),
+	e(snapshotDom.ErrInvalidMode, http.StatusBadRequest, "snapshot.invalid_mode", "restore mode must be append or replace", "режим восстановления должен быть append или replace", "қалпына келтіру режимі append немесе replace болуы керек"),
+
+	// ── batch ────────────────────────────────────────────────────────────
+	e(batchDom.ErrEmpty, http.StatusBadRequest, "batch.empty", "no operations provided for batch", "не передано ни одной операции", "бірде-бір операция берілмеген"),
+	detailed(batchDom.ErrTooLarge, unprocessable, "batch.too_large", "too many operations in batch", "слишком много операций в пакете", "пакетте операциялар тым көп"),
+	e(batchDom.ErrInvalidOp, http.StatusBadRequest, "batch.invalid_op", "op must be create, update or delete", "операция должна быть create, update или delete", "операция create, update немесе delete болуы керек"),
+	e(batchDom.ErrMissingID, http.StatusBadRequest, "batch.missing_id", "id is required for update and delete", "для update и delete нужен id", "update және delete үшін id қажет"),
+	e(batchDom.ErrInvalidVersion, http.StatusBadRequest, "batch.invalid_version", "version is allowed only for update and delete", "version передаётся только для update и delete", "version тек update және delete үшін беріледі"),
+	e(batchDom.ErrVersionNotSupported, http.StatusBadRequest, "batch.version_not_supported", "these entities have no versions", "у этих сущностей нет версий", "бұл нысандардың нұсқалары жоқ"),
+
+	// ── idempotency ──────────────────────────────────────────────────────
+	detailed(idempotencyDom.ErrInvalidKey, http.StatusBadRequest, "idempotency.invalid_key", "invalid idempotency key", "некорректный ключ идемпотентности", "идемпотенттілік кілті қате"),
+	e(idempotencyDom.ErrKeyReused, unprocessable, "idempotency.key_reused", "idempotency key was already used for a different request", "ключ идемпотентности уже использован для другого запроса", "идемпотенттілік кілті басқа сұрау үшін пайдаланылған"),
+	e(idempotencyDom.ErrInProgress, http.StatusConflict, "idempotency.in_progress", "request with this idempotency key is still in progress", "запрос с этим ключом идемпотентности ещё выполняется", "осы идемпотенттілік кілтімен сұрау әлі орындалуда"),
+
+	// ── patch ────────────────────────────────────────────────────────────
+	detailed(jsonpatch.ErrInvalidPatch, http.StatusBadRequest, "patch.invalid", "invalid patch document", "некорректный документ изменений", "өзгерістер құжаты қате"),
+	detailed(jsonpatch.ErrPathNotFound, unprocessable, "patch.path_not_found", "patch path not found", "путь из документа изменений не найден", "өзгерістер құжатындағы жол табылмады"),
+	detailed(jsonpatch.ErrTestFailed, http.StatusConflict, "patch.test_failed", "patch test operation failed", "проверка test в документе изменений не прошла", "өзгерістер құжатындағы test тексерісі өтпеді"),
+}
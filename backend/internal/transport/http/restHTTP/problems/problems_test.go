@@ -0,0 +1,175 @@
+package problems
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	catDom "github.com/Neimess/zorkin-store-project/internal/domain/category"
+	moneyDom "github.com/Neimess/zorkin-store-project/internal/domain/money"
+	presetDom "github.com/Neimess/zorkin-store-project/internal/domain/preset"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		lang       string
+		wantStatus int
+		wantCode   string
+		wantTitle  string
+		wantDetail string
+	}{
+		{
+			name:       "domain error",
+			err:        catDom.ErrCategoryNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "category.not_found",
+			wantTitle:  "category not found",
+		},
+		{
+			name:       "wrapped domain error in russian",
+			err:        fmt.Errorf("op: %w", catDom.ErrCategoryInUse),
+			lang:       "ru-RU,ru;q=0.9,en;q=0.8",
+			wantStatus: http.StatusConflict,
+			wantCode:   "category.in_use",
+			wantTitle:  "категория используется и не может быть удалена",
+		},
+		{
+			name:       "domain error in kazakh",
+			err:        catDom.ErrCategoryNotFound,
+			lang:       "kk-KZ,kk;q=0.9",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "category.not_found",
+			wantTitle:  "санат табылмады",
+		},
+		{
+			name:       "detail is exposed for validation errors",
+			err:        fmt.Errorf("%w: width", presetDom.ErrInvalidRoomParams),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "preset.invalid_room",
+			wantTitle:  "invalid room parameters",
+			wantDetail: "invalid room parameters: width",
+		},
+		{
+			name:       "app_error fallback",
+			err:        fmt.Errorf("repo: %w", app_error.ErrConflict),
+			wantStatus: http.StatusConflict,
+			wantCode:   http_utils.CodeConflict,
+			wantTitle:  "conflict",
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("boom"),
+			lang:       "de",
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   http_utils.CodeInternal,
+			wantTitle:  "internal server error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/category/1", nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.RequestIDKey, "req-1"))
+			if tc.lang != "" {
+				req.Header.Set("Accept-Language", tc.lang)
+			}
+			w := httptest.NewRecorder()
+
+			Write(w, req, slog.New(slog.DiscardHandler), tc.err)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Equal(t, http_utils.ProblemContentType, w.Header().Get("Content-Type"))
+
+			var got http_utils.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, tc.wantStatus, got.Status)
+			assert.Equal(t, tc.wantCode, got.Code)
+			assert.Equal(t, "/api/errors/"+tc.wantCode, got.Type)
+			assert.Equal(t, tc.wantTitle, got.Title)
+			assert.Equal(t, tc.wantDetail, got.Detail)
+			assert.Equal(t, "/api/category/1", got.Instance)
+			assert.Equal(t, "req-1", got.RequestID)
+		})
+	}
+}
+
+func TestCatalogIsConsistent(t *testing.T) {
+	statusByCode := make(map[string]int)
+	for _, pt := range catalog {
+		require.NotNil(t, pt.Err, pt.Code)
+		if s, ok := statusByCode[pt.Code]; ok {
+			assert.Equal(t, s, pt.Status, "code %s registered with different statuses", pt.Code)
+		}
+		statusByCode[pt.Code] = pt.Status
+	}
+
+	// у каждого зарегистрированного кода, включая общие по статусу, есть все языки
+	for _, pt := range http_utils.Problems.Catalog() {
+		for _, lang := range []string{http_utils.LangEN, http_utils.LangRU, http_utils.LangKZ} {
+			assert.NotEmpty(t, pt.Titles[lang], "%s: no %s title", pt.Code, lang)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	r := chiRouter()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/errors/preset.not_found", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var got ProblemTypeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, http.StatusNotFound, got.Status)
+	assert.Equal(t, "/api/errors/preset.not_found", got.Type)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/errors/nope", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/errors", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list []ProblemTypeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	codes := make(map[string]bool, len(list))
+	for _, it := range list {
+		codes[it.Code] = true
+	}
+	assert.True(t, codes["lead.invalid_transition"])
+	assert.True(t, codes[http_utils.CodeValidationFailed])
+}
+
+func chiRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/api/errors", Catalog)
+	r.Get("/api/errors/{code}", Describe)
+	return r
+}
+
+// Доменные ошибки, которые сервисы отдают наружу как есть, не должны
+// превращаться в 500.
+func TestDomainErrorsAreClientErrors(t *testing.T) {
+	for _, err := range []error{
+		presetDom.ErrDescriptionTooLong, presetDom.ErrItemNotFound, presetDom.ErrNilProductSummary,
+		attrDom.ErrAttributeValidation, attrDom.ErrInvalidCategoryID, attrDom.ErrAttributeConflict,
+		catDom.ErrAttributeNameEmpty, moneyDom.ErrInvalidRounding,
+	} {
+		w := httptest.NewRecorder()
+		Write(w, httptest.NewRequest(http.MethodGet, "/", nil), slog.New(slog.DiscardHandler), err)
+		assert.True(t, w.Code >= 400 && w.Code < 500, "%v: %d", err, w.Code)
+	}
+}
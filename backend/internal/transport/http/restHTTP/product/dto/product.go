@@ -2,14 +2,19 @@ package dto
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 
 	attr "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
 	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
 	serviceDom "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	discountDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
 )
 
 var validate = validator.New()
@@ -43,15 +48,30 @@ type ProductServiceRequest struct {
 // ProductResponse описывает ответ API при получении продукта.
 // swagger:model ProductResponse
 type ProductResponse struct {
-	ProductID   int64                           `json:"product_id" example:"10"`
-	Name        string                          `json:"name" example:"Керамогранит"`
-	Price       float64                         `json:"price" example:"3490"`
-	Description *string                         `json:"description,omitempty"`
-	CategoryID  int64                           `json:"category_id" example:"1"`
-	ImageURL    *string                         `json:"image_url,omitempty"`
-	CreatedAt   time.Time                       `json:"created_at" example:"2025-06-20T15:00:00Z"`
-	Attributes  []ProductAttributeValueResponse `json:"attributes,omitempty"`
-	Services    []ProductServiceResponse        `json:"services,omitempty"`
+	ProductID int64   `json:"product_id" example:"10"`
+	Name      string  `json:"name" example:"Керамогранит"`
+	Price     float64 `json:"price" example:"3490"`
+	Currency  string  `json:"currency" example:"RUB"`
+	// OldPrice и Discount заполнены, если на товар действует скидка.
+	OldPrice    *float64                             `json:"old_price,omitempty" example:"3990"`
+	Discount    *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
+	Description *string                              `json:"description,omitempty"`
+	CategoryID  int64                                `json:"category_id" example:"1"`
+	ImageURL    *string                              `json:"image_url,omitempty"`
+	// Stock — остаток, выгружаемый из 1С; отсутствует, если учёт не ведётся.
+	Stock      *float64                        `json:"stock,omitempty" example:"42.5"`
+	CreatedAt  time.Time                       `json:"created_at" example:"2025-06-20T15:00:00Z"`
+	Attributes []ProductAttributeValueResponse `json:"attributes,omitempty"`
+	Services   []ProductServiceResponse        `json:"services,omitempty"`
+	Relations  []ProductRelationResponse       `json:"relations,omitempty"`
+	Rating     RatingSummaryResponse           `json:"rating"`
+}
+
+// RatingSummaryResponse — средняя оценка и число одобренных отзывов.
+// swagger:model RatingSummaryResponse
+type RatingSummaryResponse struct {
+	Average float64 `json:"average" example:"4.5"`
+	Count   int64   `json:"count" example:"12"`
 }
 
 // ProductAttributeValueResponse отвечает за элемент атрибута в ответе.
@@ -66,10 +86,13 @@ type ProductAttributeValueResponse struct {
 // ProductServiceResponse отвечает за элемент услуги в ответе.
 // swagger:model ProductServiceResponse
 type ProductServiceResponse struct {
-	ID          int64   `json:"id" example:"1"`
-	Name        string  `json:"name" example:"Монтаж"`
-	Description *string `json:"description,omitempty" example:"Установка изделия"`
-	Price       float64 `json:"price" example:"1500.00"`
+	ID          int64                                `json:"id" example:"1"`
+	Name        string                               `json:"name" example:"Монтаж"`
+	Description *string                              `json:"description,omitempty" example:"Установка изделия"`
+	Price       float64                              `json:"price" example:"1500.00"`
+	Currency    string                               `json:"currency" example:"RUB"`
+	OldPrice    *float64                             `json:"old_price,omitempty" example:"2000.00"`
+	Discount    *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
 }
 
 // Validate проверяет поля ProductRequest.
@@ -80,26 +103,26 @@ func (r ProductRequest) Validate() error {
 			return inv
 		}
 		for _, e := range err.(validator.ValidationErrors) {
-			var msg string
+			var msg i18n.Message
 			switch e.Field() {
 			case "Name":
-				msg = "name is required and must be 2-255 chars"
+				msg = i18n.MsgName2To255
 			case "Price":
-				msg = "price is required and must be >0"
+				msg = i18n.MsgPricePositive
 			case "CategoryID":
-				msg = "category_id is required and must be >0"
+				msg = i18n.MsgCategoryIDPositive
 			case "Description":
-				msg = "description must be 2-1000 chars if set"
+				msg = i18n.MsgDescription2To1000
 			case "ImageURL":
-				msg = "image_url must be valid URL if set"
+				msg = i18n.MsgImageURL
 			case "Attributes":
-				msg = "invalid existing attributes"
+				msg = i18n.MsgInvalidAttributes
 			case "Services":
-				msg = "invalid services"
+				msg = i18n.MsgInvalidServices
 			default:
-				msg = "invalid field"
+				msg = i18n.MsgInvalidField
 			}
-			errs = append(errs, ve.FieldError{Field: e.Field(), Message: msg})
+			errs = append(errs, ve.NewFieldError(e.Field(), msg))
 		}
 	}
 	// вложенная валидация
@@ -113,7 +136,7 @@ func (r ProductRequest) Validate() error {
 	}
 	for i, s := range r.Services {
 		if err := s.Validate(); err != nil {
-			errs = append(errs, ve.FieldError{Field: fmt.Sprintf("services[%d]", i), Message: err.Error()})
+			errs = append(errs, ve.NewFieldError(fmt.Sprintf("services[%d]", i), i18n.Raw(err.Error())))
 		}
 	}
 	if len(errs) > 0 {
@@ -127,14 +150,14 @@ func (r ProductAttributeRequest) Validate() error {
 	var errs []ve.FieldError
 	if err := validate.Struct(r); err != nil {
 		for _, e := range err.(validator.ValidationErrors) {
-			var msg string
+			msg := i18n.MsgInvalidField
 			switch e.Field() {
 			case "Name":
-				msg = "name required 2-255 chars"
+				msg = i18n.MsgName2To255
 			case "Value":
-				msg = "value is required"
+				msg = i18n.MsgValueRequired
 			}
-			errs = append(errs, ve.FieldError{Field: e.Field(), Message: msg})
+			errs = append(errs, ve.NewFieldError(e.Field(), msg))
 		}
 	}
 	if len(errs) > 0 {
@@ -149,7 +172,7 @@ func (r ProductServiceRequest) Validate() error {
 	if err := validate.Struct(r); err != nil {
 		for _, e := range err.(validator.ValidationErrors) {
 			if e.Field() == "ServiceID" {
-				errs = append(errs, ve.FieldError{Field: "service_id", Message: "service_id must be >0"})
+				errs = append(errs, ve.NewFieldError("service_id", i18n.MsgServiceIDPositive))
 			}
 		}
 	}
@@ -163,7 +186,7 @@ func (r ProductServiceRequest) Validate() error {
 func (r ProductRequest) MapCreateToDomain() *prodDom.Product {
 	p := &prodDom.Product{
 		Name:        r.Name,
-		Price:       r.Price,
+		Price:       money.FromFloat(r.Price, money.Base),
 		CategoryID:  r.CategoryID,
 		Description: r.Description,
 		ImageURL:    r.ImageURL,
@@ -196,17 +219,77 @@ func MapDomainToProductResponse(p *prodDom.Product) *ProductResponse {
 	resp := &ProductResponse{
 		ProductID:   p.ID,
 		Name:        p.Name,
-		Price:       p.Price,
+		Price:       p.Price.Float64(),
+		Currency:    string(p.Price.Currency),
 		CategoryID:  p.CategoryID,
 		Description: p.Description,
 		ImageURL:    p.ImageURL,
 		CreatedAt:   p.CreatedAt,
+		Rating:      RatingSummaryResponse{Average: p.Rating.Average, Count: p.Rating.Count},
+	}
+	if p.Stock != nil {
+		stock := p.Stock.InexactFloat64()
+		resp.Stock = &stock
 	}
 	for _, pa := range p.Attributes {
 		resp.Attributes = append(resp.Attributes, ProductAttributeValueResponse{AttributeID: pa.AttributeID, Name: pa.Attribute.Name, Unit: pa.Attribute.Unit, Value: pa.Value})
 	}
+	resp.OldPrice, resp.Discount = discountDto.MapSale(p.Sale)
 	for _, s := range p.Services {
-		resp.Services = append(resp.Services, ProductServiceResponse{ID: s.ID, Name: s.Name, Description: s.Description, Price: s.Price})
+		sr := ProductServiceResponse{ID: s.ID, Name: s.Name, Description: s.Description, Price: s.Price.Float64(), Currency: string(s.Price.Currency)}
+		sr.OldPrice, sr.Discount = discountDto.MapSale(s.Sale)
+		resp.Services = append(resp.Services, sr)
+	}
+	if len(p.Relations) > 0 {
+		resp.Relations = MapRelationsToResponse(p.Relations)
 	}
 	return resp
 }
+
+// MapDomainToRequest строит тело PUT из текущего товара — документ, к которому применяется PATCH.
+func MapDomainToRequest(p *prodDom.Product) ProductRequest {
+	r := ProductRequest{
+		Name:        p.Name,
+		Price:       p.Price.Float64(),
+		Description: p.Description,
+		CategoryID:  p.CategoryID,
+		ImageURL:    p.ImageURL,
+	}
+	for _, pa := range p.Attributes {
+		r.Attributes = append(r.Attributes, ProductAttributeRequest{Name: pa.Attribute.Name, Unit: pa.Attribute.Unit, Value: pa.Value})
+	}
+	for _, s := range p.Services {
+		r.Services = append(r.Services, ProductServiceRequest{ServiceID: s.ID})
+	}
+	return r
+}
+
+// MapPatchToDomain сравнивает документ до и после PATCH и оставляет в патче
+// только изменившиеся поля. Атрибуты привязаны к категории, поэтому при смене
+// категории они перезаписываются, даже если сами не менялись.
+func MapPatchToDomain(id, version int64, before, after *ProductRequest) *prodDom.Patch {
+	p := &prodDom.Patch{
+		ID:          id,
+		Version:     version,
+		Name:        patch.Diff(before.Name, after.Name),
+		Description: patch.DiffPtr(before.Description, after.Description),
+		CategoryID:  patch.Diff(before.CategoryID, after.CategoryID),
+		ImageURL:    patch.DiffPtr(before.ImageURL, after.ImageURL),
+	}
+	if before.Price != after.Price {
+		p.Price = patch.To(money.FromFloat(after.Price, money.Base))
+	}
+	full := after.MapCreateToDomain()
+	if p.CategoryID.Set || !sameSlice(before.Attributes, after.Attributes) {
+		p.Attributes = patch.To(full.Attributes)
+	}
+	if !sameSlice(before.Services, after.Services) {
+		p.Services = patch.To(full.Services)
+	}
+	return p
+}
+
+// sameSlice считает nil и пустой список одинаковыми.
+func sameSlice[T any](a, b []T) bool {
+	return (len(a) == 0 && len(b) == 0) || reflect.DeepEqual(a, b)
+}
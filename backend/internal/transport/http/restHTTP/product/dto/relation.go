@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	discountDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
+)
+
+// ProductRelationRequest описывает связь товара с другим товаром.
+// swagger:model ProductRelationRequest
+type ProductRelationRequest struct {
+	RelatedProductID int64  `json:"related_product_id" example:"42" validate:"required,gt=0"`
+	Type             string `json:"type" example:"accessory" validate:"required,oneof=accessory alternative compatible_with required" enums:"accessory,alternative,compatible_with,required"`
+}
+
+// ProductSummaryResponse — краткая карточка товара.
+// swagger:model ProductSummaryResponse
+type ProductSummaryResponse struct {
+	ProductID int64                                `json:"product_id" example:"42"`
+	Name      string                               `json:"name" example:"Затирка эпоксидная"`
+	Price     float64                              `json:"price" example:"890"`
+	Currency  string                               `json:"currency" example:"RUB"`
+	OldPrice  *float64                             `json:"old_price,omitempty" example:"990"`
+	Discount  *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
+	ImageURL  *string                              `json:"image_url,omitempty" example:"https://example.com/grout.png"`
+}
+
+// ProductRelationResponse описывает связь в ответе.
+// swagger:model ProductRelationResponse
+type ProductRelationResponse struct {
+	RelationID int64                  `json:"relation_id" example:"3"`
+	Type       string                 `json:"type" example:"accessory"`
+	Product    ProductSummaryResponse `json:"product"`
+	CreatedAt  time.Time              `json:"created_at" example:"2025-06-20T15:00:00Z"`
+}
+
+// Validate проверяет ProductRelationRequest.
+func (r ProductRelationRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if inv, ok := err.(*validator.InvalidValidationError); ok {
+			return inv
+		}
+		for _, e := range err.(validator.ValidationErrors) {
+			switch e.Field() {
+			case "RelatedProductID":
+				errs = append(errs, ve.NewFieldError("related_product_id", i18n.MsgRelatedProductID))
+			case "Type":
+				errs = append(errs, ve.NewFieldError("type", i18n.MsgRelationType))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
+
+// MapToDomain конвертирует запрос в доменную связь товара productID.
+func (r *ProductRelationRequest) MapToDomain(productID, relationID int64) *prodDom.ProductRelation {
+	return &prodDom.ProductRelation{
+		ID:        relationID,
+		ProductID: productID,
+		RelatedID: r.RelatedProductID,
+		Type:      prodDom.RelationType(r.Type),
+	}
+}
+
+func MapSummaryToResponse(ps prodDom.ProductSummary) ProductSummaryResponse {
+	resp := ProductSummaryResponse{
+		ProductID: ps.ID,
+		Name:      ps.Name,
+		Price:     ps.Price.Float64(),
+		Currency:  string(ps.Price.Currency),
+		ImageURL:  ps.ImageURL,
+	}
+	resp.OldPrice, resp.Discount = discountDto.MapSale(ps.Sale)
+	return resp
+}
+
+func MapRelationToResponse(rel *prodDom.ProductRelation) ProductRelationResponse {
+	resp := ProductRelationResponse{
+		RelationID: rel.ID,
+		Type:       string(rel.Type),
+		Product:    ProductSummaryResponse{ProductID: rel.RelatedID},
+		CreatedAt:  rel.CreatedAt,
+	}
+	if rel.Related != nil {
+		resp.Product = MapSummaryToResponse(*rel.Related)
+	}
+	return resp
+}
+
+func MapRelationsToResponse(rels []prodDom.ProductRelation) []ProductRelationResponse {
+	out := make([]ProductRelationResponse, len(rels))
+	for i := range rels {
+		out[i] = MapRelationToResponse(&rels[i])
+	}
+	return out
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	"github.com/Neimess/zorkin-store-project/internal/domain/product"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,6 +39,80 @@ func (_m *MockProductService) EXPECT() *MockProductService_Expecter {
 	return &MockProductService_Expecter{mock: &_m.Mock}
 }
 
+// Batch provides a mock function for the type MockProductService
+func (_mock *MockProductService) Batch(ctx context.Context, items []batch.Item[*product.Product], atomic bool) ([]batch.Result[*product.Product], error) {
+	ret := _mock.Called(ctx, items, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 []batch.Result[*product.Product]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []batch.Item[*product.Product], bool) ([]batch.Result[*product.Product], error)); ok {
+		return returnFunc(ctx, items, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []batch.Item[*product.Product], bool) []batch.Result[*product.Product]); ok {
+		r0 = returnFunc(ctx, items, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]batch.Result[*product.Product])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []batch.Item[*product.Product], bool) error); ok {
+		r1 = returnFunc(ctx, items, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductService_Batch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Batch'
+type MockProductService_Batch_Call struct {
+	*mock.Call
+}
+
+// Batch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []batch.Item[*product.Product]
+//   - atomic bool
+func (_e *MockProductService_Expecter) Batch(ctx interface{}, items interface{}, atomic interface{}) *MockProductService_Batch_Call {
+	return &MockProductService_Batch_Call{Call: _e.mock.On("Batch", ctx, items, atomic)}
+}
+
+func (_c *MockProductService_Batch_Call) Run(run func(ctx context.Context, items []batch.Item[*product.Product], atomic bool)) *MockProductService_Batch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []batch.Item[*product.Product]
+		if args[1] != nil {
+			arg1 = args[1].([]batch.Item[*product.Product])
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_Batch_Call) Return(results []batch.Result[*product.Product], err error) *MockProductService_Batch_Call {
+	_c.Call.Return(results, err)
+	return _c
+}
+
+func (_c *MockProductService_Batch_Call) RunAndReturn(run func(ctx context.Context, items []batch.Item[*product.Product], atomic bool) ([]batch.Result[*product.Product], error)) *MockProductService_Batch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockProductService
 func (_mock *MockProductService) Create(ctx context.Context, product1 *product.Product) (*product.Product, error) {
 	ret := _mock.Called(ctx, product1)
@@ -106,6 +181,74 @@ func (_c *MockProductService_Create_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// CreateRelation provides a mock function for the type MockProductService
+func (_mock *MockProductService) CreateRelation(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error) {
+	ret := _mock.Called(ctx, rel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRelation")
+	}
+
+	var r0 *product.ProductRelation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) (*product.ProductRelation, error)); ok {
+		return returnFunc(ctx, rel)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) *product.ProductRelation); ok {
+		r0 = returnFunc(ctx, rel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.ProductRelation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *product.ProductRelation) error); ok {
+		r1 = returnFunc(ctx, rel)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductService_CreateRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRelation'
+type MockProductService_CreateRelation_Call struct {
+	*mock.Call
+}
+
+// CreateRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rel *product.ProductRelation
+func (_e *MockProductService_Expecter) CreateRelation(ctx interface{}, rel interface{}) *MockProductService_CreateRelation_Call {
+	return &MockProductService_CreateRelation_Call{Call: _e.mock.On("CreateRelation", ctx, rel)}
+}
+
+func (_c *MockProductService_CreateRelation_Call) Run(run func(ctx context.Context, rel *product.ProductRelation)) *MockProductService_CreateRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *product.ProductRelation
+		if args[1] != nil {
+			arg1 = args[1].(*product.ProductRelation)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_CreateRelation_Call) Return(productRelation *product.ProductRelation, err error) *MockProductService_CreateRelation_Call {
+	_c.Call.Return(productRelation, err)
+	return _c
+}
+
+func (_c *MockProductService_CreateRelation_Call) RunAndReturn(run func(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error)) *MockProductService_CreateRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateWithAttrs provides a mock function for the type MockProductService
 func (_mock *MockProductService) CreateWithAttrs(ctx context.Context, product1 *product.Product) (*product.Product, error) {
 	ret := _mock.Called(ctx, product1)
@@ -231,9 +374,146 @@ func (_c *MockProductService_Delete_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// DeleteRelation provides a mock function for the type MockProductService
+func (_mock *MockProductService) DeleteRelation(ctx context.Context, productID int64, relationID int64) error {
+	ret := _mock.Called(ctx, productID, relationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = returnFunc(ctx, productID, relationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductService_DeleteRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelation'
+type MockProductService_DeleteRelation_Call struct {
+	*mock.Call
+}
+
+// DeleteRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+//   - relationID int64
+func (_e *MockProductService_Expecter) DeleteRelation(ctx interface{}, productID interface{}, relationID interface{}) *MockProductService_DeleteRelation_Call {
+	return &MockProductService_DeleteRelation_Call{Call: _e.mock.On("DeleteRelation", ctx, productID, relationID)}
+}
+
+func (_c *MockProductService_DeleteRelation_Call) Run(run func(ctx context.Context, productID int64, relationID int64)) *MockProductService_DeleteRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_DeleteRelation_Call) Return(err error) *MockProductService_DeleteRelation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductService_DeleteRelation_Call) RunAndReturn(run func(ctx context.Context, productID int64, relationID int64) error) *MockProductService_DeleteRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FrequentlyBoughtTogether provides a mock function for the type MockProductService
+func (_mock *MockProductService) FrequentlyBoughtTogether(ctx context.Context, productID int64, limit int) ([]product.ProductSummary, error) {
+	ret := _mock.Called(ctx, productID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FrequentlyBoughtTogether")
+	}
+
+	var r0 []product.ProductSummary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]product.ProductSummary, error)); ok {
+		return returnFunc(ctx, productID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []product.ProductSummary); ok {
+		r0 = returnFunc(ctx, productID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.ProductSummary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, productID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductService_FrequentlyBoughtTogether_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FrequentlyBoughtTogether'
+type MockProductService_FrequentlyBoughtTogether_Call struct {
+	*mock.Call
+}
+
+// FrequentlyBoughtTogether is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+//   - limit int
+func (_e *MockProductService_Expecter) FrequentlyBoughtTogether(ctx interface{}, productID interface{}, limit interface{}) *MockProductService_FrequentlyBoughtTogether_Call {
+	return &MockProductService_FrequentlyBoughtTogether_Call{Call: _e.mock.On("FrequentlyBoughtTogether", ctx, productID, limit)}
+}
+
+func (_c *MockProductService_FrequentlyBoughtTogether_Call) Run(run func(ctx context.Context, productID int64, limit int)) *MockProductService_FrequentlyBoughtTogether_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_FrequentlyBoughtTogether_Call) Return(productSummarys []product.ProductSummary, err error) *MockProductService_FrequentlyBoughtTogether_Call {
+	_c.Call.Return(productSummarys, err)
+	return _c
+}
+
+func (_c *MockProductService_FrequentlyBoughtTogether_Call) RunAndReturn(run func(ctx context.Context, productID int64, limit int) ([]product.ProductSummary, error)) *MockProductService_FrequentlyBoughtTogether_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByCategoryID provides a mock function for the type MockProductService
-func (_mock *MockProductService) GetByCategoryID(ctx context.Context, categoryID int64) ([]product.Product, error) {
-	ret := _mock.Called(ctx, categoryID)
+func (_mock *MockProductService) GetByCategoryID(ctx context.Context, categoryID int64, sort product.SortOrder) ([]product.Product, error) {
+	ret := _mock.Called(ctx, categoryID, sort)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByCategoryID")
@@ -241,18 +521,18 @@ func (_mock *MockProductService) GetByCategoryID(ctx context.Context, categoryID
 
 	var r0 []product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]product.Product, error)); ok {
-		return returnFunc(ctx, categoryID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, product.SortOrder) ([]product.Product, error)); ok {
+		return returnFunc(ctx, categoryID, sort)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []product.Product); ok {
-		r0 = returnFunc(ctx, categoryID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, product.SortOrder) []product.Product); ok {
+		r0 = returnFunc(ctx, categoryID, sort)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, categoryID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, product.SortOrder) error); ok {
+		r1 = returnFunc(ctx, categoryID, sort)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -267,11 +547,12 @@ type MockProductService_GetByCategoryID_Call struct {
 // GetByCategoryID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - categoryID int64
-func (_e *MockProductService_Expecter) GetByCategoryID(ctx interface{}, categoryID interface{}) *MockProductService_GetByCategoryID_Call {
-	return &MockProductService_GetByCategoryID_Call{Call: _e.mock.On("GetByCategoryID", ctx, categoryID)}
+//   - sort product.SortOrder
+func (_e *MockProductService_Expecter) GetByCategoryID(ctx interface{}, categoryID interface{}, sort interface{}) *MockProductService_GetByCategoryID_Call {
+	return &MockProductService_GetByCategoryID_Call{Call: _e.mock.On("GetByCategoryID", ctx, categoryID, sort)}
 }
 
-func (_c *MockProductService_GetByCategoryID_Call) Run(run func(ctx context.Context, categoryID int64)) *MockProductService_GetByCategoryID_Call {
+func (_c *MockProductService_GetByCategoryID_Call) Run(run func(ctx context.Context, categoryID int64, sort product.SortOrder)) *MockProductService_GetByCategoryID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -281,9 +562,14 @@ func (_c *MockProductService_GetByCategoryID_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 product.SortOrder
+		if args[2] != nil {
+			arg2 = args[2].(product.SortOrder)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -294,7 +580,7 @@ func (_c *MockProductService_GetByCategoryID_Call) Return(products []product.Pro
 	return _c
 }
 
-func (_c *MockProductService_GetByCategoryID_Call) RunAndReturn(run func(ctx context.Context, categoryID int64) ([]product.Product, error)) *MockProductService_GetByCategoryID_Call {
+func (_c *MockProductService_GetByCategoryID_Call) RunAndReturn(run func(ctx context.Context, categoryID int64, sort product.SortOrder) ([]product.Product, error)) *MockProductService_GetByCategoryID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -367,6 +653,142 @@ func (_c *MockProductService_GetDetailed_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// ListRelations provides a mock function for the type MockProductService
+func (_mock *MockProductService) ListRelations(ctx context.Context, productID int64) ([]product.ProductRelation, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRelations")
+	}
+
+	var r0 []product.ProductRelation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]product.ProductRelation, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []product.ProductRelation); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.ProductRelation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductService_ListRelations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRelations'
+type MockProductService_ListRelations_Call struct {
+	*mock.Call
+}
+
+// ListRelations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+func (_e *MockProductService_Expecter) ListRelations(ctx interface{}, productID interface{}) *MockProductService_ListRelations_Call {
+	return &MockProductService_ListRelations_Call{Call: _e.mock.On("ListRelations", ctx, productID)}
+}
+
+func (_c *MockProductService_ListRelations_Call) Run(run func(ctx context.Context, productID int64)) *MockProductService_ListRelations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_ListRelations_Call) Return(productRelations []product.ProductRelation, err error) *MockProductService_ListRelations_Call {
+	_c.Call.Return(productRelations, err)
+	return _c
+}
+
+func (_c *MockProductService_ListRelations_Call) RunAndReturn(run func(ctx context.Context, productID int64) ([]product.ProductRelation, error)) *MockProductService_ListRelations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function for the type MockProductService
+func (_mock *MockProductService) Patch(ctx context.Context, p *product.Patch) (*product.Product, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.Patch) (*product.Product, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.Patch) *product.Product); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *product.Patch) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductService_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockProductService_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *product.Patch
+func (_e *MockProductService_Expecter) Patch(ctx interface{}, p interface{}) *MockProductService_Patch_Call {
+	return &MockProductService_Patch_Call{Call: _e.mock.On("Patch", ctx, p)}
+}
+
+func (_c *MockProductService_Patch_Call) Run(run func(ctx context.Context, p *product.Patch)) *MockProductService_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *product.Patch
+		if args[1] != nil {
+			arg1 = args[1].(*product.Patch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_Patch_Call) Return(product1 *product.Product, err error) *MockProductService_Patch_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *MockProductService_Patch_Call) RunAndReturn(run func(ctx context.Context, p *product.Patch) (*product.Product, error)) *MockProductService_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockProductService
 func (_mock *MockProductService) Update(ctx context.Context, product1 *product.Product) (*product.Product, error) {
 	ret := _mock.Called(ctx, product1)
@@ -434,3 +856,71 @@ func (_c *MockProductService_Update_Call) RunAndReturn(run func(ctx context.Cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateRelation provides a mock function for the type MockProductService
+func (_mock *MockProductService) UpdateRelation(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error) {
+	ret := _mock.Called(ctx, rel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRelation")
+	}
+
+	var r0 *product.ProductRelation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) (*product.ProductRelation, error)); ok {
+		return returnFunc(ctx, rel)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *product.ProductRelation) *product.ProductRelation); ok {
+		r0 = returnFunc(ctx, rel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.ProductRelation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *product.ProductRelation) error); ok {
+		r1 = returnFunc(ctx, rel)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductService_UpdateRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRelation'
+type MockProductService_UpdateRelation_Call struct {
+	*mock.Call
+}
+
+// UpdateRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rel *product.ProductRelation
+func (_e *MockProductService_Expecter) UpdateRelation(ctx interface{}, rel interface{}) *MockProductService_UpdateRelation_Call {
+	return &MockProductService_UpdateRelation_Call{Call: _e.mock.On("UpdateRelation", ctx, rel)}
+}
+
+func (_c *MockProductService_UpdateRelation_Call) Run(run func(ctx context.Context, rel *product.ProductRelation)) *MockProductService_UpdateRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *product.ProductRelation
+		if args[1] != nil {
+			arg1 = args[1].(*product.ProductRelation)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductService_UpdateRelation_Call) Return(productRelation *product.ProductRelation, err error) *MockProductService_UpdateRelation_Call {
+	_c.Call.Return(productRelation, err)
+	return _c
+}
+
+func (_c *MockProductService_UpdateRelation_Call) RunAndReturn(run func(ctx context.Context, rel *product.ProductRelation) (*product.ProductRelation, error)) *MockProductService_UpdateRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,7 +7,11 @@ import (
 	"log/slog"
 	"net/http"
 
+	domBatch "github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/batch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/patch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/dto"
 	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
@@ -16,9 +20,16 @@ type ProductService interface {
 	Create(ctx context.Context, product *prodDom.Product) (*prodDom.Product, error)
 	CreateWithAttrs(ctx context.Context, product *prodDom.Product) (*prodDom.Product, error)
 	GetDetailed(ctx context.Context, id int64) (*prodDom.Product, error)
-	GetByCategoryID(ctx context.Context, categoryID int64) ([]prodDom.Product, error)
+	GetByCategoryID(ctx context.Context, categoryID int64, sort prodDom.SortOrder) ([]prodDom.Product, error)
 	Update(ctx context.Context, product *prodDom.Product) (*prodDom.Product, error)
+	Patch(ctx context.Context, p *prodDom.Patch) (*prodDom.Product, error)
 	Delete(ctx context.Context, id int64) error
+	Batch(ctx context.Context, items []domBatch.Item[*prodDom.Product], atomic bool) ([]domBatch.Result[*prodDom.Product], error)
+	ListRelations(ctx context.Context, productID int64) ([]prodDom.ProductRelation, error)
+	CreateRelation(ctx context.Context, rel *prodDom.ProductRelation) (*prodDom.ProductRelation, error)
+	UpdateRelation(ctx context.Context, rel *prodDom.ProductRelation) (*prodDom.ProductRelation, error)
+	DeleteRelation(ctx context.Context, productID, relationID int64) error
+	FrequentlyBoughtTogether(ctx context.Context, productID int64, limit int) ([]prodDom.ProductSummary, error)
 }
 
 type Deps struct {
@@ -82,7 +93,7 @@ func (h Handler) Create(w http.ResponseWriter, r *http.Request) {
 
 	product, err := h.srv.Create(ctx, domainProduct)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapDomainToProductResponse(product)
@@ -117,11 +128,12 @@ func (h *Handler) GetDetailed(w http.ResponseWriter, r *http.Request) {
 
 	product, err := h.srv.GetDetailed(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
 	resp := dto.MapDomainToProductResponse(product)
+	http_utils.SetETag(w, product.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
@@ -130,7 +142,8 @@ func (h *Handler) GetDetailed(w http.ResponseWriter, r *http.Request) {
 // @Description  Returns all products that belong to the specified category
 // @Tags         products
 // @Produce      json
-// @Param        id   path      int  true  "Category ID"
+// @Param        id    path      int     true   "Category ID"
+// @Param        sort  query     string  false  "Sort order"  Enums(rating, price, -price, newest)
 // @Success      200  {array}   dto.ProductResponse  "List of products"
 // @Failure      400  {object}  http_utils.ErrorResponse    "Invalid ID"
 // @Failure      401  {object}  http_utils.ErrorResponse    "Unauthorized access"
@@ -152,9 +165,15 @@ func (h *Handler) ListByCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	products, err := h.srv.GetByCategoryID(ctx, categoryID)
+	sort := prodDom.SortOrder(r.URL.Query().Get("sort"))
+	if !sort.Valid() {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid sort order")
+		return
+	}
+
+	products, err := h.srv.GetByCategoryID(ctx, categoryID, sort)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -174,11 +193,14 @@ func (h *Handler) ListByCategory(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id       path      int                         true  "Product ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
 // @Param        product  body      dto.ProductRequest    true  "Product data to update (may contain services)"
 // @Success      200      {object}  dto.ProductResponse
 // @Failure      400      {object}  http_utils.ErrorResponse   "Bad request"
 // @Failure      404      {object}  http_utils.ErrorResponse   "Not found"
 // @Failure      422      {object}  http_utils.ErrorResponse   "Validation error"
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
 // @Failure      500      {object}  http_utils.ErrorResponse   "Internal server error"
 // @Router       /api/admin/product/{id} [put]
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
@@ -201,23 +223,82 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	p := req.MapUpdateToDomain(id)
 	prodRes, err := h.srv.Update(ctx, p)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
 	resp := dto.MapDomainToProductResponse(prodRes)
+	http_utils.SetETag(w, prodRes.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
+// Patch godoc
+// @Summary      Частично обновить продукт
+// @Description  Принимает JSON Merge Patch (application/merge-patch+json или application/json)
+// @Description  или JSON Patch (application/json-patch+json) поверх тела PUT. Записываются только
+// @Description  изменившиеся поля; если товар изменён параллельно, возвращается 409.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int     true  "Product ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
+// @Param        patch  body      object  true  "Merge patch or JSON Patch document"
+// @Success      200    {object}  dto.ProductResponse
+// @Failure      400    {object}  http_utils.ErrorResponse  "Bad request"
+// @Failure      404    {object}  http_utils.ErrorResponse  "Not found"
+// @Failure      409    {object}  http_utils.ErrorResponse  "Failed test operation"
+// @Failure      415    {object}  http_utils.ErrorResponse  "Unsupported patch media type"
+// @Failure      422    {object}  http_utils.ErrorResponse  "Validation error"
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
+// @Failure      500    {object}  http_utils.ErrorResponse  "Internal server error"
+// @Router       /api/admin/product/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.Patch")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid product ID", slog.Any("id", id), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	cur, err := h.srv.GetDetailed(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	before := dto.MapDomainToRequest(cur)
+	after, ok := patch.Apply(w, r, log, &before)
+	if !ok {
+		return
+	}
+
+	prodRes, err := h.srv.Patch(ctx, dto.MapPatchToDomain(cur.ID, cur.Version, &before, after))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	http_utils.SetETag(w, prodRes.Version)
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapDomainToProductResponse(prodRes))
+}
+
 // Delete godoc
 // @Summary      Delete a product
 // @Description  Remove a product by ID
 // @Tags         products
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Product ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
 // @Success      204  "No Content"
 // @Failure      400  {object}  http_utils.ErrorResponse  "Invalid ID"
 // @Failure      404  {object}  http_utils.ErrorResponse  "Not found"
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
 // @Failure      500  {object}  http_utils.ErrorResponse  "Internal server error"
 // @Router       /api/admin/product/{id} [delete]
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -232,31 +313,51 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.srv.Delete(ctx, id); err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, prodDom.ErrBadCategoryID):
-		h.log.Warn("invalid category reference", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusBadRequest, "invalid or missing category")
-	case errors.Is(err, prodDom.ErrBadServiceID):
-		h.log.Warn("invalid category reference", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusBadRequest, "invalid service id")
-	case errors.Is(err, prodDom.ErrInvalidAttribute):
-		h.log.Warn("invalid attribute reference", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "invalid attribute data")
-
-	case errors.Is(err, prodDom.ErrProductNotFound):
-		h.log.Warn("product not found", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusNotFound, "product not found")
-
-	default:
-		h.log.Error("unhandled service error", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusInternalServerError, "internal server error")
+// Batch godoc
+// @Summary      Batch create, update and delete products
+// @Description  Пакет операций над товарами: create, update (по id, тело как у PUT) и delete (по id).
+// @Description  update и delete обязаны передать version (ETag): без него операция получает 428, при несовпадении — 412.
+// @Description  У каждой операции свой результат. С atomic=true пакет выполняется в одной транзакции:
+// @Description  при первой ошибке всё откатывается (rolled_back), а оставшиеся операции пропускаются (skipped).
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        data body batch.Request[dto.ProductRequest] true "Batch operations"
+// @Success      200 {object} batch.Response "All operations succeeded"
+// @Success      207 {object} batch.Response "Some operations failed"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/product/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.Batch")
+
+	req, ok := http_utils.DecodeAndValidate[batch.Request[dto.ProductRequest]](w, r, log)
+	if !ok {
+		return
 	}
+
+	items := batch.ToDomain(req, func(p *dto.ProductRequest) *prodDom.Product { return p.MapCreateToDomain() })
+	results, err := h.srv.Batch(ctx, items, req.Atomic)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	batch.Write(w, r, log, req.Atomic, results, func(p *prodDom.Product) (int64, any) {
+		return p.ID, dto.MapDomainToProductResponse(p)
+	})
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
 }
@@ -15,9 +15,14 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	attrDom "github.com/Neimess/zorkin-store-project/internal/domain/attribute"
+	domBatch "github.com/Neimess/zorkin-store-project/internal/domain/batch"
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
 	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/batch"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/dto"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/mocks"
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
 	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
 
@@ -53,7 +58,7 @@ func (s *ProductHandlerSuite) TestCreate() {
 				Attributes: []dto.ProductAttributeRequest{{Name: "Объем", Value: "10"}},
 			},
 			svcMock: func(s *mocks.MockProductService) {
-				s.EXPECT().Create(mock.Anything, mock.Anything).Return(&prodDom.Product{ID: 1, Name: "ValidProduct", Price: 10, CategoryID: 1}, nil).Once()
+				s.EXPECT().Create(mock.Anything, mock.Anything).Return(&prodDom.Product{ID: 1, Name: "ValidProduct", Price: money.FromFloat(10, money.Base), CategoryID: 1}, nil).Once()
 			},
 			wantCode: http.StatusCreated,
 			wantCheck: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -176,6 +181,7 @@ func (s *ProductHandlerSuite) TestListByCategory() {
 	type testCase struct {
 		name     string
 		id       string
+		query    string
 		svcMock  func(*mocks.MockProductService)
 		wantCode int
 	}
@@ -185,10 +191,25 @@ func (s *ProductHandlerSuite) TestListByCategory() {
 			name: "success",
 			id:   "2",
 			svcMock: func(svc *mocks.MockProductService) {
-				svc.EXPECT().GetByCategoryID(mock.Anything, int64(2)).Return([]prodDom.Product{{ID: 2, Name: "ProdCat"}}, nil).Once()
+				svc.EXPECT().GetByCategoryID(mock.Anything, int64(2), prodDom.SortDefault).Return([]prodDom.Product{{ID: 2, Name: "ProdCat"}}, nil).Once()
 			},
 			wantCode: http.StatusOK,
 		},
+		{
+			name:  "sorted by rating",
+			id:    "2",
+			query: "?sort=rating",
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().GetByCategoryID(mock.Anything, int64(2), prodDom.SortRatingDesc).Return([]prodDom.Product{}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "bad sort",
+			id:       "2",
+			query:    "?sort=popularity",
+			wantCode: http.StatusBadRequest,
+		},
 		{
 			name:     "bad id",
 			id:       "abc",
@@ -205,7 +226,7 @@ func (s *ProductHandlerSuite) TestListByCategory() {
 				req = httptest.NewRequest(http.MethodGet, "/api/product/category/abc", nil)
 				req = withChiParams(req, map[string]string{"id": "abc"})
 			} else {
-				req = httptest.NewRequest(http.MethodGet, "/api/product/category/"+tc.id, nil)
+				req = httptest.NewRequest(http.MethodGet, "/api/product/category/"+tc.id+tc.query, nil)
 				req = withChiParams(req, map[string]string{"id": tc.id})
 			}
 			w := httptest.NewRecorder()
@@ -227,11 +248,102 @@ func (s *ProductHandlerSuite) TestUpdate() {
 	w := httptest.NewRecorder()
 
 	s.SetupTest()
-	s.mockSvc.EXPECT().Update(mock.Anything, mock.Anything).Return(&prodDom.Product{ID: 7, Name: "UpdatedProduct", Price: 10, CategoryID: 1}, nil).Once()
+	s.mockSvc.EXPECT().Update(mock.Anything, mock.Anything).Return(&prodDom.Product{ID: 7, Name: "UpdatedProduct", Price: money.FromFloat(10, money.Base), CategoryID: 1}, nil).Once()
 	s.h.Update(w, req)
 	assert.Equal(s.T(), http.StatusOK, w.Code)
 }
 
+func (s *ProductHandlerSuite) TestPatch() {
+	desc := "Прочный"
+	current := &prodDom.Product{
+		ID: 7, Version: 3, Name: "Плитка", Price: money.FromFloat(10, money.Base), CategoryID: 1, Description: &desc,
+		Attributes: []prodDom.ProductAttribute{{AttributeID: 2, Attribute: attrDom.Attribute{ID: 2, Name: "Объём", CategoryID: 1}, Value: "10"}},
+	}
+	patchReq := func(contentType, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/api/admin/product/7", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		return withChiParams(req, map[string]string{"id": "7"})
+	}
+
+	s.Run("merge patch writes only changed fields", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+		s.mockSvc.EXPECT().Patch(mock.Anything, mock.MatchedBy(func(p *prodDom.Patch) bool {
+			return p.ID == 7 && p.Version == 3 &&
+				p.Price.Set && p.Price.Value.Float64() == 12.5 &&
+				p.Description.Set && p.Description.Value == nil &&
+				!p.Name.Set && !p.CategoryID.Set && !p.Attributes.Set && !p.Services.Set
+		})).Return(&prodDom.Product{ID: 7, Name: "Плитка", Price: money.FromFloat(12.5, money.Base), CategoryID: 1}, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("application/merge-patch+json", `{"price":12.5,"description":null}`))
+		s.Equal(http.StatusOK, w.Code)
+	})
+
+	s.Run("json patch changing category rewrites attributes", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+		s.mockSvc.EXPECT().Patch(mock.Anything, mock.MatchedBy(func(p *prodDom.Patch) bool {
+			return p.CategoryID.Set && p.CategoryID.Value == 4 &&
+				p.Attributes.Set && len(p.Attributes.Value) == 1 && p.Attributes.Value[0].Attribute.CategoryID == 4
+		})).Return(&prodDom.Product{ID: 7, Name: "Плитка", Price: money.FromFloat(10, money.Base), CategoryID: 4}, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("application/json-patch+json",
+			`[{"op":"test","path":"/category_id","value":1},{"op":"replace","path":"/category_id","value":4}]`))
+		s.Equal(http.StatusOK, w.Code)
+	})
+
+	s.Run("failed test operation", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("application/json-patch+json", `[{"op":"test","path":"/name","value":"Ламинат"}]`))
+		s.Equal(http.StatusConflict, w.Code)
+		s.Contains(w.Body.String(), "patch.test_failed")
+	})
+
+	s.Run("patched document fails validation", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("application/merge-patch+json", `{"price":-1}`))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	s.Run("unknown field", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("application/merge-patch+json", `{"colour":"red"}`))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	s.Run("version conflict", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+		s.mockSvc.EXPECT().Patch(mock.Anything, mock.Anything).Return(nil, app_error.ErrVersionConflict).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("application/json", `{"name":"Плитка глянцевая"}`))
+		s.Equal(http.StatusPreconditionFailed, w.Code)
+		s.Contains(w.Body.String(), "precondition_failed")
+	})
+
+	s.Run("unsupported media type", func() {
+		s.SetupTest()
+		s.mockSvc.EXPECT().GetDetailed(mock.Anything, int64(7)).Return(current, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Patch(w, patchReq("text/plain", `name=x`))
+		s.Equal(http.StatusUnsupportedMediaType, w.Code)
+		s.Contains(w.Header().Get("Accept-Patch"), "application/json-patch+json")
+	})
+}
+
 func (s *ProductHandlerSuite) TestDelete() {
 	req := httptest.NewRequest(http.MethodDelete, "/api/admin/product/5", nil)
 	req = withChiParams(req, map[string]string{"id": "5"})
@@ -243,6 +355,43 @@ func (s *ProductHandlerSuite) TestDelete() {
 	assert.Equal(s.T(), http.StatusNoContent, w.Code)
 }
 
+func (s *ProductHandlerSuite) TestBatch() {
+	s.Run("atomic batch rolled back", func() {
+		s.SetupTest()
+		body := `{"atomic":true,"items":[
+			{"op":"create","data":{"name":"Плитка","price":10,"category_id":1}},
+			{"op":"delete","id":9}
+		]}`
+		s.mockSvc.EXPECT().Batch(mock.Anything, mock.MatchedBy(func(items []domBatch.Item[*prodDom.Product]) bool {
+			return len(items) == 2 && items[0].Value.Name == "Плитка" && items[0].Value.CategoryID == 1 && items[1].ID == 9
+		}), true).Return([]domBatch.Result[*prodDom.Product]{
+			{Op: domBatch.OpCreate, Status: domBatch.StatusRolledBack},
+			{Op: domBatch.OpDelete, ID: 9, Status: domBatch.StatusFailed, Err: prodDom.ErrProductNotFound},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Batch(w, httptest.NewRequest(http.MethodPost, "/api/admin/product/batch", bytes.NewBufferString(body)))
+
+		s.Equal(http.StatusMultiStatus, w.Code)
+		var resp batch.Response
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Zero(resp.Succeeded)
+		s.Equal(1, resp.Failed)
+		s.Equal("rolled_back", resp.Results[0].Status)
+		s.Nil(resp.Results[0].Error)
+		s.Equal(http.StatusNotFound, resp.Results[1].Error.Status)
+	})
+
+	s.Run("item validation", func() {
+		s.SetupTest()
+		w := httptest.NewRecorder()
+		s.h.Batch(w, httptest.NewRequest(http.MethodPost, "/api/admin/product/batch",
+			bytes.NewBufferString(`{"items":[{"op":"update","id":1,"data":{"name":"","price":10,"category_id":1}}]}`)))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+		s.Contains(w.Body.String(), "items[0].data.")
+	})
+}
+
 func TestProductHandlerSuite(t *testing.T) {
 	suite.Run(t, new(ProductHandlerSuite))
 }
@@ -0,0 +1,212 @@
+package product
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/dto"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+// ListRelations godoc
+// @Summary      List product relations
+// @Description  Returns typed links of the product: accessory, alternative, compatible_with, required
+// @Tags         products
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   dto.ProductRelationResponse
+// @Failure      400  {object}  http_utils.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  http_utils.ErrorResponse  "Product not found"
+// @Failure      500  {object}  http_utils.ErrorResponse  "Internal server error"
+// @Router       /api/admin/product/{id}/relations [get]
+func (h *Handler) ListRelations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.ListRelations")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid product ID", slog.Any("id", id), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	rels, err := h.srv.ListRelations(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRelationsToResponse(rels))
+}
+
+// CreateRelation godoc
+// @Summary      Link products
+// @Description  Creates a typed relation from the product to another one
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      int                         true  "Product ID"
+// @Param        relation  body      dto.ProductRelationRequest  true  "Relation"
+// @Success      201       {object}  dto.ProductRelationResponse
+// @Failure      400       {object}  http_utils.ErrorResponse  "Bad request"
+// @Failure      409       {object}  http_utils.ErrorResponse  "Relation already exists"
+// @Failure      422       {object}  http_utils.ErrorResponse  "Validation error"
+// @Failure      500       {object}  http_utils.ErrorResponse  "Internal server error"
+// @Router       /api/admin/product/{id}/relations [post]
+func (h *Handler) CreateRelation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.CreateRelation")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid product ID", slog.Any("id", id), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	req, ok := http_utils.DecodeAndValidate[dto.ProductRelationRequest](w, r, log)
+	if !ok {
+		return
+	}
+
+	rel, err := h.srv.CreateRelation(ctx, req.MapToDomain(id, 0))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/admin/product/%d/relations/%d", id, rel.ID))
+	http_utils.WriteJSON(w, http.StatusCreated, dto.MapRelationToResponse(rel))
+}
+
+// UpdateRelation godoc
+// @Summary      Update product relation
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      int                         true  "Product ID"
+// @Param        relationID  path      int                         true  "Relation ID"
+// @Param        relation    body      dto.ProductRelationRequest  true  "Relation"
+// @Success      200         {object}  dto.ProductRelationResponse
+// @Failure      400         {object}  http_utils.ErrorResponse  "Bad request"
+// @Failure      404         {object}  http_utils.ErrorResponse  "Relation not found"
+// @Failure      409         {object}  http_utils.ErrorResponse  "Relation already exists"
+// @Failure      422         {object}  http_utils.ErrorResponse  "Validation error"
+// @Failure      500         {object}  http_utils.ErrorResponse  "Internal server error"
+// @Router       /api/admin/product/{id}/relations/{relationID} [put]
+func (h *Handler) UpdateRelation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.UpdateRelation")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid product ID", slog.Any("id", id), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	relID, err := http_utils.IDFromURL(r, "relationID")
+	if err != nil || relID <= 0 {
+		log.Warn("invalid relation ID", slog.Any("relation_id", relID), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid relation ID")
+		return
+	}
+
+	req, ok := http_utils.DecodeAndValidate[dto.ProductRelationRequest](w, r, log)
+	if !ok {
+		return
+	}
+
+	rel, err := h.srv.UpdateRelation(ctx, req.MapToDomain(id, relID))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapRelationToResponse(rel))
+}
+
+// DeleteRelation godoc
+// @Summary      Delete product relation
+// @Tags         products
+// @Security     BearerAuth
+// @Param        id          path  int  true  "Product ID"
+// @Param        relationID  path  int  true  "Relation ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  http_utils.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  http_utils.ErrorResponse  "Relation not found"
+// @Failure      500  {object}  http_utils.ErrorResponse  "Internal server error"
+// @Router       /api/admin/product/{id}/relations/{relationID} [delete]
+func (h *Handler) DeleteRelation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.DeleteRelation")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid product ID", slog.Any("id", id), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	relID, err := http_utils.IDFromURL(r, "relationID")
+	if err != nil || relID <= 0 {
+		log.Warn("invalid relation ID", slog.Any("relation_id", relID), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid relation ID")
+		return
+	}
+
+	if err := h.srv.DeleteRelation(ctx, id, relID); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FrequentlyBoughtTogether godoc
+// @Summary      Frequently bought together
+// @Description  Required and accessory products first, then products that most often share presets with this one
+// @Tags         products
+// @Produce      json
+// @Param        id     path      int  true   "Product ID"
+// @Param        limit  query     int  false  "Max items (default 8, max 50)"
+// @Success      200    {array}   dto.ProductSummaryResponse
+// @Failure      400    {object}  http_utils.ErrorResponse  "Invalid ID"
+// @Failure      404    {object}  http_utils.ErrorResponse  "Product not found"
+// @Failure      500    {object}  http_utils.ErrorResponse  "Internal server error"
+// @Router       /api/product/{id}/frequently-bought-together [get]
+func (h *Handler) FrequentlyBoughtTogether(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "transport.http.restHTTP.product.FrequentlyBoughtTogether")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		log.Warn("invalid product ID", slog.Any("id", id), slog.Any("error", err))
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			http_utils.WriteError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	items, err := h.srv.FrequentlyBoughtTogether(ctx, id, limit)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]dto.ProductSummaryResponse, len(items))
+	for i, ps := range items {
+		resp[i] = dto.MapSummaryToResponse(ps)
+	}
+	http_utils.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,236 @@
+package product
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	prodDom "github.com/Neimess/zorkin-store-project/internal/domain/product"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product/mocks"
+)
+
+func (s *ProductHandlerSuite) TestCreateRelation() {
+	type testCase struct {
+		name      string
+		id        string
+		body      interface{}
+		svcMock   func(*mocks.MockProductService)
+		wantCode  int
+		wantCheck func(*testing.T, *httptest.ResponseRecorder)
+	}
+
+	created := &prodDom.ProductRelation{
+		ID: 3, ProductID: 1, RelatedID: 2, Type: prodDom.RelationAccessory,
+		Related: &prodDom.ProductSummary{ID: 2, Name: "Затирка", Price: money.FromFloat(890, money.Base)},
+	}
+
+	tests := []testCase{
+		{
+			name: "success",
+			id:   "1",
+			body: dto.ProductRelationRequest{RelatedProductID: 2, Type: "accessory"},
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().CreateRelation(mock.Anything, &prodDom.ProductRelation{ProductID: 1, RelatedID: 2, Type: prodDom.RelationAccessory}).
+					Return(created, nil).Once()
+			},
+			wantCode: http.StatusCreated,
+			wantCheck: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp dto.ProductRelationResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, int64(3), resp.RelationID)
+				assert.Equal(t, "accessory", resp.Type)
+				assert.Equal(t, "Затирка", resp.Product.Name)
+				assert.Equal(t, "/api/admin/product/1/relations/3", w.Header().Get("Location"))
+			},
+		},
+		{
+			name:     "bad id",
+			id:       "abc",
+			body:     dto.ProductRelationRequest{RelatedProductID: 2, Type: "accessory"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown type",
+			id:       "1",
+			body:     dto.ProductRelationRequest{RelatedProductID: 2, Type: "sibling"},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "self relation",
+			id:   "1",
+			body: dto.ProductRelationRequest{RelatedProductID: 1, Type: "required"},
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().CreateRelation(mock.Anything, mock.Anything).Return(nil, prodDom.ErrSelfRelation).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "duplicate",
+			id:   "1",
+			body: dto.ProductRelationRequest{RelatedProductID: 2, Type: "accessory"},
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().CreateRelation(mock.Anything, mock.Anything).Return(nil, prodDom.ErrRelationAlreadyExists).Once()
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			b, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/product/"+tc.id+"/relations", bytes.NewReader(b))
+			req = withChiParams(req, map[string]string{"id": tc.id})
+			w := httptest.NewRecorder()
+			if tc.svcMock != nil {
+				tc.svcMock(s.mockSvc)
+			}
+
+			s.h.CreateRelation(w, req)
+
+			assert.Equal(s.T(), tc.wantCode, w.Code)
+			if tc.wantCheck != nil {
+				tc.wantCheck(s.T(), w)
+			}
+		})
+	}
+}
+
+func (s *ProductHandlerSuite) TestUpdateRelation() {
+	body, _ := json.Marshal(dto.ProductRelationRequest{RelatedProductID: 4, Type: "alternative"})
+
+	s.Run("success", func() {
+		s.SetupTest()
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/product/1/relations/3", bytes.NewReader(body))
+		req = withChiParams(req, map[string]string{"id": "1", "relationID": "3"})
+		w := httptest.NewRecorder()
+		s.mockSvc.EXPECT().UpdateRelation(mock.Anything, &prodDom.ProductRelation{ID: 3, ProductID: 1, RelatedID: 4, Type: prodDom.RelationAlternative}).
+			Return(&prodDom.ProductRelation{ID: 3, ProductID: 1, RelatedID: 4, Type: prodDom.RelationAlternative}, nil).Once()
+		s.h.UpdateRelation(w, req)
+		assert.Equal(s.T(), http.StatusOK, w.Code)
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/product/1/relations/9", bytes.NewReader(body))
+		req = withChiParams(req, map[string]string{"id": "1", "relationID": "9"})
+		w := httptest.NewRecorder()
+		s.mockSvc.EXPECT().UpdateRelation(mock.Anything, mock.Anything).Return(nil, prodDom.ErrRelationNotFound).Once()
+		s.h.UpdateRelation(w, req)
+		assert.Equal(s.T(), http.StatusNotFound, w.Code)
+	})
+
+	s.Run("bad relation id", func() {
+		s.SetupTest()
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/product/1/relations/x", bytes.NewReader(body))
+		req = withChiParams(req, map[string]string{"id": "1", "relationID": "x"})
+		w := httptest.NewRecorder()
+		s.h.UpdateRelation(w, req)
+		assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	})
+}
+
+func (s *ProductHandlerSuite) TestDeleteRelation() {
+	req := httptest.NewRequest(http.MethodDelete, "/api/admin/product/1/relations/3", nil)
+	req = withChiParams(req, map[string]string{"id": "1", "relationID": "3"})
+	w := httptest.NewRecorder()
+
+	s.SetupTest()
+	s.mockSvc.EXPECT().DeleteRelation(mock.Anything, int64(1), int64(3)).Return(nil).Once()
+	s.h.DeleteRelation(w, req)
+	assert.Equal(s.T(), http.StatusNoContent, w.Code)
+}
+
+func (s *ProductHandlerSuite) TestListRelations() {
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/product/1/relations", nil)
+	req = withChiParams(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	s.SetupTest()
+	s.mockSvc.EXPECT().ListRelations(mock.Anything, int64(1)).Return([]prodDom.ProductRelation{
+		{ID: 1, ProductID: 1, RelatedID: 2, Type: prodDom.RelationRequired, Related: &prodDom.ProductSummary{ID: 2, Name: "Клей"}},
+	}, nil).Once()
+	s.h.ListRelations(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	var resp []dto.ProductRelationResponse
+	assert.NoError(s.T(), json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(s.T(), resp, 1)
+	assert.Equal(s.T(), "required", resp[0].Type)
+}
+
+func (s *ProductHandlerSuite) TestFrequentlyBoughtTogether() {
+	type testCase struct {
+		name     string
+		url      string
+		id       string
+		svcMock  func(*mocks.MockProductService)
+		wantCode int
+		wantLen  int
+	}
+
+	tests := []testCase{
+		{
+			name: "success with default limit",
+			url:  "/api/product/1/frequently-bought-together",
+			id:   "1",
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().FrequentlyBoughtTogether(mock.Anything, int64(1), 0).
+					Return([]prodDom.ProductSummary{{ID: 2, Name: "Затирка"}, {ID: 3, Name: "Клей"}}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantLen:  2,
+		},
+		{
+			name: "explicit limit",
+			url:  "/api/product/1/frequently-bought-together?limit=3",
+			id:   "1",
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().FrequentlyBoughtTogether(mock.Anything, int64(1), 3).Return([]prodDom.ProductSummary{}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "bad limit",
+			url:      "/api/product/1/frequently-bought-together?limit=-1",
+			id:       "1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "product not found",
+			url:  "/api/product/9/frequently-bought-together",
+			id:   "9",
+			svcMock: func(svc *mocks.MockProductService) {
+				svc.EXPECT().FrequentlyBoughtTogether(mock.Anything, int64(9), 0).Return(nil, prodDom.ErrProductNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			req := withChiParams(httptest.NewRequest(http.MethodGet, tc.url, nil), map[string]string{"id": tc.id})
+			w := httptest.NewRecorder()
+			if tc.svcMock != nil {
+				tc.svcMock(s.mockSvc)
+			}
+
+			s.h.FrequentlyBoughtTogether(w, req)
+
+			assert.Equal(s.T(), tc.wantCode, w.Code)
+			if tc.wantCode == http.StatusOK {
+				var resp []dto.ProductSummaryResponse
+				assert.NoError(s.T(), json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Len(s.T(), resp, tc.wantLen)
+			}
+		})
+	}
+}
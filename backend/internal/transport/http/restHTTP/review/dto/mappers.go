@@ -0,0 +1,50 @@
+package dto
+
+import domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+
+func MapToDomain(productID int64, r *ReviewRequest) *domReview.Review {
+	return &domReview.Review{
+		ProductID:  productID,
+		AuthorName: r.AuthorName,
+		Rating:     r.Rating,
+		Text:       r.Text,
+		Photos:     r.Photos,
+	}
+}
+
+func MapToResponse(rv *domReview.Review) *ReviewResponse {
+	return &ReviewResponse{
+		ID:         rv.ID,
+		ProductID:  rv.ProductID,
+		AuthorName: rv.AuthorName,
+		Rating:     rv.Rating,
+		Text:       rv.Text,
+		Photos:     rv.Photos,
+		CreatedAt:  rv.CreatedAt,
+	}
+}
+
+func MapToResponseList(list []domReview.Review) []ReviewResponse {
+	resp := make([]ReviewResponse, len(list))
+	for i := range list {
+		resp[i] = *MapToResponse(&list[i])
+	}
+	return resp
+}
+
+func MapToAdminResponse(rv *domReview.Review) *ReviewAdminResponse {
+	return &ReviewAdminResponse{
+		ReviewResponse: *MapToResponse(rv),
+		Status:         string(rv.Status),
+		RejectReason:   rv.RejectReason,
+		ModeratedAt:    rv.ModeratedAt,
+	}
+}
+
+func MapToAdminResponseList(list []domReview.Review) []ReviewAdminResponse {
+	resp := make([]ReviewAdminResponse, len(list))
+	for i := range list {
+		resp[i] = *MapToAdminResponse(&list[i])
+	}
+	return resp
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"strings"
+
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate *validator.Validate = validator.New()
+
+type ReviewRequest struct {
+	AuthorName string   `json:"author_name" validate:"required,min=1,max=100" example:"Анна"`
+	Rating     int      `json:"rating" validate:"required,min=1,max=5" example:"5"`
+	Text       *string  `json:"text,omitempty" validate:"omitempty,max=5000" example:"Плитка отлично легла, цвет как на фото"`
+	Photos     []string `json:"photos,omitempty" validate:"omitempty,max=5,dive,url" example:"https://example.com/photo.jpg"`
+}
+
+func (r ReviewRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		validationErrors := err.(validator.ValidationErrors)
+		for _, e := range validationErrors {
+			switch {
+			case e.Field() == "AuthorName":
+				errs = append(errs, ve.NewFieldError("author_name", i18n.MsgAuthorName))
+			case e.Field() == "Rating":
+				errs = append(errs, ve.NewFieldError("rating", i18n.MsgRating))
+			case e.Field() == "Text":
+				errs = append(errs, ve.NewFieldError("text", i18n.MsgReviewText))
+			case e.Field() == "Photos":
+				errs = append(errs, ve.NewFieldError("photos", i18n.MsgTooManyPhotos))
+			case strings.HasPrefix(e.Field(), "Photos["):
+				errs = append(errs, ve.NewFieldError("photos", i18n.MsgPhotoURL))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
+
+type RejectRequest struct {
+	Reason *string `json:"reason,omitempty" validate:"omitempty,max=500" example:"Отзыв не относится к товару"`
+}
+
+func (r RejectRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		return ve.ValidationErrorResponse{Errors: []ve.FieldError{
+			ve.NewFieldError("reason", i18n.MsgRejectReason),
+		}}
+	}
+	return nil
+}
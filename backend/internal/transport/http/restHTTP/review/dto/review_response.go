@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// ReviewResponse — опубликованный отзыв для витрины.
+type ReviewResponse struct {
+	ID         int64     `json:"id" example:"1"`
+	ProductID  int64     `json:"product_id" example:"10"`
+	AuthorName string    `json:"author_name" example:"Анна"`
+	Rating     int       `json:"rating" example:"5"`
+	Text       *string   `json:"text,omitempty"`
+	Photos     []string  `json:"photos,omitempty"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-06-20T15:00:00Z"`
+}
+
+// ReviewAdminResponse дополняет отзыв данными модерации.
+type ReviewAdminResponse struct {
+	ReviewResponse
+	Status       string     `json:"status" example:"pending"`
+	RejectReason *string    `json:"reject_reason,omitempty"`
+	ModeratedAt  *time.Time `json:"moderated_at,omitempty"`
+}
@@ -0,0 +1,226 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review/dto"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+type ReviewService interface {
+	Create(ctx context.Context, rv *domReview.Review) (*domReview.Review, error)
+	ListApproved(ctx context.Context, productID int64) ([]domReview.Review, error)
+	ListByStatus(ctx context.Context, status domReview.Status) ([]domReview.Review, error)
+	Approve(ctx context.Context, id int64) (*domReview.Review, error)
+	Reject(ctx context.Context, id int64, reason *string) (*domReview.Review, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv ReviewService
+}
+
+func NewDeps(log *slog.Logger, srv ReviewService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("review: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("review: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.review"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv ReviewService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// Create godoc
+// @Summary      Submit product review
+// @Description  Оставить отзыв о товаре. Отзыв публикуется после модерации.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                true  "Product ID"
+// @Param        data  body  dto.ReviewRequest  true  "Review"
+// @Success      202 {object} dto.ReviewAdminResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/product/{id}/reviews [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Create")
+
+	productID, err := http_utils.IDFromURL(r, "id")
+	if err != nil || productID <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.ReviewRequest](w, r, log)
+	if !ok {
+		return
+	}
+	created, err := h.srv.Create(ctx, dto.MapToDomain(productID, req))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusAccepted, dto.MapToAdminResponse(created))
+}
+
+// ListByProduct godoc
+// @Summary      List product reviews
+// @Description  Опубликованные (одобренные) отзывы товара, новые первыми
+// @Tags         reviews
+// @Produce      json
+// @Param        id  path  int  true  "Product ID"
+// @Success      200 {array}  dto.ReviewResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/product/{id}/reviews [get]
+func (h *Handler) ListByProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	productID, err := http_utils.IDFromURL(r, "id")
+	if err != nil || productID <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+	list, err := h.srv.ListApproved(ctx, productID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponseList(list))
+}
+
+// List godoc
+// @Summary      List reviews for moderation
+// @Description  Отзывы с указанным статусом; по умолчанию — очередь модерации (pending)
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query  string  false  "Review status"  Enums(pending, approved, rejected)
+// @Success      200 {array}  dto.ReviewAdminResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/reviews [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	status := domReview.Status(r.URL.Query().Get("status"))
+	list, err := h.srv.ListByStatus(ctx, status)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToAdminResponseList(list))
+}
+
+// Approve godoc
+// @Summary      Approve review
+// @Description  Опубликовать отзыв; он начинает учитываться в рейтинге товара
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Review ID"
+// @Success      200 {object} dto.ReviewAdminResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/reviews/{id}/approve [post]
+func (h *Handler) Approve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	rv, err := h.srv.Approve(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToAdminResponse(rv))
+}
+
+// Reject godoc
+// @Summary      Reject review
+// @Description  Отклонить отзыв с необязательной причиной
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  int                true  "Review ID"
+// @Param        data  body  dto.RejectRequest  false "Rejection reason"
+// @Success      200 {object} dto.ReviewAdminResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/reviews/{id}/reject [post]
+func (h *Handler) Reject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Reject")
+
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var reason *string
+	req, ok := http_utils.DecodeOptional[dto.RejectRequest](w, r, log)
+	if !ok {
+		return
+	}
+	if req != nil {
+		reason = req.Reason
+	}
+	rv, err := h.srv.Reject(ctx, id, reason)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToAdminResponse(rv))
+}
+
+// Delete godoc
+// @Summary      Delete review
+// @Description  Удалить отзыв по ID
+// @Tags         reviews
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Review ID"
+// @Success      204
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/reviews/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	if err := h.srv.Delete(ctx, id); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
@@ -0,0 +1,156 @@
+package review_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domReview "github.com/Neimess/zorkin-store-project/internal/domain/review"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewHandlerSuite struct {
+	suite.Suite
+	h   *review.Handler
+	svc *mocks.MockReviewService
+}
+
+func (s *ReviewHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockReviewService(s.T())
+	deps, err := review.NewDeps(slog.Default(), s.svc)
+	s.Require().NoError(err)
+	s.h = review.New(deps)
+}
+
+func withChiParam(r *http.Request, key, val string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add(key, val)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func (s *ReviewHandlerSuite) TestCreate() {
+	cases := []struct {
+		name     string
+		body     any
+		setup    func()
+		wantCode int
+	}{
+		{
+			name: "accepted for moderation",
+			body: dto.ReviewRequest{AuthorName: "Анна", Rating: 5, Photos: []string{"https://example.com/1.jpg"}},
+			setup: func() {
+				s.svc.EXPECT().Create(mock.Anything, mock.MatchedBy(func(rv *domReview.Review) bool {
+					return rv.ProductID == 10 && rv.Rating == 5
+				})).Return(&domReview.Review{ID: 1, ProductID: 10, AuthorName: "Анна", Rating: 5, Status: domReview.StatusPending}, nil).Once()
+			},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:     "rating out of range",
+			body:     dto.ReviewRequest{AuthorName: "Анна", Rating: 7},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "photo is not a url",
+			body:     dto.ReviewRequest{AuthorName: "Анна", Rating: 4, Photos: []string{"not a url"}},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "product not found",
+			body: dto.ReviewRequest{AuthorName: "Анна", Rating: 4},
+			setup: func() {
+				s.svc.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, domReview.ErrProductNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			if tc.setup != nil {
+				tc.setup()
+			}
+			b, _ := json.Marshal(tc.body)
+			req := withChiParam(httptest.NewRequest(http.MethodPost, "/api/product/10/reviews", bytes.NewReader(b)), "id", "10")
+			w := httptest.NewRecorder()
+			s.h.Create(w, req)
+			s.Equal(tc.wantCode, w.Code)
+		})
+	}
+}
+
+func (s *ReviewHandlerSuite) TestListByProduct() {
+	s.svc.EXPECT().ListApproved(mock.Anything, int64(10)).
+		Return([]domReview.Review{{ID: 1, ProductID: 10, AuthorName: "Анна", Rating: 5, Status: domReview.StatusApproved}}, nil).Once()
+	req := withChiParam(httptest.NewRequest(http.MethodGet, "/api/product/10/reviews", nil), "id", "10")
+	w := httptest.NewRecorder()
+	s.h.ListByProduct(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	var resp []dto.ReviewResponse
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Len(resp, 1)
+	s.NotContains(w.Body.String(), "status")
+}
+
+func (s *ReviewHandlerSuite) TestList() {
+	s.svc.EXPECT().ListByStatus(mock.Anything, domReview.Status("spam")).Return(nil, domReview.ErrInvalidStatus).Once()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/reviews?status=spam", nil)
+	w := httptest.NewRecorder()
+	s.h.List(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ReviewHandlerSuite) TestApprove() {
+	s.svc.EXPECT().Approve(mock.Anything, int64(1)).Return(nil, domReview.ErrAlreadyModerated).Once()
+	req := withChiParam(httptest.NewRequest(http.MethodPost, "/api/admin/reviews/1/approve", nil), "id", "1")
+	w := httptest.NewRecorder()
+	s.h.Approve(w, req)
+	s.Equal(http.StatusConflict, w.Code)
+}
+
+func (s *ReviewHandlerSuite) TestReject() {
+	s.Run("with reason", func() {
+		s.SetupTest()
+		s.svc.EXPECT().Reject(mock.Anything, int64(1), mock.MatchedBy(func(r *string) bool {
+			return r != nil && *r == "спам"
+		})).Return(&domReview.Review{ID: 1, Status: domReview.StatusRejected}, nil).Once()
+		req := withChiParam(httptest.NewRequest(http.MethodPost, "/api/admin/reviews/1/reject",
+			bytes.NewBufferString(`{"reason":"спам"}`)), "id", "1")
+		w := httptest.NewRecorder()
+		s.h.Reject(w, req)
+		s.Equal(http.StatusOK, w.Code)
+		var resp dto.ReviewAdminResponse
+		s.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal("rejected", resp.Status)
+	})
+	s.Run("without body", func() {
+		s.SetupTest()
+		s.svc.EXPECT().Reject(mock.Anything, int64(1), (*string)(nil)).
+			Return(&domReview.Review{ID: 1, Status: domReview.StatusRejected}, nil).Once()
+		req := withChiParam(httptest.NewRequest(http.MethodPost, "/api/admin/reviews/1/reject", nil), "id", "1")
+		w := httptest.NewRecorder()
+		s.h.Reject(w, req)
+		s.Equal(http.StatusOK, w.Code)
+	})
+}
+
+func (s *ReviewHandlerSuite) TestDelete() {
+	s.svc.EXPECT().Delete(mock.Anything, int64(5)).Return(domReview.ErrReviewNotFound).Once()
+	req := withChiParam(httptest.NewRequest(http.MethodDelete, "/api/admin/reviews/5", nil), "id", "5")
+	w := httptest.NewRecorder()
+	s.h.Delete(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestReviewHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ReviewHandlerSuite))
+}
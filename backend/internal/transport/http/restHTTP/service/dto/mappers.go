@@ -1,22 +1,30 @@
 package dto
 
-import domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+import (
+	"github.com/Neimess/zorkin-store-project/internal/domain/money"
+	"github.com/Neimess/zorkin-store-project/internal/domain/patch"
+	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	discountDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
+)
 
 func MapToDomain(r *ServiceRequest) *domService.Service {
 	return &domService.Service{
 		Name:        r.Name,
 		Description: r.Description,
-		Price:       r.Price,
+		Price:       money.FromFloat(r.Price, money.Base),
 	}
 }
 
 func MapToResponse(s *domService.Service) *ServiceResponse {
-	return &ServiceResponse{
+	resp := &ServiceResponse{
 		ID:          s.ID,
 		Name:        s.Name,
 		Description: s.Description,
-		Price:       s.Price,
+		Price:       s.Price.Float64(),
+		Currency:    string(s.Price.Currency),
 	}
+	resp.OldPrice, resp.Discount = discountDto.MapSale(s.Sale)
+	return resp
 }
 
 func MapToResponseList(list []domService.Service) []ServiceResponse {
@@ -26,3 +34,26 @@ func MapToResponseList(list []domService.Service) []ServiceResponse {
 	}
 	return resp
 }
+
+// MapToRequest — текущая услуга в виде тела PUT, к нему применяется PATCH.
+func MapToRequest(s *domService.Service) ServiceRequest {
+	return ServiceRequest{
+		Name:        s.Name,
+		Description: s.Description,
+		Price:       s.Price.Float64(),
+	}
+}
+
+// MapToPatch оставляет в патче только изменившиеся поля.
+func MapToPatch(id, version int64, before, after *ServiceRequest) *domService.Patch {
+	p := &domService.Patch{
+		ID:          id,
+		Version:     version,
+		Name:        patch.Diff(before.Name, after.Name),
+		Description: patch.DiffPtr(before.Description, after.Description),
+	}
+	if before.Price != after.Price {
+		p.Price = patch.To(money.FromFloat(after.Price, money.Base))
+	}
+	return p
+}
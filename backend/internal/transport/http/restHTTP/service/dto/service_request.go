@@ -2,6 +2,7 @@ package dto
 
 import (
 	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -23,11 +24,11 @@ func (r ServiceRequest) Validate() error {
 		for _, e := range validationErrors {
 			switch e.Field() {
 			case "Name":
-				errs = append(errs, ve.FieldError{Field: "name", Message: "name is required and must be 1-255 chars"})
+				errs = append(errs, ve.NewFieldError("name", i18n.MsgName1To255))
 			case "Price":
-				errs = append(errs, ve.FieldError{Field: "price", Message: "price is required and must be > 0"})
+				errs = append(errs, ve.NewFieldError("price", i18n.MsgPricePositive))
 			default:
-				errs = append(errs, ve.FieldError{Field: e.Field(), Message: "invalid field"})
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
 			}
 		}
 	}
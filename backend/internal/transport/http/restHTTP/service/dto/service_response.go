@@ -1,8 +1,14 @@
 package dto
 
+import discountDto "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount/dto"
+
 type ServiceResponse struct {
 	ID          int64   `json:"id" example:"1"`
 	Name        string  `json:"name" example:"Монтаж"`
 	Description *string `json:"description,omitempty" example:"Установка изделия"`
 	Price       float64 `json:"price" example:"1500.00"`
+	Currency    string  `json:"currency" example:"RUB"`
+	// OldPrice и Discount заполнены, если на услугу действует скидка.
+	OldPrice *float64                             `json:"old_price,omitempty" example:"2000.00"`
+	Discount *discountDto.AppliedDiscountResponse `json:"discount,omitempty"`
 }
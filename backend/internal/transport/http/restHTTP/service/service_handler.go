@@ -7,7 +7,11 @@ import (
 	"log/slog"
 	"net/http"
 
+	domBatch "github.com/Neimess/zorkin-store-project/internal/domain/batch"
 	domService "github.com/Neimess/zorkin-store-project/internal/domain/service"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/batch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/patch"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/service/dto"
 	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
 )
@@ -17,7 +21,9 @@ type ServiceService interface {
 	Get(ctx context.Context, id int64) (*domService.Service, error)
 	List(ctx context.Context) ([]domService.Service, error)
 	Update(ctx context.Context, s *domService.Service) (*domService.Service, error)
+	Patch(ctx context.Context, p *domService.Patch) (*domService.Service, error)
 	Delete(ctx context.Context, id int64) error
+	Batch(ctx context.Context, items []domBatch.Item[*domService.Service], atomic bool) ([]domBatch.Result[*domService.Service], error)
 }
 
 type Deps struct {
@@ -69,7 +75,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	service := dto.MapToDomain(req)
 	created, err := h.srv.Create(ctx, service)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapToResponse(created)
@@ -97,10 +103,11 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 	}
 	service, err := h.srv.Get(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapToResponse(service)
+	http_utils.SetETag(w, service.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
@@ -132,11 +139,14 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Service ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
 // @Param        data body dto.ServiceRequest true "Service data"
 // @Success      200 {object} dto.ServiceResponse
 // @Failure      400 {object} http_utils.ErrorResponse
 // @Failure      409 {object} http_utils.ErrorResponse
 // @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
 // @Failure      500 {object} http_utils.ErrorResponse
 // @Router       /api/admin/services/{id} [put]
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
@@ -154,22 +164,73 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	service.ID = id
 	updated, err := h.srv.Update(ctx, service)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	resp := dto.MapToResponse(updated)
+	http_utils.SetETag(w, updated.Version)
 	http_utils.WriteJSON(w, http.StatusOK, resp)
 }
 
+// Patch godoc
+// @Summary      Partially update service
+// @Description  Частично обновить услугу: JSON Merge Patch (application/merge-patch+json, application/json)
+// @Description  или JSON Patch (application/json-patch+json).
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Service ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
+// @Param        patch body object true "Merge patch or JSON Patch document"
+// @Success      200 {object} dto.ServiceResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      409 {object} http_utils.ErrorResponse
+// @Failure      415 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/services/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	cur, err := h.srv.Get(ctx, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	before := dto.MapToRequest(cur)
+	after, ok := patch.Apply(w, r, h.log, &before)
+	if !ok {
+		return
+	}
+	updated, err := h.srv.Patch(ctx, dto.MapToPatch(cur.ID, cur.Version, &before, after))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.SetETag(w, updated.Version)
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponse(updated))
+}
+
 // Delete godoc
 // @Summary      Delete service
 // @Description  Удалить услугу по ID
 // @Tags         services
 // @Security     BearerAuth
 // @Param        id path int true "Service ID"
+// @Param        If-Match  header  string  true  "ETag текущей версии или *"
 // @Success      204
 // @Failure      400 {object} http_utils.ErrorResponse
 // @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      412  {object}  http_utils.ErrorResponse  "Version mismatch"
+// @Failure      428  {object}  http_utils.ErrorResponse  "If-Match required"
 // @Failure      500 {object} http_utils.ErrorResponse
 // @Router       /api/admin/services/{id} [delete]
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -181,24 +242,50 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	err = h.srv.Delete(ctx, id)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, domService.ErrServiceNotFound):
-		http_utils.WriteError(w, http.StatusNotFound, "service not found")
-	case errors.Is(err, domService.ErrServiceAlreadyExists):
-		http_utils.WriteError(w, http.StatusConflict, "service already exists")
-	case errors.Is(err, domService.ErrEmptyName):
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "name must not be empty")
-	case errors.Is(err, domService.ErrNameTooLong):
-		http_utils.WriteError(w, http.StatusUnprocessableEntity, "name is too long")
-	default:
-		h.log.Error("service error", slog.Any("error", err))
-		http_utils.WriteError(w, http.StatusInternalServerError, "internal server error")
+// Batch godoc
+// @Summary      Batch create, update and delete services
+// @Description  Пакет операций над услугами: create, update (по id) и delete (по id).
+// @Description  update и delete обязаны передать version (ETag): без него операция получает 428, при несовпадении — 412.
+// @Description  У каждой операции свой результат. С atomic=true пакет выполняется в одной транзакции:
+// @Description  при первой ошибке всё откатывается (rolled_back), а оставшиеся операции пропускаются (skipped).
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        data body batch.Request[dto.ServiceRequest] true "Batch operations"
+// @Success      200 {object} batch.Response "All operations succeeded"
+// @Success      207 {object} batch.Response "Some operations failed"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/services/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.log.With("op", "Batch")
+
+	req, ok := http_utils.DecodeAndValidate[batch.Request[dto.ServiceRequest]](w, r, log)
+	if !ok {
+		return
 	}
+
+	items := batch.ToDomain(req, dto.MapToDomain)
+	results, err := h.srv.Batch(ctx, items, req.Atomic)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	batch.Write(w, r, log, req.Atomic, results, func(s *domService.Service) (int64, any) {
+		return s.ID, dto.MapToResponse(s)
+	})
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
 }
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+)
+
+// RestoreResponse — итог восстановления снимка; ключи — типы записей
+// (category, product, ...).
+type RestoreResponse struct {
+	Mode    string         `json:"mode" example:"append"`
+	Created map[string]int `json:"created"`
+	Matched map[string]int `json:"matched,omitempty"`
+}
+
+func MapResultToResponse(res domSnapshot.Result) RestoreResponse {
+	return RestoreResponse{
+		Mode:    string(res.Mode),
+		Created: countsToMap(res.Created),
+		Matched: countsToMap(res.Matched),
+	}
+}
+
+func countsToMap(c domSnapshot.Counts) map[string]int {
+	m := make(map[string]int, len(c))
+	for k, n := range c {
+		if n > 0 {
+			m[string(k)] = n
+		}
+	}
+	return m
+}
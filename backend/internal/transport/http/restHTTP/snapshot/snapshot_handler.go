@@ -0,0 +1,134 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/snapshot/dto"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+)
+
+type SnapshotService interface {
+	Export(ctx context.Context, w io.Writer) (domSnapshot.Counts, error)
+	Restore(ctx context.Context, r io.Reader, mode domSnapshot.Mode) (domSnapshot.Result, error)
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv SnapshotService
+}
+
+func NewDeps(log *slog.Logger, srv SnapshotService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("snapshot: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("snapshot: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.snapshot"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv SnapshotService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// Export godoc
+// @Summary      Export catalog snapshot
+// @Description  Снимок каталога в NDJSON: заголовок, затем записи category, attribute, service, product,
+// @Description  product_attribute, product_service, preset, preset_item, coefficient и завершающая запись end
+// @Description  с количеством записей. Снимок без end при восстановлении считается обрезанным.
+// @Description  Большой каталог удобнее выгружать командой `store snapshot export`.
+// @Tags         snapshot
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Success      200 {file} file
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/snapshot [get]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	name := fmt.Sprintf("catalog-%s.ndjson", time.Now().UTC().Format("20060102-150405"))
+	sw := &streamWriter{w: w, name: name}
+	if _, err := h.srv.Export(r.Context(), sw); err != nil {
+		if !sw.started {
+			problems.Write(w, r, h.log, err)
+			return
+		}
+		// заголовки уже отправлены: клиент получит снимок без записи end
+		h.log.Error("snapshot export aborted", slog.Any("error", err))
+	}
+}
+
+// Restore godoc
+// @Summary      Restore catalog snapshot
+// @Description  Загружает снимок из тела запроса (NDJSON, можно с Content-Encoding: gzip) в одной транзакции.
+// @Description  Строки получают новые ID, ссылки между записями пересчитываются.
+// @Description  mode=append (по умолчанию) добавляет снимок к каталогу: категории и коэффициенты с тем же
+// @Description  названием переиспользуются. mode=replace сначала удаляет весь каталог.
+// @Tags         snapshot
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Security     BearerAuth
+// @Description  С Idempotency-Key повтор получает сохранённый ответ; тело до http_server.body_limit.snapshot
+// @Description  хешируется потоком, большие снимки не держатся в памяти.
+// @Param        mode  query  string  false  "append | replace"
+// @Param        Idempotency-Key  header  string  false  "Ключ идемпотентности"
+// @Success      200 {object} dto.RestoreResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      413 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/snapshot/restore [post]
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	mode, err := domSnapshot.ParseMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		problems.Write(w, r, h.log, err)
+		return
+	}
+	var body io.Reader = r.Body
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			problems.Write(w, r, h.log, fmt.Errorf("%w: %v", domSnapshot.ErrUnsupportedFormat, err))
+			return
+		}
+		defer zr.Close()
+		body = zr
+	}
+	res, err := h.srv.Restore(r.Context(), body, mode)
+	if err != nil {
+		problems.Write(w, r, h.log, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapResultToResponse(res))
+}
+
+// streamWriter отправляет заголовки ответа с первой записью: пока ничего
+// не записано, ошибку выгрузки ещё можно вернуть как problem+json.
+type streamWriter struct {
+	w       http.ResponseWriter
+	name    string
+	started bool
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", "application/x-ndjson")
+		s.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.name))
+		s.w.WriteHeader(http.StatusOK)
+	}
+	return s.w.Write(p)
+}
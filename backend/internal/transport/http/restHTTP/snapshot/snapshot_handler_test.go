@@ -0,0 +1,130 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domSnapshot "github.com/Neimess/zorkin-store-project/internal/domain/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/snapshot"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/snapshot/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/snapshot/mocks"
+)
+
+type SnapshotHandlerSuite struct {
+	suite.Suite
+	h   *snapshot.Handler
+	svc *mocks.MockSnapshotService
+}
+
+func (s *SnapshotHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockSnapshotService(s.T())
+	deps, err := snapshot.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = snapshot.New(deps)
+}
+
+func (s *SnapshotHandlerSuite) TestExport() {
+	s.Run("streamed as attachment", func() {
+		s.svc.EXPECT().Export(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, w io.Writer) (domSnapshot.Counts, error) {
+				_, err := io.WriteString(w, "{\"format\":\"zorkin-catalog\"}\n")
+				return domSnapshot.Counts{}, err
+			}).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Export(w, httptest.NewRequest(http.MethodGet, "/api/admin/snapshot", nil))
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+		s.Contains(w.Header().Get("Content-Disposition"), `attachment; filename="catalog-`)
+		s.Contains(w.Body.String(), "zorkin-catalog")
+	})
+
+	s.Run("error before first byte", func() {
+		s.svc.EXPECT().Export(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Export(w, httptest.NewRequest(http.MethodGet, "/api/admin/snapshot", nil))
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		s.Empty(w.Header().Get("Content-Disposition"))
+	})
+}
+
+func (s *SnapshotHandlerSuite) TestRestore() {
+	const body = "{\"format\":\"zorkin-catalog\",\"version\":1}\n"
+
+	s.Run("append by default", func() {
+		s.svc.EXPECT().Restore(mock.Anything, mock.Anything, domSnapshot.ModeAppend).
+			RunAndReturn(func(_ context.Context, r io.Reader, mode domSnapshot.Mode) (domSnapshot.Result, error) {
+				b, _ := io.ReadAll(r)
+				s.Equal(body, string(b))
+				return domSnapshot.Result{
+					Mode:    mode,
+					Created: domSnapshot.Counts{domSnapshot.KindProduct: 3, domSnapshot.KindPreset: 0},
+					Matched: domSnapshot.Counts{domSnapshot.KindCategory: 1},
+				}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Restore(w, httptest.NewRequest(http.MethodPost, "/api/admin/snapshot/restore", strings.NewReader(body)))
+
+		s.Require().Equal(http.StatusOK, w.Code)
+		var resp dto.RestoreResponse
+		s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.Equal("append", resp.Mode)
+		s.Equal(map[string]int{"product": 3}, resp.Created)
+		s.Equal(map[string]int{"category": 1}, resp.Matched)
+	})
+
+	s.Run("gzip body", func() {
+		var gz bytes.Buffer
+		zw := gzip.NewWriter(&gz)
+		_, _ = zw.Write([]byte(body))
+		s.Require().NoError(zw.Close())
+
+		s.svc.EXPECT().Restore(mock.Anything, mock.Anything, domSnapshot.ModeReplace).
+			RunAndReturn(func(_ context.Context, r io.Reader, mode domSnapshot.Mode) (domSnapshot.Result, error) {
+				b, _ := io.ReadAll(r)
+				s.Equal(body, string(b))
+				return domSnapshot.Result{Mode: mode}, nil
+			}).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/snapshot/restore?mode=replace", &gz)
+		req.Header.Set("Content-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		s.h.Restore(w, req)
+		s.Equal(http.StatusOK, w.Code)
+	})
+
+	s.Run("invalid mode", func() {
+		w := httptest.NewRecorder()
+		s.h.Restore(w, httptest.NewRequest(http.MethodPost, "/api/admin/snapshot/restore?mode=merge", strings.NewReader(body)))
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("dangling reference", func() {
+		s.svc.EXPECT().Restore(mock.Anything, mock.Anything, domSnapshot.ModeAppend).
+			Return(domSnapshot.Result{}, domSnapshot.ErrDanglingReference).Once()
+
+		w := httptest.NewRecorder()
+		s.h.Restore(w, httptest.NewRequest(http.MethodPost, "/api/admin/snapshot/restore", strings.NewReader(body)))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestSnapshotHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotHandlerSuite))
+}
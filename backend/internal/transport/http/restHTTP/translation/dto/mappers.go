@@ -0,0 +1,35 @@
+package dto
+
+import (
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+func MapToDomain(entity domTr.Entity, id int64, locale i18n.Locale, req *TranslationRequest) *domTr.Translation {
+	fields := make(map[string]string, len(req.Fields))
+	for k, v := range req.Fields {
+		fields[k] = v
+	}
+	return &domTr.Translation{
+		Entity:   entity,
+		EntityID: id,
+		Locale:   locale,
+		Fields:   fields,
+	}
+}
+
+func MapToResponse(t *domTr.Translation) TranslationResponse {
+	return TranslationResponse{
+		Locale:    string(t.Locale),
+		Fields:    t.Fields,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func MapToResponseList(list []domTr.Translation) []TranslationResponse {
+	res := make([]TranslationResponse, len(list))
+	for i := range list {
+		res[i] = MapToResponse(&list[i])
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate *validator.Validate = validator.New()
+
+// TranslationRequest — значения переводимых полей на одной локали.
+// Набор полей зависит от сущности: name, description (product, preset, service),
+// name (category), name, unit (attribute).
+type TranslationRequest struct {
+	Fields map[string]string `json:"fields" validate:"required,min=1" example:"name:Porcelain tile,description:Matte finish"`
+}
+
+func (r TranslationRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		return ve.ValidationErrorResponse{Errors: []ve.FieldError{
+			ve.NewFieldError("fields", i18n.MsgTranslationNoFields),
+		}}
+	}
+	return nil
+}
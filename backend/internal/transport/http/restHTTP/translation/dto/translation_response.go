@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type TranslationResponse struct {
+	Locale    string            `json:"locale" example:"en"`
+	Fields    map[string]string `json:"fields"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2025-06-01T12:00:00Z"`
+}
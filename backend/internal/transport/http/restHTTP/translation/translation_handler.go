@@ -0,0 +1,168 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/translation/dto"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+type TranslationService interface {
+	List(ctx context.Context, entity domTr.Entity, id int64) ([]domTr.Translation, error)
+	Put(ctx context.Context, t *domTr.Translation) (*domTr.Translation, error)
+	Delete(ctx context.Context, entity domTr.Entity, id int64, locale i18n.Locale) error
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv TranslationService
+}
+
+func NewDeps(log *slog.Logger, srv TranslationService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("translation: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("translation: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.translation"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv TranslationService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// List godoc
+// @Summary      List translations
+// @Description  Переводы сущности каталога на все локали, кроме языка по умолчанию (ru)
+// @Tags         translations
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity  path  string  true  "Entity: product, category, attribute, preset, service"
+// @Param        id      path  int     true  "Entity ID"
+// @Success      200 {array}  dto.TranslationResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/translations/{entity}/{id} [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	entity, id, ok := h.parseTarget(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.srv.List(r.Context(), entity, id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponseList(list))
+}
+
+// Put godoc
+// @Summary      Set translation
+// @Description  Заменяет перевод сущности на локали целиком: поля, которых нет в запросе, удаляются
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity  path  string                  true  "Entity: product, category, attribute, preset, service"
+// @Param        id      path  int                     true  "Entity ID"
+// @Param        locale  path  string                  true  "Locale: en, kz"
+// @Param        data    body  dto.TranslationRequest  true  "Translated fields"
+// @Success      200 {object} dto.TranslationResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/translations/{entity}/{id}/{locale} [put]
+func (h *Handler) Put(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "Put")
+
+	entity, id, ok := h.parseTarget(w, r)
+	if !ok {
+		return
+	}
+	locale, ok := h.parseLocale(w, r)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.TranslationRequest](w, r, log)
+	if !ok {
+		return
+	}
+	saved, err := h.srv.Put(r.Context(), dto.MapToDomain(entity, id, locale, req))
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapToResponse(saved))
+}
+
+// Delete godoc
+// @Summary      Delete translation
+// @Description  После удаления на этой локали отдаётся контент по умолчанию
+// @Tags         translations
+// @Security     BearerAuth
+// @Param        entity  path  string  true  "Entity: product, category, attribute, preset, service"
+// @Param        id      path  int     true  "Entity ID"
+// @Param        locale  path  string  true  "Locale: en, kz"
+// @Success      204 "No Content"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/translations/{entity}/{id}/{locale} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	entity, id, ok := h.parseTarget(w, r)
+	if !ok {
+		return
+	}
+	locale, ok := h.parseLocale(w, r)
+	if !ok {
+		return
+	}
+	if err := h.srv.Delete(r.Context(), entity, id, locale); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) parseTarget(w http.ResponseWriter, r *http.Request) (domTr.Entity, int64, bool) {
+	entity := domTr.Entity(chi.URLParam(r, "entity"))
+	if !entity.Valid() {
+		h.handleServiceError(w, r, domTr.ErrInvalidEntity)
+		return "", 0, false
+	}
+	id, err := http_utils.IDFromURL(r, "id")
+	if err != nil || id <= 0 {
+		http_utils.WriteError(w, http.StatusBadRequest, "invalid entity id")
+		return "", 0, false
+	}
+	return entity, id, true
+}
+
+func (h *Handler) parseLocale(w http.ResponseWriter, r *http.Request) (i18n.Locale, bool) {
+	locale, ok := i18n.Parse(chi.URLParam(r, "locale"))
+	if !ok {
+		h.handleServiceError(w, r, domTr.ErrInvalidLocale)
+		return "", false
+	}
+	return locale, true
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
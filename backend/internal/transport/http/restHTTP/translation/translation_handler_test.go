@@ -0,0 +1,193 @@
+package translation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domTr "github.com/Neimess/zorkin-store-project/internal/domain/translation"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/translation"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/translation/mocks"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TranslationHandlerSuite struct {
+	suite.Suite
+	h   *translation.Handler
+	svc *mocks.MockTranslationService
+}
+
+func (s *TranslationHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockTranslationService(s.T())
+	deps, err := translation.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = translation.New(deps)
+}
+
+func withChiParams(r *http.Request, kv ...string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	for i := 0; i+1 < len(kv); i += 2 {
+		chiCtx.URLParams.Add(kv[i], kv[i+1])
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func (s *TranslationHandlerSuite) TestPut() {
+	cases := []struct {
+		name     string
+		entity   string
+		locale   string
+		body     string
+		lang     string
+		setup    func()
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:   "saved",
+			entity: "product",
+			locale: "en",
+			body:   `{"fields":{"name":"Porcelain tile"}}`,
+			setup: func() {
+				s.svc.EXPECT().Put(mock.Anything, mock.MatchedBy(func(t *domTr.Translation) bool {
+					return t.Entity == domTr.EntityProduct && t.EntityID == 7 &&
+						t.Locale == i18n.EN && t.Fields["name"] == "Porcelain tile"
+				})).RunAndReturn(func(_ context.Context, t *domTr.Translation) (*domTr.Translation, error) {
+					return t, nil
+				}).Once()
+			},
+			wantCode: http.StatusOK,
+			wantBody: `"locale":"en"`,
+		},
+		{
+			name:   "kk is accepted as kz",
+			entity: "category",
+			locale: "kk",
+			body:   `{"fields":{"name":"Керамогранит"}}`,
+			setup: func() {
+				s.svc.EXPECT().Put(mock.Anything, mock.MatchedBy(func(t *domTr.Translation) bool {
+					return t.Locale == i18n.KZ
+				})).RunAndReturn(func(_ context.Context, t *domTr.Translation) (*domTr.Translation, error) {
+					return t, nil
+				}).Once()
+			},
+			wantCode: http.StatusOK,
+			wantBody: `"locale":"kz"`,
+		},
+		{
+			name:     "unknown entity",
+			entity:   "lead",
+			locale:   "en",
+			body:     `{"fields":{"name":"x"}}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"code":"translation.invalid_entity"`,
+		},
+		{
+			name:     "unsupported locale",
+			entity:   "product",
+			locale:   "de",
+			body:     `{"fields":{"name":"x"}}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"code":"translation.invalid_locale"`,
+		},
+		{
+			name:     "empty fields, message in kazakh",
+			entity:   "product",
+			locale:   "en",
+			body:     `{"fields":{}}`,
+			lang:     "kk-KZ",
+			wantCode: http.StatusUnprocessableEntity,
+			wantBody: `"message":"fields бос болмауы керек"`,
+		},
+		{
+			name:   "field is not translatable",
+			entity: "category",
+			locale: "en",
+			body:   `{"fields":{"description":"x"}}`,
+			setup: func() {
+				s.svc.EXPECT().Put(mock.Anything, mock.Anything).Return(nil, domTr.ErrUnknownField).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+			wantBody: `"code":"translation.unknown_field"`,
+		},
+		{
+			name:   "entity does not exist",
+			entity: "preset",
+			locale: "en",
+			body:   `{"fields":{"name":"x"}}`,
+			setup: func() {
+				s.svc.EXPECT().Put(mock.Anything, mock.Anything).Return(nil, domTr.ErrEntityNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			if tc.setup != nil {
+				tc.setup()
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/admin/translations", bytes.NewBufferString(tc.body))
+			if tc.lang != "" {
+				req.Header.Set("Accept-Language", tc.lang)
+			}
+			req = withChiParams(req, "entity", tc.entity, "id", "7", "locale", tc.locale)
+			w := httptest.NewRecorder()
+			s.h.Put(w, req)
+			s.Equal(tc.wantCode, w.Code)
+			if tc.wantBody != "" {
+				s.Contains(w.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
+
+func (s *TranslationHandlerSuite) TestList() {
+	s.svc.EXPECT().List(mock.Anything, domTr.EntityService, int64(3)).Return([]domTr.Translation{
+		{Entity: domTr.EntityService, EntityID: 3, Locale: i18n.EN, Fields: map[string]string{"name": "Installation"}},
+	}, nil).Once()
+	req := withChiParams(httptest.NewRequest(http.MethodGet, "/api/admin/translations/service/3", nil),
+		"entity", "service", "id", "3")
+	w := httptest.NewRecorder()
+	s.h.List(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"name":"Installation"`)
+}
+
+func (s *TranslationHandlerSuite) TestDelete() {
+	s.Run("deleted", func() {
+		s.SetupTest()
+		s.svc.EXPECT().Delete(mock.Anything, domTr.EntityAttribute, int64(2), i18n.KZ).Return(nil).Once()
+		req := withChiParams(httptest.NewRequest(http.MethodDelete, "/api/admin/translations/attribute/2/kz", nil),
+			"entity", "attribute", "id", "2", "locale", "kz")
+		w := httptest.NewRecorder()
+		s.h.Delete(w, req)
+		s.Equal(http.StatusNoContent, w.Code)
+	})
+	s.Run("not found, title in russian", func() {
+		s.SetupTest()
+		s.svc.EXPECT().Delete(mock.Anything, domTr.EntityAttribute, int64(2), i18n.EN).
+			Return(domTr.ErrTranslationNotFound).Once()
+		req := withChiParams(httptest.NewRequest(http.MethodDelete, "/api/admin/translations/attribute/2/en?lang=ru", nil),
+			"entity", "attribute", "id", "2", "locale", "en")
+		w := httptest.NewRecorder()
+		s.h.Delete(w, req)
+		s.Require().Equal(http.StatusNotFound, w.Code)
+		var p http_utils.ErrorResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		s.Equal("translation.not_found", p.Code)
+		s.Equal("перевод не найден", p.Title)
+	})
+}
+
+func TestTranslationHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TranslationHandlerSuite))
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+)
+
+func (r *SubscriptionRequest) MapToDomain() *domWebhook.Subscription {
+	events := make([]domWebhook.EventType, len(r.Events))
+	for i, e := range r.Events {
+		events[i] = domWebhook.EventType(e)
+	}
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &domWebhook.Subscription{
+		URL:    r.URL,
+		Secret: r.Secret,
+		Events: events,
+		Active: active,
+	}
+}
+
+// MapSubscriptionToResponse не раскрывает секрет; после создания
+// его добавляет обработчик.
+func MapSubscriptionToResponse(s *domWebhook.Subscription) SubscriptionResponse {
+	events := make([]string, len(s.Events))
+	for i, e := range s.Events {
+		events[i] = string(e)
+	}
+	return SubscriptionResponse{
+		ID:        s.ID,
+		URL:       s.URL,
+		Events:    events,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func MapSubscriptionsToResponse(list []domWebhook.Subscription) []SubscriptionResponse {
+	res := make([]SubscriptionResponse, len(list))
+	for i := range list {
+		res[i] = MapSubscriptionToResponse(&list[i])
+	}
+	return res
+}
+
+func MapDeliveryToResponse(d *domWebhook.Delivery) DeliveryResponse {
+	res := DeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == domWebhook.DeliveryPending {
+		next := d.NextAttemptAt
+		res.NextAttemptAt = &next
+	}
+	return res
+}
+
+func MapDeliveriesToResponse(list []domWebhook.Delivery) []DeliveryResponse {
+	res := make([]DeliveryResponse, len(list))
+	for i := range list {
+		res[i] = MapDeliveryToResponse(&list[i])
+	}
+	return res
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"strings"
+
+	ve "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate *validator.Validate = validator.New()
+
+// SubscriptionRequest — подписка на события. Без secret при создании
+// секрет генерируется, при обновлении остаётся прежним. "*" в events —
+// все события.
+type SubscriptionRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048" example:"https://1c.example.com/hooks/store"`
+	Secret string   `json:"secret,omitempty" validate:"omitempty,min=16,max=256" example:"f3b1c2d4e5a6978812345678"`
+	Events []string `json:"events" validate:"required,min=1,dive,required" example:"product.updated,price.updated"`
+	Active *bool    `json:"active,omitempty" example:"true"`
+}
+
+func (r SubscriptionRequest) Validate() error {
+	var errs []ve.FieldError
+	if err := validate.Struct(r); err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			return err
+		}
+		for _, e := range err.(validator.ValidationErrors) {
+			switch {
+			case e.Field() == "URL":
+				errs = append(errs, ve.NewFieldError("url", i18n.MsgWebhookURL))
+			case e.Field() == "Secret":
+				errs = append(errs, ve.NewFieldError("secret", i18n.MsgWebhookSecret))
+			case e.Field() == "Events", strings.HasPrefix(e.Field(), "Events["):
+				errs = append(errs, ve.NewFieldError("events", i18n.MsgWebhookEvents))
+			default:
+				errs = append(errs, ve.NewFieldError(e.Field(), i18n.MsgInvalidField))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return ve.ValidationErrorResponse{Errors: errs}
+	}
+	return nil
+}
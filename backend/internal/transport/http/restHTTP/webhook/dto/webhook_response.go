@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type SubscriptionResponse struct {
+	ID  int64  `json:"id" example:"1"`
+	URL string `json:"url" example:"https://1c.example.com/hooks/store"`
+	// Secret возвращается только при создании подписки.
+	Secret    string    `json:"secret,omitempty" example:"f3b1c2d4e5a6978812345678"`
+	Events    []string  `json:"events" example:"product.updated,price.updated"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2025-02-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-02-20T12:00:00Z"`
+}
+
+type DeliveryResponse struct {
+	ID             int64      `json:"id" example:"10"`
+	EventID        int64      `json:"event_id" example:"42"`
+	EventType      string     `json:"event_type" example:"price.updated"`
+	Status         string     `json:"status" example:"pending"`
+	Attempts       int        `json:"attempts" example:"2"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty" example:"2025-02-20T12:01:00Z"`
+	LastStatusCode *int       `json:"last_status_code,omitempty" example:"503"`
+	LastError      *string    `json:"last_error,omitempty" example:"webhook: status 503: unavailable"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" example:"2025-02-20T12:00:01Z"`
+	CreatedAt      time.Time  `json:"created_at" example:"2025-02-20T12:00:00Z"`
+}
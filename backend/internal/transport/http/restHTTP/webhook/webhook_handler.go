@@ -0,0 +1,269 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/webhook/dto"
+	http_utils "github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+type WebhookService interface {
+	ListSubscriptions(ctx context.Context) ([]domWebhook.Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*domWebhook.Subscription, error)
+	CreateSubscription(ctx context.Context, s *domWebhook.Subscription) (*domWebhook.Subscription, error)
+	UpdateSubscription(ctx context.Context, s *domWebhook.Subscription) (*domWebhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domWebhook.Delivery, error)
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID int64) error
+}
+
+type Deps struct {
+	Log *slog.Logger
+	Srv WebhookService
+}
+
+func NewDeps(log *slog.Logger, srv WebhookService) (Deps, error) {
+	if srv == nil {
+		return Deps{}, errors.New("webhook: missing service")
+	}
+	if log == nil {
+		return Deps{}, errors.New("webhook: missing logger")
+	}
+	return Deps{Log: log.With("component", "restHTTP.webhook"), Srv: srv}, nil
+}
+
+type Handler struct {
+	srv WebhookService
+	log *slog.Logger
+}
+
+func New(d Deps) *Handler {
+	return &Handler{srv: d.Srv, log: d.Log}
+}
+
+// ListEventTypes godoc
+// @Summary      List webhook event types
+// @Description  Типы событий, на которые можно подписаться; "*" — все события
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} string
+// @Router       /api/admin/webhooks/events [get]
+func (h *Handler) ListEventTypes(w http.ResponseWriter, r *http.Request) {
+	http_utils.WriteJSON(w, http.StatusOK, domWebhook.EventTypes)
+}
+
+// ListSubscriptions godoc
+// @Summary      List webhook subscriptions
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array}  dto.SubscriptionResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks [get]
+func (h *Handler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.srv.ListSubscriptions(r.Context())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapSubscriptionsToResponse(subs))
+}
+
+// GetSubscription godoc
+// @Summary      Get webhook subscription
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Subscription ID"
+// @Success      200 {object} dto.SubscriptionResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks/{id} [get]
+func (h *Handler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r, "id", i18n.MsgInvalidSubscriptionID)
+	if !ok {
+		return
+	}
+	sub, err := h.srv.GetSubscription(r.Context(), id)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapSubscriptionToResponse(sub))
+}
+
+// CreateSubscription godoc
+// @Summary      Create webhook subscription
+// @Description  События отправляются POST-запросом с заголовками X-Webhook-Event, X-Webhook-Delivery,
+// @Description  X-Webhook-Timestamp и X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, "<timestamp>.<body>")).
+// @Description  Секрет возвращается только в ответе на создание; без secret в запросе он генерируется.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        data  body  dto.SubscriptionRequest  true  "Subscription"
+// @Success      201 {object} dto.SubscriptionResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks [post]
+func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "CreateSubscription")
+
+	req, ok := http_utils.DecodeAndValidate[dto.SubscriptionRequest](w, r, log)
+	if !ok {
+		return
+	}
+	sub, err := h.srv.CreateSubscription(r.Context(), req.MapToDomain())
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	resp := dto.MapSubscriptionToResponse(sub)
+	resp.Secret = sub.Secret
+	http_utils.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// UpdateSubscription godoc
+// @Summary      Update webhook subscription
+// @Description  Без secret в запросе секрет остаётся прежним
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  int                      true  "Subscription ID"
+// @Param        data  body  dto.SubscriptionRequest  true  "Subscription"
+// @Success      200 {object} dto.SubscriptionResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      422 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks/{id} [put]
+func (h *Handler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	log := h.log.With("op", "UpdateSubscription")
+
+	id, ok := h.parseID(w, r, "id", i18n.MsgInvalidSubscriptionID)
+	if !ok {
+		return
+	}
+	req, ok := http_utils.DecodeAndValidate[dto.SubscriptionRequest](w, r, log)
+	if !ok {
+		return
+	}
+	sub := req.MapToDomain()
+	sub.ID = id
+	saved, err := h.srv.UpdateSubscription(r.Context(), sub)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapSubscriptionToResponse(saved))
+}
+
+// DeleteSubscription godoc
+// @Summary      Delete webhook subscription
+// @Description  Удаляет подписку вместе с журналом доставок
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Subscription ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks/{id} [delete]
+func (h *Handler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r, "id", i18n.MsgInvalidSubscriptionID)
+	if !ok {
+		return
+	}
+	if err := h.srv.DeleteSubscription(r.Context(), id); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Журнал доставок подписки, новые сверху
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path   int  true   "Subscription ID"
+// @Param        limit  query  int  false  "Max items (default 50, max 500)"
+// @Success      200 {array}  dto.DeliveryResponse
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks/{id}/deliveries [get]
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r, "id", i18n.MsgInvalidSubscriptionID)
+	if !ok {
+		return
+	}
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			http_utils.WriteError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	list, err := h.srv.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	http_utils.WriteJSON(w, http.StatusOK, dto.MapDeliveriesToResponse(list))
+}
+
+// RetryDelivery godoc
+// @Summary      Retry webhook delivery
+// @Description  Ставит доставку в очередь заново со сброшенным счётчиком попыток
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Param        id           path  int  true  "Subscription ID"
+// @Param        deliveryID   path  int  true  "Delivery ID"
+// @Success      202 "Accepted"
+// @Failure      400 {object} http_utils.ErrorResponse
+// @Failure      404 {object} http_utils.ErrorResponse
+// @Failure      500 {object} http_utils.ErrorResponse
+// @Router       /api/admin/webhooks/{id}/deliveries/{deliveryID}/retry [post]
+func (h *Handler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r, "id", i18n.MsgInvalidSubscriptionID)
+	if !ok {
+		return
+	}
+	deliveryID, ok := h.parseID(w, r, "deliveryID", i18n.MsgInvalidDeliveryID)
+	if !ok {
+		return
+	}
+	if err := h.srv.RetryDelivery(r.Context(), id, deliveryID); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) parseID(w http.ResponseWriter, r *http.Request, param string, msg i18n.Message) (int64, bool) {
+	id, err := http_utils.IDFromURL(r, param)
+	if err != nil || id <= 0 {
+		http_utils.WriteLocalizedError(w, r, http.StatusBadRequest, msg)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	problems.Write(w, r, h.log, err)
+}
@@ -0,0 +1,151 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	domWebhook "github.com/Neimess/zorkin-store-project/internal/domain/webhook"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/webhook"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/webhook/dto"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/webhook/mocks"
+)
+
+type WebhookHandlerSuite struct {
+	suite.Suite
+	h   *webhook.Handler
+	svc *mocks.MockWebhookService
+}
+
+func (s *WebhookHandlerSuite) SetupTest() {
+	s.svc = mocks.NewMockWebhookService(s.T())
+	deps, err := webhook.NewDeps(slog.New(slog.DiscardHandler), s.svc)
+	s.Require().NoError(err)
+	s.h = webhook.New(deps)
+}
+
+func withChiParams(r *http.Request, kv ...string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	for i := 0; i+1 < len(kv); i += 2 {
+		chiCtx.URLParams.Add(kv[i], kv[i+1])
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func (s *WebhookHandlerSuite) TestCreateSubscription() {
+	s.Run("created, secret shown once", func() {
+		s.svc.EXPECT().CreateSubscription(mock.Anything, mock.MatchedBy(func(sub *domWebhook.Subscription) bool {
+			return sub.URL == "https://1c.example.com/hook" && sub.Active && sub.Secret == "" &&
+				len(sub.Events) == 2 && sub.Events[1] == domWebhook.EventPriceUpdated
+		})).RunAndReturn(func(_ context.Context, sub *domWebhook.Subscription) (*domWebhook.Subscription, error) {
+			sub.ID, sub.Secret = 1, "generated-secret-0123456789"
+			return sub, nil
+		}).Once()
+
+		body := `{"url":"https://1c.example.com/hook","events":["product.updated","price.updated"]}`
+		w := httptest.NewRecorder()
+		s.h.CreateSubscription(w, httptest.NewRequest(http.MethodPost, "/api/admin/webhooks", bytes.NewBufferString(body)))
+
+		s.Require().Equal(http.StatusCreated, w.Code)
+		var resp dto.SubscriptionResponse
+		s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.Equal(int64(1), resp.ID)
+		s.Equal("generated-secret-0123456789", resp.Secret)
+	})
+
+	s.Run("validation", func() {
+		body := `{"url":"not a url","events":[]}`
+		w := httptest.NewRecorder()
+		s.h.CreateSubscription(w, httptest.NewRequest(http.MethodPost, "/api/admin/webhooks", bytes.NewBufferString(body)))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	s.Run("unknown event", func() {
+		s.svc.EXPECT().CreateSubscription(mock.Anything, mock.Anything).
+			Return(nil, domWebhook.ErrUnknownEvent).Once()
+
+		body := `{"url":"https://1c.example.com/hook","events":["order.shipped"]}`
+		w := httptest.NewRecorder()
+		s.h.CreateSubscription(w, httptest.NewRequest(http.MethodPost, "/api/admin/webhooks", bytes.NewBufferString(body)))
+		s.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func (s *WebhookHandlerSuite) TestGetSubscription_HidesSecret() {
+	s.svc.EXPECT().GetSubscription(mock.Anything, int64(4)).Return(&domWebhook.Subscription{
+		ID: 4, URL: "https://example.com/hook", Secret: "0123456789abcdef",
+		Events: []domWebhook.EventType{domWebhook.EventAll}, Active: true,
+	}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r := withChiParams(httptest.NewRequest(http.MethodGet, "/api/admin/webhooks/4", nil), "id", "4")
+	s.h.GetSubscription(w, r)
+
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), "0123456789abcdef")
+}
+
+func (s *WebhookHandlerSuite) TestListDeliveries() {
+	s.Run("pending shows next attempt", func() {
+		next := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		code := 503
+		s.svc.EXPECT().ListDeliveries(mock.Anything, int64(2), 10).Return([]domWebhook.Delivery{
+			{ID: 7, EventID: 42, EventType: domWebhook.EventLeadCreated, Status: domWebhook.DeliveryPending,
+				Attempts: 2, NextAttemptAt: next, LastStatusCode: &code},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := withChiParams(httptest.NewRequest(http.MethodGet, "/api/admin/webhooks/2/deliveries?limit=10", nil), "id", "2")
+		s.h.ListDeliveries(w, r)
+
+		s.Require().Equal(http.StatusOK, w.Code)
+		var resp []dto.DeliveryResponse
+		s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.Require().Len(resp, 1)
+		s.Equal("lead.created", resp[0].EventType)
+		s.Equal(next, *resp[0].NextAttemptAt)
+		s.Equal(503, *resp[0].LastStatusCode)
+	})
+
+	s.Run("bad limit", func() {
+		w := httptest.NewRecorder()
+		r := withChiParams(httptest.NewRequest(http.MethodGet, "/api/admin/webhooks/2/deliveries?limit=x", nil), "id", "2")
+		s.h.ListDeliveries(w, r)
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (s *WebhookHandlerSuite) TestRetryDelivery() {
+	s.Run("accepted", func() {
+		s.svc.EXPECT().RetryDelivery(mock.Anything, int64(2), int64(7)).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		r := withChiParams(httptest.NewRequest(http.MethodPost, "/api/admin/webhooks/2/deliveries/7/retry", nil),
+			"id", "2", "deliveryID", "7")
+		s.h.RetryDelivery(w, r)
+		s.Equal(http.StatusAccepted, w.Code)
+	})
+
+	s.Run("not found", func() {
+		s.svc.EXPECT().RetryDelivery(mock.Anything, int64(2), int64(8)).Return(domWebhook.ErrDeliveryNotFound).Once()
+
+		w := httptest.NewRecorder()
+		r := withChiParams(httptest.NewRequest(http.MethodPost, "/api/admin/webhooks/2/deliveries/8/retry", nil),
+			"id", "2", "deliveryID", "8")
+		s.h.RetryDelivery(w, r)
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func TestWebhookHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerSuite))
+}
@@ -3,12 +3,16 @@ package route
 import (
 	"net/http"
 
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/problems"
 	"github.com/go-chi/chi/v5"
 )
 
 func registerBaseRoutes(r chi.Router) {
+	// /health оставлен для старых проверок; зависимости смотрит /readyz
 	r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
+	r.Get("/errors", problems.Catalog)
+	r.Get("/errors/{code}", problems.Describe)
 }
@@ -3,16 +3,21 @@ package route
 import (
 	attributeH "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/attribute"
 	categoryH "github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/category"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external"
+	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
-func registerCategoryWithAttrsAdminRoutes(r chi.Router, h *categoryH.Handler, ah *attributeH.Handler) {
+func registerCategoryWithAttrsAdminRoutes(r chi.Router, h *categoryH.Handler, ah *attributeH.Handler, eh *external.Handler) {
 	r.Route("/category", func(r chi.Router) {
 		r.Post("/", h.CreateCategory)
-		r.Put("/{id}", h.UpdateCategory)
-		r.Delete("/{id}", h.DeleteCategory)
+		r.With(customMiddlewares.RequireIfMatch).Put("/{id}", h.UpdateCategory)
+		r.With(customMiddlewares.RequireIfMatch).Patch("/{id}", h.PatchCategory)
+		r.With(customMiddlewares.RequireIfMatch).Delete("/{id}", h.DeleteCategory)
 		r.Get("/{id}", h.GetCategory)
 		r.Get("/", h.ListCategories)
+		r.Get("/by-external/{source}/{externalID}", eh.GetCategory)
+		r.With(customMiddlewares.RequireIfMatchOrCreate).Put("/by-external/{source}/{externalID}", eh.UpsertCategory)
 
 		r.Route("/{categoryID}/attribute", func(r chi.Router) {
 			r.Post("/", ah.CreateAttribute)
@@ -20,7 +25,7 @@ func registerCategoryWithAttrsAdminRoutes(r chi.Router, h *categoryH.Handler, ah
 			r.Get("/{id}", ah.GetAttribute)
 			r.Put("/{id}", ah.UpdateAttribute)
 			r.Delete("/{id}", ah.DeleteAttribute)
-			r.Post("/batch", ah.CreateAttribute)
+			r.Post("/batch", ah.Batch)
 		})
 	})
 
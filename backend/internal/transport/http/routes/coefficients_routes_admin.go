@@ -2,6 +2,7 @@ package route
 
 import (
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/coefficients"
+	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -10,7 +11,8 @@ func registerCoefficientsAdminRoutes(r chi.Router, h *coefficients.Handler) {
 		r.Post("/", h.Create)
 		r.Get("/", h.List)
 		r.Get("/{id}", h.Get)
-		r.Put("/{id}", h.Update)
-		r.Delete("/{id}", h.Delete)
+		r.With(customMiddlewares.RequireIfMatch).Put("/{id}", h.Update)
+		r.With(customMiddlewares.RequireIfMatch).Patch("/{id}", h.Patch)
+		r.With(customMiddlewares.RequireIfMatch).Delete("/{id}", h.Delete)
 	})
 }
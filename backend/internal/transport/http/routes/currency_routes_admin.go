@@ -0,0 +1,19 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerCurrencyAdminRoutes(r chi.Router, h *currency.Handler) {
+	r.Route("/currencies", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Put("/{currency}", h.PutRate)
+		r.Delete("/{currency}", h.DeleteRate)
+	})
+	r.Route("/prices/{entity}/{id}", func(r chi.Router) {
+		r.Get("/", h.ListOverrides)
+		r.Put("/{currency}", h.PutOverride)
+		r.Delete("/{currency}", h.DeleteOverride)
+	})
+}
@@ -0,0 +1,10 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/currency"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerCurrencyPublicRoutes(r chi.Router, h *currency.Handler) {
+	r.Get("/currencies", h.List)
+}
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerDiscountAdminRoutes(r chi.Router, h *discount.Handler) {
+	r.Route("/discounts", func(r chi.Router) {
+		r.Get("/", h.ListRules)
+		r.Post("/", h.CreateRule)
+		r.Get("/{id}", h.GetRule)
+		r.Put("/{id}", h.UpdateRule)
+		r.Delete("/{id}", h.DeleteRule)
+		r.Get("/{id}/promo-codes", h.ListPromoCodes)
+		r.Post("/{id}/promo-codes", h.CreatePromoCode)
+	})
+	r.Delete("/promo-codes/{code}", h.DeletePromoCode)
+}
@@ -0,0 +1,10 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/discount"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerDiscountPublicRoutes(r chi.Router, h *discount.Handler) {
+	r.Get("/promo-codes/{code}", h.CheckPromoCode)
+}
@@ -0,0 +1,11 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/exchange"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerExchangeAdminRoutes(r chi.Router, h *exchange.Handler, bodyLimit middlewareFunc) {
+	r.Get("/1c/exchange", h.Exchange)
+	r.With(bodyLimit).Post("/1c/exchange", h.Exchange)
+}
@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerExternalAdminRoutes(r chi.Router, h *external.Handler) {
+	r.Route("/external-ids", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Put("/{source}/{entity}/{externalID}", h.Bind)
+		r.Delete("/{source}/{entity}/{externalID}", h.Unbind)
+	})
+}
@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/lead"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerLeadAdminRoutes(r chi.Router, h *lead.Handler) {
+	r.Route("/leads", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}/status", h.UpdateStatus)
+	})
+}
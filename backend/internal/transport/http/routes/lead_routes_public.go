@@ -0,0 +1,12 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/lead"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerLeadPublicRoutes(r chi.Router, h *lead.Handler, limiter, bodyLimit middlewareFunc) {
+	r.Route("/leads", func(r chi.Router) {
+		r.With(limiter, bodyLimit).Post("/", h.Create)
+	})
+}
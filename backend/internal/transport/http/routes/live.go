@@ -0,0 +1,70 @@
+package route
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	"github.com/go-chi/cors"
+)
+
+// liveSettings — настройки маршрутов, которые меняются при перезагрузке
+// конфига без пересборки роутера: chi не умеет снимать маршруты, поэтому
+// swagger и pprof регистрируются заранее и закрываются переключателем.
+type liveSettings struct {
+	// cors == nil — CORS выключен
+	cors    atomic.Pointer[cors.Cors]
+	swagger atomic.Bool
+	pprof   atomic.Bool
+}
+
+func newLiveSettings(cfg *config.Config) *liveSettings {
+	s := &liveSettings{}
+	s.apply(cfg)
+	return s
+}
+
+func (s *liveSettings) apply(cfg *config.Config) {
+	var c *cors.Cors
+	if opts := cfg.HTTPServer.CORS; len(opts.AllowedOrigins) > 0 {
+		c = cors.New(corsOptions(opts))
+	}
+	s.cors.Store(c)
+	s.swagger.Store(cfg.Swagger.Enabled)
+	s.pprof.Store(cfg.HTTPServer.EnablePProf)
+}
+
+func corsOptions(cfg config.CORS) cors.Options {
+	return cors.Options{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   cfg.AllowedMethods,
+		AllowedHeaders:   cfg.AllowedHeaders,
+		ExposedHeaders:   cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	}
+}
+
+// corsHandler отвечает на CORS по действующим настройкам.
+func (s *liveSettings) corsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c := s.cors.Load(); c != nil {
+			c.Handler(next).ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// gate отвечает 404, пока enabled выключен: для клиента маршрута нет.
+func gate(enabled *atomic.Bool) middlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !enabled.Load() {
+				http.NotFound(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,13 +2,17 @@ package route
 
 import (
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/preset"
+	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
 func registerPresetAdminRoutes(r chi.Router, h *preset.Handler) {
 	r.Route("/presets", func(r chi.Router) {
 		r.Post("/", h.Create)
-		r.Delete("/{id}", h.Delete)
-		r.Put("/{id}", h.Update)
+		r.With(customMiddlewares.RequireIfMatch).Delete("/{id}", h.Delete)
+		r.With(customMiddlewares.RequireIfMatch).Put("/{id}", h.Update)
+		r.With(customMiddlewares.RequireIfMatch).Patch("/{id}", h.Patch)
+		r.Post("/{id}/clone", h.Clone)
+		r.Post("/{id}/instantiate", h.Instantiate)
 	})
 }
@@ -5,10 +5,11 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func registerPresetPublicRoutes(r chi.Router, h *preset.Handler) {
+func registerPresetPublicRoutes(r chi.Router, h *preset.Handler, bodyLimit middlewareFunc) {
 	r.Route("/presets", func(r chi.Router) {
 		r.Get("/", h.ListShort)
 		r.Get("/detailed", h.ListDetailed)
 		r.Get("/{id}", h.Get)
+		r.With(bodyLimit).Post("/{id}/configure", h.Configure)
 	})
 }
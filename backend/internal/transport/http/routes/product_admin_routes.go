@@ -1,16 +1,28 @@
 package route
 
 import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product"
+	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
-func registerProductAdminRoutes(r chi.Router, h *product.Handler) {
+func registerProductAdminRoutes(r chi.Router, h *product.Handler, eh *external.Handler) {
 	r.Route("/product", func(r chi.Router) {
 		r.Post("/", h.Create)
-		r.Put("/{id}", h.Update)
-		r.Delete("/{id}", h.Delete)
+		r.Post("/batch", h.Batch)
+		r.With(customMiddlewares.RequireIfMatch).Put("/{id}", h.Update)
+		r.With(customMiddlewares.RequireIfMatch).Patch("/{id}", h.Patch)
+		r.With(customMiddlewares.RequireIfMatch).Delete("/{id}", h.Delete)
 		r.Get("/category/{id}", h.ListByCategory)
 		r.Get("/{id}", h.GetDetailed)
+		r.Get("/by-external/{source}/{externalID}", eh.GetProduct)
+		r.With(customMiddlewares.RequireIfMatchOrCreate).Put("/by-external/{source}/{externalID}", eh.UpsertProduct)
+		r.Route("/{id}/relations", func(r chi.Router) {
+			r.Get("/", h.ListRelations)
+			r.Post("/", h.CreateRelation)
+			r.Put("/{relationID}", h.UpdateRelation)
+			r.Delete("/{relationID}", h.DeleteRelation)
+		})
 	})
 }
@@ -2,12 +2,17 @@ package route
 
 import (
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/product"
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review"
 	"github.com/go-chi/chi/v5"
 )
 
-func registerProductPublicRoutes(r chi.Router, h *product.Handler) {
+func registerProductPublicRoutes(r chi.Router, h *product.Handler, rh *review.Handler, search, bodyLimit middlewareFunc) {
 	r.Route("/product", func(r chi.Router) {
-		r.Get("/category/{id}", h.ListByCategory)
+		// сортировка и фильтры списка дороже карточки товара — отдельный лимит
+		r.With(search).Get("/category/{id}", h.ListByCategory)
 		r.Get("/{id}", h.GetDetailed)
+		r.Get("/{id}/frequently-bought-together", h.FrequentlyBoughtTogether)
+		r.Get("/{id}/reviews", rh.ListByProduct)
+		r.With(bodyLimit).Post("/{id}/reviews", rh.Create)
 	})
 }
@@ -0,0 +1,69 @@
+package route
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/internal/config"
+	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
+	"github.com/Neimess/zorkin-store-project/pkg/ratelimit"
+	"github.com/go-chi/chi/v5"
+)
+
+type middlewareFunc = func(http.Handler) http.Handler
+
+// rateLimiters — middleware политик из rate_limit. При выключенных лимитах
+// или без store все они пропускают запросы.
+type rateLimiters struct {
+	public, search, leads, auth, admin middlewareFunc
+	// apply меняет политики при перезагрузке конфига
+	apply func(config.RateLimit)
+}
+
+func newRateLimiters(deps Deps) rateLimiters {
+	log := deps.logger.With("component", "ratelimit")
+	limiters := map[string]*customMiddlewares.RateLimiter{}
+	limiter := func(name string) *customMiddlewares.RateLimiter {
+		l := customMiddlewares.NewRateLimiter(name, ratelimit.Limit{}, deps.rateStore, log)
+		limiters[name] = l
+		return l
+	}
+	res := rateLimiters{
+		public: limiter("public").Handler,
+		search: limiter("search").Handler,
+		leads:  limiter("leads").Handler,
+		// без store вход в админку не защищён от перебора кода — лучше 503
+		auth:  limiter("auth").FailClosed().Handler,
+		admin: limiter("admin").Handler,
+	}
+	res.apply = func(cfg config.RateLimit) {
+		policies := map[string]config.RatePolicy{
+			"public": cfg.Public, "search": cfg.Search, "leads": cfg.Leads, "auth": cfg.Auth, "admin": cfg.Admin,
+		}
+		for name, l := range limiters {
+			var limit ratelimit.Limit
+			if p := policies[name]; cfg.Enabled {
+				limit = ratelimit.Limit{Requests: p.Requests, Per: p.Per, Burst: p.Burst}
+			}
+			l.SetLimit(limit)
+		}
+	}
+	res.apply(deps.config.RateLimit)
+	return res
+}
+
+// adminCode пропускает только запросы с верным кодом входа в {code}.
+// Неверный код — 404, как и раньше, когда код был частью маршрута, но
+// попытка уже списана лимитером auth.
+func adminCode(code string) middlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := chi.URLParam(r, "code")
+			if code == "" || subtle.ConstantTimeCompare([]byte(got), []byte(code)) != 1 {
+				http.NotFound(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
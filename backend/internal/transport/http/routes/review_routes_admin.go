@@ -0,0 +1,15 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/review"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerReviewAdminRoutes(r chi.Router, h *review.Handler) {
+	r.Route("/reviews", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/{id}/approve", h.Approve)
+		r.Post("/{id}/reject", h.Reject)
+		r.Delete("/{id}", h.Delete)
+	})
+}
@@ -10,93 +10,198 @@ import (
 	_ "github.com/Neimess/zorkin-store-project/docs"
 	"github.com/Neimess/zorkin-store-project/internal/config"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP"
+	"github.com/Neimess/zorkin-store-project/pkg/health"
 	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
+	"github.com/Neimess/zorkin-store-project/pkg/ratelimit"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/go-chi/httplog/v3"
 )
 
 type Deps struct {
-	config   *config.Config
-	logger   *slog.Logger
-	router   chi.Router
-	handlers *restHTTP.Handlers
+	config *config.Config
+	logger *slog.Logger
+	router chi.Router
+	// adminRouter — роутер отдельного админского адреса; nil — админский
+	// API и pprof на router
+	adminRouter chi.Router
+	handlers    *restHTTP.Handlers
+	metrics     *telemetry.Metrics
+	probe       *health.Probe
+	// rateStore == nil — лимиты запросов выключены
+	rateStore ratelimit.Store
+	// reloader == nil — конфиг не перечитывается
+	reloader *config.Reloader
 }
 
-func NewDeps(cfg *config.Config, logger *slog.Logger, router chi.Router, handlers *restHTTP.Handlers) (Deps, error) {
-	if cfg == nil || logger == nil || handlers == nil {
+func NewDeps(cfg *config.Config, logger *slog.Logger, router, adminRouter chi.Router, handlers *restHTTP.Handlers, metrics *telemetry.Metrics, probe *health.Probe, rateStore ratelimit.Store, reloader *config.Reloader) (Deps, error) {
+	if cfg == nil || logger == nil || handlers == nil || probe == nil {
 		return Deps{}, fmt.Errorf("invalid dependencies")
 	}
+	if err := cfg.HTTPServer.CORS.Validate(); err != nil {
+		return Deps{}, err
+	}
 	return Deps{
-		config:   cfg,
-		logger:   logger,
-		router:   router,
-		handlers: handlers,
+		config:      cfg,
+		logger:      logger,
+		router:      router,
+		adminRouter: adminRouter,
+		handlers:    handlers,
+		metrics:     metrics,
+		probe:       probe,
+		rateStore:   rateStore,
+		reloader:    reloader,
 	}, nil
 }
 
+// NewRouter вешает маршруты на deps.router, а админский API и pprof — на
+// deps.adminRouter, если он задан. CORS, лимиты запросов и переключатели
+// swagger и pprof следуют за перезагрузками конфига.
 func NewRouter(deps Deps) chi.Router {
 	r := deps.router
-
-	// ── global middleware ────────────────────────────────────────────────
-	r.Use(middleware.RequestID, middleware.RealIP, middleware.Recoverer)
-	r.Use(middleware.Timeout(30*time.Second), middleware.Compress(5))
-	r.Use(httplog.RequestLogger(deps.logger.With("component", "http"), &httplog.Options{
-		RecoverPanics:      true,
-		LogRequestHeaders:  []string{"Origin", "User-Agent", "Accept", "Content-Type", "X-Request-ID"},
-		LogResponseHeaders: []string{"Content-Type", "Content-Length", "X-Request-ID"},
-		LogRequestBody: func(req *http.Request) bool {
-			return req.Method == http.MethodPost && strings.HasPrefix(req.URL.Path, "/debug/")
-		},
-	}))
+	httpCfg := deps.config.HTTPServer
 	isDev := deps.config.Env == config.EnvLocal || deps.config.Env == config.EnvDev
-	if isDev && deps.config.HTTPServer.EnableCORS {
-		r.Use(cors.Handler(cors.Options{
-			AllowedOrigins:   []string{"*"},
-			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-			AllowCredentials: true,
-			MaxAge:           300,
-		}))
+	limits := newRateLimiters(deps)
+	live := newLiveSettings(deps.config)
+	if deps.reloader != nil {
+		deps.reloader.OnReload(func(cfg *config.Config) {
+			live.apply(cfg)
+			limits.apply(cfg.RateLimit)
+		})
 	}
 
-	// ── profiler & swagger ───────────────────────────────────────────────
-	if isDev && deps.config.HTTPServer.EnablePProf {
-		r.Mount("/debug/pprof", profiler(deps.config.Env))
+	useGlobalMiddleware(r, deps, live)
+
+	// ── probes, metrics, profiler & swagger ──────────────────────────────
+	r.Get("/livez", deps.probe.LiveHandler)
+	r.Get("/readyz", deps.probe.ReadyHandler)
+	// при отдельном админском адресе метрики и профилировщик (в любом
+	// окружении) переезжают туда: снаружи этот адрес не виден. Иначе /metrics
+	// на основном адресе, а наружу не отдаётся — закрыт в deployment/nginx
+	admin := deps.adminRouter
+	metricsRouter := r
+	if admin != nil {
+		useGlobalMiddleware(admin, deps, live)
+		metricsRouter = admin
+	}
+	if deps.metrics != nil {
+		metricsRouter.Handle(deps.config.Telemetry.MetricsPath, deps.metrics.Handler())
+	}
+	if admin != nil {
+		admin.With(gate(&live.pprof)).Mount("/debug/pprof", profiler())
+		admin.Route("/api/admin", func(r chi.Router) {
+			registerAdminZone(r, deps, limits)
+		})
+	} else if isDev {
+		r.With(gate(&live.pprof)).Mount("/debug/pprof", profiler())
 	}
 
 	// ── public API ───────────────────────────────────────────────────────
+	forms := customMiddlewares.BodyLimit(httpCfg.BodyLimit.Forms)
 	r.Route("/api", func(r chi.Router) {
-		if isDev && deps.config.Swagger.Enabled {
-			registerSwaggerRoutes(r)
+		if isDev {
+			r.Group(func(r chi.Router) {
+				r.Use(gate(&live.swagger))
+				registerSwaggerRoutes(r, customMiddlewares.ContentSecurityPolicy(httpCfg.Security.SwaggerCSP))
+			})
 		}
 		registerBaseRoutes(r)
-		registerProductPublicRoutes(r, deps.handlers.ProductHandler)
-		registerCategoryWithAttrsPublicRoutes(r, deps.handlers.CategoryHandler, deps.handlers.AttributeHandler)
-		registerPresetPublicRoutes(r, deps.handlers.PresetHandler)
-		registerServicePublicRoutes(r, deps.handlers.ServiceHandler)
-		// ── admin zone ──────────────────────────────────────────────────
-		r.Route("/admin", func(r chi.Router) {
-			// one‑time login link
-			r.Get(fmt.Sprintf("/auth/%s", deps.config.AdminCode), deps.handlers.AuthHandler.Login)
+		// публичный каталог отдаётся на языке клиента, в валюте из ?currency=
+		// и со скидками (промокод — ?promo=); админка работает с контентом
+		// по умолчанию и ценами в базовой валюте без скидок, переводы, курсы
+		// и скидки правятся через /admin/translations, /admin/currencies и /admin/discounts
+		r.Group(func(r chi.Router) {
+			r.Use(limits.public, customMiddlewares.Locale,
+				deps.handlers.CurrencyHandler.ResolveCurrency,
+				deps.handlers.DiscountHandler.ResolvePricing)
 
-			// JWT‑protected block
-			cfg := deps.config.JWTConfig
-			jwtMW, _ := customMiddlewares.NewJWTMiddleware(customMiddlewares.JWTCfg{
-				Secret: []byte(cfg.JWTSecret), Algorithm: cfg.Algorithm, Issuer: cfg.Issuer, Audience: cfg.Audience,
-			})
-			r.Group(func(r chi.Router) {
-				r.Use(jwtMW.CheckJWT)
-
-				registerProductAdminRoutes(r, deps.handlers.ProductHandler)
-				registerCategoryWithAttrsAdminRoutes(r, deps.handlers.CategoryHandler, deps.handlers.AttributeHandler)
-				registerPresetAdminRoutes(r, deps.handlers.PresetHandler)
-				registerCoefficientsAdminRoutes(r, deps.handlers.CoefficientsHandler)
-				registerServiceAdminRoutes(r, deps.handlers.ServiceHandler)
-			})
+			registerProductPublicRoutes(r, deps.handlers.ProductHandler, deps.handlers.ReviewHandler, limits.search, forms)
+			registerCategoryWithAttrsPublicRoutes(r, deps.handlers.CategoryHandler, deps.handlers.AttributeHandler)
+			registerPresetPublicRoutes(r, deps.handlers.PresetHandler, forms)
+			registerServicePublicRoutes(r, deps.handlers.ServiceHandler)
+			registerCurrencyPublicRoutes(r, deps.handlers.CurrencyHandler)
+			registerDiscountPublicRoutes(r, deps.handlers.DiscountHandler)
+			registerLeadPublicRoutes(r, deps.handlers.LeadHandler, limits.leads, forms)
 		})
+		if admin == nil {
+			r.Route("/admin", func(r chi.Router) {
+				registerAdminZone(r, deps, limits)
+			})
+		}
 	})
 
 	return r
 }
+
+// useGlobalMiddleware — middleware, общие для всех адресов сервера.
+func useGlobalMiddleware(r chi.Router, deps Deps, live *liveSettings) {
+	httpCfg := deps.config.HTTPServer
+	// адреса уже проверены config.Validate
+	trusted, _ := httpCfg.TrustedPrefixes()
+	r.Use(middleware.RequestID, customMiddlewares.RequestIDHeader, customMiddlewares.RealIP(trusted),
+		customMiddlewares.Telemetry(deps.metrics), middleware.Recoverer)
+	r.Use(middleware.Timeout(30*time.Second), middleware.Compress(5))
+	r.Use(httplog.RequestLogger(deps.logger.With("component", "http"), &httplog.Options{
+		RecoverPanics:      true,
+		LogRequestHeaders:  []string{"Origin", "User-Agent", "Accept", "Content-Type", "X-Request-ID"},
+		LogResponseHeaders: []string{"Content-Type", "Content-Length", "X-Request-ID"},
+		LogRequestBody: func(req *http.Request) bool {
+			return req.Method == http.MethodPost && strings.HasPrefix(req.URL.Path, "/debug/")
+		},
+		// успешные пробы приходят каждые несколько секунд и только засоряют лог
+		Skip: func(req *http.Request, respStatus int) bool {
+			return respStatus < http.StatusBadRequest && (req.URL.Path == "/livez" || req.URL.Path == "/readyz")
+		},
+	}))
+	r.Use(live.corsHandler)
+	r.Use(customMiddlewares.SecurityHeaders(customMiddlewares.SecurityOptions{
+		HSTSMaxAge:            httpCfg.Security.HSTSMaxAge,
+		HSTSIncludeSubdomains: httpCfg.Security.HSTSIncludeSubdomains,
+		ContentSecurityPolicy: httpCfg.Security.ContentSecurityPolicy,
+		ReferrerPolicy:        httpCfg.Security.ReferrerPolicy,
+	}))
+	r.Use(customMiddlewares.BodyLimit(httpCfg.BodyLimit.Default))
+}
+
+// registerAdminZone — маршруты /api/admin.
+func registerAdminZone(r chi.Router, deps Deps, limits rateLimiters) {
+	// one‑time login link; код подбирают перебором, поэтому попытки
+	// ограничены лимитером auth ещё до сравнения кода
+	r.With(limits.auth, adminCode(deps.config.AdminCode)).Get("/auth/{code}", deps.handlers.AuthHandler.Login)
+	// обмен с 1С: своя авторизация (Basic + cookie сессии), JWT 1С не умеет
+	// 1С присылает файлы до exchange_1c.file_limit, их размер проверяет сам обмен
+	registerExchangeAdminRoutes(r, deps.handlers.ExchangeHandler, customMiddlewares.BodyLimit(0))
+
+	// JWT‑protected block
+	cfg := deps.config.JWTConfig
+	jwtMW, _ := customMiddlewares.NewJWTMiddleware(customMiddlewares.JWTCfg{
+		Secret: []byte(cfg.JWTSecret), Algorithm: cfg.Algorithm, Issuer: cfg.Issuer, Audience: cfg.Audience,
+	})
+	idempotent := deps.handlers.IdempotencyHandler.Idempotent
+	r.Group(func(r chi.Router) {
+		// POST с Idempotency-Key можно безопасно повторять: повтор получает сохранённый ответ.
+		// Лимит admin стоит после CheckJWT и считается по subject токена
+		r.Use(jwtMW.CheckJWT, limits.admin, idempotent)
+
+		registerProductAdminRoutes(r, deps.handlers.ProductHandler, deps.handlers.ExternalHandler)
+		registerCategoryWithAttrsAdminRoutes(r, deps.handlers.CategoryHandler, deps.handlers.AttributeHandler, deps.handlers.ExternalHandler)
+		registerPresetAdminRoutes(r, deps.handlers.PresetHandler)
+		registerCoefficientsAdminRoutes(r, deps.handlers.CoefficientsHandler)
+		registerServiceAdminRoutes(r, deps.handlers.ServiceHandler, deps.handlers.ExternalHandler)
+		registerReviewAdminRoutes(r, deps.handlers.ReviewHandler)
+		registerLeadAdminRoutes(r, deps.handlers.LeadHandler)
+		registerTranslationAdminRoutes(r, deps.handlers.TranslationHandler)
+		registerCurrencyAdminRoutes(r, deps.handlers.CurrencyHandler)
+		registerDiscountAdminRoutes(r, deps.handlers.DiscountHandler)
+		registerWebhookAdminRoutes(r, deps.handlers.WebhookHandler)
+		registerExternalAdminRoutes(r, deps.handlers.ExternalHandler)
+	})
+	// Idempotent читает тело до обработчика, поэтому лимит снимка ставится
+	// раньше него, а не на маршруте, как у остальных
+	r.Group(func(r chi.Router) {
+		r.Use(jwtMW.CheckJWT, limits.admin,
+			customMiddlewares.BodyLimit(deps.config.HTTPServer.BodyLimit.Snapshot), idempotent)
+		registerSnapshotAdminRoutes(r, deps.handlers.SnapshotHandler)
+	})
+}
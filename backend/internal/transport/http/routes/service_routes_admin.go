@@ -1,16 +1,22 @@
 package route
 
 import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/external"
 	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/service"
+	customMiddlewares "github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
-func registerServiceAdminRoutes(r chi.Router, h *service.Handler) {
+func registerServiceAdminRoutes(r chi.Router, h *service.Handler, eh *external.Handler) {
 	r.Route("/services", func(r chi.Router) {
 		r.Post("/", h.Create)
+		r.Post("/batch", h.Batch)
 		r.Get("/", h.List)
 		r.Get("/{id}", h.Get)
-		r.Put("/{id}", h.Update)
-		r.Delete("/{id}", h.Delete)
+		r.With(customMiddlewares.RequireIfMatch).Put("/{id}", h.Update)
+		r.With(customMiddlewares.RequireIfMatch).Patch("/{id}", h.Patch)
+		r.With(customMiddlewares.RequireIfMatch).Delete("/{id}", h.Delete)
+		r.Get("/by-external/{source}/{externalID}", eh.GetService)
+		r.With(customMiddlewares.RequireIfMatchOrCreate).Put("/by-external/{source}/{externalID}", eh.UpsertService)
 	})
 }
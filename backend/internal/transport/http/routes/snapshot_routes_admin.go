@@ -0,0 +1,11 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/snapshot"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerSnapshotAdminRoutes(r chi.Router, h *snapshot.Handler) {
+	r.Get("/snapshot", h.Export)
+	r.Post("/snapshot/restore", h.Restore)
+}
@@ -7,9 +7,10 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func registerSwaggerRoutes(r chi.Router) {
+func registerSwaggerRoutes(r chi.Router, csp middlewareFunc) {
 	r.Get("/swagger", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/swagger/index.html", http.StatusMovedPermanently)
 	})
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
+	// Swagger UI — HTML со своими скриптами, CSP API ему не подходит
+	r.With(csp).Get("/swagger/*", httpSwagger.WrapHandler)
 }
@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/translation"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerTranslationAdminRoutes(r chi.Router, h *translation.Handler) {
+	r.Route("/translations/{entity}/{id}", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Put("/{locale}", h.Put)
+		r.Delete("/{locale}", h.Delete)
+	})
+}
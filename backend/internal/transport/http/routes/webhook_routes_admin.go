@@ -0,0 +1,19 @@
+package route
+
+import (
+	"github.com/Neimess/zorkin-store-project/internal/transport/http/restHTTP/webhook"
+	"github.com/go-chi/chi/v5"
+)
+
+func registerWebhookAdminRoutes(r chi.Router, h *webhook.Handler) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Get("/", h.ListSubscriptions)
+		r.Post("/", h.CreateSubscription)
+		r.Get("/events", h.ListEventTypes)
+		r.Get("/{id}", h.GetSubscription)
+		r.Put("/{id}", h.UpdateSubscription)
+		r.Delete("/{id}", h.DeleteSubscription)
+		r.Get("/{id}/deliveries", h.ListDeliveries)
+		r.Post("/{id}/deliveries/{deliveryID}/retry", h.RetryDelivery)
+	})
+}
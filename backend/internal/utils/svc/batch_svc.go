@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/Neimess/zorkin-store-project/internal/domain/batch"
+	der "github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+)
+
+// Transactor выполняет fn в одной транзакции; репозитории, вызванные
+// с переданным в fn контекстом, работают внутри неё.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// RunBatch применяет операции пакета по порядку через apply.
+//
+// Без atomic операции независимы: ошибка одной не мешает остальным.
+// Операция с Version меняет сущность, только если её версия совпала,
+// иначе получает ErrVersionConflict. Для сущностей с версиями есть
+// RunVersionedBatch.
+//
+// С atomic все операции идут в одной транзакции и на первой ошибке она
+// откатывается — выполненные операции получают StatusRolledBack, оставшиеся
+// StatusSkipped. Ошибка возвращается только если не удалось открыть
+// или зафиксировать саму транзакцию.
+func RunBatch[T any](
+	ctx context.Context,
+	tr Transactor,
+	items []batch.Item[T],
+	atomic bool,
+	apply func(ctx context.Context, it batch.Item[T]) (T, error),
+) ([]batch.Result[T], error) {
+	return runBatch(ctx, tr, items, atomic, false, apply)
+}
+
+// RunVersionedBatch — RunBatch для сущностей с версиями: update и delete без
+// Version получают ErrPreconditionRequired, как одиночный запрос без If-Match.
+func RunVersionedBatch[T any](
+	ctx context.Context,
+	tr Transactor,
+	items []batch.Item[T],
+	atomic bool,
+	apply func(ctx context.Context, it batch.Item[T]) (T, error),
+) ([]batch.Result[T], error) {
+	return runBatch(ctx, tr, items, atomic, true, apply)
+}
+
+func runBatch[T any](
+	ctx context.Context,
+	tr Transactor,
+	items []batch.Item[T],
+	atomic, versioned bool,
+	apply func(ctx context.Context, it batch.Item[T]) (T, error),
+) ([]batch.Result[T], error) {
+	if err := batch.Validate(items); err != nil {
+		return nil, err
+	}
+
+	results := make([]batch.Result[T], len(items))
+	invalid := false
+	for i, it := range items {
+		results[i] = batch.Result[T]{Op: it.Op, ID: it.ID, Status: batch.StatusSkipped}
+		err := it.Validate()
+		if err == nil && versioned && it.Op != batch.OpCreate && it.Version == 0 {
+			err = der.ErrPreconditionRequired
+		}
+		if err != nil {
+			results[i].Status, results[i].Err = batch.StatusFailed, err
+			invalid = true
+		}
+	}
+
+	run := func(ctx context.Context, i int) error {
+		// version операции проверяется репозиторием так же, как If-Match
+		if v := items[i].Version; v > 0 {
+			ctx = database.WithExpectedVersions(ctx, []int64{v})
+		}
+		v, err := apply(ctx, items[i])
+		if err != nil {
+			results[i].Status, results[i].Err = batch.StatusFailed, err
+			return err
+		}
+		results[i].Status, results[i].Value = batch.StatusOK, v
+		return nil
+	}
+
+	if !atomic {
+		for i := range items {
+			if results[i].Status != batch.StatusFailed {
+				_ = run(ctx, i)
+			}
+		}
+		return results, nil
+	}
+
+	// атомарный пакет с невалидной операцией даже не начинаем
+	if invalid {
+		return results, nil
+	}
+
+	failed := -1
+	err := tr.InTx(ctx, func(ctx context.Context) error {
+		for i := range items {
+			if err := run(ctx, i); err != nil {
+				failed = i
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return results, nil
+	}
+	if failed < 0 {
+		return nil, err
+	}
+	var zero T
+	for i := range results[:failed] {
+		results[i].Status, results[i].Value = batch.StatusRolledBack, zero
+	}
+	return results, nil
+}
@@ -0,0 +1,6 @@
+ALTER TABLE preset_items
+DROP COLUMN IF EXISTS quantity_formula,
+DROP COLUMN IF EXISTS quantity;
+
+ALTER TABLE presets
+DROP COLUMN IF EXISTS is_template;
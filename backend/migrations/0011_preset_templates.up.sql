@@ -0,0 +1,6 @@
+ALTER TABLE presets
+ADD COLUMN IF NOT EXISTS is_template BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE preset_items
+ADD COLUMN IF NOT EXISTS quantity NUMERIC(10, 2) NOT NULL DEFAULT 1 CHECK (quantity > 0),
+ADD COLUMN IF NOT EXISTS quantity_formula TEXT;
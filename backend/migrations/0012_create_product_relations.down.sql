@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_product_relations_symmetric;
+DROP INDEX IF EXISTS idx_product_relations_related_product_id;
+DROP TABLE IF EXISTS product_relations;
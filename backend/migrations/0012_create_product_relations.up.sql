@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS product_relations (
+    relation_id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(product_id) ON DELETE CASCADE,
+    related_product_id BIGINT NOT NULL REFERENCES products(product_id) ON DELETE CASCADE,
+    relation_type VARCHAR(32) NOT NULL CHECK (
+        relation_type IN ('accessory', 'alternative', 'compatible_with', 'required')
+    ),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (product_id <> related_product_id),
+    UNIQUE (product_id, related_product_id, relation_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_relations_related_product_id
+ON product_relations (related_product_id);
+
+-- Симметричные связи (alternative, compatible_with) не должны дублироваться в обратную сторону.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_product_relations_symmetric
+ON product_relations (LEAST(product_id, related_product_id), GREATEST(product_id, related_product_id), relation_type)
+WHERE relation_type IN ('alternative', 'compatible_with');
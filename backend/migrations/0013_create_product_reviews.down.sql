@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_product_reviews_status_created_at;
+DROP INDEX IF EXISTS idx_product_reviews_product_id_status;
+DROP TABLE IF EXISTS product_reviews;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS product_reviews (
+    review_id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(product_id) ON DELETE CASCADE,
+    author_name VARCHAR(100) NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT,
+    photos TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (
+        status IN ('pending', 'approved', 'rejected')
+    ),
+    reject_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    moderated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_reviews_product_id_status
+ON product_reviews (product_id, status);
+
+CREATE INDEX IF NOT EXISTS idx_product_reviews_status_created_at
+ON product_reviews (status, created_at);
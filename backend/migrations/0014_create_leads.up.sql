@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS leads (
+    lead_id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL CHECK (kind IN ('callback', 'consultation')),
+    name VARCHAR(100) NOT NULL,
+    phone VARCHAR(32) NOT NULL,
+    email VARCHAR(255),
+    message TEXT,
+    preset_id BIGINT REFERENCES presets(preset_id) ON DELETE SET NULL,
+    product_id BIGINT REFERENCES products(product_id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'new' CHECK (
+        status IN ('new', 'in_progress', 'done', 'cancelled', 'spam')
+    ),
+    manager_note TEXT,
+    source_ip VARCHAR(64),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_leads_status_created_at
+ON leads (status, created_at DESC);
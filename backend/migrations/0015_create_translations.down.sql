@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trg_services_translations ON services;
+DROP TRIGGER IF EXISTS trg_presets_translations ON presets;
+DROP TRIGGER IF EXISTS trg_attributes_translations ON attributes;
+DROP TRIGGER IF EXISTS trg_categories_translations ON categories;
+DROP TRIGGER IF EXISTS trg_products_translations ON products;
+DROP FUNCTION IF EXISTS delete_translations();
+DROP TABLE IF EXISTS translations;
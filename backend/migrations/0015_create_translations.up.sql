@@ -0,0 +1,38 @@
+-- Переводы контента каталога. Значения на языке по умолчанию (ru) остаются
+-- в основных колонках сущностей, здесь хранятся только остальные локали.
+CREATE TABLE IF NOT EXISTS translations (
+    entity VARCHAR(32) NOT NULL CHECK (
+        entity IN ('product', 'category', 'attribute', 'preset', 'service')
+    ),
+    entity_id BIGINT NOT NULL,
+    locale VARCHAR(8) NOT NULL CHECK (locale IN ('en', 'kz')),
+    field VARCHAR(32) NOT NULL,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, entity_id, locale, field)
+);
+
+-- Ссылка полиморфная, поэтому вместо внешних ключей переводы удаляются триггерами.
+CREATE OR REPLACE FUNCTION delete_translations() RETURNS trigger AS $$
+BEGIN
+    EXECUTE format('DELETE FROM translations WHERE entity = %L AND entity_id = $1.%I',
+                   TG_ARGV[0], TG_ARGV[1])
+    USING OLD;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_translations AFTER DELETE ON products
+FOR EACH ROW EXECUTE FUNCTION delete_translations('product', 'product_id');
+
+CREATE TRIGGER trg_categories_translations AFTER DELETE ON categories
+FOR EACH ROW EXECUTE FUNCTION delete_translations('category', 'category_id');
+
+CREATE TRIGGER trg_attributes_translations AFTER DELETE ON attributes
+FOR EACH ROW EXECUTE FUNCTION delete_translations('attribute', 'attribute_id');
+
+CREATE TRIGGER trg_presets_translations AFTER DELETE ON presets
+FOR EACH ROW EXECUTE FUNCTION delete_translations('preset', 'preset_id');
+
+CREATE TRIGGER trg_services_translations AFTER DELETE ON services
+FOR EACH ROW EXECUTE FUNCTION delete_translations('service', 'service_id');
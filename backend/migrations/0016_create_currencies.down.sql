@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_services_price_overrides ON services;
+DROP TRIGGER IF EXISTS trg_products_price_overrides ON products;
+DROP FUNCTION IF EXISTS delete_price_overrides();
+DROP TABLE IF EXISTS price_overrides;
+DROP TABLE IF EXISTS exchange_rates;
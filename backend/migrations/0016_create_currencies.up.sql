@@ -0,0 +1,31 @@
+-- Курсы валют к базовой (RUB): rate — сколько единиц currency стоит 1 RUB.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency CHAR(3) PRIMARY KEY CHECK (currency ~ '^[A-Z]{3}$' AND currency <> 'RUB'),
+    rate NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Ручные цены в валюте, используются вместо пересчёта по курсу.
+CREATE TABLE IF NOT EXISTS price_overrides (
+    entity VARCHAR(32) NOT NULL CHECK (entity IN ('product', 'service')),
+    entity_id BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL REFERENCES exchange_rates (currency) ON DELETE CASCADE,
+    price NUMERIC(12, 2) NOT NULL CHECK (price > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, entity_id, currency)
+);
+
+CREATE OR REPLACE FUNCTION delete_price_overrides() RETURNS trigger AS $$
+BEGIN
+    EXECUTE format('DELETE FROM price_overrides WHERE entity = %L AND entity_id = $1.%I',
+                   TG_ARGV[0], TG_ARGV[1])
+    USING OLD;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_price_overrides AFTER DELETE ON products
+FOR EACH ROW EXECUTE FUNCTION delete_price_overrides('product', 'product_id');
+
+CREATE TRIGGER trg_services_price_overrides AFTER DELETE ON services
+FOR EACH ROW EXECUTE FUNCTION delete_price_overrides('service', 'service_id');
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_services_discount_rules ON services;
+DROP TRIGGER IF EXISTS trg_presets_discount_rules ON presets;
+DROP TRIGGER IF EXISTS trg_categories_discount_rules ON categories;
+DROP TRIGGER IF EXISTS trg_products_discount_rules ON products;
+DROP FUNCTION IF EXISTS delete_discount_rules();
+ALTER TABLE leads DROP COLUMN IF EXISTS promo_code;
+DROP TABLE IF EXISTS promo_codes;
+DROP TABLE IF EXISTS discount_rules;
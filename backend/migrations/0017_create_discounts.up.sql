@@ -0,0 +1,55 @@
+-- Правила скидок. target_id ссылается на сущность из target, поэтому
+-- внешнего ключа нет: правила удаляются триггерами вместе с сущностью.
+CREATE TABLE IF NOT EXISTS discount_rules (
+    rule_id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value NUMERIC(12, 2) NOT NULL CHECK (value > 0),
+    target VARCHAR(16) NOT NULL CHECK (target IN ('product', 'category', 'preset', 'service')),
+    target_id BIGINT NOT NULL,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    promo_only BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (kind <> 'percent' OR value <= 100),
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_discount_rules_target
+ON discount_rules (target, target_id);
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    code VARCHAR(32) PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES discount_rules (rule_id) ON DELETE CASCADE,
+    usage_limit INT CHECK (usage_limit > 0),
+    used_count INT NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_codes_rule_id
+ON promo_codes (rule_id);
+
+ALTER TABLE leads
+ADD COLUMN IF NOT EXISTS promo_code VARCHAR(32) REFERENCES promo_codes (code) ON DELETE SET NULL;
+
+CREATE OR REPLACE FUNCTION delete_discount_rules() RETURNS trigger AS $$
+BEGIN
+    EXECUTE format('DELETE FROM discount_rules WHERE target = %L AND target_id = $1.%I',
+                   TG_ARGV[0], TG_ARGV[1])
+    USING OLD;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_discount_rules AFTER DELETE ON products
+FOR EACH ROW EXECUTE FUNCTION delete_discount_rules('product', 'product_id');
+
+CREATE TRIGGER trg_categories_discount_rules AFTER DELETE ON categories
+FOR EACH ROW EXECUTE FUNCTION delete_discount_rules('category', 'category_id');
+
+CREATE TRIGGER trg_presets_discount_rules AFTER DELETE ON presets
+FOR EACH ROW EXECUTE FUNCTION delete_discount_rules('preset', 'preset_id');
+
+CREATE TRIGGER trg_services_discount_rules AFTER DELETE ON services
+FOR EACH ROW EXECUTE FUNCTION delete_discount_rules('service', 'service_id');
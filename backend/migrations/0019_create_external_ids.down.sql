@@ -0,0 +1,8 @@
+ALTER TABLE leads DROP COLUMN IF EXISTS exported_at;
+ALTER TABLE products DROP COLUMN IF EXISTS stock;
+DROP TRIGGER IF EXISTS trg_services_external_ids ON services;
+DROP TRIGGER IF EXISTS trg_products_external_ids ON products;
+DROP TRIGGER IF EXISTS trg_attributes_external_ids ON attributes;
+DROP TRIGGER IF EXISTS trg_categories_external_ids ON categories;
+DROP FUNCTION IF EXISTS delete_external_ids();
+DROP TABLE IF EXISTS external_ids;
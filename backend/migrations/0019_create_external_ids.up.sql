@@ -0,0 +1,43 @@
+-- Внешние идентификаторы сущностей каталога (GUID из 1С и т. п.):
+-- повторная выгрузка обновляет уже созданные записи, а не плодит дубли.
+CREATE TABLE IF NOT EXISTS external_ids (
+    source VARCHAR(32) NOT NULL,
+    entity VARCHAR(16) NOT NULL CHECK (
+        entity IN ('category', 'attribute', 'product', 'service')
+    ),
+    external_id VARCHAR(128) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (source, entity, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_ids_entity
+ON external_ids (entity, entity_id);
+
+CREATE OR REPLACE FUNCTION delete_external_ids() RETURNS trigger AS $$
+BEGIN
+    EXECUTE format('DELETE FROM external_ids WHERE entity = %L AND entity_id = $1.%I',
+                   TG_ARGV[0], TG_ARGV[1])
+    USING OLD;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_categories_external_ids AFTER DELETE ON categories
+FOR EACH ROW EXECUTE FUNCTION delete_external_ids('category', 'category_id');
+
+CREATE TRIGGER trg_attributes_external_ids AFTER DELETE ON attributes
+FOR EACH ROW EXECUTE FUNCTION delete_external_ids('attribute', 'attribute_id');
+
+CREATE TRIGGER trg_products_external_ids AFTER DELETE ON products
+FOR EACH ROW EXECUTE FUNCTION delete_external_ids('product', 'product_id');
+
+CREATE TRIGGER trg_services_external_ids AFTER DELETE ON services
+FOR EACH ROW EXECUTE FUNCTION delete_external_ids('service', 'service_id');
+
+-- Остатки приходят из учётной системы (offers.xml), вручную не редактируются.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock NUMERIC(12, 3);
+
+-- Момент последней выгрузки заявки в 1С как заказа; изменённые после
+-- выгрузки заявки уходят повторно.
+ALTER TABLE leads ADD COLUMN IF NOT EXISTS exported_at TIMESTAMPTZ;
@@ -0,0 +1,15 @@
+-- Ключи идемпотентности админских POST: повтор запроса с тем же
+-- Idempotency-Key получает сохранённый ответ вместо повторного выполнения.
+-- status_code IS NULL, пока первый запрос ещё выполняется.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT,
+    headers JSONB,
+    body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at
+ON idempotency_keys (created_at);
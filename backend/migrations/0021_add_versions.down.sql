@@ -0,0 +1,5 @@
+ALTER TABLE coefficients DROP COLUMN IF EXISTS version;
+ALTER TABLE services DROP COLUMN IF EXISTS version;
+ALTER TABLE categories DROP COLUMN IF EXISTS version;
+ALTER TABLE presets DROP COLUMN IF EXISTS version;
+ALTER TABLE products DROP COLUMN IF EXISTS version;
@@ -0,0 +1,8 @@
+-- Версия строки для оптимистичной блокировки: каждое изменение увеличивает
+-- version, а частичное обновление (PATCH) проходит, только если версия
+-- не изменилась с момента чтения.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE presets ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE services ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE coefficients ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS preset_item_alternatives;
+
+ALTER TABLE preset_items
+DROP COLUMN IF EXISTS optional,
+DROP COLUMN IF EXISTS slot_name;
@@ -0,0 +1,14 @@
+-- Позиция пресета — слот конфигуратора: товар по умолчанию, допустимые
+-- замены и признак, что слот можно убрать из комплекта.
+ALTER TABLE preset_items
+ADD COLUMN IF NOT EXISTS slot_name VARCHAR(100),
+ADD COLUMN IF NOT EXISTS optional BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS preset_item_alternatives (
+    preset_item_id BIGINT NOT NULL REFERENCES preset_items(preset_item_id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL REFERENCES products(product_id) ON DELETE CASCADE,
+    PRIMARY KEY (preset_item_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_preset_item_alternatives_product
+ON preset_item_alternatives (product_id);
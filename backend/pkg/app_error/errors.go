@@ -10,6 +10,12 @@ var (
 	ErrInternal   = errors.New("internal server error")
 	ErrCanceled   = errors.New("operation canceled")
 	ErrTimeout    = errors.New("operation timeout")
+	// ErrVersionConflict — запись изменилась после того, как её прочитали
+	// (не совпала version при оптимистичной блокировке).
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrPreconditionRequired — запись перезаписала бы существующую строку,
+	// но клиент не передал If-Match.
+	ErrPreconditionRequired = errors.New("precondition required")
 )
 
 // 23505
@@ -3,9 +3,70 @@ package args
 import "github.com/alexflint/go-arg"
 
 type Args struct {
-	ConfigPath string `arg:"-c,--config"  help:"Path to the configuration file"`
+	ConfigPath string       `arg:"-c,--config"  help:"Path to the configuration file"`
+	Migrate    *MigrateCmd  `arg:"subcommand:migrate" help:"Manage database schema migrations"`
+	Seed       *SeedCmd     `arg:"subcommand:seed" help:"Fill an empty database with a generated demo catalog"`
+	Snapshot   *SnapshotCmd `arg:"subcommand:snapshot" help:"Export or restore the catalog as a portable NDJSON snapshot"`
+	Config     *ConfigCmd   `arg:"subcommand:config" help:"Inspect the configuration"`
 }
 
+// MigrateCmd — store migrate up|down|status|goto N|force N|create NAME.
+type MigrateCmd struct {
+	Up     *MigrateUp     `arg:"subcommand:up" help:"Apply all pending migrations"`
+	Down   *MigrateDown   `arg:"subcommand:down" help:"Roll back the last applied migration"`
+	Status *MigrateStatus `arg:"subcommand:status" help:"List applied and pending migrations"`
+	Goto   *MigrateGoto   `arg:"subcommand:goto" help:"Migrate up or down to the given version"`
+	Force  *MigrateForce  `arg:"subcommand:force" help:"Set the schema version and clear the dirty flag without running migrations"`
+	Create *MigrateCreate `arg:"subcommand:create" help:"Create empty up/down migration files"`
+}
+
+type MigrateUp struct{}
+
+type MigrateDown struct{}
+
+type MigrateStatus struct{}
+
+type MigrateGoto struct {
+	Version uint `arg:"positional,required" help:"Target schema version"`
+}
+
+type MigrateForce struct {
+	Version int `arg:"positional,required" help:"Schema version to record"`
+}
+
+type MigrateCreate struct {
+	Name string `arg:"positional,required" help:"Migration name, e.g. add_preset_slots"`
+	Dir  string `arg:"--dir" default:"./migrations" help:"Directory with migration files"`
+}
+
+// SeedCmd — store seed [--profile NAME] [--seed N].
+type SeedCmd struct {
+	Profile string `arg:"--profile" default:"small" help:"Catalog size: small, medium or load-test"`
+	Seed    int64  `arg:"--seed" default:"1" help:"Random seed; the same seed produces the same catalog"`
+}
+
+// SnapshotCmd — store snapshot export [--out FILE] | restore FILE [--mode MODE].
+type SnapshotCmd struct {
+	Export  *SnapshotExport  `arg:"subcommand:export" help:"Write the catalog snapshot to a file or stdout"`
+	Restore *SnapshotRestore `arg:"subcommand:restore" help:"Load a catalog snapshot in a single transaction"`
+}
+
+type SnapshotExport struct {
+	Out string `arg:"-o,--out" default:"-" help:"Output file, - for stdout; a .gz suffix compresses the snapshot"`
+}
+
+type SnapshotRestore struct {
+	File string `arg:"positional,required" help:"Snapshot file, - for stdin; gzip is detected automatically"`
+	Mode string `arg:"--mode" default:"append" help:"append adds to the current catalog, replace deletes it first"`
+}
+
+// ConfigCmd — store config check.
+type ConfigCmd struct {
+	Check *ConfigCheck `arg:"subcommand:check" help:"Print the effective configuration with secrets redacted and validate it"`
+}
+
+type ConfigCheck struct{}
+
 func Parse() *Args {
 	var args Args
 	arg.MustParse(&args)
@@ -0,0 +1,69 @@
+// Package certreload — TLS-сертификат, который подменяется без перезапуска
+// сервера: после продления (certbot, cert-manager) файлы перечитываются по
+// изменению времени модификации или по SIGHUP.
+package certreload
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/pkg/filewatch"
+)
+
+type Reloader struct {
+	certFile, keyFile string
+	log               *slog.Logger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// New читает сертификат и ключ; ошибка — если их нет или они не парные.
+func New(certFile, keyFile string, log *slog.Logger) (*Reloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("certreload: cert_file and key_file are required")
+	}
+	r := &Reloader{certFile: certFile, keyFile: keyFile, log: log}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate — для tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Reload перечитывает файлы. При ошибке остаётся прежний сертификат:
+// certbot пишет сертификат и ключ по очереди, и между записями они не парные.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("certreload: %w", err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// Watch перечитывает сертификат, когда файлы меняются (проверка раз
+// в interval) или приходит SIGHUP, пока не отменён ctx.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	filewatch.Watch(ctx, interval, []string{r.certFile, r.keyFile}, func(reason string) error {
+		if err := r.Reload(); err != nil {
+			r.log.Error("tls certificate reload failed, keeping previous", slog.String("reason", reason), slog.Any("error", err))
+			return err
+		}
+		r.log.Info("tls certificate reloaded", slog.String("reason", reason), slog.String("cert_file", r.certFile))
+		return nil
+	})
+}
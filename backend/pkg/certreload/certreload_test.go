@@ -0,0 +1,88 @@
+package certreload
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePair пишет самоподписанный сертификат для cn и двигает время
+// модификации файлов на mod.
+func writePair(t *testing.T, dir, cn string, mod time.Time) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.Chtimes(certFile, mod, mod))
+	require.NoError(t, os.Chtimes(keyFile, mod, mod))
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, r *Reloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	mod := time.Now().Add(-time.Hour)
+	certFile, keyFile := writePair(t, dir, "old", mod)
+
+	r, err := New(certFile, keyFile, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	assert.Equal(t, "old", commonName(t, r))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+	// Watch запоминает время модификации файлов при старте
+	time.Sleep(50 * time.Millisecond)
+
+	t.Run("file change", func(t *testing.T) {
+		writePair(t, dir, "new", mod.Add(time.Minute))
+		assert.Eventually(t, func() bool { return commonName(t, r) == "new" }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("broken pair keeps previous", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyFile, []byte("garbage"), 0o600))
+		assert.Error(t, r.Reload())
+		assert.Equal(t, "new", commonName(t, r))
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("", "", slog.New(slog.DiscardHandler))
+	assert.Error(t, err)
+	_, err = New(filepath.Join(t.TempDir(), "missing.crt"), "missing.key", slog.New(slog.DiscardHandler))
+	assert.Error(t, err)
+}
@@ -6,7 +6,8 @@ import (
 	"net/url"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -27,6 +28,9 @@ func WithConnectTimeout(d time.Duration) Option {
 	return func(c *config) { c.connectTimeout = d }
 }
 
+// WithTracer подключает трассировку запросов (span'ы, метрики) на уровне драйвера.
+func WithTracer(t pgx.QueryTracer) Option { return func(c *config) { c.tracer = t } }
+
 func New(ctx context.Context, opts ...Option) (*sqlx.DB, error) {
 	cfg := defaultConfig()
 	for _, option := range opts {
@@ -38,10 +42,12 @@ func New(ctx context.Context, opts ...Option) (*sqlx.DB, error) {
 		return nil, err
 	}
 
-	db, err := sqlx.Open("pgx", dsn)
+	connCfg, err := pgx.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
 	}
+	connCfg.Tracer = cfg.tracer
+	db := sqlx.NewDb(stdlib.OpenDB(*connCfg), "pgx")
 	db.SetMaxOpenConns(cfg.maxOpenConns)
 	db.SetMaxIdleConns(cfg.minIdleConns)
 	db.SetConnMaxLifetime(cfg.connMaxLifetime)
@@ -84,6 +90,7 @@ type config struct {
 	minIdleConns    int
 	connMaxLifetime time.Duration
 	connectTimeout  time.Duration
+	tracer          pgx.QueryTracer
 }
 
 func defaultConfig() *config {
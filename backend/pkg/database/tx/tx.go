@@ -2,6 +2,7 @@ package tx
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
 
 	"github.com/jmoiron/sqlx"
@@ -15,7 +16,12 @@ import (
 type TxAction func(*sqlx.Tx) error
 
 // RunInTx executes a TxFunc within a transaction, returning its result and any error.
+// If ctx already carries a transaction (see WithTx), fn joins it: commit and
+// rollback are left to whoever opened it.
 func RunInTx[T any](ctx context.Context, db *sqlx.DB, fn func(*sqlx.Tx) (T, error)) (result T, err error) {
+	if outer, ok := FromContext(ctx); ok {
+		return fn(outer)
+	}
 	tx, err := db.BeginTxx(ctx, nil)
 	if err != nil {
 		return result, err
@@ -70,3 +76,54 @@ func ExecInTx(
 ) error {
 	return RunInTxAction(ctx, db, execFn)
 }
+
+type txKey struct{}
+
+// WithTx returns a context carrying tx. RunInTx and Q called with this context
+// work inside tx instead of opening their own transaction or connection.
+func WithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// FromContext returns the transaction carried by ctx, if any.
+func FromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// Querier is the subset of *sqlx.DB and *sqlx.Tx used by repositories.
+type Querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Q returns the transaction carried by ctx, or db when there is none.
+func Q(ctx context.Context, db *sqlx.DB) Querier {
+	if tx, ok := FromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// Transactor lets the service layer group several repository calls into one
+// transaction without knowing about sqlx.
+type Transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) *Transactor {
+	if db == nil {
+		panic("NewTransactor: db is nil")
+	}
+	return &Transactor{db: db}
+}
+
+// InTx runs fn in a transaction passed down through ctx: it is committed if fn
+// returns nil and rolled back otherwise.
+func (t *Transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTxAction(ctx, t.db, func(tx *sqlx.Tx) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Update builds a single-row UPDATE that touches only the columns passed to Set
+// and uses optimistic locking: the row's version column is incremented and the
+// statement matches only while the version is still the one the caller read.
+// Table and column names are trusted input and must be constants.
+type Update struct {
+	table    string
+	idColumn string
+	sets     []string
+	args     []any
+}
+
+func NewUpdate(table, idColumn string) *Update {
+	return &Update{table: table, idColumn: idColumn}
+}
+
+// Set adds column = value to the statement.
+func (u *Update) Set(column string, value any) *Update {
+	u.args = append(u.args, value)
+	u.sets = append(u.sets, fmt.Sprintf("%s = $%d", column, len(u.args)))
+	return u
+}
+
+// Empty reports whether no column was set; the statement then only bumps the version.
+func (u *Update) Empty() bool {
+	return len(u.sets) == 0
+}
+
+// SQL returns the statement and its arguments. The statement returns the new
+// version; versions expected by ctx (If-Match) are checked as well.
+func (u *Update) SQL(ctx context.Context, id, version int64) (string, []any) {
+	sets := append(append([]string(nil), u.sets...), "version = version + 1")
+	args := append(append([]any(nil), u.args...), id, version)
+	where := fmt.Sprintf("%s = $%d AND version = $%d", u.idColumn, len(args)-1, len(args))
+	guard, args := Guard(ctx, args)
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s%s RETURNING version",
+		u.table, strings.Join(sets, ", "), where, guard,
+	)
+	return query, args
+}
+
+// Exec runs the statement and returns the new version. It returns sql.ErrNoRows
+// when the row does not exist and app_error.ErrVersionConflict when it exists
+// but its version has changed since it was read.
+func (u *Update) Exec(ctx context.Context, q sqlx.QueryerContext, id, version int64) (int64, error) {
+	query, args := u.SQL(ctx, id, version)
+	var next int64
+	err := q.QueryRowxContext(ctx, query, args...).Scan(&next)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, NoRows(ctx, q, u.table, u.idColumn, id)
+	}
+	return next, err
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type versionsKey struct{}
+
+// WithExpectedVersions stores the row versions the client has seen (If-Match).
+// Repositories add them to UPDATE and DELETE statements through Guard.
+func WithExpectedVersions(ctx context.Context, versions []int64) context.Context {
+	return context.WithValue(ctx, versionsKey{}, versions)
+}
+
+// ExpectedVersions returns the versions stored by WithExpectedVersions.
+func ExpectedVersions(ctx context.Context) ([]int64, bool) {
+	v, ok := ctx.Value(versionsKey{}).([]int64)
+	return v, ok
+}
+
+// Guard returns the condition " AND version = ANY($n)" for the versions expected
+// by ctx and the args extended with them. Without expectations the statement is
+// left unconditional and Guard returns "" and args unchanged.
+func Guard(ctx context.Context, args []any) (string, []any) {
+	versions, ok := ExpectedVersions(ctx)
+	if !ok {
+		return "", args
+	}
+	args = append(args, pq.Array(versions))
+	return fmt.Sprintf(" AND version = ANY($%d)", len(args)), args
+}
+
+// NoRows explains a guarded statement that matched no rows: sql.ErrNoRows when
+// the row does not exist, app_error.ErrVersionConflict when it exists with
+// another version.
+func NoRows(ctx context.Context, q sqlx.QueryerContext, table, idColumn string, id int64) error {
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1)", table, idColumn)
+	var found bool
+	if err := q.QueryRowxContext(ctx, query, id).Scan(&found); err != nil {
+		return err
+	}
+	if !found {
+		return sql.ErrNoRows
+	}
+	return app_error.ErrVersionConflict
+}
+
+// Precondition is the client's condition for a write that may create the row
+// instead of changing it (upsert by external id).
+type Precondition int
+
+const (
+	// PreconditionNone — neither If-Match nor If-None-Match: the row may be
+	// created, but an existing one must not be overwritten.
+	PreconditionNone Precondition = iota + 1
+	// PreconditionIfMatch — the row must exist; the versions, if any, are in
+	// WithExpectedVersions.
+	PreconditionIfMatch
+	// PreconditionIfNoneMatch — If-None-Match: *, the row may only be created.
+	PreconditionIfNoneMatch
+)
+
+type preconditionKey struct{}
+
+// WithPrecondition stores the precondition of an upsert request.
+func WithPrecondition(ctx context.Context, p Precondition) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, p)
+}
+
+// PreconditionFrom returns the precondition stored by WithPrecondition; ok is
+// false when the request was not checked (internal calls, imports).
+func PreconditionFrom(ctx context.Context) (p Precondition, ok bool) {
+	p, ok = ctx.Value(preconditionKey{}).(Precondition)
+	return p, ok
+}
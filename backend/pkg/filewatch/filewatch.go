@@ -0,0 +1,64 @@
+// Package filewatch перечитывает файлы без перезапуска процесса: по SIGHUP
+// или когда меняется время их модификации.
+package filewatch
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+)
+
+const (
+	ReasonSignal  = "sighup"
+	ReasonChanged = "file changed"
+)
+
+// Watch вызывает reload при SIGHUP и когда у одного из files меняется время
+// модификации (проверка раз в interval; interval <= 0 — только SIGHUP), пока
+// не отменён ctx. Если reload после изменения файлов вернул ошибку, он
+// повторяется на следующей проверке: файлы могли быть записаны не до конца.
+func Watch(ctx context.Context, interval time.Duration, files []string, reload func(reason string) error) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	seen := modTimes(files)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if reload(ReasonSignal) == nil {
+				seen = modTimes(files)
+			}
+		case <-tick:
+			mod := modTimes(files)
+			if slices.EqualFunc(mod, seen, time.Time.Equal) {
+				continue
+			}
+			if reload(ReasonChanged) == nil {
+				seen = mod
+			}
+		}
+	}
+}
+
+// modTimes — время модификации файлов; у недоступного файла — нулевое.
+func modTimes(files []string) []time.Time {
+	mod := make([]time.Time, len(files))
+	for i, name := range files {
+		if st, err := os.Stat(name); err == nil {
+			mod[i] = st.ModTime()
+		}
+	}
+	return mod
+}
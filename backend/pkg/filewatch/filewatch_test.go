@@ -0,0 +1,51 @@
+package filewatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+	touch := func(d time.Duration) {
+		mod := time.Now().Add(d)
+		require.NoError(t, os.Chtimes(file, mod, mod))
+	}
+	touch(-time.Hour)
+
+	var calls atomic.Int32
+	fail := atomic.Bool{}
+	fail.Store(true)
+	reload := func(reason string) error {
+		assert.Equal(t, ReasonChanged, reason)
+		calls.Add(1)
+		if fail.Load() {
+			return errors.New("half-written")
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Watch(ctx, 10*time.Millisecond, []string{file}, reload)
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, calls.Load(), "no reload without changes")
+
+	// неудачная перезагрузка повторяется, пока не пройдёт
+	touch(-time.Minute)
+	assert.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	fail.Store(false)
+	time.Sleep(50 * time.Millisecond)
+	settled := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, settled, calls.Load(), "successful reload is not repeated")
+}
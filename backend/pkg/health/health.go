@@ -0,0 +1,136 @@
+// Package health — пробы для балансировщика и оркестратора: /livez отвечает,
+// пока процесс жив, /readyz — пока приложение может обслуживать запросы.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check проверяет одну зависимость; nil — зависимость доступна.
+type Check func(ctx context.Context) error
+
+const (
+	StatusOK       = "ok"
+	StatusError    = "error"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+	StatusDraining = "draining"
+)
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Probe собирает проверки готовности. Проверки добавляются до запуска
+// сервера; Drain переводит пробу в «не готов» до конца жизни процесса.
+type Probe struct {
+	timeout  time.Duration
+	checks   []namedCheck
+	draining atomic.Bool
+}
+
+// New: timeout ограничивает все проверки одного запроса /readyz.
+func New(timeout time.Duration) *Probe {
+	return &Probe{timeout: timeout}
+}
+
+func (p *Probe) Add(name string, check Check) {
+	p.checks = append(p.checks, namedCheck{name: name, check: check})
+}
+
+// Drain вызывается в начале остановки: балансировщик видит 503 на /readyz
+// и уводит трафик, пока сервер дорабатывает текущие запросы.
+func (p *Probe) Drain() {
+	p.draining.Store(true)
+}
+
+// Ready выполняет проверки параллельно и возвращает сводку.
+func (p *Probe) Ready(ctx context.Context) Report {
+	if p.draining.Load() {
+		return Report{Status: StatusDraining}
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]CheckResult, len(p.checks))}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, c := range p.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := c.check(ctx)
+			res := CheckResult{Status: StatusOK, Duration: time.Since(start).String()}
+			if err != nil {
+				res.Status, res.Error = StatusError, err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.name] = res
+			if err != nil {
+				report.Status = StatusNotReady
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// LiveHandler — /livez: процесс жив и обслуживает HTTP, зависимости не проверяются.
+func (p *Probe) LiveHandler(w http.ResponseWriter, _ *http.Request) {
+	writeReport(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// ReadyHandler — /readyz: 200 со сводкой проверок или 503, если какая-то
+// не прошла или приложение останавливается.
+func (p *Probe) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := p.Ready(r.Context())
+	code := http.StatusOK
+	if report.Status != StatusReady {
+		code = http.StatusServiceUnavailable
+	}
+	writeReport(w, code, report)
+}
+
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// Writable проверяет, что в каталог можно писать: создаёт и удаляет временный файл.
+func Writable(dir string) Check {
+	return func(context.Context) error {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return err
+		}
+		f, err := os.CreateTemp(dir, ".readyz-*")
+		if err != nil {
+			return err
+		}
+		_ = f.Close()
+		return os.Remove(f.Name())
+	}
+}
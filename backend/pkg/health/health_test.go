@@ -0,0 +1,100 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ready(t *testing.T, p *health.Probe) (int, health.Report) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	p.ReadyHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var report health.Report
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	return w.Code, report
+}
+
+func TestReadyz(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+
+	t.Run("all checks pass", func(t *testing.T) {
+		p := health.New(time.Second)
+		p.Add("database", ok)
+		p.Add("migrations", ok)
+
+		code, report := ready(t, p)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, health.StatusReady, report.Status)
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.StatusOK, report.Checks["migrations"].Status)
+	})
+
+	t.Run("failed check", func(t *testing.T) {
+		p := health.New(time.Second)
+		p.Add("database", ok)
+		p.Add("migrations", func(context.Context) error { return errors.New("schema version 20, expected 21") })
+
+		code, report := ready(t, p)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.StatusNotReady, report.Status)
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.StatusError, report.Checks["migrations"].Status)
+		assert.Equal(t, "schema version 20, expected 21", report.Checks["migrations"].Error)
+	})
+
+	t.Run("checks share the timeout", func(t *testing.T) {
+		p := health.New(10 * time.Millisecond)
+		p.Add("database", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		code, report := ready(t, p)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		p := health.New(time.Second)
+		p.Add("database", ok)
+		p.Drain()
+
+		code, report := ready(t, p)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.StatusDraining, report.Status)
+	})
+}
+
+func TestLivezIgnoresChecks(t *testing.T) {
+	p := health.New(time.Second)
+	p.Add("database", func(context.Context) error { return errors.New("down") })
+	p.Drain()
+
+	w := httptest.NewRecorder()
+	p.LiveHandler(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestWritable(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "exchange")
+	require.NoError(t, health.Writable(dir)(context.Background()))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	assert.Error(t, health.Writable(file)(context.Background()))
+}
@@ -0,0 +1,36 @@
+package http_utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag — сильный ETag версии сущности: "3".
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetETag отдаёт версию сущности в заголовке ETag.
+func SetETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", ETag(version))
+}
+
+// ParseIfMatch разбирает If-Match: wildcard — передан «*», иначе versions — версии
+// из ETag вида "3". Слабые и чужие ETag пропускаются: сильному сравнению
+// они не соответствуют никогда.
+func ParseIfMatch(header string) (versions []int64, wildcard bool) {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if v, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions, false
+}
@@ -2,29 +2,94 @@ package http_utils
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
+// ErrBodyTooLarge — тело запроса длиннее лимита middleware.BodyLimit.
+var ErrBodyTooLarge = errors.New("request body too large")
+
 type Validatable interface {
 	Validate() error
 }
 
+// DecodeAndValidate читает JSON-тело и валидирует его. Ошибки отдаются как
+// problem+json на языке запроса (?lang= или Accept-Language).
 func DecodeAndValidate[T Validatable](w http.ResponseWriter, r *http.Request, log *slog.Logger) (*T, bool) {
+	return decodeAndValidate[T](w, r, log, false)
+}
+
+// DecodeOptional — DecodeAndValidate для необязательного тела: пустое тело
+// (в том числе chunked, где Content-Length неизвестен) даёт nil и true.
+func DecodeOptional[T Validatable](w http.ResponseWriter, r *http.Request, log *slog.Logger) (*T, bool) {
+	return decodeAndValidate[T](w, r, log, true)
+}
+
+func decodeAndValidate[T Validatable](w http.ResponseWriter, r *http.Request, log *slog.Logger, optional bool) (*T, bool) {
 	var req T
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if optional && errors.Is(err, io.EOF) {
+			return nil, true
+		}
+		if errors.Is(err, ErrBodyTooLarge) {
+			log.Warn("request body too large", slog.Any("error", err))
+			WriteLocalizedError(w, r, http.StatusRequestEntityTooLarge, i18n.MsgBodyTooLarge)
+			return nil, false
+		}
 		log.Warn("invalid JSON", slog.Any("error", err))
-		WriteError(w, http.StatusBadRequest, "invalid JSON")
+		WriteLocalizedError(w, r, http.StatusBadRequest, i18n.MsgInvalidJSON)
 		return nil, false
 	}
-	if err := req.Validate(); err != nil {
-		if ve, ok := err.(ValidationErrorResponse); ok {
-			log.Warn("validation failed", slog.Any("validation_errors", ve.Errors))
-			WriteValidationError(w, http.StatusUnprocessableEntity, ve)
-			return nil, false
-		}
-		WriteError(w, http.StatusUnprocessableEntity, err.Error())
+	if !Validate(w, r, log, req) {
 		return nil, false
 	}
 	return &req, true
 }
+
+// Validate валидирует уже разобранный запрос и при ошибке пишет ответ 422.
+func Validate(w http.ResponseWriter, r *http.Request, log *slog.Logger, req Validatable) bool {
+	err := req.Validate()
+	if err == nil {
+		return true
+	}
+	if ve, ok := err.(ValidationErrorResponse); ok {
+		log.Warn("validation failed", slog.Any("validation_errors", ve.Errors))
+		writeLocalizedValidation(w, r, ve)
+		return false
+	}
+	WriteLocalizedError(w, r, http.StatusUnprocessableEntity, i18n.Raw(err.Error()))
+	return false
+}
+
+// WriteLocalizedError — WriteError с заголовком и detail на языке запроса.
+// message остаётся исходным (английским) для клиентов старого формата.
+func WriteLocalizedError(w http.ResponseWriter, r *http.Request, statusCode int, msg i18n.Message) {
+	lang := Lang(r)
+	p := Problems.statusProblem(statusCode, lang)
+	p.Detail = i18n.T(i18n.Locale(lang), msg)
+	p.Message = msg.String()
+	p.Instance = r.URL.Path
+	p.RequestID = middleware.GetReqID(r.Context())
+	WriteProblem(w, p)
+}
+
+func writeLocalizedValidation(w http.ResponseWriter, r *http.Request, ve ValidationErrorResponse) {
+	lang := Lang(r)
+	p := Problems.statusProblem(http.StatusUnprocessableEntity, lang)
+	p.Errors = make([]FieldError, len(ve.Errors))
+	for i, fe := range ve.Errors {
+		p.Errors[i] = fe
+		if fe.Code != "" {
+			p.Errors[i].Message = i18n.T(i18n.Locale(lang), fe.msg)
+		}
+	}
+	p.Message = ve.Error()
+	p.Instance = r.URL.Path
+	p.RequestID = middleware.GetReqID(r.Context())
+	WriteProblem(w, p)
+}
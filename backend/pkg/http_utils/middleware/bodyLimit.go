@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// BodyLimit ограничивает тело запроса n байтами; n <= 0 — без ограничения.
+// Запрос с Content-Length больше n сразу получает 413, а чтение тела без
+// Content-Length после n байт возвращает http_utils.ErrBodyTooLarge.
+//
+// Лимиты не складываются: действует ближайший к обработчику, поэтому
+// маршрут может поднять общий лимит роутера.
+func BodyLimit(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Body
+			if b, ok := raw.(*limitedBody); ok {
+				raw = b.raw
+			}
+			if n <= 0 || raw == nil || raw == http.NoBody {
+				r.Body = raw
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.ContentLength > n {
+				http_utils.WriteLocalizedError(w, r, http.StatusRequestEntityTooLarge, i18n.MsgBodyTooLarge)
+				return
+			}
+			r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, raw, n), raw: raw}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limitedBody помнит исходное тело, чтобы следующий BodyLimit заменил лимит.
+type limitedBody struct {
+	io.ReadCloser
+	raw io.ReadCloser
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = fmt.Errorf("%w: limit %d bytes", http_utils.ErrBodyTooLarge, tooLarge.Limit)
+	}
+	return n, err
+}
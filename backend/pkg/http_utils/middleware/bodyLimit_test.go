@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimit(t *testing.T) {
+	// readAll отвечает 204, если тело прочиталось целиком, и 413 на ErrBodyTooLarge.
+	readAll := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			assert.ErrorIs(t, err, http_utils.ErrBodyTooLarge)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	serve := func(h http.Handler, body string, chunked bool) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if chunked {
+			req.ContentLength = -1
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	body := strings.Repeat("x", 50)
+
+	for _, tc := range []struct {
+		name    string
+		h       http.Handler
+		chunked bool
+		want    int
+	}{
+		{name: "within limit", h: BodyLimit(100)(readAll), want: http.StatusNoContent},
+		{name: "content length over limit", h: BodyLimit(10)(readAll), want: http.StatusRequestEntityTooLarge},
+		{name: "chunked over limit", h: BodyLimit(10)(readAll), chunked: true, want: http.StatusRequestEntityTooLarge},
+		{name: "no limit", h: BodyLimit(0)(readAll), chunked: true, want: http.StatusNoContent},
+		// действует ближайший к обработчику лимит: маршрут поднимает общий
+		{name: "route raises router limit", h: BodyLimit(10)(BodyLimit(100)(readAll)), chunked: true, want: http.StatusNoContent},
+		{name: "route lowers router limit", h: BodyLimit(100)(BodyLimit(10)(readAll)), chunked: true, want: http.StatusRequestEntityTooLarge},
+		{name: "route lifts router limit", h: BodyLimit(10)(BodyLimit(0)(readAll)), chunked: true, want: http.StatusNoContent},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, serve(tc.h, body, tc.chunked))
+		})
+	}
+
+	t.Run("nested limits wrap the original body", func(t *testing.T) {
+		var got io.ReadCloser
+		h := BodyLimit(10)(BodyLimit(100)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			got = r.Body
+		})))
+		serve(h, body, true)
+		lb, ok := got.(*limitedBody)
+		require.True(t, ok)
+		_, nested := lb.raw.(*limitedBody)
+		assert.False(t, nested, "a second limit must replace the first, not stack on it")
+	})
+}
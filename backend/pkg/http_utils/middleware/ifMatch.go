@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// RequireIfMatch требует If-Match у PUT, PATCH и DELETE: без заголовка — 428.
+// Версии из ETag кладутся в контекст (database.WithExpectedVersions), и
+// репозиторий меняет строку, только если её версия среди них, иначе — 412.
+// If-Match: * лишь требует, чтобы сущность существовала.
+func RequireIfMatch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get("If-Match")
+		if header == "" {
+			http_utils.WriteLocalizedError(w, r, http.StatusPreconditionRequired, i18n.MsgIfMatchRequired)
+			return
+		}
+		versions, wildcard := http_utils.ParseIfMatch(header)
+		if wildcard {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(versions) == 0 {
+			http_utils.WriteLocalizedError(w, r, http.StatusPreconditionFailed, i18n.MsgIfMatchMismatch)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(database.WithExpectedVersions(r.Context(), versions)))
+	})
+}
+
+// RequireIfMatchOrCreate — RequireIfMatch для upsert, где PUT может создать
+// сущность. Условие запроса кладётся в контекст (database.WithPrecondition),
+// а проверяет его сервис, когда знает, есть ли строка:
+//   - If-Match — сущность должна существовать, иначе 412; версии — как в RequireIfMatch;
+//   - If-None-Match: * — только создание, существующая сущность даёт 412;
+//   - без заголовков сущность можно создать, а перезаписать нельзя — 428.
+func RequireIfMatchOrCreate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		ifMatch, ifNoneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+		switch {
+		case ifMatch != "":
+			versions, wildcard := http_utils.ParseIfMatch(ifMatch)
+			if !wildcard && len(versions) == 0 {
+				http_utils.WriteLocalizedError(w, r, http.StatusPreconditionFailed, i18n.MsgIfMatchMismatch)
+				return
+			}
+			ctx = database.WithPrecondition(ctx, database.PreconditionIfMatch)
+			if !wildcard {
+				ctx = database.WithExpectedVersions(ctx, versions)
+			}
+		case ifNoneMatch != "":
+			if strings.TrimSpace(ifNoneMatch) != "*" {
+				http_utils.WriteLocalizedError(w, r, http.StatusBadRequest, i18n.MsgIfNoneMatchWildcard)
+				return
+			}
+			ctx = database.WithPrecondition(ctx, database.PreconditionIfNoneMatch)
+		default:
+			ctx = database.WithPrecondition(ctx, database.PreconditionNone)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Neimess/zorkin-store-project/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// captured — что обработчик увидел в контексте.
+type captured struct {
+	called       bool
+	versions     []int64
+	hasVersions  bool
+	precondition database.Precondition
+	checked      bool
+}
+
+func serveConditional(mw func(http.Handler) http.Handler, method string, headers map[string]string) (int, captured) {
+	var c captured
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.called = true
+		c.versions, c.hasVersions = database.ExpectedVersions(r.Context())
+		c.precondition, c.checked = database.PreconditionFrom(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req := httptest.NewRequest(method, "/", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code, c
+}
+
+func TestRequireIfMatch(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		method   string
+		ifMatch  string
+		want     int
+		versions []int64
+	}{
+		{name: "read is not checked", method: http.MethodGet, want: http.StatusNoContent},
+		{name: "missing header", method: http.MethodPut, want: http.StatusPreconditionRequired},
+		{name: "missing header on delete", method: http.MethodDelete, want: http.StatusPreconditionRequired},
+		{name: "unparseable header", method: http.MethodPatch, ifMatch: `W/"abc"`, want: http.StatusPreconditionFailed},
+		{name: "wildcard", method: http.MethodPut, ifMatch: "*", want: http.StatusNoContent},
+		{name: "versions", method: http.MethodPut, ifMatch: `"3", "4"`, want: http.StatusNoContent, versions: []int64{3, 4}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tc.ifMatch != "" {
+				headers["If-Match"] = tc.ifMatch
+			}
+			code, c := serveConditional(RequireIfMatch, tc.method, headers)
+			assert.Equal(t, tc.want, code)
+			assert.Equal(t, tc.want == http.StatusNoContent, c.called)
+			assert.Equal(t, tc.versions, c.versions)
+			assert.Equal(t, tc.versions != nil, c.hasVersions, "wildcard must not restrict versions")
+		})
+	}
+}
+
+func TestRequireIfMatchOrCreate(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		headers      map[string]string
+		want         int
+		precondition database.Precondition
+		versions     []int64
+	}{
+		{name: "no headers", want: http.StatusNoContent, precondition: database.PreconditionNone},
+		{name: "if-match", headers: map[string]string{"If-Match": `"2"`}, want: http.StatusNoContent,
+			precondition: database.PreconditionIfMatch, versions: []int64{2}},
+		{name: "if-match wildcard", headers: map[string]string{"If-Match": "*"}, want: http.StatusNoContent,
+			precondition: database.PreconditionIfMatch},
+		{name: "unparseable if-match", headers: map[string]string{"If-Match": "junk"}, want: http.StatusPreconditionFailed},
+		{name: "if-none-match wildcard", headers: map[string]string{"If-None-Match": "*"}, want: http.StatusNoContent,
+			precondition: database.PreconditionIfNoneMatch},
+		{name: "if-none-match with tag", headers: map[string]string{"If-None-Match": `"3"`}, want: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			code, c := serveConditional(RequireIfMatchOrCreate, http.MethodPut, tc.headers)
+			assert.Equal(t, tc.want, code)
+			if tc.want != http.StatusNoContent {
+				assert.False(t, c.called)
+				return
+			}
+			assert.True(t, c.checked)
+			assert.Equal(t, tc.precondition, c.precondition)
+			assert.Equal(t, tc.versions, c.versions)
+		})
+	}
+
+	t.Run("read is not checked", func(t *testing.T) {
+		code, c := serveConditional(RequireIfMatchOrCreate, http.MethodGet, nil)
+		assert.Equal(t, http.StatusNoContent, code)
+		assert.False(t, c.checked)
+	})
+}
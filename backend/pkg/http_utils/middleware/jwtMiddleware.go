@@ -2,7 +2,10 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
 	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
 	"github.com/auth0/go-jwt-middleware/v2/validator"
 )
@@ -29,5 +32,18 @@ func NewJWTMiddleware(cfg JWTCfg, opts ...jwtmiddleware.Option) (*jwtmiddleware.
 		return nil, err
 	}
 
+	opts = append([]jwtmiddleware.Option{jwtmiddleware.WithErrorHandler(jwtErrorHandler)}, opts...)
 	return jwtmiddleware.New(v.ValidateToken, opts...), nil
 }
+
+// jwtErrorHandler отвечает на ошибки проверки токена в формате problem+json.
+func jwtErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	switch {
+	case errors.Is(err, jwtmiddleware.ErrJWTMissing):
+		http_utils.WriteError(w, http.StatusUnauthorized, "JWT is missing")
+	case errors.Is(err, jwtmiddleware.ErrJWTInvalid):
+		http_utils.WriteError(w, http.StatusUnauthorized, "JWT is invalid")
+	default:
+		http_utils.WriteError(w, http.StatusInternalServerError, "something went wrong while checking the JWT")
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+)
+
+// Locale выбирает язык контента по ?lang= или Accept-Language и кладёт его
+// в контекст (i18n.WithLocale); без явного выбора — i18n.Default.
+// Язык ответа сообщается в Content-Language.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loc, ok := i18n.Negotiate(r)
+		if !ok {
+			loc = i18n.Default
+		}
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", string(loc))
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), loc)))
+	})
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/Neimess/zorkin-store-project/pkg/ratelimit"
+	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+)
+
+// RateLimiter ограничивает частоту запросов по одной политике. Корзина
+// у каждого клиента своя: после CheckJWT — по subject токена, иначе по IP.
+// Адрес клиента берётся из r.RemoteAddr, поэтому перед лимитером должен
+// стоять RealIP с доверенными прокси, если сервис работает за прокси.
+type RateLimiter struct {
+	name       string
+	limit      atomic.Pointer[ratelimit.Limit]
+	store      ratelimit.Store
+	failClosed bool
+	log        *slog.Logger
+}
+
+// NewRateLimiter: name отделяет корзины политики от корзин других политик
+// в общем store.
+func NewRateLimiter(name string, limit ratelimit.Limit, store ratelimit.Store, log *slog.Logger) *RateLimiter {
+	l := &RateLimiter{name: name, store: store, log: log.With(slog.String("policy", name))}
+	l.SetLimit(limit)
+	return l
+}
+
+// FailClosed: при недоступном store отвечать 503, а не пропускать запрос.
+// Нужно политикам, которые защищают от перебора, — вход в админку.
+func (l *RateLimiter) FailClosed() *RateLimiter {
+	l.failClosed = true
+	return l
+}
+
+// SetLimit меняет политику на лету, в том числе включает и выключает её.
+// Уже набранные корзины остаются: store пересчитывает их под новый лимит.
+func (l *RateLimiter) SetLimit(limit ratelimit.Limit) {
+	l.limit.Store(&limit)
+}
+
+// Handler проставляет заголовки RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset и RateLimit-Policy, а когда токенов нет — отвечает 429
+// с Retry-After. Выключенная политика или store == nil пропускают всё.
+// Если store недоступен, запрос пропускается: лимиты не должны ронять API, —
+// кроме политик с FailClosed, которые отвечают 503.
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	if l.store == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := *l.limit.Load()
+		if !limit.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		res, err := l.store.Take(r.Context(), l.name+":"+ClientKey(r), limit)
+		if err != nil {
+			if l.failClosed {
+				l.log.Error("rate limit store failed, request rejected", slog.Any("error", err))
+				http_utils.WriteError(w, http.StatusServiceUnavailable, "service unavailable")
+				return
+			}
+			l.log.Warn("rate limit store failed, request allowed", slog.Any("error", err))
+			next.ServeHTTP(w, r)
+			return
+		}
+		h := w.Header()
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", limit.Requests, seconds(limit.Per), limit.Capacity()))
+		h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(seconds(res.Reset)))
+		if !res.Allowed {
+			h.Set("Retry-After", strconv.Itoa(max(1, seconds(res.RetryAfter))))
+			http_utils.WriteError(w, http.StatusTooManyRequests, "too many requests")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientKey — кому принадлежит запрос: "sub:<subject>" для запросов
+// с проверенным JWT, иначе "ip:<адрес>".
+func ClientKey(r *http.Request) string {
+	if claims, ok := r.Context().Value(jwtmiddleware.ContextKey{}).(*validator.ValidatedClaims); ok &&
+		claims.RegisteredClaims.Subject != "" {
+		return "sub:" + claims.RegisteredClaims.Subject
+	}
+	return "ip:" + clientIP(r)
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+type brokenStore struct{}
+
+func (brokenStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("redis: connection refused")
+}
+
+func TestRateLimiterStoreFailure(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 5, Per: time.Minute}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	serve := func(l *RateLimiter) int {
+		rec := httptest.NewRecorder()
+		l.Handler(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(NewRateLimiter("public", limit, brokenStore{}, slog.New(slog.DiscardHandler))))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(NewRateLimiter("auth", limit, brokenStore{}, slog.New(slog.DiscardHandler)).FailClosed()))
+}
+
+// stubStore отдаёт заранее заданный результат.
+type stubStore struct{ res ratelimit.Result }
+
+func (s stubStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return s.res, nil
+}
+
+func TestRateLimiterHeaders(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 10, Per: time.Minute, Burst: 20}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	serve := func(l *RateLimiter) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		l.Handler(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec
+	}
+	log := slog.New(slog.DiscardHandler)
+
+	t.Run("allowed", func(t *testing.T) {
+		rec := serve(NewRateLimiter("public", limit, stubStore{ratelimit.Result{
+			Allowed: true, Limit: 20, Remaining: 7, Reset: 78500 * time.Millisecond,
+		}}, log))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "10;w=60;burst=20", rec.Header().Get("RateLimit-Policy"))
+		assert.Equal(t, "20", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "7", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "79", rec.Header().Get("RateLimit-Reset"), "reset is rounded up")
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		rec := serve(NewRateLimiter("public", limit, stubStore{ratelimit.Result{
+			Limit: 20, Reset: 2 * time.Minute, RetryAfter: 5500 * time.Millisecond,
+		}}, log))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "6", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("retry-after is at least a second", func(t *testing.T) {
+		rec := serve(NewRateLimiter("public", limit, stubStore{ratelimit.Result{Limit: 20}}, log))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("disabled policy", func(t *testing.T) {
+		rec := serve(NewRateLimiter("public", ratelimit.Limit{}, stubStore{}, log))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	})
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// RealIP подставляет в r.RemoteAddr адрес клиента из X-Forwarded-For или
+// X-Real-IP, но только если запрос пришёл от доверенного прокси из trusted.
+// Иначе заголовки игнорируются: их может выставить сам клиент, чтобы
+// обойти лимиты по IP.
+//
+// X-Forwarded-For читается справа налево: адреса доверенных прокси
+// пропускаются, первый недоверенный — клиент. Без доверенных прокси
+// middleware ничего не меняет.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(trusted) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if peer, ok := parseIP(clientIP(r)); ok && isTrusted(trusted, peer) {
+				if ip, ok := forwardedIP(r.Header, trusted); ok {
+					r.RemoteAddr = ip.String()
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP — адрес клиента по заголовкам доверенного прокси.
+func forwardedIP(h http.Header, trusted []netip.Prefix) (netip.Addr, bool) {
+	var hops []string
+	for _, v := range h.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	var leftmost netip.Addr
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, ok := parseIP(hops[i])
+		if !ok {
+			// цепочку дальше испорченного звена проверить нельзя
+			return netip.Addr{}, false
+		}
+		if !isTrusted(trusted, ip) {
+			return ip, true
+		}
+		leftmost = ip
+	}
+	if leftmost.IsValid() {
+		return leftmost, true
+	}
+	return parseIP(h.Get("X-Real-IP"))
+}
+
+func parseIP(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+func isTrusted(trusted []netip.Prefix, ip netip.Addr) bool {
+	for _, p := range trusted {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("127.0.0.1/32")}
+
+	for _, tc := range []struct {
+		name, remote, xff, xRealIP string
+		trusted                    []netip.Prefix
+		want                       string
+	}{
+		{name: "no trusted proxies", remote: "10.0.0.2:5000", xff: "203.0.113.7", trusted: nil, want: "10.0.0.2:5000"},
+		{name: "untrusted peer", remote: "198.51.100.1:5000", xff: "203.0.113.7", trusted: trusted, want: "198.51.100.1:5000"},
+		{name: "forwarded for", remote: "10.0.0.2:5000", xff: "203.0.113.7", trusted: trusted, want: "203.0.113.7"},
+		// клиент дописал свой адрес в начало цепочки — берётся последний недоверенный
+		{name: "spoofed chain", remote: "10.0.0.2:5000", xff: "1.2.3.4, 203.0.113.7, 10.0.0.5", trusted: trusted, want: "203.0.113.7"},
+		{name: "only proxies", remote: "127.0.0.1:5000", xff: "10.0.0.9, 10.0.0.5", trusted: trusted, want: "10.0.0.9"},
+		{name: "broken chain", remote: "10.0.0.2:5000", xff: "203.0.113.7, junk", trusted: trusted, want: "10.0.0.2:5000"},
+		{name: "real ip", remote: "10.0.0.2:5000", xRealIP: "203.0.113.8", trusted: trusted, want: "203.0.113.8"},
+		{name: "no headers", remote: "10.0.0.2:5000", trusted: trusted, want: "10.0.0.2:5000"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			h := RealIP(tc.trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remote
+			if tc.xff != "" {
+				req.Header.Set("X-Forwarded-For", tc.xff)
+			}
+			if tc.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tc.xRealIP)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
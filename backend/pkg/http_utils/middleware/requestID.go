@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDHeader отдаёт идентификатор запроса (chi middleware.RequestID)
+// в заголовке X-Request-ID, чтобы клиент мог сослаться на него.
+// Должен стоять после middleware.RequestID.
+func RequestIDHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := middleware.GetReqID(r.Context()); id != "" {
+			w.Header().Set(http_utils.RequestIDHeader, id)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	serve := func(h http.Handler, proto string) http.Header {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if proto != "" {
+			req.Header.Set("X-Forwarded-Proto", proto)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Header()
+	}
+	opts := SecurityOptions{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: "default-src 'none'",
+		ReferrerPolicy:        "no-referrer",
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		h := serve(SecurityHeaders(opts)(ok), "")
+		assert.Equal(t, "nosniff", h.Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", h.Get("X-Frame-Options"))
+		assert.Equal(t, "no-referrer", h.Get("Referrer-Policy"))
+		assert.Equal(t, "default-src 'none'", h.Get("Content-Security-Policy"))
+		assert.Empty(t, h.Get("Strict-Transport-Security"), "HSTS is sent over HTTPS only")
+	})
+
+	t.Run("hsts behind https proxy", func(t *testing.T) {
+		h := serve(SecurityHeaders(opts)(ok), "https")
+		assert.Equal(t, "max-age=31536000; includeSubDomains", h.Get("Strict-Transport-Security"))
+	})
+
+	t.Run("empty options are omitted", func(t *testing.T) {
+		h := serve(SecurityHeaders(SecurityOptions{})(ok), "https")
+		assert.Equal(t, "nosniff", h.Get("X-Content-Type-Options"))
+		for _, name := range []string{"Referrer-Policy", "Content-Security-Policy", "Strict-Transport-Security"} {
+			assert.NotContains(t, h, name)
+		}
+	})
+
+	t.Run("route overrides csp", func(t *testing.T) {
+		h := serve(SecurityHeaders(opts)(ContentSecurityPolicy("default-src 'self'")(ok)), "")
+		assert.Equal(t, "default-src 'self'", h.Get("Content-Security-Policy"))
+
+		h = serve(SecurityHeaders(opts)(ContentSecurityPolicy("")(ok)), "")
+		assert.NotContains(t, h, "Content-Security-Policy")
+	})
+}
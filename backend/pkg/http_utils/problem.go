@@ -0,0 +1,233 @@
+package http_utils
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Neimess/zorkin-store-project/pkg/app_error"
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	RequestIDHeader    = "X-Request-ID"
+	// DefaultDocsURL — путь, по которому отдаётся каталог кодов ошибок.
+	DefaultDocsURL = "/api/errors"
+
+	LangEN = string(i18n.EN)
+	LangRU = string(i18n.RU)
+	LangKZ = string(i18n.KZ)
+	// DefaultLang — язык заголовков ошибок, если клиент не указал свой:
+	// английский, чтобы не ломать клиентов старого формата.
+	DefaultLang = LangEN
+)
+
+// Коды, которые выдаются по HTTP-статусу, если ошибка не зарегистрирована.
+const (
+	CodeBadRequest       = "bad_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeConflict         = "conflict"
+	CodeTooLarge         = "payload_too_large"
+	CodeUnsupportedMedia = "unsupported_media_type"
+	CodePrecondition     = "precondition_failed"
+	CodeNoPrecondition   = "precondition_required"
+	CodeValidationFailed = "validation_failed"
+	CodeTooManyRequests  = "too_many_requests"
+	CodeCanceled         = "request_canceled"
+	CodeInternal         = "internal"
+	CodeUnavailable      = "unavailable"
+	CodeTimeout          = "timeout"
+)
+
+// StatusClientClosedRequest — нестандартный статус (nginx) для отменённых клиентом запросов.
+const StatusClientClosedRequest = 499
+
+// ProblemType описывает, как ошибка превращается в problem+json.
+type ProblemType struct {
+	Err    error             `json:"-"`
+	Status int               `json:"status"`
+	Code   string            `json:"code"`
+	Titles map[string]string `json:"titles"`
+	// ExposeDetail — отдавать текст ошибки клиенту в detail
+	// (ошибки валидации домена, где текст и есть объяснение).
+	ExposeDetail bool `json:"-"`
+}
+
+// Title возвращает заголовок на языке lang, иначе на DefaultLang.
+func (t ProblemType) Title(lang string) string {
+	if s, ok := t.Titles[lang]; ok {
+		return s
+	}
+	return t.Titles[DefaultLang]
+}
+
+// ProblemRegistry сопоставляет sentinel-ошибкам статус, стабильный код и заголовки.
+// Поиск идёт по errors.Is в порядке регистрации; общие ошибки app_error
+// проверяются последними, чтобы доменные ошибки имели приоритет.
+type ProblemRegistry struct {
+	docsURL  string
+	mu       sync.RWMutex
+	types    []ProblemType
+	fallback []ProblemType
+	byStatus map[int]ProblemType
+}
+
+func NewProblemRegistry(docsURL string) *ProblemRegistry {
+	r := &ProblemRegistry{docsURL: strings.TrimRight(docsURL, "/"), byStatus: map[int]ProblemType{}}
+	for _, t := range []ProblemType{
+		{Status: http.StatusBadRequest, Code: CodeBadRequest, Titles: titles("bad request", "некорректный запрос", "қате сұрау")},
+		{Status: http.StatusUnauthorized, Code: CodeUnauthorized, Titles: titles("unauthorized", "требуется авторизация", "авторизация қажет")},
+		{Status: http.StatusForbidden, Code: CodeForbidden, Titles: titles("forbidden", "доступ запрещён", "кіруге тыйым салынған")},
+		{Status: http.StatusNotFound, Code: CodeNotFound, Titles: titles("not found", "не найдено", "табылмады")},
+		{Status: http.StatusMethodNotAllowed, Code: CodeMethodNotAllowed, Titles: titles("method not allowed", "метод не поддерживается", "әдіс қолдау көрсетілмейді")},
+		{Status: http.StatusConflict, Code: CodeConflict, Titles: titles("conflict", "конфликт", "қайшылық")},
+		{Status: http.StatusPreconditionFailed, Code: CodePrecondition, Titles: titles("precondition failed", "условие запроса не выполнено", "сұрау шарты орындалмады")},
+		{Status: http.StatusRequestEntityTooLarge, Code: CodeTooLarge, Titles: titles("payload too large", "слишком большой запрос", "сұрау тым үлкен")},
+		{Status: http.StatusUnsupportedMediaType, Code: CodeUnsupportedMedia, Titles: titles("unsupported media type", "неподдерживаемый формат тела", "дене пішімі қолдау көрсетілмейді")},
+		{Status: http.StatusUnprocessableEntity, Code: CodeValidationFailed, Titles: titles("validation failed", "ошибка валидации", "тексеру сәтсіз аяқталды")},
+		{Status: http.StatusPreconditionRequired, Code: CodeNoPrecondition, Titles: titles("precondition required", "требуется заголовок If-Match", "If-Match тақырыбы қажет")},
+		{Status: http.StatusTooManyRequests, Code: CodeTooManyRequests, Titles: titles("too many requests", "слишком много запросов", "сұраулар тым көп")},
+		{Status: StatusClientClosedRequest, Code: CodeCanceled, Titles: titles("request canceled", "запрос отменён", "сұрау тоқтатылды")},
+		{Status: http.StatusInternalServerError, Code: CodeInternal, Titles: titles("internal server error", "внутренняя ошибка сервера", "сервердің ішкі қатесі")},
+		{Status: http.StatusServiceUnavailable, Code: CodeUnavailable, Titles: titles("service unavailable", "сервис недоступен", "сервис қолжетімсіз")},
+		{Status: http.StatusGatewayTimeout, Code: CodeTimeout, Titles: titles("operation timeout", "превышено время ожидания", "күту уақыты асып кетті")},
+	} {
+		r.byStatus[t.Status] = t
+	}
+	// тело могут обрезать посреди разбора доменного формата (снимок, JSON),
+	// поэтому превышение лимита проверяется раньше доменных ошибок
+	tooLarge := r.byStatus[http.StatusRequestEntityTooLarge]
+	tooLarge.Err = ErrBodyTooLarge
+	r.types = append(r.types, tooLarge)
+	for err, status := range map[error]int{
+		app_error.ErrBadRequest:           http.StatusBadRequest,
+		app_error.ErrNotFound:             http.StatusNotFound,
+		app_error.ErrConflict:             http.StatusConflict,
+		app_error.ErrVersionConflict:      http.StatusPreconditionFailed,
+		app_error.ErrPreconditionRequired: http.StatusPreconditionRequired,
+		app_error.ErrValidation:           http.StatusUnprocessableEntity,
+		app_error.ErrCanceled:             StatusClientClosedRequest,
+		app_error.ErrInternal:             http.StatusInternalServerError,
+		app_error.ErrTimeout:              http.StatusGatewayTimeout,
+	} {
+		t := r.byStatus[status]
+		t.Err = err
+		r.fallback = append(r.fallback, t)
+	}
+	return r
+}
+
+// Problems — реестр по умолчанию; доменные ошибки регистрируются в нём транспортным слоем.
+var Problems = NewProblemRegistry(DefaultDocsURL)
+
+func titles(en, ru, kz string) map[string]string {
+	return map[string]string{LangEN: en, LangRU: ru, LangKZ: kz}
+}
+
+// Register добавляет описания ошибок. Один код может соответствовать нескольким ошибкам.
+func (r *ProblemRegistry) Register(types ...ProblemType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types = append(r.types, types...)
+}
+
+// Lookup находит описание ошибки.
+func (r *ProblemRegistry) Lookup(err error) (ProblemType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, list := range [][]ProblemType{r.types, r.fallback} {
+		for _, t := range list {
+			if errors.Is(err, t.Err) {
+				return t, true
+			}
+		}
+	}
+	return ProblemType{}, false
+}
+
+// Catalog возвращает все известные коды, по одному описанию на код, отсортированные по коду.
+func (r *ProblemRegistry) Catalog() []ProblemType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[string]struct{})
+	var res []ProblemType
+	add := func(t ProblemType) {
+		if _, ok := seen[t.Code]; ok {
+			return
+		}
+		seen[t.Code] = struct{}{}
+		res = append(res, t)
+	}
+	for _, t := range r.types {
+		add(t)
+	}
+	for _, t := range r.byStatus {
+		add(t)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Code < res[j].Code })
+	return res
+}
+
+// Problem строит problem-документ для ошибки; незарегистрированные ошибки — 500.
+func (r *ProblemRegistry) Problem(req *http.Request, err error) ErrorResponse {
+	lang := Lang(req)
+	t, ok := r.Lookup(err)
+	if !ok {
+		t = r.byStatus[http.StatusInternalServerError]
+	}
+	p := r.fromType(t, lang)
+	if t.ExposeDetail {
+		p.Detail = err.Error()
+		p.Message = p.Detail
+	}
+	p.Instance = req.URL.Path
+	p.RequestID = middleware.GetReqID(req.Context())
+	return p
+}
+
+// Write пишет ошибку в ответ и возвращает выбранный статус.
+func (r *ProblemRegistry) Write(w http.ResponseWriter, req *http.Request, err error) int {
+	p := r.Problem(req, err)
+	WriteProblem(w, p)
+	return p.Status
+}
+
+func (r *ProblemRegistry) statusProblem(status int, lang string) ErrorResponse {
+	t, ok := r.byStatus[status]
+	if !ok {
+		t = ProblemType{Status: status, Code: CodeInternal, Titles: map[string]string{LangEN: strings.ToLower(http.StatusText(status))}}
+		if status < http.StatusInternalServerError {
+			t.Code = CodeBadRequest
+		}
+	}
+	return r.fromType(t, lang)
+}
+
+func (r *ProblemRegistry) fromType(t ProblemType, lang string) ErrorResponse {
+	return ErrorResponse{
+		Type:    r.typeURL(t.Code),
+		Title:   t.Title(lang),
+		Status:  t.Status,
+		Code:    t.Code,
+		Message: t.Title(DefaultLang),
+	}
+}
+
+func (r *ProblemRegistry) typeURL(code string) string {
+	return r.docsURL + "/" + code
+}
+
+// Lang выбирает язык ответа (?lang= или Accept-Language), иначе DefaultLang.
+func Lang(r *http.Request) string {
+	if loc, ok := i18n.Negotiate(r); ok {
+		return string(loc)
+	}
+	return DefaultLang
+}
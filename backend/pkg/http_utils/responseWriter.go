@@ -5,17 +5,34 @@ import (
 	"net/http"
 )
 
+// ErrorResponse — тело ошибки в формате RFC 7807 (application/problem+json).
 type ErrorResponse struct {
+	Type      string       `json:"type" example:"/api/errors/bad_request"`
+	Title     string       `json:"title" example:"bad request"`
+	Status    int          `json:"status" example:"400"`
+	Code      string       `json:"code" example:"bad_request"`
+	Detail    string       `json:"detail,omitempty" example:"Invalid request"`
+	Instance  string       `json:"instance,omitempty" example:"/api/product/1"`
+	RequestID string       `json:"request_id,omitempty" example:"host/abc-000001"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Message дублирует detail (или title) для клиентов старого формата.
 	Message string `json:"message" example:"Invalid request"`
 }
 
-// WriteError writes a JSON error response using the standard encoding/json package.
+// WriteError writes an RFC 7807 problem with a generic code derived from the status.
 func WriteError(w http.ResponseWriter, statusCode int, msg string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(ErrorResponse{
-		Message: msg,
-	}); err != nil {
+	p := Problems.statusProblem(statusCode, DefaultLang)
+	p.Detail = msg
+	p.Message = msg
+	p.RequestID = w.Header().Get(RequestIDHeader)
+	WriteProblem(w, p)
+}
+
+// WriteProblem writes a prepared problem document.
+func WriteProblem(w http.ResponseWriter, p ErrorResponse) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
 		http.Error(w, "failed to encode error response", http.StatusInternalServerError)
 		return
 	}
@@ -2,13 +2,23 @@
 package http_utils
 
 import (
-	"encoding/json"
 	"net/http"
+
+	"github.com/Neimess/zorkin-store-project/pkg/i18n"
 )
 
+// FieldError — ошибка одного поля. Code — стабильный код сообщения, по нему
+// клиент может показать свой текст; Message — английский текст.
 type FieldError struct {
 	Field   string `json:"field"`
+	Code    string `json:"code,omitempty" example:"field.invalid"`
 	Message string `json:"message"`
+	msg     i18n.Message
+}
+
+// NewFieldError — ошибка поля field с сообщением из каталога i18n.
+func NewFieldError(field string, m i18n.Message) FieldError {
+	return FieldError{Field: field, Code: m.Code, Message: m.String(), msg: m}
 }
 
 type ValidationErrorResponse struct {
@@ -19,8 +29,11 @@ func (v ValidationErrorResponse) Error() string {
 	return "validation failed"
 }
 
+// WriteValidationError пишет problem+json с кодом validation_failed и ошибками полей.
 func WriteValidationError(w http.ResponseWriter, statusCode int, resp ValidationErrorResponse) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	_ = json.NewEncoder(w).Encode(resp)
+	p := Problems.statusProblem(statusCode, DefaultLang)
+	p.Errors = resp.Errors
+	p.Message = resp.Error()
+	p.RequestID = w.Header().Get(RequestIDHeader)
+	WriteProblem(w, p)
 }
@@ -0,0 +1,97 @@
+// Package i18n — локали, выбор языка запроса и каталог сообщений API.
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	RU Locale = "ru"
+	EN Locale = "en"
+	KZ Locale = "kz"
+
+	// Default — язык основного контента каталога.
+	Default = RU
+)
+
+// Supported перечисляет поддерживаемые локали, язык по умолчанию — первым.
+var Supported = []Locale{RU, EN, KZ}
+
+// Parse разбирает код языка ("en", "en-US", "kk-KZ"). Для казахского
+// принимается и код ISO 639-1 "kk", и принятый в проекте "kz".
+func Parse(s string) (Locale, bool) {
+	base := strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(base, "-_"); i >= 0 {
+		base = base[:i]
+	}
+	switch base {
+	case "ru":
+		return RU, true
+	case "en":
+		return EN, true
+	case "kz", "kk":
+		return KZ, true
+	}
+	return "", false
+}
+
+// Negotiate выбирает локаль по параметру ?lang=, затем по Accept-Language
+// с учётом q-весов. ok=false — клиент не запросил ни одну поддерживаемую локаль.
+func Negotiate(r *http.Request) (loc Locale, ok bool) {
+	if v := r.URL.Query().Get("lang"); v != "" {
+		if loc, ok := Parse(v); ok {
+			return loc, true
+		}
+	}
+	return fromAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+func fromAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		loc Locale
+		q   float64
+	}
+	var cs []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		loc, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			cs = append(cs, candidate{loc, q})
+		}
+	}
+	if len(cs) == 0 {
+		return "", false
+	}
+	sort.SliceStable(cs, func(i, j int) bool { return cs[i].q > cs[j].q })
+	return cs[0].loc, true
+}
+
+type ctxKey struct{}
+
+// WithLocale сохраняет локаль запроса в контексте.
+func WithLocale(ctx context.Context, loc Locale) context.Context {
+	return context.WithValue(ctx, ctxKey{}, loc)
+}
+
+// FromContext возвращает локаль из контекста или Default.
+func FromContext(ctx context.Context) Locale {
+	if loc, ok := ctx.Value(ctxKey{}).(Locale); ok {
+		return loc
+	}
+	return Default
+}
@@ -0,0 +1,161 @@
+package i18n
+
+import "fmt"
+
+// Message — сообщение API со стабильным кодом. Переводы ищутся по коду, а не
+// по английскому тексту, так что правка формулировки их не теряет. Text —
+// английский текст, он же message для клиентов старого формата.
+type Message struct {
+	Code string
+	Text string
+	args []any
+}
+
+// With подставляет аргументы в текст сообщения и его переводы (как fmt.Sprintf).
+func (m Message) With(args ...any) Message {
+	m.args = args
+	return m
+}
+
+// String возвращает английский текст с подставленными аргументами.
+func (m Message) String() string {
+	if len(m.args) == 0 {
+		return m.Text
+	}
+	return fmt.Sprintf(m.Text, m.args...)
+}
+
+// Raw — сообщение без кода, например текст ошибки домена. Оно не
+// переводится и отдаётся как есть.
+func Raw(text string) Message {
+	return Message{Text: text}
+}
+
+// T переводит сообщение на локаль loc; без перевода возвращает английский текст.
+func T(loc Locale, m Message) string {
+	s, ok := translations[m.Code][loc]
+	if !ok {
+		return m.String()
+	}
+	if len(m.args) == 0 {
+		return s
+	}
+	return fmt.Sprintf(s, m.args...)
+}
+
+// translations — переводы сообщений по коду.
+var translations = map[string]map[Locale]string{}
+
+func msg(code, en, ru, kz string) Message {
+	if _, dup := translations[code]; dup {
+		panic("i18n: duplicate message code " + code)
+	}
+	translations[code] = map[Locale]string{RU: ru, KZ: kz}
+	return Message{Code: code, Text: en}
+}
+
+// Тело и заголовки запроса.
+var (
+	MsgInvalidJSON           = msg("request.invalid_json", "invalid JSON", "некорректный JSON", "JSON қате")
+	MsgBodyUnreadable        = msg("request.body_unreadable", "failed to read request body", "не удалось прочитать тело запроса", "сұрау денесін оқу мүмкін болмады")
+	MsgBodyTooLarge          = msg("request.body_too_large", "request body too large", "тело запроса слишком большое", "сұрау денесі тым үлкен")
+	MsgPatchMediaType        = msg("patch.unsupported_media_type", "unsupported patch media type", "неподдерживаемый формат документа изменений", "өзгерістер құжатының пішімі қолдау көрсетілмейді")
+	MsgPatchSchemaMismatch   = msg("patch.schema_mismatch", "patched document does not match resource schema", "документ после изменений не соответствует схеме ресурса", "өзгерістерден кейінгі құжат ресурс схемасына сәйкес келмейді")
+	MsgIfMatchRequired       = msg("precondition.if_match_required", "If-Match header is required", "нужен заголовок If-Match", "If-Match тақырыбы қажет")
+	MsgIfMatchMismatch       = msg("precondition.if_match_mismatch", "If-Match does not match the current version", "If-Match не совпадает с текущей версией", "If-Match ағымдағы нұсқаға сәйкес келмейді")
+	MsgIfNoneMatchWildcard   = msg("precondition.if_none_match_wildcard", "only If-None-Match: * is supported", "поддерживается только If-None-Match: *", "тек If-None-Match: * қолдау көрсетіледі")
+	MsgInvalidSubscriptionID = msg("webhook.invalid_subscription_id", "invalid subscription id", "некорректный id подписки", "жазылым id қате")
+	MsgInvalidDeliveryID     = msg("webhook.invalid_delivery_id", "invalid delivery id", "некорректный id доставки", "жеткізу id қате")
+)
+
+// Ошибки полей.
+var (
+	MsgInvalidField = msg("field.invalid", "invalid field", "некорректное поле", "өріс қате")
+
+	MsgName1To100          = msg("name.required_1_100", "name is required and must be 1-100 chars", "name обязательно, от 1 до 100 символов", "name міндетті, 1-ден 100 таңбаға дейін")
+	MsgName1To255          = msg("name.required_1_255", "name is required and must be 1-255 chars", "name обязательно, от 1 до 255 символов", "name міндетті, 1-ден 255 таңбаға дейін")
+	MsgName2To100          = msg("name.required_2_100", "name is required and must be 2-100 chars", "name обязательно, от 2 до 100 символов", "name міндетті, 2-ден 100 таңбаға дейін")
+	MsgName2To255          = msg("name.required_2_255", "name is required and must be 2-255 chars", "name обязательно, от 2 до 255 символов", "name міндетті, 2-ден 255 таңбаға дейін")
+	MsgOptionalName2To100  = msg("name.length_2_100", "name must be 2-100 chars", "name должно быть от 2 до 100 символов", "name 2-ден 100 таңбаға дейін болуы керек")
+	MsgValueRequired       = msg("value.required", "value is required", "value обязательно", "value міндетті")
+	MsgValuePositive       = msg("value.positive", "value is required and must be > 0", "value обязательно и должно быть больше 0", "value міндетті және 0-ден үлкен болуы керек")
+	MsgUnitTooLong         = msg("unit.too_long", "unit must not exceed 50 characters", "unit не длиннее 50 символов", "unit 50 таңбадан аспауы керек")
+	MsgParentID            = msg("parent_id.invalid", "parent_id may be does not exists", "parent_id, возможно, не существует", "parent_id жоқ болуы мүмкін")
+	MsgPricePositive       = msg("price.positive", "price is required and must be > 0", "price обязательна и должна быть больше 0", "price міндетті және 0-ден үлкен болуы керек")
+	MsgCategoryIDPositive  = msg("category_id.positive", "category_id is required and must be >0", "category_id обязательно и должно быть больше 0", "category_id міндетті және 0-ден үлкен болуы керек")
+	MsgDescription2To1000  = msg("description.length_2_1000", "description must be 2-1000 chars if set", "description, если задано, от 2 до 1000 символов", "description берілсе, 2-ден 1000 таңбаға дейін")
+	MsgImageURL            = msg("image_url.invalid", "image_url must be a valid URL", "image_url должен быть корректным URL", "image_url дұрыс URL болуы керек")
+	MsgInvalidAttributes   = msg("product.attributes_invalid", "invalid existing attributes", "некорректные атрибуты", "атрибуттар қате")
+	MsgInvalidServices     = msg("product.services_invalid", "invalid services", "некорректные услуги", "қызметтер қате")
+	MsgServiceIDPositive   = msg("service_id.positive", "service_id must be >0", "service_id должно быть больше 0", "service_id 0-ден үлкен болуы керек")
+	MsgRelatedProductID    = msg("relation.related_product_id", "related_product_id is required and must be >0", "related_product_id обязательно и должно быть больше 0", "related_product_id міндетті және 0-ден үлкен болуы керек")
+	MsgRelationType        = msg("relation.type", "type must be one of: accessory, alternative, compatible_with, required", "type должен быть одним из: accessory, alternative, compatible_with, required", "type мына мәндердің бірі болуы керек: accessory, alternative, compatible_with, required")
+	MsgProductIDRequired   = msg("product_id.required", "product_id is required", "product_id обязательно", "product_id міндетті")
+	MsgProductIDPositive   = msg("product_id.positive", "product_id must be positive", "product_id должно быть положительным", "product_id оң сан болуы керек")
+	MsgPresetIDPositive    = msg("preset_id.positive", "preset_id must be positive", "preset_id должно быть положительным", "preset_id оң сан болуы керек")
+	MsgEntityIDPositive    = msg("entity_id.positive", "entity_id must be a positive integer", "entity_id должно быть положительным целым числом", "entity_id оң бүтін сан болуы керек")
+	MsgMustBePositive      = msg("field.positive", "%s must be greater than 0", "%s должно быть больше 0", "%s 0-ден үлкен болуы керек")
+	MsgVersionPositive     = msg("version.positive", "version must be positive", "version должен быть положительным", "version оң сан болуы керек")
+	MsgTranslationNoFields = msg("translation.fields_empty", "fields must not be empty", "fields не может быть пустым", "fields бос болмауы керек")
+	MsgRatePositive        = msg("currency.rate_positive", "rate is required and must be > 0", "rate обязателен и должен быть больше 0", "rate міндетті және 0-ден үлкен болуы керек")
+)
+
+// Пресеты и конфигуратор.
+var (
+	MsgPresetNoItems        = msg("preset.items_empty", "items must contain at least one item", "items должен содержать хотя бы одну позицию", "items кемінде бір позициядан тұруы керек")
+	MsgQuantityPositive     = msg("preset.quantity_positive", "quantity must be greater than 0", "quantity должно быть больше 0", "quantity 0-ден үлкен болуы керек")
+	MsgQuantityFormulaLong  = msg("preset.quantity_formula_too_long", "quantity_formula must be at most 255 characters", "quantity_formula не длиннее 255 символов", "quantity_formula 255 таңбадан аспауы керек")
+	MsgSlotNameTooLong      = msg("preset.slot_name_too_long", "slot_name must be at most 100 characters", "slot_name не длиннее 100 символов", "slot_name 100 таңбадан аспауы керек")
+	MsgAlternativesInvalid  = msg("preset.alternatives_invalid", "alternatives must contain valid product IDs", "alternatives должен содержать корректные id товаров", "alternatives дұрыс тауар id-лерінен тұруы керек")
+	MsgTotalPricePositive   = msg("preset.total_price_positive", "total_price must be greater than 0", "total_price должна быть больше 0", "total_price 0-ден үлкен болуы керек")
+	MsgOpeningsAreaNegative = msg("preset.openings_area_negative", "openings_area must not be negative", "openings_area не может быть отрицательной", "openings_area теріс болмауы керек")
+	MsgSlotIDRequired       = msg("preset.slot_id_required", "slot_id is required", "slot_id обязательно", "slot_id міндетті")
+	MsgProductOrOmit        = msg("preset.product_or_omit", "product_id and omit are mutually exclusive", "product_id и omit нельзя задавать вместе", "product_id және omit бірге берілмейді")
+)
+
+// Отзывы.
+var (
+	MsgAuthorName    = msg("review.author_name", "author_name is required and must be 1-100 chars", "author_name обязательно, от 1 до 100 символов", "author_name міндетті, 1-ден 100 таңбаға дейін")
+	MsgRating        = msg("review.rating", "rating must be between 1 and 5", "rating должен быть от 1 до 5", "rating 1-ден 5-ке дейін болуы керек")
+	MsgReviewText    = msg("review.text_too_long", "text must be at most 5000 chars", "text не длиннее 5000 символов", "text 5000 таңбадан аспауы керек")
+	MsgTooManyPhotos = msg("review.too_many_photos", "at most 5 photos are allowed", "допускается не больше 5 фотографий", "5 фотодан артық болмауы керек")
+	MsgPhotoURL      = msg("review.photo_url", "each photo must be a valid URL", "каждое фото должно быть корректным URL", "әр фото дұрыс URL болуы керек")
+	MsgRejectReason  = msg("review.reason_too_long", "reason must be at most 500 chars", "reason не длиннее 500 символов", "reason 500 таңбадан аспауы керек")
+)
+
+// Заявки.
+var (
+	MsgLeadKind      = msg("lead.kind", "kind must be callback or consultation", "kind должен быть callback или consultation", "kind callback немесе consultation болуы керек")
+	MsgPhoneRequired = msg("lead.phone_required", "phone is required", "phone обязателен", "phone міндетті")
+	MsgEmail         = msg("lead.email", "email must be a valid address", "email должен быть корректным адресом", "email дұрыс мекенжай болуы керек")
+	MsgLeadMessage   = msg("lead.message_too_long", "message must be at most 2000 chars", "message не длиннее 2000 символов", "message 2000 таңбадан аспауы керек")
+	MsgLeadStatus    = msg("lead.status", "status must be one of new, in_progress, done, cancelled, spam", "status должен быть одним из: new, in_progress, done, cancelled, spam", "status мына мәндердің бірі болуы керек: new, in_progress, done, cancelled, spam")
+	MsgLeadNote      = msg("lead.note_too_long", "note must be at most 1000 chars", "note не длиннее 1000 символов", "note 1000 таңбадан аспауы керек")
+	MsgPromoCode     = msg("lead.promo_code", "promo_code must be 3-32 chars", "promo_code от 3 до 32 символов", "promo_code 3-тен 32 таңбаға дейін болуы керек")
+)
+
+// Скидки.
+var (
+	MsgDiscountKind     = msg("discount.kind", "kind must be percent or fixed", "kind должен быть percent или fixed", "kind percent немесе fixed болуы керек")
+	MsgDiscountTarget   = msg("discount.target", "target must be one of product, category, preset, service", "target должен быть одним из: product, category, preset, service", "target мына мәндердің бірі болуы керек: product, category, preset, service")
+	MsgDiscountTargetID = msg("discount.target_id", "target_id must be positive", "target_id должно быть положительным", "target_id оң сан болуы керек")
+	MsgPromoCodeCode    = msg("discount.code", "code is required and must be 3-32 chars", "code обязателен, от 3 до 32 символов", "code міндетті, 3-тен 32 таңбаға дейін")
+	MsgUsageLimit       = msg("discount.usage_limit", "usage_limit must be positive", "usage_limit должно быть положительным", "usage_limit оң сан болуы керек")
+)
+
+// Вебхуки.
+var (
+	MsgWebhookURL    = msg("webhook.url", "url is required and must be a valid url", "url обязателен и должен быть корректным адресом", "url міндетті және дұрыс мекенжай болуы керек")
+	MsgWebhookSecret = msg("webhook.secret", "secret must be 16-256 chars", "secret от 16 до 256 символов", "secret 16-дан 256 таңбаға дейін болуы керек")
+	MsgWebhookEvents = msg("webhook.events", "events must list at least one event type", "events должен содержать хотя бы один тип события", "events кемінде бір оқиға түрін қамтуы керек")
+)
+
+// Пакетные операции.
+var (
+	MsgBatchEmpty         = msg("batch.items_empty", "items must contain at least one operation", "items должен содержать хотя бы одну операцию", "items кемінде бір операциядан тұруы керек")
+	MsgBatchTooMany       = msg("batch.items_too_many", "items must contain at most %d operations", "items должен содержать не больше %d операций", "items %d операциядан аспауы керек")
+	MsgBatchOp            = msg("batch.op", "op must be create, update or delete", "op должен быть create, update или delete", "op create, update немесе delete болуы керек")
+	MsgBatchIDRequired    = msg("batch.id_required", "id is required for update and delete", "для update и delete нужен id", "update және delete үшін id қажет")
+	MsgBatchDataRequired  = msg("batch.data_required", "data is required for create and update", "для create и update нужен data", "create және update үшін data қажет")
+	MsgBatchVersionCreate = msg("batch.version_on_create", "version is allowed only for update and delete", "version передаётся только для update и delete", "version тек update және delete үшін беріледі")
+)
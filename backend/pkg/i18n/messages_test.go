@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "некорректный JSON", T(RU, MsgInvalidJSON))
+	assert.Equal(t, "JSON қате", T(KZ, MsgInvalidJSON))
+	assert.Equal(t, "invalid JSON", T(EN, MsgInvalidJSON))
+
+	// перевод ищется по коду: правка английского текста его не теряет
+	edited := MsgInvalidJSON
+	edited.Text = "malformed JSON"
+	assert.Equal(t, "некорректный JSON", T(RU, edited))
+	assert.Equal(t, "malformed JSON", T(EN, edited))
+
+	many := MsgBatchTooMany.With(100)
+	assert.Equal(t, "items must contain at most 100 operations", many.String())
+	assert.Equal(t, "items должен содержать не больше 100 операций", T(RU, many))
+
+	raw := Raw("quantity formula: unknown variable x")
+	assert.Empty(t, raw.Code)
+	assert.Equal(t, raw.Text, T(RU, raw))
+}
+
+func TestTranslationsCoverEveryLocale(t *testing.T) {
+	for code, tr := range translations {
+		for _, loc := range []Locale{RU, KZ} {
+			assert.NotEmpty(t, tr[loc], "%s has no %s translation", code, loc)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
 	"os"
 
@@ -14,14 +15,26 @@ const (
 )
 
 func MustInitLogger(env string) *slog.Logger {
+	return MustInitLoggerTo(env, os.Stdout)
+}
+
+// MustInitLoggerTo — то же, что MustInitLogger, но пишет в w: команды,
+// которые выводят данные в stdout, пишут журнал в stderr.
+func MustInitLoggerTo(env string, w io.Writer) *slog.Logger {
+	return MustInitLoggerLevel(env, w, DefaultLevel(env))
+}
+
+// MustInitLoggerLevel — логгер окружения env с уровнем level; с *slog.LevelVar
+// уровень можно менять, не пересоздавая логгер.
+func MustInitLoggerLevel(env string, w io.Writer, level slog.Leveler) *slog.Logger {
 	var log *slog.Logger
 	switch env {
 	case ENVLocal:
 		log = slog.New(
 			slogcolor.NewHandler(
-				os.Stdout,
+				w,
 				&slogcolor.Options{
-					Level:       slog.LevelDebug,
+					Level:       level,
 					TimeFormat:  slogcolor.DefaultOptions.TimeFormat,
 					SrcFileMode: slogcolor.ShortFile,
 				},
@@ -29,18 +42,18 @@ func MustInitLogger(env string) *slog.Logger {
 	case ENVDev:
 		log = slog.New(
 			slog.NewJSONHandler(
-				os.Stdout,
+				w,
 				&slog.HandlerOptions{
-					Level:     slog.LevelDebug,
+					Level:     level,
 					AddSource: true,
 				}),
 		)
 	case ENVProd:
 		log = slog.New(
 			slog.NewJSONHandler(
-				os.Stdout,
+				w,
 				&slog.HandlerOptions{
-					Level: slog.LevelInfo,
+					Level: level,
 				}),
 		)
 	default:
@@ -50,6 +63,14 @@ func MustInitLogger(env string) *slog.Logger {
 	return log
 }
 
+// DefaultLevel — уровень журнала окружения, если он не задан явно.
+func DefaultLevel(env string) slog.Level {
+	if env == ENVProd {
+		return slog.LevelInfo
+	}
+	return slog.LevelDebug
+}
+
 func WithComponent(logger *slog.Logger, name string) *slog.Logger {
 	if logger == nil {
 		logger = slog.Default()
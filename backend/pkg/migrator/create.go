@@ -0,0 +1,48 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create заводит в dir пару пустых файлов миграции со следующим номером:
+// 0022_add_slots.up.sql и 0022_add_slots.down.sql. Имя приводится к snake_case.
+func Create(dir, name string) (up, down string, err error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", errors.New("migration name is empty")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("read migrations dir: %w", err)
+	}
+	var last uint
+	for _, e := range entries {
+		if m, err := source.Parse(e.Name()); err == nil && m.Version > last {
+			last = m.Version
+		}
+	}
+
+	base := fmt.Sprintf("%04d_%s", last+1, name)
+	up = filepath.Join(dir, base+".up.sql")
+	down = filepath.Join(dir, base+".down.sql")
+	for _, p := range []string{up, down} {
+		f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+		if err := f.Close(); err != nil {
+			return "", "", err
+		}
+	}
+	return up, down, nil
+}
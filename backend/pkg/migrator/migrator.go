@@ -2,6 +2,7 @@ package migrator
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/Neimess/zorkin-store-project/migrations"
@@ -18,6 +19,8 @@ const (
 	Up Mode = iota
 	DownOne
 	ForceTo
+	// Goto переводит схему на Options.Version: вперёд или назад, по шагу за миграцию.
+	Goto
 )
 
 type Options struct {
@@ -69,6 +72,13 @@ func Run(dsn string, opts Options) (err error) {
 		if err := m.Force(opts.Version); err != nil {
 			return err
 		}
+	case Goto:
+		if opts.Version < 0 {
+			return fmt.Errorf("invalid target version: %d", opts.Version)
+		}
+		if err := m.Migrate(uint(opts.Version)); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown migration mode: %v", opts.Mode)
 	}
@@ -0,0 +1,42 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	list, err := Embedded()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+
+	assert.Equal(t, uint(1), list[0].Version)
+	assert.Equal(t, "create_schema", list[0].Name)
+	for i := 1; i < len(list); i++ {
+		assert.Equal(t, list[i-1].Version+1, list[i].Version, "migrations must be numbered without gaps")
+	}
+
+	latest, err := Latest()
+	require.NoError(t, err)
+	assert.Equal(t, list[len(list)-1].Version, latest)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0007_old.up.sql"), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0007_old.down.sql"), nil, 0o600))
+
+	up, down, err := Create(dir, "Add Preset-Slots")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0008_add_preset_slots.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "0008_add_preset_slots.down.sql"), down)
+	assert.FileExists(t, up)
+	assert.FileExists(t, down)
+
+	_, _, err = Create(dir, "  --  ")
+	assert.Error(t, err)
+}
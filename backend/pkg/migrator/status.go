@@ -0,0 +1,124 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/Neimess/zorkin-store-project/migrations"
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// Migration — миграция, встроенная в бинарник.
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Status — состояние схемы относительно встроенных миграций.
+type Status struct {
+	Current uint
+	Dirty   bool
+	Latest  uint
+	// Migrations — все встроенные миграции по возрастанию версии.
+	Migrations []Migration
+}
+
+// Embedded возвращает встроенные миграции по возрастанию версии.
+func Embedded() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+	byVersion := make(map[uint]Migration)
+	for _, e := range entries {
+		m, err := source.Parse(e.Name())
+		if err != nil {
+			continue
+		}
+		byVersion[m.Version] = Migration{Version: m.Version, Name: m.Identifier}
+	}
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Latest возвращает версию последней миграции, встроенной в бинарник.
+func Latest() (uint, error) {
+	list, err := Embedded()
+	if err != nil {
+		return 0, err
+	}
+	if len(list) == 0 {
+		return 0, errors.New("no embedded migrations")
+	}
+	return list[len(list)-1].Version, nil
+}
+
+// Current читает версию схемы из таблицы golang-migrate. Если миграции ещё
+// не применялись, возвращает 0.
+func Current(ctx context.Context, db *sql.DB) (version uint, dirty bool, err error) {
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		return 0, false, nil
+	}
+	err = db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Check сверяет версию схемы в БД со встроенными миграциями: ошибка, если
+// миграция не доведена (dirty) или схема отстаёт либо опережает бинарник.
+func Check(ctx context.Context, db *sql.DB, expected uint) error {
+	version, dirty, err := Current(ctx, db)
+	if err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty", version)
+	}
+	if version != expected {
+		return fmt.Errorf("schema version %d, expected %d", version, expected)
+	}
+	return nil
+}
+
+// GetStatus сопоставляет версию схемы в БД со встроенными миграциями.
+// golang-migrate хранит только текущую версию, поэтому применёнными
+// считаются все миграции до неё включительно.
+func GetStatus(ctx context.Context, dsn string) (Status, error) {
+	list, err := Embedded()
+	if err != nil {
+		return Status{}, err
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return Status{}, fmt.Errorf("open db: %w", err)
+	}
+	defer db.Close()
+
+	current, dirty, err := Current(ctx, db)
+	if err != nil {
+		return Status{}, fmt.Errorf("read schema version: %w", err)
+	}
+	st := Status{Current: current, Dirty: dirty, Migrations: list}
+	for i := range st.Migrations {
+		st.Migrations[i].Applied = st.Migrations[i].Version <= current
+	}
+	if len(list) > 0 {
+		st.Latest = list[len(list)-1].Version
+	}
+	return st, nil
+}
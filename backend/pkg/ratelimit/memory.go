@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery — как часто MemoryStore удаляет наполнившиеся корзины.
+const sweepEvery = time.Minute
+
+// MemoryStore хранит корзины в памяти процесса: у каждого экземпляра
+// сервиса свои счётчики.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweepAt time.Time
+}
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+	// full — когда корзина наполнится; после этого её можно забыть.
+	full time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, l Limit) (Result, error) {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.After(s.sweepAt) {
+		for k, b := range s.buckets {
+			if !now.Before(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.sweepAt = now.Add(sweepEvery)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.Capacity()), at: now}
+		s.buckets[key] = b
+	}
+	b.tokens = l.refill(b.tokens, now.Sub(b.at))
+	b.at = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	res := l.result(b.tokens, allowed)
+	b.full = now.Add(res.Reset)
+	return res, nil
+}
@@ -0,0 +1,85 @@
+// Package ratelimit — ограничение частоты запросов по алгоритму token bucket.
+//
+// У каждого ключа (IP, subject токена) своя корзина ёмкостью Burst токенов,
+// которая пополняется на Requests токенов за Per. Запрос забирает токен;
+// пустая корзина — отказ до появления следующего токена. Корзины хранит
+// Store: в памяти процесса или в Redis, если экземпляров несколько.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit — политика ограничения. Нулевые Requests или Per выключают её.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	// Burst — ёмкость корзины, сколько запросов можно сделать подряд;
+	// 0 — равна Requests.
+	Burst int
+}
+
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// Capacity — ёмкость корзины с учётом Burst == 0.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// interval — за сколько восстанавливается один токен.
+func (l Limit) interval() time.Duration {
+	return l.Per / time.Duration(l.Requests)
+}
+
+// refill возвращает число токенов через elapsed после tokens, не больше ёмкости.
+func (l Limit) refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += float64(elapsed) / float64(l.interval())
+	}
+	return math.Min(tokens, float64(l.Capacity()))
+}
+
+// result описывает состояние корзины, в которой осталось tokens.
+func (l Limit) result(tokens float64, allowed bool) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     l.Capacity(),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     l.duration(float64(l.Capacity()) - tokens),
+	}
+	if !allowed {
+		res.RetryAfter = l.duration(1 - tokens)
+	}
+	return res
+}
+
+func (l Limit) duration(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens * float64(l.interval())))
+}
+
+// Result — итог попытки взять токен.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter — через сколько появится токен; только при отказе.
+	RetryAfter time.Duration
+	// Reset — через сколько корзина наполнится целиком.
+	Reset time.Duration
+}
+
+// Store хранит корзины. Take забирает токен из корзины key и сообщает,
+// разрешён ли запрос.
+type Store interface {
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+}
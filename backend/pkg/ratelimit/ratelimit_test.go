@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 6 запросов в минуту — токен раз в 10 секунд, подряд не больше 3.
+var limit = Limit{Requests: 6, Per: time.Minute, Burst: 3}
+
+// testStore проверяет Store с управляемыми часами: advance двигает время.
+func testStore(t *testing.T, s Store, advance func(time.Duration)) {
+	ctx := context.Background()
+	take := func(key string) Result {
+		t.Helper()
+		res, err := s.Take(ctx, key, limit)
+		require.NoError(t, err)
+		return res
+	}
+
+	for i := 2; i >= 0; i-- {
+		res := take("a")
+		require.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, i, res.Remaining)
+	}
+	res := take("a")
+	require.False(t, res.Allowed)
+	assert.Equal(t, 10*time.Second, res.RetryAfter)
+	assert.Equal(t, 30*time.Second, res.Reset)
+
+	// у другого ключа своя корзина
+	assert.True(t, take("b").Allowed)
+
+	advance(5 * time.Second)
+	res = take("a")
+	require.False(t, res.Allowed)
+	assert.Equal(t, 5*time.Second, res.RetryAfter)
+
+	advance(5 * time.Second)
+	res = take("a")
+	require.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	// за простой корзина наполняется, но не больше ёмкости
+	advance(time.Hour)
+	res = take("a")
+	require.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Remaining)
+	assert.Equal(t, 10*time.Second, res.Reset)
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	testStore(t, s, func(d time.Duration) { now = now.Add(d) })
+
+	t.Run("full buckets are swept", func(t *testing.T) {
+		now = now.Add(2 * sweepEvery)
+		_, err := s.Take(context.Background(), "c", limit)
+		require.NoError(t, err)
+		assert.Len(t, s.buckets, 1)
+	})
+}
+
+func TestRedisStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	testStore(t, NewRedisStore(client, "rl:"), func(d time.Duration) {
+		now = now.Add(d)
+		mr.SetTime(now)
+	})
+	assert.True(t, mr.Exists("rl:a"))
+
+	t.Run("redis unavailable", func(t *testing.T) {
+		mr.Close()
+		_, err := NewRedisStore(client, "rl:").Take(context.Background(), "a", limit)
+		assert.Error(t, err)
+	})
+}
+
+func TestLimit(t *testing.T) {
+	assert.False(t, Limit{}.Enabled())
+	assert.False(t, Limit{Requests: 5}.Enabled())
+	assert.True(t, Limit{Requests: 5, Per: time.Minute}.Enabled())
+	assert.Equal(t, 5, Limit{Requests: 5, Per: time.Minute}.Capacity())
+}
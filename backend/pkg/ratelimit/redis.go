@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript списывает токен атомарно. Время берётся у Redis, а не у
+// экземпляров сервиса, чтобы расхождение их часов не меняло лимит.
+// Корзина — хеш {tokens, at (мс)}; ключ живёт, пока корзина не наполнится.
+//
+// ARGV[1] — интервал пополнения одного токена в мс, ARGV[2] — ёмкость.
+var takeScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'at')
+local tokens = tonumber(state[1])
+local at = tonumber(state[2])
+if tokens == nil or at == nil then
+	tokens = burst
+elseif now > at then
+	tokens = math.min(burst, tokens + (now - at) / interval)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) * interval) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore хранит корзины в Redis, общие для всех экземпляров сервиса.
+// Нужен Redis 5 и новее: скрипт пишет после TIME.
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore: prefix отделяет ключи лимитов от остальных данных в базе Redis.
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, l Limit) (Result, error) {
+	interval := float64(l.interval()) / float64(time.Millisecond)
+	raw, err := takeScript.Run(ctx, s.client, []string{s.prefix + key}, interval, l.Capacity()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: redis: %w", err)
+	}
+	if len(raw) != 2 {
+		return Result{}, fmt.Errorf("ratelimit: redis: unexpected reply %v", raw)
+	}
+	allowed, _ := raw[0].(int64)
+	str, _ := raw[1].(string)
+	tokens, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: redis: tokens %q: %w", str, err)
+	}
+	return l.result(tokens, allowed == 1), nil
+}
@@ -0,0 +1,62 @@
+package telemetry_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Neimess/zorkin-store-project/pkg/http_utils/middleware"
+	"github.com/Neimess/zorkin-store-project/pkg/telemetry"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *telemetry.Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestRequestMetricsUseRoutePattern(t *testing.T) {
+	m := telemetry.NewMetrics()
+	r := chi.NewRouter()
+	r.Use(middleware.Telemetry(m))
+	r.Get("/api/product/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, path := range []string{"/api/product/1", "/api/product/2", "/nope"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/api/product/{id}",status="200"} 2`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, out, `http_requests_in_flight 0`)
+}
+
+func TestQueryMetrics(t *testing.T) {
+	m := telemetry.NewMetrics()
+	m.ObserveQuery("product.Get", nil, time.Millisecond)
+	m.ObserveQuery("product.Get", errors.New("boom"), time.Millisecond)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `db_query_duration_seconds_count{operation="product.Get",status="ok"} 1`)
+	assert.Contains(t, out, `db_query_duration_seconds_count{operation="product.Get",status="error"} 1`)
+}
+
+func TestNilMetricsAreNoop(t *testing.T) {
+	var m *telemetry.Metrics
+	m.StartRequest()()
+	m.ObserveRequest(http.MethodGet, "/", http.StatusOK, time.Millisecond)
+	m.ObserveQuery("product.Get", nil, time.Millisecond)
+	assert.NoError(t, m.RegisterDB(nil, "test"))
+}
@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer — pgx.QueryTracer: на каждый запрос к БД открывает span и
+// пишет db_query_duration_seconds. Операция — метод репозитория, из которого
+// пришёл запрос ("product.UpdateWithAttrs"); она берётся из стека вызовов,
+// поэтому репозиториям не нужно ничего передавать.
+type QueryTracer struct {
+	metrics *Metrics
+	prefix  string
+}
+
+// NewQueryTracer: prefix — путь пакетов репозиториев, например
+// "github.com/.../internal/infrastructure/".
+func NewQueryTracer(m *Metrics, prefix string) *QueryTracer {
+	return &QueryTracer{metrics: m, prefix: prefix}
+}
+
+type queryKey struct{}
+
+type queryTrace struct {
+	operation string
+	start     time.Time
+	span      trace.Span
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	op := t.operation()
+	ctx, span := Tracer().Start(ctx, "db "+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(op), semconv.DBQueryText(data.SQL)),
+	)
+	return context.WithValue(ctx, queryKey{}, &queryTrace{operation: op, start: time.Now(), span: span})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	q, ok := ctx.Value(queryKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+	if data.Err != nil {
+		q.span.RecordError(data.Err)
+		q.span.SetStatus(codes.Error, data.Err.Error())
+	}
+	q.span.End()
+	t.metrics.ObserveQuery(q.operation, data.Err, time.Since(q.start))
+}
+
+// operation ищет ближайший к запросу кадр стека из пакетов репозиториев и
+// сокращает его до "пакет.Метод": получатель и замыкания (func1) отбрасываются.
+func (t *QueryTracer) operation() string {
+	var pcs [64]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, t.prefix); ok {
+			return shortName(name)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+func shortName(fn string) string {
+	parts := strings.Split(fn, ".")
+	for _, p := range parts[1:] {
+		if strings.HasPrefix(p, "(") || isClosure(p) {
+			continue
+		}
+		return parts[0] + "." + p
+	}
+	return parts[0]
+}
+
+// isClosure узнаёт имена замыканий: func1, func2…
+func isClosure(name string) bool {
+	n, ok := strings.CutPrefix(name, "func")
+	return ok && n != "" && strings.Trim(n, "0123456789") == ""
+}
@@ -0,0 +1,25 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortName(t *testing.T) {
+	tests := []struct {
+		fn   string
+		want string
+	}{
+		{fn: "category.(*PGCategoryRepository).Update", want: "category.Update"},
+		{fn: "product.(*PGProductRepository).UpdateWithAttrs.func1", want: "product.UpdateWithAttrs"},
+		{fn: "product.(*PGProductRepository).Delete.func1.1", want: "product.Delete"},
+		{fn: "preset.scanItems", want: "preset.scanItems"},
+		{fn: "lead.(*PGLeadRepository).functionalIndex", want: "lead.functionalIndex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fn, func(t *testing.T) {
+			assert.Equal(t, tt.want, shortName(tt.fn))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/Neimess/zorkin-store-project"
+
+type TracingOptions struct {
+	ServiceName    string
+	ServiceVersion string
+	// Endpoint — host:port OTLP/HTTP коллектора, например localhost:4318.
+	Endpoint string
+	Insecure bool
+	// SampleRatio — доля трассируемых корневых запросов, от 0 до 1.
+	SampleRatio float64
+}
+
+// InitTracing настраивает глобальный TracerProvider с экспортом по OTLP/HTTP
+// и W3C-пропагацию (traceparent, baggage). Возвращённая функция дописывает
+// накопленные span'ы и останавливает экспорт. Без вызова InitTracing
+// span'ы из Start ничего не стоят: глобальный провайдер — noop.
+func InitTracing(ctx context.Context, opts TracingOptions) (func(context.Context) error, error) {
+	clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("otlp exporter: %w", err)
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer — трассировщик приложения из глобального провайдера.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+// Start открывает дочерний span операции. Сервисы называют span своим op:
+// "service.product.Update".
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
@@ -40,6 +40,7 @@ func TestProductEndpoints_TableDriven(t *testing.T) {
 	headers := map[string]string{
 		"Authorization": "Bearer " + token,
 		"Content-Type":  "application/json",
+		"If-Match":      "*",
 	}
 
 	type testCase struct {